@@ -0,0 +1,236 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// extractChdirOptions removes all of the -chdir=... options appearing before
+// the subcommand from the given arguments, and returns their values in the
+// order given.
+func extractChdirOptions(args []string) ([]string, []string, error) {
+	const argName = "-chdir"
+	const argPrefix = argName + "="
+
+	var values []string
+	newArgs := make([]string, 0, len(args))
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			// Because the chdir option is a subcommand-agnostic one, we require
+			// it to appear before any subcommand argument, so if we find a
+			// non-option before we find -chdir then we are finished.
+			newArgs = append(newArgs, args[i:]...)
+			break
+		}
+		if arg == argName || arg == argPrefix {
+			return nil, args, fmt.Errorf("must include an equals sign followed by a directory path, like -chdir=example")
+		}
+		if strings.HasPrefix(arg, argPrefix) {
+			values = append(values, arg[len(argPrefix):])
+			continue
+		}
+		newArgs = append(newArgs, arg)
+	}
+	if len(values) == 0 {
+		return nil, args, nil
+	}
+	return values, newArgs, nil
+}
+
+// extractChdirSummaryOption removes a -chdir-summary=... option appearing
+// before the subcommand from the given arguments, and returns its value.
+func extractChdirSummaryOption(args []string) (string, []string) {
+	const argPrefix = "-chdir-summary="
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			// As with -chdir, the option must appear before any subcommand.
+			break
+		}
+		if strings.HasPrefix(arg, argPrefix) {
+			newArgs := make([]string, 0, len(args)-1)
+			newArgs = append(newArgs, args[:i]...)
+			newArgs = append(newArgs, args[i+1:]...)
+			return arg[len(argPrefix):], newArgs
+		}
+	}
+	return "", args
+}
+
+// expandChdirPatterns expands any glob patterns in the given -chdir values
+// into the directories they match, and reports whether OpenTofu should run
+// once per directory rather than just switching to a single directory.
+//
+// Each directory appears only once in the result, in the order of the first
+// pattern matching it.
+func expandChdirPatterns(patterns []string) ([]string, bool, error) {
+	multi := len(patterns) > 1
+	seen := make(map[string]bool)
+	var ret []string
+	for _, pattern := range patterns {
+		if !hasGlobMeta(pattern) {
+			if !seen[pattern] {
+				seen[pattern] = true
+				ret = append(ret, pattern)
+			}
+			continue
+		}
+
+		multi = true
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		found := false
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			found = true
+			if !seen[match] {
+				seen[match] = true
+				ret = append(ret, match)
+			}
+		}
+		if !found {
+			return nil, false, fmt.Errorf("the pattern %q does not match any directories", pattern)
+		}
+	}
+	return ret, multi, nil
+}
+
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// chdirResult is the outcome of running OpenTofu in one of several working
+// directories, as recorded in the -chdir-summary file.
+type chdirResult struct {
+	Dir      string `json:"dir"`
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+}
+
+type chdirSummary struct {
+	ExitCode int           `json:"exit_code"`
+	Results  []chdirResult `json:"results"`
+}
+
+// chdirRunFunc runs OpenTofu with the given arguments in the given working
+// directory and returns its exit code.
+type chdirRunFunc func(dir string, args []string) (int, error)
+
+// runInDirs runs OpenTofu with the given arguments once in each of the given
+// directories, one after another, and returns the aggregated exit code.
+//
+// The child processes receive interrupts from the terminal directly, so
+// shutdownCh only stops runInDirs from starting the remaining directories,
+// which are then recorded as failed.
+//
+// If summaryPath is set, a JSON summary of the results is written there.
+func runInDirs(dirs []string, args []string, summaryPath string, run chdirRunFunc, shutdownCh <-chan struct{}) int {
+	results := make([]chdirResult, 0, len(dirs))
+	interrupted := false
+	for _, dir := range dirs {
+		if !interrupted {
+			select {
+			case <-shutdownCh:
+				interrupted = true
+			default:
+			}
+		}
+		if interrupted {
+			results = append(results, chdirResult{Dir: dir, ExitCode: 1, Error: "skipped after interrupt"})
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "\n=== %s: tofu %s\n", dir, strings.Join(args, " "))
+		code, err := run(dir, args)
+		result := chdirResult{Dir: dir, ExitCode: code}
+		if err != nil {
+			result.ExitCode = 1
+			result.Error = err.Error()
+			fmt.Fprintf(os.Stderr, "Failed to run OpenTofu in %s: %s\n", dir, err)
+		}
+		results = append(results, result)
+	}
+
+	summary := chdirSummary{
+		ExitCode: chdirExitCode(results),
+		Results:  results,
+	}
+
+	fmt.Fprintf(os.Stderr, "\n=== Summary\n")
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", result.Dir, result.Error)
+		case result.ExitCode == 0:
+			fmt.Fprintf(os.Stderr, "  %s: ok\n", result.Dir)
+		default:
+			fmt.Fprintf(os.Stderr, "  %s: exit code %d\n", result.Dir, result.ExitCode)
+		}
+	}
+
+	if summaryPath != "" {
+		src, err := json.MarshalIndent(summary, "", "  ")
+		if err == nil {
+			err = os.WriteFile(summaryPath, append(src, '\n'), 0644)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write -chdir-summary file: %s\n", err)
+			return 1
+		}
+	}
+
+	return summary.ExitCode
+}
+
+// chdirExitCode aggregates the exit codes of several runs: it is 0 if all of
+// them succeeded, 1 if any of them failed, and otherwise 2, so that the
+// -detailed-exitcode option of "tofu plan" still reports whether any of the
+// directories have changes.
+func chdirExitCode(results []chdirResult) int {
+	ret := 0
+	for _, result := range results {
+		switch result.ExitCode {
+		case 0:
+		case 2:
+			if ret == 0 {
+				ret = 2
+			}
+		default:
+			return 1
+		}
+	}
+	return ret
+}
+
+// runChdirChild runs the current executable as a child process with the
+// given working directory, sharing the standard streams of this process.
+func runChdirChild(dir string, args []string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 1, err
+	}
+	cmd := exec.Command(exe, append([]string{"-chdir=" + dir}, args...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
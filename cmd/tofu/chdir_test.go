@@ -0,0 +1,212 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtractChdirOptions(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		wantDirs []string
+		wantArgs []string
+		wantErr  bool
+	}{
+		"none": {
+			[]string{"plan", "-chdir=foo"},
+			nil,
+			[]string{"plan", "-chdir=foo"},
+			false,
+		},
+		"single": {
+			[]string{"-chdir=foo", "plan"},
+			[]string{"foo"},
+			[]string{"plan"},
+			false,
+		},
+		"repeated": {
+			[]string{"-chdir=foo", "-read-only", "-chdir=bar", "plan", "-chdir=baz"},
+			[]string{"foo", "bar"},
+			[]string{"-read-only", "plan", "-chdir=baz"},
+			false,
+		},
+		"missing value": {
+			[]string{"-chdir", "foo", "plan"},
+			nil,
+			[]string{"-chdir", "foo", "plan"},
+			true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotDirs, gotArgs, err := extractChdirOptions(test.args)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(gotDirs, test.wantDirs) {
+				t.Errorf("wrong dirs\ngot:  %#v\nwant: %#v", gotDirs, test.wantDirs)
+			}
+			if !reflect.DeepEqual(gotArgs, test.wantArgs) {
+				t.Errorf("wrong args\ngot:  %#v\nwant: %#v", gotArgs, test.wantArgs)
+			}
+		})
+	}
+}
+
+func TestExpandChdirPatterns(t *testing.T) {
+	td := t.TempDir()
+	for _, dir := range []string{"stacks/network", "stacks/app", "modules/vpc"} {
+		if err := os.MkdirAll(filepath.Join(td, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(td, "stacks", "README.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(td); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tests := map[string]struct {
+		patterns  []string
+		wantDirs  []string
+		wantMulti bool
+		wantErr   bool
+	}{
+		"single dir": {
+			[]string{"stacks/app"},
+			[]string{"stacks/app"},
+			false,
+			false,
+		},
+		"repeated": {
+			[]string{"stacks/app", "modules/vpc", "stacks/app"},
+			[]string{"stacks/app", "modules/vpc"},
+			true,
+			false,
+		},
+		"glob": {
+			[]string{"stacks/*"},
+			[]string{"stacks/app", "stacks/network"},
+			true,
+			false,
+		},
+		"glob and dir": {
+			[]string{"stacks/network", "stacks/*"},
+			[]string{"stacks/network", "stacks/app"},
+			true,
+			false,
+		},
+		"no matches": {
+			[]string{"environments/*"},
+			nil,
+			false,
+			true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotDirs, gotMulti, err := expandChdirPatterns(test.patterns)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(gotDirs, test.wantDirs) {
+				t.Errorf("wrong dirs\ngot:  %#v\nwant: %#v", gotDirs, test.wantDirs)
+			}
+			if gotMulti != test.wantMulti {
+				t.Errorf("wrong multi %t; want %t", gotMulti, test.wantMulti)
+			}
+		})
+	}
+}
+
+func TestRunInDirs(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.json")
+	codes := map[string]int{"a": 0, "b": 2, "c": 0}
+
+	var gotDirs []string
+	run := func(dir string, args []string) (int, error) {
+		gotDirs = append(gotDirs, dir)
+		if !reflect.DeepEqual(args, []string{"plan", "-detailed-exitcode"}) {
+			t.Errorf("wrong args %#v", args)
+		}
+		return codes[dir], nil
+	}
+
+	code := runInDirs([]string{"a", "b", "c"}, []string{"plan", "-detailed-exitcode"}, summaryPath, run, nil)
+	if code != 2 {
+		t.Errorf("wrong exit code %d; want 2", code)
+	}
+	if !reflect.DeepEqual(gotDirs, []string{"a", "b", "c"}) {
+		t.Errorf("wrong dirs %#v", gotDirs)
+	}
+
+	src, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got chdirSummary
+	if err := json.Unmarshal(src, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := chdirSummary{
+		ExitCode: 2,
+		Results: []chdirResult{
+			{Dir: "a", ExitCode: 0},
+			{Dir: "b", ExitCode: 2},
+			{Dir: "c", ExitCode: 0},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong summary\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestRunInDirs_interrupted(t *testing.T) {
+	shutdownCh := make(chan struct{})
+	var gotDirs []string
+	run := func(dir string, args []string) (int, error) {
+		gotDirs = append(gotDirs, dir)
+		close(shutdownCh)
+		return 0, nil
+	}
+
+	if code := runInDirs([]string{"a", "b"}, []string{"apply"}, "", run, shutdownCh); code != 1 {
+		t.Errorf("wrong exit code %d; want 1", code)
+	}
+	if !reflect.DeepEqual(gotDirs, []string{"a"}) {
+		t.Errorf("wrong dirs %#v", gotDirs)
+	}
+}
+
+func TestChdirExitCode(t *testing.T) {
+	tests := []struct {
+		codes []int
+		want  int
+	}{
+		{[]int{0, 0}, 0},
+		{[]int{0, 2}, 2},
+		{[]int{2, 1, 0}, 1},
+		{[]int{-1}, 1},
+	}
+	for _, test := range tests {
+		var results []chdirResult
+		for _, code := range test.codes {
+			results = append(results, chdirResult{ExitCode: code})
+		}
+		if got := chdirExitCode(results); got != test.want {
+			t.Errorf("exit codes %v: got %d, want %d", test.codes, got, test.want)
+		}
+	}
+}
@@ -17,8 +17,11 @@ import (
 	"github.com/mitchellh/cli"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/command"
+	"github.com/opentofu/opentofu/internal/command/applywebhook"
 	"github.com/opentofu/opentofu/internal/command/cliconfig"
+	"github.com/opentofu/opentofu/internal/command/costestimate"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/command/webbrowser"
 	"github.com/opentofu/opentofu/internal/getproviders"
@@ -31,6 +34,17 @@ import (
 // that assume that OpenTofu is being run from a command prompt.
 const runningInAutomationEnvName = "TF_IN_AUTOMATION"
 
+// readOnlyEnvName gives the name of an environment variable that can be set
+// to any non-empty value to run OpenTofu in read-only mode, with the same
+// effect as the global -read-only option.
+const readOnlyEnvName = "TF_READ_ONLY"
+
+// ignoreVersionConstraintEnvName gives the name of an environment variable
+// that can be set to any non-empty value to ignore unmet OpenTofu version
+// constraints, with the same effect as the global -ignore-version-constraint
+// option.
+const ignoreVersionConstraintEnvName = "TF_IGNORE_VERSION_CONSTRAINT"
+
 // commands is the mapping of all the available OpenTofu commands.
 var commands map[string]cli.CommandFactory
 
@@ -63,6 +77,10 @@ func initCommands(
 	providerSrc getproviders.Source,
 	providerDevOverrides map[addrs.Provider]getproviders.PackageLocalDir,
 	unmanagedProviders map[addrs.Provider]*plugin.ReattachConfig,
+	readOnly bool,
+	ignoreVersionConstraint bool,
+	auditLog *auditlog.Log,
+	project *command.Project,
 ) {
 	var inAutomation bool
 	if v := os.Getenv(runningInAutomationEnvName); v != "" {
@@ -86,10 +104,24 @@ func initCommands(
 
 	wd := workingDir(originalWorkingDir, os.Getenv("TF_DATA_DIR"))
 
+	// The CLI configuration's plugin cache directory, which may come from
+	// the environment, takes precedence over the project file's.
+	pluginCacheDir := config.PluginCacheDir
+	if pluginCacheDir == "" && project != nil {
+		pluginCacheDir = project.PluginCacheDir
+	}
+
+	view := views.NewView(streams).
+		SetRunningInAutomation(inAutomation).
+		SetCostEstimator(costEstimator(config)).
+		SetCollapsedAttributes(config.CollapsedAttributes()).
+		SetColorTheme(config.ColorThemeColors()).
+		SetNoUnicode(config.NoUnicode)
+
 	meta := command.Meta{
 		WorkingDir: wd,
 		Streams:    streams,
-		View:       views.NewView(streams).SetRunningInAutomation(inAutomation),
+		View:       view,
 
 		Color:            true,
 		GlobalPluginDirs: globalPluginDirs(),
@@ -98,11 +130,21 @@ func initCommands(
 		Services:        services,
 		BrowserLauncher: webbrowser.NewNativeLauncher(),
 
-		RunningInAutomation: inAutomation,
-		CLIConfigDir:        configDir,
-		PluginCacheDir:      config.PluginCacheDir,
+		RunningInAutomation:     inAutomation,
+		ReadOnly:                readOnly,
+		IgnoreVersionConstraint: ignoreVersionConstraint,
+		AuditLog:                auditLog,
+		ApplyWebhook:            applyWebhook(config),
+		CLIConfigDir:            configDir,
+		PluginCacheDir:          pluginCacheDir,
+		Project:                 project,
 
 		PluginCacheMayBreakDependencyLockFile: config.PluginCacheMayBreakDependencyLockFile,
+		ProviderChecksumPolicy:                config.ProviderChecksumPolicy(),
+		UpgradePolicy:                         config.UpgradePolicy(),
+		EnforceEncryption:                     config.EnforceEncryption,
+		ColorTheme:                            config.ColorTheme,
+		NoUnicode:                             config.NoUnicode,
 
 		ShutdownCh:    makeShutdownCh(),
 		CallerContext: ctx,
@@ -127,6 +169,46 @@ func initCommands(
 			}, nil
 		},
 
+		"auth": func() (cli.Command, error) {
+			return &command.AuthCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"auth status": func() (cli.Command, error) {
+			return &command.AuthStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"bundle": func() (cli.Command, error) {
+			return &command.BundleCommand{}, nil
+		},
+
+		"bundle create": func() (cli.Command, error) {
+			return &command.BundleCreateCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"bundle install": func() (cli.Command, error) {
+			return &command.BundleInstallCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"check-status": func() (cli.Command, error) {
+			return &command.CheckStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"completion": func() (cli.Command, error) {
+			return &command.CompletionCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"console": func() (cli.Command, error) {
 			return &command.ConsoleCommand{
 				Meta: meta,
@@ -175,6 +257,12 @@ func initCommands(
 			}, nil
 		},
 
+		"explain": func() (cli.Command, error) {
+			return &command.ExplainCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"fmt": func() (cli.Command, error) {
 			return &command.FmtCommand{
 				Meta: meta,
@@ -229,6 +317,24 @@ func initCommands(
 			}, nil
 		},
 
+		"modules": func() (cli.Command, error) {
+			return &command.ModulesCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"modules schema": func() (cli.Command, error) {
+			return &command.ModulesSchemaCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"modules vendor": func() (cli.Command, error) {
+			return &command.ModulesVendorCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"output": func() (cli.Command, error) {
 			return &command.OutputCommand{
 				Meta: meta,
@@ -247,6 +353,24 @@ func initCommands(
 			}, nil
 		},
 
+		"providers cache": func() (cli.Command, error) {
+			return &command.ProvidersCacheCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"providers cache gc": func() (cli.Command, error) {
+			return &command.ProvidersCacheGCCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"providers cache verify": func() (cli.Command, error) {
+			return &command.ProvidersCacheVerifyCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"providers lock": func() (cli.Command, error) {
 			return &command.ProvidersLockCommand{
 				Meta: meta,
@@ -265,6 +389,12 @@ func initCommands(
 			}, nil
 		},
 
+		"providers verify": func() (cli.Command, error) {
+			return &command.ProvidersVerifyCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"push": func() (cli.Command, error) {
 			return &command.PushCommand{
 				Meta: meta,
@@ -277,6 +407,24 @@ func initCommands(
 			}, nil
 		},
 
+		"registry": func() (cli.Command, error) {
+			return &command.RegistryCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"registry publish-module": func() (cli.Command, error) {
+			return &command.RegistryPublishModuleCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"registry publish-provider": func() (cli.Command, error) {
+			return &command.RegistryPublishProviderCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"show": func() (cli.Command, error) {
 			return &command.ShowCommand{
 				Meta: meta,
@@ -295,6 +443,15 @@ func initCommands(
 			}, nil
 		},
 
+		"upgrade": func() (cli.Command, error) {
+			return &command.UpgradeCommand{
+				Meta:              meta,
+				Version:           Version,
+				VersionPrerelease: VersionPrerelease,
+				Platform:          getproviders.CurrentPlatform,
+			}, nil
+		},
+
 		"validate": func() (cli.Command, error) {
 			return &command.ValidateCommand{
 				Meta: meta,
@@ -380,6 +537,12 @@ func initCommands(
 			}, nil
 		},
 
+		"state orphans": func() (cli.Command, error) {
+			return &command.StateOrphansCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"state rm": func() (cli.Command, error) {
 			return &command.StateRmCommand{
 				StateMeta: command.StateMeta{
@@ -388,6 +551,14 @@ func initCommands(
 			}, nil
 		},
 
+		"state rm-deposed": func() (cli.Command, error) {
+			return &command.StateRmDeposedCommand{
+				StateMeta: command.StateMeta{
+					Meta: meta,
+				},
+			}, nil
+		},
+
 		"state remove": func() (cli.Command, error) {
 			return &command.AliasCommand{
 				Command: &command.StateRmCommand{
@@ -398,6 +569,14 @@ func initCommands(
 			}, nil
 		},
 
+		"state migrate-keys": func() (cli.Command, error) {
+			return &command.StateMigrateKeysCommand{
+				StateMeta: command.StateMeta{
+					Meta: meta,
+				},
+			}, nil
+		},
+
 		"state mv": func() (cli.Command, error) {
 			return &command.StateMvCommand{
 				StateMeta: command.StateMeta{
@@ -428,12 +607,38 @@ func initCommands(
 			}, nil
 		},
 
+		"state split": func() (cli.Command, error) {
+			return &command.StateSplitCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"state stats": func() (cli.Command, error) {
+			return &command.StateStatsCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"state serve": func() (cli.Command, error) {
+			return &command.StateServeCommand{
+				StateMeta: command.StateMeta{
+					Meta: meta,
+				},
+			}, nil
+		},
+
 		"state show": func() (cli.Command, error) {
 			return &command.StateShowCommand{
 				Meta: meta,
 			}, nil
 		},
 
+		"state upgrade-check": func() (cli.Command, error) {
+			return &command.StateUpgradeCheckCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"state replace-provider": func() (cli.Command, error) {
 			return &command.StateReplaceProviderCommand{
 				StateMeta: command.StateMeta{
@@ -489,6 +694,31 @@ func credentialsSource(config *cliconfig.Config) (auth.CredentialsSource, error)
 	return config.CredentialsSource(helperPlugins)
 }
 
+// costEstimator returns the external cost estimator selected in the CLI
+// configuration, or nil if there isn't one.
+func costEstimator(config *cliconfig.Config) *costestimate.Estimator {
+	if len(config.CostEstimators) == 0 {
+		return nil
+	}
+	// Config.Validate rejects more than one cost_estimator block.
+	estimator := config.CostEstimators[0]
+	return &costestimate.Estimator{
+		Command: estimator.Command,
+		Args:    estimator.Args,
+	}
+}
+
+// applyWebhook returns the apply webhook selected in the CLI configuration,
+// or nil if there isn't one.
+func applyWebhook(config *cliconfig.Config) *applywebhook.Notifier {
+	if len(config.ApplyWebhooks) == 0 {
+		return nil
+	}
+	// Config.Validate rejects more than one apply_webhook block.
+	webhook := config.ApplyWebhooks[0]
+	return applywebhook.New(webhook.URL, webhook.Headers)
+}
+
 func getAliasCommandKeys() []string {
 	keys := []string{}
 	for key, cmdFact := range commands {
@@ -61,8 +61,14 @@ All other commands:
 %s
 Global options (use these before the subcommand, if any):
   -chdir=DIR    Switch to a different working directory before executing the
-                given subcommand.
+                given subcommand. With a glob pattern or when repeated, run
+                the subcommand once in each directory.
   -help         Show this help output, or the help for a specified subcommand.
+  -ignore-version-constraint
+                Report unmet OpenTofu version constraints as warnings rather
+                than errors, for emergency patching.
+  -read-only    Refuse to write state, acquire state locks, or run operations
+                that could change remote objects.
   -version      An alias for the "version" subcommand.
 `, listCommands(commands, primaryCommands, maxKeyLen), listCommands(commands, otherCommands, maxKeyLen))
 
@@ -23,9 +23,12 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/auditlog"
+	"github.com/opentofu/opentofu/internal/command"
 	"github.com/opentofu/opentofu/internal/command/cliconfig"
 	"github.com/opentofu/opentofu/internal/command/format"
 	"github.com/opentofu/opentofu/internal/didyoumean"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/terminal"
@@ -164,6 +167,17 @@ func realMain() int {
 		}
 	}
 
+	// The proxy and client certificate configuration must be in place before
+	// we make any outbound HTTP requests, including for service discovery.
+	if proxyConfig := config.ProxyConfig(); proxyConfig != nil {
+		httpclient.SetProxyConfig(proxyConfig)
+	}
+	if certs, err := config.ClientCertificates(); err != nil {
+		Ui.Error(fmt.Sprintf("There is a problem with the client_certificate configuration: %s\n\nOpenTofu will not present client certificates to any host.", err))
+	} else if len(certs) != 0 {
+		httpclient.SetClientCertificates(certs)
+	}
+
 	// Get any configured credentials from the config and initialize
 	// a service discovery object. The slightly awkward predeclaration of
 	// disco is required to allow us to pass untyped nil as the creds source
@@ -183,6 +197,7 @@ func realMain() int {
 		// object checks that and just acts as though no credentials are present.
 		services = disco.NewWithCredentialsSource(nil)
 	}
+	services.Transport = httpclient.WithClientCertificates(httpclient.NewTransport())
 	services.SetUserAgent(httpclient.OpenTofuUserAgent(version.String()))
 
 	providerSrc, diags := providerSource(config.ProviderInstallation, services)
@@ -228,13 +243,30 @@ func realMain() int {
 
 	// The arguments can begin with a -chdir option to ask OpenTofu to switch
 	// to a different working directory for the rest of its work. If that
-	// option is present then extractChdirOption returns a trimmed args with that option removed.
-	overrideWd, args, err := extractChdirOption(args)
+	// option is present then extractChdirOptions returns a trimmed args with that option removed.
+	chdirs, args, err := extractChdirOptions(args)
+	if err != nil {
+		Ui.Error(fmt.Sprintf("Invalid -chdir option: %s", err))
+		return 1
+	}
+	chdirSummaryPath, args := extractChdirSummaryOption(args)
+	overrideWds, multiWd, err := expandChdirPatterns(chdirs)
 	if err != nil {
 		Ui.Error(fmt.Sprintf("Invalid -chdir option: %s", err))
 		return 1
 	}
-	if overrideWd != "" {
+	if multiWd && os.Getenv("COMP_LINE") == "" {
+		// With a glob pattern or more than one -chdir option, we run the
+		// command once for each directory in a separate child process,
+		// since much of OpenTofu assumes a single working directory.
+		return runInDirs(overrideWds, args, chdirSummaryPath, runChdirChild, makeShutdownCh())
+	}
+	if chdirSummaryPath != "" {
+		Ui.Error("The -chdir-summary option requires a -chdir option with a glob pattern, or more than one -chdir option.")
+		return 1
+	}
+	if len(overrideWds) != 0 {
+		overrideWd := overrideWds[0]
 		err := os.Chdir(overrideWd)
 		if err != nil {
 			Ui.Error(fmt.Sprintf("Error handling -chdir option: %s", err))
@@ -242,13 +274,47 @@ func realMain() int {
 		}
 	}
 
+	// The arguments can also include a -read-only option, which is likewise
+	// subcommand-agnostic and so is removed before the subcommand sees them.
+	readOnly, args := extractGlobalBoolOption(args, "-read-only")
+	if v := os.Getenv(readOnlyEnvName); v != "" {
+		readOnly = true
+	}
+	ignoreVersionConstraint, args := extractGlobalBoolOption(args, "-ignore-version-constraint")
+	if v := os.Getenv(ignoreVersionConstraintEnvName); v != "" {
+		ignoreVersionConstraint = true
+	}
+
+	// The working directory can have a project file setting defaults for
+	// the commands run there. Its version constraint is checked below, once
+	// we know which command is running.
+	project, projectDiags := command.LoadProject(".")
+	if len(projectDiags) > 0 {
+		Ui.Error(fmt.Sprintf("There are some problems with the %s project file:", command.ProjectFilename))
+		for _, diag := range projectDiags {
+			earlyColor := &colorstring.Colorize{
+				Colors:  colorstring.DefaultColors,
+				Disable: true, // Disable color to be conservative until we know better
+				Reset:   true,
+			}
+			Ui.Error(format.Diagnostic(diag, nil, earlyColor, 78))
+		}
+		if projectDiags.HasErrors() {
+			return 1
+		}
+	}
+
+	// The audit log is told which command it's recording once the final
+	// arguments are known, below.
+	auditLog := auditlog.New(config.AuditLog)
+
 	// In tests, Commands may already be set to provide mock commands
 	if commands == nil {
 		// Commands get to hold on to the original working directory here,
 		// in case they need to refer back to it for any special reason, though
 		// they should primarily be working with the override working directory
 		// that we've now switched to above.
-		initCommands(ctx, originalWd, streams, config, services, providerSrc, providerDevOverrides, unmanagedProviders)
+		initCommands(ctx, originalWd, streams, config, services, providerSrc, providerDevOverrides, unmanagedProviders, readOnly, ignoreVersionConstraint, auditLog, project)
 	}
 
 	// Attempt to ensure the config directory exists.
@@ -262,6 +328,9 @@ func realMain() int {
 	// Make sure we clean up any managed plugins at the end of this
 	defer plugin.CleanupClients()
 
+	// Overwrite any encryption keys still cached for decryption before exiting.
+	defer encryption.ZeroizeCachedKeys()
+
 	// Build the CLI so far, we do this so we can query the subcommand.
 	cliRunner := &cli.CLI{
 		Args:       args,
@@ -312,6 +381,7 @@ func realMain() int {
 		AutocompleteInstall:   "install-autocomplete",
 		AutocompleteUninstall: "uninstall-autocomplete",
 	}
+	auditLog.SetInvocation(cliRunner.Subcommand(), cliRunner.SubcommandArgs())
 
 	// Before we continue we'll check whether the requested command is
 	// actually known. If not, we might be able to suggest an alternative
@@ -344,6 +414,35 @@ func realMain() int {
 		}
 	}
 
+	// The project file's version constraint doesn't prevent checking the
+	// version or upgrading to a version that meets it.
+	switch cmd := cliRunner.Subcommand(); {
+	case autoComplete, cmd == "", cmd == "version", cmd == "upgrade", cliRunner.IsHelp(), cliRunner.IsVersion():
+	default:
+		if versionDiags := project.CheckVersion(); versionDiags.HasErrors() {
+			earlyColor := &colorstring.Colorize{
+				Colors:  colorstring.DefaultColors,
+				Disable: true,
+				Reset:   true,
+			}
+			if ignoreVersionConstraint {
+				Ui.Warn("Ignoring the project file's version constraint, as requested by the -ignore-version-constraint option:")
+				for _, diag := range versionDiags {
+					Ui.Warn(format.Diagnostic(diag, nil, earlyColor, 78))
+				}
+			} else {
+				for _, diag := range versionDiags {
+					Ui.Error(format.Diagnostic(diag, nil, earlyColor, 78))
+				}
+				return 1
+			}
+		}
+	}
+
+	// If a plugin crashes, we save a crash bundle describing it in the
+	// working directory's data directory.
+	logging.SetCrashBundleDir(filepath.Join(workingDir(originalWd, os.Getenv("TF_DATA_DIR")).DataDir(), "crashes"))
+
 	exitCode, err := cliRunner.Run()
 	if err != nil {
 		Ui.Error(fmt.Sprintf("Error executing CLI: %s", err.Error()))
@@ -356,6 +455,11 @@ func realMain() int {
 		for _, panicLog := range logging.PluginPanics() {
 			Ui.Error(panicLog)
 		}
+		if bundlePath, err := logging.WritePluginCrashBundle(); err != nil {
+			log.Printf("[ERROR] Failed to write the plugin crash bundle: %s", err)
+		} else if bundlePath != "" {
+			Ui.Error(fmt.Sprintf("A crash bundle describing the plugin failure was saved to %s. Please attach it when reporting the problem, after checking that the plugin output it contains doesn't include anything sensitive.", bundlePath))
+		}
 	}
 
 	return exitCode
@@ -457,50 +561,23 @@ func parseReattachProviders(in string) (map[addrs.Provider]*plugin.ReattachConfi
 	return unmanagedProviders, nil
 }
 
-func extractChdirOption(args []string) (string, []string, error) {
-	if len(args) == 0 {
-		return "", args, nil
-	}
-
-	const argName = "-chdir"
-	const argPrefix = argName + "="
-	var argValue string
-	var argPos int
-
+// extractGlobalBoolOption removes the given boolean option, such as
+// -read-only, appearing before the subcommand from the given arguments, and
+// reports whether it was present.
+func extractGlobalBoolOption(args []string, option string) (bool, []string) {
 	for i, arg := range args {
 		if !strings.HasPrefix(arg, "-") {
-			// Because the chdir option is a subcommand-agnostic one, we require
-			// it to appear before any subcommand argument, so if we find a
-			// non-option before we find -chdir then we are finished.
+			// As with -chdir, the option must appear before any subcommand.
 			break
 		}
-		if arg == argName || arg == argPrefix {
-			return "", args, fmt.Errorf("must include an equals sign followed by a directory path, like -chdir=example")
+		if arg == option {
+			newArgs := make([]string, 0, len(args)-1)
+			newArgs = append(newArgs, args[:i]...)
+			newArgs = append(newArgs, args[i+1:]...)
+			return true, newArgs
 		}
-		if strings.HasPrefix(arg, argPrefix) {
-			argPos = i
-			argValue = arg[len(argPrefix):]
-		}
-	}
-
-	// When we fall out here, we'll have populated argValue with a non-empty
-	// string if the -chdir=... option was present and valid, or left it
-	// empty if it wasn't present.
-	if argValue == "" {
-		return "", args, nil
-	}
-
-	// If we did find the option then we'll need to produce a new args that
-	// doesn't include it anymore.
-	if argPos == 0 {
-		// Easy case: we can just slice off the front
-		return argValue, args[1:], nil
 	}
-	// Otherwise we need to construct a new array and copy to it.
-	newArgs := make([]string, len(args)-1)
-	copy(newArgs, args[:argPos])
-	copy(newArgs[argPos:], args[argPos+1:])
-	return argValue, newArgs, nil
+	return false, args
 }
 
 // Creates the configuration directory.
@@ -0,0 +1,177 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package auditlog implements the optional local audit log, which records a
+// JSON entry for every state snapshot written by OpenTofu.
+//
+// This is a separate package so that backends can record entries without
+// creating a circular reference to the command package.
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log appends entries to an audit log file. A nil *Log is valid and discards
+// all entries, so callers don't need to check whether auditing is enabled.
+type Log struct {
+	path string
+
+	mu      sync.Mutex
+	command string
+	args    []string
+	user    string
+	now     func() time.Time
+}
+
+// Entry is a single audit log record, describing one state snapshot that
+// was written.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Command   string    `json:"command"`
+	Args      []string  `json:"args"`
+	Workspace string    `json:"workspace"`
+	Lineage   string    `json:"lineage,omitempty"`
+
+	// SerialBefore and SerialAfter are the serials of the state snapshot
+	// before and after the write, if known.
+	SerialBefore *uint64 `json:"serial_before"`
+	SerialAfter  *uint64 `json:"serial_after"`
+}
+
+// New returns a Log that appends to the file at the given path, creating it
+// if necessary. If path is empty then New returns nil, which disables
+// auditing.
+func New(path string) *Log {
+	if path == "" {
+		return nil
+	}
+	return &Log{
+		path: path,
+		user: currentUser(),
+		now:  time.Now,
+	}
+}
+
+// SetInvocation records the subcommand and arguments OpenTofu was run with,
+// to be included in all subsequent entries. Values that might be sensitive,
+// such as those of -var options, are redacted.
+func (l *Log) SetInvocation(command string, args []string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.command = command
+	l.args = redactArgs(args)
+}
+
+// Record appends an entry for a state snapshot written to the given
+// workspace. The timestamp, user and invocation are filled in automatically.
+func (l *Log) Record(workspace, lineage string, serialBefore, serialAfter *uint64) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := Entry{
+		Timestamp:    l.now().UTC(),
+		User:         l.user,
+		Command:      l.command,
+		Args:         l.args,
+		Workspace:    workspace,
+		Lineage:      lineage,
+		SerialBefore: serialBefore,
+		SerialAfter:  serialAfter,
+	}
+	if entry.Args == nil {
+		entry.Args = []string{}
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// redactedOptions are the options whose values are replaced in the recorded
+// arguments, because they can contain secrets.
+var redactedOptions = []string{"-var", "-backend-config"}
+
+func redactArgs(args []string) []string {
+	ret := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		ret[i] = arg
+		if redactNext {
+			ret[i] = redactValue(arg)
+			redactNext = false
+			continue
+		}
+
+		option, value, hasValue := strings.Cut(arg, "=")
+		if !isRedactedOption(option) {
+			continue
+		}
+		if hasValue {
+			ret[i] = option + "=" + redactValue(value)
+		} else {
+			// The option and its value are given as separate arguments,
+			// as in -var 'name=value'.
+			redactNext = true
+		}
+	}
+	return ret
+}
+
+// isRedactedOption returns true if the given option is one of
+// redactedOptions. The flag parser accepts options with either one or two
+// leading dashes, so both forms are recognized.
+func isRedactedOption(option string) bool {
+	if strings.HasPrefix(option, "--") {
+		option = option[1:]
+	}
+	for _, redacted := range redactedOptions {
+		if option == redacted {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue hides the value of a "name=value" pair. Values without an
+// equals sign, such as the path of a backend configuration file, are kept.
+func redactValue(v string) string {
+	name, _, found := strings.Cut(v, "=")
+	if !found {
+		return v
+	}
+	return name + "=(redacted)"
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
@@ -0,0 +1,114 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := New(path)
+	l.user = "tester"
+	l.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	l.SetInvocation("apply", []string{"-auto-approve", "-var=password=hunter2", "-var", "user=admin", "-backend-config=backend.hcl"})
+
+	before, after := uint64(4), uint64(5)
+	if err := l.Record("default", "lineage", &before, &after); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Record("prod", "lineage", nil, &after); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var got []Entry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(sc.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit log line %q: %s", sc.Text(), err)
+		}
+		got = append(got, entry)
+	}
+
+	args := []string{"-auto-approve", "-var=password=(redacted)", "-var", "user=(redacted)", "-backend-config=backend.hcl"}
+	want := []Entry{
+		{
+			Timestamp:    l.now(),
+			User:         "tester",
+			Command:      "apply",
+			Args:         args,
+			Workspace:    "default",
+			Lineage:      "lineage",
+			SerialBefore: &before,
+			SerialAfter:  &after,
+		},
+		{
+			Timestamp:   l.now(),
+			User:        "tester",
+			Command:     "apply",
+			Args:        args,
+			Workspace:   "prod",
+			Lineage:     "lineage",
+			SerialAfter: &after,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("wrong entries\n%s", diff)
+	}
+}
+
+func TestLog_nil(t *testing.T) {
+	l := New("")
+	if l != nil {
+		t.Fatalf("expected nil log for empty path")
+	}
+	l.SetInvocation("apply", nil)
+	if err := l.Record("default", "", nil, nil); err != nil {
+		t.Fatalf("unexpected error from nil log: %s", err)
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	got := redactArgs([]string{
+		"-var=a=secret",
+		"--var=b=secret",
+		"-var", "c=secret",
+		"--var", "d=secret",
+		"-backend-config=token=secret",
+		"--backend-config=token=secret",
+		"--backend-config", "token=secret",
+		"-backend-config=backend.hcl",
+		"-var-file=secret.tfvars",
+		"-auto-approve",
+	})
+	want := []string{
+		"-var=a=(redacted)",
+		"--var=b=(redacted)",
+		"-var", "c=(redacted)",
+		"--var", "d=(redacted)",
+		"-backend-config=token=(redacted)",
+		"--backend-config=token=(redacted)",
+		"--backend-config", "token=(redacted)",
+		"-backend-config=backend.hcl",
+		"-var-file=secret.tfvars",
+		"-auto-approve",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
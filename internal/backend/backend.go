@@ -14,6 +14,7 @@ import (
 	"errors"
 	"log"
 	"os"
+	"time"
 
 	svchost "github.com/hashicorp/terraform-svchost"
 	"github.com/mitchellh/go-homedir"
@@ -52,6 +53,11 @@ var (
 	// The caller can detect this to do special fallback behavior or produce
 	// a specific, helpful error message.
 	ErrWorkspacesNotSupported = errors.New("workspaces not supported")
+
+	// ErrReadOnly is returned when a caller attempts an operation that would
+	// modify state or remote objects, or create or delete a workspace, while
+	// OpenTofu is running in read-only mode.
+	ErrReadOnly = errors.New("not allowed in read-only mode")
 )
 
 // InitFn is used to initialize a new backend.
@@ -172,6 +178,20 @@ type Local interface {
 	LocalRun(context.Context, *Operation) (*LocalRun, statemgr.Full, tfdiags.Diagnostics)
 }
 
+// CredentialsDescriber is an optional interface for backends that can obtain
+// credentials from more than one source, so that "tofu auth status" can
+// report which of them a configuration would use.
+type CredentialsDescriber interface {
+	// DescribeCredentials returns a short description of the source of the
+	// credentials that the backend would use with the given configuration,
+	// taking into account the environment variables that the backend reads.
+	// It may return an empty string if the source is not known.
+	//
+	// The configuration conforms to the backend's schema but has not been
+	// passed to PrepareConfig, so any attributes that aren't set are null.
+	DescribeCredentials(config cty.Value) string
+}
+
 // LocalRun represents the assortment of objects that we can collect or
 // calculate from an Operation object, which we can then use for local
 // operations.
@@ -284,6 +304,21 @@ type Operation struct {
 	Targets      []addrs.Targetable
 	Excludes     []addrs.Targetable
 	ForceReplace []addrs.AbsResourceInstance
+	// TargetProviders extends Targets with all resources that are bound to
+	// the given provider configurations.
+	TargetProviders []addrs.ProviderConfig
+	// ReplaceDependents also replaces the resource instances that depend on
+	// the instances in ForceReplace.
+	ReplaceDependents bool
+	// NoInputSummary disables the summary of missing root module variables
+	// shown before interactively prompting for their values.
+	NoInputSummary bool
+	// ChangeLimits are checked against the plan of an apply operation before
+	// any changes are made.
+	ChangeLimits ChangeLimits
+	// MaxRuntime, if nonzero, is the duration after the start of an apply
+	// operation when it stops starting new resource instance changes.
+	MaxRuntime time.Duration
 	// Injected by the command creating the operation (plan/apply/refresh/etc...)
 	Variables map[string]UnparsedVariableValue
 	RootCall  configs.StaticModuleCall
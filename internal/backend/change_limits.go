@@ -0,0 +1,79 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ChangeLimits are the maximum numbers of changes that an apply operation
+// may make. The operation fails before making any changes if its plan
+// exceeds either limit, which protects against unexpectedly large changes,
+// such as many resources being destroyed because of a changed data source.
+//
+// A nil limit means that number is not limited.
+type ChangeLimits struct {
+	// Changes is the maximum number of managed resource instances that the
+	// plan may create, update, replace, destroy or forget.
+	Changes *int
+
+	// Destroys is the maximum number of managed resource instance objects
+	// that the plan may destroy, including those destroyed to be replaced
+	// and deposed objects.
+	Destroys *int
+}
+
+// Empty returns true if neither limit is set.
+func (l ChangeLimits) Empty() bool {
+	return l.Changes == nil && l.Destroys == nil
+}
+
+// Check returns an error diagnostic for each limit that the given planned
+// changes exceed.
+func (l ChangeLimits) Check(changes *plans.Changes) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if l.Empty() || changes == nil {
+		return diags
+	}
+
+	changed, destroyed := 0, 0
+	for _, rc := range changes.Resources {
+		if rc.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			continue
+		}
+		switch rc.Action {
+		case plans.NoOp:
+			continue
+		case plans.Delete, plans.DeleteThenCreate, plans.CreateThenDelete:
+			destroyed++
+		}
+		changed++
+	}
+
+	if l.Changes != nil && changed > *l.Changes {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Plan exceeds the change limit",
+			fmt.Sprintf(
+				"The plan would change %d resource instances, but at most %d may be changed. No changes have been applied.\n\nReview the plan, and if the changes are expected, apply again with a higher -limit-changes value.",
+				changed, *l.Changes,
+			),
+		))
+	}
+	if l.Destroys != nil && destroyed > *l.Destroys {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Plan exceeds the destroy limit",
+			fmt.Sprintf(
+				"The plan would destroy %d resource instance objects, but at most %d may be destroyed. No changes have been applied.\n\nReview the plan, and if the changes are expected, apply again with a higher -limit-destroys value.",
+				destroyed, *l.Destroys,
+			),
+		))
+	}
+	return diags
+}
@@ -0,0 +1,89 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+func TestChangeLimitsCheck(t *testing.T) {
+	changes := plans.NewChanges()
+	for name, action := range map[string]plans.Action{
+		"create":  plans.Create,
+		"update":  plans.Update,
+		"replace": plans.DeleteThenCreate,
+		"delete":  plans.Delete,
+		"noop":    plans.NoOp,
+	} {
+		changes.Resources = append(changes.Resources, &plans.ResourceInstanceChangeSrc{
+			Addr: addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: name,
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			ChangeSrc: plans.ChangeSrc{Action: action},
+		})
+	}
+	// Reading data sources doesn't count as a change.
+	changes.Resources = append(changes.Resources, &plans.ResourceInstanceChangeSrc{
+		Addr: addrs.Resource{
+			Mode: addrs.DataResourceMode,
+			Type: "test_data",
+			Name: "read",
+		}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+		ChangeSrc: plans.ChangeSrc{Action: plans.Read},
+	})
+
+	limit := func(n int) *int {
+		return &n
+	}
+	tests := map[string]struct {
+		limits ChangeLimits
+		want   []string
+	}{
+		"no limits": {
+			ChangeLimits{},
+			nil,
+		},
+		"within limits": {
+			ChangeLimits{Changes: limit(4), Destroys: limit(2)},
+			nil,
+		},
+		"too many changes": {
+			ChangeLimits{Changes: limit(3)},
+			[]string{"The plan would change 4 resource instances, but at most 3 may be changed"},
+		},
+		"too many destroys": {
+			ChangeLimits{Changes: limit(4), Destroys: limit(0)},
+			[]string{"The plan would destroy 2 resource instance objects, but at most 0 may be destroyed"},
+		},
+		"both": {
+			ChangeLimits{Changes: limit(0), Destroys: limit(1)},
+			[]string{"Plan exceeds the change limit", "Plan exceeds the destroy limit"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := test.limits.Check(changes)
+			if len(test.want) == 0 {
+				if len(diags) != 0 {
+					t.Fatalf("unexpected diagnostics: %s", diags.ErrWithWarnings())
+				}
+				return
+			}
+			if !diags.HasErrors() {
+				t.Fatal("expected errors but got none")
+			}
+			for _, want := range test.want {
+				if got := diags.Err().Error(); !strings.Contains(got, want) {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
 
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/terminal"
 	"github.com/opentofu/opentofu/internal/tofu"
 )
@@ -93,4 +94,13 @@ type CLIOpts struct {
 	// some sort of workflow automation tool that abstracts away the
 	// exact commands that are being run.
 	RunningInAutomation bool
+
+	// ReadOnly indicates that OpenTofu is running in read-only mode. Backends
+	// must then refuse any operation that could modify remote objects, and
+	// must not write state, acquire state locks or create workspaces.
+	ReadOnly bool
+
+	// AuditLog, if non-nil, must be used to record every new state snapshot
+	// persisted by the backend's state managers.
+	AuditLog *auditlog.Log
 }
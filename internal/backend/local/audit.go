@@ -0,0 +1,123 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/auditlog"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// AuditStateMgr wraps the given state manager for the given workspace so
+// that each new state snapshot it persists is recorded in the audit log. If
+// the log is nil, or the state manager is already being audited, then it is
+// returned unchanged.
+//
+// This is exported so that other enhanced backends can audit their state
+// managers in the same way as the local backend.
+func AuditStateMgr(log *auditlog.Log, workspace string, s statemgr.Full) statemgr.Full {
+	if log == nil {
+		return s
+	}
+	switch s.(type) {
+	case *auditedStateMgr, *auditedMigrator:
+		return s
+	}
+
+	audited := &auditedStateMgr{
+		Full:      s,
+		log:       log,
+		workspace: workspace,
+	}
+	if m, ok := s.(statemgr.Migrator); ok {
+		return &auditedMigrator{
+			auditedStateMgr: audited,
+			migrator:        m,
+		}
+	}
+	return audited
+}
+
+type auditedStateMgr struct {
+	statemgr.Full
+
+	log       *auditlog.Log
+	workspace string
+}
+
+var _ statemgr.Full = (*auditedStateMgr)(nil)
+var _ statemgr.PersistentMeta = (*auditedStateMgr)(nil)
+var _ IntermediateStateConditionalPersister = (*auditedStateMgr)(nil)
+
+func (s *auditedStateMgr) PersistState(schemas *tofu.Schemas) error {
+	before := s.StateSnapshotMeta()
+	if err := s.Full.PersistState(schemas); err != nil {
+		return err
+	}
+	return s.record(before)
+}
+
+// record appends an audit log entry for a write that moved the persistent
+// snapshot on from the given metadata, unless nothing was actually written.
+func (s *auditedStateMgr) record(before statemgr.SnapshotMeta) error {
+	var serialBefore, serialAfter *uint64
+	after := s.StateSnapshotMeta()
+	if _, ok := s.Full.(statemgr.PersistentMeta); ok {
+		if before.Lineage == after.Lineage && before.Serial == after.Serial {
+			// The state manager declined to write a new snapshot, because
+			// the state hasn't changed.
+			return nil
+		}
+		if before.Lineage != "" {
+			serialBefore = &before.Serial
+		}
+		serialAfter = &after.Serial
+	}
+
+	if err := s.log.Record(s.workspace, after.Lineage, serialBefore, serialAfter); err != nil {
+		return fmt.Errorf("the state was saved, but recording it in the audit log failed: %w", err)
+	}
+	return nil
+}
+
+func (s *auditedStateMgr) StateSnapshotMeta() statemgr.SnapshotMeta {
+	if m, ok := s.Full.(statemgr.PersistentMeta); ok {
+		return m.StateSnapshotMeta()
+	}
+	return statemgr.SnapshotMeta{}
+}
+
+func (s *auditedStateMgr) ShouldPersistIntermediateState(info *IntermediateStatePersistInfo) bool {
+	if m, ok := s.Full.(IntermediateStateConditionalPersister); ok {
+		return m.ShouldPersistIntermediateState(info)
+	}
+	return DefaultIntermediateStatePersistRule(info)
+}
+
+// auditedMigrator is an auditedStateMgr for a state manager that supports
+// full-fidelity migration, which must be preserved so that migrating state
+// through an audited state manager keeps its lineage and serial.
+type auditedMigrator struct {
+	*auditedStateMgr
+	migrator statemgr.Migrator
+}
+
+var _ statemgr.Migrator = (*auditedMigrator)(nil)
+
+func (s *auditedMigrator) StateForMigration() *statefile.File {
+	return s.migrator.StateForMigration()
+}
+
+func (s *auditedMigrator) WriteStateForMigration(f *statefile.File, force bool) error {
+	// Some state managers persist the migrated snapshot immediately, rather
+	// than waiting for a call to PersistState.
+	before := s.StateSnapshotMeta()
+	if err := s.migrator.WriteStateForMigration(f, force); err != nil {
+		return err
+	}
+	return s.record(before)
+}
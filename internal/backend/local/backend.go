@@ -12,9 +12,11 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"sync"
 
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
@@ -80,6 +82,15 @@ type Local struct {
 	OpInput      bool
 	OpValidation bool
 
+	// ReadOnly, if set, makes all state managers returned by StateMgr
+	// read-only and refuses operations and workspace changes that could
+	// modify state or remote objects.
+	ReadOnly bool
+
+	// AuditLog, if non-nil, records every new state snapshot persisted by
+	// the state managers returned by StateMgr.
+	AuditLog *auditlog.Log
+
 	// Backend, if non-nil, will use this backend for non-enhanced behavior.
 	// This allows local behavior with remote state storage. It is a way to
 	// "upgrade" a non-enhanced backend to an enhanced backend with typical
@@ -95,6 +106,7 @@ type Local struct {
 }
 
 var _ backend.Backend = (*Local)(nil)
+var _ backend.CredentialsDescriber = (*Local)(nil)
 
 // New returns a new initialized local backend.
 func New(enc encryption.StateEncryption) *Local {
@@ -190,6 +202,17 @@ func (b *Local) Configure(obj cty.Value) tfdiags.Diagnostics {
 	return diags
 }
 
+// DescribeCredentials implements backend.CredentialsDescriber.
+func (b *Local) DescribeCredentials(obj cty.Value) string {
+	if b.Backend != nil {
+		if describer, ok := b.Backend.(backend.CredentialsDescriber); ok {
+			return describer.DescribeCredentials(obj)
+		}
+		return ""
+	}
+	return "none needed for local state files"
+}
+
 func (b *Local) ServiceDiscoveryAliases() ([]backend.HostAlias, error) {
 	return []backend.HostAlias{}, nil
 }
@@ -229,6 +252,10 @@ func (b *Local) Workspaces() ([]string, error) {
 //
 // The "default" workspace cannot be removed.
 func (b *Local) DeleteWorkspace(name string, force bool) error {
+	if b.ReadOnly {
+		return fmt.Errorf("cannot delete workspace %q: %w", name, backend.ErrReadOnly)
+	}
+
 	// If we have a backend handling state, defer to that.
 	if b.Backend != nil {
 		return b.Backend.DeleteWorkspace(name, force)
@@ -247,6 +274,29 @@ func (b *Local) DeleteWorkspace(name string, force bool) error {
 }
 
 func (b *Local) StateMgr(name string) (statemgr.Full, error) {
+	if b.ReadOnly {
+		// Asking for the state manager of a workspace that doesn't exist yet
+		// would implicitly create it, which we must not do in read-only mode.
+		workspaces, err := b.Workspaces()
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(workspaces, name) {
+			return nil, fmt.Errorf("cannot create workspace %q: %w", name, backend.ErrReadOnly)
+		}
+	}
+
+	s, err := b.stateMgr(name)
+	if err != nil {
+		return nil, err
+	}
+	if b.ReadOnly {
+		return statemgr.NewReadOnly(s), nil
+	}
+	return AuditStateMgr(b.AuditLog, name, s), nil
+}
+
+func (b *Local) stateMgr(name string) (statemgr.Full, error) {
 	// If we have a backend handling state, delegate to that.
 	if b.Backend != nil {
 		return b.Backend.StateMgr(name)
@@ -263,7 +313,7 @@ func (b *Local) StateMgr(name string) (statemgr.Full, error) {
 	statePath, stateOutPath, backupPath := b.StatePaths(name)
 	log.Printf("[TRACE] backend/local: state manager for workspace %q will:\n - read initial snapshot from %s\n - write new snapshots to %s\n - create any backup at %s", name, statePath, stateOutPath, backupPath)
 
-	s := statemgr.NewFilesystemBetweenPaths(statePath, stateOutPath, b.encryption)
+	s := statemgr.NewFilesystemBetweenPaths(statePath, stateOutPath, encryption.StateForWorkspace(b.encryption, name))
 	if backupPath != "" {
 		s.SetBackupPath(backupPath)
 	}
@@ -287,6 +337,11 @@ func (b *Local) Operation(ctx context.Context, op *backend.Operation) (*backend.
 	if op.View == nil {
 		panic("Operation called with nil View")
 	}
+	if b.ReadOnly {
+		if err := op.Type.CheckReadOnly(); err != nil {
+			return nil, err
+		}
+	}
 
 	// Determine the function to call for our operation
 	var f func(context.Context, context.Context, *backend.Operation, *backend.RunningOperation)
@@ -80,6 +80,17 @@ func (b *Local) opApply(
 	stateHook := new(StateHook)
 	op.Hooks = append(op.Hooks, stateHook)
 
+	// The maximum runtime counts from the start of the operation, since the
+	// time taken to plan also counts towards any external time limit.
+	var deadline *deadlineHook
+	if op.MaxRuntime > 0 {
+		deadline = newDeadlineHook(time.Now().Add(op.MaxRuntime))
+		// The deadline hook must come before the view's hooks: once it halts
+		// a change, the later hooks aren't called for it, so the UI doesn't
+		// report a change that never starts.
+		op.Hooks = append([]tofu.Hook{deadline}, op.Hooks...)
+	}
+
 	// Get our context
 	lr, _, opState, contextDiags := b.localRun(ctx, op)
 	diags = diags.Append(contextDiags)
@@ -134,7 +145,7 @@ func (b *Local) opApply(
 			// plan.Errored will be true in this case, which our plan
 			// renderer can rely on to tailor its messaging.
 			if plan != nil && (len(plan.Changes.Resources) != 0 || len(plan.Changes.Outputs) != 0) {
-				op.View.Plan(plan, schemas)
+				op.View.Plan(stopCtx, plan, schemas)
 			}
 			op.ReportResult(runningOp, diags)
 			return
@@ -143,12 +154,20 @@ func (b *Local) opApply(
 		trivialPlan := !plan.CanApply()
 		hasUI := op.UIOut != nil && op.UIIn != nil
 		mustConfirm := hasUI && !op.AutoApprove && !trivialPlan
-		op.View.Plan(plan, schemas)
+		op.View.Plan(stopCtx, plan, schemas)
 
 		if testHookStopPlanApply != nil {
 			testHookStopPlanApply()
 		}
 
+		// The plan has been shown, so the user can see which changes exceed
+		// the limits.
+		if limitDiags := op.ChangeLimits.Check(plan.Changes); limitDiags.HasErrors() {
+			diags = diags.Append(limitDiags)
+			op.ReportResult(runningOp, diags)
+			return
+		}
+
 		// Check if we've been stopped before going through confirmation, or
 		// skipping confirmation in the case of -auto-approve.
 		// This can currently happen if a single stop request was received
@@ -251,6 +270,11 @@ func (b *Local) opApply(
 			op.ReportResult(runningOp, diags)
 			return
 		}
+		if limitDiags := op.ChangeLimits.Check(plan.Changes); limitDiags.HasErrors() {
+			diags = diags.Append(limitDiags)
+			op.ReportResult(runningOp, diags)
+			return
+		}
 		for _, change := range plan.Changes.Resources {
 			if change.Action != plans.NoOp {
 				op.View.PlannedChange(change)
@@ -258,8 +282,20 @@ func (b *Local) opApply(
 		}
 	}
 
+	// If the deadline passed while planning or waiting for approval then
+	// there's no time left to apply anything.
+	if deadline != nil && deadline.Expired() {
+		if remaining := deadline.RemainingChanges(plan.Changes); len(remaining) != 0 {
+			op.View.RemainingChanges(remaining)
+			diags = maxRuntimeDiagnostics(diags, op.MaxRuntime)
+			op.ReportResult(runningOp, diags)
+			return
+		}
+	}
+
 	// Set up our hook for continuous state updates
 	stateHook.StateMgr = opState
+	stateHook.DependencyLocks = op.DependencyLocks
 
 	// Start to apply in a goroutine so that we can be interrupted.
 	var applyState *states.State
@@ -276,6 +312,9 @@ func (b *Local) opApply(
 	if b.opWait(doneCh, stopCtx, cancelCtx, lr.Core, opState, op.View) {
 		return
 	}
+	if deadline != nil && deadline.Halted() {
+		applyDiags = maxRuntimeDiagnostics(applyDiags, op.MaxRuntime)
+	}
 	diags = diags.Append(applyDiags)
 
 	// Even on error with an empty state, the state value should not be nil.
@@ -287,6 +326,7 @@ func (b *Local) opApply(
 	}
 
 	// Store the final state
+	backend.RecordProviderVersions(applyState, op.DependencyLocks)
 	runningOp.State = applyState
 	err := statemgr.WriteAndPersist(opState, applyState, schemas)
 	if err != nil {
@@ -303,6 +343,10 @@ func (b *Local) opApply(
 		return
 	}
 
+	if deadline != nil && deadline.Halted() {
+		op.View.RemainingChanges(deadline.RemainingChanges(plan.Changes))
+	}
+
 	if applyDiags.HasErrors() {
 		op.ReportResult(runningOp, diags)
 		return
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/terminal"
 	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
 )
 
 func TestLocal_applyBasic(t *testing.T) {
@@ -77,6 +79,121 @@ test_instance.foo:
 		t.Fatalf("unexpected error output:\n%s", errOutput)
 	}
 }
+func TestLocal_applyChangeLimits(t *testing.T) {
+	b := TestLocal(t)
+
+	p := TestLocalProvider(t, b, "test", applyFixtureSchema())
+
+	op, configCleanup, done := testOperationApply(t, "./testdata/apply")
+	defer configCleanup()
+	limit := 0
+	op.ChangeLimits.Changes = &limit
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("operation succeeded; want error")
+	}
+
+	if p.ApplyResourceChangeCalled {
+		t.Fatal("apply should not be called")
+	}
+
+	if errOutput := done(t).Stderr(); !strings.Contains(errOutput, "Plan exceeds the change limit") {
+		t.Fatalf("unexpected error output:\n%s", errOutput)
+	}
+}
+
+func TestLocal_applyMaxRuntime(t *testing.T) {
+	b := TestLocal(t)
+
+	p := TestLocalProvider(t, b, "test", applyFixtureSchema())
+
+	op, configCleanup, done := testOperationApply(t, "./testdata/apply")
+	defer configCleanup()
+	// The deadline passes while planning, so nothing is applied.
+	op.MaxRuntime = time.Nanosecond
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("operation succeeded; want error")
+	}
+
+	if p.ApplyResourceChangeCalled {
+		t.Fatal("apply should not be called")
+	}
+
+	output := done(t)
+	if got, want := output.Stdout(), "  - test_instance.foo: create"; !strings.Contains(got, want) {
+		t.Fatalf("remaining changes not reported\ngot:\n%s\nwant: %s", got, want)
+	}
+	if got, want := output.Stderr(), "Maximum runtime reached"; !strings.Contains(got, want) {
+		t.Fatalf("unexpected error output:\n%s", got)
+	}
+}
+
+func TestLocal_applyMaxRuntimeHooks(t *testing.T) {
+	b := TestLocal(t)
+
+	p := TestLocalProvider(t, b, "test", applyFixtureSchema())
+	// The deadline passes while the first resource is being created, so the
+	// second one, which depends on it, must not start.
+	p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+		time.Sleep(500 * time.Millisecond)
+		return providers.ApplyResourceChangeResponse{NewState: cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("yes"),
+			"ami": cty.StringVal("bar"),
+		})}
+	}
+
+	op, configCleanup, done := testOperationApply(t, "./testdata/apply-chain")
+	defer configCleanup()
+	op.MaxRuntime = 250 * time.Millisecond
+	// Hooks such as those of the UI must not see the changes that the
+	// deadline halts.
+	viewHook := &applyStartedHook{}
+	op.Hooks = append(op.Hooks, viewHook)
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	done(t)
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("operation succeeded; want error")
+	}
+
+	for _, addr := range viewHook.started {
+		if addr == "test_instance.bar" {
+			t.Fatal("PreApply was called for the halted change")
+		}
+	}
+}
+
+// applyStartedHook records the resource instances that PreApply is called
+// for.
+type applyStartedHook struct {
+	tofu.NilHook
+
+	mu      sync.Mutex
+	started []string
+}
+
+func (h *applyStartedHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.started = append(h.started, addr.String())
+	return tofu.HookActionContinue, nil
+}
+
 func TestLocal_applyCheck(t *testing.T) {
 	b := TestLocal(t)
 
@@ -14,10 +14,12 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/lang"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -191,7 +193,7 @@ func (b *Local) localRunDirect(op *backend.Operation, run *backend.LocalRun, cor
 		// values through interactive prompts.
 		// TODO: Need to route the operation context through into here, so that
 		// the interactive prompts can be sensitive to its timeouts/etc.
-		rawVariables = b.interactiveCollectVariables(context.TODO(), op.Variables, config.Module.Variables, op.UIIn)
+		rawVariables = b.interactiveCollectVariables(context.TODO(), op.Variables, config.Module.Variables, op.UIIn, op.UIOut, !op.NoInputSummary)
 	}
 
 	variables, varDiags := backend.ParseVariableValues(rawVariables, config.Module.Variables)
@@ -204,7 +206,9 @@ func (b *Local) localRunDirect(op *backend.Operation, run *backend.LocalRun, cor
 		Mode:               op.PlanMode,
 		Targets:            op.Targets,
 		Excludes:           op.Excludes,
+		TargetProviders:    op.TargetProviders,
 		ForceReplace:       op.ForceReplace,
+		ReplaceDependents:  op.ReplaceDependents,
 		SetVariables:       variables,
 		SkipRefresh:        op.Type != backend.OperationTypeRefresh && !op.PlanRefresh,
 		GenerateConfigPath: op.GenerateConfigOut,
@@ -292,6 +296,12 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 	})
 
 	loader := configload.NewLoaderFromSnapshot(snap)
+	if op.ConfigLoader != nil {
+		// The plan may have been created while ignoring the configuration's
+		// OpenTofu version constraints, in which case applying it must
+		// ignore them too.
+		loader.IgnoreCoreVersionConstraints(op.ConfigLoader.CoreVersionConstraintsIgnored())
+	}
 	config, configDiags := loader.LoadConfig(snap.Modules[""].Dir, subCall)
 	diags = diags.Append(configDiags)
 	if configDiags.HasErrors() {
@@ -414,7 +424,16 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 // messages that variables are not set rather than reporting that input failed:
 // the primary resolution to missing variables is to provide them by some other
 // means.
-func (b *Local) interactiveCollectVariables(ctx context.Context, existing map[string]backend.UnparsedVariableValue, vcs map[string]*configs.Variable, uiInput tofu.UIInput) map[string]backend.UnparsedVariableValue {
+//
+// If showSummary is set and uiOutput is not nil then all of the variables
+// that will be requested are listed together before the first prompt, so
+// that the user can decide whether to provide them some other way instead.
+//
+// Each prompt includes the variable's description, type constraint and any
+// validation rules. A value that cannot be parsed or that fails one of the
+// validation rules is reported and the user is asked again, rather than
+// letting the whole operation fail later.
+func (b *Local) interactiveCollectVariables(ctx context.Context, existing map[string]backend.UnparsedVariableValue, vcs map[string]*configs.Variable, uiInput tofu.UIInput, uiOutput tofu.UIOutput, showSummary bool) map[string]backend.UnparsedVariableValue {
 	var needed []string
 	if b.OpInput && uiInput != nil {
 		for name, vc := range vcs {
@@ -437,30 +456,164 @@ func (b *Local) interactiveCollectVariables(ctx context.Context, existing map[st
 	// If we get here then we're planning to prompt for at least one additional
 	// variable's value.
 	sort.Strings(needed) // prompt in lexical order
+	if showSummary && uiOutput != nil {
+		uiOutput.Output(interactiveVariablesSummary(needed, vcs))
+	}
+
 	ret := make(map[string]backend.UnparsedVariableValue, len(vcs))
 	for k, v := range existing {
 		ret[k] = v
 	}
 	for _, name := range needed {
 		vc := vcs[name]
-		rawValue, err := uiInput.Input(ctx, &tofu.InputOpts{
-			Id:          fmt.Sprintf("var.%s", name),
-			Query:       fmt.Sprintf("var.%s", name),
-			Description: vc.Description,
-			Secret:      vc.Sensitive,
-		})
-		if err != nil {
-			// Since interactive prompts are best-effort, we'll just continue
-			// here and let subsequent validation report this as a variable
-			// not specified.
-			log.Printf("[WARN] backend/local: Failed to request user input for variable %q: %s", name, err)
-			continue
+		desc := interactiveVariableDescription(vc)
+		for {
+			rawValue, err := uiInput.Input(ctx, &tofu.InputOpts{
+				Id:          fmt.Sprintf("var.%s", name),
+				Query:       fmt.Sprintf("var.%s", name),
+				Description: desc,
+				Secret:      vc.Sensitive,
+			})
+			if err != nil {
+				// Since interactive prompts are best-effort, we'll just continue
+				// here and let subsequent validation report this as a variable
+				// not specified.
+				log.Printf("[WARN] backend/local: Failed to request user input for variable %q: %s", name, err)
+				break
+			}
+			if problem := checkInteractiveVariableValue(name, vc, rawValue); problem != "" {
+				log.Printf("[DEBUG] backend/local: Invalid value entered for variable %q: %s", name, problem)
+				desc = fmt.Sprintf("Invalid value: %s\n\n%s", problem, interactiveVariableDescription(vc))
+				continue
+			}
+			ret[name] = unparsedInteractiveVariableValue{Name: name, RawValue: rawValue}
+			break
 		}
-		ret[name] = unparsedInteractiveVariableValue{Name: name, RawValue: rawValue}
 	}
 	return ret
 }
 
+// interactiveVariablesSummary returns a message listing all of the given
+// variables, which must all be declared in vcs, to show before prompting
+// for any of them.
+func interactiveVariablesSummary(names []string, vcs map[string]*configs.Variable) string {
+	var buf strings.Builder
+	buf.WriteString("The following required variables are not set and will be requested interactively:\n")
+	for _, name := range names {
+		vc := vcs[name]
+		fmt.Fprintf(&buf, "  - var.%s (%s)", name, interactiveVariableTypeName(vc))
+		if vc.Description != "" {
+			fmt.Fprintf(&buf, ": %s", firstLine(vc.Description))
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("\nUse -var or -var-file, or set TF_VAR_ environment variables, to provide them without prompting.\n")
+	return buf.String()
+}
+
+// interactiveVariableDescription returns the description shown when prompting
+// for the given variable, including its type constraint and the error messages
+// of any validation rules that can be described without a value.
+func interactiveVariableDescription(vc *configs.Variable) string {
+	var buf strings.Builder
+	if vc.Description != "" {
+		buf.WriteString(vc.Description)
+		buf.WriteString("\n\n")
+	}
+	fmt.Fprintf(&buf, "Type: %s", interactiveVariableTypeName(vc))
+	var rules []string
+	for _, rule := range vc.Validations {
+		msg, diags := rule.ErrorMessage.Value(nil)
+		if diags.HasErrors() || !msg.IsKnown() || msg.IsNull() || !msg.Type().Equals(cty.String) {
+			continue // the message depends on the value, so we can't show it yet
+		}
+		rules = append(rules, msg.AsString())
+	}
+	if len(rules) != 0 {
+		buf.WriteString("\nValidation rules:")
+		for _, rule := range rules {
+			fmt.Fprintf(&buf, "\n  - %s", rule)
+		}
+	}
+	return buf.String()
+}
+
+func interactiveVariableTypeName(vc *configs.Variable) string {
+	ty := vc.ConstraintType
+	if ty == cty.NilType {
+		ty = vc.Type
+	}
+	if ty == cty.NilType || ty == cty.DynamicPseudoType {
+		return "any"
+	}
+	return ty.FriendlyNameForConstraint()
+}
+
+// checkInteractiveVariableValue checks whether the given raw value entered
+// by the user is acceptable for the given variable, returning a description
+// of the problem if not, or an empty string if the value seems valid.
+//
+// This is only an early check for the benefit of the prompt: validation rules
+// that cannot be evaluated outside of the full module context are skipped
+// here and will be checked again during the operation as normal.
+func checkInteractiveVariableValue(name string, vc *configs.Variable, rawValue string) string {
+	val, diags := vc.ParsingMode.Parse(name, rawValue)
+	if diags.HasErrors() {
+		return diagnosticsSummary(diags)
+	}
+	if vc.ConstraintType != cty.NilType {
+		var err error
+		val, err = convert.Convert(val, vc.ConstraintType)
+		if err != nil {
+			return fmt.Sprintf("Unsuitable value for var.%s: %s.", name, tfdiags.FormatError(err))
+		}
+	}
+	if vc.TypeDefaults != nil && !val.IsNull() {
+		val = vc.TypeDefaults.Apply(val)
+	}
+
+	hclCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{name: val}),
+		},
+		Functions: (&lang.Scope{BaseDir: ".", PureOnly: true}).Functions(),
+	}
+	for _, rule := range vc.Validations {
+		result, hclDiags := rule.Condition.Value(hclCtx)
+		if hclDiags.HasErrors() || !result.IsWhollyKnown() || result.IsNull() {
+			continue // can't check this rule without the rest of the module
+		}
+		result, err := convert.Convert(result, cty.Bool)
+		if err != nil || result.False() {
+			msg, msgDiags := rule.ErrorMessage.Value(hclCtx)
+			if msgDiags.HasErrors() || !msg.IsKnown() || msg.IsNull() || !msg.Type().Equals(cty.String) {
+				return "The value does not satisfy the variable's validation rules."
+			}
+			return msg.AsString()
+		}
+	}
+	return ""
+}
+
+func diagnosticsSummary(diags hcl.Diagnostics) string {
+	var msgs []string
+	for _, diag := range diags {
+		if diag.Detail != "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", diag.Summary, diag.Detail))
+		} else {
+			msgs = append(msgs, diag.Summary)
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
 // stubUnsetVariables ensures that all required variables defined in the
 // configuration exist in the resulting map, by adding new elements as necessary.
 //
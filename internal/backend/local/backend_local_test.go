@@ -10,14 +10,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/clistate"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
@@ -279,3 +284,112 @@ func (s *stateStorageThatFailsRefresh) RefreshState() error {
 func (s *stateStorageThatFailsRefresh) PersistState(schemas *tofu.Schemas) error {
 	return fmt.Errorf("unimplemented")
 }
+
+func TestLocalInteractiveCollectVariables(t *testing.T) {
+	condition, hclDiags := hclsyntax.ParseExpression([]byte(`length(var.name) > 3`), "test.tf", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatal(hclDiags.Error())
+	}
+	vcs := map[string]*configs.Variable{
+		"name": {
+			Name:           "name",
+			Description:    "The name of the thing.",
+			Type:           cty.String,
+			ConstraintType: cty.String,
+			ParsingMode:    configs.VariableParseLiteral,
+			Validations: []*configs.CheckRule{
+				{
+					Condition:    condition,
+					ErrorMessage: hcl.StaticExpr(cty.StringVal("The name must be longer than three characters."), hcl.Range{}),
+				},
+			},
+		},
+		"count": {
+			Name:           "count",
+			Type:           cty.Number,
+			ConstraintType: cty.Number,
+			ParsingMode:    configs.VariableParseHCL,
+		},
+		"optional": {
+			Name:           "optional",
+			Type:           cty.String,
+			ConstraintType: cty.String,
+			ParsingMode:    configs.VariableParseLiteral,
+			Default:        cty.StringVal("default"),
+		},
+	}
+
+	answers := map[string][]string{
+		"var.name":  {"abc", "abcd"},
+		"var.count": {"not a number", "2"},
+	}
+	var prompts []*tofu.InputOpts
+	input := &tofu.MockUIInput{
+		InputFn: func(opts *tofu.InputOpts) (string, error) {
+			prompts = append(prompts, opts)
+			answer := answers[opts.Id][0]
+			answers[opts.Id] = answers[opts.Id][1:]
+			return answer, nil
+		},
+	}
+	output := &tofu.MockUIOutput{}
+
+	b := New(encryption.StateEncryptionDisabled())
+	b.OpInput = true
+	got := b.interactiveCollectVariables(context.Background(), nil, vcs, input, output, true)
+
+	want := map[string]backend.UnparsedVariableValue{
+		"count": unparsedInteractiveVariableValue{Name: "count", RawValue: "2"},
+		"name":  unparsedInteractiveVariableValue{Name: "name", RawValue: "abcd"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	if len(prompts) != 4 {
+		t.Fatalf("wrong number of prompts %d; want 4", len(prompts))
+	}
+	if got, want := prompts[0].Description, "Type: number"; got != want {
+		t.Errorf("wrong first description\ngot:  %q\nwant: %q", got, want)
+	}
+	if got := prompts[1].Description; !strings.HasPrefix(got, "Invalid value: ") {
+		t.Errorf("expected re-prompt after invalid number, got description %q", got)
+	}
+	if got, want := prompts[2].Description, "The name of the thing.\n\nType: string\nValidation rules:\n  - The name must be longer than three characters."; got != want {
+		t.Errorf("wrong name description\ngot:  %q\nwant: %q", got, want)
+	}
+	if got, want := prompts[3].Description, "Invalid value: The name must be longer than three characters.\n\n"+prompts[2].Description; got != want {
+		t.Errorf("wrong re-prompt description\ngot:  %q\nwant: %q", got, want)
+	}
+
+	wantSummary := `The following required variables are not set and will be requested interactively:
+  - var.count (number)
+  - var.name (string): The name of the thing.
+
+Use -var or -var-file, or set TF_VAR_ environment variables, to provide them without prompting.
+`
+	if output.OutputMessage != wantSummary {
+		t.Errorf("wrong summary\ngot:\n%s\nwant:\n%s", output.OutputMessage, wantSummary)
+	}
+}
+
+func TestLocalInteractiveCollectVariables_noSummary(t *testing.T) {
+	vcs := map[string]*configs.Variable{
+		"name": {
+			Name:           "name",
+			Type:           cty.String,
+			ConstraintType: cty.String,
+			ParsingMode:    configs.VariableParseLiteral,
+		},
+	}
+	input := &tofu.MockUIInput{InputReturnString: "value"}
+	output := &tofu.MockUIOutput{}
+
+	b := New(encryption.StateEncryptionDisabled())
+	b.OpInput = true
+	b.interactiveCollectVariables(context.Background(), nil, vcs, input, output, false)
+
+	if output.OutputCalled {
+		t.Errorf("unexpected summary output: %s", output.OutputMessage)
+	}
+}
@@ -211,7 +211,7 @@ func (b *Local) opPlan(
 		return
 	}
 
-	op.View.Plan(plan, schemas)
+	op.View.Plan(stopCtx, plan, schemas)
 
 	// If we've accumulated any diagnostics along the way then we'll show them
 	// here just before we show the summary and next steps. This can potentially
@@ -452,7 +452,9 @@ OpenTofu will perform the following actions:
         }
     }
 
-Plan: 1 to add, 0 to change, 1 to destroy.`
+Plan: 1 to add, 0 to change, 1 to destroy.
+1 of the objects to destroy are deposed objects left over from
+partially-failed replacements. Run "tofu state list -deposed" to list them.`
 	if output := done(t).Stdout(); !strings.Contains(output, expectedOutput) {
 		t.Fatalf("Unexpected output\ngot\n%s\n\nwant:\n%s", output, expectedOutput)
 	}
@@ -119,6 +119,7 @@ func (b *Local) opRefresh(
 		return
 	}
 
+	backend.RecordProviderVersions(newState, op.DependencyLocks)
 	err := statemgr.WriteAndPersist(opState, newState, schemas)
 	if err != nil {
 		diags = diags.Append(fmt.Errorf("failed to write state: %w", err))
@@ -6,6 +6,8 @@
 package local
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -13,6 +15,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states/statefile"
@@ -231,6 +234,100 @@ func TestLocal_multiStateBackend(t *testing.T) {
 	}
 }
 
+func TestLocal_readOnly(t *testing.T) {
+	configDir, err := filepath.Abs("./testdata/apply")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTmpDir(t)
+
+	b := New(encryption.StateEncryptionDisabled())
+	if err := b.CLIInit(&backend.CLIOpts{ReadOnly: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatalf("unexpected error getting default state manager: %s", err)
+	}
+	if err := s.WriteState(nil); !errors.Is(err, statemgr.ErrReadOnly) {
+		t.Errorf("WriteState returned %v; want statemgr.ErrReadOnly", err)
+	}
+	if _, err := s.Lock(statemgr.NewLockInfo()); !errors.Is(err, statemgr.ErrReadOnly) {
+		t.Errorf("Lock returned %v; want statemgr.ErrReadOnly", err)
+	}
+
+	if _, err := b.StateMgr("new"); !errors.Is(err, backend.ErrReadOnly) {
+		t.Errorf("creating a workspace returned %v; want backend.ErrReadOnly", err)
+	}
+	if _, err := os.Stat(filepath.Join(DefaultWorkspaceDir, "new")); !os.IsNotExist(err) {
+		t.Errorf("workspace directory should not have been created, but got: %v", err)
+	}
+	if err := b.DeleteWorkspace("new", true); !errors.Is(err, backend.ErrReadOnly) {
+		t.Errorf("deleting a workspace returned %v; want backend.ErrReadOnly", err)
+	}
+
+	op, configCleanup, _ := testOperationApply(t, configDir)
+	defer configCleanup()
+	if _, err := b.Operation(context.Background(), op); !errors.Is(err, backend.ErrReadOnly) {
+		t.Errorf("apply operation returned %v; want backend.ErrReadOnly", err)
+	}
+}
+
+func TestLocal_auditLog(t *testing.T) {
+	testTmpDir(t)
+
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	b := New(encryption.StateEncryptionDisabled())
+	if err := b.CLIInit(&backend.CLIOpts{AuditLog: auditlog.New(logPath)}); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := b.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteState(statemgr.TestFullInitialState()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PersistState(nil); err != nil {
+		t.Fatal(err)
+	}
+	// Persisting a state that hasn't changed since it was last read doesn't
+	// write a new snapshot, and so must not be recorded.
+	if err := s.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PersistState(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 audit log entry, got %d:\n%s", len(lines), raw)
+	}
+	var entry auditlog.Entry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Workspace != backend.DefaultStateName {
+		t.Errorf("wrong workspace %q", entry.Workspace)
+	}
+	if entry.SerialBefore == nil || *entry.SerialBefore != 0 {
+		t.Errorf("wrong serial_before %v; want 0", entry.SerialBefore)
+	}
+	if entry.SerialAfter == nil || *entry.SerialAfter != 1 {
+		t.Errorf("wrong serial_after %v; want 1", entry.SerialAfter)
+	}
+}
+
 // testTmpDir changes into a tmp dir and change back automatically when the test
 // and all its subtests complete.
 func testTmpDir(t *testing.T) {
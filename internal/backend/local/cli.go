@@ -16,6 +16,8 @@ func (b *Local) CLIInit(opts *backend.CLIOpts) error {
 	b.ContextOpts = opts.ContextOpts
 	b.OpInput = opts.Input
 	b.OpValidation = opts.Validation
+	b.ReadOnly = opts.ReadOnly
+	b.AuditLog = opts.AuditLog
 
 	// configure any new cli options
 	if opts.StatePath != "" {
@@ -0,0 +1,123 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// errMaxRuntimeReached is the error that deadlineHook returns to halt the
+// changes that would start after the deadline.
+var errMaxRuntimeReached = errors.New("maximum runtime reached")
+
+// deadlineHook is a hook that prevents an apply operation from starting any
+// new resource instance changes after a deadline, while letting the changes
+// already in progress complete. It also records which planned changes were
+// applied, so the remaining ones can be reported.
+type deadlineHook struct {
+	tofu.NilHook
+
+	deadline time.Time
+
+	mu      sync.Mutex
+	halted  bool
+	applied map[deadlineChangeKey]struct{}
+}
+
+type deadlineChangeKey struct {
+	addr    string
+	deposed states.DeposedKey
+}
+
+var _ tofu.Hook = (*deadlineHook)(nil)
+
+func newDeadlineHook(deadline time.Time) *deadlineHook {
+	return &deadlineHook{
+		deadline: deadline,
+		applied:  make(map[deadlineChangeKey]struct{}),
+	}
+}
+
+// Expired returns true if the deadline has passed.
+func (h *deadlineHook) Expired() bool {
+	return !time.Now().Before(h.deadline)
+}
+
+// Halted returns true if the hook prevented any change from starting.
+func (h *deadlineHook) Halted() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.halted
+}
+
+func (h *deadlineHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	if !h.Expired() {
+		return tofu.HookActionContinue, nil
+	}
+	h.mu.Lock()
+	h.halted = true
+	h.mu.Unlock()
+	return tofu.HookActionHalt, errMaxRuntimeReached
+}
+
+func (h *deadlineHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (tofu.HookAction, error) {
+	if err != nil {
+		return tofu.HookActionContinue, nil
+	}
+	key := deadlineChangeKey{addr: addr.String()}
+	if dk, ok := gen.(states.DeposedKey); ok {
+		key.deposed = dk
+	}
+	h.mu.Lock()
+	h.applied[key] = struct{}{}
+	h.mu.Unlock()
+	return tofu.HookActionContinue, nil
+}
+
+// RemainingChanges returns the planned changes to managed resources that
+// haven't been applied.
+func (h *deadlineHook) RemainingChanges(changes *plans.Changes) []*plans.ResourceInstanceChangeSrc {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var ret []*plans.ResourceInstanceChangeSrc
+	for _, rc := range changes.Resources {
+		if rc.Action == plans.NoOp || rc.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			continue
+		}
+		if _, ok := h.applied[deadlineChangeKey{addr: rc.Addr.String(), deposed: rc.DeposedKey}]; ok {
+			continue
+		}
+		ret = append(ret, rc)
+	}
+	return ret
+}
+
+// maxRuntimeDiagnostics replaces the errors of the changes that the hook
+// halted with a single error explaining that the maximum runtime was reached.
+func maxRuntimeDiagnostics(diags tfdiags.Diagnostics, maxRuntime time.Duration) tfdiags.Diagnostics {
+	var ret tfdiags.Diagnostics
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Error && diag.Description().Summary == errMaxRuntimeReached.Error() {
+			continue
+		}
+		ret = ret.Append(diag)
+	}
+	return ret.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Maximum runtime reached",
+		fmt.Sprintf("OpenTofu didn't start any more changes after the maximum runtime of %s had elapsed. The changes that completed have been saved in the state, and the remaining changes are listed above. Run \"tofu apply\" again to apply them.", maxRuntime),
+	))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestDeadlineHook(t *testing.T) {
+	instance := func(name string) addrs.AbsResourceInstance {
+		return addrs.Resource{
+			Mode: addrs.ManagedResourceMode,
+			Type: "test_instance",
+			Name: name,
+		}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	}
+	changes := plans.NewChanges()
+	for _, rc := range []struct {
+		name    string
+		deposed states.DeposedKey
+		action  plans.Action
+	}{
+		{"a", states.NotDeposed, plans.Create},
+		{"b", states.NotDeposed, plans.Update},
+		{"b", "00000001", plans.Delete},
+		{"c", states.NotDeposed, plans.NoOp},
+	} {
+		changes.Resources = append(changes.Resources, &plans.ResourceInstanceChangeSrc{
+			Addr:       instance(rc.name),
+			DeposedKey: rc.deposed,
+			ChangeSrc:  plans.ChangeSrc{Action: rc.action},
+		})
+	}
+
+	h := newDeadlineHook(time.Now().Add(time.Hour))
+	if h.Expired() {
+		t.Fatal("deadline expired early")
+	}
+	action, err := h.PreApply(instance("a"), states.CurrentGen, plans.Create, cty.NullVal(cty.DynamicPseudoType), cty.NullVal(cty.DynamicPseudoType))
+	if action != tofu.HookActionContinue || err != nil {
+		t.Fatalf("change halted before the deadline: %s", err)
+	}
+	h.PostApply(instance("a"), states.CurrentGen, cty.NullVal(cty.DynamicPseudoType), nil)
+	h.PostApply(instance("b"), states.DeposedKey("00000001"), cty.NullVal(cty.DynamicPseudoType), nil)
+
+	h.deadline = time.Now()
+	action, err = h.PreApply(instance("b"), states.CurrentGen, plans.Update, cty.NullVal(cty.DynamicPseudoType), cty.NullVal(cty.DynamicPseudoType))
+	if action != tofu.HookActionHalt || err != errMaxRuntimeReached {
+		t.Fatalf("change not halted after the deadline")
+	}
+	if !h.Halted() {
+		t.Fatal("hook doesn't report halting a change")
+	}
+
+	remaining := h.RemainingChanges(changes)
+	if len(remaining) != 1 {
+		t.Fatalf("wrong number of remaining changes %d; want 1", len(remaining))
+	}
+	if got := remaining[0]; !got.Addr.Equal(instance("b")) || got.DeposedKey != states.NotDeposed {
+		t.Errorf("wrong remaining change %s %s", got.Addr, got.DeposedKey)
+	}
+}
+
+func TestMaxRuntimeDiagnostics(t *testing.T) {
+	var diags tfdiags.Diagnostics
+	diags = diags.Append(errMaxRuntimeReached, errMaxRuntimeReached)
+	diags = diags.Append(tfdiags.SimpleWarning("unrelated"))
+
+	got := maxRuntimeDiagnostics(diags, 45*time.Minute)
+	if len(got) != 2 {
+		t.Fatalf("wrong number of diagnostics %d; want 2\n%s", len(got), got.ErrWithWarnings())
+	}
+	if got[0].Severity() != tfdiags.Warning {
+		t.Errorf("warning was not kept")
+	}
+	if desc := got[1].Description(); desc.Summary != "Maximum runtime reached" {
+		t.Errorf("wrong error %q", desc.Summary)
+	}
+}
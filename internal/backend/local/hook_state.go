@@ -10,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -35,6 +37,11 @@ type StateHook struct {
 	// and PersistInterval is ignored if this is nil.
 	Schemas *tofu.Schemas
 
+	// DependencyLocks are the provider versions selected for the operation,
+	// which are recorded in each state snapshot so that intermediate
+	// snapshots carry them just like the final one.
+	DependencyLocks *depsfile.Locks
+
 	intermediatePersist IntermediateStatePersistInfo
 }
 
@@ -76,6 +83,7 @@ func (h *StateHook) PostStateUpdate(new *states.State) (tofu.HookAction, error)
 	}
 
 	if h.StateMgr != nil {
+		backend.RecordProviderVersions(new, h.DependencyLocks)
 		if err := h.StateMgr.WriteState(new); err != nil {
 			return tofu.HookActionHalt, err
 		}
@@ -130,7 +138,13 @@ func (h *StateHook) Stopping() {
 }
 
 func (h *StateHook) shouldPersist() bool {
-	if m, ok := h.StateMgr.(IntermediateStateConditionalPersister); ok {
+	mgr := h.StateMgr
+	if ro, ok := mgr.(interface{ Unwrap() statemgr.Full }); ok {
+		// A read-only state manager follows the rules of the state manager
+		// it wraps.
+		mgr = ro.Unwrap()
+	}
+	if m, ok := mgr.(IntermediateStateConditionalPersister); ok {
 		return m.ShouldPersistIntermediateState(&h.intermediatePersist)
 	}
 	return DefaultIntermediateStatePersistRule(&h.intermediatePersist)
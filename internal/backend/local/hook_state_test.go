@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -37,6 +40,26 @@ func TestStateHook(t *testing.T) {
 	}
 }
 
+func TestStateHook_providerVersions(t *testing.T) {
+	is := statemgr.NewTransientInMemory(nil)
+	locks := depsfile.NewLocks()
+	provider := addrs.NewDefaultProvider("null")
+	locks.SetProvider(provider, getproviders.MustParseVersion("3.2.1"), nil, nil)
+	hook := &StateHook{StateMgr: is, DependencyLocks: locks}
+
+	// Intermediate snapshots must record the selected provider versions
+	// too, since one of them can end up being the last one persisted.
+	if _, err := hook.PostStateUpdate(statemgr.TestFullInitialState()); err != nil {
+		t.Fatalf("unexpected error from PostStateUpdate: %s", err)
+	}
+	want := map[addrs.Provider]getproviders.Version{
+		provider: getproviders.MustParseVersion("3.2.1"),
+	}
+	if diff := cmp.Diff(want, is.State().ProviderVersions); diff != "" {
+		t.Errorf("wrong provider versions\n%s", diff)
+	}
+}
+
 func TestStateHookStopping(t *testing.T) {
 	is := &testPersistentState{}
 	hook := &StateHook{
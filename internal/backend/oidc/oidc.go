@@ -0,0 +1,151 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package oidc obtains OpenID Connect ID tokens from the CI systems that
+// issue them to their jobs, so that backends can exchange those tokens for
+// short-lived cloud credentials without an external credential helper.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/httpclient"
+)
+
+const (
+	// ProviderGitHub obtains the token from the GitHub Actions ID token
+	// service, which requires the job to have the "id-token: write"
+	// permission.
+	ProviderGitHub = "github"
+
+	// ProviderGitLab reads the token from an environment variable declared
+	// in the "id_tokens" section of the GitLab CI/CD job.
+	ProviderGitLab = "gitlab"
+)
+
+// Providers lists the valid values of Source.Provider.
+var Providers = []string{ProviderGitHub, ProviderGitLab}
+
+// DefaultGitLabTokenEnv is the environment variable that the GitLab provider
+// reads the token from when Source.TokenEnv is not set.
+const DefaultGitLabTokenEnv = "GITLAB_OIDC_TOKEN"
+
+const (
+	githubRequestURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// Source describes where to obtain an ID token from.
+type Source struct {
+	// Provider is the CI system that issues the token, one of Providers.
+	Provider string
+
+	// Audience is the audience to request the token for. GitLab sets the
+	// audience of its tokens in the job definition instead, so Audience is
+	// ignored for that provider.
+	Audience string
+
+	// TokenEnv overrides the environment variable that the GitLab provider
+	// reads the token from. It is ignored for other providers.
+	TokenEnv string
+
+	// HTTPClient is the client used to request tokens. If it is nil, a client
+	// from package httpclient is used.
+	HTTPClient *http.Client
+}
+
+// ValidateProvider returns an error if the given name is not one of
+// Providers.
+func ValidateProvider(name string) error {
+	for _, p := range Providers {
+		if name == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported OIDC provider %q; must be one of %q", name, Providers)
+}
+
+// Token returns an ID token from the source.
+func (s Source) Token(ctx context.Context) (string, error) {
+	switch s.Provider {
+	case ProviderGitHub:
+		return s.githubToken(ctx)
+	case ProviderGitLab:
+		return s.gitlabToken()
+	default:
+		return "", ValidateProvider(s.Provider)
+	}
+}
+
+func (s Source) gitlabToken() (string, error) {
+	env := s.TokenEnv
+	if env == "" {
+		env = DefaultGitLabTokenEnv
+	}
+	token := strings.TrimSpace(os.Getenv(env))
+	if token == "" {
+		return "", fmt.Errorf("the environment variable %s is not set; declare it in the id_tokens section of the GitLab CI/CD job", env)
+	}
+	return token, nil
+}
+
+func (s Source) githubToken(ctx context.Context) (string, error) {
+	requestURL := os.Getenv(githubRequestURLEnv)
+	requestToken := os.Getenv(githubRequestTokenEnv)
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("the environment variables %s and %s are not set; the GitHub Actions job needs the \"id-token: write\" permission", githubRequestURLEnv, githubRequestTokenEnv)
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", githubRequestURLEnv, err)
+	}
+	if s.Audience != "" {
+		q := u.Query()
+		q.Set("audience", s.Audience)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	client := s.HTTPClient
+	if client == nil {
+		client = httpclient.New()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request a GitHub Actions ID token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the GitHub Actions ID token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request a GitHub Actions ID token: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("invalid GitHub Actions ID token response: %w", err)
+	}
+	if result.Value == "" {
+		return "", fmt.Errorf("the GitHub Actions ID token response contains no token")
+	}
+	return result.Value, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSourceToken_github(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer request-token"; got != want {
+			t.Errorf("wrong Authorization header %q; want %q", got, want)
+		}
+		if got, want := r.URL.Query().Get("api-version"), "2.0"; got != want {
+			t.Errorf("wrong api-version %q; want %q", got, want)
+		}
+		if got, want := r.URL.Query().Get("audience"), "sts.amazonaws.com"; got != want {
+			t.Errorf("wrong audience %q; want %q", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"value":"id-token"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv(githubRequestURLEnv, srv.URL+"/token?api-version=2.0")
+	t.Setenv(githubRequestTokenEnv, "request-token")
+
+	src := Source{Provider: ProviderGitHub, Audience: "sts.amazonaws.com", HTTPClient: srv.Client()}
+	got, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "id-token" {
+		t.Errorf("wrong token %q; want %q", got, "id-token")
+	}
+}
+
+func TestSourceToken_githubErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "permission denied", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	src := Source{Provider: ProviderGitHub, HTTPClient: srv.Client()}
+
+	t.Run("not configured", func(t *testing.T) {
+		t.Setenv(githubRequestURLEnv, "")
+		t.Setenv(githubRequestTokenEnv, "")
+		_, err := src.Token(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "id-token: write") {
+			t.Errorf("wrong error: %v", err)
+		}
+	})
+	t.Run("request failed", func(t *testing.T) {
+		t.Setenv(githubRequestURLEnv, srv.URL)
+		t.Setenv(githubRequestTokenEnv, "request-token")
+		_, err := src.Token(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "permission denied") {
+			t.Errorf("wrong error: %v", err)
+		}
+	})
+}
+
+func TestSourceToken_gitlab(t *testing.T) {
+	t.Setenv(DefaultGitLabTokenEnv, "default-token\n")
+	t.Setenv("CUSTOM_ID_TOKEN", "custom-token")
+
+	tests := map[string]struct {
+		tokenEnv string
+		want     string
+		wantErr  string
+	}{
+		"default variable": {
+			want: "default-token",
+		},
+		"custom variable": {
+			tokenEnv: "CUSTOM_ID_TOKEN",
+			want:     "custom-token",
+		},
+		"unset variable": {
+			tokenEnv: "MISSING_ID_TOKEN",
+			wantErr:  "MISSING_ID_TOKEN is not set",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src := Source{Provider: ProviderGitLab, TokenEnv: test.tokenEnv}
+			got, err := src.Token(context.Background())
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("wrong error %v; want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("wrong token %q; want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateProvider(t *testing.T) {
+	for _, name := range Providers {
+		if err := ValidateProvider(name); err != nil {
+			t.Errorf("unexpected error for %q: %s", name, err)
+		}
+	}
+	if err := ValidateProvider("jenkins"); err == nil {
+		t.Error("expected an error for an unsupported provider")
+	}
+}
@@ -5,6 +5,8 @@
 
 package backend
 
+import "fmt"
+
 //go:generate go run golang.org/x/tools/cmd/stringer -type=OperationType operation_type.go
 
 // OperationType is an enum used with Operation to specify the operation
@@ -17,3 +19,16 @@ const (
 	OperationTypePlan
 	OperationTypeApply
 )
+
+// CheckReadOnly returns an error wrapping ErrReadOnly if operations of this
+// type are not allowed in read-only mode, or nil otherwise.
+func (t OperationType) CheckReadOnly() error {
+	switch t {
+	case OperationTypeRefresh:
+		return fmt.Errorf("refresh operations are %w", ErrReadOnly)
+	case OperationTypeApply:
+		return fmt.Errorf("apply operations are %w", ErrReadOnly)
+	default:
+		return nil
+	}
+}
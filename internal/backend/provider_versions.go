@@ -0,0 +1,43 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+// RecordProviderVersions updates the provider versions recorded in the given
+// state to the versions selected in the given dependency locks, so that a
+// later run with an older version of one of these providers can report
+// which version the state requires.
+//
+// Only the providers that the state still refers to are recorded. A provider
+// that has no lock, such as a built-in provider, keeps any version that was
+// recorded for it before.
+func RecordProviderVersions(state *states.State, locks *depsfile.Locks) {
+	if state == nil {
+		return
+	}
+
+	versions := make(map[addrs.Provider]getproviders.Version)
+	for _, configAddr := range state.ProviderAddrs() {
+		addr := configAddr.Provider
+		if locks != nil {
+			if lock := locks.Provider(addr); lock != nil {
+				versions[addr] = lock.Version()
+				continue
+			}
+		}
+		if v, ok := state.ProviderVersions[addr]; ok {
+			versions[addr] = v
+		}
+	}
+	if len(versions) == 0 {
+		versions = nil
+	}
+	state.ProviderVersions = versions
+}
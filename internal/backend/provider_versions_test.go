@@ -0,0 +1,67 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestRecordProviderVersions(t *testing.T) {
+	locked := addrs.NewDefaultProvider("locked")
+	unlocked := addrs.NewDefaultProvider("unlocked")
+	removed := addrs.NewDefaultProvider("removed")
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, provider := range []addrs.Provider{locked, unlocked} {
+			s.SetResourceInstanceCurrent(
+				addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: provider.Type + "_thing",
+					Name: "foo",
+				}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON: []byte(`{}`),
+					Status:    states.ObjectReady,
+				},
+				addrs.AbsProviderConfig{
+					Provider: provider,
+					Module:   addrs.RootModule,
+				},
+				addrs.NoKey,
+			)
+		}
+	})
+	state.ProviderVersions = map[addrs.Provider]getproviders.Version{
+		locked:   getproviders.MustParseVersion("1.0.0"),
+		unlocked: getproviders.MustParseVersion("2.0.0"),
+		removed:  getproviders.MustParseVersion("3.0.0"),
+	}
+
+	locks := depsfile.NewLocks()
+	locks.SetProvider(locked, getproviders.MustParseVersion("1.5.0"), nil, nil)
+	RecordProviderVersions(state, locks)
+
+	want := map[addrs.Provider]getproviders.Version{
+		locked:   getproviders.MustParseVersion("1.5.0"),
+		unlocked: getproviders.MustParseVersion("2.0.0"),
+	}
+	if diff := cmp.Diff(want, state.ProviderVersions); diff != "" {
+		t.Errorf("wrong provider versions\n%s", diff)
+	}
+
+	// Without any locks or previously-recorded versions there is nothing
+	// to record.
+	state.ProviderVersions = nil
+	RecordProviderVersions(state, nil)
+	if state.ProviderVersions != nil {
+		t.Errorf("unexpected provider versions %#v", state.ProviderVersions)
+	}
+}
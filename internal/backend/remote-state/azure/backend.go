@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backend/oidc"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
 )
@@ -188,6 +189,30 @@ func New(enc encryption.StateEncryption) backend.Backend {
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ARM_OIDC_REQUEST_TOKEN", "ACTIONS_ID_TOKEN_REQUEST_TOKEN"}, ""),
 				Description: "The bearer token to use for the request to the OIDC providers `oidc_request_url` URL to fetch an ID token. Needs to be used in conjunction with `oidc_request_url`. This is meant to be used for Github Actions.",
 			},
+			"oidc_provider": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, _ string) ([]string, []error) {
+					if err := oidc.ValidateProvider(v.(string)); err != nil {
+						return nil, []error{err}
+					}
+					return nil, nil
+				},
+				ConflictsWith: []string{"oidc_token", "oidc_token_file_path"},
+				Description:   "The CI system to obtain an ID token from for OIDC authentication, either `github` or `gitlab`. Implies `use_oidc`.",
+			},
+			"oidc_audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "api://AzureADTokenExchange",
+				Description: "The audience to request the ID token for, when `oidc_provider` is `github`.",
+			},
+			"oidc_token_env": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The environment variable that contains the ID token, when `oidc_provider` is `gitlab`. Defaults to `GITLAB_OIDC_TOKEN`.",
+			},
 
 			// Feature Flags
 			"use_azuread_auth": {
@@ -282,6 +307,23 @@ func (b *Backend) configure(ctx context.Context) error {
 		UseAzureADAuthentication:      data.Get("use_azuread_auth").(bool),
 	}
 
+	if provider, ok := data.GetOk("oidc_provider"); ok {
+		src := oidc.Source{
+			Provider: provider.(string),
+			Audience: data.Get("oidc_audience").(string),
+			TokenEnv: data.Get("oidc_token_env").(string),
+		}
+		token, err := src.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to obtain an OIDC token: %w", err)
+		}
+		config.OIDCToken = token
+		config.OIDCTokenFilePath = ""
+		config.OIDCRequestURL = ""
+		config.OIDCRequestToken = ""
+		config.UseOIDC = true
+	}
+
 	armClient, err := buildArmClient(context.TODO(), config)
 	if err != nil {
 		return err
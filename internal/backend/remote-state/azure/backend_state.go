@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -99,7 +100,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 		timeoutSeconds:     b.armClient.timeoutSeconds,
 	}
 
-	stateMgr := remote.NewState(client, b.encryption)
+	stateMgr := remote.NewState(client, encryption.StateForWorkspace(b.encryption, name))
 
 	// Grab the value
 	if err := stateMgr.RefreshState(); err != nil {
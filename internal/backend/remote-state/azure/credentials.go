@@ -0,0 +1,61 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package azure
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+)
+
+var _ backend.CredentialsDescriber = (*Backend)(nil)
+
+// DescribeCredentials implements backend.CredentialsDescriber, following the
+// same order of precedence as buildArmClient.
+func (b *Backend) DescribeCredentials(config cty.Value) string {
+	switch {
+	case configString(config, "access_key", "ARM_ACCESS_KEY") != "":
+		return "storage account access key"
+	case configString(config, "sas_token", "ARM_SAS_TOKEN") != "":
+		return "SAS token"
+	case configString(config, "oidc_provider", "") != "":
+		return fmt.Sprintf("%s OIDC token for service principal %s", configString(config, "oidc_provider", ""), configString(config, "client_id", "ARM_CLIENT_ID"))
+	case configBool(config, "use_oidc", "ARM_USE_OIDC"):
+		return fmt.Sprintf("OIDC token for service principal %s", configString(config, "client_id", "ARM_CLIENT_ID"))
+	case configString(config, "client_certificate_path", "ARM_CLIENT_CERTIFICATE_PATH") != "":
+		return fmt.Sprintf("client certificate for service principal %s", configString(config, "client_id", "ARM_CLIENT_ID"))
+	case configString(config, "client_secret", "ARM_CLIENT_SECRET") != "":
+		return fmt.Sprintf("client secret for service principal %s", configString(config, "client_id", "ARM_CLIENT_ID"))
+	case configBool(config, "use_msi", "ARM_USE_MSI"):
+		return "managed service identity"
+	default:
+		return "Azure CLI"
+	}
+}
+
+// configString returns the value of the given string attribute, or of the
+// environment variable that is its default when it is not set.
+func configString(config cty.Value, name, envvar string) string {
+	if val := config.GetAttr(name); !val.IsNull() {
+		return val.AsString()
+	}
+	if envvar == "" {
+		return ""
+	}
+	return os.Getenv(envvar)
+}
+
+// configBool returns the value of the given bool attribute, or of the
+// environment variable that is its default when it is not set.
+func configBool(config cty.Value, name, envvar string) bool {
+	if val := config.GetAttr(name); !val.IsNull() {
+		return val.True()
+	}
+	v, _ := strconv.ParseBool(os.Getenv(envvar))
+	return v
+}
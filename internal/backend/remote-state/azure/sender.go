@@ -11,13 +11,14 @@ import (
 	"net/http/httputil"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/logging"
 )
 
 func buildSender() autorest.Sender {
 	return autorest.DecorateSender(&http.Client{
 		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
+			Proxy: httpclient.Proxy,
 		},
 	}, withRequestLogging())
 }
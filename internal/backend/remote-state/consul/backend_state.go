@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -83,7 +84,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 			GZip:      gzip,
 			lockState: b.lock,
 		},
-		b.encryption,
+		encryption.StateForWorkspace(b.encryption, name),
 	)
 
 	if !b.lock {
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -85,7 +86,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 	if err != nil {
 		return nil, err
 	}
-	stateMgr := remote.NewState(c, b.encryption)
+	stateMgr := remote.NewState(c, encryption.StateForWorkspace(b.encryption, name))
 
 	ws, err := b.Workspaces()
 	if err != nil {
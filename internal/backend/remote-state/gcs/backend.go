@@ -16,6 +16,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backend/oidc"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
@@ -75,6 +76,46 @@ func New(enc encryption.StateEncryption) backend.Backend {
 				Description: "An OAuth2 token used for GCP authentication",
 			},
 
+			"workload_identity_federation": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"access_token", "credentials"},
+				Description:   "Exchange an OIDC token issued by a CI system for credentials, using a workload identity pool provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"workload_identity_provider": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The full resource name of the workload identity pool provider, in the form projects/NUMBER/locations/global/workloadIdentityPools/POOL/providers/PROVIDER",
+						},
+						"oidc_provider": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, _ string) ([]string, []error) {
+								if err := oidc.ValidateProvider(v.(string)); err != nil {
+									return nil, []error{err}
+								}
+								return nil, nil
+							},
+							Description: "The CI system that issues the OIDC token: github or gitlab",
+						},
+						"oidc_audience": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The audience to request the OIDC token for. Defaults to the URL of the workload identity pool provider",
+						},
+						"oidc_token_env": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The environment variable that contains the OIDC token, for the gitlab OIDC provider",
+						},
+					},
+				},
+			},
+
 			"impersonate_service_account": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -152,7 +193,9 @@ func (b *Backend) configure(ctx context.Context) error {
 	var creds string
 	var tokenSource oauth2.TokenSource
 
-	if v, ok := data.GetOk("access_token"); ok {
+	if v, ok := data.GetOk("workload_identity_federation"); ok && len(v.([]interface{})) > 0 {
+		tokenSource = newWorkloadIdentityTokenSource(ctx, v.([]interface{})[0].(map[string]interface{}))
+	} else if v, ok := data.GetOk("access_token"); ok {
 		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{
 			AccessToken: v.(string),
 		})
@@ -0,0 +1,60 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package gcs
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+)
+
+var _ backend.CredentialsDescriber = (*Backend)(nil)
+
+// DescribeCredentials implements backend.CredentialsDescriber, following the
+// same order of precedence as configure.
+func (b *Backend) DescribeCredentials(config cty.Value) string {
+	var desc string
+	if wif := config.GetAttr("workload_identity_federation"); !wif.IsNull() && wif.LengthInt() > 0 {
+		block := wif.Index(cty.NumberIntVal(0))
+		desc = fmt.Sprintf("%s OIDC token, exchanged through workload identity pool provider %s",
+			configString(block, "oidc_provider"), configString(block, "workload_identity_provider"))
+	} else if configString(config, "access_token") != "" {
+		desc = "access token from the backend configuration"
+	} else if os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN") != "" {
+		desc = "access token from the GOOGLE_OAUTH_ACCESS_TOKEN environment variable"
+	} else if configString(config, "credentials") != "" {
+		desc = "credentials from the backend configuration"
+	} else if os.Getenv("GOOGLE_BACKEND_CREDENTIALS") != "" {
+		desc = "credentials from the GOOGLE_BACKEND_CREDENTIALS environment variable"
+	} else if os.Getenv("GOOGLE_CREDENTIALS") != "" {
+		desc = "credentials from the GOOGLE_CREDENTIALS environment variable"
+	} else {
+		desc = "Application Default Credentials"
+	}
+
+	account := configString(config, "impersonate_service_account")
+	for _, env := range []string{"GOOGLE_BACKEND_IMPERSONATE_SERVICE_ACCOUNT", "GOOGLE_IMPERSONATE_SERVICE_ACCOUNT"} {
+		if account == "" {
+			account = os.Getenv(env)
+		}
+	}
+	if account != "" {
+		desc += fmt.Sprintf(", impersonating service account %s", account)
+	}
+	return desc
+}
+
+func configString(obj cty.Value, name string) string {
+	if obj.IsNull() || !obj.Type().HasAttribute(name) {
+		return ""
+	}
+	val := obj.GetAttr(name)
+	if val.IsNull() || !val.IsKnown() {
+		return ""
+	}
+	return val.AsString()
+}
@@ -0,0 +1,88 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package gcs
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
+)
+
+func TestBackendDescribeCredentials(t *testing.T) {
+	for _, env := range []string{
+		"GOOGLE_OAUTH_ACCESS_TOKEN",
+		"GOOGLE_BACKEND_CREDENTIALS",
+		"GOOGLE_CREDENTIALS",
+		"GOOGLE_BACKEND_IMPERSONATE_SERVICE_ACCOUNT",
+		"GOOGLE_IMPERSONATE_SERVICE_ACCOUNT",
+	} {
+		t.Setenv(env, "")
+	}
+
+	b := New(encryption.StateEncryptionDisabled()).(*Backend)
+	schema := b.ConfigSchema()
+
+	tests := map[string]struct {
+		attrs map[string]cty.Value
+		env   map[string]string
+		want  string
+	}{
+		"default": {
+			want: "Application Default Credentials",
+		},
+		"credentials from the environment": {
+			env:  map[string]string{"GOOGLE_CREDENTIALS": "{}"},
+			want: "credentials from the GOOGLE_CREDENTIALS environment variable",
+		},
+		"access token and impersonation": {
+			attrs: map[string]cty.Value{
+				"access_token":                cty.StringVal("token"),
+				"impersonate_service_account": cty.StringVal("tofu@example.iam.gserviceaccount.com"),
+			},
+			want: "access token from the backend configuration, impersonating service account tofu@example.iam.gserviceaccount.com",
+		},
+		"workload identity federation": {
+			attrs: map[string]cty.Value{
+				"workload_identity_federation": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"workload_identity_provider": cty.StringVal("projects/123/locations/global/workloadIdentityPools/ci/providers/github"),
+						"oidc_provider":              cty.StringVal("github"),
+						"oidc_audience":              cty.NullVal(cty.String),
+						"oidc_token_env":             cty.NullVal(cty.String),
+					}),
+				}),
+			},
+			want: "github OIDC token, exchanged through workload identity pool provider projects/123/locations/global/workloadIdentityPools/ci/providers/github",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			got := b.DescribeCredentials(configValue(t, schema, test.attrs))
+			if got != test.want {
+				t.Errorf("wrong description\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
+
+// configValue returns a value conforming to the given schema with the given
+// attributes set and all others null.
+func configValue(t *testing.T, schema *configschema.Block, attrs map[string]cty.Value) cty.Value {
+	t.Helper()
+	vals := make(map[string]cty.Value)
+	for name, ty := range schema.ImpliedType().AttributeTypes() {
+		if val, ok := attrs[name]; ok {
+			vals[name] = val
+		} else {
+			vals[name] = cty.NullVal(ty)
+		}
+	}
+	return cty.ObjectVal(vals)
+}
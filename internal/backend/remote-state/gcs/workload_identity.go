@@ -0,0 +1,121 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/opentofu/opentofu/internal/backend/oidc"
+	"github.com/opentofu/opentofu/internal/httpclient"
+)
+
+// stsTokenURL is the Google Security Token Service endpoint that exchanges
+// an ID token for a federated access token. Tests replace it with the URL of
+// a fake service.
+var stsTokenURL = "https://sts.googleapis.com/v1/token"
+
+// cloudPlatformScope is the scope of the federated access token. It must be
+// broader than the storage scopes so that the token can also be used to
+// impersonate a service account.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// workloadIdentityTokenSource is an oauth2.TokenSource that exchanges an ID
+// token issued by a CI system for a federated access token, using a Google
+// Cloud workload identity pool provider.
+type workloadIdentityTokenSource struct {
+	ctx    context.Context
+	source oidc.Source
+
+	// provider is the full resource name of the workload identity pool
+	// provider, starting with "projects/".
+	provider string
+
+	client *http.Client
+}
+
+// newWorkloadIdentityTokenSource returns a token source for the given
+// "workload_identity_federation" block.
+func newWorkloadIdentityTokenSource(ctx context.Context, block map[string]interface{}) oauth2.TokenSource {
+	provider := strings.TrimPrefix(block["workload_identity_provider"].(string), "//iam.googleapis.com/")
+	audience := block["oidc_audience"].(string)
+	if audience == "" {
+		audience = "https://iam.googleapis.com/" + provider
+	}
+	ts := &workloadIdentityTokenSource{
+		ctx: ctx,
+		source: oidc.Source{
+			Provider: block["oidc_provider"].(string),
+			Audience: audience,
+			TokenEnv: block["oidc_token_env"].(string),
+		},
+		provider: provider,
+		client:   httpclient.New(),
+	}
+	return oauth2.ReuseTokenSource(nil, ts)
+}
+
+func (ts *workloadIdentityTokenSource) Token() (*oauth2.Token, error) {
+	idToken, err := ts.source.Token(ts.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain an OIDC token for workload identity federation: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"audience":             {"//iam.googleapis.com/" + ts.provider},
+		"scope":                {cloudPlatformScope},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"subject_token_type":   {"urn:ietf:params:oauth:token-type:jwt"},
+		"subject_token":        {idToken},
+	}
+	req, err := http.NewRequestWithContext(ts.ctx, http.MethodPost, stsTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange the OIDC token with the Google Security Token Service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Google Security Token Service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to exchange the OIDC token with the Google Security Token Service: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invalid Google Security Token Service response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("the Google Security Token Service response contains no access token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken: result.AccessToken,
+		TokenType:   result.TokenType,
+	}
+	if result.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package gcs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/backend/oidc"
+)
+
+func TestWorkloadIdentityTokenSource(t *testing.T) {
+	const provider = "projects/123/locations/global/workloadIdentityPools/ci/providers/gitlab"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"grant_type":         "urn:ietf:params:oauth:grant-type:token-exchange",
+			"audience":           "//iam.googleapis.com/" + provider,
+			"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+			"subject_token":      "id-token",
+		}
+		for name, value := range want {
+			if got := r.PostForm.Get(name); got != value {
+				t.Errorf("wrong %s %q; want %q", name, got, value)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"federated-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	oldURL := stsTokenURL
+	stsTokenURL = srv.URL
+	defer func() { stsTokenURL = oldURL }()
+
+	t.Setenv(oidc.DefaultGitLabTokenEnv, "id-token")
+
+	ts := newWorkloadIdentityTokenSource(context.Background(), map[string]interface{}{
+		"workload_identity_provider": "//iam.googleapis.com/" + provider,
+		"oidc_provider":              oidc.ProviderGitLab,
+		"oidc_audience":              "",
+		"oidc_token_env":             "",
+	})
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "federated-token" {
+		t.Errorf("wrong access token %q", token.AccessToken)
+	}
+	if token.Expiry.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("wrong expiry %s", token.Expiry)
+	}
+}
+
+func TestWorkloadIdentityTokenSource_oidcError(t *testing.T) {
+	t.Setenv("MISSING_ID_TOKEN", "")
+
+	ts := newWorkloadIdentityTokenSource(context.Background(), map[string]interface{}{
+		"workload_identity_provider": "projects/123/locations/global/workloadIdentityPools/ci/providers/gitlab",
+		"oidc_provider":              oidc.ProviderGitLab,
+		"oidc_audience":              "",
+		"oidc_token_env":             "MISSING_ID_TOKEN",
+	})
+	if _, err := ts.Token(); err == nil {
+		t.Fatal("expected an error")
+	}
+}
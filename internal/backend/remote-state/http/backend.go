@@ -22,6 +22,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/states/remote"
@@ -276,6 +277,7 @@ func (b *Backend) configure(ctx context.Context) error {
 	}
 
 	rClient := retryablehttp.NewClient()
+	rClient.HTTPClient.Transport = httpclient.NewTransport()
 	rClient.RetryMax = data.Get("retry_max").(int)
 	rClient.RetryWaitMin = time.Duration(data.Get("retry_wait_min").(int)) * time.Second
 	rClient.RetryWaitMax = time.Duration(data.Get("retry_wait_max").(int)) * time.Second
@@ -308,7 +310,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 		return nil, backend.ErrWorkspacesNotSupported
 	}
 
-	return remote.NewState(b.client, b.encryption), nil
+	return remote.NewState(b.client, encryption.StateForWorkspace(b.encryption, name)), nil
 }
 
 func (b *Backend) Workspaces() ([]string, error) {
@@ -7,6 +7,7 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/json"
@@ -15,6 +16,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/opentofu/opentofu/internal/states/remote"
@@ -41,9 +43,21 @@ type httpClient struct {
 
 	lockID       string
 	jsonLockInfo []byte
+
+	// gzipUploads is set when the server has indicated, using the
+	// Accept-Encoding response header as described in RFC 7694, that it
+	// accepts gzip-compressed request bodies.
+	gzipUploads bool
 }
 
 func (c *httpClient) httpRequest(method string, url *url.URL, data []byte, what string) (*http.Response, error) {
+	return c.httpRequestWithEncoding(method, url, data, "", what)
+}
+
+// httpRequestWithEncoding is like httpRequest, but additionally sets the
+// Content-Encoding header to the given value when it is not empty. data must
+// already be encoded accordingly.
+func (c *httpClient) httpRequestWithEncoding(method string, url *url.URL, data []byte, contentEncoding string, what string) (*http.Response, error) {
 	var body interface{}
 	if len(data) > 0 {
 		body = data
@@ -69,6 +83,9 @@ func (c *httpClient) httpRequest(method string, url *url.URL, data []byte, what
 	// Work with data/body
 	if len(data) > 0 {
 		req.Header.Set("Content-Type", "application/json")
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
 
 		// Generate the MD5
 		hash := md5.Sum(data)
@@ -166,6 +183,8 @@ func (c *httpClient) Get() (*remote.Payload, error) {
 	}
 	defer resp.Body.Close()
 
+	c.gzipUploads = acceptsGzip(resp.Header)
+
 	// Handle the common status codes
 	switch resp.StatusCode {
 	case http.StatusOK:
@@ -245,7 +264,30 @@ func (c *httpClient) Put(data []byte) error {
 	if c.UpdateMethod != "" {
 		method = c.UpdateMethod
 	}
-	resp, err := c.httpRequest(method, &base, data, "upload state")
+	var resp *http.Response
+	var err error
+	if c.gzipUploads {
+		var compressed bytes.Buffer
+		zw := gzip.NewWriter(&compressed)
+		if _, err := zw.Write(data); err != nil {
+			return fmt.Errorf("Failed to compress state: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("Failed to compress state: %w", err)
+		}
+		resp, err = c.httpRequestWithEncoding(method, &base, compressed.Bytes(), "gzip", "upload state")
+		if err == nil && resp.StatusCode == http.StatusUnsupportedMediaType {
+			// The server no longer accepts compressed uploads, so we'll
+			// retry without compression. RFC 7694 calls for this status code
+			// in that situation.
+			log.Printf("[DEBUG] UPLOAD STATE, server rejected gzip-compressed state; retrying without compression")
+			resp.Body.Close()
+			c.gzipUploads = false
+			resp, err = c.httpRequest(method, &base, data, "upload state")
+		}
+	} else {
+		resp, err = c.httpRequest(method, &base, data, "upload state")
+	}
 	if err != nil {
 		return err
 	}
@@ -254,6 +296,7 @@ func (c *httpClient) Put(data []byte) error {
 	// Handle the error codes
 	switch resp.StatusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		c.gzipUploads = c.gzipUploads || acceptsGzip(resp.Header)
 		return nil
 	default:
 		log.Printf("[DEBUG] UPLOAD STATE, %d: %s", resp.StatusCode, parseResponseBodyForLog(resp))
@@ -282,3 +325,22 @@ func (c *httpClient) Delete() error {
 func (c *httpClient) IsLockingEnabled() bool {
 	return c.UnlockURL != nil
 }
+
+// acceptsGzip returns true if the given response headers include an
+// Accept-Encoding header that allows gzip-compressed request bodies.
+func acceptsGzip(header http.Header) bool {
+	for _, value := range header.Values("Accept-Encoding") {
+		for _, coding := range strings.Split(value, ",") {
+			coding, params, _ := strings.Cut(strings.TrimSpace(coding), ";")
+			if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+				continue
+			}
+			// A quality value of zero means "not acceptable".
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok && strings.Trim(q, "0.") == "" {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
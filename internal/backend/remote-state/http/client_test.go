@@ -7,6 +7,7 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"net/http"
@@ -357,3 +358,77 @@ func TestHttpClient_lock(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpClient_gzipUploads(t *testing.T) {
+	var stored []byte
+	var gotEncodings []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Accept-Encoding", "gzip")
+		switch r.Method {
+		case http.MethodGet:
+			if stored == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write(stored)
+		case http.MethodPost:
+			gotEncodings = append(gotEncodings, r.Header.Get("Content-Encoding"))
+			var body io.Reader = r.Body
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				zr, err := gzip.NewReader(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				body = zr
+			}
+			stored, _ = io.ReadAll(body)
+		}
+	}))
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	client := &httpClient{URL: url, Client: retryablehttp.NewClient()}
+
+	// The first upload happens before the client has learned that the server
+	// accepts compressed bodies.
+	if err := client.Put([]byte(`{"version":4}`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := client.Put([]byte(`{"version":4,"serial":2}`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	payload, err := client.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := string(payload.Data), `{"version":4,"serial":2}`; got != want {
+		t.Errorf("wrong stored state %q; want %q", got, want)
+	}
+	if want := []string{"", "gzip"}; !reflect.DeepEqual(gotEncodings, want) {
+		t.Errorf("wrong content encodings %q; want %q", gotEncodings, want)
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := map[string]bool{
+		"":                  false,
+		"gzip":              true,
+		"GZIP":              true,
+		"br, gzip;q=0.5":    true,
+		"gzip;q=0":          false,
+		"identity, deflate": false,
+	}
+	for value, want := range tests {
+		header := http.Header{}
+		if value != "" {
+			header.Set("Accept-Encoding", value)
+		}
+		if got := acceptsGzip(header); got != want {
+			t.Errorf("acceptsGzip(%q) = %t; want %t", value, got, want)
+		}
+	}
+}
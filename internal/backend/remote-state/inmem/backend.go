@@ -76,7 +76,7 @@ func (b *Backend) configure(ctx context.Context) error {
 		Name: backend.DefaultStateName,
 	}
 
-	states.m[backend.DefaultStateName] = remote.NewState(defaultClient, b.encryption)
+	states.m[backend.DefaultStateName] = remote.NewState(defaultClient, encryption.StateForWorkspace(b.encryption, backend.DefaultStateName))
 
 	// set the default client lock info per the test config
 	data := schema.FromContextBackendConfig(ctx)
@@ -128,7 +128,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 			&RemoteClient{
 				Name: name,
 			},
-			b.encryption,
+			encryption.StateForWorkspace(b.encryption, name),
 		)
 		states.m[name] = s
 
@@ -12,6 +12,7 @@ import (
 	"sort"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -84,7 +85,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 		return nil, err
 	}
 
-	stateMgr := remote.NewState(c, b.encryption)
+	stateMgr := remote.NewState(c, encryption.StateForWorkspace(b.encryption, name))
 
 	// Grab the value
 	if err := stateMgr.RefreshState(); err != nil {
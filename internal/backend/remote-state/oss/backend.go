@@ -495,7 +495,7 @@ func getTransport() *http.Transport {
 	}
 	transport := cleanhttp.DefaultTransport()
 	transport.TLSHandshakeTimeout = time.Duration(handshakeTimeout) * time.Second
-	transport.Proxy = http.ProxyFromEnvironment
+	transport.Proxy = httpclient.Proxy
 	return transport
 }
 
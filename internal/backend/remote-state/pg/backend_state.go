@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -62,7 +63,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 			Name:       name,
 			SchemaName: b.schemaName,
 		},
-		b.encryption,
+		encryption.StateForWorkspace(b.encryption, name),
 	)
 
 	// Check to see if this state already exists.
@@ -24,6 +24,7 @@ import (
 	baselogging "github.com/hashicorp/aws-sdk-go-base/v2/logging"
 	awsbaseValidation "github.com/hashicorp/aws-sdk-go-base/v2/validation"
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backend/oidc"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/httpclient"
@@ -404,6 +405,21 @@ func (b *Backend) ConfigSchema() *configschema.Block {
 							Optional:    true,
 							Description: "The duration, between 15 minutes and 12 hours, of the role session. Valid time units are ns, us (or µs), ms, s, h, or m.",
 						},
+						"oidc_provider": {
+							Type:        cty.String,
+							Optional:    true,
+							Description: "The CI system to obtain an OpenID Connect ID token from, instead of using web_identity_token or web_identity_token_file. Valid values are github and gitlab.",
+						},
+						"oidc_audience": {
+							Type:        cty.String,
+							Optional:    true,
+							Description: "The audience to request the OpenID Connect ID token for, when oidc_provider is github. Defaults to sts.amazonaws.com.",
+						},
+						"oidc_token_env": {
+							Type:        cty.String,
+							Optional:    true,
+							Description: "The environment variable that contains the OpenID Connect ID token, when oidc_provider is gitlab. Defaults to GITLAB_OIDC_TOKEN.",
+						},
 					},
 				},
 			},
@@ -779,6 +795,26 @@ func (b *Backend) Configure(obj cty.Value) tfdiags.Diagnostics {
 
 	if val := obj.GetAttr("assume_role_with_web_identity"); !val.IsNull() {
 		cfg.AssumeRoleWithWebIdentity = configureAssumeRoleWithWebIdentity(val)
+
+		if provider, ok := stringAttrOk(val, "oidc_provider"); ok {
+			src := oidc.Source{
+				Provider: provider,
+				Audience: stringAttrDefault(val, "oidc_audience", "sts.amazonaws.com"),
+				TokenEnv: stringAttr(val, "oidc_token_env"),
+			}
+			token, err := src.Token(ctx)
+			if err != nil {
+				diags = diags.Append(tfdiags.AttributeValue(
+					tfdiags.Error,
+					"Failed to obtain an OIDC token",
+					err.Error(),
+					cty.GetAttrPath("assume_role_with_web_identity").GetAttr("oidc_provider"),
+				))
+				return diags
+			}
+			cfg.AssumeRoleWithWebIdentity.WebIdentityToken = token
+			cfg.AssumeRoleWithWebIdentity.WebIdentityTokenFile = ""
+		}
 	}
 
 	if val, ok := stringSliceAttrDefaultEnvVarOk(obj, "shared_credentials_files", "AWS_SHARED_CREDENTIALS_FILE"); ok {
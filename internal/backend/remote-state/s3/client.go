@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -36,6 +37,18 @@ const (
 	s3EncryptionAlgorithm  = "AES256"
 	stateIDSuffix          = "-md5"
 	s3ErrCodeInternalError = "InternalError"
+
+	// multipartUploadThreshold is the state size above which states are
+	// uploaded using a multipart upload rather than a single request.
+	multipartUploadThreshold = 64 << 20
+
+	// multipartUploadPartSize is the size of each part of a multipart upload,
+	// except the last.
+	multipartUploadPartSize = 16 << 20
+
+	// multipartUploadConcurrency is the number of parts of a multipart upload
+	// that are sent concurrently.
+	multipartUploadConcurrency = 4
 )
 
 type RemoteClient struct {
@@ -190,6 +203,35 @@ func (c *RemoteClient) get(ctx context.Context) (*remote.Payload, error) {
 }
 
 func (c *RemoteClient) Put(data []byte) error {
+	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	// Large states are uploaded in several parts concurrently, which is
+	// considerably faster than a single request. Multipart uploads can't be
+	// combined with customer-provided encryption keys without repeating the
+	// key on every request, so we keep to a single request in that case.
+	var err error
+	if len(data) > multipartUploadThreshold && c.customerEncryptionKey == nil {
+		err = c.putMultipart(ctx, data)
+	} else {
+		err = c.putObject(ctx, data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload state: %w", err)
+	}
+
+	sum := md5.Sum(data)
+	if err := c.putMD5(ctx, sum[:]); err != nil {
+		// if this errors out, we unfortunately have to error out altogether,
+		// since the next Get will inevitably fail.
+		return fmt.Errorf("failed to store state MD5: %w", err)
+
+	}
+
+	return nil
+}
+
+func (c *RemoteClient) putObject(ctx context.Context, data []byte) error {
 	contentType := "application/json"
 	contentLength := int64(len(data))
 
@@ -207,10 +249,7 @@ func (c *RemoteClient) Put(data []byte) error {
 		// There is a conflict in the aws-go-sdk-v2 that prevents it from working with many s3 compatible services
 		// Since we can pre-compute the hash here, we can work around it.
 		// ref: https://github.com/aws/aws-sdk-go-v2/issues/1689
-		algo := sha256.New()
-		algo.Write(data)
-		sum64str := base64.StdEncoding.EncodeToString(algo.Sum(nil))
-		i.ChecksumSHA256 = &sum64str
+		i.ChecksumSHA256 = aws.String(sha256Base64(data))
 	}
 
 	if c.serverSideEncryption {
@@ -232,23 +271,110 @@ func (c *RemoteClient) Put(data []byte) error {
 
 	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
 
-	ctx := context.TODO()
-	ctx, _ = attachLoggerToContext(ctx)
-
 	_, err := c.s3Client.PutObject(ctx, i)
-	if err != nil {
-		return fmt.Errorf("failed to upload state: %w", err)
-	}
+	return err
+}
 
-	sum := md5.Sum(data)
-	if err := c.putMD5(ctx, sum[:]); err != nil {
-		// if this errors out, we unfortunately have to error out altogether,
-		// since the next Get will inevitably fail.
-		return fmt.Errorf("failed to store state MD5: %w", err)
+// putMultipart uploads the given data using an S3 multipart upload, sending
+// up to multipartUploadConcurrency parts at once.
+func (c *RemoteClient) putMultipart(ctx context.Context, data []byte) error {
+	i := &s3.CreateMultipartUploadInput{
+		ContentType: aws.String("application/json"),
+		Bucket:      &c.bucketName,
+		Key:         &c.path,
+	}
+	if !c.skipS3Checksum {
+		i.ChecksumAlgorithm = types.ChecksumAlgorithmSha256
+	}
+	if c.serverSideEncryption {
+		if c.kmsKeyID != "" {
+			i.SSEKMSKeyId = &c.kmsKeyID
+			i.ServerSideEncryption = types.ServerSideEncryptionAwsKms
+		} else {
+			i.ServerSideEncryption = s3EncryptionAlgorithm
+		}
+	}
+	if c.acl != "" {
+		i.ACL = types.ObjectCannedACL(c.acl)
+	}
 
+	log.Printf("[DEBUG] Starting multipart upload of %d bytes of remote state to S3 bucket %q, key %q", len(data), c.bucketName, c.path)
+	out, err := c.s3Client.CreateMultipartUpload(ctx, i)
+	if err != nil {
+		return err
+	}
+	uploadID := out.UploadId
+
+	numParts := (len(data) + multipartUploadPartSize - 1) / multipartUploadPartSize
+	parts := make([]types.CompletedPart, numParts)
+	errs := make([]error, numParts)
+	sem := make(chan struct{}, multipartUploadConcurrency)
+	var wg sync.WaitGroup
+	for n := 0; n < numParts; n++ {
+		start := n * multipartUploadPartSize
+		end := min(start+multipartUploadPartSize, len(data))
+		chunk := data[start:end]
+		partNumber := int32(n + 1)
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(n int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			pi := &s3.UploadPartInput{
+				Bucket:        &c.bucketName,
+				Key:           &c.path,
+				UploadId:      uploadID,
+				PartNumber:    aws.Int32(partNumber),
+				ContentLength: aws.Int64(int64(len(chunk))),
+				Body:          bytes.NewReader(chunk),
+			}
+			if !c.skipS3Checksum {
+				pi.ChecksumAlgorithm = types.ChecksumAlgorithmSha256
+				pi.ChecksumSHA256 = aws.String(sha256Base64(chunk))
+			}
+			log.Printf("[TRACE] Uploading part %d of %d of remote state to S3", partNumber, numParts)
+			pout, err := c.s3Client.UploadPart(ctx, pi)
+			if err != nil {
+				errs[n] = fmt.Errorf("part %d: %w", partNumber, err)
+				return
+			}
+			parts[n] = types.CompletedPart{
+				ETag:           pout.ETag,
+				PartNumber:     aws.Int32(partNumber),
+				ChecksumSHA256: pout.ChecksumSHA256,
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		_, abortErr := c.s3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &c.bucketName,
+			Key:      &c.path,
+			UploadId: uploadID,
+		})
+		if abortErr != nil {
+			log.Printf("[WARN] Failed to abort multipart upload of remote state to S3: %s", abortErr)
+		}
+		return err
 	}
 
-	return nil
+	_, err = c.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   &c.bucketName,
+		Key:      &c.path,
+		UploadId: uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: parts,
+		},
+	})
+	return err
+}
+
+func sha256Base64(data []byte) string {
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
 }
 
 func (c *RemoteClient) Delete() error {
@@ -0,0 +1,52 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package s3
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/backend"
+)
+
+var _ backend.CredentialsDescriber = (*Backend)(nil)
+
+// DescribeCredentials implements backend.CredentialsDescriber. The AWS SDK
+// decides between most of the sources itself, so the description names the
+// source with the highest precedence that is set.
+func (b *Backend) DescribeCredentials(obj cty.Value) string {
+	if val := obj.GetAttr("assume_role_with_web_identity"); !val.IsNull() {
+		var token string
+		switch {
+		case stringAttr(val, "oidc_provider") != "":
+			token = fmt.Sprintf("%s OIDC token", stringAttr(val, "oidc_provider"))
+		case stringAttr(val, "web_identity_token") != "":
+			token = "web identity token from the backend configuration"
+		default:
+			token = fmt.Sprintf("web identity token file %s", stringAttrDefaultEnvVar(val, "web_identity_token_file", "AWS_WEB_IDENTITY_TOKEN_FILE"))
+		}
+		return fmt.Sprintf("%s, exchanged with AssumeRoleWithWebIdentity for role %s", token, stringAttrDefaultEnvVar(val, "role_arn", "AWS_ROLE_ARN"))
+	}
+
+	var desc string
+	switch {
+	case stringAttr(obj, "access_key") != "":
+		desc = "access key from the backend configuration"
+	case stringAttr(obj, "profile") != "":
+		desc = fmt.Sprintf("shared configuration profile %q", stringAttr(obj, "profile"))
+	case os.Getenv("AWS_ACCESS_KEY_ID") != "":
+		desc = "access key from the AWS_ACCESS_KEY_ID environment variable"
+	case os.Getenv("AWS_PROFILE") != "":
+		desc = fmt.Sprintf("shared configuration profile %q from the AWS_PROFILE environment variable", os.Getenv("AWS_PROFILE"))
+	default:
+		desc = "the default AWS credential chain"
+	}
+
+	if val := obj.GetAttr("assume_role"); !val.IsNull() {
+		desc += fmt.Sprintf(", assuming role %s", stringAttr(val, "role_arn"))
+	}
+	return desc
+}
@@ -0,0 +1,67 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package s3
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+)
+
+func TestBackendDescribeCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ROLE_ARN", "")
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "")
+
+	b := New(encryption.StateEncryptionDisabled()).(*Backend)
+
+	tests := map[string]struct {
+		config map[string]cty.Value
+		env    map[string]string
+		want   string
+	}{
+		"default": {
+			config: map[string]cty.Value{},
+			want:   "the default AWS credential chain",
+		},
+		"profile from the environment": {
+			config: map[string]cty.Value{},
+			env:    map[string]string{"AWS_PROFILE": "ci"},
+			want:   `shared configuration profile "ci" from the AWS_PROFILE environment variable`,
+		},
+		"access key and assume role": {
+			config: map[string]cty.Value{
+				"access_key": cty.StringVal("AKIA"),
+				"secret_key": cty.StringVal("secret"),
+				"assume_role": cty.ObjectVal(map[string]cty.Value{
+					"role_arn": cty.StringVal("arn:aws:iam::123456789012:role/tofu"),
+				}),
+			},
+			want: "access key from the backend configuration, assuming role arn:aws:iam::123456789012:role/tofu",
+		},
+		"oidc": {
+			config: map[string]cty.Value{
+				"assume_role_with_web_identity": cty.ObjectVal(map[string]cty.Value{
+					"role_arn":      cty.StringVal("arn:aws:iam::123456789012:role/tofu"),
+					"oidc_provider": cty.StringVal("gitlab"),
+				}),
+			},
+			want: "gitlab OIDC token, exchanged with AssumeRoleWithWebIdentity for role arn:aws:iam::123456789012:role/tofu",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			config := populateSchema(t, b.ConfigSchema(), cty.ObjectVal(test.config))
+			if got := b.DescribeCredentials(config); got != test.want {
+				t.Errorf("wrong description\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
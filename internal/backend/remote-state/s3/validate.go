@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/opentofu/opentofu/internal/backend/oidc"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -111,8 +112,19 @@ func validateAssumeRoleWithWebIdentity(obj cty.Value, objPath cty.Path) tfdiags.
 	validateAttributesConflict(
 		cty.GetAttrPath("web_identity_token"),
 		cty.GetAttrPath("web_identity_token_file"),
+		cty.GetAttrPath("oidc_provider"),
 	)(obj, objPath, &diags)
 
+	if val, ok := stringAttrOk(obj, "oidc_provider"); ok {
+		if err := oidc.ValidateProvider(val); err != nil {
+			diags = diags.Append(attributeErrDiag(
+				"Invalid Value",
+				err.Error(),
+				objPath.GetAttr("oidc_provider"),
+			))
+		}
+	}
+
 	if val, ok := stringAttrOk(obj, "session_name"); ok {
 		validateNonEmptyString(val, objPath.GetAttr("session_name"), &diags)
 	}
@@ -374,3 +374,72 @@ func Test_validateNestedAssumeRole(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateAssumeRoleWithWebIdentity(t *testing.T) {
+	tests := []struct {
+		description   string
+		input         cty.Value
+		expectedDiags []string
+	}{
+		{
+			description: "Valid Input",
+			input: cty.ObjectVal(map[string]cty.Value{
+				"role_arn":           cty.StringVal("valid-role-arn"),
+				"web_identity_token": cty.StringVal("valid-token"),
+				"oidc_provider":      cty.NullVal(cty.String),
+			}),
+			expectedDiags: nil,
+		},
+		{
+			description: "Valid OIDC Provider",
+			input: cty.ObjectVal(map[string]cty.Value{
+				"role_arn":           cty.StringVal("valid-role-arn"),
+				"web_identity_token": cty.NullVal(cty.String),
+				"oidc_provider":      cty.StringVal("github"),
+			}),
+			expectedDiags: nil,
+		},
+		{
+			description: "Invalid OIDC Provider",
+			input: cty.ObjectVal(map[string]cty.Value{
+				"role_arn":           cty.StringVal("valid-role-arn"),
+				"web_identity_token": cty.NullVal(cty.String),
+				"oidc_provider":      cty.StringVal("jenkins"),
+			}),
+			expectedDiags: []string{
+				"unsupported OIDC provider \"jenkins\"; must be one of [\"github\" \"gitlab\"]",
+			},
+		},
+		{
+			description: "Conflicting Token And OIDC Provider",
+			input: cty.ObjectVal(map[string]cty.Value{
+				"role_arn":           cty.StringVal("valid-role-arn"),
+				"web_identity_token": cty.StringVal("valid-token"),
+				"oidc_provider":      cty.StringVal("gitlab"),
+			}),
+			expectedDiags: []string{
+				"Only one of web_identity_token, web_identity_token_file, oidc_provider can be set.",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			input := test.input.AsValueMap()
+			for _, name := range []string{"web_identity_token_file", "session_name", "policy", "duration"} {
+				input[name] = cty.NullVal(cty.String)
+			}
+			input["policy_arns"] = cty.NullVal(cty.Set(cty.String))
+
+			diagnostics := validateAssumeRoleWithWebIdentity(cty.ObjectVal(input), cty.GetAttrPath("assume_role_with_web_identity"))
+			if len(diagnostics) != len(test.expectedDiags) {
+				t.Errorf("Expected %d diagnostics, but got %d", len(test.expectedDiags), len(diagnostics))
+			}
+			for i, diag := range diagnostics {
+				if i < len(test.expectedDiags) && diag.Description().Detail != test.expectedDiags[i] {
+					t.Errorf("Mismatch in diagnostic %d. Expected: %q, Got: %q", i, test.expectedDiags[i], diag.Description().Detail)
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
@@ -97,6 +98,14 @@ type Remote struct {
 	// a warning diagnostic instead of an error.
 	ignoreVersionConflict bool
 
+	// readOnly, if true, disables all operations that could modify state,
+	// workspaces or remote objects. It is set from the CLI options.
+	readOnly bool
+
+	// auditLog, if non-nil, records every new state snapshot persisted by
+	// the state managers returned by StateMgr. It is set from the CLI options.
+	auditLog *auditlog.Log
+
 	encryption encryption.StateEncryption
 }
 
@@ -620,6 +629,9 @@ func (b *Remote) WorkspaceNamePattern() string {
 
 // DeleteWorkspace implements backend.Enhanced.
 func (b *Remote) DeleteWorkspace(name string, _ bool) error {
+	if b.readOnly {
+		return fmt.Errorf("cannot delete workspace %q: %w", name, backend.ErrReadOnly)
+	}
 	if b.workspace == "" && name == backend.DefaultStateName {
 		return backend.ErrDefaultWorkspaceNotSupported
 	}
@@ -656,6 +668,10 @@ func (b *Remote) StateMgr(name string) (statemgr.Full, error) {
 		return nil, backend.ErrWorkspacesNotSupported
 	}
 
+	// The encryption is for the workspace as OpenTofu names it, not the
+	// remote workspace.
+	enc := encryption.StateForWorkspace(b.encryption, name)
+
 	// Configure the remote workspace name.
 	switch {
 	case name == backend.DefaultStateName:
@@ -670,6 +686,10 @@ func (b *Remote) StateMgr(name string) (statemgr.Full, error) {
 	}
 
 	if err == tfe.ErrResourceNotFound {
+		if b.readOnly {
+			return nil, fmt.Errorf("cannot create workspace %s: %w", name, backend.ErrReadOnly)
+		}
+
 		options := tfe.WorkspaceCreateOptions{
 			Name: tfe.String(name),
 		}
@@ -708,10 +728,10 @@ func (b *Remote) StateMgr(name string) (statemgr.Full, error) {
 		// This is optionally set during OpenTofu Enterprise runs.
 		runID: os.Getenv("TFE_RUN_ID"),
 
-		encryption: b.encryption,
+		encryption: enc,
 	}
 
-	state := remote.NewState(client, b.encryption)
+	state := remote.NewState(client, enc)
 	if client.runID != "" {
 		// client.runID will be set if we're running a Terraform Cloud
 		// or Terraform Enterprise remote execution environment, in which
@@ -722,7 +742,10 @@ func (b *Remote) StateMgr(name string) (statemgr.Full, error) {
 		// by this special case.
 		state.DisableIntermediateSnapshots()
 	}
-	return state, nil
+	if b.readOnly {
+		return statemgr.NewReadOnly(state), nil
+	}
+	return backendLocal.AuditStateMgr(b.auditLog, name, state), nil
 }
 
 func isLocalExecutionMode(execMode string) bool {
@@ -759,6 +782,12 @@ func (b *Remote) fetchWorkspace(ctx context.Context, organization string, name s
 
 // Operation implements backend.Enhanced.
 func (b *Remote) Operation(ctx context.Context, op *backend.Operation) (*backend.RunningOperation, error) {
+	if b.readOnly {
+		if err := op.Type.CheckReadOnly(); err != nil {
+			return nil, err
+		}
+	}
+
 	w, err := b.fetchWorkspace(ctx, b.organization, op.Workspace)
 
 	if err != nil {
@@ -136,6 +136,22 @@ func (b *Remote) opPlan(stopCtx, cancelCtx context.Context, op *backend.Operatio
 		))
 	}
 
+	if len(op.TargetProviders) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-target-provider option is not supported",
+			"The -target-provider option is not currently supported for remote plans.",
+		))
+	}
+
+	if op.ReplaceDependents {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-replace-dependents option is not supported",
+			"The -replace-dependents option is not currently supported for remote plans.",
+		))
+	}
+
 	if !op.PlanRefresh {
 		desiredAPIVersion, _ := version.NewVersion("2.4")
 
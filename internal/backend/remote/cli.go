@@ -20,6 +20,8 @@ func (b *Remote) CLIInit(opts *backend.CLIOpts) error {
 	b.CLI = opts.CLI
 	b.CLIColor = opts.CLIColor
 	b.ContextOpts = opts.ContextOpts
+	b.readOnly = opts.ReadOnly
+	b.auditLog = opts.AuditLog
 
 	return nil
 }
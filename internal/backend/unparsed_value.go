@@ -61,11 +61,11 @@ func ParseUndeclaredVariableValues(vv map[string]UnparsedVariableValue, decls ma
 			// Some users will actively ignore this warning because they use a .tfvars file
 			// across multiple configurations.
 			if seenUndeclaredInFile < 2 {
-				diags = diags.Append(tfdiags.Sourceless(
+				diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(
 					tfdiags.Warning,
 					"Value for undeclared variable",
 					fmt.Sprintf("The root module does not declare a variable named %q but a value was found in file %q. If you meant to use this value, add a \"variable\" block to the configuration.\n\nTo silence these warnings, use TF_VAR_... environment variables to provide certain \"global\" settings to all configurations in your organization. To reduce the verbosity of these warnings, use the -compact-warnings option.", name, val.SourceRange.Filename),
-				))
+				), tfdiags.CodeUndeclaredVariableInFile))
 			}
 			seenUndeclaredInFile++
 
@@ -75,19 +75,19 @@ func ParseUndeclaredVariableValues(vv map[string]UnparsedVariableValue, decls ma
 			// when they are used across many (but not necessarily all)
 			// configurations.
 		case tofu.ValueFromCLIArg:
-			diags = diags.Append(tfdiags.Sourceless(
+			diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Value for undeclared variable",
 				fmt.Sprintf("A variable named %q was assigned on the command line, but the root module does not declare a variable of that name. To use this value, add a \"variable\" block to the configuration.", name),
-			))
+			), tfdiags.CodeUndeclaredVariable))
 		default:
 			// For all other source types we are more vague, but other situations
 			// don't generally crop up at this layer in practice.
-			diags = diags.Append(tfdiags.Sourceless(
+			diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Value for undeclared variable",
 				fmt.Sprintf("A variable named %q was assigned a value, but the root module does not declare a variable of that name. To use this value, add a \"variable\" block to the configuration.", name),
-			))
+			), tfdiags.CodeUndeclaredVariable))
 		}
 	}
 
@@ -97,6 +97,7 @@ func ParseUndeclaredVariableValues(vv map[string]UnparsedVariableValue, decls ma
 			Severity: hcl.DiagWarning,
 			Summary:  "Values for undeclared variables",
 			Detail:   fmt.Sprintf("In addition to the other similar warnings shown, %d other variable(s) defined without being declared.", extras),
+			Extra:    tfdiags.CodeUndeclaredVariableInFile,
 		})
 	}
 
@@ -0,0 +1,274 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// ManifestFilename is the name of the manifest entry at the root of
+	// every bundle archive.
+	ManifestFilename = "manifest.json"
+
+	// ProvidersDir is the directory within a bundle containing provider
+	// packages, using the packed filesystem mirror layout.
+	ProvidersDir = "providers"
+
+	// ModulesDir is the directory within a bundle containing installed
+	// module packages, along with the module manifest that describes them.
+	ModulesDir = "modules"
+
+	// BinaryDir is the directory within a bundle containing the OpenTofu
+	// executable, when the bundle was created with one included.
+	BinaryDir = "bin"
+
+	// formatVersion is the version of the bundle layout written by this
+	// package. Bundles with any other version are rejected.
+	formatVersion = 1
+)
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	FormatVersion int `json:"format_version"`
+
+	// OpenTofuVersion is the version of OpenTofu that created the bundle.
+	OpenTofuVersion string `json:"opentofu_version"`
+
+	// Files maps the slash-separated path of each file in the bundle to
+	// its SHA-256 checksum, in the "sha256:" hex format.
+	Files map[string]string `json:"files"`
+}
+
+// Create writes a bundle archive to w containing all of the files in srcDir.
+//
+// srcDir should be laid out as described by the ProvidersDir, ModulesDir and
+// BinaryDir constants. Only regular files and directories are included;
+// symlinks and other special files cause an error.
+func Create(w io.Writer, srcDir string, tofuVersion string) error {
+	manifest := &Manifest{
+		FormatVersion:   formatVersion,
+		OpenTofuVersion: tofuVersion,
+		Files:           make(map[string]string),
+	}
+
+	var names []string
+	err := filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", p)
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if name == ManifestFilename {
+			return fmt.Errorf("%s is reserved for the bundle manifest", name)
+		}
+		sum, err := fileChecksum(p)
+		if err != nil {
+			return err
+		}
+		manifest.Files[name] = sum
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan bundle contents: %w", err)
+	}
+	sort.Strings(names)
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		// Should never happen because the manifest is entirely under our
+		// control.
+		return fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	err = tw.WriteHeader(&tar.Header{
+		Name: ManifestFilename,
+		Mode: 0644,
+		Size: int64(len(manifestJSON)),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifestJSON); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := addFile(tw, filepath.Join(srcDir, filepath.FromSlash(name)), name); err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// Extract unpacks the bundle archive from r into dstDir, which must already
+// exist, and then verifies that the extracted files match the bundle's
+// manifest exactly.
+//
+// If Extract returns an error then the contents of dstDir are unspecified and
+// the caller should discard them.
+func Extract(r io.Reader, dstDir string) (*Manifest, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid bundle archive: %w", err)
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+
+	var manifest *Manifest
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a valid bundle archive: %w", err)
+		}
+
+		name := path.Clean(hdr.Name)
+		if !fs.ValidPath(name) || name == "." {
+			return nil, fmt.Errorf("bundle contains invalid path %q", hdr.Name)
+		}
+
+		if manifest == nil {
+			if name != ManifestFilename {
+				return nil, fmt.Errorf("bundle does not start with %s", ManifestFilename)
+			}
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+			}
+			if manifest.FormatVersion != formatVersion {
+				return nil, fmt.Errorf("unsupported bundle format version %d", manifest.FormatVersion)
+			}
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			// ok
+		case tar.TypeDir:
+			continue
+		default:
+			return nil, fmt.Errorf("bundle entry %q is not a regular file", hdr.Name)
+		}
+		if _, ok := manifest.Files[name]; !ok {
+			return nil, fmt.Errorf("bundle entry %q is not listed in the manifest", name)
+		}
+
+		dst := filepath.Join(dstDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return nil, err
+		}
+		if err := extractFile(tr, dst, fs.FileMode(hdr.Mode).Perm()); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("bundle is empty")
+	}
+
+	if err := Verify(dstDir, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Verify checks that every file listed in the given manifest is present in
+// dir with the expected checksum.
+func Verify(dir string, manifest *Manifest) error {
+	var problems []string
+	for name, want := range manifest.Files {
+		got, err := fileChecksum(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+		if got != want {
+			problems = append(problems, fmt.Sprintf("%s: checksum %s does not match manifest checksum %s", name, got, want))
+		}
+	}
+	if len(problems) != 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("bundle verification failed:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func addFile(tw *tar.Writer, filename, name string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: int64(info.Mode().Perm()),
+		Size: info.Size(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func extractFile(r io.Reader, dst string, mode fs.FileMode) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func fileChecksum(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package bundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateExtract(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"providers/example.com/foo/bar/terraform-provider-bar_1.0.0_linux_amd64.zip": "provider",
+		"modules/modules.json":  `{"Modules":[]}`,
+		"modules/child/main.tf": "# child",
+	}
+	for name, content := range files {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Create(&buf, src, "1.10.0"); err != nil {
+		t.Fatalf("unexpected error creating bundle: %s", err)
+	}
+
+	dst := t.TempDir()
+	manifest, err := Extract(bytes.NewReader(buf.Bytes()), dst)
+	if err != nil {
+		t.Fatalf("unexpected error extracting bundle: %s", err)
+	}
+	if got, want := manifest.OpenTofuVersion, "1.10.0"; got != want {
+		t.Errorf("wrong version %q; want %q", got, want)
+	}
+	if got, want := len(manifest.Files), len(files); got != want {
+		t.Errorf("wrong number of files %d; want %d", got, want)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("failed to read %s: %s", name, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("wrong content for %s: %q; want %q", name, got, want)
+		}
+	}
+}
+
+func TestVerify_mismatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := &Manifest{
+		FormatVersion: formatVersion,
+		Files: map[string]string{
+			"a": "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			"b": "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+	err := Verify(dir, manifest)
+	if err == nil {
+		t.Fatal("succeeded; want error")
+	}
+	for _, want := range []string{"a: checksum", "b: open"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%s", want, err)
+		}
+	}
+}
+
+func TestExtract_notBundle(t *testing.T) {
+	_, err := Extract(strings.NewReader("not a bundle"), t.TempDir())
+	if err == nil {
+		t.Fatal("succeeded; want error")
+	}
+}
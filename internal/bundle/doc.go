@@ -0,0 +1,13 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package bundle implements the archive format used by "tofu bundle" to
+// transfer the external dependencies of a configuration into a network that
+// has no access to the origin provider registries and module sources.
+//
+// A bundle is a gzip-compressed tar archive whose first entry is a manifest
+// recording the SHA-256 checksum of every other file in the archive. The
+// manifest is verified in full when a bundle is extracted, so that a bundle
+// that was truncated or tampered with in transit is rejected before any of
+// its contents are installed.
+package bundle
@@ -27,6 +27,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
 
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/jsonformat"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
@@ -109,6 +110,14 @@ type Cloud struct {
 	// to determine whether or not to ask the user for approval of a run.
 	input bool
 
+	// readOnly, if true, disables all operations that could modify state,
+	// workspaces or remote objects. It is set from the CLI options.
+	readOnly bool
+
+	// auditLog, if non-nil, records every new state snapshot persisted by
+	// the state managers returned by StateMgr. It is set from the CLI options.
+	auditLog *auditlog.Log
+
 	encryption encryption.StateEncryption
 }
 
@@ -620,6 +629,9 @@ func (b *Cloud) Workspaces() ([]string, error) {
 
 // DeleteWorkspace implements backend.Enhanced.
 func (b *Cloud) DeleteWorkspace(name string, force bool) error {
+	if b.readOnly {
+		return fmt.Errorf("cannot delete workspace %q: %w", name, backend.ErrReadOnly)
+	}
 	if name == backend.DefaultStateName {
 		return backend.ErrDefaultWorkspaceNotSupported
 	}
@@ -689,6 +701,10 @@ func (b *Cloud) StateMgr(name string) (statemgr.Full, error) {
 		}
 	}
 
+	if err == tfe.ErrResourceNotFound && b.readOnly {
+		return nil, fmt.Errorf("cannot create workspace %s: %w", name, backend.ErrReadOnly)
+	}
+
 	if err == tfe.ErrResourceNotFound {
 		// Create workspace if it was not found
 
@@ -749,7 +765,7 @@ func (b *Cloud) StateMgr(name string) (statemgr.Full, error) {
 		}
 	}
 
-	if b.workspaceTagsRequireUpdate(workspace, b.WorkspaceMapping) {
+	if !b.readOnly && b.workspaceTagsRequireUpdate(workspace, b.WorkspaceMapping) {
 		options := tfe.WorkspaceAddTagsOptions{
 			Tags: b.WorkspaceMapping.tfeTags(),
 		}
@@ -773,11 +789,21 @@ func (b *Cloud) StateMgr(name string) (statemgr.Full, error) {
 		}
 	}
 
-	return &State{tfeClient: b.client, organization: b.organization, workspace: workspace, enableIntermediateSnapshots: false, encryption: b.encryption}, nil
+	state := &State{tfeClient: b.client, organization: b.organization, workspace: workspace, enableIntermediateSnapshots: false, encryption: encryption.StateForWorkspace(b.encryption, name)}
+	if b.readOnly {
+		return statemgr.NewReadOnly(state), nil
+	}
+	return backendLocal.AuditStateMgr(b.auditLog, name, state), nil
 }
 
 // Operation implements backend.Enhanced.
 func (b *Cloud) Operation(ctx context.Context, op *backend.Operation) (*backend.RunningOperation, error) {
+	if b.readOnly {
+		if err := op.Type.CheckReadOnly(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Retrieve the workspace for this operation.
 	w, err := b.fetchWorkspace(ctx, b.organization, op.Workspace)
 	if err != nil {
@@ -86,6 +86,38 @@ func (b *Cloud) opApply(stopCtx, cancelCtx context.Context, op *backend.Operatio
 		))
 	}
 
+	if len(op.TargetProviders) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-target-provider option is not supported",
+			"The -target-provider option is not currently supported for remote plans.",
+		))
+	}
+
+	if !op.ChangeLimits.Empty() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Change limits are not supported",
+			"The -limit-changes and -limit-destroys options are not currently supported for remote plans.",
+		))
+	}
+
+	if op.MaxRuntime != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-max-runtime option is not supported",
+			"The -max-runtime option is not currently supported for remote plans.",
+		))
+	}
+
+	if op.ReplaceDependents {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-replace-dependents option is not supported",
+			"The -replace-dependents option is not currently supported for remote plans.",
+		))
+	}
+
 	// Return if there are any errors.
 	if diags.HasErrors() {
 		return nil, diags.Err()
@@ -23,6 +23,8 @@ func (b *Cloud) CLIInit(opts *backend.CLIOpts) error {
 	b.ContextOpts = opts.ContextOpts
 	b.runningInAutomation = opts.RunningInAutomation
 	b.input = opts.Input
+	b.readOnly = opts.ReadOnly
+	b.auditLog = opts.AuditLog
 	b.renderer = &jsonformat.Renderer{
 		Streams:  opts.Streams,
 		Colorize: opts.CLIColor,
@@ -87,6 +87,22 @@ func (b *Cloud) opPlan(stopCtx, cancelCtx context.Context, op *backend.Operation
 		))
 	}
 
+	if len(op.TargetProviders) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-target-provider option is not supported",
+			"The -target-provider option is not currently supported for remote plans.",
+		))
+	}
+
+	if op.ReplaceDependents {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-replace-dependents option is not supported",
+			"The -replace-dependents option is not currently supported for remote plans.",
+		))
+	}
+
 	if len(op.GenerateConfigOut) > 0 {
 		diags = diags.Append(genconfig.ValidateTargetFile(op.GenerateConfigOut))
 	}
@@ -51,6 +51,8 @@ func (c *ApplyCommand) Run(rawArgs []string) int {
 	}
 
 	c.View.SetShowSensitive(args.ShowSensitive)
+	c.View.SetExpandCollapsed(args.ExpandCollapsed)
+	c.View.SetProgressTUI(args.TUI)
 
 	// Instantiate the view, even if there are flag errors, so that we render
 	// diagnostics according to the desired view
@@ -134,8 +136,12 @@ func (c *ApplyCommand) Run(rawArgs []string) int {
 	}
 	diags = nil
 
+	opReq.ChangeLimits = c.changeLimits(args)
+	opReq.MaxRuntime = args.MaxRuntime
+
 	// Run the operation
 	op, diags := c.RunOperation(ctx, be, opReq)
+	c.ApplyWebhook.Close()
 	view.Diagnostics(diags)
 	if diags.HasErrors() {
 		return 1
@@ -282,11 +288,17 @@ func (c *ApplyCommand) OperationRequest(
 	opReq.ConfigDir = "."
 	opReq.PlanMode = args.PlanMode
 	opReq.Hooks = view.Hooks()
+	if hook := c.ApplyWebhook.Hook(); hook != nil {
+		opReq.Hooks = append(opReq.Hooks, hook)
+	}
 	opReq.PlanFile = planFile
 	opReq.PlanRefresh = args.Refresh
 	opReq.Targets = args.Targets
 	opReq.Excludes = args.Excludes
+	opReq.TargetProviders = args.TargetProviders
 	opReq.ForceReplace = args.ForceReplace
+	opReq.ReplaceDependents = args.ReplaceDependents
+	opReq.NoInputSummary = args.NoInputSummary
 	opReq.Type = backend.OperationTypeApply
 	opReq.View = view.Operation()
 
@@ -300,6 +312,24 @@ func (c *ApplyCommand) OperationRequest(
 	return opReq, diags
 }
 
+// changeLimits returns the change limits given on the command line, using
+// those in the project file as defaults.
+func (c *ApplyCommand) changeLimits(args *arguments.Apply) backend.ChangeLimits {
+	limits := backend.ChangeLimits{
+		Changes:  args.LimitChanges,
+		Destroys: args.LimitDestroys,
+	}
+	if c.Project != nil {
+		if limits.Changes == nil {
+			limits.Changes = c.Project.LimitChanges
+		}
+		if limits.Destroys == nil {
+			limits.Destroys = c.Project.LimitDestroys
+		}
+	}
+	return limits
+}
+
 func (c *ApplyCommand) GatherVariables(args *arguments.Vars) {
 	// FIXME the arguments package currently trivially gathers variable related
 	// arguments in a heterogeneous slice, in order to minimize the number of
@@ -378,6 +408,19 @@ Options:
 
   -input=true            Ask for input for variables if not directly set.
 
+  -limit-changes=n       Fail without applying anything if the plan would
+                         change more than n resource instances.
+
+  -limit-destroys=n      Fail without applying anything if the plan would
+                         destroy more than n resource instance objects.
+
+  -max-runtime=duration  Stop starting new changes once the given duration,
+                         such as "45m", has elapsed since the command started,
+                         and list the changes that were not applied.
+
+  -no-input-summary      Don't list all of the missing variables before
+                         asking for their values.
+
   -no-color              If specified, output won't contain any color.
 
   -parallelism=n         Limit the number of parallel resource operations.
@@ -392,6 +435,15 @@ Options:
 
   -show-sensitive        If specified, sensitive values will be displayed.
 
+  -expand-collapsed      Show in full the changes to attributes that the
+                         collapse_attributes CLI configuration setting
+                         would otherwise collapse.
+
+  -ui=tui                Show the progress of the operation as a live table
+                         of the resource instances being worked on, instead
+                         of a message for each step. Has no effect unless
+                         the output is a terminal.
+
   -json                  Produce output in a machine-readable JSON format,
                          suitable for use in text editor integrations and 
                          other automated systems. Always disables color.
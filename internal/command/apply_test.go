@@ -28,6 +28,7 @@ import (
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -725,6 +726,50 @@ func TestApply_plan(t *testing.T) {
 	}
 }
 
+func TestApply_planFromReadOnlyMode(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("apply"), td)
+	defer testChdir(t, td)()
+	f, err := os.Create(DefaultStateFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = statefile.Write(&statefile.File{Lineage: "read-only", Serial: 5, State: testState()}, f, encryption.StateEncryptionDisabled())
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := applyFixtureProvider()
+	view, done := testView(t)
+	plan := &PlanCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+			ReadOnly:         true,
+		},
+	}
+	if code := plan.Run([]string{"-out=read-only.tfplan"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, done(t).Stderr())
+	}
+	done(t)
+
+	// A plan created in read-only mode must record the metadata of the
+	// state snapshot it was created from, so that it can be applied later.
+	view, done = testView(t)
+	apply := &ApplyCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+	code := apply.Run([]string{"read-only.tfplan"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, output.Stderr())
+	}
+}
+
 func TestApply_plan_backup(t *testing.T) {
 	statePath := testTempFile(t)
 	backupPath := testTempFile(t)
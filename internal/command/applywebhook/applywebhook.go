@@ -0,0 +1,243 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package applywebhook implements the optional apply webhook, which posts
+// the result of each resource instance change to an HTTP endpoint as soon as
+// it completes, so that dashboards can follow an apply in real time.
+package applywebhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// DefaultTimeout is how long a single request to the endpoint may take
+// before we give up on it.
+const DefaultTimeout = 10 * time.Second
+
+// queueSize is how many events can be waiting to be posted before the apply
+// has to wait for the endpoint to catch up.
+const queueSize = 256
+
+// Event is the JSON document posted to the endpoint for each resource
+// instance change that completes.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Address   string    `json:"address"`
+	Action    string    `json:"action"`
+
+	// Status is either "complete" or "errored".
+	Status     string `json:"status"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Notifier posts Events to an HTTP endpoint. A nil *Notifier is valid and
+// discards all events, so callers don't need to check whether the webhook
+// is enabled.
+//
+// Events are posted one at a time in the background, in the order the
+// changes complete. Call Close once the apply has finished to wait for the
+// remaining events to be posted.
+type Notifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	now     func() time.Time
+
+	mu     sync.Mutex
+	queue  chan Event
+	done   chan struct{}
+	closed bool
+}
+
+// New returns a Notifier that posts to the given URL, with the given extra
+// request headers. If url is empty then New returns nil, which disables the
+// webhook.
+func New(url string, headers map[string]string) *Notifier {
+	if url == "" {
+		return nil
+	}
+	return &Notifier{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: DefaultTimeout, Transport: httpclient.NewTransport()},
+		now:     time.Now,
+	}
+}
+
+// Hook returns a hook that reports the changes made by an apply operation to
+// the notifier, or nil if n is nil.
+func (n *Notifier) Hook() tofu.Hook {
+	if n == nil {
+		return nil
+	}
+	return &hook{
+		notifier: n,
+		started:  make(map[string]startedChange),
+	}
+}
+
+// Close waits for all events sent so far to be posted, and discards any
+// sent afterwards.
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return
+	}
+	n.closed = true
+	queue, done := n.queue, n.done
+	n.mu.Unlock()
+
+	if queue != nil {
+		close(queue)
+		<-done
+	}
+}
+
+func (n *Notifier) send(event Event) {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return
+	}
+	if n.queue == nil {
+		n.queue = make(chan Event, queueSize)
+		n.done = make(chan struct{})
+		go n.run(n.queue, n.done)
+	}
+	queue := n.queue
+	n.mu.Unlock()
+
+	queue <- event
+}
+
+func (n *Notifier) run(queue <-chan Event, done chan<- struct{}) {
+	defer close(done)
+	for event := range queue {
+		if err := n.post(event); err != nil {
+			// The webhook is only informational, so a failure to post must
+			// not interrupt the apply.
+			log.Printf("[WARN] applywebhook: failed to post result for %s: %s", event.Address, err)
+		}
+	}
+}
+
+func (n *Notifier) post(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range n.headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+type startedChange struct {
+	action plans.Action
+	start  time.Time
+}
+
+// hook is the tofu.Hook returned by Notifier.Hook.
+type hook struct {
+	tofu.NilHook
+
+	notifier *Notifier
+
+	mu      sync.Mutex
+	started map[string]startedChange
+}
+
+var _ tofu.Hook = (*hook)(nil)
+
+func (h *hook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	h.mu.Lock()
+	h.started[addr.String()] = startedChange{
+		action: action,
+		start:  h.notifier.now(),
+	}
+	h.mu.Unlock()
+	return tofu.HookActionContinue, nil
+}
+
+func (h *hook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (tofu.HookAction, error) {
+	key := addr.String()
+	h.mu.Lock()
+	started, ok := h.started[key]
+	delete(h.started, key)
+	h.mu.Unlock()
+
+	if !ok || started.action == plans.NoOp {
+		return tofu.HookActionContinue, nil
+	}
+
+	now := h.notifier.now()
+	event := Event{
+		Timestamp:  now.UTC(),
+		Address:    key,
+		Action:     actionName(started.action),
+		Status:     "complete",
+		DurationMS: now.Sub(started.start).Milliseconds(),
+	}
+	if err != nil {
+		event.Status = "errored"
+		event.Error = err.Error()
+	}
+	h.notifier.send(event)
+	return tofu.HookActionContinue, nil
+}
+
+// actionName returns the name of the given action, using the same names as
+// the machine-readable UI.
+func actionName(action plans.Action) string {
+	switch action {
+	case plans.Create:
+		return "create"
+	case plans.Read:
+		return "read"
+	case plans.Update:
+		return "update"
+	case plans.DeleteThenCreate, plans.CreateThenDelete:
+		return "replace"
+	case plans.Delete:
+		return "delete"
+	case plans.Forget:
+		return "forget"
+	default:
+		return "noop"
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package applywebhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestNotifier(t *testing.T) {
+	var mu sync.Mutex
+	var got []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("wrong Authorization header %q", auth)
+		}
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		got = append(got, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	n := New(server.URL, map[string]string{"Authorization": "Bearer secret"})
+	now := time.Date(2024, 10, 16, 10, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+	h := n.Hook()
+
+	created := addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "a"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	failed := addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "b"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	unchanged := addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "c"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+	h.PreApply(created, states.CurrentGen, plans.Create, cty.NullVal(cty.DynamicPseudoType), cty.EmptyObjectVal)
+	h.PreApply(failed, states.CurrentGen, plans.DeleteThenCreate, cty.EmptyObjectVal, cty.EmptyObjectVal)
+	h.PreApply(unchanged, states.CurrentGen, plans.NoOp, cty.EmptyObjectVal, cty.EmptyObjectVal)
+	now = now.Add(1500 * time.Millisecond)
+	h.PostApply(created, states.CurrentGen, cty.EmptyObjectVal, nil)
+	h.PostApply(failed, states.CurrentGen, cty.NullVal(cty.DynamicPseudoType), errors.New("boom"))
+	h.PostApply(unchanged, states.CurrentGen, cty.EmptyObjectVal, nil)
+	n.Close()
+
+	want := []Event{
+		{
+			Timestamp:  now,
+			Address:    "test_instance.a",
+			Action:     "create",
+			Status:     "complete",
+			DurationMS: 1500,
+		},
+		{
+			Timestamp:  now,
+			Address:    "test_instance.b",
+			Action:     "replace",
+			Status:     "errored",
+			DurationMS: 1500,
+			Error:      "boom",
+		},
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of events\ngot:  %#v\nwant: %#v", got, want)
+	}
+	for i := range want {
+		if !got[i].Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("wrong timestamp for event %d: %s", i, got[i].Timestamp)
+		}
+		got[i].Timestamp = want[i].Timestamp
+		if got[i] != want[i] {
+			t.Errorf("wrong event %d\ngot:  %#v\nwant: %#v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNotifier_nil(t *testing.T) {
+	var n *Notifier
+	if n := New("", nil); n != nil {
+		t.Fatalf("expected a nil notifier, got %#v", n)
+	}
+	if h := n.Hook(); h != nil {
+		t.Fatalf("expected no hook, got %#v", h)
+	}
+	n.Close()
+}
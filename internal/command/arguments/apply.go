@@ -7,6 +7,7 @@ package arguments
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -34,6 +35,24 @@ type Apply struct {
 
 	// ShowSensitive is used to display the value of variables marked as sensitive.
 	ShowSensitive bool
+
+	// ExpandCollapsed is used to display the changes to attributes that the
+	// CLI configuration would otherwise collapse into a one-line marker.
+	ExpandCollapsed bool
+
+	// TUI replaces the scrolling progress messages with a live table of
+	// resource instances, as selected by the -ui=tui option.
+	TUI bool
+
+	// LimitChanges and LimitDestroys, if not nil, are the maximum numbers of
+	// resource instances that the plan may change and destroy respectively
+	// for the apply to go ahead.
+	LimitChanges  *int
+	LimitDestroys *int
+
+	// MaxRuntime, if nonzero, is the duration after which the apply stops
+	// starting new resource instance changes.
+	MaxRuntime time.Duration
 }
 
 // ParseApply processes CLI arguments, returning an Apply value and errors.
@@ -51,10 +70,20 @@ func ParseApply(args []string) (*Apply, tfdiags.Diagnostics) {
 	cmdFlags.BoolVar(&apply.AutoApprove, "auto-approve", false, "auto-approve")
 	cmdFlags.BoolVar(&apply.InputEnabled, "input", true, "input")
 	cmdFlags.BoolVar(&apply.ShowSensitive, "show-sensitive", false, "displays sensitive values")
+	cmdFlags.BoolVar(&apply.ExpandCollapsed, "expand-collapsed", false, "displays collapsed attribute changes")
+
+	cmdFlags.DurationVar(&apply.MaxRuntime, "max-runtime", 0, "max-runtime")
+
+	var limitChanges, limitDestroys int
+	cmdFlags.IntVar(&limitChanges, "limit-changes", 0, "limit-changes")
+	cmdFlags.IntVar(&limitDestroys, "limit-destroys", 0, "limit-destroys")
 
 	var json bool
 	cmdFlags.BoolVar(&json, "json", false, "json")
 
+	var ui string
+	cmdFlags.StringVar(&ui, "ui", "", "ui")
+
 	if err := cmdFlags.Parse(args); err != nil {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
@@ -77,6 +106,37 @@ func ParseApply(args []string) (*Apply, tfdiags.Diagnostics) {
 		))
 	}
 
+	for _, limit := range []struct {
+		name  string
+		value int
+		field **int
+	}{
+		{"limit-changes", limitChanges, &apply.LimitChanges},
+		{"limit-destroys", limitDestroys, &apply.LimitDestroys},
+	} {
+		if !FlagIsSet(cmdFlags, limit.name) {
+			continue
+		}
+		if limit.value < 0 {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid change limit",
+				fmt.Sprintf("The -%s option requires a number that is zero or greater.", limit.name),
+			))
+			continue
+		}
+		value := limit.value
+		*limit.field = &value
+	}
+
+	if apply.MaxRuntime < 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid maximum runtime",
+			"The -max-runtime option requires a positive duration, such as \"45m\".",
+		))
+	}
+
 	// JSON view currently does not support input, so we disable it here.
 	if json {
 		apply.InputEnabled = false
@@ -95,6 +155,10 @@ func ParseApply(args []string) (*Apply, tfdiags.Diagnostics) {
 
 	diags = diags.Append(apply.Operation.Parse())
 
+	tui, uiDiags := parseProgressUI(ui, json)
+	diags = diags.Append(uiDiags)
+	apply.TUI = tui
+
 	switch {
 	case json:
 		apply.ViewType = ViewJSON
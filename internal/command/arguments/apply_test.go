@@ -8,6 +8,7 @@ package arguments
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/opentofu/opentofu/internal/tfdiags"
 
@@ -54,6 +55,23 @@ func TestParseApply_basicValid(t *testing.T) {
 				},
 			},
 		},
+		"interactive progress display": {
+			[]string{"-ui=tui"},
+			&Apply{
+				AutoApprove:  false,
+				InputEnabled: true,
+				PlanPath:     "",
+				ViewType:     ViewHuman,
+				TUI:          true,
+				State:        &State{Lock: true},
+				Vars:         &Vars{},
+				Operation: &Operation{
+					PlanMode:    plans.NormalMode,
+					Parallelism: 10,
+					Refresh:     true,
+				},
+			},
+		},
 		"destroy mode": {
 			[]string{"-destroy"},
 			&Apply{
@@ -356,6 +374,71 @@ func TestParseApply_replace(t *testing.T) {
 	}
 }
 
+func TestParseApply_replaceDependents(t *testing.T) {
+	got, diags := ParseApply([]string{"-replace", "foo_bar.baz", "-replace-dependents"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if !got.Operation.ReplaceDependents {
+		t.Fatal("ReplaceDependents should be set")
+	}
+
+	_, diags = ParseApply([]string{"-replace-dependents"})
+	if len(diags) == 0 {
+		t.Fatal("expected diags but got none")
+	}
+	if got, want := diags.Err().Error(), "can only be used together with at least one -replace"; !strings.Contains(got, want) {
+		t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestParseApply_limits(t *testing.T) {
+	got, diags := ParseApply([]string{"-limit-changes=10", "-limit-destroys=0"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if got.LimitChanges == nil || *got.LimitChanges != 10 {
+		t.Errorf("wrong LimitChanges %v; want 10", got.LimitChanges)
+	}
+	if got.LimitDestroys == nil || *got.LimitDestroys != 0 {
+		t.Errorf("wrong LimitDestroys %v; want 0", got.LimitDestroys)
+	}
+
+	got, diags = ParseApply(nil)
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if got.LimitChanges != nil || got.LimitDestroys != nil {
+		t.Errorf("limits should be unset by default")
+	}
+
+	_, diags = ParseApply([]string{"-limit-destroys=-1"})
+	if len(diags) == 0 {
+		t.Fatal("expected diags but got none")
+	}
+	if got, want := diags.Err().Error(), "The -limit-destroys option requires a number that is zero or greater"; !strings.Contains(got, want) {
+		t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestParseApply_maxRuntime(t *testing.T) {
+	got, diags := ParseApply([]string{"-max-runtime=45m"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if got, want := got.MaxRuntime, 45*time.Minute; got != want {
+		t.Errorf("wrong MaxRuntime %s; want %s", got, want)
+	}
+
+	_, diags = ParseApply([]string{"-max-runtime=-1s"})
+	if len(diags) == 0 {
+		t.Fatal("expected diags but got none")
+	}
+	if got, want := diags.Err().Error(), "requires a positive duration"; !strings.Contains(got, want) {
+		t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+	}
+}
+
 func TestParseApply_vars(t *testing.T) {
 	testCases := map[string]struct {
 		args []string
@@ -0,0 +1,60 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// CheckStatus represents the command-line arguments for the check-status
+// command.
+type CheckStatus struct {
+	// StatePath is an optional path to a state file, from which the check
+	// results will be loaded.
+	StatePath string
+
+	// ViewType specifies which output format to use: human or JSON.
+	ViewType ViewType
+
+	Vars *Vars
+}
+
+// ParseCheckStatus processes CLI arguments, returning a CheckStatus value and
+// errors. If errors are encountered, a CheckStatus value is still returned
+// representing the best effort interpretation of the arguments.
+func ParseCheckStatus(args []string) (*CheckStatus, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	checkStatus := &CheckStatus{
+		Vars: &Vars{},
+	}
+
+	var jsonOutput bool
+	cmdFlags := extendedFlagSet("check-status", nil, nil, checkStatus.Vars)
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
+	cmdFlags.StringVar(&checkStatus.StatePath, "state", "", "path")
+
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+	}
+
+	if len(cmdFlags.Args()) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unexpected argument",
+			"The check-status command does not expect any arguments.",
+		))
+	}
+
+	if jsonOutput {
+		checkStatus.ViewType = ViewJSON
+	} else {
+		checkStatus.ViewType = ViewHuman
+	}
+
+	return checkStatus, diags
+}
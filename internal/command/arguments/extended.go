@@ -8,12 +8,15 @@ package arguments
 import (
 	"flag"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -73,6 +76,10 @@ type Operation struct {
 	// than a set of excluded resource addresses and resources dependent on them.
 	Excludes []addrs.Targetable
 
+	// TargetProviders extends Targets with all resources that are bound to
+	// the given provider configurations.
+	TargetProviders []addrs.ProviderConfig
+
 	// ForceReplace addresses cause OpenTofu to force a particular set of
 	// resource instances to generate "replace" actions in any plan where they
 	// would normally have generated "no-op" or "update" actions.
@@ -85,14 +92,25 @@ type Operation struct {
 	// learn a use-case for broader matching.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// ReplaceDependents extends ForceReplace so that OpenTofu also replaces
+	// any resource instances that depend on the ones being replaced, rather
+	// than trying to update them in-place.
+	ReplaceDependents bool
+
+	// NoInputSummary disables the summary of all missing root module
+	// variables that is normally shown before interactively prompting for
+	// their values.
+	NoInputSummary bool
+
 	// These private fields are used only temporarily during decoding. Use
 	// method Parse to populate the exported fields from these, validating
 	// the raw values in the process.
-	targetsRaw      []string
-	excludesRaw     []string
-	forceReplaceRaw []string
-	destroyRaw      bool
-	refreshOnlyRaw  bool
+	targetsRaw         []string
+	excludesRaw        []string
+	targetProvidersRaw []string
+	forceReplaceRaw    []string
+	destroyRaw         bool
+	refreshOnlyRaw     bool
 }
 
 // parseTargetables gets a list of strings, each representing a targetable object, and returns a list of
@@ -102,7 +120,21 @@ func parseTargetables(rawTargetables []string, flag string) ([]addrs.Targetable,
 	var targetables []addrs.Targetable
 	var diags tfdiags.Diagnostics
 
-	for _, tr := range rawTargetables {
+	var expanded []string
+	for _, raw := range rawTargetables {
+		rangeAddrs, err := expandInstanceKeyRanges(raw)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				fmt.Sprintf("Invalid %s %q", flag, raw),
+				fmt.Sprintf("Invalid instance key range: %s.", err),
+			))
+			continue
+		}
+		expanded = append(expanded, rangeAddrs...)
+	}
+
+	for _, tr := range expanded {
 		traversal, syntaxDiags := hclsyntax.ParseTraversalAbs([]byte(tr), "", hcl.Pos{Line: 1, Column: 1})
 		if syntaxDiags.HasErrors() {
 			diags = diags.Append(tfdiags.Sourceless(
@@ -128,6 +160,89 @@ func parseTargetables(rawTargetables []string, flag string) ([]addrs.Targetable,
 	return targetables, diags
 }
 
+// maxInstanceKeyRangeExpansion is the largest number of addresses that a
+// single address containing instance key ranges may expand to.
+const maxInstanceKeyRangeExpansion = 1000
+
+// expandInstanceKeyRanges expands any instance key ranges in the given
+// address into the individual addresses they represent.
+//
+// An instance key range is written as [start..end] in place of a single
+// numeric instance key, and includes both start and end. For example,
+// "aws_instance.web[0..2]" expands to "aws_instance.web[0]",
+// "aws_instance.web[1]", and "aws_instance.web[2]". An address may contain
+// more than one range, in which case the result includes every combination.
+//
+// An address without any ranges is returned unchanged as the only element
+// of the result.
+func expandInstanceKeyRanges(raw string) ([]string, error) {
+	ret := []string{""}
+	inQuotes := false
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case inQuotes && c == '\\' && i+1 < len(raw):
+			for j := range ret {
+				ret[j] += raw[i : i+2]
+			}
+			i++
+			continue
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == '[' && !inQuotes:
+			end := strings.IndexByte(raw[i:], ']')
+			if end == -1 {
+				break
+			}
+			start, last, ok, err := parseInstanceKeyRange(raw[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			if count := len(ret) * (last - start + 1); count > maxInstanceKeyRangeExpansion {
+				return nil, fmt.Errorf("the ranges in this address expand to %d addresses, but at most %d are allowed", count, maxInstanceKeyRangeExpansion)
+			}
+			next := make([]string, 0, len(ret)*(last-start+1))
+			for _, prefix := range ret {
+				for key := start; key <= last; key++ {
+					next = append(next, fmt.Sprintf("%s[%d]", prefix, key))
+				}
+			}
+			ret = next
+			i += end
+			continue
+		}
+		for j := range ret {
+			ret[j] += raw[i : i+1]
+		}
+	}
+	return ret, nil
+}
+
+// parseInstanceKeyRange parses the content between the brackets of a
+// possible instance key range. If the content isn't a range then ok is false
+// and the caller should treat it as a normal instance key.
+func parseInstanceKeyRange(raw string) (int, int, bool, error) {
+	before, after, found := strings.Cut(raw, "..")
+	if !found || strings.Contains(raw, `"`) {
+		return 0, 0, false, nil
+	}
+	start, err := strconv.Atoi(strings.TrimSpace(before))
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("the start of [%s] is not a whole number", raw)
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(after))
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("the end of [%s] is not a whole number", raw)
+	}
+	if start < 0 || end < start {
+		return 0, 0, false, fmt.Errorf("[%s] must start at zero or greater and must not end before it starts", raw)
+	}
+	return start, end, true, nil
+}
+
 func parseRawTargetsAndExcludes(targets []string, excludes []string) ([]addrs.Targetable, []addrs.Targetable, tfdiags.Diagnostics) {
 	var parsedTargets []addrs.Targetable
 	var parsedExcludes []addrs.Targetable
@@ -152,6 +267,46 @@ func parseRawTargetsAndExcludes(targets []string, excludes []string) ([]addrs.Ta
 	return parsedTargets, parsedExcludes, diags
 }
 
+// parseTargetProviders parses the values of the -target-provider flag. Each
+// value is either a provider configuration address as written in the root
+// module, like "aws.us_east_1", or an absolute provider configuration address
+// as recorded in the state, like
+// provider["registry.opentofu.org/hashicorp/aws"].us_east_1.
+func parseTargetProviders(rawProviders []string) ([]addrs.ProviderConfig, tfdiags.Diagnostics) {
+	var providers []addrs.ProviderConfig
+	var diags tfdiags.Diagnostics
+
+	for _, raw := range rawProviders {
+		var addr addrs.ProviderConfig
+		var addrDiags tfdiags.Diagnostics
+		switch {
+		case strings.HasPrefix(raw, "provider[") || strings.HasPrefix(raw, "module."):
+			addr, addrDiags = addrs.ParseAbsProviderConfigStr(raw)
+		case strings.Contains(raw, "["):
+			// Selecting a single instance of a provider configuration would
+			// require evaluating the provider references of each resource.
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				fmt.Sprintf("Invalid target-provider %q", raw),
+				"Instance keys are not supported. Specify the provider configuration to select the resources using any of its instances.",
+			))
+			continue
+		default:
+			addr, addrDiags = configs.ParseProviderConfigCompactStr(raw)
+		}
+		if addrDiags.HasErrors() {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				fmt.Sprintf("Invalid target-provider %q", raw),
+				addrDiags[0].Description().Detail,
+			))
+			continue
+		}
+		providers = append(providers, addr)
+	}
+	return providers, diags
+}
+
 // Parse must be called on Operation after initial flag parse. This processes
 // the raw target flags into addrs.Targetable values, returning diagnostics if
 // invalid.
@@ -162,7 +317,31 @@ func (o *Operation) Parse() tfdiags.Diagnostics {
 	o.Targets, o.Excludes, parseDiags = parseRawTargetsAndExcludes(o.targetsRaw, o.excludesRaw)
 	diags = diags.Append(parseDiags)
 
+	if len(o.targetProvidersRaw) > 0 && len(o.excludesRaw) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid combination of arguments",
+			"-target-provider and -exclude flags cannot be used together. Please remove one of the flags",
+		))
+	}
+	o.TargetProviders, parseDiags = parseTargetProviders(o.targetProvidersRaw)
+	diags = diags.Append(parseDiags)
+
+	var forceReplaceRaw []string
 	for _, raw := range o.forceReplaceRaw {
+		rangeAddrs, err := expandInstanceKeyRanges(raw)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				fmt.Sprintf("Invalid force-replace address %q", raw),
+				fmt.Sprintf("Invalid instance key range: %s.", err),
+			))
+			continue
+		}
+		forceReplaceRaw = append(forceReplaceRaw, rangeAddrs...)
+	}
+
+	for _, raw := range forceReplaceRaw {
 		traversal, syntaxDiags := hclsyntax.ParseTraversalAbs([]byte(raw), "", hcl.Pos{Line: 1, Column: 1})
 		if syntaxDiags.HasErrors() {
 			diags = diags.Append(tfdiags.Sourceless(
@@ -195,6 +374,14 @@ func (o *Operation) Parse() tfdiags.Diagnostics {
 		o.ForceReplace = append(o.ForceReplace, addr)
 	}
 
+	if o.ReplaceDependents && len(o.forceReplaceRaw) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid combination of arguments",
+			"The -replace-dependents option can only be used together with at least one -replace=... option.",
+		))
+	}
+
 	// If you add a new possible value for o.PlanMode here, consider also
 	// adding a specialized error message for it in ParseApplyDestroy.
 	switch {
@@ -271,7 +458,10 @@ func extendedFlagSet(name string, state *State, operation *Operation, vars *Vars
 		f.BoolVar(&operation.refreshOnlyRaw, "refresh-only", false, "refresh-only")
 		f.Var((*flagStringSlice)(&operation.targetsRaw), "target", "target")
 		f.Var((*flagStringSlice)(&operation.excludesRaw), "exclude", "exclude")
+		f.Var((*flagStringSlice)(&operation.targetProvidersRaw), "target-provider", "target-provider")
 		f.Var((*flagStringSlice)(&operation.forceReplaceRaw), "replace", "replace")
+		f.BoolVar(&operation.ReplaceDependents, "replace-dependents", false, "replace-dependents")
+		f.BoolVar(&operation.NoInputSummary, "no-input-summary", false, "no-input-summary")
 	}
 
 	// Gather all -var and -var-file arguments into one heterogeneous structure
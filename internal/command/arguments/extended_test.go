@@ -0,0 +1,85 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandInstanceKeyRanges(t *testing.T) {
+	testCases := map[string]struct {
+		raw     string
+		want    []string
+		wantErr string
+	}{
+		"no range": {
+			raw:  `aws_instance.web`,
+			want: []string{`aws_instance.web`},
+		},
+		"single key": {
+			raw:  `module.m["us-east-1"].aws_instance.web[2]`,
+			want: []string{`module.m["us-east-1"].aws_instance.web[2]`},
+		},
+		"resource range": {
+			raw:  `aws_instance.web[0..2]`,
+			want: []string{`aws_instance.web[0]`, `aws_instance.web[1]`, `aws_instance.web[2]`},
+		},
+		"single element range": {
+			raw:  `aws_instance.web[3..3]`,
+			want: []string{`aws_instance.web[3]`},
+		},
+		"module and resource ranges": {
+			raw: `module.m[0..1].aws_instance.web[ 4 .. 5 ]`,
+			want: []string{
+				`module.m[0].aws_instance.web[4]`,
+				`module.m[0].aws_instance.web[5]`,
+				`module.m[1].aws_instance.web[4]`,
+				`module.m[1].aws_instance.web[5]`,
+			},
+		},
+		"dots in string key": {
+			raw:  `module.m["a..b"].aws_instance.web[0..1]`,
+			want: []string{`module.m["a..b"].aws_instance.web[0]`, `module.m["a..b"].aws_instance.web[1]`},
+		},
+		"escaped quote in string key": {
+			raw:  `module.m["a\"[0..1]"].aws_instance.web`,
+			want: []string{`module.m["a\"[0..1]"].aws_instance.web`},
+		},
+		"reversed range": {
+			raw:     `aws_instance.web[2..1]`,
+			wantErr: "[2..1] must start at zero or greater and must not end before it starts",
+		},
+		"non-numeric range": {
+			raw:     `aws_instance.web[a..2]`,
+			wantErr: "the start of [a..2] is not a whole number",
+		},
+		"too many": {
+			raw:     `module.m[0..99].aws_instance.web[0..99]`,
+			wantErr: "the ranges in this address expand to 10000 addresses, but at most 1000 are allowed",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := expandInstanceKeyRanges(tc.raw)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got none", tc.wantErr)
+				}
+				if err.Error() != tc.wantErr {
+					t.Fatalf("wrong error\n got: %s\nwant: %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
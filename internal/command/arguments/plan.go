@@ -37,6 +37,14 @@ type Plan struct {
 
 	// ShowSensitive is used to display the value of variables marked as sensitive.
 	ShowSensitive bool
+
+	// ExpandCollapsed is used to display the changes to attributes that the
+	// CLI configuration would otherwise collapse into a one-line marker.
+	ExpandCollapsed bool
+
+	// TUI replaces the scrolling progress messages with a live table of
+	// resource instances, as selected by the -ui=tui option.
+	TUI bool
 }
 
 // ParsePlan processes CLI arguments, returning a Plan value and errors.
@@ -56,10 +64,14 @@ func ParsePlan(args []string) (*Plan, tfdiags.Diagnostics) {
 	cmdFlags.StringVar(&plan.OutPath, "out", "", "out")
 	cmdFlags.StringVar(&plan.GenerateConfigPath, "generate-config-out", "", "generate-config-out")
 	cmdFlags.BoolVar(&plan.ShowSensitive, "show-sensitive", false, "displays sensitive values")
+	cmdFlags.BoolVar(&plan.ExpandCollapsed, "expand-collapsed", false, "displays collapsed attribute changes")
 
 	var json bool
 	cmdFlags.BoolVar(&json, "json", false, "json")
 
+	var ui string
+	cmdFlags.StringVar(&ui, "ui", "", "ui")
+
 	if err := cmdFlags.Parse(args); err != nil {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
@@ -80,6 +92,10 @@ func ParsePlan(args []string) (*Plan, tfdiags.Diagnostics) {
 
 	diags = diags.Append(plan.Operation.Parse())
 
+	tui, uiDiags := parseProgressUI(ui, json)
+	diags = diags.Append(uiDiags)
+	plan.TUI = tui
+
 	// JSON view currently does not support input, so we disable it here
 	if json {
 		plan.InputEnabled = false
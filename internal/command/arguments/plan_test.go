@@ -54,6 +54,23 @@ func TestParsePlan_basicValid(t *testing.T) {
 				},
 			},
 		},
+		"interactive progress display": {
+			[]string{"-ui=tui"},
+			&Plan{
+				DetailedExitCode: false,
+				InputEnabled:     true,
+				OutPath:          "",
+				ViewType:         ViewHuman,
+				TUI:              true,
+				State:            &State{Lock: true},
+				Vars:             &Vars{},
+				Operation: &Operation{
+					PlanMode:    plans.NormalMode,
+					Parallelism: 10,
+					Refresh:     true,
+				},
+			},
+		},
 		"JSON view disables input": {
 			[]string{"-json"},
 			&Plan{
@@ -100,6 +117,37 @@ func TestParsePlan_invalid(t *testing.T) {
 	}
 }
 
+func TestParsePlan_invalidUI(t *testing.T) {
+	testCases := map[string]struct {
+		args []string
+		want string
+	}{
+		"unknown": {
+			[]string{"-ui=fancy"},
+			"Invalid value for -ui",
+		},
+		"with JSON": {
+			[]string{"-ui=tui", "-json"},
+			"Incompatible command-line options",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := ParsePlan(tc.args)
+			if len(diags) == 0 {
+				t.Fatal("expected diags but got none")
+			}
+			if got, want := diags.Err().Error(), tc.want; !strings.Contains(got, want) {
+				t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+			}
+			if got.TUI {
+				t.Fatal("TUI enabled despite the error")
+			}
+		})
+	}
+}
+
 func TestParsePlan_tooManyArguments(t *testing.T) {
 	got, diags := ParsePlan([]string{"saved.tfplan"})
 	if len(diags) == 0 {
@@ -116,6 +164,8 @@ func TestParsePlan_tooManyArguments(t *testing.T) {
 func TestParsePlan_targets(t *testing.T) {
 	foobarbaz, _ := addrs.ParseTargetStr("foo_bar.baz")
 	boop, _ := addrs.ParseTargetStr("module.boop")
+	boop0, _ := addrs.ParseTargetStr(`module.boop["a"].foo_bar.baz[0]`)
+	boop1, _ := addrs.ParseTargetStr(`module.boop["a"].foo_bar.baz[1]`)
 	testCases := map[string]struct {
 		args    []string
 		want    []addrs.Targetable
@@ -133,6 +183,15 @@ func TestParsePlan_targets(t *testing.T) {
 			args: []string{"-target=foo_bar.baz", "-target", "module.boop"},
 			want: []addrs.Targetable{foobarbaz.Subject, boop.Subject},
 		},
+		"instance key range": {
+			args: []string{`-target=module.boop["a"].foo_bar.baz[0..1]`},
+			want: []addrs.Targetable{boop0.Subject, boop1.Subject},
+		},
+		"invalid instance key range": {
+			args:    []string{"-target=foo_bar.baz[1..0]"},
+			want:    nil,
+			wantErr: `Invalid target "foo_bar.baz[1..0]": Invalid instance key range: [1..0] must start at zero or greater and must not end before it starts.`,
+		},
 		"invalid traversal": {
 			args:    []string{"-target=foo."},
 			want:    nil,
@@ -250,6 +309,64 @@ func TestParsePlan_excludeAndTarget(t *testing.T) {
 	}
 }
 
+func TestParsePlan_targetProviders(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		want    []addrs.ProviderConfig
+		wantErr string
+	}{
+		"no target providers by default": {
+			args: nil,
+			want: nil,
+		},
+		"compact": {
+			args: []string{"-target-provider=aws", "-target-provider", "aws.us_east_1"},
+			want: []addrs.ProviderConfig{
+				addrs.LocalProviderConfig{LocalName: "aws"},
+				addrs.LocalProviderConfig{LocalName: "aws", Alias: "us_east_1"},
+			},
+		},
+		"absolute": {
+			args: []string{`-target-provider=module.child.provider["registry.opentofu.org/hashicorp/aws"].west`},
+			want: []addrs.ProviderConfig{
+				addrs.AbsProviderConfig{
+					Module:   addrs.RootModule.Child("child"),
+					Provider: addrs.NewDefaultProvider("aws"),
+					Alias:    "west",
+				},
+			},
+		},
+		"instance key": {
+			args:    []string{`-target-provider=aws.by_region["us-east-1"]`},
+			want:    nil,
+			wantErr: `Invalid target-provider "aws.by_region[\"us-east-1\"]": Instance keys are not supported.`,
+		},
+		"with exclude": {
+			args:    []string{"-target-provider=aws", "-exclude=foo_bar.baz"},
+			want:    []addrs.ProviderConfig{addrs.LocalProviderConfig{LocalName: "aws"}},
+			wantErr: "-target-provider and -exclude flags cannot be used together",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := ParsePlan(tc.args)
+			if tc.wantErr == "" && len(diags) > 0 {
+				t.Fatalf("unexpected diags: %v", diags)
+			} else if tc.wantErr != "" {
+				if len(diags) == 0 {
+					t.Fatalf("expected diags but got none")
+				} else if got := diags.Err().Error(); !strings.Contains(got, tc.wantErr) {
+					t.Fatalf("wrong diags\n got: %s\nwant: %s", got, tc.wantErr)
+				}
+			}
+			if !cmp.Equal(got.Operation.TargetProviders, tc.want) {
+				t.Fatalf("unexpected result\n%s", cmp.Diff(got.Operation.TargetProviders, tc.want))
+			}
+		})
+	}
+}
+
 func TestParsePlan_vars(t *testing.T) {
 	testCases := map[string]struct {
 		args []string
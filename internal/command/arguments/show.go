@@ -6,6 +6,8 @@
 package arguments
 
 import (
+	"fmt"
+
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -22,6 +24,21 @@ type Show struct {
 
 	// ShowSensitive is used to display the value of variables marked as sensitive.
 	ShowSensitive bool
+
+	// ExpandCollapsed is used to display the changes to attributes that the
+	// CLI configuration would otherwise collapse into a one-line marker.
+	ExpandCollapsed bool
+
+	// Workspace is the workspace whose latest state snapshot is displayed
+	// instead of the currently selected workspace. It is only valid when no
+	// Path is given.
+	Workspace string
+
+	// BackendConfig are raw -backend-config values that override the
+	// backend configuration used to read the latest state snapshot, in the
+	// same forms that "tofu init" accepts. It is only valid when no Path is
+	// given.
+	BackendConfig []string
 }
 
 // ParseShow processes CLI arguments, returning a Show value and errors.
@@ -35,9 +52,14 @@ func ParseShow(args []string) (*Show, tfdiags.Diagnostics) {
 	}
 
 	var jsonOutput bool
+	var outputFormat string
 	cmdFlags := extendedFlagSet("show", nil, nil, show.Vars)
 	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
+	cmdFlags.StringVar(&outputFormat, "format", "", "format")
 	cmdFlags.BoolVar(&show.ShowSensitive, "show-sensitive", false, "displays sensitive values")
+	cmdFlags.BoolVar(&show.ExpandCollapsed, "expand-collapsed", false, "displays collapsed attribute changes")
+	cmdFlags.StringVar(&show.Workspace, "workspace", "", "workspace")
+	cmdFlags.Var((*flagStringSlice)(&show.BackendConfig), "backend-config", "backend-config")
 
 	if err := cmdFlags.Parse(args); err != nil {
 		diags = diags.Append(tfdiags.Sourceless(
@@ -60,9 +82,33 @@ func ParseShow(args []string) (*Show, tfdiags.Diagnostics) {
 		show.Path = args[0]
 	}
 
+	if show.Path != "" && (show.Workspace != "" || len(show.BackendConfig) != 0) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Incompatible command line options",
+			"The -workspace and -backend-config options select which latest state snapshot to show, so they cannot be used when showing a plan or state file.",
+		))
+	}
+
 	switch {
+	case jsonOutput && outputFormat != "":
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Incompatible command line options",
+			"The -json and -format options are mutually exclusive.",
+		))
+		show.ViewType = ViewJSON
 	case jsonOutput:
 		show.ViewType = ViewJSON
+	case outputFormat == "patch":
+		show.ViewType = ViewPatch
+	case outputFormat != "":
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid output format",
+			fmt.Sprintf("The output format %q is not supported. The only supported format is \"patch\".", outputFormat),
+		))
+		show.ViewType = ViewHuman
 	default:
 		show.ViewType = ViewHuman
 	}
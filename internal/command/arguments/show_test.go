@@ -39,6 +39,22 @@ func TestParseShow_valid(t *testing.T) {
 				ViewType: ViewJSON,
 			},
 		},
+		"patch": {
+			[]string{"-format=patch", "foo"},
+			&Show{
+				Path:     "foo",
+				ViewType: ViewPatch,
+			},
+		},
+		"workspace": {
+			[]string{"-workspace=staging", "-backend-config=bucket=other", "-backend-config=extra.hcl"},
+			&Show{
+				Path:          "",
+				ViewType:      ViewHuman,
+				Workspace:     "staging",
+				BackendConfig: []string{"bucket=other", "extra.hcl"},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -48,7 +64,7 @@ func TestParseShow_valid(t *testing.T) {
 			if len(diags) > 0 {
 				t.Fatalf("unexpected diags: %v", diags)
 			}
-			if *got != *tc.want {
+			if !reflect.DeepEqual(got, tc.want) {
 				t.Fatalf("unexpected result\n got: %#v\nwant: %#v", got, tc.want)
 			}
 		})
@@ -75,6 +91,49 @@ func TestParseShow_invalid(t *testing.T) {
 				),
 			},
 		},
+		"unsupported format": {
+			[]string{"-format=yaml"},
+			&Show{
+				Path:     "",
+				ViewType: ViewHuman,
+			},
+			tfdiags.Diagnostics{
+				tfdiags.Sourceless(
+					tfdiags.Error,
+					"Invalid output format",
+					`The output format "yaml" is not supported. The only supported format is "patch".`,
+				),
+			},
+		},
+		"json and format": {
+			[]string{"-json", "-format=patch"},
+			&Show{
+				Path:     "",
+				ViewType: ViewJSON,
+			},
+			tfdiags.Diagnostics{
+				tfdiags.Sourceless(
+					tfdiags.Error,
+					"Incompatible command line options",
+					"The -json and -format options are mutually exclusive.",
+				),
+			},
+		},
+		"workspace with path": {
+			[]string{"-workspace=staging", "foo"},
+			&Show{
+				Path:      "foo",
+				ViewType:  ViewHuman,
+				Workspace: "staging",
+			},
+			tfdiags.Diagnostics{
+				tfdiags.Sourceless(
+					tfdiags.Error,
+					"Incompatible command line options",
+					"The -workspace and -backend-config options select which latest state snapshot to show, so they cannot be used when showing a plan or state file.",
+				),
+			},
+		},
 		"too many arguments": {
 			[]string{"-json", "bar", "baz"},
 			&Show{
@@ -95,7 +154,7 @@ func TestParseShow_invalid(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			got, gotDiags := ParseShow(tc.args)
 			got.Vars = nil
-			if *got != *tc.want {
+			if !reflect.DeepEqual(got, tc.want) {
 				t.Fatalf("unexpected result\n got: %#v\nwant: %#v", got, tc.want)
 			}
 			if !reflect.DeepEqual(gotDiags, tc.wantDiags) {
@@ -5,6 +5,12 @@
 
 package arguments
 
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
 // ViewType represents which view layer to use for a given command. Not all
 // commands will support all view types, and validation that the type is
 // supported should happen in the view constructor.
@@ -15,6 +21,7 @@ const (
 	ViewHuman ViewType = 'H'
 	ViewJSON  ViewType = 'J'
 	ViewRaw   ViewType = 'R'
+	ViewPatch ViewType = 'P'
 )
 
 func (vt ViewType) String() string {
@@ -27,7 +34,37 @@ func (vt ViewType) String() string {
 		return "json"
 	case ViewRaw:
 		return "raw"
+	case ViewPatch:
+		return "patch"
 	default:
 		return "unknown"
 	}
 }
+
+// parseProgressUI validates the value of the -ui option of the plan and apply
+// commands, returning true if it selects the interactive progress display.
+func parseProgressUI(ui string, json bool) (bool, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	switch ui {
+	case "", "text":
+		return false, diags
+	case "tui":
+		if json {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Incompatible command-line options",
+				"The -ui=tui option can't be used with -json, because the interactive progress display is only for human-readable output.",
+			))
+			return false, diags
+		}
+		return true, diags
+	default:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid value for -ui",
+			fmt.Sprintf("The -ui option must be either \"text\", for scrolling progress messages, or \"tui\", for an interactive progress display, not %q.", ui),
+		))
+		return false, diags
+	}
+}
@@ -24,6 +24,15 @@ type Validate struct {
 	// included with the module.
 	NoTests bool
 
+	// Lint indicates that OpenTofu should also report unused declarations,
+	// such as local values and input variables that are never referenced.
+	Lint bool
+
+	// CachedSchemas indicates that OpenTofu should validate using only the
+	// provider schemas saved by an earlier run, without starting any
+	// provider plugins.
+	CachedSchemas bool
+
 	// ViewType specifies which output format to use: human, JSON, or "raw".
 	ViewType ViewType
 
@@ -45,6 +54,8 @@ func ParseValidate(args []string) (*Validate, tfdiags.Diagnostics) {
 	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.StringVar(&validate.TestDirectory, "test-directory", "tests", "test-directory")
 	cmdFlags.BoolVar(&validate.NoTests, "no-tests", false, "no-tests")
+	cmdFlags.BoolVar(&validate.Lint, "lint", false, "lint")
+	cmdFlags.BoolVar(&validate.CachedSchemas, "cached-schemas", false, "cached-schemas")
 
 	if err := cmdFlags.Parse(args); err != nil {
 		diags = diags.Append(tfdiags.Sourceless(
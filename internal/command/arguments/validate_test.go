@@ -60,6 +60,24 @@ func TestParseValidate_valid(t *testing.T) {
 				NoTests:       true,
 			},
 		},
+		"lint": {
+			[]string{"-lint"},
+			&Validate{
+				Path:          ".",
+				TestDirectory: "tests",
+				ViewType:      ViewHuman,
+				Lint:          true,
+			},
+		},
+		"cached-schemas": {
+			[]string{"-cached-schemas"},
+			&Validate{
+				Path:          ".",
+				TestDirectory: "tests",
+				ViewType:      ViewHuman,
+				CachedSchemas: true,
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -0,0 +1,35 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// AuthCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type AuthCommand struct {
+	Meta
+}
+
+func (c *AuthCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *AuthCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] auth <subcommand> [options] [args]
+
+  This command has subcommands for inspecting the credentials that OpenTofu
+  uses to access remote services and backends.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AuthCommand) Synopsis() string {
+	return "Inspect the credentials OpenTofu uses"
+}
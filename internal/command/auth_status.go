@@ -0,0 +1,335 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/posener/complete"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backend"
+	backendInit "github.com/opentofu/opentofu/internal/backend/init"
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// AuthStatusCommand is a Command implementation that reports which source of
+// credentials OpenTofu would use for each remote service host and for the
+// backend, and whether those credentials currently resolve.
+type AuthStatusCommand struct {
+	Meta
+}
+
+func (c *AuthStatusCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var checkBackend bool
+	cmdFlags := c.Meta.defaultFlagSet("auth status")
+	cmdFlags.BoolVar(&checkBackend, "backend", true, "backend")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("The auth status command expects no arguments.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	mods, diags := c.authStatusModules()
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	ok := c.showHostCredentials(authStatusHostUses(mods))
+	c.Ui.Output("")
+	if !c.showBackendCredentials(mods, checkBackend) {
+		ok = false
+	}
+
+	if !ok {
+		return 1
+	}
+	return 0
+}
+
+// authStatusModules loads the configuration in the current directory. The
+// full configuration is used if its modules are installed, so that the hosts
+// used by child modules are included, and otherwise only the root module.
+func (c *AuthStatusCommand) authStatusModules() ([]*configs.Module, tfdiags.Diagnostics) {
+	if config, diags := c.loadConfig("."); !diags.HasErrors() {
+		var mods []*configs.Module
+		config.DeepEach(func(cfg *configs.Config) {
+			mods = append(mods, cfg.Module)
+		})
+		return mods, nil
+	}
+
+	mod, diags := c.loadSingleModule(".", configs.SelectiveLoadAll)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return []*configs.Module{mod}, diags
+}
+
+// authStatusHostUses returns a description of each use of a remote service
+// host by the given modules, such as "provider example.com/ns/name", by host.
+func authStatusHostUses(mods []*configs.Module) map[svchost.Hostname][]string {
+	uses := make(map[svchost.Hostname][]string)
+	addProvider := func(provider addrs.Provider) {
+		if provider.IsZero() || provider.IsBuiltIn() {
+			return
+		}
+		use := "provider " + provider.ForDisplay()
+		if !slices.Contains(uses[provider.Hostname], use) {
+			uses[provider.Hostname] = append(uses[provider.Hostname], use)
+		}
+	}
+
+	for _, mod := range mods {
+		if mod.ProviderRequirements != nil {
+			for _, req := range mod.ProviderRequirements.RequiredProviders {
+				addProvider(req.Type)
+			}
+		}
+		for _, r := range mod.ManagedResources {
+			addProvider(r.Provider)
+		}
+		for _, r := range mod.DataResources {
+			addProvider(r.Provider)
+		}
+		for _, mc := range mod.ModuleCalls {
+			src, ok := mc.SourceAddr.(addrs.ModuleSourceRegistry)
+			if !ok {
+				continue
+			}
+			use := "module " + src.Package.ForDisplay()
+			if !slices.Contains(uses[src.Package.Host], use) {
+				uses[src.Package.Host] = append(uses[src.Package.Host], use)
+			}
+		}
+	}
+
+	for _, list := range uses {
+		sort.Strings(list)
+	}
+	return uses
+}
+
+// showHostCredentials reports the credentials for each host that is used by
+// the configuration or has credentials configured. It returns false if the
+// credentials for any host could not be read.
+func (c *AuthStatusCommand) showHostCredentials(uses map[svchost.Hostname][]string) bool {
+	creds := c.Services.CredentialsSource().(*cliconfig.CredentialsSource)
+
+	hosts := creds.ConfiguredHosts()
+	for host := range uses {
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i] < hosts[j]
+	})
+
+	c.Ui.Output(c.Colorize().Color("[reset][bold]Host credentials:"))
+	if len(hosts) == 0 {
+		c.Ui.Output("\n  No hosts have credentials configured or are used by the configuration.")
+		return true
+	}
+
+	ok := true
+	for _, host := range hosts {
+		var source string
+		switch {
+		case creds.HostCredentialsInEnvironment(host):
+			source = "TF_TOKEN_ environment variable"
+		default:
+			switch creds.HostCredentialsLocation(host) {
+			case cliconfig.CredentialsInPrimaryFile:
+				filename, _ := creds.CredentialsFilePath()
+				source = fmt.Sprintf("credentials file %s", filename)
+			case cliconfig.CredentialsInOtherFile:
+				source = "credentials block in the CLI configuration"
+			case cliconfig.CredentialsViaHelper:
+				source = fmt.Sprintf("%q credentials helper", creds.CredentialsHelperType())
+			default:
+				source = "none"
+			}
+		}
+
+		var status string
+		hostCreds, err := creds.ForHost(host)
+		switch {
+		case err != nil:
+			status = fmt.Sprintf("failed to read credentials: %s", err)
+			ok = false
+		case hostCreds == nil || hostCreds.Token() == "":
+			status = "no credentials"
+		default:
+			status = "token available"
+			if exp, hasExp := tokenExpiry(hostCreds.Token()); hasExp {
+				if exp.Before(time.Now()) {
+					status += fmt.Sprintf(", expired at %s", exp.UTC().Format(time.RFC3339))
+				} else {
+					status += fmt.Sprintf(", expires at %s", exp.UTC().Format(time.RFC3339))
+				}
+			}
+		}
+
+		c.Ui.Output(fmt.Sprintf("\n  %s", host.ForDisplay()))
+		c.Ui.Output(fmt.Sprintf("    Source:  %s", source))
+		c.Ui.Output(fmt.Sprintf("    Status:  %s", status))
+		if len(uses[host]) != 0 {
+			c.Ui.Output(fmt.Sprintf("    Used by: %s", strings.Join(uses[host], "\n             ")))
+		}
+	}
+	return ok
+}
+
+// tokenExpiry returns the expiry time of the given token if it is a JSON Web
+// Token with an expiry claim. Other tokens are opaque, so their expiry isn't
+// known.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	exp, err := claims.Exp.Int64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(exp, 0), true
+}
+
+// showBackendCredentials reports the credentials for the backend that the
+// working directory was initialized with, and if check is true, whether they
+// resolve. It returns false if they don't.
+func (c *AuthStatusCommand) showBackendCredentials(mods []*configs.Module, check bool) bool {
+	c.Ui.Output(c.Colorize().Color("[reset][bold]Backend:\n"))
+
+	sMgr := &clistate.LocalState{Path: filepath.Join(c.DataDir(), DefaultStateFilename)}
+	if err := sMgr.RefreshState(); err != nil {
+		c.Ui.Output(fmt.Sprintf("  Failed to read the backend configuration saved by \"tofu init\": %s", err))
+		return false
+	}
+	s := sMgr.State()
+	if s == nil || s.Backend == nil || s.Backend.Type == "" {
+		if mods[0].Backend != nil || mods[0].CloudConfig != nil {
+			c.Ui.Output("  The backend is not initialized. Run \"tofu init\" to initialize it.")
+			return false
+		}
+		c.Ui.Output("  Type:        local")
+		c.Ui.Output("  Credentials: none needed for local state files")
+		return true
+	}
+
+	c.Ui.Output(fmt.Sprintf("  Type:        %s", s.Backend.Type))
+	f := backendInit.Backend(s.Backend.Type)
+	if f == nil {
+		c.Ui.Output(fmt.Sprintf("  Status:      unknown backend type %q", s.Backend.Type))
+		return false
+	}
+	// Listing workspaces never reads a state snapshot, so state encryption
+	// isn't needed to check the credentials.
+	b := f(encryption.StateEncryptionDisabled())
+	configVal, err := s.Backend.Config(b.ConfigSchema())
+	if err != nil {
+		c.Ui.Output(fmt.Sprintf("  Status:      failed to decode the backend configuration saved by \"tofu init\": %s", err))
+		return false
+	}
+
+	desc := "not reported by this backend"
+	if describer, ok := b.(backend.CredentialsDescriber); ok {
+		if d := describer.DescribeCredentials(configVal); d != "" {
+			desc = d
+		}
+	}
+	c.Ui.Output(fmt.Sprintf("  Credentials: %s", desc))
+
+	if !check {
+		return true
+	}
+
+	var diags tfdiags.Diagnostics
+	newVal, validDiags := b.PrepareConfig(configVal)
+	diags = diags.Append(validDiags)
+	if !diags.HasErrors() {
+		diags = diags.Append(b.Configure(newVal))
+	}
+	if !diags.HasErrors() {
+		if _, err := b.Workspaces(); err != nil {
+			diags = diags.Append(err)
+		}
+	}
+	if diags.HasErrors() {
+		c.Ui.Output("  Status:      credentials don't resolve")
+		c.showDiagnostics(diags)
+		return false
+	}
+	c.Ui.Output("  Status:      credentials resolve")
+	return true
+}
+
+func (c *AuthStatusCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] auth status [options]
+
+  Report which credentials OpenTofu would use, to help debug authentication
+  problems.
+
+  For each remote service host that has credentials configured, or that the
+  configuration in the current directory uses to install providers or
+  modules, this shows where the credentials come from, whether they can be
+  read, and when they expire if that is known.
+
+  For the backend that the working directory was initialized with, this shows
+  which source of credentials the backend would use, and checks that they
+  resolve by listing the backend's workspaces.
+
+  The exit status is 1 if any credentials could not be read or don't resolve.
+
+Options:
+
+  -backend=false   Don't connect to the backend to check its credentials.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AuthStatusCommand) Synopsis() string {
+	return "Report which credentials OpenTofu would use"
+}
+
+func (c *AuthStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *AuthStatusCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-backend": completePredictBoolean,
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	svcauth "github.com/hashicorp/terraform-svchost/auth"
+	"github.com/hashicorp/terraform-svchost/disco"
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+)
+
+func TestAuthStatus(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("auth-status"), td)
+	defer testChdir(t, td)()
+
+	credsSrc := cliconfig.EmptyCredentialsSourceForTests(filepath.Join(t.TempDir(), "credentials.tfrc.json"))
+	if err := credsSrc.StoreForHost(svchost.Hostname("stored.example.com"), svcauth.HostCredentialsToken("opaque-token")); err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Setenv("TF_TOKEN_example_com", testJWT(exp))
+
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &AuthStatusCommand{
+		Meta: Meta{
+			Ui:       ui,
+			View:     view,
+			Services: disco.NewWithCredentialsSource(credsSrc),
+		},
+	}
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("wrong exit status %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	got := ui.OutputWriter.String()
+	for _, want := range []string{
+		"  example.com\n    Source:  TF_TOKEN_ environment variable\n    Status:  token available, expires at 2099-01-02T03:04:05Z\n    Used by: module example.com/acme/network/aws\n             provider example.com/acme/foo\n",
+		"  stored.example.com\n    Source:  credentials file ",
+		"    Status:  token available\n",
+		"  Type:        local\n  Credentials: none needed for local state files\n  Status:      credentials resolve\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q\n\n%s", want, got)
+		}
+	}
+}
+
+func TestAuthStatus_notInitialized(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("auth-status"), td)
+	defer testChdir(t, td)()
+	if err := os.RemoveAll(".terraform"); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &AuthStatusCommand{
+		Meta: Meta{
+			Ui:       ui,
+			View:     view,
+			Services: disco.NewWithCredentialsSource(cliconfig.EmptyCredentialsSourceForTests(filepath.Join(t.TempDir(), "credentials.tfrc.json"))),
+		},
+	}
+	if code := c.Run([]string{"-backend=false"}); code != 1 {
+		t.Fatalf("wrong exit status %d; want 1\n\n%s", code, ui.OutputWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), `The backend is not initialized. Run "tofu init" to initialize it.`; !strings.Contains(got, want) {
+		t.Errorf("output does not contain %q\n\n%s", want, got)
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	exp := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	got, ok := tokenExpiry(testJWT(exp))
+	if !ok || !got.Equal(exp) {
+		t.Errorf("wrong expiry %s, %t; want %s", got, ok, exp)
+	}
+
+	for _, token := range []string{"opaque-token", "a.b.c", "a." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"x"}`)) + ".c"} {
+		if _, ok := tokenExpiry(token); ok {
+			t.Errorf("unexpected expiry for %q", token)
+		}
+	}
+}
+
+// testJWT returns an unsigned JSON Web Token that expires at the given time.
+func testJWT(exp time.Time) string {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"ci","exp":%d}`, exp.Unix())))
+	return header + "." + payload + "."
+}
@@ -6,7 +6,11 @@
 package command
 
 import (
+	"sort"
+
 	"github.com/posener/complete"
+
+	"github.com/opentofu/opentofu/internal/backend"
 )
 
 // This file contains some re-usable predictors for auto-complete. The
@@ -35,40 +39,88 @@ func (s completePredictSequence) Predict(a complete.Args) []string {
 
 func (m *Meta) completePredictWorkspaceName() complete.Predictor {
 	return complete.PredictFunc(func(a complete.Args) []string {
-		// There are lot of things that can fail in here, so if we encounter
-		// any error then we'll just return nothing and not support autocomplete
-		// until whatever error is fixed. (The user can't actually see the error
-		// here, but other commands should produce a user-visible error before
-		// too long.)
-
-		// We assume here that we want to autocomplete for the current working
-		// directory, since we don't have enough context to know where to
-		// find any config path argument, and it might be _after_ the argument
-		// we're trying to complete here anyway.
-		configPath, err := modulePath(nil)
-		if err != nil {
+		b := m.completeBackend()
+		if b == nil {
 			return nil
 		}
 
-		backendConfig, diags := m.loadBackendConfig(configPath)
-		if diags.HasErrors() {
+		names, _ := b.Workspaces()
+		return names
+	})
+}
+
+// completePredictResourceAddress predicts the addresses of the resource
+// instances in the state of the currently-selected workspace.
+func (m *Meta) completePredictResourceAddress() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		b := m.completeBackend()
+		if b == nil {
 			return nil
 		}
 
-		// Load the encryption configuration
-		enc, encDiags := m.Encryption()
-		if encDiags.HasErrors() {
+		workspace, err := m.Workspace()
+		if err != nil {
 			return nil
 		}
-
-		b, diags := m.Backend(&BackendOpts{
-			Config: backendConfig,
-		}, enc.State())
-		if diags.HasErrors() {
+		stateMgr, err := b.StateMgr(workspace)
+		if err != nil {
+			return nil
+		}
+		if err := stateMgr.RefreshState(); err != nil {
+			return nil
+		}
+		state := stateMgr.State()
+		if state == nil {
 			return nil
 		}
 
-		names, _ := b.Workspaces()
-		return names
+		var addrs []string
+		for _, ms := range state.Modules {
+			for _, rs := range ms.Resources {
+				for key := range rs.Instances {
+					addrs = append(addrs, rs.Addr.Instance(key).String())
+				}
+			}
+		}
+		sort.Strings(addrs)
+		return addrs
 	})
 }
+
+// completeBackend returns the backend for the working directory, or nil if
+// it can't be initialized.
+func (m *Meta) completeBackend() backend.Backend {
+	// There are lot of things that can fail in here, so if we encounter
+	// any error then we'll just return nothing and not support autocomplete
+	// until whatever error is fixed. (The user can't actually see the error
+	// here, but other commands should produce a user-visible error before
+	// too long.)
+
+	// We assume here that we want to autocomplete for the current working
+	// directory, since we don't have enough context to know where to
+	// find any config path argument, and it might be _after_ the argument
+	// we're trying to complete here anyway.
+	configPath, err := modulePath(nil)
+	if err != nil {
+		return nil
+	}
+
+	backendConfig, diags := m.loadBackendConfig(configPath)
+	if diags.HasErrors() {
+		return nil
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := m.Encryption()
+	if encDiags.HasErrors() {
+		return nil
+	}
+
+	b, diags := m.Backend(&BackendOpts{
+		Config: backendConfig,
+	}, enc.State())
+	if diags.HasErrors() {
+		return nil
+	}
+	return b
+}
@@ -39,3 +39,22 @@ func TestMetaCompletePredictWorkspaceName(t *testing.T) {
 		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
 	}
 }
+
+func TestMetaCompletePredictResourceAddress(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+	testStateFileDefault(t, testState())
+
+	ui := new(cli.MockUi)
+	meta := &Meta{Ui: ui}
+
+	predictor := meta.completePredictResourceAddress()
+
+	got := predictor.Predict(complete.Args{
+		Last: "",
+	})
+	want := []string{"test_instance.foo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/copy"
+)
+
+// BundleCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type BundleCommand struct {
+	Meta
+}
+
+func (c *BundleCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *BundleCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] bundle <subcommand> [options] [args]
+
+  This command has subcommands for transferring the external dependencies
+  of a configuration into an environment without network access to provider
+  registries or module sources.
+
+  A bundle is a single archive containing the provider packages selected in
+  the dependency lock file, the installed modules, and optionally the
+  OpenTofu executable itself. Every file in the bundle is checksummed, and
+  the checksums are verified before anything is installed.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *BundleCommand) Synopsis() string {
+	return "Package dependencies for use without network access"
+}
+
+// copyBundleDir copies the contents of the directory src into the directory
+// dst, creating dst first if necessary.
+func copyBundleDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	return copy.CopyDir(dst, src)
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,267 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/opentofu/opentofu/internal/bundle"
+	"github.com/opentofu/opentofu/internal/copy"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/version"
+)
+
+// BundleCreateCommand is a Command implementation that implements the
+// "tofu bundle create" command, which packages the providers and modules
+// required by the current configuration into a single verifiable archive.
+type BundleCreateCommand struct {
+	Meta
+}
+
+func (c *BundleCreateCommand) Synopsis() string {
+	return "Create a dependency bundle for the current configuration"
+}
+
+func (c *BundleCreateCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("bundle create")
+	c.Meta.varFlagSet(cmdFlags)
+	var optPlatforms FlagStringSlice
+	var includeBinary bool
+	cmdFlags.Var(&optPlatforms, "platform", "target platform")
+	cmdFlags.BoolVar(&includeBinary, "include-binary", false, "include the OpenTofu executable")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No bundle file specified",
+			"The bundle create command requires the path of the bundle file to create as a command-line argument.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	bundleFile := args[0]
+
+	platforms := []getproviders.Platform{getproviders.CurrentPlatform}
+	if len(optPlatforms) != 0 {
+		platforms = make([]getproviders.Platform, 0, len(optPlatforms))
+		for _, platformStr := range optPlatforms {
+			platform, err := getproviders.ParsePlatform(platformStr)
+			if err != nil {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Invalid target platform",
+					fmt.Sprintf("The string %q given in the -platform option is not a valid target platform: %s.", platformStr, err),
+				))
+				continue
+			}
+			platforms = append(platforms, platform)
+		}
+	}
+	if includeBinary && (len(platforms) != 1 || platforms[0] != getproviders.CurrentPlatform) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Incompatible options",
+			fmt.Sprintf("The -include-binary option can only be used when the bundle targets only the current platform, %s.", getproviders.CurrentPlatform),
+		))
+	}
+
+	// Installation steps can be cancelled by SIGINT and similar.
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	config, confDiags := c.loadConfig(".")
+	diags = diags.Append(confDiags)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	reqs, moreDiags := config.ProviderRequirements()
+	diags = diags.Append(moreDiags)
+
+	lockedDeps, lockedDepsDiags := c.Meta.lockedDependencies()
+	diags = diags.Append(lockedDepsDiags)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// A bundle must always reproduce exactly the selections recorded in the
+	// dependency lock file, so that the disconnected environment installs
+	// the same packages that were tested in the connected one.
+	if lockedDeps.Empty() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Missing dependency lock file",
+			"A bundle can only be created for a configuration with a dependency lock file. Run \"tofu init\" to select provider versions before creating a bundle.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if errs := config.VerifyDependencySelections(lockedDeps); len(errs) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Inconsistent dependency lock file",
+			fmt.Sprintf("To update the locked dependency selections to match a changed configuration, run:\n  tofu init -upgrade\n got:%v", errs),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	stagingDir, err := os.MkdirTemp("", "tofu-bundle")
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to create bundle",
+			fmt.Sprintf("Could not create a temporary directory to prepare the bundle: %s.", err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	defer os.RemoveAll(stagingDir)
+
+	// Unlike other commands, this command always consults the origin registry
+	// for every provider, for the same reason as "tofu providers mirror".
+	source := getproviders.NewMemoizeSource(
+		getproviders.NewRegistrySource(c.Services),
+	)
+	providersDir := filepath.Join(stagingDir, bundle.ProvidersDir)
+	diags = diags.Append(mirrorProviderPackages(ctx, c.Ui, source, reqs, lockedDeps, platforms, providersDir))
+	diags = diags.Append(writeProviderMirrorIndexes(providersDir))
+
+	if len(config.Children) != 0 {
+		c.Ui.Output("- Bundling installed modules...")
+		if _, err := os.Stat(c.modulesDir()); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Modules not installed",
+				"This configuration calls modules that have not been installed yet. Run \"tofu init\" to install them before creating a bundle.",
+			))
+		} else if err := copyBundleDir(c.modulesDir(), filepath.Join(stagingDir, bundle.ModulesDir)); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to bundle modules",
+				fmt.Sprintf("Could not copy the installed modules into the bundle: %s.", err),
+			))
+		}
+	}
+
+	if includeBinary {
+		c.Ui.Output("- Bundling the OpenTofu executable...")
+		diags = diags.Append(c.bundleBinary(filepath.Join(stagingDir, bundle.BinaryDir)))
+	}
+
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("- Writing bundle to %s...", bundleFile))
+	if err := writeBundleFile(bundleFile, stagingDir); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to create bundle",
+			fmt.Sprintf("Could not write the bundle file %s: %s.", bundleFile, err),
+		))
+	}
+
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+func (c *BundleCreateCommand) bundleBinary(dst string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		return diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to bundle the OpenTofu executable",
+			fmt.Sprintf("Could not determine the location of the running OpenTofu executable: %s.", err),
+		))
+	}
+
+	name := "tofu"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	if err := os.MkdirAll(dst, 0755); err == nil {
+		err = copy.CopyFile(exe, filepath.Join(dst, name))
+	}
+	if err != nil {
+		return diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to bundle the OpenTofu executable",
+			fmt.Sprintf("Could not copy %s into the bundle: %s.", exe, err),
+		))
+	}
+	return diags
+}
+
+func writeBundleFile(filename, stagingDir string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Create(f, stagingDir, version.String()); err != nil {
+		f.Close()
+		os.Remove(filename)
+		return err
+	}
+	return f.Close()
+}
+
+func (c *BundleCreateCommand) Help() string {
+	return `
+Usage: tofu [global options] bundle create [options] <bundle-file>
+
+  Creates a single archive containing the external dependencies of the
+  current configuration, for transfer into an environment that has no
+  network access to provider registries or module sources.
+
+  The bundle contains the provider packages selected in the dependency lock
+  file and the modules installed by "tofu init", so both must be up to date
+  before creating a bundle. Use "tofu bundle install" in the destination
+  environment to install the contents of the bundle.
+
+Options:
+
+  -platform=os_arch  Choose which target platform to include provider
+                     packages for. By default OpenTofu will include packages
+                     suitable for the platform where you run this command.
+                     Use this flag multiple times to include packages for
+                     multiple target systems.
+
+  -include-binary    Include the running OpenTofu executable in the bundle.
+                     This can be used only when the bundle targets only the
+                     current platform.
+
+  -var 'foo=bar'     Set a value for one of the input variables in the root
+                     module of the configuration. Use this option more than
+                     once to set more than one variable.
+
+  -var-file=filename Load variable values from the given file, in addition
+                     to the default files terraform.tfvars and *.auto.tfvars.
+                     Use this option more than once to include more than one
+                     variables file.
+`
+}
@@ -0,0 +1,155 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/opentofu/opentofu/internal/bundle"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// BundleInstallCommand is a Command implementation that implements the
+// "tofu bundle install" command, which verifies a bundle created by
+// "tofu bundle create" and installs its contents into the local provider
+// mirror and module cache layouts.
+type BundleInstallCommand struct {
+	Meta
+}
+
+func (c *BundleInstallCommand) Synopsis() string {
+	return "Install the contents of a dependency bundle"
+}
+
+func (c *BundleInstallCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("bundle install")
+	var providersDir, binaryDir string
+	cmdFlags.StringVar(&providersDir, "providers-dir", "terraform.d/plugins", "provider mirror directory")
+	cmdFlags.StringVar(&binaryDir, "binary-dir", "", "directory for the OpenTofu executable")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No bundle file specified",
+			"The bundle install command requires the path of a bundle file as a command-line argument.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	bundleFile := args[0]
+
+	stagingDir, err := os.MkdirTemp("", "tofu-bundle")
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to install bundle",
+			fmt.Sprintf("Could not create a temporary directory to extract the bundle: %s.", err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	defer os.RemoveAll(stagingDir)
+
+	c.Ui.Output(fmt.Sprintf("- Verifying bundle %s...", bundleFile))
+	manifest, err := extractBundleFile(bundleFile, stagingDir)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid bundle",
+			fmt.Sprintf("The bundle %s could not be verified: %s.", bundleFile, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("  - Verified %d files created by OpenTofu v%s", len(manifest.Files), manifest.OpenTofuVersion))
+
+	if src := filepath.Join(stagingDir, bundle.ProvidersDir); dirExists(src) {
+		c.Ui.Output(fmt.Sprintf("- Installing provider packages into %s...", providersDir))
+		if err := copyBundleDir(src, providersDir); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to install provider packages",
+				fmt.Sprintf("Could not copy the bundled provider packages into %s: %s.", providersDir, err),
+			))
+		}
+	}
+
+	if src := filepath.Join(stagingDir, bundle.ModulesDir); dirExists(src) {
+		c.Ui.Output(fmt.Sprintf("- Installing modules into %s...", c.modulesDir()))
+		if err := copyBundleDir(src, c.modulesDir()); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to install modules",
+				fmt.Sprintf("Could not copy the bundled modules into %s: %s.", c.modulesDir(), err),
+			))
+		}
+	}
+
+	if src := filepath.Join(stagingDir, bundle.BinaryDir); dirExists(src) {
+		if binaryDir == "" {
+			c.Ui.Output("- Skipping the bundled OpenTofu executable because -binary-dir was not set")
+		} else {
+			c.Ui.Output(fmt.Sprintf("- Installing the OpenTofu executable into %s...", binaryDir))
+			if err := copyBundleDir(src, binaryDir); err != nil {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Failed to install the OpenTofu executable",
+					fmt.Sprintf("Could not copy the bundled executable into %s: %s.", binaryDir, err),
+				))
+			}
+		}
+	}
+
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+func extractBundleFile(filename, dstDir string) (*bundle.Manifest, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return bundle.Extract(f, dstDir)
+}
+
+func (c *BundleInstallCommand) Help() string {
+	return `
+Usage: tofu [global options] bundle install [options] <bundle-file>
+
+  Verifies a bundle created by "tofu bundle create" and installs its
+  contents, so that "tofu init" can succeed without network access to
+  provider registries or module sources.
+
+  Provider packages are installed into a filesystem mirror directory, and
+  modules are installed into the module cache of the current working
+  directory. Nothing is installed unless every file in the bundle matches
+  the checksums recorded when the bundle was created.
+
+Options:
+
+  -providers-dir=path  The filesystem mirror directory to install provider
+                       packages into. Defaults to "terraform.d/plugins", which
+                       OpenTofu searches automatically for the configuration
+                       in the current working directory.
+
+  -binary-dir=path     If the bundle includes the OpenTofu executable, install
+                       it into the given directory. By default the bundled
+                       executable is not installed.
+`
+}
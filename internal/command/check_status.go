@@ -0,0 +1,159 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// CheckStatusCommand is a Command implementation that prints the results of
+// the check blocks, preconditions, and postconditions that the most recent
+// plan or apply recorded in the state.
+type CheckStatusCommand struct {
+	Meta
+}
+
+func (c *CheckStatusCommand) Run(rawArgs []string) int {
+	// Parse and apply global view arguments
+	common, rawArgs := arguments.ParseView(rawArgs)
+	c.View.Configure(common)
+
+	// Parse and validate flags
+	args, diags := arguments.ParseCheckStatus(rawArgs)
+	if diags.HasErrors() {
+		c.View.Diagnostics(diags)
+		c.View.HelpPrompt("check-status")
+		return 1
+	}
+
+	view := views.NewCheckStatus(args.ViewType, c.View)
+
+	// Inject variables from args into meta for static evaluation
+	c.GatherVariables(args.Vars)
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	results, moreDiags := c.CheckResults(args.StatePath, enc)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	view.CheckStatus(results)
+	view.Diagnostics(diags)
+	return 0
+}
+
+// CheckResults returns the check results recorded in the state of the
+// currently-selected workspace, or nil if there are none.
+func (c *CheckStatusCommand) CheckResults(statePath string, enc encryption.Encryption) (*states.CheckResults, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	// Allow state path override
+	if statePath != "" {
+		c.Meta.statePath = statePath
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil, enc.State())
+	diags = diags.Append(backendDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	// This is a read-only command
+	c.ignoreRemoteVersionConflict(b)
+
+	env, err := c.Workspace()
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Error selecting workspace: %w", err))
+		return nil, diags
+	}
+
+	// Get the state
+	stateMgr, err := b.StateMgr(env)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Failed to load state: %w", err))
+		return nil, diags
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		diags = diags.Append(fmt.Errorf("Failed to load state: %w", err))
+		return nil, diags
+	}
+
+	state := stateMgr.State()
+	if state == nil {
+		return nil, diags
+	}
+	return state.CheckResults, diags
+}
+
+func (c *CheckStatusCommand) GatherVariables(args *arguments.Vars) {
+	// FIXME the arguments package currently trivially gathers variable related
+	// arguments in a heterogeneous slice, in order to minimize the number of
+	// code paths gathering variables during the transition to this structure.
+	// Once all commands that gather variables have been converted to this
+	// structure, we could move the variable gathering code to the arguments
+	// package directly, removing this shim layer.
+
+	varArgs := args.All()
+	items := make([]rawFlag, len(varArgs))
+	for i := range varArgs {
+		items[i].Name = varArgs[i].Name
+		items[i].Value = varArgs[i].Value
+	}
+	c.Meta.variableArgs = rawFlags{items: &items}
+}
+
+func (c *CheckStatusCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] check-status [options]
+
+  Reads the results of the check blocks, preconditions, and postconditions
+  that the most recent plan or apply recorded in the OpenTofu state, and
+  prints the status of each checkable object.
+
+  This command does not create a plan or contact any providers, so it is
+  cheap enough to run from monitoring systems between runs.
+
+Options:
+
+  -state=path        Path to the state file to read. Defaults to
+                     "terraform.tfstate". Ignored when remote
+                     state is used.
+
+  -no-color          If specified, output won't contain any color.
+
+  -json              If specified, machine readable output will be
+                     printed in JSON format.
+
+  -var 'foo=bar'     Set a value for one of the input variables in the root
+                     module of the configuration. Use this option more than
+                     once to set more than one variable.
+
+  -var-file=filename Load variable values from the given file, in addition
+                     to the default files terraform.tfvars and *.auto.tfvars.
+                     Use this option more than once to include more than one
+                     variables file.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *CheckStatusCommand) Synopsis() string {
+	return "Show the check results recorded in the state"
+}
@@ -0,0 +1,150 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func testCheckStatusState() *states.State {
+	state := testState()
+	resource := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "foo",
+	}
+	output := addrs.OutputValue{Name: "url"}
+	state.CheckResults = &states.CheckResults{
+		ConfigResults: addrs.MakeMap(
+			addrs.MakeMapElem[addrs.ConfigCheckable](
+				resource.InModule(addrs.RootModule),
+				&states.CheckResultAggregate{
+					Status: checks.StatusFail,
+					ObjectResults: addrs.MakeMap(
+						addrs.MakeMapElem[addrs.Checkable](
+							resource.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+							&states.CheckResultObject{
+								Status:          checks.StatusFail,
+								FailureMessages: []string{"Instance must be running."},
+							},
+						),
+					),
+				},
+			),
+			addrs.MakeMapElem[addrs.ConfigCheckable](
+				output.InModule(addrs.RootModule),
+				&states.CheckResultAggregate{
+					Status: checks.StatusPass,
+					ObjectResults: addrs.MakeMap(
+						addrs.MakeMapElem[addrs.Checkable](
+							output.Absolute(addrs.RootModuleInstance),
+							&states.CheckResultObject{
+								Status: checks.StatusPass,
+							},
+						),
+					),
+				},
+			),
+		),
+	}
+	return state
+}
+
+func TestCheckStatus(t *testing.T) {
+	statePath := testStateFile(t, testCheckStatusState())
+
+	view, done := testView(t)
+	c := &CheckStatusCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", "-state", statePath})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: \n%s", output.Stderr())
+	}
+
+	want := `output.url: pass
+  output.url: pass
+test_instance.foo: fail
+  test_instance.foo: fail
+    - Instance must be running.
+`
+	if got := output.Stdout(); got != want {
+		t.Fatalf("wrong output\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCheckStatus_json(t *testing.T) {
+	statePath := testStateFile(t, testCheckStatusState())
+
+	view, done := testView(t)
+	c := &CheckStatusCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-state", statePath, "-json"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: \n%s", output.Stderr())
+	}
+
+	var got []struct {
+		Address struct {
+			ToDisplay string `json:"to_display"`
+		} `json:"address"`
+		Status    string `json:"status"`
+		Instances []struct {
+			Status   string `json:"status"`
+			Problems []struct {
+				Message string `json:"message"`
+			} `json:"problems"`
+		} `json:"instances"`
+	}
+	if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, output.Stdout())
+	}
+	if len(got) != 2 {
+		t.Fatalf("wrong number of results: %d", len(got))
+	}
+	if got[1].Address.ToDisplay != "test_instance.foo" || got[1].Status != "fail" {
+		t.Fatalf("wrong result for the resource: %#v", got[1])
+	}
+	if problems := got[1].Instances[0].Problems; len(problems) != 1 || problems[0].Message != "Instance must be running." {
+		t.Fatalf("wrong problems for the resource: %#v", problems)
+	}
+}
+
+func TestCheckStatus_noResults(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	view, done := testView(t)
+	c := &CheckStatusCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-state", statePath})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: \n%s", output.Stderr())
+	}
+	if got, want := strings.TrimSpace(output.Stdout()), "The state does not contain any check results."; got != want {
+		t.Fatalf("wrong output\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
+
+	"github.com/opentofu/opentofu/internal/command/format"
 )
 
 // ColorizeUi is a Ui implementation that colors its output according
@@ -21,6 +23,10 @@ type ColorizeUi struct {
 	ErrorColor  string
 	WarnColor   string
 	Ui          cli.Ui
+
+	// NoUnicode replaces the box-drawing characters in all messages with
+	// ASCII characters, using format.ASCIIOnly.
+	NoUnicode bool
 }
 
 func (u *ColorizeUi) Ask(query string) (string, error) {
@@ -48,6 +54,9 @@ func (u *ColorizeUi) Warn(message string) {
 }
 
 func (u *ColorizeUi) colorize(message string, color string) string {
+	if u.NoUnicode {
+		message = format.ASCIIOnly(message)
+	}
 	if color == "" {
 		return message
 	}
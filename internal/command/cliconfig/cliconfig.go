@@ -18,19 +18,24 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl"
+	hclast "github.com/hashicorp/hcl/hcl/ast"
 
 	svchost "github.com/hashicorp/terraform-svchost"
 
+	"github.com/opentofu/opentofu/internal/command/format"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 const pluginCacheDirEnvVar = "TF_PLUGIN_CACHE_DIR"
 const pluginCacheMayBreakLockFileEnvVar = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
+const colorThemeEnvVar = "TF_COLOR_THEME"
+const noUnicodeEnvVar = "TF_NO_UNICODE"
 
 // Config is the structure of the configuration for the OpenTofu CLI.
 //
@@ -55,11 +60,68 @@ type Config struct {
 	// over the requirements of the dependency lock file.
 	PluginCacheMayBreakDependencyLockFile bool `hcl:"plugin_cache_may_break_dependency_lock_file"`
 
+	// If set, OpenTofu appends a JSON record to this file for every state
+	// snapshot it writes, as a local audit log of state changes.
+	AuditLog string `hcl:"audit_log"`
+
+	// EnforceEncryption makes state and plan encryption mandatory for every
+	// configuration, as if its encryption block set enforced = true.
+	EnforceEncryption bool `hcl:"enforce_encryption"`
+
 	Hosts map[string]*ConfigHost `hcl:"host"`
 
+	// ClientCertificatesByHost are the TLS client certificates that OpenTofu
+	// presents to registries and network mirrors that require mutual TLS
+	// authentication, keyed by hostname.
+	ClientCertificatesByHost map[string]*ConfigClientCertificate `hcl:"client_certificate"`
+
 	Credentials        map[string]map[string]interface{}   `hcl:"credentials"`
 	CredentialsHelpers map[string]*ConfigCredentialsHelper `hcl:"credentials_helper"`
 
+	// CostEstimators represents any cost_estimator blocks in the
+	// configuration. Only one of these is allowed across the whole
+	// configuration, but we decode into a slice here so that we can handle
+	// that validation at validation time rather than initial decode time.
+	CostEstimators []*ConfigCostEstimator
+
+	// ApplyWebhooks represents any apply_webhook blocks in the
+	// configuration. Only one of these is allowed across the whole
+	// configuration, but we decode into a slice here so that we can handle
+	// that validation at validation time rather than initial decode time.
+	ApplyWebhooks []*ConfigApplyWebhook
+
+	// ProviderChecksumPolicies represents any provider_checksum_policy
+	// blocks in the configuration. Only one of these is allowed across the
+	// whole configuration, but we decode into a slice here so that we can
+	// handle that validation at validation time rather than initial decode
+	// time.
+	ProviderChecksumPolicies []*ConfigProviderChecksumPolicy
+
+	// Upgrades represents any upgrade blocks in the configuration. Only one
+	// of these is allowed across the whole configuration, but we decode into
+	// a slice here so that we can handle that validation at validation time
+	// rather than initial decode time.
+	Upgrades []*ConfigUpgrade
+
+	// Proxies represents any proxy blocks in the configuration. Only one of
+	// these is allowed across the whole configuration, but we decode into a
+	// slice here so that we can handle that validation at validation time
+	// rather than initial decode time.
+	Proxies []*ConfigProxy
+
+	// CollapseAttributes lists attributes, in the form TYPE.ATTRIBUTE, whose
+	// in-place changes are known to be noisy and so are rendered as a
+	// one-line marker in plans.
+	CollapseAttributes []string `hcl:"collapse_attributes"`
+
+	// ColorTheme is the name of the color theme for human-oriented output,
+	// such as "colorblind". An empty string selects the default theme.
+	ColorTheme string `hcl:"color_theme"`
+
+	// NoUnicode replaces the box-drawing characters in human-oriented output
+	// with ASCII characters.
+	NoUnicode bool `hcl:"no_unicode"`
+
 	// ProviderInstallation represents any provider_installation blocks
 	// in the configuration. Only one of these is allowed across the whole
 	// configuration, but we decode into a slice here so that we can handle
@@ -74,6 +136,22 @@ type ConfigHost struct {
 	Services map[string]interface{} `hcl:"services"`
 }
 
+// ConfigCostEstimator is the structure of the "cost_estimator" nested block
+// within the CLI configuration, which configures an external program that
+// estimates the cost of the resource changes in a plan.
+type ConfigCostEstimator struct {
+	Command string   `hcl:"command"`
+	Args    []string `hcl:"args"`
+}
+
+// ConfigApplyWebhook is the structure of the "apply_webhook" nested block
+// within the CLI configuration, which configures an HTTP endpoint that
+// receives the result of each resource instance change during an apply.
+type ConfigApplyWebhook struct {
+	URL     string            `hcl:"url"`
+	Headers map[string]string `hcl:"headers"`
+}
+
 // ConfigCredentialsHelper is the structure of the "credentials_helper"
 // nested block within the CLI configuration.
 type ConfigCredentialsHelper struct {
@@ -184,6 +262,25 @@ func loadConfigFile(path string) (*Config, tfdiags.Diagnostics) {
 	diags = diags.Append(moreDiags)
 	result.ProviderInstallation = providerInstBlocks
 
+	// The other blocks that may appear only once are decoded separately too,
+	// because DecodeObject can't decode unlabeled blocks into a slice.
+	root := obj.Node.(*hclast.ObjectList)
+	if result.CostEstimators, err = decodeUnlabeledBlocks[ConfigCostEstimator](root, "cost_estimator"); err != nil {
+		diags = diags.Append(fmt.Errorf("Error parsing %s: %w", path, err))
+	}
+	if result.ProviderChecksumPolicies, err = decodeUnlabeledBlocks[ConfigProviderChecksumPolicy](root, "provider_checksum_policy"); err != nil {
+		diags = diags.Append(fmt.Errorf("Error parsing %s: %w", path, err))
+	}
+	if result.Upgrades, err = decodeUnlabeledBlocks[ConfigUpgrade](root, "upgrade"); err != nil {
+		diags = diags.Append(fmt.Errorf("Error parsing %s: %w", path, err))
+	}
+	if result.ApplyWebhooks, err = decodeUnlabeledBlocks[ConfigApplyWebhook](root, "apply_webhook"); err != nil {
+		diags = diags.Append(fmt.Errorf("Error parsing %s: %w", path, err))
+	}
+	if result.Proxies, err = decodeProxiesFromConfig(root); err != nil {
+		diags = diags.Append(fmt.Errorf("Error parsing %s: %w", path, err))
+	}
+
 	// Replace all env vars
 	for k, v := range result.Providers {
 		result.Providers[k] = os.ExpandEnv(v)
@@ -195,10 +292,53 @@ func loadConfigFile(path string) (*Config, tfdiags.Diagnostics) {
 	if result.PluginCacheDir != "" {
 		result.PluginCacheDir = os.ExpandEnv(result.PluginCacheDir)
 	}
+	if result.AuditLog != "" {
+		result.AuditLog = os.ExpandEnv(result.AuditLog)
+	}
+	for _, estimator := range result.CostEstimators {
+		estimator.Command = os.ExpandEnv(estimator.Command)
+	}
+	for _, cert := range result.ClientCertificatesByHost {
+		cert.CertFile = os.ExpandEnv(cert.CertFile)
+		cert.KeyFile = os.ExpandEnv(cert.KeyFile)
+	}
+	for _, proxy := range result.Proxies {
+		// Proxy URLs are expanded so that proxy credentials can be kept out
+		// of the configuration file.
+		for _, rule := range proxy.Rules {
+			rule.Proxy = os.ExpandEnv(rule.Proxy)
+		}
+	}
+	for _, webhook := range result.ApplyWebhooks {
+		// Headers are expanded too so that secrets such as bearer tokens
+		// can be kept out of the configuration file.
+		webhook.URL = os.ExpandEnv(webhook.URL)
+		for name, value := range webhook.Headers {
+			webhook.Headers[name] = os.ExpandEnv(value)
+		}
+	}
 
 	return result, diags
 }
 
+// decodeUnlabeledBlocks decodes each of the blocks of the given type in the
+// given list into a new T. The blocks must not have any labels.
+func decodeUnlabeledBlocks[T any](list *hclast.ObjectList, blockType string) ([]*T, error) {
+	var ret []*T
+	for _, item := range list.Filter(blockType).Items {
+		body, ok := item.Val.(*hclast.ObjectType)
+		if !ok || len(item.Keys) != 0 {
+			return nil, fmt.Errorf("%s: %s must be a block without labels", item.Pos(), blockType)
+		}
+		v := new(T)
+		if err := hcl.DecodeObject(v, body); err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
 func loadConfigDir(path string) (*Config, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 	result := &Config{}
@@ -257,6 +397,14 @@ func envConfig(env map[string]string) *Config {
 		config.PluginCacheMayBreakDependencyLockFile = true
 	}
 
+	if envColorTheme := env[colorThemeEnvVar]; envColorTheme != "" {
+		config.ColorTheme = envColorTheme
+	}
+
+	if envNoUnicode := env[noUnicodeEnvVar]; envNoUnicode != "" && envNoUnicode != "0" {
+		config.NoUnicode = true
+	}
+
 	return config
 }
 
@@ -303,6 +451,12 @@ func (c *Config) Validate() tfdiags.Diagnostics {
 		}
 	}
 
+	// Check that all "client_certificate" blocks have valid hostnames and
+	// name both a certificate and a key.
+	for givenHost, cert := range c.ClientCertificatesByHost {
+		diags = diags.Append(cert.validate(givenHost))
+	}
+
 	// Check that all "credentials" blocks have valid hostnames.
 	for givenHost := range c.Credentials {
 		_, err := svchost.ForComparison(givenHost)
@@ -320,6 +474,80 @@ func (c *Config) Validate() tfdiags.Diagnostics {
 		)
 	}
 
+	// Should have zero or one "cost_estimator" blocks
+	if len(c.CostEstimators) > 1 {
+		diags = diags.Append(
+			fmt.Errorf("No more than one cost_estimator block may be specified"),
+		)
+	}
+	for _, estimator := range c.CostEstimators {
+		if estimator.Command == "" {
+			diags = diags.Append(
+				fmt.Errorf("The cost_estimator block must set the command argument"),
+			)
+		}
+	}
+
+	// Should have zero or one "apply_webhook" blocks
+	if len(c.ApplyWebhooks) > 1 {
+		diags = diags.Append(
+			fmt.Errorf("No more than one apply_webhook block may be specified"),
+		)
+	}
+	for _, webhook := range c.ApplyWebhooks {
+		if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			diags = diags.Append(
+				fmt.Errorf("The apply_webhook block must set the url argument to an absolute http or https URL"),
+			)
+		}
+	}
+
+	// Should have zero or one "provider_checksum_policy" blocks
+	if len(c.ProviderChecksumPolicies) > 1 {
+		diags = diags.Append(
+			fmt.Errorf("No more than one provider_checksum_policy block may be specified"),
+		)
+	}
+	for _, policy := range c.ProviderChecksumPolicies {
+		diags = diags.Append(policy.validate())
+	}
+
+	// Should have zero or one "upgrade" blocks
+	if len(c.Upgrades) > 1 {
+		diags = diags.Append(
+			fmt.Errorf("No more than one upgrade block may be specified"),
+		)
+	}
+	for _, upgrade := range c.Upgrades {
+		diags = diags.Append(upgrade.validate())
+	}
+
+	// Should have zero or one "proxy" blocks
+	if len(c.Proxies) > 1 {
+		diags = diags.Append(
+			fmt.Errorf("No more than one proxy block may be specified"),
+		)
+	}
+	for _, proxy := range c.Proxies {
+		diags = diags.Append(proxy.validate())
+	}
+
+	for _, attr := range c.CollapseAttributes {
+		if _, _, ok := parseCollapseAttribute(attr); !ok {
+			diags = diags.Append(
+				fmt.Errorf("The collapse_attributes entry %q is invalid: must be a resource type and a top-level attribute name separated by a period, like aws_iam_policy.policy", attr),
+			)
+		}
+	}
+
+	if c.ColorTheme != "" {
+		if _, ok := format.ColorThemeColors(c.ColorTheme); !ok {
+			diags = diags.Append(
+				fmt.Errorf("The color_theme %q is not supported; must be one of %s", c.ColorTheme, strings.Join(format.ColorThemeNames(), ", ")),
+			)
+		}
+	}
+
 	// Should have zero or one "provider_installation" blocks
 	if len(c.ProviderInstallation) > 1 {
 		diags = diags.Append(
@@ -369,12 +597,42 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.PluginCacheDir = c2.PluginCacheDir
 	}
 
+	result.AuditLog = c.AuditLog
+	if result.AuditLog == "" {
+		result.AuditLog = c2.AuditLog
+	}
+
+	result.ColorTheme = c.ColorTheme
+	if result.ColorTheme == "" {
+		result.ColorTheme = c2.ColorTheme
+	}
+
+	if c.NoUnicode || c2.NoUnicode {
+		result.NoUnicode = true
+	}
+
 	if c.PluginCacheMayBreakDependencyLockFile || c2.PluginCacheMayBreakDependencyLockFile {
 		// This setting saturates to "on"; once either configuration sets it,
 		// there is no way to override it back to off again.
 		result.PluginCacheMayBreakDependencyLockFile = true
 	}
 
+	if c.EnforceEncryption || c2.EnforceEncryption {
+		// Like the setting above, this saturates to "on" so that a
+		// configuration file can't switch off a policy set in another.
+		result.EnforceEncryption = true
+	}
+
+	if (len(c.ClientCertificatesByHost) + len(c2.ClientCertificatesByHost)) > 0 {
+		result.ClientCertificatesByHost = make(map[string]*ConfigClientCertificate)
+		for host, cert := range c.ClientCertificatesByHost {
+			result.ClientCertificatesByHost[host] = cert
+		}
+		for host, cert := range c2.ClientCertificatesByHost {
+			result.ClientCertificatesByHost[host] = cert
+		}
+	}
+
 	if (len(c.Hosts) + len(c2.Hosts)) > 0 {
 		result.Hosts = make(map[string]*ConfigHost)
 		for name, host := range c.Hosts {
@@ -408,6 +666,36 @@ func (c *Config) Merge(c2 *Config) *Config {
 		}
 	}
 
+	if (len(c.CostEstimators) + len(c2.CostEstimators)) > 0 {
+		result.CostEstimators = append(result.CostEstimators, c.CostEstimators...)
+		result.CostEstimators = append(result.CostEstimators, c2.CostEstimators...)
+	}
+
+	if (len(c.ApplyWebhooks) + len(c2.ApplyWebhooks)) > 0 {
+		result.ApplyWebhooks = append(result.ApplyWebhooks, c.ApplyWebhooks...)
+		result.ApplyWebhooks = append(result.ApplyWebhooks, c2.ApplyWebhooks...)
+	}
+
+	if (len(c.ProviderChecksumPolicies) + len(c2.ProviderChecksumPolicies)) > 0 {
+		result.ProviderChecksumPolicies = append(result.ProviderChecksumPolicies, c.ProviderChecksumPolicies...)
+		result.ProviderChecksumPolicies = append(result.ProviderChecksumPolicies, c2.ProviderChecksumPolicies...)
+	}
+
+	if (len(c.Upgrades) + len(c2.Upgrades)) > 0 {
+		result.Upgrades = append(result.Upgrades, c.Upgrades...)
+		result.Upgrades = append(result.Upgrades, c2.Upgrades...)
+	}
+
+	if (len(c.Proxies) + len(c2.Proxies)) > 0 {
+		result.Proxies = append(result.Proxies, c.Proxies...)
+		result.Proxies = append(result.Proxies, c2.Proxies...)
+	}
+
+	if (len(c.CollapseAttributes) + len(c2.CollapseAttributes)) > 0 {
+		result.CollapseAttributes = append(result.CollapseAttributes, c.CollapseAttributes...)
+		result.CollapseAttributes = append(result.CollapseAttributes, c2.CollapseAttributes...)
+	}
+
 	if (len(c.ProviderInstallation) + len(c2.ProviderInstallation)) > 0 {
 		result.ProviderInstallation = append(result.ProviderInstallation, c.ProviderInstallation...)
 		result.ProviderInstallation = append(result.ProviderInstallation, c2.ProviderInstallation...)
@@ -459,3 +747,41 @@ func cliConfigFileOverride() string {
 	}
 	return configFilePath
 }
+
+// CollapsedAttributes returns the valid entries of CollapseAttributes as a
+// map from resource type to attribute names.
+func (c *Config) CollapsedAttributes() map[string][]string {
+	if len(c.CollapseAttributes) == 0 {
+		return nil
+	}
+	ret := make(map[string][]string)
+	for _, attr := range c.CollapseAttributes {
+		typeName, attrName, ok := parseCollapseAttribute(attr)
+		if !ok {
+			// Already reported by Validate.
+			continue
+		}
+		ret[typeName] = append(ret[typeName], attrName)
+	}
+	return ret
+}
+
+// ColorThemeColors returns the colors of the selected color theme, in the
+// form expected by colorstring.Colorize, or those of the default theme if
+// ColorTheme doesn't name a valid theme.
+func (c *Config) ColorThemeColors() map[string]string {
+	colors, ok := format.ColorThemeColors(c.ColorTheme)
+	if !ok {
+		// Already reported by Validate.
+		colors, _ = format.ColorThemeColors(format.DefaultColorTheme)
+	}
+	return colors
+}
+
+func parseCollapseAttribute(attr string) (string, string, bool) {
+	typeName, attrName, ok := strings.Cut(attr, ".")
+	if !ok || typeName == "" || attrName == "" || strings.Contains(attrName, ".") {
+		return "", "", false
+	}
+	return typeName, attrName, true
+}
@@ -6,6 +6,7 @@
 package cliconfig
 
 import (
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -13,6 +14,10 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/selfupdate"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -115,6 +120,22 @@ func TestEnvConfig(t *testing.T) {
 			},
 			&Config{},
 		},
+		"TF_COLOR_THEME and TF_NO_UNICODE": {
+			map[string]string{
+				"TF_COLOR_THEME": "colorblind",
+				"TF_NO_UNICODE":  "1",
+			},
+			&Config{
+				ColorTheme: "colorblind",
+				NoUnicode:  true,
+			},
+		},
+		"TF_NO_UNICODE=0": {
+			map[string]string{
+				"TF_NO_UNICODE": "0",
+			},
+			&Config{},
+		},
 		"TF_PLUGIN_CACHE_DIR and TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE": {
 			map[string]string{
 				"TF_PLUGIN_CACHE_DIR":                            "beep",
@@ -268,6 +289,214 @@ func TestLoadConfig_credentials(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_costEstimator(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "cost-estimator"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		CostEstimators: []*ConfigCostEstimator{
+			{
+				Command: "/usr/local/bin/estimate-costs",
+				Args:    []string{"--region", "eu-west-1"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+}
+
+func TestLoadConfig_applyWebhook(t *testing.T) {
+	t.Setenv("TF_TEST_APPLY_WEBHOOK_TOKEN", "secret")
+
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "apply-webhook"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		ApplyWebhooks: []*ConfigApplyWebhook{
+			{
+				URL: "https://deploys.example.com/hooks/opentofu",
+				Headers: map[string]string{
+					"Authorization": "Bearer secret",
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+}
+
+func TestLoadConfig_providerChecksumPolicy(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-checksum-policy"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		ProviderChecksumPolicies: []*ConfigProviderChecksumPolicy{
+			{
+				AllowedHashSchemes:  []string{"h1"},
+				RequiredHashSchemes: []string{"h1"},
+				SigningKeys: map[string]*ConfigProviderSigningKeys{
+					"registry.opentofu.org/hashicorp": {
+						KeyIDs: []string{"0C0AF313E5FD9F80"},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	gotPolicy := got.ProviderChecksumPolicy()
+	wantPolicy := &providercache.ChecksumPolicy{
+		AllowedHashSchemes:  []getproviders.HashScheme{getproviders.HashScheme1},
+		RequiredHashSchemes: []getproviders.HashScheme{getproviders.HashScheme1},
+		SigningKeys: map[string][]string{
+			"registry.opentofu.org/hashicorp": {"0C0AF313E5FD9F80"},
+		},
+	}
+	if !reflect.DeepEqual(gotPolicy, wantPolicy) {
+		t.Errorf("wrong policy\ngot:  %swant: %s", spew.Sdump(gotPolicy), spew.Sdump(wantPolicy))
+	}
+}
+
+func TestLoadConfig_upgrade(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "upgrade"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		Upgrades: []*ConfigUpgrade{
+			{
+				Channel:        "prerelease",
+				Version:        "~> 1.8.0",
+				DownloadURL:    "https://mirror.example.com/opentofu",
+				SigningKeyFile: "/etc/opentofu/release-key.asc",
+
+				SigningKeyFingerprint: "E3E6 E43D 84CB 852E ADB0 051D 0C0A F313 E5FD 9F80",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	gotPolicy := got.UpgradePolicy()
+	wantPolicy := &selfupdate.Policy{
+		Channel:        selfupdate.ChannelPrerelease,
+		Constraints:    getproviders.MustParseVersionConstraints("~> 1.8.0"),
+		DownloadURL:    "https://mirror.example.com/opentofu",
+		SigningKeyFile: "/etc/opentofu/release-key.asc",
+
+		SigningKeyFingerprint: "E3E6 E43D 84CB 852E ADB0 051D 0C0A F313 E5FD 9F80",
+	}
+	if !reflect.DeepEqual(gotPolicy, wantPolicy) {
+		t.Errorf("wrong policy\ngot:  %swant: %s", spew.Sdump(gotPolicy), spew.Sdump(wantPolicy))
+	}
+}
+
+func TestLoadConfig_proxy(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "proxy"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		Proxies: []*ConfigProxy{
+			{
+				Rules: []*ConfigProxyRule{
+					{
+						Hosts: []string{"registry.opentofu.org", "*.corp.example.com"},
+						Proxy: "http://proxy.corp.example.com:3128",
+					},
+					{
+						Hosts: []string{"*.internal"},
+						Proxy: "direct",
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	gotProxy := got.ProxyConfig()
+	wantProxy := &httpclient.ProxyConfig{
+		Rules: []httpclient.ProxyRule{
+			{
+				Hosts: []string{"registry.opentofu.org", "*.corp.example.com"},
+				Proxy: &url.URL{Scheme: "http", Host: "proxy.corp.example.com:3128"},
+			},
+			{
+				Hosts: []string{"*.internal"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(gotProxy, wantProxy) {
+		t.Errorf("wrong proxy config\ngot:  %swant: %s", spew.Sdump(gotProxy), spew.Sdump(wantProxy))
+	}
+}
+
+func TestLoadConfig_clientCertificates(t *testing.T) {
+	t.Setenv("TF_TEST_CERT_DIR", "/home/tofu/certs")
+
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "client-certificates"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		ClientCertificatesByHost: map[string]*ConfigClientCertificate{
+			"registry.example.com": {
+				CertFile: "/home/tofu/certs/registry.crt",
+				KeyFile:  "/home/tofu/certs/registry.key",
+			},
+			"mirror.example.com": {
+				CertFile: "/etc/tofu/mirror.crt",
+				KeyFile:  "/etc/tofu/mirror.key",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	if _, err := got.ClientCertificates(); err == nil {
+		t.Error("expected an error loading certificate files that don't exist")
+	}
+}
+
+func TestConfigCollapsedAttributes(t *testing.T) {
+	config := &Config{
+		CollapseAttributes: []string{
+			"aws_iam_policy.policy",
+			"aws_iam_role.assume_role_policy",
+			"aws_iam_role.inline_policy",
+			"invalid",
+		},
+	}
+
+	got := config.CollapsedAttributes()
+	want := map[string][]string{
+		"aws_iam_policy": {"policy"},
+		"aws_iam_role":   {"assume_role_policy", "inline_policy"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+}
+
 func TestConfigValidate(t *testing.T) {
 	tests := map[string]struct {
 		Config    *Config
@@ -334,6 +563,210 @@ func TestConfigValidate(t *testing.T) {
 			},
 			1, // no more than one credentials_helper block allowed
 		},
+		"cost_estimator good": {
+			&Config{
+				CostEstimators: []*ConfigCostEstimator{
+					{Command: "estimate-costs"},
+				},
+			},
+			0,
+		},
+		"cost_estimator without command": {
+			&Config{
+				CostEstimators: []*ConfigCostEstimator{
+					{},
+				},
+			},
+			1, // command is required
+		},
+		"cost_estimator too many": {
+			&Config{
+				CostEstimators: []*ConfigCostEstimator{
+					{Command: "foo"},
+					{Command: "bar"},
+				},
+			},
+			1, // no more than one cost_estimator block allowed
+		},
+		"apply_webhook good": {
+			&Config{
+				ApplyWebhooks: []*ConfigApplyWebhook{
+					{URL: "https://example.com/hook"},
+				},
+			},
+			0,
+		},
+		"apply_webhook without url": {
+			&Config{
+				ApplyWebhooks: []*ConfigApplyWebhook{
+					{},
+				},
+			},
+			1, // url is required
+		},
+		"apply_webhook relative url": {
+			&Config{
+				ApplyWebhooks: []*ConfigApplyWebhook{
+					{URL: "/hook"},
+				},
+			},
+			1, // url must be absolute
+		},
+		"apply_webhook too many": {
+			&Config{
+				ApplyWebhooks: []*ConfigApplyWebhook{
+					{URL: "https://example.com/a"},
+					{URL: "https://example.com/b"},
+				},
+			},
+			1, // no more than one apply_webhook block allowed
+		},
+		"provider_checksum_policy good": {
+			&Config{
+				ProviderChecksumPolicies: []*ConfigProviderChecksumPolicy{
+					{
+						AllowedHashSchemes:  []string{"h1"},
+						RequiredHashSchemes: []string{"h1"},
+						SigningKeys: map[string]*ConfigProviderSigningKeys{
+							"registry.opentofu.org/hashicorp": {KeyIDs: []string{"0C0AF313E5FD9F80"}},
+						},
+					},
+				},
+			},
+			0,
+		},
+		"provider_checksum_policy invalid": {
+			&Config{
+				ProviderChecksumPolicies: []*ConfigProviderChecksumPolicy{
+					{
+						AllowedHashSchemes:  []string{"h1", "md5"},
+						RequiredHashSchemes: []string{"zh"},
+						SigningKeys: map[string]*ConfigProviderSigningKeys{
+							"hashicorp": {},
+						},
+					},
+				},
+			},
+			4, // unknown scheme, required scheme not allowed, bad namespace, no key IDs
+		},
+		"provider_checksum_policy too many": {
+			&Config{
+				ProviderChecksumPolicies: []*ConfigProviderChecksumPolicy{
+					{},
+					{},
+				},
+			},
+			1, // no more than one provider_checksum_policy block allowed
+		},
+		"upgrade good": {
+			&Config{
+				Upgrades: []*ConfigUpgrade{
+					{Channel: "stable", Version: ">= 1.8.0"},
+				},
+			},
+			0,
+		},
+		"upgrade invalid": {
+			&Config{
+				Upgrades: []*ConfigUpgrade{
+					{Channel: "nightly", Version: "latest"},
+				},
+			},
+			2, // unknown channel, invalid version constraint
+		},
+		"upgrade too many": {
+			&Config{
+				Upgrades: []*ConfigUpgrade{
+					{},
+					{},
+				},
+			},
+			1, // no more than one upgrade block allowed
+		},
+		"proxy good": {
+			&Config{
+				Proxies: []*ConfigProxy{
+					{
+						Rules: []*ConfigProxyRule{
+							{Hosts: []string{"*"}, Proxy: "socks5://proxy.example.com:1080"},
+							{Hosts: []string{"example.com", "*.example.com"}, Proxy: "DIRECT"},
+						},
+					},
+				},
+			},
+			0,
+		},
+		"proxy invalid": {
+			&Config{
+				Proxies: []*ConfigProxy{
+					{
+						Rules: []*ConfigProxyRule{
+							{Hosts: []string{"example.*", "example.com:443"}, Proxy: "ftp://proxy.example.com"},
+							{Proxy: "proxy.example.com"},
+						},
+					},
+				},
+			},
+			5, // two invalid hosts, unsupported scheme, no hosts, not a URL
+		},
+		"proxy too many": {
+			&Config{
+				Proxies: []*ConfigProxy{
+					{},
+					{},
+				},
+			},
+			1, // no more than one proxy block allowed
+		},
+		"client_certificate good": {
+			&Config{
+				ClientCertificatesByHost: map[string]*ConfigClientCertificate{
+					"registry.example.com": {
+						CertFile: "registry.crt",
+						KeyFile:  "registry.key",
+					},
+				},
+			},
+			0,
+		},
+		"client_certificate invalid": {
+			&Config{
+				ClientCertificatesByHost: map[string]*ConfigClientCertificate{
+					"example..com": {
+						CertFile: "registry.crt",
+						KeyFile:  "registry.key",
+					},
+					"mirror.example.com": {
+						CertFile: "mirror.crt",
+					},
+				},
+			},
+			2, // invalid hostname, no key file
+		},
+		"collapse_attributes good": {
+			&Config{
+				CollapseAttributes: []string{"aws_iam_policy.policy"},
+			},
+			0,
+		},
+		"collapse_attributes invalid": {
+			&Config{
+				CollapseAttributes: []string{"aws_iam_policy", "aws_iam_policy.policy.x", ".policy"},
+			},
+			3, // each entry must be TYPE.ATTRIBUTE
+		},
+		"color_theme good": {
+			&Config{
+				ColorTheme: "colorblind",
+			},
+			0,
+		},
+		"color_theme invalid": {
+			&Config{
+				ColorTheme: "rainbow",
+			},
+			1, // must be the name of a color theme
+		},
 		"provider_installation good none": {
 			&Config{
 				ProviderInstallation: nil,
@@ -403,6 +836,7 @@ func TestConfig_Merge(t *testing.T) {
 		CredentialsHelpers: map[string]*ConfigCredentialsHelper{
 			"buz": {},
 		},
+		ColorTheme: "colorblind",
 		ProviderInstallation: []*ProviderInstallation{
 			{
 				Methods: []*ProviderInstallationMethod{
@@ -449,6 +883,8 @@ func TestConfig_Merge(t *testing.T) {
 			},
 		},
 		PluginCacheMayBreakDependencyLockFile: true,
+		ColorTheme:                            "light",
+		NoUnicode:                             true,
 	}
 
 	expected := &Config{
@@ -504,6 +940,8 @@ func TestConfig_Merge(t *testing.T) {
 			},
 		},
 		PluginCacheMayBreakDependencyLockFile: true,
+		ColorTheme:                            "colorblind",
+		NoUnicode:                             true,
 	}
 
 	actual := c1.Merge(c2)
@@ -0,0 +1,65 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ConfigClientCertificate is the structure of the "client_certificate"
+// nested block within the CLI configuration, which selects the TLS client
+// certificate that OpenTofu presents to a particular host.
+type ConfigClientCertificate struct {
+	CertFile string `hcl:"cert_file"`
+	KeyFile  string `hcl:"key_file"`
+}
+
+func (c *ConfigClientCertificate) validate(givenHost string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if _, err := svchost.ForComparison(givenHost); err != nil {
+		diags = diags.Append(
+			fmt.Errorf("The client_certificate %q block has an invalid hostname: %w", givenHost, err),
+		)
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		diags = diags.Append(
+			fmt.Errorf("The client_certificate %q block must set both cert_file and key_file", givenHost),
+		)
+	}
+
+	return diags
+}
+
+// ClientCertificates loads the TLS client certificates selected in the CLI
+// configuration, keyed by the host they are presented to. It returns an
+// error if any of the certificates can't be loaded.
+//
+// The result is meaningful only for a configuration that has passed
+// validation.
+func (c *Config) ClientCertificates() (map[svchost.Hostname]tls.Certificate, error) {
+	if len(c.ClientCertificatesByHost) == 0 {
+		return nil, nil
+	}
+
+	ret := make(map[svchost.Hostname]tls.Certificate, len(c.ClientCertificatesByHost))
+	for givenHost, config := range c.ClientCertificatesByHost {
+		// Invalid hostnames are caught during validation.
+		host, err := svchost.ForComparison(givenHost)
+		if err != nil {
+			continue
+		}
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate for %s: %w", host.ForDisplay(), err)
+		}
+		ret[host] = cert
+	}
+	return ret, nil
+}
@@ -12,6 +12,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/zclconf/go-cty/cty"
@@ -277,6 +278,35 @@ func (s *CredentialsSource) HostCredentialsLocation(host svchost.Hostname) Crede
 	return CredentialsNotAvailable
 }
 
+// HostCredentialsInEnvironment returns true if the credentials for the given
+// hostname come from a TF_TOKEN_ environment variable, which takes precedence
+// over the location returned by HostCredentialsLocation.
+func (s *CredentialsSource) HostCredentialsInEnvironment(host svchost.Hostname) bool {
+	return hostCredentialsFromEnv(host) != nil
+}
+
+// ConfiguredHosts returns the hostnames that have credentials set in the
+// environment or in the CLI configuration, in lexical order. Any hosts that
+// only a credentials helper has credentials for are not included, because
+// helpers can't list their hosts.
+func (s *CredentialsSource) ConfiguredHosts() []svchost.Hostname {
+	seen := make(map[svchost.Hostname]struct{})
+	for host := range collectCredentialsFromEnv() {
+		seen[host] = struct{}{}
+	}
+	for host := range s.configured {
+		seen[host] = struct{}{}
+	}
+	ret := make([]svchost.Hostname, 0, len(seen))
+	for host := range seen {
+		ret = append(ret, host)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
 // CredentialsFilePath returns the full path to the local credentials
 // configuration file, so that a caller can mention this path in order to
 // be transparent about where credentials will be stored.
@@ -197,6 +197,33 @@ func TestCredentialsForHost(t *testing.T) {
 	})
 }
 
+func TestCredentialsConfiguredHosts(t *testing.T) {
+	credSrc := &CredentialsSource{
+		configured: map[svchost.Hostname]cty.Value{
+			"configured.example.com": cty.ObjectVal(map[string]cty.Value{
+				"token": cty.StringVal("configured"),
+			}),
+			"both.example.com": cty.ObjectVal(map[string]cty.Value{
+				"token": cty.StringVal("configured"),
+			}),
+		},
+	}
+	t.Setenv("TF_TOKEN_env_example_com", "from-env")
+	t.Setenv("TF_TOKEN_both_example_com", "from-env")
+
+	want := []svchost.Hostname{"both.example.com", "configured.example.com", "env.example.com"}
+	if diff := cmp.Diff(want, credSrc.ConfiguredHosts()); diff != "" {
+		t.Errorf("wrong hosts\n%s", diff)
+	}
+
+	if !credSrc.HostCredentialsInEnvironment("both.example.com") {
+		t.Error("both.example.com credentials should come from the environment")
+	}
+	if credSrc.HostCredentialsInEnvironment("configured.example.com") {
+		t.Error("configured.example.com credentials should not come from the environment")
+	}
+}
+
 func TestCredentialsStoreForget(t *testing.T) {
 	d := t.TempDir()
 
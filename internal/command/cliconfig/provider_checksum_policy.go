@@ -0,0 +1,133 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ConfigProviderChecksumPolicy is the structure of the
+// "provider_checksum_policy" nested block within the CLI configuration, which
+// imposes additional requirements on the provenance of the provider packages
+// that "tofu init" installs.
+type ConfigProviderChecksumPolicy struct {
+	AllowedHashSchemes  []string                              `hcl:"allowed_hash_schemes"`
+	RequiredHashSchemes []string                              `hcl:"required_hash_schemes"`
+	SigningKeys         map[string]*ConfigProviderSigningKeys `hcl:"signing_keys"`
+}
+
+// ConfigProviderSigningKeys is the structure of the "signing_keys" nested
+// block within a "provider_checksum_policy" block, whose label is a provider
+// namespace given as "hostname/namespace".
+type ConfigProviderSigningKeys struct {
+	KeyIDs []string `hcl:"key_ids"`
+}
+
+// checksumPolicyHashSchemes are the hash scheme names that can be used in
+// a provider_checksum_policy block, without their trailing colon.
+var checksumPolicyHashSchemes = map[string]getproviders.HashScheme{
+	"h1": getproviders.HashScheme1,
+	"zh": getproviders.HashSchemeZip,
+}
+
+func (p *ConfigProviderChecksumPolicy) validate() tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	for _, names := range [][]string{p.AllowedHashSchemes, p.RequiredHashSchemes} {
+		for _, name := range names {
+			if _, ok := checksumPolicyHashSchemes[name]; !ok {
+				diags = diags.Append(
+					fmt.Errorf("The provider_checksum_policy block has an invalid hash scheme %q: must be either \"h1\" or \"zh\"", name),
+				)
+			}
+		}
+	}
+	if len(p.AllowedHashSchemes) > 0 {
+		for _, name := range p.RequiredHashSchemes {
+			if !slices.Contains(p.AllowedHashSchemes, name) {
+				diags = diags.Append(
+					fmt.Errorf("The provider_checksum_policy block requires the hash scheme %q, which is not included in allowed_hash_schemes", name),
+				)
+			}
+		}
+	}
+
+	for given, keys := range p.SigningKeys {
+		if _, err := parseChecksumPolicyNamespace(given); err != nil {
+			diags = diags.Append(
+				fmt.Errorf("The provider_checksum_policy signing_keys %q block has an invalid provider namespace: %w", given, err),
+			)
+		}
+		if keys == nil || len(keys.KeyIDs) == 0 {
+			diags = diags.Append(
+				fmt.Errorf("The provider_checksum_policy signing_keys %q block must list at least one key ID in key_ids", given),
+			)
+		}
+	}
+
+	return diags
+}
+
+// ProviderChecksumPolicy returns the provider checksum policy selected in the
+// CLI configuration, or nil if there isn't one.
+//
+// The result is meaningful only for a configuration that has passed
+// validation.
+func (c *Config) ProviderChecksumPolicy() *providercache.ChecksumPolicy {
+	if len(c.ProviderChecksumPolicies) == 0 {
+		return nil
+	}
+	// Config.Validate rejects more than one provider_checksum_policy block.
+	config := c.ProviderChecksumPolicies[0]
+
+	ret := &providercache.ChecksumPolicy{}
+	for _, name := range config.AllowedHashSchemes {
+		ret.AllowedHashSchemes = append(ret.AllowedHashSchemes, checksumPolicyHashSchemes[name])
+	}
+	for _, name := range config.RequiredHashSchemes {
+		ret.RequiredHashSchemes = append(ret.RequiredHashSchemes, checksumPolicyHashSchemes[name])
+	}
+	if len(config.SigningKeys) > 0 {
+		ret.SigningKeys = make(map[string][]string, len(config.SigningKeys))
+		for given, keys := range config.SigningKeys {
+			namespace, err := parseChecksumPolicyNamespace(given)
+			if err != nil || keys == nil {
+				continue // invalid namespaces are caught during validation
+			}
+			ret.SigningKeys[namespace] = append(ret.SigningKeys[namespace], keys.KeyIDs...)
+		}
+	}
+	return ret
+}
+
+// parseChecksumPolicyNamespace parses a provider namespace written as
+// "hostname/namespace" and returns it in the normalized form expected by
+// providercache.ChecksumPolicy.
+func parseChecksumPolicyNamespace(given string) (string, error) {
+	givenHost, givenNamespace, ok := strings.Cut(given, "/")
+	if !ok || strings.Contains(givenNamespace, "/") {
+		return "", fmt.Errorf("must be a hostname and a namespace separated by a slash, like registry.opentofu.org/hashicorp")
+	}
+	host, err := svchost.ForComparison(givenHost)
+	if err != nil {
+		return "", err
+	}
+	namespace, err := addrs.ParseProviderPart(givenNamespace)
+	if err != nil {
+		return "", err
+	}
+	return providercache.ChecksumPolicyNamespace(addrs.Provider{
+		Hostname:  host,
+		Namespace: namespace,
+	}), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	hclast "github.com/hashicorp/hcl/hcl/ast"
+
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// proxyDirect is the value of the proxy argument of a proxy rule that makes
+// OpenTofu connect to the matching hosts directly.
+const proxyDirect = "direct"
+
+// ConfigProxy is the structure of the "proxy" nested block within the CLI
+// configuration, which selects the proxies used for outbound HTTP requests
+// in place of the proxy environment variables.
+type ConfigProxy struct {
+	Rules []*ConfigProxyRule
+}
+
+// ConfigProxyRule is the structure of the "rule" nested block within a
+// "proxy" block, which selects the proxy for a set of hosts.
+type ConfigProxyRule struct {
+	Hosts []string `hcl:"hosts"`
+	Proxy string   `hcl:"proxy"`
+}
+
+// decodeProxiesFromConfig decodes the proxy blocks in the given list,
+// including their nested rule blocks.
+func decodeProxiesFromConfig(list *hclast.ObjectList) ([]*ConfigProxy, error) {
+	proxies, err := decodeUnlabeledBlocks[ConfigProxy](list, "proxy")
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range list.Filter("proxy").Items {
+		body := item.Val.(*hclast.ObjectType)
+		if proxies[i].Rules, err = decodeUnlabeledBlocks[ConfigProxyRule](body.List, "rule"); err != nil {
+			return nil, err
+		}
+	}
+	return proxies, nil
+}
+
+func (p *ConfigProxy) validate() tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	for _, rule := range p.Rules {
+		if len(rule.Hosts) == 0 {
+			diags = diags.Append(
+				fmt.Errorf("Each rule block in the proxy block must set the hosts argument"),
+			)
+		}
+		for _, host := range rule.Hosts {
+			if !validProxyRuleHost(host) {
+				diags = diags.Append(
+					fmt.Errorf("The proxy rule host %q is invalid: must be a host name, optionally starting with \"*.\" to match its subdomains, or \"*\" to match all hosts", host),
+				)
+			}
+		}
+		if _, err := parseProxyURL(rule.Proxy); err != nil {
+			diags = diags.Append(
+				fmt.Errorf("The proxy rule for %s has an invalid proxy: %w", strings.Join(rule.Hosts, ", "), err),
+			)
+		}
+	}
+
+	return diags
+}
+
+func validProxyRuleHost(host string) bool {
+	if host == "*" {
+		return true
+	}
+	name := strings.TrimPrefix(host, "*.")
+	return name != "" && !strings.ContainsAny(name, "*/:")
+}
+
+// parseProxyURL parses the proxy argument of a proxy rule, returning nil for
+// "direct".
+func parseProxyURL(raw string) (*url.URL, error) {
+	if strings.EqualFold(raw, proxyDirect) {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("must be %q or an absolute URL, such as http://proxy.example.com:3128", proxyDirect)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q: must be http, https or socks5", u.Scheme)
+	}
+}
+
+// ProxyConfig returns the proxy configuration selected in the CLI
+// configuration, or nil if there isn't one.
+//
+// The result is meaningful only for a configuration that has passed
+// validation.
+func (c *Config) ProxyConfig() *httpclient.ProxyConfig {
+	if len(c.Proxies) == 0 {
+		return nil
+	}
+	// Config.Validate rejects more than one proxy block.
+	config := c.Proxies[0]
+
+	ret := &httpclient.ProxyConfig{}
+	for _, rule := range config.Rules {
+		// Invalid proxies are caught during validation.
+		proxy, _ := parseProxyURL(rule.Proxy)
+		ret.Rules = append(ret.Rules, httpclient.ProxyRule{
+			Hosts: rule.Hosts,
+			Proxy: proxy,
+		})
+	}
+	return ret
+}
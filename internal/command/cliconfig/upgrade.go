@@ -0,0 +1,97 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/selfupdate"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ConfigUpgrade is the structure of the "upgrade" nested block within the CLI
+// configuration, which controls which releases "tofu upgrade" installs and
+// where it gets them from.
+type ConfigUpgrade struct {
+	Channel        string `hcl:"channel"`
+	Version        string `hcl:"version"`
+	APIURL         string `hcl:"api_url"`
+	DownloadURL    string `hcl:"download_url"`
+	SigningKeyURL  string `hcl:"signing_key_url"`
+	SigningKeyFile string `hcl:"signing_key_file"`
+
+	SigningKeyFingerprint string `hcl:"signing_key_fingerprint"`
+}
+
+func (u *ConfigUpgrade) validate() tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	switch selfupdate.Channel(u.Channel) {
+	case "", selfupdate.ChannelStable, selfupdate.ChannelPrerelease:
+		// valid
+	default:
+		diags = diags.Append(
+			fmt.Errorf("The upgrade block has an invalid channel %q: must be either \"stable\" or \"prerelease\"", u.Channel),
+		)
+	}
+	if u.Version != "" {
+		if _, err := getproviders.ParseVersionConstraints(u.Version); err != nil {
+			diags = diags.Append(
+				fmt.Errorf("The upgrade block has an invalid version constraint %q: %w", u.Version, err),
+			)
+		}
+	}
+
+	if u.SigningKeyFingerprint != "" && !validFingerprint(u.SigningKeyFingerprint) {
+		diags = diags.Append(
+			fmt.Errorf("The upgrade block has an invalid signing_key_fingerprint %q: must be the 40 hexadecimal digits of an OpenPGP key fingerprint", u.SigningKeyFingerprint),
+		)
+	}
+
+	return diags
+}
+
+// validFingerprint returns true if the given string is an OpenPGP v4 key
+// fingerprint, optionally with spaces between groups of digits as gpg prints
+// it.
+func validFingerprint(fingerprint string) bool {
+	digits := strings.ReplaceAll(fingerprint, " ", "")
+	if len(digits) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(digits)
+	return err == nil
+}
+
+// UpgradePolicy returns the policy for "tofu upgrade" selected in the CLI
+// configuration, or nil if there isn't one.
+//
+// The result is meaningful only for a configuration that has passed
+// validation.
+func (c *Config) UpgradePolicy() *selfupdate.Policy {
+	if len(c.Upgrades) == 0 {
+		return nil
+	}
+	// Config.Validate rejects more than one upgrade block.
+	config := c.Upgrades[0]
+
+	ret := &selfupdate.Policy{
+		Channel:        selfupdate.Channel(config.Channel),
+		APIURL:         config.APIURL,
+		DownloadURL:    config.DownloadURL,
+		SigningKeyURL:  config.SigningKeyURL,
+		SigningKeyFile: os.ExpandEnv(config.SigningKeyFile),
+
+		SigningKeyFingerprint: config.SigningKeyFingerprint,
+	}
+	if config.Version != "" {
+		// Invalid constraints are caught during validation.
+		ret.Constraints, _ = getproviders.ParseVersionConstraints(config.Version)
+	}
+	return ret
+}
@@ -106,8 +106,9 @@ func (l *locker) WithContext(ctx context.Context) Locker {
 }
 
 // Locker locks the given state and outputs to the user if locking is taking
-// longer than the threshold. The lock is retried until the context is
-// cancelled.
+// longer than the threshold. The lock is retried with exponential backoff
+// until the context is cancelled, reporting each wait for a lock held by
+// another process to the user.
 func (l *locker) Lock(s statemgr.Locker, reason string) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
@@ -123,7 +124,7 @@ func (l *locker) Lock(s statemgr.Locker, reason string) tfdiags.Diagnostics {
 	lockInfo.Operation = reason
 
 	err := slowmessage.Do(LockThreshold, func() error {
-		id, err := statemgr.LockWithContext(ctx, s, lockInfo)
+		id, err := statemgr.LockWithContextAndWait(ctx, s, lockInfo, l.view.Waiting)
 		l.lockID = id
 		return err
 	}, l.view.Locking)
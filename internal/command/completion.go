@@ -0,0 +1,163 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/posener/complete"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// CompletionCommand is a Command implementation that prints a shell script
+// enabling tab completion of OpenTofu commands.
+//
+// The scripts don't contain the completions themselves. They call back into
+// OpenTofu with the partial command line in the COMP_LINE environment
+// variable, so completions come from the AutocompleteArgs and
+// AutocompleteFlags methods of each command and stay in sync with the
+// installed version.
+type CompletionCommand struct {
+	Meta
+}
+
+// completionScripts are the completion scripts for each supported shell,
+// with %[1]s as a placeholder for the name of the OpenTofu executable.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for OpenTofu
+complete -o nospace -C %[1]s %[1]s
+`,
+
+	"zsh": `# zsh completion for OpenTofu
+autoload -U +X bashcompinit && bashcompinit
+complete -o nospace -C %[1]s %[1]s
+`,
+
+	"fish": `# fish completion for OpenTofu
+function __tofu_complete
+    set -lx COMP_LINE (commandline -cp)
+    test -z (commandline -ct)
+    and set COMP_LINE "$COMP_LINE "
+    %[1]s
+end
+complete -f -c %[1]s -a "(__tofu_complete)"
+`,
+
+	"powershell": `# PowerShell completion for OpenTofu
+Register-ArgumentCompleter -Native -CommandName '%[1]s' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $line = $commandAst.ToString()
+    if ($line.Length -gt $cursorPosition) {
+        $line = $line.Substring(0, $cursorPosition)
+    } elseif ($wordToComplete -eq '') {
+        $line = $line + ' '
+    }
+    $env:COMP_LINE = $line
+    $env:COMP_POINT = $line.Length
+    try {
+        & '%[1]s' 2>$null | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+        }
+    } finally {
+        Remove-Item Env:\COMP_LINE, Env:\COMP_POINT -ErrorAction SilentlyContinue
+    }
+}
+`,
+}
+
+func (c *CompletionCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("completion")
+	var binName string
+	cmdFlags.StringVar(&binName, "command-name", "tofu", "command-name")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid number of arguments",
+			fmt.Sprintf("The completion command expects exactly one argument: the name of the shell, which must be one of %s.", strings.Join(completionShells(), ", ")),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported shell",
+			fmt.Sprintf("OpenTofu cannot generate completions for %q. The supported shells are %s.", args[0], strings.Join(completionShells(), ", ")),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if binName == "" || strings.ContainsAny(binName, " \t\n'\"$`\\;&|<>()") {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid command name",
+			fmt.Sprintf("The command name %q cannot be used in a completion script. It must be a plain executable name or path without spaces or shell metacharacters.", binName),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	c.Ui.Output(strings.TrimSuffix(fmt.Sprintf(script, binName), "\n"))
+	return 0
+}
+
+func completionShells() []string {
+	ret := make([]string, 0, len(completionScripts))
+	for name := range completionScripts {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (c *CompletionCommand) AutocompleteArgs() complete.Predictor {
+	return completePredictSequence{
+		complete.PredictSet(completionShells()...),
+	}
+}
+
+func (c *CompletionCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-command-name": complete.PredictAnything,
+	}
+}
+
+func (c *CompletionCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] completion [options] SHELL
+
+  Prints a script that enables tab completion of OpenTofu commands, flags,
+  workspace names, and resource addresses in SHELL, which must be one of
+  bash, fish, powershell, or zsh.
+
+  Load the script in your shell's startup file. For example, for bash:
+
+      source <(tofu completion bash)
+
+Options:
+
+  -command-name=NAME   The name or path the script uses to run OpenTofu.
+                       Defaults to "tofu".
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *CompletionCommand) Synopsis() string {
+	return "Generate a shell completion script"
+}
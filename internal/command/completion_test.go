@@ -0,0 +1,77 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestCompletion(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"bash": {
+			[]string{"bash"},
+			"complete -o nospace -C tofu tofu",
+		},
+		"zsh": {
+			[]string{"zsh"},
+			"bashcompinit",
+		},
+		"fish": {
+			[]string{"fish"},
+			`complete -f -c tofu -a "(__tofu_complete)"`,
+		},
+		"powershell": {
+			[]string{"powershell"},
+			"Register-ArgumentCompleter -Native -CommandName 'tofu'",
+		},
+		"command name": {
+			[]string{"-command-name=/opt/tofu/bin/tofu", "bash"},
+			"complete -o nospace -C /opt/tofu/bin/tofu /opt/tofu/bin/tofu",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := &CompletionCommand{
+				Meta: Meta{
+					Ui: ui,
+				},
+			}
+			if code := c.Run(test.args); code != 0 {
+				t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+			}
+			if got := ui.OutputWriter.String(); !strings.Contains(got, test.want) {
+				t.Errorf("output does not contain %q:\n%s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCompletion_invalid(t *testing.T) {
+	tests := map[string][]string{
+		"no shell":          {},
+		"unsupported shell": {"tcsh"},
+		"too many args":     {"bash", "zsh"},
+		"unsafe name":       {"-command-name=tofu; rm -rf /", "bash"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := &CompletionCommand{
+				Meta: Meta{
+					Ui: ui,
+				},
+			}
+			if code := c.Run(args); code != 1 {
+				t.Fatalf("expected failure, got %d\n\n%s", code, ui.OutputWriter.String())
+			}
+		})
+	}
+}
@@ -11,9 +11,15 @@ import (
 	"os"
 	"strings"
 
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/repl"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -33,6 +39,12 @@ func (c *ConsoleCommand) Run(args []string) int {
 	args = c.Meta.process(args)
 	cmdFlags := c.Meta.extendedFlagSet("console")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	var planPath string
+	cmdFlags.StringVar(&planPath, "plan", "", "path")
+	var traceExpr string
+	cmdFlags.StringVar(&traceExpr, "trace", "", "expression")
+	var expandAddr string
+	cmdFlags.StringVar(&expandAddr, "expand-dynamic", "", "address")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command line flags: %s\n", err.Error()))
@@ -158,6 +170,16 @@ func (c *ConsoleCommand) Run(args []string) int {
 	// set the ConsoleMode to true so any available console-only functions included.
 	scope.ConsoleMode = true
 
+	if planPath != "" {
+		savedPlan, planDiags := c.savedPlanValue(planPath, configPath, enc)
+		diags = diags.Append(planDiags)
+		if planDiags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		scope.SavedPlan = savedPlan
+	}
+
 	if diags.HasErrors() {
 		diags = diags.Append(tfdiags.SimpleWarning("Due to the problems above, some expressions may produce unexpected results."))
 	}
@@ -169,7 +191,33 @@ func (c *ConsoleCommand) Run(args []string) int {
 
 	// IO Loop
 	session := &repl.Session{
-		Scope: scope,
+		Scope:   scope,
+		Config:  lr.Config.Module,
+		Sources: c.configSources(),
+	}
+
+	// When asked to trace an expression, we show how its value was derived
+	// and exit without starting the console.
+	if traceExpr != "" {
+		result, diags := session.Trace(traceExpr)
+		c.showDiagnostics(diags)
+		if diags.HasErrors() {
+			return 1
+		}
+		ui.Output(result)
+		return 0
+	}
+
+	// Likewise, when asked to expand the dynamic blocks of a resource we
+	// show the expanded configuration and exit.
+	if expandAddr != "" {
+		result, diags := session.ExpandDynamicBlocks(expandAddr)
+		c.showDiagnostics(diags)
+		if diags.HasErrors() {
+			return 1
+		}
+		ui.Output(result)
+		return 0
 	}
 
 	// Determine if stdin is a pipe. If so, we evaluate directly.
@@ -180,6 +228,70 @@ func (c *ConsoleCommand) Run(args []string) int {
 	return c.modeInteractive(session, ui)
 }
 
+// savedPlanValue reads the local saved plan file at the given path and returns
+// its JSON representation as a cty value, so that the console-only functions
+// for interrogating a saved plan can expose it.
+func (c *ConsoleCommand) savedPlanValue(path string, configPath string, enc encryption.Encryption) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	pf, err := planfile.OpenWrapped(path, enc.Plan())
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read plan file",
+			fmt.Sprintf("Could not read the plan file %s: %s.", path, err),
+		))
+		return cty.NilVal, diags
+	}
+	lp, ok := pf.Local()
+	if !ok {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported plan file",
+			fmt.Sprintf("The plan file %s was saved by a cloud backend. The console can only load local plan files.", path),
+		))
+		return cty.NilVal, diags
+	}
+
+	rootCall, callDiags := c.rootModuleCall(configPath)
+	diags = diags.Append(callDiags)
+	if callDiags.HasErrors() {
+		return cty.NilVal, diags
+	}
+	plan, stateFile, config, err := getDataFromPlanfileReader(lp, rootCall)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read plan file",
+			fmt.Sprintf("Could not read the plan file %s: %s.", path, err),
+		))
+		return cty.NilVal, diags
+	}
+
+	schemas, schemaDiags := c.MaybeGetSchemas(stateFile.State, config)
+	diags = diags.Append(schemaDiags)
+	if schemaDiags.HasErrors() {
+		return cty.NilVal, diags
+	}
+
+	src, err := jsonplan.Marshal(config, plan, stateFile, schemas)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to marshal plan to JSON: %w", err))
+		return cty.NilVal, diags
+	}
+	ty, err := ctyjson.ImpliedType(src)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to decode JSON plan: %w", err))
+		return cty.NilVal, diags
+	}
+	val, err := ctyjson.Unmarshal(src, ty)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to decode JSON plan: %w", err))
+		return cty.NilVal, diags
+	}
+	return val, diags
+}
+
 func (c *ConsoleCommand) modePiped(session *repl.Session, ui cli.Ui) int {
 	scanner := bufio.NewScanner(os.Stdin)
 
@@ -237,9 +349,25 @@ Options:
                          will be performed. All locations, for all errors
                          will be listed. Disabled by default
 
+  -expand-dynamic=addr   Show the configuration of the given resource in the
+                         root module with its dynamic blocks expanded and its
+                         arguments evaluated, then exit without starting the
+                         console. Resources using count or for_each must be
+                         given with an instance key.
+
+  -plan=path             Load the given saved plan file, whose planned values
+                         and resource changes are then available from the
+                         planned_values() and resource_changes() functions.
+
   -state=path            Legacy option for the local backend only. See the local
                          backend's documentation for more information.
 
+  -trace=expression      Evaluate the given expression and show how its value
+                         was derived, including the value and declaration of
+                         each object it refers to and the result of each
+                         function call, then exit without starting the
+                         console.
+
   -var 'foo=bar'         Set a variable in the OpenTofu configuration. This
                          flag can be set multiple times.
 
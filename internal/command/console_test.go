@@ -350,3 +350,77 @@ func TestConsole_multiline_pipe(t *testing.T) {
 		})
 	}
 }
+
+func TestConsole_plan(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("plan"), td)
+	defer testChdir(t, td)()
+
+	outPath := filepath.Join(td, "test.plan")
+
+	p := planFixtureProvider()
+	planView, planDone := testView(t)
+	planCmd := &PlanCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			View:             planView,
+		},
+	}
+	if code := planCmd.Run([]string{"-out", outPath}); code != 0 {
+		t.Fatalf("plan failed: %d\n\n%s", code, planDone(t).Stderr())
+	}
+
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &ConsoleCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	var output bytes.Buffer
+	defer testStdinPipe(t, strings.NewReader(
+		"join(\",\", [for rc in resource_changes() : rc.address])\n"+
+			"resource_changes()[0].change.after.ami\n",
+	))()
+	outCloser := testStdoutCapture(t, &output)
+
+	code := c.Run([]string{"-plan", outPath})
+	outCloser()
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if got, want := output.String(), "\"test_instance.foo\"\n\"bar\"\n"; got != want {
+		t.Fatalf("unexpected output\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestConsole_planFunctionsWithoutPlan(t *testing.T) {
+	testCwd(t)
+
+	p := testProvider()
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &ConsoleCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	defer testStdinPipe(t, strings.NewReader("planned_values()\n"))()
+	outCloser := testStdoutCapture(t, new(bytes.Buffer))
+
+	// Without streams the console runs in interactive mode, which reports
+	// the error and then carries on reading input.
+	c.Run(nil)
+	outCloser()
+
+	if got, want := ui.ErrorWriter.String(), "no saved plan is loaded"; !strings.Contains(got, want) {
+		t.Fatalf("expected error containing %q, got:\n%s", want, got)
+	}
+}
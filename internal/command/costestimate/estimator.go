@@ -0,0 +1,127 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package costestimate runs an external program that estimates the cost of
+// the resource changes in a plan, so that the estimates can be shown
+// alongside the plan.
+package costestimate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+)
+
+// DefaultTimeout is how long an estimator may run before we give up on it.
+const DefaultTimeout = time.Minute
+
+// Estimator runs an external program to estimate the cost of resource
+// changes.
+//
+// The program receives a JSON document on stdin with a "format_version"
+// property and a "resource_changes" property in the same format as the
+// corresponding property of the JSON plan output, and must write a Response
+// to stdout as JSON.
+type Estimator struct {
+	Command string
+	Args    []string
+
+	// Timeout overrides DefaultTimeout, if set.
+	Timeout time.Duration
+}
+
+// Request is the JSON document written to an estimator's stdin.
+type Request struct {
+	FormatVersion   string                    `json:"format_version"`
+	ResourceChanges []jsonplan.ResourceChange `json:"resource_changes"`
+}
+
+// Response is the JSON document an estimator writes to its stdout.
+type Response struct {
+	// Currency is the default currency for all of the estimates, which
+	// an individual estimate can override.
+	Currency string `json:"currency"`
+
+	ResourceChanges []ResourceCost `json:"resource_changes"`
+}
+
+// ResourceCost is the estimated cost of a single resource change, identified
+// by the same address and deposed key as in the request.
+type ResourceCost struct {
+	Address       string   `json:"address"`
+	Deposed       string   `json:"deposed,omitempty"`
+	Currency      string   `json:"currency,omitempty"`
+	MonthlyBefore *float64 `json:"monthly_before,omitempty"`
+	MonthlyAfter  *float64 `json:"monthly_after,omitempty"`
+}
+
+// Annotate runs the estimator for the given resource changes and sets the
+// Cost field of each change that the estimator returned an estimate for.
+//
+// Changes that the estimator doesn't mention are left unchanged, so an
+// estimator can skip resource types it doesn't know about.
+func (e *Estimator) Annotate(ctx context.Context, changes []jsonplan.ResourceChange) error {
+	resp, err := e.run(ctx, Request{
+		FormatVersion:   jsonplan.FormatVersion,
+		ResourceChanges: changes,
+	})
+	if err != nil {
+		return err
+	}
+
+	type changeKey struct {
+		addr, deposed string
+	}
+	costs := make(map[changeKey]*jsonplan.Cost, len(resp.ResourceChanges))
+	for _, rc := range resp.ResourceChanges {
+		currency := rc.Currency
+		if currency == "" {
+			currency = resp.Currency
+		}
+		costs[changeKey{rc.Address, rc.Deposed}] = &jsonplan.Cost{
+			Currency:      currency,
+			MonthlyBefore: rc.MonthlyBefore,
+			MonthlyAfter:  rc.MonthlyAfter,
+		}
+	}
+	for i := range changes {
+		if cost, ok := costs[changeKey{changes[i].Address, changes[i].Deposed}]; ok {
+			changes[i].Cost = cost
+		}
+	}
+	return nil
+}
+
+func (e *Estimator) run(ctx context.Context, req Request) (*Response, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	timeout := e.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...) //nolint:gosec // Launching the configured external command is the entire point.
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w\n\nStderr:\n-------\n%s", e.Command, err, stderr.String())
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("%s returned an invalid response: %w", e.Command, err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package costestimate
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+)
+
+func TestEstimatorAnnotate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on a POSIX shell")
+	}
+
+	e := &Estimator{
+		Command: "sh",
+		Args: []string{"-c", `cat >/dev/null; echo '{
+			"currency": "USD",
+			"resource_changes": [
+				{"address": "test_instance.a", "monthly_after": 25},
+				{"address": "test_instance.b", "deposed": "00000001", "currency": "EUR", "monthly_before": 10}
+			]
+		}'`},
+	}
+	changes := []jsonplan.ResourceChange{
+		{Address: "test_instance.a"},
+		{Address: "test_instance.b"},
+		{Address: "test_instance.b", Deposed: "00000001"},
+	}
+
+	if err := e.Annotate(context.Background(), changes); err != nil {
+		t.Fatal(err)
+	}
+
+	twentyFive, ten := 25.0, 10.0
+	want := []*jsonplan.Cost{
+		{Currency: "USD", MonthlyAfter: &twentyFive},
+		nil,
+		{Currency: "EUR", MonthlyBefore: &ten},
+	}
+	for i, change := range changes {
+		if diff := cmp.Diff(want[i], change.Cost); diff != "" {
+			t.Errorf("wrong cost for %s %s\n%s", change.Address, change.Deposed, diff)
+		}
+	}
+}
+
+func TestEstimatorAnnotate_failure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on a POSIX shell")
+	}
+
+	e := &Estimator{
+		Command: "sh",
+		Args:    []string{"-c", "echo 'no pricing data' >&2; exit 1"},
+	}
+	changes := []jsonplan.ResourceChange{{Address: "test_instance.a"}}
+
+	if err := e.Annotate(context.Background(), changes); err == nil {
+		t.Fatal("succeeded; want error")
+	}
+	if changes[0].Cost != nil {
+		t.Errorf("unexpected cost %#v after failure", changes[0].Cost)
+	}
+}
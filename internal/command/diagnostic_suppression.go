@@ -0,0 +1,159 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// suppressDiagnostics hides or summarizes the warnings in the given
+// diagnostics that the root module in the current working directory
+// acknowledges in its "diagnostics" block.
+//
+// The root module is only loaded the first time there are warnings to
+// check, and any problems loading it are ignored here because the command
+// reports them itself when it loads the configuration.
+func (m *Meta) suppressDiagnostics(diags tfdiags.Diagnostics) tfdiags.Diagnostics {
+	hasWarnings := false
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Warning {
+			hasWarnings = true
+			break
+		}
+	}
+	if !hasWarnings {
+		return diags
+	}
+
+	if !m.diagnosticSuppressionsLoaded {
+		m.diagnosticSuppressionsLoaded = true
+		suppressions, err := m.loadDiagnosticSuppressions(".")
+		if err != nil {
+			log.Printf("[WARN] Can't load the diagnostic suppressions of the root module: %s", err)
+		}
+		m.diagnosticSuppressions = suppressions
+	}
+	if len(m.diagnosticSuppressions) == 0 {
+		return diags
+	}
+
+	if m.expiredSuppressionsReported == nil {
+		m.expiredSuppressionsReported = make(map[*configs.DiagnosticSuppression]bool)
+	}
+	return applyDiagnosticSuppressions(diags, m.diagnosticSuppressions, time.Now(), m.expiredSuppressionsReported)
+}
+
+// loadDiagnosticSuppressions loads only the diagnostic suppressions of the
+// module in the given directory.
+//
+// This deliberately doesn't use loadSingleModule, because that would collect
+// and cache the root module's input variable values, and so hide any problems
+// with them from the command that actually needs them. The suppressions never
+// refer to variables, so they can be decoded without them.
+func (m *Meta) loadDiagnosticSuppressions(dir string) ([]*configs.DiagnosticSuppression, error) {
+	dir = m.normalizePath(dir)
+	if !m.dirIsConfigPath(dir) {
+		return nil, nil
+	}
+
+	loader, err := m.initConfigLoader()
+	if err != nil {
+		return nil, err
+	}
+
+	call := configs.NewStaticModuleCall(addrs.RootModule, func(_ *configs.Variable) (cty.Value, hcl.Diagnostics) {
+		return cty.DynamicVal, nil
+	}, dir, "")
+	mod, diags := loader.Parser().LoadConfigDirSelective(dir, call, configs.SelectiveLoadDiagnostics)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return mod.DiagnosticSuppressions, nil
+}
+
+// applyDiagnosticSuppressions returns the given diagnostics without the
+// warnings that match one of the given suppressions, and with a single
+// warning summarizing those that match a suppression with the "downgrade"
+// action.
+//
+// Suppressions that have expired at the given time don't apply, and instead
+// cause a warning the first time they match a diagnostic, unless they are
+// already recorded in reported.
+func applyDiagnosticSuppressions(diags tfdiags.Diagnostics, suppressions []*configs.DiagnosticSuppression, now time.Time, reported map[*configs.DiagnosticSuppression]bool) tfdiags.Diagnostics {
+	var ret tfdiags.Diagnostics
+	var downgraded []string
+
+Diags:
+	for _, diag := range diags {
+		for _, suppression := range suppressions {
+			if !suppression.Matches(diag) {
+				continue
+			}
+			if suppression.Expired(now) {
+				if !reported[suppression] {
+					reported[suppression] = true
+					ret = ret.Append(expiredSuppressionDiagnostic(suppression))
+				}
+				continue
+			}
+
+			if suppression.Action == configs.DiagnosticSuppressionDowngrade {
+				downgraded = append(downgraded, downgradedWarningSummary(diag))
+			}
+			continue Diags
+		}
+		ret = ret.Append(diag)
+	}
+
+	if len(downgraded) != 0 {
+		var detail strings.Builder
+		detail.WriteString("The configuration acknowledges the following warnings, so only their summaries are shown:\n")
+		for _, summary := range downgraded {
+			fmt.Fprintf(&detail, "  - %s\n", summary)
+		}
+		ret = ret.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Acknowledged warnings",
+			strings.TrimSuffix(detail.String(), "\n"),
+		))
+	}
+
+	return ret
+}
+
+// downgradedWarningSummary returns a one-line description of a warning that
+// matches a suppression with the "downgrade" action.
+func downgradedWarningSummary(diag tfdiags.Diagnostic) string {
+	desc := diag.Description()
+	summary := desc.Summary
+	if code := tfdiags.DiagnosticCode(diag); code != "" {
+		summary = fmt.Sprintf("%s [%s]", summary, code)
+	}
+	if desc.Address != "" {
+		summary = fmt.Sprintf("%s (%s)", summary, desc.Address)
+	}
+	return summary
+}
+
+func expiredSuppressionDiagnostic(suppression *configs.DiagnosticSuppression) tfdiags.Diagnostic {
+	return tfdiags.Diagnostics(nil).Append(&hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Expired diagnostic suppression",
+		Detail: fmt.Sprintf(
+			"This suppression expired on %s, so the warnings it matches are shown again. Remove it if the warnings are no longer acceptable, or extend its expiry date if they still are.",
+			suppression.Expires.Format(time.DateOnly),
+		),
+		Subject: suppression.DeclRange.Ptr(),
+	})[0]
+}
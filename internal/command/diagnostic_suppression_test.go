@@ -0,0 +1,69 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestApplyDiagnosticSuppressions(t *testing.T) {
+	now := time.Date(2030, 1, 15, 12, 0, 0, 0, time.UTC)
+	active := now.AddDate(0, 1, 0)
+	expired := now.AddDate(0, -1, 0)
+
+	var diags tfdiags.Diagnostics
+	diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(tfdiags.Warning, "Resource targeting is in effect", ""), tfdiags.CodeResourceTargeting))
+	diags = diags.Append(tfdiags.Sourceless(tfdiags.Warning, "Argument is deprecated", ""))
+	diags = diags.Append(tfdiags.Sourceless(tfdiags.Warning, "Something new", ""))
+	diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Argument is deprecated", ""))
+
+	t.Run("suppress and downgrade", func(t *testing.T) {
+		suppressions := []*configs.DiagnosticSuppression{
+			{Code: tfdiags.CodeResourceTargeting, Action: configs.DiagnosticSuppressionHide, Expires: active},
+			{Summary: "Argument is deprecated", Action: configs.DiagnosticSuppressionDowngrade, Expires: active},
+		}
+		got := applyDiagnosticSuppressions(diags, suppressions, now, map[*configs.DiagnosticSuppression]bool{})
+
+		var summaries []string
+		for _, diag := range got {
+			summaries = append(summaries, diag.Description().Summary)
+		}
+		want := "Something new, Argument is deprecated, Acknowledged warnings"
+		if got := strings.Join(summaries, ", "); got != want {
+			t.Fatalf("wrong diagnostics\ngot:  %s\nwant: %s", got, want)
+		}
+		if got := got[len(got)-1].Description().Detail; !strings.Contains(got, "  - Argument is deprecated") {
+			t.Errorf("acknowledged warning doesn't list the downgraded warning:\n%s", got)
+		}
+		if got[1].Severity() != tfdiags.Error {
+			t.Errorf("error was not preserved")
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		suppressions := []*configs.DiagnosticSuppression{
+			{Code: tfdiags.CodeResourceTargeting, Action: configs.DiagnosticSuppressionHide, Expires: expired},
+		}
+		reported := map[*configs.DiagnosticSuppression]bool{}
+
+		got := applyDiagnosticSuppressions(diags, suppressions, now, reported)
+		if len(got) != len(diags)+1 {
+			t.Fatalf("wrong number of diagnostics %d; want %d", len(got), len(diags)+1)
+		}
+		if got := got[0].Description(); got.Summary != "Expired diagnostic suppression" || !strings.Contains(got.Detail, "expired on 2029-12-15") {
+			t.Errorf("wrong expiry warning: %s: %s", got.Summary, got.Detail)
+		}
+
+		// The expired suppression is only reported once.
+		got = applyDiagnosticSuppressions(diags, suppressions, now, reported)
+		if len(got) != len(diags) {
+			t.Fatalf("wrong number of diagnostics %d; want %d", len(got), len(diags))
+		}
+	})
+}
@@ -0,0 +1,420 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// ExplainCommand is a Command implementation that explains why a saved plan
+// proposes a particular action for a resource instance.
+type ExplainCommand struct {
+	Meta
+}
+
+func (c *ExplainCommand) Run(args []string) int {
+	var diags tfdiags.Diagnostics
+
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("explain")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("The explain command expects exactly two arguments: the path to a saved plan file and the address of a resource instance.")
+		return cli.RunResultHelp
+	}
+	planPath, rawAddr := args[0], args[1]
+
+	addr, addrDiags := addrs.ParseAbsResourceInstanceStr(rawAddr)
+	diags = diags.Append(addrDiags)
+	if addrDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	pf, err := planfile.OpenWrapped(planPath, enc.Plan())
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read plan file",
+			fmt.Sprintf("Couldn't read the plan file %s: %s.", planPath, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	lp, ok := pf.Local()
+	if !ok {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported plan file",
+			"The explain command works only with plan files created locally, not with saved cloud plans.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	rootCall, callDiags := c.rootModuleCall(".")
+	diags = diags.Append(callDiags)
+	if callDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	plan, stateFile, config, err := getDataFromPlanfileReader(lp, rootCall)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read plan file",
+			fmt.Sprintf("Couldn't read the plan file %s: %s.", planPath, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	var changes []*plans.ResourceInstanceChangeSrc
+	for _, rc := range plan.Changes.Resources {
+		if rc.Addr.Equal(addr) {
+			changes = append(changes, rc)
+		}
+	}
+	if len(changes) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Resource instance not in plan",
+			fmt.Sprintf("The plan doesn't include any change for %s. Use \"tofu show\" to see the resource instances that the plan includes.", addr),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].DeposedKey < changes[j].DeposedKey
+	})
+
+	// The schemas allow us to report which attributes are changing, but
+	// we can still give a useful explanation without them.
+	var state *states.State
+	if stateFile != nil {
+		state = stateFile.State
+	}
+	schemas, schemaDiags := c.MaybeGetSchemas(state, config)
+	for _, diag := range schemaDiags {
+		if diag.Severity() == tfdiags.Warning {
+			diags = diags.Append(diag)
+		}
+	}
+
+	var rc *configs.Resource
+	if modCfg := config.DescendentForInstance(addr.Module); modCfg != nil {
+		rc = modCfg.Module.ResourceByAddr(addr.Resource.Resource)
+	}
+
+	for i, change := range changes {
+		if i > 0 {
+			c.Ui.Output("")
+		}
+		c.Ui.Output(explainResourceInstanceChange(change, rc, schemas))
+	}
+
+	c.showDiagnostics(diags)
+	return 0
+}
+
+// explainResourceInstanceChange returns a human-readable explanation of the
+// given planned change, referring to the given resource configuration where
+// possible. Both rc and schemas may be nil if they're not available.
+func explainResourceInstanceChange(change *plans.ResourceInstanceChangeSrc, rc *configs.Resource, schemas *tofu.Schemas) string {
+	var buf strings.Builder
+
+	dispAddr := change.Addr.String()
+	if change.DeposedKey != states.NotDeposed {
+		dispAddr = fmt.Sprintf("%s (deposed object %s)", dispAddr, change.DeposedKey)
+	}
+
+	switch change.Action {
+	case plans.NoOp:
+		fmt.Fprintf(&buf, "%s has no changes planned.\n", dispAddr)
+	case plans.Create:
+		fmt.Fprintf(&buf, "%s will be created.\n", dispAddr)
+	case plans.Read:
+		fmt.Fprintf(&buf, "%s will be read during apply.\n", dispAddr)
+	case plans.Update:
+		fmt.Fprintf(&buf, "%s will be updated in-place.\n", dispAddr)
+	case plans.CreateThenDelete, plans.DeleteThenCreate:
+		fmt.Fprintf(&buf, "%s will be replaced.\n", dispAddr)
+	case plans.Delete:
+		fmt.Fprintf(&buf, "%s will be destroyed.\n", dispAddr)
+	case plans.Forget:
+		fmt.Fprintf(&buf, "%s will be removed from the state but will not be destroyed.\n", dispAddr)
+	default:
+		fmt.Fprintf(&buf, "%s has planned action %s.\n", dispAddr, change.Action)
+	}
+
+	if reason := explainActionReason(change, rc); reason != "" {
+		fmt.Fprintf(&buf, "\nReason: %s\n", reason)
+	}
+	if change.Importing != nil {
+		fmt.Fprintf(&buf, "\nThe object will be imported using the ID %q.\n", change.Importing.ID)
+	}
+	if change.Moved() {
+		fmt.Fprintf(&buf, "\nThe object has moved from %s.\n", change.PrevRunAddr)
+	}
+
+	if lines := explainAttributeChanges(change, rc, schemas); len(lines) > 0 {
+		buf.WriteString("\nChanged attributes:\n")
+		for _, line := range lines {
+			fmt.Fprintf(&buf, "  %s\n", line)
+		}
+	}
+
+	if rc != nil {
+		fmt.Fprintf(&buf, "\nConfiguration: %s\n", rc.DeclRange)
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+func explainActionReason(change *plans.ResourceInstanceChangeSrc, rc *configs.Resource) string {
+	switch change.ActionReason {
+	case plans.ResourceInstanceReplaceBecauseTainted:
+		return "the object is marked as tainted, either because it was only partially created or because it was tainted with \"tofu taint\", so it must be replaced."
+	case plans.ResourceInstanceReplaceByRequest:
+		return "replacement was requested with the -replace option, either directly or through -replace-dependents."
+	case plans.ResourceInstanceReplaceByTriggers:
+		ret := "an object referenced in the lifecycle replace_triggered_by argument has changed."
+		if rc != nil {
+			for _, expr := range rc.TriggersReplacement {
+				ret += fmt.Sprintf("\n  - %s at %s", hclExprSource(expr), expr.Range())
+			}
+		}
+		return ret
+	case plans.ResourceInstanceReplaceBecauseCannotUpdate:
+		return "the provider can't update some of the changed attributes in-place, so the object must be replaced. These attributes are marked as forcing replacement below."
+	case plans.ResourceInstanceDeleteBecauseNoResourceConfig:
+		return fmt.Sprintf("%s is not in the configuration.", change.Addr.ContainingResource())
+	case plans.ResourceInstanceDeleteBecauseNoModule:
+		return fmt.Sprintf("%s is not in the configuration.", change.Addr.Module)
+	case plans.ResourceInstanceDeleteBecauseNoMoveTarget:
+		return fmt.Sprintf("%s was moved to %s, which is not in the configuration.", change.PrevRunAddr, change.Addr)
+	case plans.ResourceInstanceDeleteBecauseWrongRepetition:
+		return "the instance key doesn't match whether the resource uses count or for_each."
+	case plans.ResourceInstanceDeleteBecauseCountIndex:
+		return fmt.Sprintf("index %s is out of range for count.", change.Addr.Resource.Key)
+	case plans.ResourceInstanceDeleteBecauseEachKey:
+		return fmt.Sprintf("key %s is not in the for_each map.", change.Addr.Resource.Key)
+	case plans.ResourceInstanceReadBecauseConfigUnknown:
+		return "the configuration refers to values that won't be known until apply."
+	case plans.ResourceInstanceReadBecauseDependencyPending:
+		return "the data source depends on a resource or a module with changes pending."
+	case plans.ResourceInstanceReadBecauseCheckNested:
+		return "the configuration will be reloaded to verify a check block."
+	}
+	if change.Action == plans.Delete && change.DeposedKey != states.NotDeposed {
+		return "the object is left over from a partially-failed replacement of this instance."
+	}
+	return ""
+}
+
+// explainAttributeChanges returns a line for each top-level attribute or
+// nested block whose value differs between the before and after values of
+// the given change, marking those that force replacement and giving the
+// location in the configuration where each is set.
+//
+// Values themselves are not included, so that the result never reveals
+// sensitive values.
+func explainAttributeChanges(change *plans.ResourceInstanceChangeSrc, rc *configs.Resource, schemas *tofu.Schemas) []string {
+	if change.Action != plans.Update && !change.Action.IsReplace() {
+		return nil
+	}
+
+	forcesReplacement := make(map[string]bool)
+	for _, path := range change.RequiredReplace.List() {
+		if name := explainPathRootName(path); name != "" {
+			forcesReplacement[name] = true
+		}
+	}
+
+	var names []string
+	changed := make(map[string]string)
+
+	var schema *configschema.Block
+	if schemas != nil {
+		schema, _ = schemas.ResourceTypeConfig(change.ProviderAddr.Provider, change.Addr.Resource.Resource.Mode, change.Addr.Resource.Resource.Type)
+	}
+	var decoded *plans.ResourceInstanceChange
+	if schema != nil {
+		decoded, _ = change.Decode(schema.ImpliedType())
+	}
+	if decoded != nil {
+		before, _ := decoded.Before.UnmarkDeep()
+		after, _ := decoded.After.UnmarkDeep()
+		for name := range before.Type().AttributeTypes() {
+			var b, a cty.Value
+			if !before.IsNull() {
+				b = before.GetAttr(name)
+			} else {
+				b = cty.NullVal(before.Type().AttributeType(name))
+			}
+			if after.Type().IsObjectType() && after.Type().HasAttribute(name) && !after.IsNull() {
+				a = after.GetAttr(name)
+			} else {
+				a = cty.NullVal(before.Type().AttributeType(name))
+			}
+			switch {
+			case !a.IsWhollyKnown():
+				changed[name] = "(known after apply)"
+			case !a.RawEquals(b):
+				changed[name] = ""
+			case forcesReplacement[name]:
+				changed[name] = ""
+			default:
+				continue
+			}
+			names = append(names, name)
+		}
+	} else {
+		// Without a schema we can only report the attributes that the
+		// provider told us force replacement.
+		for name := range forcesReplacement {
+			changed[name] = ""
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	ret := make([]string, 0, len(names))
+	for _, name := range names {
+		line := name
+		if note := changed[name]; note != "" {
+			line += " " + note
+		}
+		if forcesReplacement[name] {
+			line += " (forces replacement)"
+		}
+		if rng := explainConfigRange(rc, name); rng != nil {
+			line += fmt.Sprintf("\n      set at %s", rng)
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
+
+// explainPathRootName returns the name of the top-level attribute or block
+// that the given path starts with, or an empty string if the path doesn't
+// start with an attribute.
+func explainPathRootName(path cty.Path) string {
+	if len(path) == 0 {
+		return ""
+	}
+	if step, ok := path[0].(cty.GetAttrStep); ok {
+		return step.Name
+	}
+	return ""
+}
+
+// explainConfigRange returns the source range of the argument or first
+// nested block with the given name in the given resource configuration, or
+// nil if there isn't one or it can't be found.
+func explainConfigRange(rc *configs.Resource, name string) *hcl.Range {
+	if rc == nil {
+		return nil
+	}
+	body, ok := rc.Config.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	if attr, ok := body.Attributes[name]; ok {
+		return attr.SrcRange.Ptr()
+	}
+	for _, block := range body.Blocks {
+		if block.Type == name {
+			return block.DefRange().Ptr()
+		}
+	}
+	return nil
+}
+
+// hclExprSource returns the source code of the given expression, or a
+// placeholder if it isn't available.
+func hclExprSource(expr hcl.Expression) string {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return "(expression)"
+	}
+	var buf strings.Builder
+	for _, step := range traversal {
+		switch step := step.(type) {
+		case hcl.TraverseRoot:
+			buf.WriteString(step.Name)
+		case hcl.TraverseAttr:
+			buf.WriteString("." + step.Name)
+		default:
+			return "(expression)"
+		}
+	}
+	return buf.String()
+}
+
+func (c *ExplainCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] explain [options] PLANFILE ADDRESS
+
+  Explains why a saved plan proposes the action it does for the resource
+  instance at the given address.
+
+  The explanation includes the reason for the action, such as the object
+  being tainted, replacement being requested with -replace or by a
+  lifecycle replace_triggered_by argument, or the provider being unable
+  to update some attributes in-place. It also lists the attributes that
+  are changing and where each of them is set in the configuration.
+
+Options:
+
+  -no-color           If specified, output won't contain any color.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ExplainCommand) Synopsis() string {
+	return "Explain the planned action for a resource instance"
+}
@@ -0,0 +1,106 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestExplain_replaceBecauseCannotUpdate(t *testing.T) {
+	_, snap := testModuleWithSnapshot(t, "show")
+	priorVal := cty.ObjectVal(map[string]cty.Value{
+		"id":  cty.StringVal("foo"),
+		"ami": cty.StringVal("foo"),
+	})
+	plannedVal := cty.ObjectVal(map[string]cty.Value{
+		"id":  cty.UnknownVal(cty.String),
+		"ami": cty.StringVal("bar"),
+	})
+	priorValRaw, err := plans.NewDynamicValue(priorVal, plannedVal.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plannedValRaw, err := plans.NewDynamicValue(plannedVal, plannedVal.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := testPlan(t)
+	plan.Changes.SyncWrapper().AppendResourceInstanceChange(&plans.ResourceInstanceChangeSrc{
+		Addr: addrs.Resource{
+			Mode: addrs.ManagedResourceMode,
+			Type: "test_instance",
+			Name: "foo",
+		}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+		ProviderAddr: addrs.AbsProviderConfig{
+			Provider: addrs.NewDefaultProvider("test"),
+			Module:   addrs.RootModule,
+		},
+		ChangeSrc: plans.ChangeSrc{
+			Action: plans.DeleteThenCreate,
+			Before: priorValRaw,
+			After:  plannedValRaw,
+		},
+		ActionReason:    plans.ResourceInstanceReplaceBecauseCannotUpdate,
+		RequiredReplace: cty.NewPathSet(cty.GetAttrPath("ami")),
+	})
+	planPath := testPlanFile(t, snap, states.NewState(), plan)
+
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &ExplainCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	if code := c.Run([]string{planPath, "test_instance.foo"}); code != 0 {
+		t.Fatalf("wrong exit status %d; want 0\nstderr: %s", code, ui.ErrorWriter.String())
+	}
+
+	got := ui.OutputWriter.String()
+	for _, want := range []string{
+		"test_instance.foo will be replaced.",
+		"the provider can't update some of the changed attributes in-place",
+		"ami (forces replacement)",
+		"main.tf:2,5",
+		"id (known after apply)",
+		"Configuration: ",
+		"main.tf:1,1",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output is missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestExplain_notInPlan(t *testing.T) {
+	planPath := testPlanFileNoop(t)
+
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &ExplainCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	if code := c.Run([]string{planPath, "test_instance.foo"}); code != 1 {
+		t.Fatalf("wrong exit status %d; want 1\nstdout: %s", code, ui.OutputWriter.String())
+	}
+	if got, want := ui.ErrorWriter.String(), "Resource instance not in plan"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/mitchellh/cli"
 
 	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/lang"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -47,6 +48,7 @@ type FmtCommand struct {
 	diff      bool
 	check     bool
 	recursive bool
+	fix       bool
 	input     io.Reader // STDIN if nil
 }
 
@@ -62,6 +64,7 @@ func (c *FmtCommand) Run(args []string) int {
 	cmdFlags.BoolVar(&c.diff, "diff", false, "diff")
 	cmdFlags.BoolVar(&c.check, "check", false, "check")
 	cmdFlags.BoolVar(&c.recursive, "recursive", false, "recursive")
+	cmdFlags.BoolVar(&c.fix, "fix", false, "fix")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
@@ -195,7 +198,17 @@ func (c *FmtCommand) processFile(path string, r io.Reader, w io.Writer, isStdout
 		return diags
 	}
 
-	result := c.formatSourceCode(src, path)
+	fixed := src
+	if c.fix {
+		var fixDiags hcl.Diagnostics
+		fixed, fixDiags = lang.FixDeprecatedFunctionCalls(src, path)
+		diags = diags.Append(fixDiags)
+		if fixDiags.HasErrors() {
+			return diags
+		}
+	}
+
+	result := c.formatSourceCode(fixed, path)
 
 	if !bytes.Equal(src, result) {
 		// Something was changed
@@ -583,6 +596,10 @@ Options:
 
   -recursive     Also process files in subdirectories. By default, only the
                  given directory (or current directory) is processed.
+
+  -fix           Also replace deprecated uses of built-in functions with
+                 their recommended replacements, where that can be done
+                 automatically.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -396,6 +396,42 @@ func TestFmt_stdinArg(t *testing.T) {
 	}
 }
 
+func TestFmt_fix(t *testing.T) {
+	input := new(bytes.Buffer)
+	input.WriteString(`locals {
+  a = lookup(var.map, "a")
+  b = lookup(var.map, "b", "default")
+  c = lookup(lookup(var.nested, "x"), var.key)
+  d = core::lookup(var.a ? var.b : var.c, "d")
+}
+`)
+
+	ui := new(cli.MockUi)
+	c := &FmtCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+		input: input,
+	}
+
+	args := []string{"-fix", "-"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("wrong exit code. errors: \n%s", ui.ErrorWriter.String())
+	}
+
+	want := `locals {
+  a = var.map["a"]
+  b = lookup(var.map, "b", "default")
+  c = var.nested["x"][var.key]
+  d = (var.a ? var.b : var.c)["d"]
+}
+`
+	if got := ui.OutputWriter.String(); got != want {
+		t.Fatalf("wrong output\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func TestFmt_nonDefaultOptions(t *testing.T) {
 	tempDir := fmtFixtureWriteDir(t)
 
@@ -0,0 +1,107 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/colorstring"
+)
+
+// DefaultColorTheme is the name of the color theme that OpenTofu uses unless
+// the CLI configuration selects another one.
+const DefaultColorTheme = "default"
+
+// colorThemes are the color themes that the CLI configuration can select,
+// each given as the colors that differ from colorstring.DefaultColors.
+//
+// The themes only change the codes that the color names in our colorstring
+// markup produce, so that the same markup renders in every theme and a theme
+// must keep the meaning of each name: for example "red" marks errors and
+// destroyed objects and "green" marks created ones, whatever colors they
+// actually render as.
+var colorThemes = map[string]map[string]string{
+	DefaultColorTheme: nil,
+
+	// The "colorblind" theme replaces red and green, which are hard to tell
+	// apart with the most common forms of color blindness, with vermilion and
+	// blue from the Okabe-Ito palette.
+	"colorblind": {
+		"red":    "38;5;166",
+		"green":  "38;5;32",
+		"yellow": "38;5;220",
+		"cyan":   "38;5;117",
+	},
+
+	// The "light" theme uses darker colors that stay readable on terminals
+	// with a light background, where the standard yellow and light gray in
+	// particular are hard to see.
+	"light": {
+		"red":        "38;5;124",
+		"green":      "38;5;28",
+		"yellow":     "38;5;136",
+		"blue":       "38;5;25",
+		"cyan":       "38;5;30",
+		"dark_gray":  "38;5;240",
+		"light_gray": "38;5;242",
+	},
+}
+
+// ColorThemeNames returns the names of all of the available color themes, in
+// lexical order.
+func ColorThemeNames() []string {
+	names := make([]string, 0, len(colorThemes))
+	for name := range colorThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ColorThemeColors returns the colors of the color theme with the given name,
+// in the form expected by colorstring.Colorize, or false if there is no such
+// theme. An empty name selects the default theme.
+//
+// The result is a new map, which the caller may modify.
+func ColorThemeColors(name string) (map[string]string, bool) {
+	if name == "" {
+		name = DefaultColorTheme
+	}
+	overrides, ok := colorThemes[name]
+	if !ok {
+		return nil, false
+	}
+
+	colors := make(map[string]string, len(colorstring.DefaultColors)+1)
+	for k, v := range colorstring.DefaultColors {
+		colors[k] = v
+	}
+	colors["purple"] = "38;5;57"
+	for k, v := range overrides {
+		colors[k] = v
+	}
+	return colors, true
+}
+
+// asciiReplacer replaces the non-ASCII characters that OpenTofu uses to
+// decorate its human-oriented output with ASCII characters of a similar
+// shape.
+var asciiReplacer = strings.NewReplacer(
+	"─", "-",
+	"│", "|",
+	"╷", ",",
+	"╵", "'",
+	"├", "+",
+)
+
+// ASCIIOnly returns the given output with the box-drawing characters that
+// OpenTofu uses for diagnostics and horizontal rules replaced by ASCII
+// characters, for terminals and screen readers that don't handle them well.
+//
+// Any other non-ASCII characters, such as those in values or messages from
+// providers, are left as they are.
+func ASCIIOnly(s string) string {
+	return asciiReplacer.Replace(s)
+}
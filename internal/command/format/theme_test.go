@@ -0,0 +1,77 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/mitchellh/colorstring"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestColorThemeColors(t *testing.T) {
+	for _, name := range ColorThemeNames() {
+		t.Run(name, func(t *testing.T) {
+			colors, ok := ColorThemeColors(name)
+			if !ok {
+				t.Fatalf("theme %q not found", name)
+			}
+			// Every theme must define every color that the default one does,
+			// so that the same markup renders in all of them.
+			for k := range colorstring.DefaultColors {
+				if _, ok := colors[k]; !ok {
+					t.Errorf("theme %q doesn't define %q", name, k)
+				}
+			}
+			if _, ok := colors["purple"]; !ok {
+				t.Errorf("theme %q doesn't define %q", name, "purple")
+			}
+		})
+	}
+
+	defaults, ok := ColorThemeColors("")
+	if !ok || defaults["red"] != colorstring.DefaultColors["red"] {
+		t.Errorf("empty name didn't select the default theme")
+	}
+
+	colorblind, _ := ColorThemeColors("colorblind")
+	if colorblind["red"] == defaults["red"] || colorblind["green"] == defaults["green"] {
+		t.Errorf("colorblind theme doesn't replace red and green")
+	}
+
+	// The result must be a copy, so that callers can't modify the themes.
+	colorblind["red"] = "0"
+	if again, _ := ColorThemeColors("colorblind"); again["red"] == "0" {
+		t.Errorf("modifying the result modified the theme")
+	}
+
+	if _, ok := ColorThemeColors("rainbow"); ok {
+		t.Errorf("unknown theme was found")
+	}
+}
+
+func TestASCIIOnly(t *testing.T) {
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Something went wrong",
+		Detail:   "The value “example” isn't valid.",
+	}
+	var diags tfdiags.Diagnostics
+	diags = diags.Append(diag)
+
+	got := ASCIIOnly(Diagnostic(diags[0], nil, disabledColorize, 40) + HorizontalRule(nil, 10))
+	want := `,
+| Error: Something went wrong
+|
+| The value “example” isn't valid.
+'
+
+---------`
+	if strings.TrimSpace(got) != want {
+		t.Errorf("wrong result\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -185,7 +185,23 @@ func (c *GraphCommand) Run(args []string) int {
 	case "plan-refresh-only":
 		g, graphDiags = lr.Core.PlanGraphForUI(lr.Config, lr.InputState, plans.RefreshOnlyMode)
 	case "plan-destroy":
-		g, graphDiags = lr.Core.PlanGraphForUI(lr.Config, lr.InputState, plans.DestroyMode)
+		if lr.Plan == nil {
+			g, graphDiags = lr.Core.PlanGraphForUI(lr.Config, lr.InputState, plans.DestroyMode)
+			break
+		}
+
+		// With a saved destroy plan we render the graph that applying it
+		// would actually walk, so that the destroy ordering (including any
+		// create_before_destroy edges) can be checked before applying.
+		if lr.Plan.UIMode != plans.DestroyMode {
+			graphDiags = graphDiags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Saved plan is not a destroy plan",
+				fmt.Sprintf("The graph type %q can only be used with a plan file created with the -destroy option. Use -type=apply instead to render the graph for applying the given plan.", graphTypeStr),
+			))
+			break
+		}
+		g, graphDiags = lr.Core.ApplyGraphForUI(lr.Plan, lr.Config)
 	case "apply":
 		plan := lr.Plan
 
@@ -270,6 +286,8 @@ Options:
   -type=plan       Type of graph to output. Can be: plan, plan-refresh-only,
                    plan-destroy, or apply. By default OpenTofu chooses
 				   "plan", or "apply" if you also set the -plan=... option.
+                   With a destroy plan file, "plan-destroy" renders the
+                   graph that applying the plan will execute.
 
   -module-depth=n  (deprecated) In prior versions of OpenTofu, specified the
 				   depth of modules to show in the output.
@@ -163,3 +163,89 @@ func TestGraph_plan(t *testing.T) {
 		t.Fatalf("doesn't look like digraph: %s", output)
 	}
 }
+
+func TestGraph_planDestroy(t *testing.T) {
+	testCwd(t)
+
+	planFileForMode := func(t *testing.T, mode plans.Mode) string {
+		t.Helper()
+		plan := &plans.Plan{
+			Changes: plans.NewChanges(),
+			UIMode:  mode,
+		}
+		plan.Changes.Resources = append(plan.Changes.Resources, &plans.ResourceInstanceChangeSrc{
+			Addr: addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "foo",
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			ChangeSrc: plans.ChangeSrc{
+				Action: plans.Delete,
+				Before: plans.DynamicValue(`{}`),
+				After:  plans.DynamicValue(`null`),
+			},
+			ProviderAddr: addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+		})
+		beConfig := cty.ObjectVal(map[string]cty.Value{
+			"path":          cty.NilVal,
+			"workspace_dir": cty.NilVal,
+		})
+		emptyConfig, err := plans.NewDynamicValue(beConfig, beConfig.Type())
+		if err != nil {
+			t.Fatal(err)
+		}
+		plan.Backend = plans.Backend{
+			Type:   "local",
+			Config: emptyConfig,
+		}
+		_, configSnap := testModuleWithSnapshot(t, "graph")
+		return testPlanFile(t, configSnap, states.NewState(), plan)
+	}
+
+	t.Run("destroy plan", func(t *testing.T) {
+		ui := new(cli.MockUi)
+		c := &GraphCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(applyFixtureProvider()),
+				Ui:               ui,
+			},
+		}
+
+		args := []string{
+			"-type", "plan-destroy",
+			"-plan", planFileForMode(t, plans.DestroyMode),
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+		}
+
+		output := ui.OutputWriter.String()
+		if !strings.Contains(output, `test_instance.foo (destroy)`) {
+			t.Fatalf("graph does not include the planned destroy: %s", output)
+		}
+	})
+
+	t.Run("normal plan", func(t *testing.T) {
+		ui := new(cli.MockUi)
+		c := &GraphCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(applyFixtureProvider()),
+				Ui:               ui,
+			},
+		}
+
+		args := []string{
+			"-type", "plan-destroy",
+			"-plan", planFileForMode(t, plans.NormalMode),
+		}
+		if code := c.Run(args); code != 1 {
+			t.Fatalf("unexpected success: \n%s", ui.OutputWriter.String())
+		}
+		if got := ui.ErrorWriter.String(); !strings.Contains(got, "Saved plan is not a destroy plan") {
+			t.Fatalf("wrong error: %s", got)
+		}
+	})
+}
@@ -7,9 +7,14 @@ package command
 
 import (
 	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
 
 	version "github.com/hashicorp/go-version"
 	"github.com/mitchellh/cli"
+	"github.com/opentofu/opentofu/internal/command/views"
+	viewsjson "github.com/opentofu/opentofu/internal/command/views/json"
 	"github.com/opentofu/opentofu/internal/initwd"
 )
 
@@ -17,6 +22,12 @@ type uiModuleInstallHooks struct {
 	initwd.ModuleInstallHooksImpl
 	Ui             cli.Ui
 	ShowLocalPaths bool
+
+	// JSONView, if set, receives structured events for each module in
+	// addition to the messages written to Ui. The sources map must then also
+	// be initialized, to track the source addresses of downloaded modules.
+	JSONView *views.JSONView
+	sources  map[string]string
 }
 
 var _ initwd.ModuleInstallHooks = uiModuleInstallHooks{}
@@ -27,6 +38,11 @@ func (h uiModuleInstallHooks) Download(modulePath, packageAddr string, v *versio
 	} else {
 		h.Ui.Info(fmt.Sprintf("Downloading %s for %s...", packageAddr, modulePath))
 	}
+
+	if h.JSONView != nil {
+		h.sources[modulePath] = packageAddr
+		h.JSONView.InitEvent(viewsjson.NewInitModuleDownload(modulePath, packageAddr, versionString(v)))
+	}
 }
 
 func (h uiModuleInstallHooks) Install(modulePath string, v *version.Version, localDir string) {
@@ -35,4 +51,40 @@ func (h uiModuleInstallHooks) Install(modulePath string, v *version.Version, loc
 	} else {
 		h.Ui.Info(fmt.Sprintf("- %s", modulePath))
 	}
+
+	if h.JSONView != nil {
+		h.JSONView.InitEvent(viewsjson.NewInitModuleInstalled(modulePath, h.sources[modulePath], versionString(v), localDir, dirSize(localDir)))
+	}
+}
+
+func versionString(v *version.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// dirSize returns the total size in bytes of the regular files in the given
+// directory and its subdirectories, for reporting the size of installed
+// modules.
+func dirSize(dir string) int64 {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		log.Printf("[WARN] failed to determine size of module directory %s: %s", dir, err)
+	}
+	return size
 }
@@ -275,6 +275,7 @@ func (c *ImportCommand) Run(args []string) int {
 	}
 
 	// Persist the final state
+	backend.RecordProviderVersions(newState, opReq.DependencyLocks)
 	log.Printf("[INFO] Writing state output to: %s", c.Meta.StateOutPath())
 	if err := state.WriteState(newState); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
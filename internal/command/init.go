@@ -26,13 +26,16 @@ import (
 	backendInit "github.com/opentofu/opentofu/internal/backend/init"
 	"github.com/opentofu/opentofu/internal/cloud"
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
 	"github.com/opentofu/opentofu/internal/command/views"
+	viewsjson "github.com/opentofu/opentofu/internal/command/views/json"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/providercache"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
 	"github.com/opentofu/opentofu/internal/tofumigrate"
@@ -43,11 +46,17 @@ import (
 // module and clones it to the working directory.
 type InitCommand struct {
 	Meta
+
+	// jsonView is set when producing machine-readable output, and receives
+	// structured progress events in addition to the wrapped UI messages.
+	jsonView *views.JSONView
 }
 
 func (c *InitCommand) Run(args []string) int {
 	var flagFromModule, flagLockfile, testsDirectory string
 	var flagBackend, flagCloud, flagGet, flagUpgrade bool
+	var flagBackendOnly, flagModulesOnly, flagProvidersOnly bool
+	var flagMigrateSources, flagDryRun bool
 	var flagPluginPath FlagStringSlice
 	flagConfigExtra := newRawFlags("-backend-config")
 
@@ -58,11 +67,16 @@ func (c *InitCommand) Run(args []string) int {
 	cmdFlags.Var(flagConfigExtra, "backend-config", "")
 	cmdFlags.StringVar(&flagFromModule, "from-module", "", "copy the source of the given module into the directory before init")
 	cmdFlags.BoolVar(&flagGet, "get", true, "")
+	cmdFlags.BoolVar(&flagBackendOnly, "backend-only", false, "only initialize the backend")
+	cmdFlags.BoolVar(&flagModulesOnly, "modules-only", false, "only install modules")
+	cmdFlags.BoolVar(&flagProvidersOnly, "providers-only", false, "only install providers")
 	cmdFlags.BoolVar(&c.forceInitCopy, "force-copy", false, "suppress prompts about copying state data")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
 	cmdFlags.BoolVar(&c.reconfigure, "reconfigure", false, "reconfigure")
 	cmdFlags.BoolVar(&c.migrateState, "migrate-state", false, "migrate state")
+	cmdFlags.BoolVar(&flagMigrateSources, "migrate-sources", false, "migrate legacy registry addresses")
+	cmdFlags.BoolVar(&flagDryRun, "dry-run", false, "only show the changes -migrate-sources would make")
 	cmdFlags.BoolVar(&flagUpgrade, "upgrade", false, "")
 	cmdFlags.Var(&flagPluginPath, "plugin-dir", "plugin directory")
 	cmdFlags.StringVar(&flagLockfile, "lockfile", "", "Set a dependency lockfile mode")
@@ -74,13 +88,22 @@ func (c *InitCommand) Run(args []string) int {
 		return 1
 	}
 
+	// The project file's backend configuration applies only when none is
+	// given on the command line.
+	if flagConfigExtra.Empty() && c.Project != nil {
+		for _, v := range c.Project.BackendConfig {
+			flagConfigExtra.Set(v)
+		}
+	}
+
 	if c.outputInJSON {
 		c.Meta.color = false
 		c.Meta.Color = false
 		c.oldUi = c.Ui
+		c.jsonView = views.NewJSONView(c.View)
 		c.Ui = &WrappedUi{
 			cliUi:        c.oldUi,
-			jsonView:     views.NewJSONView(c.View),
+			jsonView:     c.jsonView,
 			outputInJSON: true,
 		}
 	}
@@ -98,6 +121,44 @@ func (c *InitCommand) Run(args []string) int {
 		flagBackend = flagCloud
 	}
 
+	phaseFlagsSet := 0
+	for _, set := range []bool{flagBackendOnly, flagModulesOnly, flagProvidersOnly} {
+		if set {
+			phaseFlagsSet++
+		}
+	}
+	if phaseFlagsSet > 1 {
+		c.Ui.Error("The -backend-only, -modules-only and -providers-only options are mutually-exclusive")
+		return 1
+	}
+	if phaseFlagsSet > 0 && (backendFlagSet || cloudFlagSet || arguments.FlagIsSet(cmdFlags, "get")) {
+		c.Ui.Error("The -backend-only, -modules-only and -providers-only options cannot be used with -backend, -cloud or -get")
+		return 1
+	}
+	if phaseFlagsSet > 0 && flagFromModule != "" {
+		c.Ui.Error("The -from-module option cannot be used with -backend-only, -modules-only or -providers-only")
+		return 1
+	}
+	if flagDryRun && !flagMigrateSources {
+		c.Ui.Error("The -dry-run option can only be used with -migrate-sources")
+		return 1
+	}
+	if flagMigrateSources && (flagBackendOnly || flagModulesOnly) {
+		c.Ui.Error("The -migrate-sources option cannot be used with -backend-only or -modules-only")
+		return 1
+	}
+
+	// Each of the phase flags selects exactly one of the initialization
+	// phases, skipping the others.
+	switch {
+	case flagBackendOnly:
+		flagGet = false
+	case flagModulesOnly, flagProvidersOnly:
+		flagBackend = false
+		flagCloud = false
+		flagGet = flagModulesOnly
+	}
+
 	if c.migrateState && c.reconfigure {
 		c.Ui.Error("The -migrate-state and -reconfigure options are mutually-exclusive")
 		return 1
@@ -157,6 +218,8 @@ func (c *InitCommand) Run(args []string) int {
 		hooks := uiModuleInstallHooks{
 			Ui:             c.Ui,
 			ShowLocalPaths: false, // since they are in a weird location for init
+			JSONView:       c.jsonView,
+			sources:        make(map[string]string),
 		}
 
 		ctx, span := tracer.Start(ctx, "-from-module=...", trace.WithAttributes(
@@ -205,8 +268,9 @@ func (c *InitCommand) Run(args []string) int {
 	}
 
 	var enc encryption.Encryption
-	// If backend flag is explicitly set to false i.e -backend=false, we disable state and plan encryption
-	if backendFlagSet && !flagBackend {
+	// If backend flag is explicitly set to false i.e -backend=false, or the
+	// backend isn't initialized in this run, we disable state and plan encryption
+	if (backendFlagSet && !flagBackend) || flagModulesOnly || flagProvidersOnly {
 		enc = encryption.Disabled()
 	} else {
 		// Load the encryption configuration
@@ -227,10 +291,19 @@ func (c *InitCommand) Run(args []string) int {
 	var backendOutput bool
 
 	switch {
+	case flagModulesOnly:
+		// Installing modules needs neither the backend nor the state, so
+		// we don't require credentials for the backend.
 	case flagCloud && rootModEarly.CloudConfig != nil:
 		back, backendOutput, backDiags = c.initCloud(ctx, rootModEarly, flagConfigExtra, enc)
+		c.initEvent(viewsjson.NewInitBackend("cloud", !backDiags.HasErrors()))
 	case flagBackend:
 		back, backendOutput, backDiags = c.initBackend(ctx, rootModEarly, flagConfigExtra, enc)
+		backendType := "local"
+		if rootModEarly.Backend != nil {
+			backendType = rootModEarly.Backend.Type
+		}
+		c.initEvent(viewsjson.NewInitBackend(backendType, !backDiags.HasErrors()))
 	default:
 		// load the previously-stored backend config
 		back, backDiags = c.Meta.backendFromState(ctx, enc.State())
@@ -240,6 +313,7 @@ func (c *InitCommand) Run(args []string) int {
 	}
 
 	var state *states.State
+	var stateMgr statemgr.Full
 
 	// If we have a functional backend (either just initialized or initialized
 	// on a previous run) we'll use the current state as a potential source
@@ -263,6 +337,19 @@ func (c *InitCommand) Run(args []string) int {
 		}
 
 		state = sMgr.State()
+		stateMgr = sMgr
+	}
+
+	if flagBackendOnly {
+		diags = diags.Append(earlyConfDiags.StrictDeduplicateMerge(backDiags))
+		if earlyConfDiags.HasErrors() {
+			c.Ui.Error(strings.TrimSpace(errInitConfigError))
+		}
+		c.showDiagnostics(diags)
+		if diags.HasErrors() {
+			return 1
+		}
+		return c.initPhaseSuccess(header, "Backend")
 	}
 
 	if flagGet {
@@ -345,6 +432,21 @@ func (c *InitCommand) Run(args []string) int {
 		state = migratedState
 	}
 
+	if flagMigrateSources {
+		migrateDiags := c.migrateSources(config, stateMgr, flagDryRun)
+		diags = diags.Append(migrateDiags)
+		if migrateDiags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		header = true
+	}
+
+	if flagModulesOnly {
+		c.showDiagnostics(diags)
+		return c.initPhaseSuccess(header, "Module")
+	}
+
 	// Now that we have loaded all modules, check the module tree for missing providers.
 	providersOutput, providersAbort, providerDiags := c.getProviders(ctx, config, state, flagUpgrade, flagPluginPath, flagLockfile)
 	diags = diags.Append(providerDiags)
@@ -356,6 +458,30 @@ func (c *InitCommand) Run(args []string) int {
 		header = true
 	}
 
+	// Now that the providers are installed, make sure that the selected
+	// versions support the capabilities the configuration relies on.
+	capabilityDiags := c.checkProviderCapabilities(config)
+	diags = diags.Append(capabilityDiags)
+	if capabilityDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Also make sure that the selected versions aren't older than the ones
+	// that last updated the state, which can happen when the lock file is
+	// reverted or a version constraint is lowered.
+	schemaVersionDiags := c.checkStateSchemaVersions(state)
+	diags = diags.Append(schemaVersionDiags)
+	if schemaVersionDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	if flagProvidersOnly {
+		c.showDiagnostics(diags)
+		return c.initPhaseSuccess(header, "Provider")
+	}
+
 	// If we outputted information, then we need to output a newline
 	// so that our success message is nicely spaced out from prior text.
 	if header {
@@ -387,6 +513,158 @@ func (c *InitCommand) Run(args []string) int {
 	return 0
 }
 
+// checkProviderCapabilities starts the providers that have capabilities
+// declared in their required_providers entries, and returns an error for
+// each capability that the installed version doesn't support.
+func (c *InitCommand) checkProviderCapabilities(config *configs.Config) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	declared := false
+	config.DeepEach(func(modCfg *configs.Config) {
+		if modCfg.Module.ProviderRequirements == nil {
+			return
+		}
+		for _, req := range modCfg.Module.ProviderRequirements.RequiredProviders {
+			declared = declared || len(req.Capabilities) != 0
+		}
+	})
+	if !declared {
+		return diags
+	}
+
+	opts, err := c.contextOpts()
+	if err != nil {
+		diags = diags.Append(err)
+		return diags
+	}
+	tfCtx, ctxDiags := tofu.NewContext(opts)
+	diags = diags.Append(ctxDiags)
+	if ctxDiags.HasErrors() {
+		return diags
+	}
+	return diags.Append(tfCtx.CheckProviderCapabilities(config))
+}
+
+// checkStateSchemaVersions starts the providers that manage resources in the
+// given state, and returns an error for each provider whose selected version
+// is older than the one that saved some of those resources.
+func (c *InitCommand) checkStateSchemaVersions(state *states.State) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if state == nil || !state.HasManagedResourceInstanceObjects() {
+		return diags
+	}
+
+	// Any problems with the installed providers themselves are reported
+	// when they're used for a plan, so we only check what we can here.
+	opts, err := c.contextOpts()
+	if err != nil {
+		log.Printf("[WARN] init: can't check the state schema versions: %s", err)
+		return diags
+	}
+	tfCtx, ctxDiags := tofu.NewContext(opts)
+	if ctxDiags.HasErrors() {
+		log.Printf("[WARN] init: can't check the state schema versions: %s", ctxDiags.Err())
+		return diags
+	}
+	return diags.Append(tfCtx.CheckStateSchemaVersions(state))
+}
+
+// migrateSources updates the provider addresses in the stored state that
+// refer to the legacy registry, and shows the equivalent changes to make to
+// source addresses in the configuration. If dryRun is set, the changes are
+// only shown.
+func (c *InitCommand) migrateSources(config *configs.Config, stateMgr statemgr.Full, dryRun bool) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	var state *states.State
+	if stateMgr != nil {
+		if c.stateLock && !dryRun {
+			stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+			if lockDiags := stateLocker.Lock(stateMgr, "init-migrate-sources"); lockDiags.HasErrors() {
+				return diags.Append(lockDiags)
+			}
+			defer func() {
+				if unlockDiags := stateLocker.Unlock(); unlockDiags.HasErrors() {
+					c.showDiagnostics(unlockDiags)
+				}
+			}()
+		}
+		if err := stateMgr.RefreshState(); err != nil {
+			diags = diags.Append(fmt.Errorf("failed to refresh state: %w", err))
+			return diags
+		}
+		state = stateMgr.State()
+	}
+
+	migration, moreDiags := tofumigrate.PlanSourceMigration(config, state)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return diags
+	}
+	if migration.Empty() {
+		c.Ui.Output(c.Colorize().Color("\n[reset][bold]No legacy registry addresses found in the state or configuration."))
+		return diags
+	}
+
+	colorize := c.Colorize()
+	if len(migration.StateChanges) > 0 {
+		verb := "will"
+		if dryRun {
+			verb = "would"
+		}
+		c.Ui.Output(colorize.Color(fmt.Sprintf("\n[reset][bold]OpenTofu %s update the provider addresses in the state:\n", verb)))
+		for _, change := range migration.StateChanges {
+			c.Ui.Output(colorize.Color(fmt.Sprintf("  [yellow]~[reset] Updating provider for %d resources:", len(change.Resources))))
+			c.Ui.Output(colorize.Color(fmt.Sprintf("    [red]-[reset] %s", change.From)))
+			c.Ui.Output(colorize.Color(fmt.Sprintf("    [green]+[reset] %s", change.To)))
+			for _, addr := range change.Resources {
+				c.Ui.Output(fmt.Sprintf("      %s", addr))
+			}
+			c.Ui.Output("")
+		}
+	}
+	if len(migration.ConfigChanges) > 0 {
+		c.Ui.Output(colorize.Color("\n[reset][bold]Update the following source addresses in the configuration:\n"))
+		for _, change := range migration.ConfigChanges {
+			c.Ui.Output(fmt.Sprintf("  %s:%d (%s)", change.Range.Filename, change.Range.Start.Line, change.Subject))
+			c.Ui.Output(colorize.Color(fmt.Sprintf("    [red]-[reset] source = %q", change.From)))
+			c.Ui.Output(colorize.Color(fmt.Sprintf("    [green]+[reset] source = %q", change.To)))
+			c.Ui.Output("")
+		}
+	}
+
+	if dryRun || len(migration.StateChanges) == 0 {
+		return diags
+	}
+
+	migration.ApplyToState(state)
+	if err := stateMgr.WriteState(state); err != nil {
+		diags = diags.Append(fmt.Errorf("failed to write state: %w", err))
+		return diags
+	}
+	// Provider schemas are only needed to persist state in cloud mode, and
+	// the providers are not installed yet at this point, so we can't fetch
+	// them here.
+	if err := stateMgr.PersistState(nil); err != nil {
+		diags = diags.Append(fmt.Errorf("failed to persist state: %w", err))
+		return diags
+	}
+	c.Ui.Output(colorize.Color("[reset][green]Updated the provider addresses in the state."))
+	return diags
+}
+
+// initPhaseSuccess reports that the single initialization phase selected by
+// one of the -backend-only, -modules-only or -providers-only options has
+// completed, and returns the exit status for the command.
+func (c *InitCommand) initPhaseSuccess(header bool, phase string) int {
+	if header {
+		c.Ui.Output("")
+	}
+	c.Ui.Output(c.Colorize().Color(strings.TrimSpace(fmt.Sprintf(outputInitPhaseSuccess, phase))))
+	return 0
+}
+
 func (c *InitCommand) getModules(ctx context.Context, path, testsDir string, earlyRoot *configs.Module, upgrade bool) (output bool, abort bool, diags tfdiags.Diagnostics) {
 	testModules := false // We can also have modules buried in test files.
 	for _, file := range earlyRoot.Tests {
@@ -416,6 +694,8 @@ func (c *InitCommand) getModules(ctx context.Context, path, testsDir string, ear
 	hooks := uiModuleInstallHooks{
 		Ui:             c.Ui,
 		ShowLocalPaths: true,
+		JSONView:       c.jsonView,
+		sources:        make(map[string]string),
 	}
 
 	installAbort, installDiags := c.installModules(ctx, path, testsDir, upgrade, false, hooks)
@@ -640,6 +920,11 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 	// and incomplete providers are stored here for later analysis.
 	var incompleteProviders []string
 
+	// These track details of the in-progress installation of each provider,
+	// for the structured events emitted when producing JSON output.
+	lockedProviders := make(map[addrs.Provider]bool)
+	providerLocations := make(map[addrs.Provider]string)
+
 	// Because we're currently just streaming a series of events sequentially
 	// into the terminal, we're showing only a subset of the events to keep
 	// things relatively concise. Later it'd be nice to have a progress UI
@@ -653,11 +938,14 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 		},
 		ProviderAlreadyInstalled: func(provider addrs.Provider, selectedVersion getproviders.Version) {
 			c.Ui.Info(fmt.Sprintf("- Using previously-installed %s v%s", provider.ForDisplay(), selectedVersion))
+			c.initEvent(viewsjson.NewInitProviderInstalled(provider, selectedVersion, viewsjson.InitProviderSourceAlreadyInstalled, "", nil))
 		},
 		BuiltInProviderAvailable: func(provider addrs.Provider) {
 			c.Ui.Info(fmt.Sprintf("- %s is built in to OpenTofu", provider.ForDisplay()))
+			c.initEvent(viewsjson.NewInitProviderInstalled(provider, getproviders.UnspecifiedVersion, viewsjson.InitProviderSourceBuiltIn, "", nil))
 		},
 		BuiltInProviderFailure: func(provider addrs.Provider, err error) {
+			c.initEvent(viewsjson.NewInitProviderErrored(provider, getproviders.UnspecifiedVersion, "", err))
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Invalid dependency on built-in provider",
@@ -665,6 +953,7 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 			))
 		},
 		QueryPackagesBegin: func(provider addrs.Provider, versionConstraints getproviders.VersionConstraints, locked bool) {
+			lockedProviders[provider] = locked
 			if locked {
 				c.Ui.Info(fmt.Sprintf("- Reusing previous version of %s from the dependency lock file", provider.ForDisplay()))
 			} else {
@@ -675,13 +964,22 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 				}
 			}
 		},
+		QueryPackagesSuccess: func(provider addrs.Provider, selectedVersion getproviders.Version) {
+			c.initEvent(viewsjson.NewInitProviderSelected(provider, selectedVersion, reqs[provider], lockedProviders[provider]))
+		},
 		LinkFromCacheBegin: func(provider addrs.Provider, version getproviders.Version, cacheRoot string) {
 			c.Ui.Info(fmt.Sprintf("- Using %s v%s from the shared cache directory", provider.ForDisplay(), version))
+			providerLocations[provider] = cacheRoot
+		},
+		LinkFromCacheSuccess: func(provider addrs.Provider, version getproviders.Version, localDir string) {
+			c.initEvent(viewsjson.NewInitProviderInstalled(provider, version, viewsjson.InitProviderSourceCache, providerLocations[provider], nil))
 		},
 		FetchPackageBegin: func(provider addrs.Provider, version getproviders.Version, location getproviders.PackageLocation) {
 			c.Ui.Info(fmt.Sprintf("- Installing %s v%s...", provider.ForDisplay(), version))
+			providerLocations[provider] = location.String()
 		},
 		QueryPackagesFailure: func(provider addrs.Provider, err error) {
+			c.initEvent(viewsjson.NewInitProviderErrored(provider, getproviders.UnspecifiedVersion, "", err))
 			switch errorTy := err.(type) {
 			case getproviders.ErrProviderNotFound:
 				sources := errorTy.Sources
@@ -757,6 +1055,15 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 					))
 				}
 
+			case getproviders.ErrSourcesUnavailable:
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Failed to query available provider packages",
+					fmt.Sprintf("Could not retrieve the list of available versions for provider %s, because none of the installation methods that can provide it could be reached. OpenTofu tried:\n\n%s",
+						provider.ForDisplay(), formatSourceAttempts(errorTy.Attempts),
+					),
+				))
+
 			case getproviders.ErrRequestCanceled:
 				// We don't attribute cancellation to any particular operation,
 				// but rather just emit a single general message about it at
@@ -789,6 +1096,7 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 			))
 		},
 		LinkFromCacheFailure: func(provider addrs.Provider, version getproviders.Version, err error) {
+			c.initEvent(viewsjson.NewInitProviderErrored(provider, version, providerLocations[provider], err))
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Failed to install provider from shared cache",
@@ -796,6 +1104,7 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 			))
 		},
 		FetchPackageFailure: func(provider addrs.Provider, version getproviders.Version, err error) {
+			c.initEvent(viewsjson.NewInitProviderErrored(provider, version, providerLocations[provider], err))
 			const summaryIncompatible = "Incompatible provider version"
 			switch err := err.(type) {
 			case getproviders.ErrProtocolNotSupported:
@@ -852,6 +1161,15 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 					))
 				}
 
+			case getproviders.ErrSourcesUnavailable:
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Failed to install provider",
+					fmt.Sprintf("Error while installing %s v%s, because none of the installation methods that can provide it could be reached. OpenTofu tried:\n\n%s",
+						provider.ForDisplay(), version, formatSourceAttempts(err.Attempts),
+					),
+				))
+
 			case getproviders.ErrRequestCanceled:
 				// We don't attribute cancellation to any particular operation,
 				// but rather just emit a single general message about it at
@@ -875,6 +1193,8 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 			}
 		},
 		FetchPackageSuccess: func(provider addrs.Provider, version getproviders.Version, localDir string, authResult *getproviders.PackageAuthenticationResult) {
+			c.initEvent(viewsjson.NewInitProviderInstalled(provider, version, viewsjson.InitProviderSourceFetched, providerLocations[provider], authResult))
+
 			var keyID string
 			if authResult != nil && authResult.Signed() {
 				keyID = authResult.KeyID
@@ -890,6 +1210,8 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 			}
 		},
 		ProvidersLockUpdated: func(provider addrs.Provider, version getproviders.Version, localHashes []getproviders.Hash, signedHashes []getproviders.Hash, priorHashes []getproviders.Hash) {
+			c.initEvent(viewsjson.NewInitLockUpdated(provider, version, localHashes, signedHashes, priorHashes))
+
 			// We're going to use this opportunity to track if we have any
 			// "incomplete" installs of providers. An incomplete install is
 			// when we are only going to write the local hashes into our lock
@@ -1039,6 +1361,24 @@ version control system if they represent changes you intended to make.`))
 	return true, false, diags
 }
 
+// initEvent emits the given structured progress event if the command is
+// producing machine-readable output.
+func (c *InitCommand) initEvent(e viewsjson.InitEvent) {
+	if c.jsonView != nil {
+		c.jsonView.InitEvent(e)
+	}
+}
+
+// formatSourceAttempts describes each of the given failed attempts to query a
+// provider installation method, as a bulleted list.
+func formatSourceAttempts(attempts []getproviders.SourceAttempt) string {
+	lines := make([]string, len(attempts))
+	for i, attempt := range attempts {
+		lines[i] = fmt.Sprintf("  - %s: %s", attempt.Source, attempt.Err)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // backendConfigOverrideBody interprets the raw values of -backend-config
 // arguments into a hcl Body that should override the backend settings given
 // in the configuration.
@@ -1047,7 +1387,7 @@ version control system if they represent changes you intended to make.`))
 //
 // If the returned diagnostics contains errors then the returned body may be
 // incomplete or invalid.
-func (c *InitCommand) backendConfigOverrideBody(flags rawFlags, schema *configschema.Block) (hcl.Body, tfdiags.Diagnostics) {
+func (m *Meta) backendConfigOverrideBody(flags rawFlags, schema *configschema.Block) (hcl.Body, tfdiags.Diagnostics) {
 	items := flags.AllItems()
 	if len(items) == 0 {
 		return nil, nil
@@ -1084,7 +1424,7 @@ func (c *InitCommand) backendConfigOverrideBody(flags rawFlags, schema *configsc
 
 		if eq == -1 {
 			// The value is interpreted as a filename.
-			newBody, fileDiags := c.loadHCLFile(item.Value)
+			newBody, fileDiags := m.loadHCLFile(item.Value)
 			diags = diags.Append(fileDiags)
 			if fileDiags.HasErrors() {
 				continue
@@ -1150,20 +1490,25 @@ func (c *InitCommand) AutocompleteArgs() complete.Predictor {
 
 func (c *InitCommand) AutocompleteFlags() complete.Flags {
 	return complete.Flags{
-		"-backend":        completePredictBoolean,
-		"-cloud":          completePredictBoolean,
-		"-backend-config": complete.PredictFiles("*.tfvars"), // can also be key=value, but we can't "predict" that
-		"-force-copy":     complete.PredictNothing,
-		"-from-module":    completePredictModuleSource,
-		"-get":            completePredictBoolean,
-		"-input":          completePredictBoolean,
-		"-lock":           completePredictBoolean,
-		"-lock-timeout":   complete.PredictAnything,
-		"-no-color":       complete.PredictNothing,
-		"-plugin-dir":     complete.PredictDirs(""),
-		"-reconfigure":    complete.PredictNothing,
-		"-migrate-state":  complete.PredictNothing,
-		"-upgrade":        completePredictBoolean,
+		"-backend":         completePredictBoolean,
+		"-cloud":           completePredictBoolean,
+		"-backend-config":  complete.PredictFiles("*.tfvars"), // can also be key=value, but we can't "predict" that
+		"-backend-only":    complete.PredictNothing,
+		"-force-copy":      complete.PredictNothing,
+		"-from-module":     completePredictModuleSource,
+		"-get":             completePredictBoolean,
+		"-input":           completePredictBoolean,
+		"-lock":            completePredictBoolean,
+		"-lock-timeout":    complete.PredictAnything,
+		"-no-color":        complete.PredictNothing,
+		"-plugin-dir":      complete.PredictDirs(""),
+		"-reconfigure":     complete.PredictNothing,
+		"-migrate-state":   complete.PredictNothing,
+		"-migrate-sources": complete.PredictNothing,
+		"-dry-run":         complete.PredictNothing,
+		"-modules-only":    complete.PredictNothing,
+		"-providers-only":  complete.PredictNothing,
+		"-upgrade":         completePredictBoolean,
 	}
 }
 
@@ -1200,6 +1545,9 @@ Options:
                           times. The backend type must be in the configuration
                           itself.
 
+  -backend-only           Only initialize the backend or cloud backend,
+                          skipping module and provider installation.
+
   -compact-warnings       If OpenTofu produces any warnings that are not
                           accompanied by errors, show them in a more compact
                           form that includes only the summary messages.
@@ -1222,6 +1570,15 @@ Options:
 
   -get=false              Disable downloading modules for this configuration.
 
+  -modules-only           Only install the modules for this configuration,
+                          without initializing the backend or installing
+                          providers. The backend is not contacted, so no
+                          backend credentials are required.
+
+  -providers-only         Only install the providers for this configuration,
+                          using the modules and backend that were previously
+                          initialized.
+
   -input=false            Disable interactive prompts. Note that some actions may
                           require interactive prompts and will error if input is
                           disabled.
@@ -1245,6 +1602,14 @@ Options:
   -migrate-state          Reconfigure a backend, and attempt to migrate any
                           existing state.
 
+  -migrate-sources        Update provider addresses in the state that refer to
+                          the legacy registry.terraform.io registry to use the
+                          OpenTofu registry instead, and suggest the equivalent
+                          changes to source addresses in the configuration.
+
+  -dry-run                With -migrate-sources, only show the changes that
+                          would be made, without changing the state.
+
   -upgrade                Install the latest module and provider versions
                           allowed within configured constraints, overriding the
                           default behavior of selecting exactly the version
@@ -1266,7 +1631,9 @@ Options:
 
   -json                   Produce output in a machine-readable JSON format, 
                           suitable for use in text editor integrations and other 
-                          automated systems. Always disables color.
+                          automated systems. Always disables color. Progress
+                          of backend, module, and provider installation is
+                          reported as structured events.
 
   -var 'foo=bar'          Set a value for one of the input variables in the root
                           module of the configuration. Use this option more than
@@ -1312,6 +1679,10 @@ const outputInitSuccess = `
 [reset][bold][green]OpenTofu has been successfully initialized![reset][green]
 `
 
+const outputInitPhaseSuccess = `
+[reset][bold][green]%s initialization completed successfully![reset][green]
+`
+
 const outputInitSuccessCloud = `
 [reset][bold][green]Cloud backend has been successfully initialized![reset][green]
 `
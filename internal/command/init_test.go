@@ -189,6 +189,125 @@ func TestInit_get(t *testing.T) {
 	}
 }
 
+func TestInit_jsonModuleEvents(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("init-get"), td)
+	defer testChdir(t, td)()
+
+	view, done := testView(t)
+	c := &InitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               new(cli.MockUi),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-json"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: \n%s", output.All())
+	}
+
+	events := testInitJSONEvents(t, output.Stdout())
+	if got := events["init_backend"]; len(got) != 1 || got[0]["type"] != "local" || got[0]["success"] != true {
+		t.Errorf("wrong init_backend events: %#v", got)
+	}
+	installed := events["init_module_installed"]
+	if len(installed) != 1 {
+		t.Fatalf("expected 1 init_module_installed event, got %#v", installed)
+	}
+	if got, want := installed[0]["module"], "foo"; got != want {
+		t.Errorf("wrong module %q; want %q", got, want)
+	}
+	if got, want := installed[0]["dir"], "foo"; got != want {
+		t.Errorf("wrong dir %q; want %q", got, want)
+	}
+	if bytes, ok := installed[0]["bytes"].(float64); !ok || bytes <= 0 {
+		t.Errorf("wrong bytes %#v; want a positive number", installed[0]["bytes"])
+	}
+}
+
+func TestInit_jsonProviderEvents(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("init-get-providers"), td)
+	defer testChdir(t, td)()
+
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"exact":        {"1.2.3"},
+		"greater-than": {"2.3.4", "2.3.3", "2.3.0"},
+		"between":      {"3.4.5", "2.3.4", "1.2.3"},
+	})
+	defer close()
+
+	view, done := testView(t)
+	c := &InitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               new(cli.MockUi),
+			View:             view,
+			ProviderSource:   providerSource,
+		},
+	}
+
+	code := c.Run([]string{"-backend=false", "-json"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: \n%s", output.All())
+	}
+
+	events := testInitJSONEvents(t, output.Stdout())
+	if got := events["init_backend"]; len(got) != 0 {
+		t.Errorf("unexpected init_backend events: %#v", got)
+	}
+
+	selected := make(map[string]interface{})
+	for _, e := range events["init_provider_selected"] {
+		selected[e["provider"].(string)] = e["version"]
+	}
+	wantSelected := map[string]interface{}{
+		"registry.opentofu.org/hashicorp/exact":        "1.2.3",
+		"registry.opentofu.org/hashicorp/greater-than": "2.3.4",
+		"registry.opentofu.org/hashicorp/between":      "2.3.4",
+	}
+	if diff := cmp.Diff(wantSelected, selected); diff != "" {
+		t.Errorf("wrong selected providers\n%s", diff)
+	}
+
+	for _, e := range events["init_provider_installed"] {
+		if e["source"] != "fetched" {
+			t.Errorf("wrong source for %s: %#v", e["provider"], e["source"])
+		}
+	}
+	if got := len(events["init_provider_installed"]); got != 3 {
+		t.Errorf("expected 3 init_provider_installed events, got %d", got)
+	}
+	if got := len(events["init_lock_updated"]); got != 3 {
+		t.Errorf("expected 3 init_lock_updated events, got %d", got)
+	}
+}
+
+// testInitJSONEvents parses the JSON lines output of "tofu init -json" and
+// returns the payloads of the init events grouped by message type.
+func testInitJSONEvents(t *testing.T, output string) map[string][]map[string]interface{} {
+	t.Helper()
+
+	events := make(map[string][]map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var msg struct {
+			Type string                 `json:"type"`
+			Init map[string]interface{} `json:"init"`
+		}
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatalf("invalid JSON output line %q: %s", line, err)
+		}
+		if msg.Init != nil {
+			events[msg.Type] = append(events[msg.Type], msg.Init)
+		}
+	}
+	return events
+}
+
 func TestInit_getUpgradeModules(t *testing.T) {
 	// Create a temporary working directory that is empty
 	td := t.TempDir()
@@ -220,6 +339,209 @@ func TestInit_getUpgradeModules(t *testing.T) {
 	}
 }
 
+func TestInit_backendOnly(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("init-phases"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &InitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	if code := c.Run([]string{"-backend-only"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(DefaultDataDir, DefaultStateFilename)); err != nil {
+		t.Fatalf("backend was not initialized: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(DefaultDataDir, "modules")); !os.IsNotExist(err) {
+		t.Fatalf("modules should not have been installed, but got: %v", err)
+	}
+	if got, want := ui.OutputWriter.String(), "Backend initialization completed successfully!"; !strings.Contains(got, want) {
+		t.Fatalf("output doesn't contain %q:\n%s", want, got)
+	}
+}
+
+func TestInit_modulesOnly(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("init-phases"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &InitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	if code := c.Run([]string{"-modules-only"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(DefaultDataDir, "modules", "modules.json")); err != nil {
+		t.Fatalf("modules were not installed: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(DefaultDataDir, DefaultStateFilename)); !os.IsNotExist(err) {
+		t.Fatalf("backend should not have been initialized, but got: %v", err)
+	}
+	if got, want := ui.OutputWriter.String(), "Module initialization completed successfully!"; !strings.Contains(got, want) {
+		t.Fatalf("output doesn't contain %q:\n%s", want, got)
+	}
+}
+
+func TestInit_providersOnly(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("init-get-providers"), td)
+	defer testChdir(t, td)()
+
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"exact":        {"1.2.3"},
+		"greater-than": {"2.3.4", "2.3.3", "2.3.0"},
+		"between":      {"3.4.5", "2.3.4", "1.2.3"},
+	})
+	defer close()
+
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &InitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			View:             view,
+			ProviderSource:   providerSource,
+		},
+	}
+
+	if code := c.Run([]string{"-providers-only"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	exactPath := fmt.Sprintf(".terraform/providers/registry.opentofu.org/hashicorp/exact/1.2.3/%s", getproviders.CurrentPlatform)
+	if _, err := os.Stat(exactPath); os.IsNotExist(err) {
+		t.Fatal("provider 'exact' not downloaded")
+	}
+	if got, want := ui.OutputWriter.String(), "Provider initialization completed successfully!"; !strings.Contains(got, want) {
+		t.Fatalf("output doesn't contain %q:\n%s", want, got)
+	}
+}
+
+func TestInit_phaseFlagsConflict(t *testing.T) {
+	tests := map[string][]string{
+		"two phases":        {"-backend-only", "-modules-only"},
+		"phase and get":     {"-providers-only", "-get=false"},
+		"phase and backend": {"-modules-only", "-backend=false"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			td := t.TempDir()
+			testCopyDir(t, testFixturePath("init-phases"), td)
+			defer testChdir(t, td)()
+
+			ui := new(cli.MockUi)
+			view, _ := testView(t)
+			c := &InitCommand{
+				Meta: Meta{
+					testingOverrides: metaOverridesForProvider(testProvider()),
+					Ui:               ui,
+					View:             view,
+				},
+			}
+
+			if code := c.Run(args); code != 1 {
+				t.Fatalf("expected error, got success\n%s", ui.OutputWriter.String())
+			}
+			if got, want := ui.ErrorWriter.String(), "-backend-only, -modules-only"; !strings.Contains(got, want) {
+				t.Fatalf("wrong error; want substring %q, got:\n%s", want, got)
+			}
+		})
+	}
+}
+
+func TestInit_migrateSources(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+	if err := os.WriteFile("main.tf", []byte("resource \"test_instance\" \"foo\" {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	legacyProvider := addrs.MustParseProviderSourceString("registry.terraform.io/hashicorp/test")
+	testStateFileDefault(t, states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "foo"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			&states.ResourceInstanceObjectSrc{
+				Status:    states.ObjectReady,
+				AttrsJSON: []byte(`{"id":"foo"}`),
+			},
+			addrs.AbsProviderConfig{Module: addrs.RootModule, Provider: legacyProvider},
+			addrs.NoKey,
+		)
+	}))
+
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"test": {"1.2.3"},
+	})
+	defer close()
+
+	for _, dryRun := range []bool{true, false} {
+		args := []string{"-migrate-sources"}
+		wantProvider := "registry.opentofu.org/hashicorp/test"
+		if dryRun {
+			args = append(args, "-dry-run")
+			wantProvider = legacyProvider.String()
+		}
+
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		c := &InitCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+				View:             view,
+				ProviderSource:   providerSource,
+			},
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+		}
+		if got, want := ui.OutputWriter.String(), "test_instance.foo"; !strings.Contains(got, want) {
+			t.Fatalf("output doesn't contain %q:\n%s", want, got)
+		}
+
+		state := testStateRead(t, DefaultStateFilename)
+		resource := state.RootModule().Resources["test_instance.foo"]
+		if got := resource.ProviderConfig.Provider.String(); got != wantProvider {
+			t.Errorf("wrong provider in state with dry run %t: got %s, want %s", dryRun, got, wantProvider)
+		}
+	}
+}
+
+func TestInit_migrateSourcesFlags(t *testing.T) {
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	c := &InitCommand{
+		Meta: Meta{
+			Ui:   ui,
+			View: view,
+		},
+	}
+	if code := c.Run([]string{"-dry-run"}); code != 1 {
+		t.Fatalf("expected error, got success\n%s", ui.OutputWriter.String())
+	}
+	if got, want := ui.ErrorWriter.String(), "only be used with -migrate-sources"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error; want substring %q, got:\n%s", want, got)
+	}
+}
+
 func TestInit_backend(t *testing.T) {
 	// Create a temporary working directory that is empty
 	td := t.TempDir()
@@ -0,0 +1,62 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package renderers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/jsonformat/computed"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+var _ computed.DiffRenderer = (*annotatedRenderer)(nil)
+
+// Annotated renders a change in full, followed by a comment with the given
+// note at the end of its first line.
+func Annotated(change computed.Diff, note string) computed.DiffRenderer {
+	return &annotatedRenderer{
+		inner: change,
+		note:  note,
+	}
+}
+
+type annotatedRenderer struct {
+	inner computed.Diff
+	note  string
+}
+
+func (renderer annotatedRenderer) RenderHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) string {
+	rendered := renderer.inner.RenderHuman(indent, opts)
+	comment := opts.Colorize.Color(fmt.Sprintf(" [dark_gray]# %s[reset]", renderer.note))
+	if first, rest, ok := strings.Cut(rendered, "\n"); ok {
+		return first + comment + "\n" + rest
+	}
+	return rendered + comment
+}
+
+func (renderer annotatedRenderer) WarningsHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) []string {
+	return renderer.inner.WarningsHuman(indent, opts)
+}
+
+// AnnotateAttributes returns a copy of the given resource diff in which each
+// changed top-level attribute with a note in the given map is rendered using
+// Annotated. Diffs that don't use the block renderer are returned unchanged.
+func AnnotateAttributes(diff computed.Diff, notes map[string]string) computed.Diff {
+	block, ok := diff.Renderer.(*blockRenderer)
+	if !ok || len(notes) == 0 {
+		return diff
+	}
+
+	attributes := make(map[string]computed.Diff, len(block.attributes))
+	for key, attribute := range block.attributes {
+		if note, ok := notes[key]; ok && attribute.Action != plans.NoOp {
+			attribute = computed.NewDiff(Annotated(attribute, note), attribute.Action, attribute.Replace)
+		}
+		attributes[key] = attribute
+	}
+
+	diff.Renderer = Block(attributes, block.blocks)
+	return diff
+}
@@ -0,0 +1,57 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package renderers
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/command/jsonformat/computed"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+var _ computed.DiffRenderer = (*collapsedRenderer)(nil)
+
+// Collapsed renders a change as a single-line marker instead of in full, for
+// attributes the user has said produce noisy diffs.
+func Collapsed(change computed.Diff) computed.DiffRenderer {
+	return &collapsedRenderer{
+		inner: change,
+	}
+}
+
+type collapsedRenderer struct {
+	inner computed.Diff
+}
+
+func (renderer collapsedRenderer) RenderHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) string {
+	return fmt.Sprintf("(collapsed change)%s", forcesReplacement(diff.Replace, opts))
+}
+
+func (renderer collapsedRenderer) WarningsHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) []string {
+	return renderer.inner.WarningsHuman(indent, opts)
+}
+
+// CollapseAttributes returns a copy of the given resource diff in which any
+// updated top-level attributes with the given names are rendered using
+// Collapsed. Attributes that are being created, deleted or left unchanged are
+// still rendered in full, as are diffs that don't use the block renderer.
+func CollapseAttributes(diff computed.Diff, names []string) computed.Diff {
+	block, ok := diff.Renderer.(*blockRenderer)
+	if !ok || len(names) == 0 {
+		return diff
+	}
+
+	attributes := make(map[string]computed.Diff, len(block.attributes))
+	for key, attribute := range block.attributes {
+		attributes[key] = attribute
+	}
+	for _, name := range names {
+		if attribute, ok := attributes[name]; ok && attribute.Action == plans.Update {
+			attributes[name] = computed.NewDiff(Collapsed(attribute), attribute.Action, attribute.Replace)
+		}
+	}
+
+	diff.Renderer = Block(attributes, block.blocks)
+	return diff
+}
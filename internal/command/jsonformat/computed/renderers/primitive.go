@@ -135,12 +135,13 @@ func (renderer primitiveRenderer) renderStringDiff(diff computed.Diff, indent in
 		beforeString := evaluatePrimitiveString(renderer.before, opts)
 		afterString := evaluatePrimitiveString(renderer.after, opts)
 
-		if beforeString.Json != nil && afterString.Json != nil {
+		if beforeString.Json != nil && afterString.Json != nil && beforeString.IsYaml == afterString.IsYaml {
 			return renderer.renderStringDiffAsJson(diff, indent, opts, beforeString, afterString)
 		}
 
 		if beforeString.Json != nil || afterString.Json != nil {
-			// This means one of the strings is JSON and one isn't. We're going
+			// This means one of the strings is JSON or YAML and the other
+			// isn't, or is in the other format. We're going
 			// to be a little inefficient here, but we can just reuse another
 			// renderer for this so let's keep it simple.
 			return computed.NewDiff(
@@ -237,8 +238,15 @@ func (renderer primitiveRenderer) renderStringDiffAsJson(diff computed.Diff, ind
 		action = plans.NoOp
 	}
 
+	// We render YAML documents in the same way as JSON, but using the name
+	// of the function that would produce the equivalent string.
+	encodeFunc := "jsonencode"
+	if before.IsYaml || after.IsYaml {
+		encodeFunc = "yamlencode"
+	}
+
 	if strings.Contains(renderedJsonDiff, "\n") {
-		return fmt.Sprintf("jsonencode(%s\n%s%s%s%s\n%s%s)%s", whitespace, formatIndent(indent+1), writeDiffActionSymbol(action, opts), renderedJsonDiff, replace, formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts), nullSuffix(diff.Action, opts))
+		return fmt.Sprintf("%s(%s\n%s%s%s%s\n%s%s)%s", encodeFunc, whitespace, formatIndent(indent+1), writeDiffActionSymbol(action, opts), renderedJsonDiff, replace, formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts), nullSuffix(diff.Action, opts))
 	}
-	return fmt.Sprintf("jsonencode(%s)%s%s", renderedJsonDiff, whitespace, replace)
+	return fmt.Sprintf("%s(%s)%s%s", encodeFunc, renderedJsonDiff, whitespace, replace)
 }
@@ -287,6 +287,48 @@ jsonencode(
           - key_two = "value_two"
         }
     )
+`,
+		},
+		"primitive_yaml_string_create": {
+			diff: computed.Diff{
+				Renderer: Primitive(nil, "key_one: value_one\nkey_two: value_two\n", cty.String),
+				Action:   plans.Create,
+			},
+			expected: `
+yamlencode(
+        {
+          + key_one = "value_one"
+          + key_two = "value_two"
+        }
+    )
+`,
+		},
+		"primitive_yaml_string_update": {
+			diff: computed.Diff{
+				Renderer: Primitive("kind: Deployment\nspec:\n  replicas: 1\n", "kind: Deployment\nspec:\n  replicas: 3\n", cty.String),
+				Action:   plans.Update,
+			},
+			expected: `
+yamlencode(
+      ~ {
+          ~ spec = {
+              ~ replicas = 1 -> 3
+            }
+            # (1 unchanged attribute hidden)
+        }
+    )
+`,
+		},
+		"primitive_invalid_yaml_string_create": {
+			diff: computed.Diff{
+				Renderer: Primitive(nil, "hello: world\nnot yaml", cty.String),
+				Action:   plans.Create,
+			},
+			expected: `
+<<-EOT
+        hello: world
+        not yaml
+    EOT
 `,
 		},
 		"primitive_fake_json_string_update": {
@@ -11,6 +11,9 @@ import (
 	"fmt"
 	"strings"
 
+	ctyyaml "github.com/zclconf/go-cty-yaml"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
 	"github.com/opentofu/opentofu/internal/command/jsonformat/computed"
 )
 
@@ -18,6 +21,10 @@ type evaluatedString struct {
 	String string
 	Json   interface{}
 
+	// IsYaml is true if Json was decoded from a YAML document rather than
+	// from JSON.
+	IsYaml bool
+
 	IsMultiline bool
 	IsNull      bool
 }
@@ -45,6 +52,14 @@ func evaluatePrimitiveString(value interface{}, opts computed.RenderHumanOpts) e
 	}
 
 	if strings.Contains(str, "\n") {
+		if yv, ok := decodeYamlDocument(str); ok {
+			return evaluatedString{
+				String: str,
+				Json:   yv,
+				IsYaml: true,
+			}
+		}
+
 		return evaluatedString{
 			String:      strings.TrimSpace(str),
 			IsMultiline: true,
@@ -56,6 +71,41 @@ func evaluatePrimitiveString(value interface{}, opts computed.RenderHumanOpts) e
 	}
 }
 
+// decodeYamlDocument returns the structure of the given string if it is a
+// YAML document whose top-level value is a mapping or a sequence, in the same
+// form we'd get from decoding the equivalent JSON.
+//
+// We only try this for multiline strings, and don't accept documents that
+// are just a single scalar, because almost any text is a valid YAML scalar.
+func decodeYamlDocument(str string) (interface{}, bool) {
+	src := []byte(str)
+	ty, err := ctyyaml.Standard.ImpliedType(src)
+	if err != nil || !(ty.IsObjectType() || ty.IsTupleType()) {
+		return nil, false
+	}
+	if ty.HasDynamicTypes() {
+		// YAML nulls have no implied type, and the JSON encoding of
+		// values of unknown type wouldn't match the original document.
+		return nil, false
+	}
+	val, err := ctyyaml.Standard.Unmarshal(src, ty)
+	if err != nil {
+		return nil, false
+	}
+	buf, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		return nil, false
+	}
+
+	var jv interface{}
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jv); err != nil {
+		return nil, false
+	}
+	return jv, true
+}
+
 func (e evaluatedString) RenderSimple() string {
 	if e.IsNull {
 		return e.String
@@ -0,0 +1,99 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonformat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+)
+
+// costComment returns a short description of the estimated monthly cost of
+// a single resource change, or an empty string if there is no estimate.
+func costComment(cost *jsonplan.Cost) string {
+	if cost == nil {
+		return ""
+	}
+	before, after := cost.MonthlyBefore, cost.MonthlyAfter
+	switch {
+	case before == nil && after == nil:
+		return ""
+	case before == nil:
+		return formatCost(*after, cost.Currency)
+	case after == nil:
+		return fmt.Sprintf("%s -> none", formatCost(*before, cost.Currency))
+	case *before == *after:
+		return formatCost(*after, cost.Currency)
+	default:
+		return fmt.Sprintf("%s -> %s", formatCost(*before, cost.Currency), formatCost(*after, cost.Currency))
+	}
+}
+
+// renderCostSummary prints the total estimated monthly cost before and after
+// the given changes, for each currency used by the cost estimates. It prints
+// nothing if none of the changes have a cost estimate.
+func renderCostSummary(renderer Renderer, changes []diff) {
+	type totals struct {
+		before, after float64
+	}
+	byCurrency := make(map[string]*totals)
+	for _, change := range changes {
+		cost := change.change.Cost
+		if cost == nil || (cost.MonthlyBefore == nil && cost.MonthlyAfter == nil) {
+			continue
+		}
+		t, ok := byCurrency[cost.Currency]
+		if !ok {
+			t = &totals{}
+			byCurrency[cost.Currency] = t
+		}
+		if cost.MonthlyBefore != nil {
+			t.before += *cost.MonthlyBefore
+		}
+		if cost.MonthlyAfter != nil {
+			t.after += *cost.MonthlyAfter
+		}
+	}
+	if len(byCurrency) == 0 {
+		return
+	}
+
+	currencies := make([]string, 0, len(byCurrency))
+	for currency := range byCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	parts := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		t := byCurrency[currency]
+		parts = append(parts, fmt.Sprintf(
+			"%s%s (from %s to %s)",
+			costDeltaSign(t.after-t.before),
+			formatCost(t.after-t.before, currency),
+			formatCost(t.before, currency),
+			formatCost(t.after, currency),
+		))
+	}
+	renderer.Streams.Printf(
+		renderer.Colorize.Color("[bold]Estimated monthly cost change:[reset] %s.\n"),
+		strings.Join(parts, ", "),
+	)
+}
+
+func costDeltaSign(delta float64) string {
+	if delta > 0 {
+		return "+"
+	}
+	return ""
+}
+
+func formatCost(amount float64, currency string) string {
+	if currency == "" {
+		return fmt.Sprintf("%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonformat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/colorstring"
+
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+	"github.com/opentofu/opentofu/internal/command/jsonprovider"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/terminal"
+)
+
+func TestRenderHuman_Costs(t *testing.T) {
+	color := &colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}
+
+	ten, twelve, twentyFive := 10.0, 12.0, 25.0
+	plan := Plan{
+		PlanFormatVersion:     jsonplan.FormatVersion,
+		ProviderFormatVersion: jsonprovider.FormatVersion,
+		ProviderSchemas: map[string]*jsonprovider.Provider{
+			"test": {
+				ResourceSchemas: map[string]*jsonprovider.Schema{
+					"test_resource": {
+						Block: &jsonprovider.Block{
+							Attributes: map[string]*jsonprovider.Attribute{
+								"value": {
+									AttributeType: marshalJson(t, "string"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ResourceChanges: []jsonplan.ResourceChange{
+			{
+				Address:      "test_resource.a",
+				Mode:         "managed",
+				Type:         "test_resource",
+				Name:         "a",
+				ProviderName: "test",
+				Change: jsonplan.Change{
+					Actions: []string{"create"},
+					After:   marshalJson(t, map[string]interface{}{"value": "a"}),
+				},
+				Cost: &jsonplan.Cost{Currency: "USD", MonthlyAfter: &twentyFive},
+			},
+			{
+				Address:      "test_resource.b",
+				Mode:         "managed",
+				Type:         "test_resource",
+				Name:         "b",
+				ProviderName: "test",
+				Change: jsonplan.Change{
+					Actions: []string{"update"},
+					Before:  marshalJson(t, map[string]interface{}{"value": "small"}),
+					After:   marshalJson(t, map[string]interface{}{"value": "large"}),
+				},
+				Cost: &jsonplan.Cost{Currency: "USD", MonthlyBefore: &ten, MonthlyAfter: &twelve},
+			},
+			{
+				Address:      "test_resource.c",
+				Mode:         "managed",
+				Type:         "test_resource",
+				Name:         "c",
+				ProviderName: "test",
+				Change: jsonplan.Change{
+					Actions: []string{"delete"},
+					Before:  marshalJson(t, map[string]interface{}{"value": "c"}),
+				},
+				Cost: &jsonplan.Cost{Currency: "USD", MonthlyBefore: &ten},
+			},
+		},
+	}
+
+	streams, done := terminal.StreamsForTesting(t)
+	plan.renderHuman(Renderer{Colorize: color, Streams: streams}, plans.NormalMode)
+	got := done(t).Stdout()
+
+	for _, want := range []string{
+		"  # test_resource.a will be created\n  # (estimated monthly cost: 25.00 USD)\n",
+		"  # test_resource.b will be updated in-place\n  # (estimated monthly cost: 10.00 USD -> 12.00 USD)\n",
+		"  # test_resource.c will be destroyed\n  # (estimated monthly cost: 10.00 USD -> none)\n",
+		"Plan: 1 to add, 1 to change, 1 to destroy.\nEstimated monthly cost change: +17.00 USD (from 20.00 USD to 37.00 USD).\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output is missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestCostComment(t *testing.T) {
+	ten, twelve := 10.0, 12.0
+	tcs := map[string]struct {
+		cost *jsonplan.Cost
+		want string
+	}{
+		"nil": {
+			nil,
+			"",
+		},
+		"no estimates": {
+			&jsonplan.Cost{Currency: "USD"},
+			"",
+		},
+		"unchanged": {
+			&jsonplan.Cost{Currency: "USD", MonthlyBefore: &ten, MonthlyAfter: &ten},
+			"10.00 USD",
+		},
+		"no currency": {
+			&jsonplan.Cost{MonthlyBefore: &ten, MonthlyAfter: &twelve},
+			"10.00 -> 12.00",
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			if got := costComment(tc.cost); got != tc.want {
+				t.Errorf("wrong result\ngot:  %q\nwant: %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -7,10 +7,12 @@ package jsonformat
 
 import (
 	"github.com/opentofu/opentofu/internal/command/jsonformat/computed"
+	"github.com/opentofu/opentofu/internal/command/jsonformat/computed/renderers"
 	"github.com/opentofu/opentofu/internal/command/jsonformat/differ"
 	"github.com/opentofu/opentofu/internal/command/jsonformat/structured"
 	"github.com/opentofu/opentofu/internal/command/jsonformat/structured/attribute_path"
 	"github.com/opentofu/opentofu/internal/command/jsonplan"
+	"github.com/opentofu/opentofu/internal/command/jsonstate"
 	"github.com/opentofu/opentofu/internal/plans"
 )
 
@@ -72,6 +74,53 @@ func precomputeDiffs(plan Plan, mode plans.Mode) diffs {
 	return diffs
 }
 
+// collapse replaces the changes to the given attributes of each managed
+// resource type with a one-line marker, for attributes that are known to
+// produce noisy diffs.
+func (d diffs) collapse(attributes map[string][]string) {
+	if len(attributes) == 0 {
+		return
+	}
+	for _, changes := range [][]diff{d.drift, d.changes} {
+		for i, change := range changes {
+			if change.change.Mode != jsonstate.ManagedResourceMode {
+				continue
+			}
+			if names, ok := attributes[change.change.Type]; ok {
+				changes[i].diff = renderers.CollapseAttributes(change.diff, names)
+			}
+		}
+	}
+}
+
+// driftCauseNotes are the comments shown beside drifted attributes for each
+// of the drift causes in a refresh-only plan.
+var driftCauseNotes = map[string]string{
+	string(plans.DriftCauseProvider):      "provider-reported drift",
+	string(plans.DriftCauseSchemaUpgrade): "schema upgrade effect",
+	string(plans.DriftCauseNormalization): "value normalization",
+}
+
+// annotateDriftCauses adds a comment to each drifted top-level attribute
+// that says what the likely cause of the change was, for the drifted
+// resources that have drift causes.
+func (d diffs) annotateDriftCauses() {
+	for i, drift := range d.drift {
+		if len(drift.change.DriftCauses) == 0 {
+			continue
+		}
+		notes := make(map[string]string, len(drift.change.DriftCauses))
+		for name, cause := range drift.change.DriftCauses {
+			note, ok := driftCauseNotes[cause]
+			if !ok {
+				note = driftCauseNotes[string(plans.DriftCauseProvider)]
+			}
+			notes[name] = note
+		}
+		d.drift[i].diff = renderers.AnnotateAttributes(drift.diff, notes)
+	}
+}
+
 type diffs struct {
 	drift   []diff
 	changes []diff
@@ -0,0 +1,179 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/colorstring"
+
+	"github.com/opentofu/opentofu/internal/command/jsonformat/collections"
+	"github.com/opentofu/opentofu/internal/command/jsonformat/computed"
+	"github.com/opentofu/opentofu/internal/command/jsonformat/differ"
+	"github.com/opentofu/opentofu/internal/command/jsonformat/structured"
+	"github.com/opentofu/opentofu/internal/command/jsonformat/structured/attribute_path"
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+	"github.com/opentofu/opentofu/internal/command/jsonprovider"
+	"github.com/opentofu/opentofu/internal/command/jsonstate"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+// patchContextLines is the number of unchanged lines shown around each
+// change in a patch, matching the default for diff -u and git diff.
+const patchContextLines = 3
+
+// RenderPatchPlan prints the resource changes in the given plan as a unified
+// diff between the rendered body of each resource instance before and after
+// its change, suitable for display in code review tools.
+//
+// Drift and output changes are not included.
+func (renderer Renderer) RenderPatchPlan(plan Plan) {
+	// Patches are for consumption by other tools, so we never use color.
+	opts := computed.NewRenderHumanOpts(&colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}, renderer.ShowSensitive)
+	opts.HideDiffActionSymbols = true
+
+	for _, change := range plan.ResourceChanges {
+		action := jsonplan.UnmarshalActions(change.Change.Actions)
+		if action == plans.NoOp {
+			continue
+		}
+		if action == plans.Delete && change.Mode != jsonstate.ManagedResourceMode {
+			// We don't render deleted data sources in the human plan either.
+			continue
+		}
+
+		schema := plan.getSchema(change)
+		if schema == nil {
+			continue
+		}
+
+		var before, after []string
+		if patchValuePresent(change.Change.Before) {
+			before = renderPatchBody(change, jsonplan.Change{
+				After:          change.Change.Before,
+				AfterSensitive: change.Change.BeforeSensitive,
+			}, schema.Block, opts)
+		}
+		if patchValuePresent(change.Change.After) {
+			after = renderPatchBody(change, jsonplan.Change{
+				After:          change.Change.After,
+				AfterUnknown:   change.Change.AfterUnknown,
+				AfterSensitive: change.Change.AfterSensitive,
+			}, schema.Block, opts)
+		}
+
+		renderer.Streams.Print(unifiedPatch(patchPath(change), before, after))
+	}
+}
+
+func patchValuePresent(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null"))
+}
+
+func patchPath(change jsonplan.ResourceChange) string {
+	if len(change.Deposed) != 0 {
+		return fmt.Sprintf("%s (deposed object %s)", change.Address, change.Deposed)
+	}
+	return change.Address
+}
+
+// renderPatchBody renders the value in the "after" side of the given change
+// as a resource block, returning the lines of the result.
+func renderPatchBody(resource jsonplan.ResourceChange, value jsonplan.Change, block *jsonprovider.Block, opts computed.RenderHumanOpts) []string {
+	// We render the value as if it were being created, which shows all of
+	// the non-null attributes and nested blocks without any diff markers.
+	change := structured.FromJsonChange(value, attribute_path.AlwaysMatcher())
+	body := differ.ComputeDiffForBlock(change, block).RenderHuman(0, opts)
+	return strings.Split(fmt.Sprintf("%s %s", resourceChangeHeader(resource), body), "\n")
+}
+
+type patchLine struct {
+	op   byte // ' ', '-', or '+'
+	text string
+}
+
+// unifiedPatch returns a unified diff between the given lines, with the
+// given path in the header. A nil slice of lines represents a file that
+// doesn't exist on that side of the diff.
+func unifiedPatch(path string, before, after []string) string {
+	var lines []patchLine
+	collections.ProcessSlice(before, after, func(beforeIx, afterIx int) {
+		switch {
+		case beforeIx >= len(before):
+			lines = append(lines, patchLine{'+', after[afterIx]})
+		case afterIx >= len(after):
+			lines = append(lines, patchLine{'-', before[beforeIx]})
+		default:
+			lines = append(lines, patchLine{' ', before[beforeIx]})
+		}
+	}, func(string) bool { return false })
+
+	var buf strings.Builder
+	beforePath, afterPath := "a/"+path, "b/"+path
+	if before == nil {
+		beforePath = "/dev/null"
+	}
+	if after == nil {
+		afterPath = "/dev/null"
+	}
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", beforePath, afterPath)
+
+	// beforeLine and afterLine hold the line numbers, counting from zero, at
+	// which each entry of lines appears on each side of the diff.
+	beforeLine := make([]int, len(lines)+1)
+	afterLine := make([]int, len(lines)+1)
+	for i, line := range lines {
+		beforeLine[i+1], afterLine[i+1] = beforeLine[i], afterLine[i]
+		if line.op != '+' {
+			beforeLine[i+1]++
+		}
+		if line.op != '-' {
+			afterLine[i+1]++
+		}
+	}
+
+	for start := 0; start < len(lines); {
+		// Find the next change, and then extend the hunk until we reach a
+		// run of unchanged lines too long to be context for two changes.
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for next := first + 1; next < len(lines) && next <= last+2*patchContextLines+1; next++ {
+			if lines[next].op != ' ' {
+				last = next
+			}
+		}
+
+		from := max(first-patchContextLines, start)
+		to := min(last+patchContextLines+1, len(lines))
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n",
+			patchRange(beforeLine[from], beforeLine[to]-beforeLine[from]),
+			patchRange(afterLine[from], afterLine[to]-afterLine[from]),
+		)
+		for _, line := range lines[from:to] {
+			fmt.Fprintf(&buf, "%c%s\n", line.op, line.text)
+		}
+		start = to
+	}
+	return buf.String()
+}
+
+// patchRange formats a range of lines for a hunk header, given the zero-based
+// index of its first line and its length.
+func patchRange(start, count int) string {
+	if count == 0 {
+		// An empty range refers to the line before the insertion point.
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
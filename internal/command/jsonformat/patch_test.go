@@ -0,0 +1,93 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonformat
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnifiedPatch(t *testing.T) {
+	tcs := map[string]struct {
+		before, after []string
+		want          string
+	}{
+		"create": {
+			nil,
+			[]string{"a {", "    x = 1", "}"},
+			`--- /dev/null
++++ b/test.a
+@@ -0,0 +1,3 @@
++a {
++    x = 1
++}
+`,
+		},
+		"delete": {
+			[]string{"a {", "}"},
+			nil,
+			`--- a/test.a
++++ /dev/null
+@@ -1,2 +0,0 @@
+-a {
+-}
+`,
+		},
+		"separate hunks": {
+			[]string{"a {", "1", "2", "3", "4", "5", "6", "7", "8", "9", "}"},
+			[]string{"a {", "one", "2", "3", "4", "5", "6", "7", "8", "nine", "}"},
+			`--- a/test.a
++++ b/test.a
+@@ -1,5 +1,5 @@
+ a {
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,5 +7,5 @@
+ 6
+ 7
+ 8
+-9
++nine
+ }
+`,
+		},
+		"merged hunk": {
+			[]string{"1", "2", "3", "4", "5", "6", "7", "8"},
+			[]string{"one", "2", "3", "4", "5", "6", "7", "eight"},
+			`--- a/test.a
++++ b/test.a
+@@ -1,8 +1,8 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+ 6
+ 7
+-8
++eight
+`,
+		},
+		"no changes": {
+			[]string{"a {", "}"},
+			[]string{"a {", "}"},
+			`--- a/test.a
++++ b/test.a
+`,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			got := unifiedPatch("test.a", tc.before, tc.after)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
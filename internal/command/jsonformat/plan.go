@@ -59,11 +59,14 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 	}
 
 	diffs := precomputeDiffs(plan, mode)
+	diffs.collapse(renderer.CollapsedAttributes)
+	diffs.annotateDriftCauses()
 	haveRefreshChanges := renderHumanDiffDrift(renderer, diffs, mode)
 
 	willPrintResourceChanges := false
 	counts := make(map[plans.Action]int)
 	importingCount := 0
+	deposedCount := 0
 	var changes []diff
 	for _, diff := range diffs.changes {
 		action := jsonplan.UnmarshalActions(diff.change.Change.Actions)
@@ -81,6 +84,9 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 		if diff.Importing() {
 			importingCount++
 		}
+		if len(diff.change.Deposed) != 0 && action == plans.Delete {
+			deposedCount++
+		}
 
 		// Don't count move-only changes
 		if action != plans.NoOp {
@@ -101,7 +107,7 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 
 		if checkOpts(plans.Errored) {
 			if haveRefreshChanges {
-				renderer.Streams.Print(format.HorizontalRule(renderer.Colorize, renderer.Streams.Stdout.Columns()))
+				renderer.Streams.Print(renderer.horizontalRule())
 				renderer.Streams.Println()
 			}
 			renderer.Streams.Print(
@@ -123,7 +129,7 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 					renderer.Streams.Stdout.Columns()))
 			case plans.DestroyMode:
 				if haveRefreshChanges {
-					renderer.Streams.Print(format.HorizontalRule(renderer.Colorize, renderer.Streams.Stdout.Columns()))
+					renderer.Streams.Print(renderer.horizontalRule())
 					fmt.Fprintln(renderer.Streams.Stdout.File)
 				}
 				renderer.Streams.Print(renderer.Colorize.Color("\n[reset][bold][green]No changes.[reset][bold] No objects need to be destroyed.[reset]\n\n"))
@@ -132,7 +138,7 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 					renderer.Streams.Stdout.Columns()))
 			default:
 				if haveRefreshChanges {
-					renderer.Streams.Print(format.HorizontalRule(renderer.Colorize, renderer.Streams.Stdout.Columns()))
+					renderer.Streams.Print(renderer.horizontalRule())
 					renderer.Streams.Println("")
 				}
 				renderer.Streams.Print(
@@ -177,7 +183,7 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 		}
 	}
 	if haveRefreshChanges {
-		renderer.Streams.Print(format.HorizontalRule(renderer.Colorize, renderer.Streams.Stdout.Columns()))
+		renderer.Streams.Print(renderer.horizontalRule())
 		renderer.Streams.Println()
 	}
 
@@ -237,6 +243,14 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 				counts[plans.Update],
 				counts[plans.Delete]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete])
 		}
+		if deposedCount > 0 {
+			renderer.Streams.Println(format.WordWrap(
+				fmt.Sprintf("%d of the objects to destroy are deposed objects left over from partially-failed replacements. Run \"tofu state list -deposed\" to list them.", deposedCount),
+				renderer.Streams.Stdout.Columns(),
+			))
+		}
+
+		renderCostSummary(renderer, changes)
 	}
 
 	if len(outputs) > 0 {
@@ -519,6 +533,23 @@ func resourceChangeComment(resource jsonplan.ResourceChange, action plans.Action
 	if resource.Change.Importing != nil && (action == plans.CreateThenDelete || action == plans.DeleteThenCreate) {
 		buf.WriteString("  # [reset][yellow]Warning: this will destroy the imported resource[reset]\n")
 	}
+	if len(resource.Identity) > 0 {
+		// We show the identifiers of the existing remote object so that
+		// reviewers can correlate the change with the real object without
+		// digging through the full diff or the state.
+		var ids []string
+		for _, name := range jsonplan.IdentityAttributes {
+			if id, ok := resource.Identity[name]; ok {
+				ids = append(ids, fmt.Sprintf("%s = %q", name, id))
+			}
+		}
+		if len(ids) > 0 {
+			buf.WriteString(fmt.Sprintf("  # [reset](remote object: %s)\n", strings.Join(ids, ", ")))
+		}
+	}
+	if cost := costComment(resource.Cost); cost != "" {
+		buf.WriteString(fmt.Sprintf("  # [reset](estimated monthly cost: %s)\n", cost))
+	}
 
 	return buf.String()
 }
@@ -8,6 +8,7 @@ package jsonformat
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -429,6 +430,171 @@ Plan: 1 to import, 1 to add, 0 to change, 1 to destroy.
 	}
 }
 
+func TestRenderHuman_CollapsedAttributes(t *testing.T) {
+	color := &colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}
+
+	plan := Plan{
+		PlanFormatVersion:     jsonplan.FormatVersion,
+		ProviderFormatVersion: jsonprovider.FormatVersion,
+		ProviderSchemas: map[string]*jsonprovider.Provider{
+			"test": {
+				ResourceSchemas: map[string]*jsonprovider.Schema{
+					"test_policy": {
+						Block: &jsonprovider.Block{
+							Attributes: map[string]*jsonprovider.Attribute{
+								"name": {
+									AttributeType: marshalJson(t, "string"),
+								},
+								"policy": {
+									AttributeType: marshalJson(t, "string"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ResourceChanges: []jsonplan.ResourceChange{
+			{
+				Address:      "test_policy.a",
+				Mode:         "managed",
+				Type:         "test_policy",
+				Name:         "a",
+				ProviderName: "test",
+				Change: jsonplan.Change{
+					Actions: []string{"update"},
+					Before: marshalJson(t, map[string]interface{}{
+						"name":   "before",
+						"policy": `{"Statement":[{"Action":["b","a"]}]}`,
+					}),
+					After: marshalJson(t, map[string]interface{}{
+						"name":   "after",
+						"policy": `{"Statement":[{"Action":["a","b"]}]}`,
+					}),
+				},
+			},
+		},
+	}
+
+	tcs := map[string]struct {
+		collapsed map[string][]string
+		want      []string
+		notWant   []string
+	}{
+		"collapsed": {
+			collapsed: map[string][]string{"test_policy": {"policy"}},
+			want: []string{
+				`~ name   = "before" -> "after"`,
+				`~ policy = (collapsed change)`,
+			},
+			notWant: []string{"Statement"},
+		},
+		"other type": {
+			collapsed: map[string][]string{"test_other": {"policy"}},
+			want:      []string{"Statement"},
+			notWant:   []string{`(collapsed change)`},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			streams, done := terminal.StreamsForTesting(t)
+			renderer := Renderer{
+				Colorize:            color,
+				Streams:             streams,
+				CollapsedAttributes: tc.collapsed,
+			}
+			plan.renderHuman(renderer, plans.NormalMode)
+
+			got := done(t).Stdout()
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output is missing %q\n%s", want, got)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output unexpectedly contains %q\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHuman_DriftCauses(t *testing.T) {
+	color := &colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}
+
+	plan := Plan{
+		PlanFormatVersion:     jsonplan.FormatVersion,
+		ProviderFormatVersion: jsonprovider.FormatVersion,
+		ProviderSchemas: map[string]*jsonprovider.Provider{
+			"test": {
+				ResourceSchemas: map[string]*jsonprovider.Schema{
+					"test_policy": {
+						Block: &jsonprovider.Block{
+							Attributes: map[string]*jsonprovider.Attribute{
+								"name": {
+									AttributeType: marshalJson(t, "string"),
+								},
+								"policy": {
+									AttributeType: marshalJson(t, "string"),
+								},
+								"region": {
+									AttributeType: marshalJson(t, "string"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ResourceDrift: []jsonplan.ResourceChange{
+			{
+				Address:      "test_policy.a",
+				Mode:         "managed",
+				Type:         "test_policy",
+				Name:         "a",
+				ProviderName: "test",
+				Change: jsonplan.Change{
+					Actions: []string{"update"},
+					Before: marshalJson(t, map[string]interface{}{
+						"name":   "before",
+						"policy": `{"a": 1}`,
+						"region": nil,
+					}),
+					After: marshalJson(t, map[string]interface{}{
+						"name":   "after",
+						"policy": `{"a":1}`,
+						"region": "us-east-1",
+					}),
+				},
+				DriftCauses: map[string]string{
+					"name":   "provider",
+					"policy": "normalization",
+					"region": "schema_upgrade",
+				},
+			},
+		},
+	}
+
+	streams, done := terminal.StreamsForTesting(t)
+	renderer := Renderer{
+		Colorize: color,
+		Streams:  streams,
+	}
+	plan.renderHuman(renderer, plans.RefreshOnlyMode)
+
+	got := done(t).Stdout()
+	for _, want := range []string{
+		`~ name   = "before" -> "after" # provider-reported drift`,
+		`~ policy = jsonencode( # whitespace changes # value normalization`,
+		`+ region = "us-east-1" # schema upgrade effect`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output is missing %q\n%s", want, got)
+		}
+	}
+}
+
 func TestResourceChange_primitiveTypes(t *testing.T) {
 	testCases := map[string]testCase{
 		"creation": {
@@ -526,6 +692,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be destroyed
+  # (remote object: id = "i-02ae66f368e8518a9")
   - resource "test_instance" "example" {
       - id = "i-02ae66f368e8518a9" -> null
     }`,
@@ -546,6 +713,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example (deposed object byebye) will be destroyed
   # (left over from a partially-failed replacement of this instance)
+  # (remote object: id = "i-02ae66f368e8518a9")
   - resource "test_instance" "example" {
       - id = "i-02ae66f368e8518a9" -> null
     }`,
@@ -566,6 +734,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be destroyed
+  # (remote object: id = "i-02ae66f368e8518a9")
   - resource "test_instance" "example" {
       - id = "i-02ae66f368e8518a9" -> null
     }`,
@@ -584,6 +753,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be removed from the OpenTofu state but will not be destroyed
+  # (remote object: id = "i-02ae66f368e8518a9")
   . resource "test_instance" "example" {
     id = "i-02ae66f368e8518a9"
 }`,
@@ -604,6 +774,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example (deposed object byebye) will be removed from the OpenTofu state but will not be destroyed
   # (left over from a partially-failed replacement of this instance)
+  # (remote object: id = "i-02ae66f368e8518a9")
   . resource "test_instance" "example" {
     id = "i-02ae66f368e8518a9"
 }`,
@@ -627,6 +798,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami = "ami-BEFORE" -> "ami-AFTER"
         id  = "i-02ae66f368e8518a9"
@@ -654,6 +826,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
         id         = "i-02ae66f368e8518a9"
       ~ "saml:aud" = "https://example.com/saml" -> "https://saml.example.com"
@@ -682,6 +855,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 				cty.GetAttrStep{Name: "ami"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami = "ami-BEFORE" -> "ami-AFTER" # forces replacement
         id  = "i-02ae66f368e8518a9"
@@ -709,6 +883,7 @@ func TestResourceChange_primitiveTypes(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami = "ami-BEFORE" -> "ami-AFTER"
         id  = "i-02ae66f368e8518a9"
@@ -741,6 +916,7 @@ field`),
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ more_lines = <<-EOT
@@ -773,6 +949,7 @@ new line`),
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       + more_lines = <<-EOT
@@ -803,6 +980,7 @@ new line`),
 				cty.GetAttrStep{Name: "more_lines"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ more_lines = <<-EOT # forces replacement
@@ -874,6 +1052,7 @@ new line`),
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "blah")
   ~ resource "test_instance" "example" {
       ~ id       = "blah" -> (known after apply)
       ~ str      = "before" -> "after"
@@ -904,6 +1083,7 @@ new line`),
 				cty.GetAttrStep{Name: "ami"},
 			}),
 			ExpectedOutput: `  # test_instance.example is tainted, so it must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami = "ami-BEFORE" -> "ami-AFTER" # forces replacement
       ~ id  = "i-02ae66f368e8518a9" -> (known after apply)
@@ -1059,6 +1239,7 @@ new line`),
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
         id   = "i-02ae66f368e8518a9"
@@ -1133,6 +1314,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1163,6 +1345,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1193,6 +1376,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1223,6 +1407,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1253,6 +1438,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1290,6 +1476,7 @@ func TestResourceChange_JSON(t *testing.T) {
 				cty.GetAttrStep{Name: "json_field"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1320,6 +1507,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode( # whitespace changes
@@ -1353,6 +1541,7 @@ func TestResourceChange_JSON(t *testing.T) {
 				cty.GetAttrStep{Name: "json_field"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode( # whitespace changes force replacement
@@ -1403,6 +1592,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1433,6 +1623,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1463,6 +1654,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1496,6 +1688,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1527,6 +1720,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1560,6 +1754,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1596,6 +1791,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1631,6 +1827,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1666,6 +1863,7 @@ func TestResourceChange_JSON(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ json_field = jsonencode(
@@ -1749,6 +1947,7 @@ func TestResourceChange_listObject(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ accounts = [
             {
@@ -1800,6 +1999,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       + list_field = [
@@ -1832,6 +2032,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [
@@ -1875,6 +2076,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [
@@ -1919,6 +2121,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 				cty.GetAttrStep{Name: "list_field"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [ # forces replacement
@@ -1961,6 +2164,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [
@@ -2023,6 +2227,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [
@@ -2055,6 +2260,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       + list_field = []
@@ -2091,6 +2297,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [
@@ -2137,6 +2344,7 @@ func TestResourceChange_primitiveList(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id         = "i-02ae66f368e8518a9" -> (known after apply)
       ~ list_field = [
@@ -2187,6 +2395,7 @@ func TestResourceChange_primitiveTuple(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
         id          = "i-02ae66f368e8518a9"
       ~ tuple_field = [
@@ -2228,6 +2437,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       + set_field = [
@@ -2260,6 +2470,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [
@@ -2297,6 +2508,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [
@@ -2338,6 +2550,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 				cty.GetAttrStep{Name: "set_field"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [ # forces replacement
@@ -2375,6 +2588,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [
@@ -2434,6 +2648,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [
@@ -2465,6 +2680,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       + set_field = []
@@ -2496,6 +2712,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [
@@ -2533,6 +2750,7 @@ func TestResourceChange_primitiveSet(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ set_field = [
@@ -2574,6 +2792,7 @@ func TestResourceChange_map(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       + map_field = {
@@ -2608,6 +2827,7 @@ func TestResourceChange_map(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ map_field = {
@@ -2647,6 +2867,7 @@ func TestResourceChange_map(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ map_field = {
@@ -2689,6 +2910,7 @@ func TestResourceChange_map(t *testing.T) {
 				cty.GetAttrStep{Name: "map_field"},
 			}),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ map_field = { # forces replacement
@@ -2726,6 +2948,7 @@ func TestResourceChange_map(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ map_field = {
@@ -2790,6 +3013,7 @@ func TestResourceChange_map(t *testing.T) {
 			},
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ id        = "i-02ae66f368e8518a9" -> (known after apply)
       ~ map_field = {
@@ -2841,6 +3065,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -2879,6 +3104,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -2924,6 +3150,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -2984,6 +3211,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3049,6 +3277,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			),
 			Schema: testSchema(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3104,6 +3333,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			),
 			Schema: testSchema(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [ # forces replacement
@@ -3151,6 +3381,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3282,6 +3513,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3349,6 +3581,7 @@ func TestResourceChange_nestedList(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3453,6 +3686,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3505,6 +3739,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       # Warning: this attribute value will be marked as sensitive and will not
@@ -3555,6 +3790,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       # Warning: this attribute value will be marked as sensitive and will not
@@ -3609,6 +3845,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3672,6 +3909,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			),
 			Schema: testSchema(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3728,6 +3966,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3772,6 +4011,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       + disks = []
@@ -3814,6 +4054,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       + disks = [
@@ -3869,6 +4110,7 @@ func TestResourceChange_nestedSet(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = [
@@ -3966,6 +4208,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4018,6 +4261,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4080,6 +4324,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4147,6 +4392,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			),
 			Schema: testSchema(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4198,6 +4444,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4250,6 +4497,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4308,6 +4556,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = {
@@ -4363,6 +4612,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4413,6 +4663,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4467,6 +4718,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4521,6 +4773,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4580,6 +4833,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaMultipleBlocks(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4634,6 +4888,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaMultipleBlocks(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4692,6 +4947,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaMultipleBlocks(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4750,6 +5006,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaMultipleBlocks(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4822,6 +5079,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaMultipleBlocks(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4888,6 +5146,7 @@ func TestResourceChange_nestedMap(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaMultipleBlocks(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
         id    = "i-02ae66f368e8518a9"
@@ -4938,6 +5197,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
         id   = "i-02ae66f368e8518a9"
@@ -4974,6 +5234,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
       + disk = {
@@ -5023,6 +5284,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			),
 			Schema: testSchema(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
       ~ disk = {
@@ -5068,6 +5330,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			),
 			Schema: testSchema(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
       ~ disk = { # forces replacement
@@ -5109,6 +5372,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchema(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
       - disk = {
@@ -5185,6 +5449,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
       ~ disk = {
@@ -5226,6 +5491,7 @@ func TestResourceChange_nestedSingle(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaPlus(configschema.NestingSingle),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami  = "ami-BEFORE" -> "ami-AFTER"
       ~ disk = {
@@ -5297,6 +5563,7 @@ func TestResourceChange_nestedMapSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value)
@@ -5332,6 +5599,7 @@ func TestResourceChange_nestedMapSensitiveSchema(t *testing.T) {
 			),
 			Schema: testSchemaSensitive(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value) # forces replacement
@@ -5362,6 +5630,7 @@ func TestResourceChange_nestedMapSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       - disks = (sensitive value) -> null
@@ -5392,6 +5661,7 @@ func TestResourceChange_nestedMapSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingMap),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value)
@@ -5458,6 +5728,7 @@ func TestResourceChange_nestedListSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value)
@@ -5493,6 +5764,7 @@ func TestResourceChange_nestedListSensitiveSchema(t *testing.T) {
 			),
 			Schema: testSchemaSensitive(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value) # forces replacement
@@ -5523,6 +5795,7 @@ func TestResourceChange_nestedListSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       - disks = (sensitive value) -> null
@@ -5553,6 +5826,7 @@ func TestResourceChange_nestedListSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingList),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value)
@@ -5619,6 +5893,7 @@ func TestResourceChange_nestedSetSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value)
@@ -5654,6 +5929,7 @@ func TestResourceChange_nestedSetSensitiveSchema(t *testing.T) {
 			),
 			Schema: testSchemaSensitive(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value) # forces replacement
@@ -5684,6 +5960,7 @@ func TestResourceChange_nestedSetSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       - disks = (sensitive value) -> null
@@ -5714,6 +5991,7 @@ func TestResourceChange_nestedSetSensitiveSchema(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			Schema:          testSchemaSensitive(configschema.NestingSet),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami   = "ami-BEFORE" -> "ami-AFTER"
       ~ disks = (sensitive value)
@@ -6210,6 +6488,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				},
 			},
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       # Warning: this attribute value will no longer be marked as sensitive
       # after applying this change.
@@ -6334,6 +6613,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				},
 			},
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
         id         = "i-02ae66f368e8518a9"
       ~ list_field = [
@@ -6469,6 +6749,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				},
 			},
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       ~ ami        = (sensitive value)
         id         = "i-02ae66f368e8518a9"
@@ -6615,6 +6896,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				},
 			},
 			ExpectedOutput: `  # test_instance.example will be updated in-place
+  # (remote object: id = "i-02ae66f368e8518a9")
   ~ resource "test_instance" "example" {
       # Warning: this attribute value will no longer be marked as sensitive
       # after applying this change. The value is unchanged.
@@ -6738,6 +7020,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				},
 			},
 			ExpectedOutput: `  # test_instance.example will be destroyed
+  # (remote object: id = "i-02ae66f368e8518a9")
   - resource "test_instance" "example" {
       - ami        = (sensitive value) -> null
       - id         = "i-02ae66f368e8518a9" -> null
@@ -6837,6 +7120,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				},
 			},
 			ExpectedOutput: `  # test_instance.example will be removed from the OpenTofu state but will not be destroyed
+  # (remote object: id = "i-02ae66f368e8518a9")
   . resource "test_instance" "example" {
     ami        = (sensitive value)
     id         = "i-02ae66f368e8518a9"
@@ -6918,6 +7202,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				cty.GetAttrPath("nested_block_set"),
 			),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami = (sensitive value) # forces replacement
         id  = "i-02ae66f368e8518a9"
@@ -6953,6 +7238,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				cty.GetAttrPath("ami"),
 			),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ ami = (sensitive value) # forces replacement
         id  = "i-02ae66f368e8518a9"
@@ -6994,6 +7280,7 @@ func TestResourceChange_sensitiveVariable(t *testing.T) {
 				cty.GetAttrPath("password"),
 			),
 			ExpectedOutput: `  # test_instance.example must be replaced
+  # (remote object: id = "i-02ae66f368e8518a9")
 -/+ resource "test_instance" "example" {
       ~ conn_info = { # forces replacement
           ~ password = (sensitive value)
@@ -7038,6 +7325,7 @@ func TestResourceChange_moved(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be updated in-place
   # (moved from test_instance.previous)
+  # (remote object: id = "12345")
   ~ resource "test_instance" "example" {
       ~ bar = "baz" -> "boop"
         id  = "12345"
@@ -7094,6 +7382,7 @@ func TestResourceChange_moved(t *testing.T) {
 			RequiredReplace: cty.NewPathSet(),
 			ExpectedOutput: `  # test_instance.example will be removed from the OpenTofu state but will not be destroyed
   # (moved from test_instance.previous)
+  # (remote object: id = "12345")
   . resource "test_instance" "example" {
     id = "12345"
 }`,
@@ -83,6 +83,15 @@ type Renderer struct {
 
 	RunningInAutomation bool
 	ShowSensitive       bool
+
+	// CollapsedAttributes lists, for each managed resource type, the names
+	// of top-level attributes whose in-place changes are rendered as a
+	// one-line marker instead of in full.
+	CollapsedAttributes map[string][]string
+
+	// NoUnicode replaces the box-drawing characters in horizontal rules and
+	// diagnostics with ASCII characters.
+	NoUnicode bool
 }
 
 func (renderer Renderer) RenderHumanPlan(plan Plan, mode plans.Mode, opts ...plans.Quality) {
@@ -115,6 +124,21 @@ func (renderer Renderer) RenderHumanState(state State) {
 	state.renderHumanStateOutputs(renderer, opts)
 }
 
+// horizontalRule returns a horizontal rule that fills the width of the
+// renderer's standard output stream.
+func (renderer Renderer) horizontalRule() string {
+	return renderer.asciiOnly(format.HorizontalRule(renderer.Colorize, renderer.Streams.Stdout.Columns()))
+}
+
+// asciiOnly returns the given output with its box-drawing characters
+// replaced by ASCII characters if NoUnicode is set, or unchanged otherwise.
+func (renderer Renderer) asciiOnly(s string) string {
+	if !renderer.NoUnicode {
+		return s
+	}
+	return format.ASCIIOnly(s)
+}
+
 func (renderer Renderer) RenderLog(log *JSONLog) error {
 	switch log.Type {
 	case LogRefreshComplete,
@@ -132,7 +156,7 @@ func (renderer Renderer) RenderLog(log *JSONLog) error {
 
 	case LogDiagnostic:
 		diag := format.DiagnosticFromJSON(log.Diagnostic, renderer.Colorize, 78)
-		renderer.Streams.Print(diag)
+		renderer.Streams.Print(renderer.asciiOnly(diag))
 
 	case LogOutputs:
 		if len(log.Outputs) > 0 {
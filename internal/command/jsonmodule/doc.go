@@ -0,0 +1,8 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jsonmodule implements methods for outputting a JSON description of
+// the interface of a single module: its input variables, outputs, provider
+// requirements, resources and module calls, as loaded from its source files
+// without installing anything.
+package jsonmodule
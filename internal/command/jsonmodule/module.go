@@ -0,0 +1,227 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonmodule
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+// FormatVersion represents the version of the json format and will be
+// incremented for any change to this format that requires changes to a
+// consuming parser.
+const FormatVersion = "1.0"
+
+// module is the top-level object returned when describing a module.
+type module struct {
+	FormatVersion     string                       `json:"format_version"`
+	RequiredCore      []string                     `json:"required_core,omitempty"`
+	Variables         map[string]*variable         `json:"variables"`
+	Outputs           map[string]*output           `json:"outputs"`
+	RequiredProviders map[string]*requiredProvider `json:"required_providers"`
+	ManagedResources  []*resource                  `json:"managed_resources"`
+	DataResources     []*resource                  `json:"data_resources"`
+	ModuleCalls       map[string]*moduleCall       `json:"module_calls"`
+}
+
+type variable struct {
+	// Type is the type constraint of the variable, in the JSON type
+	// representation of cty, including any optional object attributes.
+	Type        json.RawMessage `json:"type"`
+	Description string          `json:"description,omitempty"`
+
+	// Default is the default value of the variable. It's omitted if the
+	// variable is required.
+	Default     json.RawMessage `json:"default,omitempty"`
+	Required    bool            `json:"required"`
+	Sensitive   bool            `json:"sensitive"`
+	Nullable    bool            `json:"nullable"`
+	Validations []*checkRule    `json:"validations,omitempty"`
+	Pos         pos             `json:"pos"`
+}
+
+// checkRule describes a validation rule, with its expressions as they are
+// written in the source code.
+type checkRule struct {
+	Condition    string `json:"condition"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type output struct {
+	Description string `json:"description,omitempty"`
+	Sensitive   bool   `json:"sensitive"`
+	Pos         pos    `json:"pos"`
+}
+
+type requiredProvider struct {
+	Source               string   `json:"source"`
+	VersionConstraints   []string `json:"version_constraints,omitempty"`
+	ConfigurationAliases []string `json:"configuration_aliases,omitempty"`
+}
+
+type resource struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+
+	// Provider is the fully-qualified address of the provider of the
+	// resource type, and ProviderConfig is the address of the provider
+	// configuration it uses within the module.
+	Provider       string `json:"provider"`
+	ProviderConfig string `json:"provider_config"`
+	Pos            pos    `json:"pos"`
+}
+
+type moduleCall struct {
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+	Pos     pos    `json:"pos"`
+}
+
+type pos struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+}
+
+// Marshal returns the JSON description of the given module. The sources are
+// used to include the source code of expressions, such as the conditions of
+// variable validation rules, and may be nil.
+func Marshal(mod *configs.Module, sources map[string]*hcl.File) ([]byte, error) {
+	ret := &module{
+		FormatVersion:     FormatVersion,
+		Variables:         make(map[string]*variable, len(mod.Variables)),
+		Outputs:           make(map[string]*output, len(mod.Outputs)),
+		RequiredProviders: make(map[string]*requiredProvider),
+		ManagedResources:  make([]*resource, 0, len(mod.ManagedResources)),
+		DataResources:     make([]*resource, 0, len(mod.DataResources)),
+		ModuleCalls:       make(map[string]*moduleCall, len(mod.ModuleCalls)),
+	}
+
+	for _, vc := range mod.CoreVersionConstraints {
+		ret.RequiredCore = append(ret.RequiredCore, vc.Required.String())
+	}
+
+	for name, v := range mod.Variables {
+		mv, err := marshalVariable(v, sources)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", name, err)
+		}
+		ret.Variables[name] = mv
+	}
+
+	for name, o := range mod.Outputs {
+		ret.Outputs[name] = &output{
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+			Pos:         marshalPos(o.DeclRange),
+		}
+	}
+
+	if mod.ProviderRequirements != nil {
+		for name, req := range mod.ProviderRequirements.RequiredProviders {
+			rp := &requiredProvider{
+				Source: req.Type.String(),
+			}
+			if len(req.Requirement.Required) != 0 {
+				rp.VersionConstraints = append(rp.VersionConstraints, req.Requirement.Required.String())
+			}
+			for _, alias := range req.Aliases {
+				rp.ConfigurationAliases = append(rp.ConfigurationAliases, alias.StringCompact())
+			}
+			ret.RequiredProviders[name] = rp
+		}
+	}
+
+	for _, r := range mod.ManagedResources {
+		ret.ManagedResources = append(ret.ManagedResources, marshalResource(r))
+	}
+	sort.Slice(ret.ManagedResources, func(i, j int) bool {
+		return ret.ManagedResources[i].Address < ret.ManagedResources[j].Address
+	})
+	for _, r := range mod.DataResources {
+		ret.DataResources = append(ret.DataResources, marshalResource(r))
+	}
+	sort.Slice(ret.DataResources, func(i, j int) bool {
+		return ret.DataResources[i].Address < ret.DataResources[j].Address
+	})
+
+	for name, mc := range mod.ModuleCalls {
+		call := &moduleCall{
+			Source: mc.SourceAddrRaw,
+			Pos:    marshalPos(mc.DeclRange),
+		}
+		if len(mc.Version.Required) != 0 {
+			call.Version = mc.Version.Required.String()
+		}
+		ret.ModuleCalls[name] = call
+	}
+
+	return json.Marshal(ret)
+}
+
+func marshalVariable(v *configs.Variable, sources map[string]*hcl.File) (*variable, error) {
+	ty, err := v.ConstraintType.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	ret := &variable{
+		Type:        ty,
+		Description: v.Description,
+		Required:    v.Required(),
+		Sensitive:   v.Sensitive,
+		Nullable:    v.Nullable,
+		Pos:         marshalPos(v.DeclRange),
+	}
+	if !v.Required() {
+		ret.Default, err = ctyjson.Marshal(v.Default, v.Default.Type())
+		if err != nil {
+			return nil, fmt.Errorf("default value: %w", err)
+		}
+	}
+	for _, rule := range v.Validations {
+		ret.Validations = append(ret.Validations, &checkRule{
+			Condition:    expressionSource(rule.Condition, sources),
+			ErrorMessage: expressionSource(rule.ErrorMessage, sources),
+		})
+	}
+	return ret, nil
+}
+
+func marshalResource(r *configs.Resource) *resource {
+	return &resource{
+		Address:        r.Addr().String(),
+		Type:           r.Type,
+		Name:           r.Name,
+		Provider:       r.Provider.String(),
+		ProviderConfig: r.ProviderConfigAddr().StringCompact(),
+		Pos:            marshalPos(r.DeclRange),
+	}
+}
+
+func marshalPos(rng hcl.Range) pos {
+	return pos{
+		Filename: rng.Filename,
+		Line:     rng.Start.Line,
+	}
+}
+
+// expressionSource returns the source code of the given expression, or an
+// empty string if the source isn't available.
+func expressionSource(expr hcl.Expression, sources map[string]*hcl.File) string {
+	if expr == nil {
+		return ""
+	}
+	rng := expr.Range()
+	file, ok := sources[rng.Filename]
+	if !ok || file == nil {
+		return ""
+	}
+	return string(rng.SliceBytes(file.Bytes))
+}
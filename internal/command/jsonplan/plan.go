@@ -279,6 +279,11 @@ func MarshalForLog(
 		return nil, fmt.Errorf("error in marshaling output changes: %w", err)
 	}
 
+	// The changes don't record which instance of a provider configuration
+	// using for_each each resource instance uses, so we take it from the
+	// prior state for the instances that already exist.
+	output.marshalProviderInstances(p.PriorState)
+
 	// output.Checks
 	if p.Checks != nil && p.Checks.ConfigResults.Len() > 0 {
 		output.Checks = jsonchecks.MarshalCheckStates(p.Checks)
@@ -316,6 +321,57 @@ func Marshal(
 	return json.Marshal(output)
 }
 
+// marshalProviderInstances adds the key of the provider configuration
+// instance from the given prior state to the provider configuration addresses
+// of the planned values, drift and changes of the resource instances that
+// exist in that state.
+func (p *Plan) marshalProviderInstances(priorState *states.State) {
+	if priorState == nil {
+		return
+	}
+
+	// The provider configuration addresses of the instances that use an
+	// instance of a provider configuration, by the instance address and the
+	// address of the provider configuration itself.
+	instances := make(map[[2]string]string)
+	for _, ms := range priorState.Modules {
+		for _, rs := range ms.Resources {
+			for key := range rs.Instances {
+				providerConfig, providerKey := rs.InstanceProvider(key)
+				if providerKey == addrs.NoKey {
+					continue
+				}
+				instances[[2]string{rs.Addr.Instance(key).String(), providerConfig.String()}] = providerConfig.InstanceString(providerKey)
+			}
+		}
+	}
+	if len(instances) == 0 {
+		return
+	}
+
+	lookup := func(addr, providerConfig string) string {
+		if ret, ok := instances[[2]string{addr, providerConfig}]; ok {
+			return ret
+		}
+		return providerConfig
+	}
+	for _, changes := range [][]ResourceChange{p.ResourceChanges, p.ResourceDrift} {
+		for i := range changes {
+			changes[i].ProviderConfig = lookup(changes[i].Address, changes[i].ProviderConfig)
+		}
+	}
+	var walk func(m *Module)
+	walk = func(m *Module) {
+		for i := range m.Resources {
+			m.Resources[i].ProviderConfig = lookup(m.Resources[i].Address, m.Resources[i].ProviderConfig)
+		}
+		for i := range m.ChildModules {
+			walk(&m.ChildModules[i])
+		}
+	}
+	walk(&p.PlannedValues.RootModule)
+}
+
 func (p *Plan) marshalPlanVariables(vars map[string]plans.DynamicValue, decls map[string]*configs.Variable) error {
 	p.Variables = make(Variables, len(vars))
 
@@ -370,6 +426,43 @@ func (p *Plan) marshalPlanVariables(vars map[string]plans.DynamicValue, decls ma
 	return nil
 }
 
+// marshalIdentity returns the values of the identity attributes of the given
+// object, omitting any that are sensitive according to the given value from
+// jsonstate.SensitiveAsBoolWithPathValueMarks, or that are not known
+// non-empty strings.
+func marshalIdentity(obj, sensitive cty.Value) map[string]string {
+	if obj.IsNull() || !obj.IsKnown() || !obj.Type().IsObjectType() {
+		return nil
+	}
+	if !sensitive.Type().IsObjectType() {
+		// If the sensitive value isn't an object then either nothing is
+		// sensitive or the whole object is.
+		if sensitive.Type() == cty.Bool && sensitive.True() {
+			return nil
+		}
+		sensitive = cty.EmptyObjectVal
+	}
+
+	var ret map[string]string
+	for _, name := range IdentityAttributes {
+		if !obj.Type().HasAttribute(name) || obj.Type().AttributeType(name) != cty.String {
+			continue
+		}
+		if sensitive.Type().HasAttribute(name) {
+			continue
+		}
+		v := obj.GetAttr(name)
+		if v.IsNull() || !v.IsKnown() || v.AsString() == "" {
+			continue
+		}
+		if ret == nil {
+			ret = make(map[string]string)
+		}
+		ret[name] = v.AsString()
+	}
+	return ret
+}
+
 // MarshalResourceChanges converts the provided internal representation of
 // ResourceInstanceChangeSrc objects into the public structured JSON changes.
 //
@@ -440,6 +533,10 @@ func MarshalResourceChanges(resources []*plans.ResourceInstanceChangeSrc, schema
 			if err != nil {
 				return nil, err
 			}
+			switch rc.Action {
+			case plans.Update, plans.CreateThenDelete, plans.DeleteThenCreate, plans.Delete, plans.Forget:
+				r.Identity = marshalIdentity(changeV.Before, bs)
+			}
 		}
 		if changeV.After != cty.NilVal {
 			if changeV.After.IsWhollyKnown() {
@@ -521,6 +618,7 @@ func MarshalResourceChanges(resources []*plans.ResourceInstanceChangeSrc, schema
 		r.Name = addr.Resource.Resource.Name
 		r.Type = addr.Resource.Resource.Type
 		r.ProviderName = rc.ProviderAddr.Provider.String()
+		r.ProviderConfig = rc.ProviderAddr.String()
 
 		switch rc.ActionReason {
 		case plans.ResourceInstanceChangeNoReason:
@@ -555,6 +653,13 @@ func MarshalResourceChanges(resources []*plans.ResourceInstanceChangeSrc, schema
 			return nil, fmt.Errorf("resource %s has an unsupported action reason %s", r.Address, rc.ActionReason)
 		}
 
+		if len(rc.DriftCauses) != 0 {
+			r.DriftCauses = make(map[string]string, len(rc.DriftCauses))
+			for name, cause := range rc.DriftCauses {
+				r.DriftCauses[name] = string(cause)
+			}
+		}
+
 		ret = append(ret, r)
 
 	}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
 )
 
 func TestOmitUnknowns(t *testing.T) {
@@ -288,6 +289,75 @@ func TestUnknownAsBool(t *testing.T) {
 	}
 }
 
+func TestMarshalIdentity(t *testing.T) {
+	tests := map[string]struct {
+		Obj       cty.Value
+		Sensitive cty.Value
+		Want      map[string]string
+	}{
+		"null": {
+			cty.NullVal(cty.Object(map[string]cty.Type{"id": cty.String})),
+			cty.False,
+			nil,
+		},
+		"id and arn": {
+			cty.ObjectVal(map[string]cty.Value{
+				"id":   cty.StringVal("i-abc123"),
+				"arn":  cty.StringVal("arn:aws:ec2:us-east-1:123456789012:instance/i-abc123"),
+				"name": cty.StringVal("web"),
+			}),
+			cty.EmptyObjectVal,
+			map[string]string{
+				"id":  "i-abc123",
+				"arn": "arn:aws:ec2:us-east-1:123456789012:instance/i-abc123",
+			},
+		},
+		"sensitive id": {
+			cty.ObjectVal(map[string]cty.Value{
+				"id":  cty.StringVal("secret"),
+				"arn": cty.StringVal("arn:example"),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"id": cty.True,
+			}),
+			map[string]string{
+				"arn": "arn:example",
+			},
+		},
+		"entirely sensitive": {
+			cty.ObjectVal(map[string]cty.Value{
+				"id": cty.StringVal("secret"),
+			}),
+			cty.True,
+			nil,
+		},
+		"unknown, empty and non-string": {
+			cty.ObjectVal(map[string]cty.Value{
+				"id":  cty.UnknownVal(cty.String),
+				"arn": cty.StringVal(""),
+			}),
+			cty.EmptyObjectVal,
+			nil,
+		},
+		"number id": {
+			cty.ObjectVal(map[string]cty.Value{
+				"id": cty.NumberIntVal(1),
+			}),
+			cty.EmptyObjectVal,
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := marshalIdentity(test.Obj, test.Sensitive)
+			if diff := cmp.Diff(test.Want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestEncodePaths(t *testing.T) {
 	tests := map[string]struct {
 		Input cty.PathSet
@@ -514,3 +584,54 @@ func BenchmarkUnknownAsBool_9(b *testing.B) {
 		unknownAsBool(value)
 	}
 }
+
+func TestMarshalProviderInstances(t *testing.T) {
+	provider := addrs.AbsProviderConfig{
+		Module:   addrs.RootModule,
+		Provider: addrs.NewDefaultProvider("test"),
+		Alias:    "regional",
+	}
+	existing := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_thing",
+		Name: "existing",
+	}.Instance(addrs.StringKey("east")).Absolute(addrs.RootModuleInstance)
+	priorState := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			existing,
+			&states.ResourceInstanceObjectSrc{
+				Status:    states.ObjectReady,
+				AttrsJSON: []byte(`{}`),
+			},
+			provider,
+			addrs.StringKey("us-east-1"),
+		)
+	})
+
+	p := &Plan{
+		PlannedValues: StateValues{
+			RootModule: Module{
+				Resources: []Resource{
+					{Address: existing.String(), ProviderConfig: provider.String()},
+					{Address: `test_thing.new["west"]`, ProviderConfig: provider.String()},
+				},
+			},
+		},
+		ResourceChanges: []ResourceChange{
+			{Address: existing.String(), ProviderConfig: provider.String()},
+		},
+	}
+	p.marshalProviderInstances(priorState)
+
+	want := `provider["registry.opentofu.org/hashicorp/test"].regional["us-east-1"]`
+	if got := p.PlannedValues.RootModule.Resources[0].ProviderConfig; got != want {
+		t.Errorf("wrong provider config for existing instance\ngot:  %s\nwant: %s", got, want)
+	}
+	if got := p.ResourceChanges[0].ProviderConfig; got != want {
+		t.Errorf("wrong provider config for change\ngot:  %s\nwant: %s", got, want)
+	}
+	// The provider instance for a new resource instance isn't known.
+	if got, want := p.PlannedValues.RootModule.Resources[1].ProviderConfig, provider.String(); got != want {
+		t.Errorf("wrong provider config for new instance\ngot:  %s\nwant: %s", got, want)
+	}
+}
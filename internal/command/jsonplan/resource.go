@@ -31,6 +31,12 @@ type Resource struct {
 	// offering "google_compute_instance".
 	ProviderName string `json:"provider_name,omitempty"`
 
+	// ProviderConfig is the address of the provider configuration for this
+	// resource instance, including the alias. It includes the key of the
+	// provider configuration instance for a provider configuration using
+	// for_each only if the resource instance exists in the prior state.
+	ProviderConfig string `json:"provider_config,omitempty"`
+
 	// SchemaVersion indicates which version of the resource type schema the
 	// "values" property conforms to.
 	SchemaVersion uint64 `json:"schema_version"`
@@ -77,6 +83,10 @@ type ResourceChange struct {
 	Index        json.RawMessage `json:"index,omitempty"`
 	ProviderName string          `json:"provider_name,omitempty"`
 
+	// ProviderConfig is the address of the provider configuration for this
+	// resource instance, as described for Resource.ProviderConfig.
+	ProviderConfig string `json:"provider_config,omitempty"`
+
 	// "deposed", if set, indicates that this action applies to a "deposed"
 	// object of the given instance rather than to its "current" object. Omitted
 	// for changes to the current object.
@@ -94,4 +104,41 @@ type ResourceChange struct {
 	// information should be resilient to encountering unrecognized values
 	// and treat them as an unspecified reason.
 	ActionReason string `json:"action_reason,omitempty"`
+
+	// DriftCauses is a keyword for the likely cause of the change to each
+	// top-level attribute or nested block type of a drifted resource
+	// instance, keyed by name. It is present only in the resource_drift of
+	// refresh-only plans, and like ActionReason it is only for display
+	// purposes, so consumers should treat unrecognized values as "provider".
+	DriftCauses map[string]string `json:"drift_causes,omitempty"`
+
+	// Identity contains the values of attributes that identify the existing
+	// remote object, such as its id, to help correlate this change with the
+	// object it affects. It is omitted if the change doesn't affect an
+	// existing object, or if the object has no non-sensitive identifying
+	// attributes.
+	Identity map[string]string `json:"identity,omitempty"`
+
+	// Cost is an estimate of the recurring cost of the affected remote
+	// object, as reported by an external cost estimator. It is omitted
+	// unless a cost estimator is configured and returned an estimate for
+	// this change.
+	Cost *Cost `json:"cost,omitempty"`
 }
+
+// Cost describes the estimated monthly cost of a remote object before and
+// after a change.
+//
+// Either of MonthlyBefore and MonthlyAfter may be nil if there is no object
+// on that side of the change, or if the estimator could not estimate it.
+type Cost struct {
+	Currency      string   `json:"currency"`
+	MonthlyBefore *float64 `json:"monthly_before,omitempty"`
+	MonthlyAfter  *float64 `json:"monthly_after,omitempty"`
+}
+
+// IdentityAttributes are the names of the top-level attributes that, by
+// widespread provider convention, hold stable identifiers for a remote
+// object. They are included in ResourceChange.Identity in this order of
+// preference.
+var IdentityAttributes = []string{"id", "arn"}
@@ -193,11 +193,12 @@ func marshalPlanResources(changeMap map[string]*plans.ResourceInstanceChangeSrc,
 		}
 
 		resource := Resource{
-			Address:      r.Addr.String(),
-			Type:         r.Addr.Resource.Resource.Type,
-			Name:         r.Addr.Resource.Resource.Name,
-			ProviderName: r.ProviderAddr.Provider.String(),
-			Index:        r.Addr.Resource.Key,
+			Address:        r.Addr.String(),
+			Type:           r.Addr.Resource.Resource.Type,
+			Name:           r.Addr.Resource.Resource.Name,
+			ProviderName:   r.ProviderAddr.Provider.String(),
+			ProviderConfig: r.ProviderAddr.String(),
+			Index:          r.Addr.Resource.Key,
 		}
 
 		switch r.Addr.Resource.Resource.Mode {
@@ -208,6 +208,7 @@ func TestMarshalPlanResources(t *testing.T) {
 				Name:            "example",
 				Index:           addrs.InstanceKey(nil),
 				ProviderName:    "registry.opentofu.org/hashicorp/test",
+				ProviderConfig:  `provider["registry.opentofu.org/hashicorp/test"]`,
 				SchemaVersion:   1,
 				AttributeValues: AttributeValues{},
 				SensitiveValues: json.RawMessage("{}"),
@@ -245,13 +246,14 @@ func TestMarshalPlanResources(t *testing.T) {
 				"foozles": cty.StringVal("bat"),
 			}),
 			Want: []Resource{{
-				Address:       "test_thing.example",
-				Mode:          "managed",
-				Type:          "test_thing",
-				Name:          "example",
-				Index:         addrs.InstanceKey(nil),
-				ProviderName:  "registry.opentofu.org/hashicorp/test",
-				SchemaVersion: 1,
+				Address:        "test_thing.example",
+				Mode:           "managed",
+				Type:           "test_thing",
+				Name:           "example",
+				Index:          addrs.InstanceKey(nil),
+				ProviderName:   "registry.opentofu.org/hashicorp/test",
+				ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
+				SchemaVersion:  1,
 				AttributeValues: AttributeValues{
 					"woozles": json.RawMessage(`"baz"`),
 					"foozles": json.RawMessage(`"bat"`),
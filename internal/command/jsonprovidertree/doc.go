@@ -0,0 +1,7 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jsonprovidertree implements methods for outputting a JSON
+// description of the provider requirements of each module in a configuration,
+// and of how provider configurations are passed between those modules.
+package jsonprovidertree
@@ -0,0 +1,245 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonprovidertree
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/registry/response"
+)
+
+// FormatVersion represents the version of the json format and will be
+// incremented for any change to this format that requires changes to a
+// consuming parser.
+const FormatVersion = "1.0"
+
+// The ways in which a module can get a provider configuration, used as the
+// values of providerConfig.Source.
+const (
+	// sourceDeclared means the module has its own provider block.
+	sourceDeclared = "declared"
+
+	// sourceImplied means the root module has no provider block, so
+	// OpenTofu uses an empty configuration.
+	sourceImplied = "implied"
+
+	// sourcePassed means the configuration is passed explicitly in the
+	// providers argument of the module call.
+	sourcePassed = "passed"
+
+	// sourceInherited means the module implicitly inherits the default
+	// configuration of its parent.
+	sourceInherited = "inherited"
+
+	// sourceMissing means no configuration is available, which is an error
+	// at runtime.
+	sourceMissing = "missing"
+)
+
+type providerTree struct {
+	FormatVersion  string   `json:"format_version"`
+	RootModule     *module  `json:"root_module"`
+	StateProviders []string `json:"state_providers,omitempty"`
+
+	// Deprecations maps the fully-qualified address of each provider that
+	// its registry announces as deprecated to the details of the
+	// deprecation.
+	Deprecations map[string]*deprecation `json:"deprecations,omitempty"`
+}
+
+type deprecation struct {
+	Reason    string `json:"reason,omitempty"`
+	Link      string `json:"link,omitempty"`
+	EndOfLife string `json:"end_of_life,omitempty"`
+
+	// Message is the same description of the deprecation that tofu init
+	// shows as a warning.
+	Message string `json:"message"`
+}
+
+type module struct {
+	// RequiredProviders maps the fully-qualified address of each provider
+	// the module requires to its version constraints, if any.
+	RequiredProviders map[string]string  `json:"required_providers"`
+	ProviderConfigs   []*providerConfig  `json:"provider_configs"`
+	ModuleCalls       map[string]*module `json:"module_calls,omitempty"`
+}
+
+// providerConfig describes a provider configuration that a module uses, by
+// its local name within the module, and where it comes from.
+type providerConfig struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Source   string `json:"source"`
+
+	// Configuration is the absolute address of the provider block that
+	// ultimately supplies the configuration. It's omitted if the
+	// configuration is missing.
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// Marshal returns the JSON description of the provider requirements and the
+// provider configurations of every module in the given configuration. The
+// requirements must be the result of calling ProviderRequirementsByModule on
+// the same configuration. The deprecations, if any, are those of the providers
+// in either set of requirements.
+func Marshal(config *configs.Config, reqs *configs.ModuleRequirements, stateReqs getproviders.Requirements, deprecations map[addrs.Provider]*response.Deprecation) ([]byte, error) {
+	ret := &providerTree{
+		FormatVersion: FormatVersion,
+		RootModule:    marshalModule(config, reqs),
+	}
+	for provider := range stateReqs {
+		ret.StateProviders = append(ret.StateProviders, provider.String())
+	}
+	sort.Strings(ret.StateProviders)
+	if len(deprecations) > 0 {
+		ret.Deprecations = make(map[string]*deprecation, len(deprecations))
+		for provider, dep := range deprecations {
+			ret.Deprecations[provider.String()] = &deprecation{
+				Reason:    dep.Reason,
+				Link:      dep.Link,
+				EndOfLife: dep.EndOfLife,
+				Message:   dep.Describe(provider.ForDisplay()),
+			}
+		}
+	}
+	return json.Marshal(ret)
+}
+
+func marshalModule(cfg *configs.Config, reqs *configs.ModuleRequirements) *module {
+	ret := &module{
+		RequiredProviders: make(map[string]string, len(reqs.Requirements)),
+		ProviderConfigs:   make([]*providerConfig, 0),
+	}
+	for provider, constraints := range reqs.Requirements {
+		ret.RequiredProviders[provider.String()] = getproviders.VersionConstraintsString(constraints)
+	}
+
+	for _, addr := range usedProviderConfigs(cfg) {
+		provider := cfg.Module.ProviderForLocalConfig(addr)
+		pc := &providerConfig{
+			Name:     addr.StringCompact(),
+			Provider: provider.String(),
+		}
+		abs, source := resolveProviderConfig(cfg, addr)
+		pc.Source = source
+		if source != sourceMissing {
+			pc.Configuration = abs.String()
+		}
+		ret.ProviderConfigs = append(ret.ProviderConfigs, pc)
+	}
+
+	if len(cfg.Children) > 0 {
+		ret.ModuleCalls = make(map[string]*module, len(cfg.Children))
+		for name, child := range cfg.Children {
+			childReqs := reqs.Children[name]
+			if childReqs == nil {
+				childReqs = &configs.ModuleRequirements{}
+			}
+			ret.ModuleCalls[name] = marshalModule(child, childReqs)
+		}
+	}
+	return ret
+}
+
+// usedProviderConfigs returns the local addresses of all the provider
+// configurations that the given module declares, expects, passes to its
+// children or uses for its resources, sorted by address.
+func usedProviderConfigs(cfg *configs.Config) []addrs.LocalProviderConfig {
+	seen := make(map[addrs.LocalProviderConfig]struct{})
+	for _, pc := range cfg.Module.ProviderConfigs {
+		seen[pc.Addr()] = struct{}{}
+	}
+	if reqs := cfg.Module.ProviderRequirements; reqs != nil {
+		for _, req := range reqs.RequiredProviders {
+			for _, alias := range req.Aliases {
+				seen[alias] = struct{}{}
+			}
+		}
+	}
+	for _, r := range cfg.Module.ManagedResources {
+		seen[r.ProviderConfigAddr()] = struct{}{}
+	}
+	for _, r := range cfg.Module.DataResources {
+		seen[r.ProviderConfigAddr()] = struct{}{}
+	}
+	for _, mc := range cfg.Module.ModuleCalls {
+		for _, passed := range mc.Providers {
+			seen[passed.InParent.Addr()] = struct{}{}
+		}
+	}
+	if call := parentCall(cfg); call != nil {
+		for _, passed := range call.Providers {
+			seen[passed.InChild.Addr()] = struct{}{}
+		}
+	}
+
+	ret := make([]addrs.LocalProviderConfig, 0, len(seen))
+	for addr := range seen {
+		ret = append(ret, addr)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].StringCompact() < ret[j].StringCompact()
+	})
+	return ret
+}
+
+// resolveProviderConfig follows the given provider configuration of a module
+// up through its ancestors, and returns the absolute address of the provider
+// configuration that supplies it along with how the module gets it.
+func resolveProviderConfig(cfg *configs.Config, addr addrs.LocalProviderConfig) (addrs.AbsProviderConfig, string) {
+	provider := cfg.Module.ProviderForLocalConfig(addr)
+	abs := addrs.AbsProviderConfig{
+		Module:   cfg.Path,
+		Provider: provider,
+		Alias:    addr.Alias,
+	}
+	if _, ok := cfg.Module.ProviderConfigs[addr.StringCompact()]; ok {
+		return abs, sourceDeclared
+	}
+	if cfg.Path.IsRoot() {
+		if addr.Alias != "" {
+			return abs, sourceMissing
+		}
+		return abs, sourceImplied
+	}
+
+	if call := parentCall(cfg); call != nil {
+		for _, passed := range call.Providers {
+			if passed.InChild.Addr() == addr {
+				ret, source := resolveProviderConfig(cfg.Parent, passed.InParent.Addr())
+				if source == sourceMissing {
+					return ret, source
+				}
+				return ret, sourcePassed
+			}
+		}
+	}
+
+	if addr.Alias != "" {
+		// Only default configurations can be inherited.
+		return abs, sourceMissing
+	}
+	parentAddr := addrs.LocalProviderConfig{
+		LocalName: cfg.Parent.Module.LocalNameForProvider(provider),
+	}
+	ret, source := resolveProviderConfig(cfg.Parent, parentAddr)
+	if source == sourceMissing {
+		return ret, source
+	}
+	return ret, sourceInherited
+}
+
+// parentCall returns the module call in the parent module that calls the
+// given module, or nil for the root module.
+func parentCall(cfg *configs.Config) *configs.ModuleCall {
+	if cfg.Parent == nil {
+		return nil
+	}
+	return cfg.Parent.Module.ModuleCalls[cfg.Path[len(cfg.Path)-1]]
+}
@@ -88,6 +88,11 @@ type Resource struct {
 	// offering "google_compute_instance".
 	ProviderName string `json:"provider_name"`
 
+	// ProviderConfig is the address of the provider configuration that most
+	// recently managed this resource instance, including the alias and, for
+	// a provider configuration using for_each, the key of its instance.
+	ProviderConfig string `json:"provider_config,omitempty"`
+
 	// SchemaVersion indicates which version of the resource type schema the
 	// "values" property conforms to.
 	SchemaVersion uint64 `json:"schema_version"`
@@ -363,11 +368,13 @@ func marshalResources(resources map[string]*states.Resource, module addrs.Module
 
 			resAddr := r.Addr.Resource
 
+			providerConfig, providerKey := r.InstanceProvider(k)
 			current := Resource{
-				Address:      r.Addr.Instance(k).String(),
-				Type:         resAddr.Type,
-				Name:         resAddr.Name,
-				ProviderName: r.ProviderConfig.Provider.String(),
+				Address:        r.Addr.Instance(k).String(),
+				Type:           resAddr.Type,
+				Name:           resAddr.Name,
+				ProviderName:   r.ProviderConfig.Provider.String(),
+				ProviderConfig: providerConfig.InstanceString(providerKey),
 			}
 
 			if k != nil {
@@ -449,12 +456,13 @@ func marshalResources(resources map[string]*states.Resource, module addrs.Module
 
 				// copy the base fields from the current instance
 				deposed := Resource{
-					Address:      current.Address,
-					Type:         current.Type,
-					Name:         current.Name,
-					ProviderName: current.ProviderName,
-					Mode:         current.Mode,
-					Index:        current.Index,
+					Address:        current.Address,
+					Type:           current.Type,
+					Name:           current.Name,
+					ProviderName:   current.ProviderName,
+					ProviderConfig: current.ProviderConfig,
+					Mode:           current.Mode,
+					Index:          current.Index,
 				}
 
 				riObj, err := rios.Decode(schema.ImpliedType())
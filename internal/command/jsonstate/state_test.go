@@ -222,12 +222,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_thing.bar",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`null`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -264,12 +265,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_thing.bar",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`"sensuzles"`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -310,12 +312,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_thing.bar",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`"confuzles"`),
 						"woozles": json.RawMessage(`null`),
@@ -381,12 +384,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar[0]",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        json.RawMessage(`0`),
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_thing.bar[0]",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          json.RawMessage(`0`),
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`null`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -423,12 +427,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar[\"rockhopper\"]",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        json.RawMessage(`"rockhopper"`),
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_thing.bar[\"rockhopper\"]",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          json.RawMessage(`"rockhopper"`),
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`null`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -467,13 +472,14 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
-					DeposedKey:   deposedKey.String(),
+					Address:        "test_thing.bar",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
+					DeposedKey:     deposedKey.String(),
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`null`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -516,12 +522,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_thing.bar",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_thing.bar",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`null`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -529,13 +536,14 @@ func TestMarshalResources(t *testing.T) {
 					SensitiveValues: json.RawMessage("{\"foozles\":true}"),
 				},
 				{
-					Address:      "test_thing.bar",
-					Mode:         "managed",
-					Type:         "test_thing",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
-					DeposedKey:   deposedKey.String(),
+					Address:        "test_thing.bar",
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
+					DeposedKey:     deposedKey.String(),
 					AttributeValues: AttributeValues{
 						"foozles": json.RawMessage(`null`),
 						"woozles": json.RawMessage(`"confuzles"`),
@@ -576,12 +584,13 @@ func TestMarshalResources(t *testing.T) {
 			testSchemas(),
 			[]Resource{
 				{
-					Address:      "test_map_attr.bar",
-					Mode:         "managed",
-					Type:         "test_map_attr",
-					Name:         "bar",
-					Index:        nil,
-					ProviderName: "registry.opentofu.org/hashicorp/test",
+					Address:        "test_map_attr.bar",
+					Mode:           "managed",
+					Type:           "test_map_attr",
+					Name:           "bar",
+					Index:          nil,
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
 					AttributeValues: AttributeValues{
 						"data": json.RawMessage(`{"woozles":"confuzles"}`),
 					},
@@ -590,6 +599,51 @@ func TestMarshalResources(t *testing.T) {
 			},
 			false,
 		},
+		"resource with provider instance": {
+			map[string]*states.Resource{
+				"test_thing.bar": {
+					Addr: addrs.AbsResource{
+						Resource: addrs.Resource{
+							Mode: addrs.ManagedResourceMode,
+							Type: "test_thing",
+							Name: "bar",
+						},
+					},
+					Instances: map[addrs.InstanceKey]*states.ResourceInstance{
+						addrs.StringKey("east"): {
+							Current: &states.ResourceInstanceObjectSrc{
+								Status:    states.ObjectReady,
+								AttrsJSON: []byte(`{"woozles":"confuzles"}`),
+							},
+							ProviderKey: addrs.StringKey("us-east-1"),
+						},
+					},
+					ProviderConfig: addrs.AbsProviderConfig{
+						Provider: addrs.NewDefaultProvider("test"),
+						Module:   addrs.RootModule,
+						Alias:    "regional",
+					},
+				},
+			},
+			testSchemas(),
+			[]Resource{
+				{
+					Address:        `test_thing.bar["east"]`,
+					Mode:           "managed",
+					Type:           "test_thing",
+					Name:           "bar",
+					Index:          json.RawMessage(`"east"`),
+					ProviderName:   "registry.opentofu.org/hashicorp/test",
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"].regional["us-east-1"]`,
+					AttributeValues: AttributeValues{
+						"foozles": json.RawMessage(`null`),
+						"woozles": json.RawMessage(`"confuzles"`),
+					},
+					SensitiveValues: json.RawMessage("{\"foozles\":true}"),
+				},
+			},
+			false,
+		},
 	}
 
 	for name, test := range tests {
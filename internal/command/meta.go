@@ -25,8 +25,10 @@ import (
 	"github.com/mitchellh/colorstring"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/auditlog"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/backend/local"
+	"github.com/opentofu/opentofu/internal/command/applywebhook"
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/format"
 	"github.com/opentofu/opentofu/internal/command/views"
@@ -36,8 +38,10 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	legacy "github.com/opentofu/opentofu/internal/legacy/tofu"
+	"github.com/opentofu/opentofu/internal/providercache"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/provisioners"
+	"github.com/opentofu/opentofu/internal/selfupdate"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/terminal"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -96,6 +100,27 @@ type Meta struct {
 	// the specific commands being run.
 	RunningInAutomation bool
 
+	// ReadOnly is set when OpenTofu runs in read-only mode, either with the
+	// global -read-only option or the TF_READ_ONLY environment variable. The
+	// backends then guarantee that no state is written, no state locks are
+	// acquired, and no operations that could change remote objects are run.
+	ReadOnly bool
+
+	// IgnoreVersionConstraint is set by the global -ignore-version-constraint
+	// option or the TF_IGNORE_VERSION_CONSTRAINT environment variable, to
+	// report unmet OpenTofu version constraints as warnings rather than
+	// errors.
+	IgnoreVersionConstraint bool
+
+	// AuditLog, if non-nil, records every state snapshot written by the
+	// backends, as configured by the audit_log CLI configuration setting.
+	AuditLog *auditlog.Log
+
+	// ApplyWebhook, if non-nil, receives the result of each resource
+	// instance change made by an apply, as configured by the apply_webhook
+	// CLI configuration block.
+	ApplyWebhook *applywebhook.Notifier
+
 	// CLIConfigDir is the directory from which CLI configuration files were
 	// read by the caller and the directory where any changes to CLI
 	// configuration files by commands should be made.
@@ -124,6 +149,34 @@ type Meta struct {
 	// longer any compelling reasons for folks to not lock their dependencies.
 	PluginCacheMayBreakDependencyLockFile bool
 
+	// ProviderChecksumPolicy, if non-nil, imposes additional requirements on
+	// the checksums and signing keys of provider packages installed by
+	// "tofu init", as configured in the CLI configuration.
+	ProviderChecksumPolicy *providercache.ChecksumPolicy
+
+	// Project, if non-nil, holds the settings from the project file in the
+	// working directory, which provide defaults for some command options.
+	Project *Project
+
+	// UpgradePolicy, if non-nil, is the release channel, version constraint,
+	// and release locations for "tofu upgrade" from the CLI configuration.
+	UpgradePolicy *selfupdate.Policy
+
+	// EnforceEncryption makes state and plan encryption mandatory, as if the
+	// configuration's encryption block set enforced = true. It is set by
+	// the enforce_encryption CLI configuration setting.
+	EnforceEncryption bool
+
+	// ColorTheme is the name of the color theme for human-oriented output,
+	// as selected by the color_theme CLI configuration setting. An empty
+	// string selects the default theme.
+	ColorTheme string
+
+	// NoUnicode replaces the box-drawing characters in human-oriented output
+	// with ASCII characters. It is set by the no_unicode CLI configuration
+	// setting.
+	NoUnicode bool
+
 	// ProviderSource allows determining the available versions of a provider
 	// and determines where a distribution package for a particular
 	// provider version can be obtained.
@@ -285,6 +338,12 @@ type Meta struct {
 	// This helps prevent duplicate errors/warnings.
 	rootModuleCallCache *configs.StaticModuleCall
 	inputVariableCache  map[string]backend.UnparsedVariableValue
+
+	// Used to cache the diagnostic suppressions of the root module, and
+	// to report each expired suppression only once.
+	diagnosticSuppressions       []*configs.DiagnosticSuppression
+	diagnosticSuppressionsLoaded bool
+	expiredSuppressionsReported  map[*configs.DiagnosticSuppression]bool
 }
 
 type testingOverrides struct {
@@ -313,11 +372,12 @@ func (m *Meta) StateOutPath() string {
 
 // Colorize returns the colorization structure for a command.
 func (m *Meta) Colorize() *colorstring.Colorize {
-	colors := make(map[string]string)
-	for k, v := range colorstring.DefaultColors {
-		colors[k] = v
+	colors, ok := format.ColorThemeColors(m.ColorTheme)
+	if !ok {
+		// An unknown theme is already reported when validating the CLI
+		// configuration, so we just fall back to the default here.
+		colors, _ = format.ColorThemeColors(format.DefaultColorTheme)
 	}
-	colors["purple"] = "38;5;57"
 
 	return &colorstring.Colorize{
 		Colors:  colors,
@@ -669,6 +729,7 @@ func (m *Meta) process(args []string) []string {
 			ErrorColor: "[red]",
 			WarnColor:  "[yellow]",
 			Ui:         m.oldUi,
+			NoUnicode:  m.NoUnicode,
 		},
 	}
 
@@ -681,6 +742,7 @@ func (m *Meta) process(args []string) []string {
 			ConsolidateErrors:   m.consolidateErrors,
 			NoColor:             !m.Color,
 		})
+		m.View.SetDiagnosticsFilter(m.suppressDiagnostics)
 	}
 
 	return args
@@ -739,6 +801,11 @@ func (m *Meta) showDiagnostics(vals ...interface{}) {
 		return
 	}
 
+	diags = m.suppressDiagnostics(diags)
+	if len(diags) == 0 {
+		return
+	}
+
 	outputWidth := m.ErrorColumns()
 
 	if m.consolidateWarnings {
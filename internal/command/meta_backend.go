@@ -397,6 +397,8 @@ func (m *Meta) backendCLIOpts() (*backend.CLIOpts, error) {
 		ContextOpts:         contextOpts,
 		Input:               m.Input(),
 		RunningInAutomation: m.RunningInAutomation,
+		ReadOnly:            m.ReadOnly,
+		AuditLog:            m.AuditLog,
 	}, err
 }
 
@@ -424,7 +426,10 @@ func (m *Meta) Operation(b backend.Backend, vt arguments.ViewType, enc encryptio
 	}
 
 	stateLocker := clistate.NewNoopLocker()
-	if m.stateLock {
+	// State locks can't be acquired in read-only mode, but since operations
+	// that are allowed in read-only mode can't write state either there is
+	// also nothing to protect with a lock.
+	if m.stateLock && !m.ReadOnly {
 		view := views.NewStateLocker(vt, m.View)
 		stateLocker = clistate.NewLocker(m.stateLockTimeout, view)
 	}
@@ -926,6 +931,7 @@ func (m *Meta) backend_c_r_S(
 		Source:          b,
 		Destination:     localB,
 		ViewType:        vt,
+		Encryption:      enc,
 	})
 	if err != nil {
 		diags = diags.Append(err)
@@ -1019,6 +1025,7 @@ func (m *Meta) backend_C_r_s(c *configs.Backend, cHash int, sMgr *clistate.Local
 			Source:          localB,
 			Destination:     b,
 			ViewType:        vt,
+			Encryption:      enc,
 		})
 		if err != nil {
 			diags = diags.Append(err)
@@ -1193,6 +1200,7 @@ func (m *Meta) backend_C_r_S_changed(c *configs.Backend, cHash int, sMgr *clista
 			Source:          oldB,
 			Destination:     b,
 			ViewType:        vt,
+			Encryption:      enc,
 		})
 		if err != nil {
 			diags = diags.Append(err)
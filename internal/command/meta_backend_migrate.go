@@ -33,6 +33,11 @@ type backendMigrateOpts struct {
 	Source, Destination         backend.Backend
 	ViewType                    arguments.ViewType
 
+	// Encryption is the configured state encryption. It is used for the
+	// copies of the states written for the user to compare when encryption
+	// is enforced.
+	Encryption encryption.StateEncryption
+
 	// Fields below are set internally when migrate is called
 
 	sourceWorkspace      string
@@ -498,7 +503,7 @@ func (m *Meta) backendMigrateNonEmptyConfirm(
 
 	// Helper to write the state
 	saveHelper := func(n, path string, s *states.State) error {
-		return statemgr.WriteAndPersist(statemgr.NewFilesystem(path, encryption.StateEncryptionDisabled()), s, nil)
+		return statemgr.WriteAndPersist(statemgr.NewFilesystem(path, encryption.StateForUserFile(opts.Encryption)), s, nil)
 	}
 
 	// Write the states
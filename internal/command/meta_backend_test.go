@@ -6,6 +6,7 @@
 package command
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
@@ -1408,6 +1409,114 @@ func TestMetaBackend_configuredChangeCopy_multiToMulti(t *testing.T) {
 	}
 }
 
+// Changing a configured backend that supports multi-state to a
+// backend that also supports multi-state, with the states encrypted by a
+// method that binds them to their workspace.
+func TestMetaBackend_configuredChangeCopy_multiToMultiBindContext(t *testing.T) {
+	// Create a temporary working directory that is empty
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("backend-change-multi-to-multi"), td)
+	defer testChdir(t, td)()
+
+	err := os.WriteFile("main.tf", []byte(`
+terraform {
+  backend "local" {
+    workspace_dir = "envdir-new"
+  }
+  encryption {
+    key_provider "pbkdf2" "basic" {
+      passphrase = "correct-horse-battery-staple"
+      iterations = 200000
+    }
+    method "aes_gcm" "bound" {
+      keys         = key_provider.pbkdf2.basic
+      bind_context = true
+    }
+    state {
+      method = method.aes_gcm.bound
+    }
+  }
+}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// encryptionFor returns the state encryption as it is when the given
+	// workspace is selected.
+	encryptionFor := func(workspace string) encryption.StateEncryption {
+		t.Setenv(WorkspaceNameEnvVar, workspace)
+		enc, diags := testMetaBackend(t, nil).Encryption()
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		return enc.State()
+	}
+
+	// Encrypt the existing states in their workspaces.
+	for workspace, path := range map[string]string{
+		backend.DefaultStateName: "local-state.tfstate",
+		"env2":                   filepath.Join(backendLocal.DefaultWorkspaceDir, "env2", backendLocal.DefaultStateFilename),
+	} {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stateFile, err := statefile.Read(bytes.NewReader(src), encryption.StateEncryptionDisabled())
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := statefile.Write(stateFile, f, encryptionFor(workspace)); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	// Ask input
+	defer testInputMap(t, map[string]string{
+		"backend-migrate-multistate-to-multistate": "yes",
+	})()
+
+	// Get the backend, with the default workspace selected
+	enc := encryptionFor("")
+	m := testMetaBackend(t, nil)
+	b, diags := m.Backend(&BackendOpts{Init: true}, enc)
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	// Check resulting states
+	for workspace, lineage := range map[string]string{
+		backend.DefaultStateName: "backend-change",
+		"env2":                   "backend-change-env2",
+	} {
+		s, err := b.StateMgr(workspace)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := s.RefreshState(); err != nil {
+			t.Fatalf("unexpected error reading the state of %s: %s", workspace, err)
+		}
+		if got := testStateMgrCurrentLineage(s); got != lineage {
+			t.Fatalf("wrong lineage %q for %s; want %q", got, workspace, lineage)
+		}
+	}
+
+	// The migrated state must be readable when its workspace is selected.
+	f, err := os.Open(filepath.Join("envdir-new", "env2", backendLocal.DefaultStateFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := statefile.Read(f, encryptionFor("env2")); err != nil {
+		t.Fatalf("can't read the migrated state in its workspace: %s", err)
+	}
+}
+
 // Changing a configured backend that supports multi-state to a
 // backend that also supports multi-state, but doesn't allow a
 // default state while the default state is non-empty.
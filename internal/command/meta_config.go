@@ -24,6 +24,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/initwd"
 	"github.com/opentofu/opentofu/internal/registry"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -293,6 +294,15 @@ func (m *Meta) installModules(ctx context.Context, rootDir, testsDir string, upg
 
 	inst := initwd.NewModuleInstaller(m.modulesDir(), loader, m.registryClient())
 
+	vendor, err := getmodules.LoadVendor(filepath.Join(rootDir, getmodules.VendorDir))
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to read vendored modules: %w", err))
+		return true, diags
+	}
+	if vendor != nil {
+		inst.UseVendor(vendor)
+	}
+
 	call, vDiags := m.rootModuleCall(rootDir)
 	diags = diags.Append(vDiags)
 	if diags.HasErrors() {
@@ -456,6 +466,7 @@ func (m *Meta) initConfigLoader() (*configload.Loader, error) {
 			return nil, err
 		}
 		loader.AllowLanguageExperiments(m.AllowExperimentalFeatures)
+		loader.IgnoreCoreVersionConstraints(m.IgnoreVersionConstraint)
 		m.configLoader = loader
 		if m.View != nil {
 			m.View.SetConfigSources(loader.Sources)
@@ -51,6 +51,9 @@ func (m *Meta) EncryptionFromModule(module *configs.Module) (encryption.Encrypti
 		}
 		cfg = cfg.Merge(envCfg)
 	}
+	if m.EnforceEncryption {
+		cfg = cfg.Merge(&config.EncryptionConfig{Enforced: true})
+	}
 
 	enc, encDiags := encryption.New(encryption.DefaultRegistry, cfg, module.StaticEvaluator)
 	diags = diags.Append(encDiags)
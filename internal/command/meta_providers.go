@@ -81,6 +81,7 @@ func (m *Meta) providerInstallerCustomSource(source getproviders.Source) *provid
 		unmanagedProviderTypes[ty] = struct{}{}
 	}
 	inst.SetUnmanagedProviderTypes(unmanagedProviderTypes)
+	inst.SetChecksumPolicy(m.ProviderChecksumPolicy)
 	return inst
 }
 
@@ -120,6 +121,15 @@ func (m *Meta) providerLocalCacheDir() *providercache.Dir {
 	return providercache.NewDir(dir)
 }
 
+// providerSchemaCacheDir returns an object representing the
+// configuration-specific directory of saved provider schemas, which allows
+// validating the configuration without starting the provider plugins.
+func (m *Meta) providerSchemaCacheDir() *providercache.SchemaDir {
+	m.fixupMissingWorkingDir()
+	dir := m.WorkingDir.ProviderSchemaCacheDir()
+	return providercache.NewSchemaDir(dir)
+}
+
 // providerGlobalCacheDir returns an object representing the shared global
 // provider cache directory, used as a read-through cache when installing
 // new provider plugin packages.
@@ -351,7 +361,7 @@ func (m *Meta) internalProviders() map[string]providers.Factory {
 // file in the given cache package and uses go-plugin to implement
 // providers.Interface against it.
 func providerFactory(meta *providercache.CachedProvider) providers.Factory {
-	return func() (providers.Interface, error) {
+	start := func() (providers.Interface, error) {
 		execFile, err := meta.ExecutableFile()
 		if err != nil {
 			return nil, err
@@ -388,6 +398,16 @@ func providerFactory(meta *providercache.CachedProvider) providers.Factory {
 
 		return p, err
 	}
+
+	// We start the plugin processes ourselves, so we can also restart them
+	// if they exit unexpectedly.
+	return func() (providers.Interface, error) {
+		p, err := start()
+		if err != nil {
+			return nil, err
+		}
+		return newRestartingProvider(meta.Provider, p, start), nil
+	}
 }
 
 // initializeProviderInstance uses the plugin dispensed by the RPC client, and initializes a plugin instance
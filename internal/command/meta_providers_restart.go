@@ -0,0 +1,276 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// pluginDidNotRespondSummary is the summary of the diagnostic the plugin
+// clients return when a provider plugin stops responding, usually because
+// its process exited unexpectedly.
+const pluginDidNotRespondSummary = "Plugin did not respond"
+
+// restartingProvider wraps a provider plugin, and restarts it once if it
+// exits unexpectedly during a request that is safe to retry, so that an
+// occasional provider crash doesn't fail an otherwise healthy run.
+//
+// Only requests that don't change any remote objects are retried. In
+// particular, a failed ApplyResourceChange is never retried, since the
+// change might have been partially made.
+type restartingProvider struct {
+	addr  addrs.Provider
+	start providers.Factory
+
+	mu        sync.Mutex
+	current   providers.Interface
+	configure *providers.ConfigureProviderRequest
+	restarted bool
+}
+
+var _ providers.Interface = (*restartingProvider)(nil)
+
+func newRestartingProvider(addr addrs.Provider, initial providers.Interface, start providers.Factory) *restartingProvider {
+	return &restartingProvider{
+		addr:    addr,
+		start:   start,
+		current: initial,
+	}
+}
+
+func (p *restartingProvider) instance() providers.Interface {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+// retryAfterRestart calls the given function with a new instance of the
+// provider if the given diagnostics show that the failed instance stopped
+// responding, and returns the new instance's result along with a warning
+// about the restart. Otherwise, it returns ok false.
+func retryAfterRestart[T any](p *restartingProvider, failed providers.Interface, diags tfdiags.Diagnostics, method string, call func(providers.Interface) (T, tfdiags.Diagnostics)) (result T, resultDiags tfdiags.Diagnostics, ok bool) {
+	if !pluginDidNotRespond(diags) {
+		return result, nil, false
+	}
+	next, restartDiags := p.restart(failed, method)
+	if next == nil {
+		return result, nil, false
+	}
+	result, resultDiags = call(next)
+	return result, restartDiags.Append(resultDiags), true
+}
+
+// restart replaces the given failed instance of the provider with a new one,
+// configured in the same way, and returns the new instance.
+//
+// The provider is only restarted once. If it has already been restarted,
+// restart returns the current instance if it isn't the failed one, or nil
+// otherwise.
+func (p *restartingProvider) restart(failed providers.Interface, method string) (providers.Interface, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current != failed {
+		// Another request already restarted the provider.
+		return p.current, diags
+	}
+	if p.restarted {
+		return nil, diags
+	}
+	p.restarted = true
+
+	log.Printf("[WARN] Provider %s stopped responding during %s; restarting it", p.addr, method)
+	if err := failed.Close(); err != nil {
+		log.Printf("[DEBUG] Failed to close provider %s after it stopped responding: %s", p.addr, err)
+	}
+
+	next, err := p.start()
+	if err != nil {
+		log.Printf("[ERROR] Failed to restart provider %s: %s", p.addr, err)
+		return nil, diags
+	}
+	if p.configure != nil {
+		resp := next.ConfigureProvider(*p.configure)
+		if resp.Diagnostics.HasErrors() {
+			log.Printf("[ERROR] Failed to configure restarted provider %s: %s", p.addr, resp.Diagnostics.Err())
+			next.Close()
+			return nil, diags
+		}
+	}
+	p.current = next
+
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Warning,
+		"Provider restarted",
+		fmt.Sprintf(
+			"The provider %s exited unexpectedly during a %s request, so OpenTofu restarted it and retried the request. If this keeps happening, please report it to the provider's maintainers.",
+			p.addr, method,
+		),
+	))
+	return next, diags
+}
+
+func pluginDidNotRespond(diags tfdiags.Diagnostics) bool {
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Error && diag.Description().Summary == pluginDidNotRespondSummary {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *restartingProvider) GetProviderSchema() providers.GetProviderSchemaResponse {
+	inst := p.instance()
+	resp := inst.GetProviderSchema()
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "GetProviderSchema", func(next providers.Interface) (providers.GetProviderSchemaResponse, tfdiags.Diagnostics) {
+		r := next.GetProviderSchema()
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) ValidateProviderConfig(req providers.ValidateProviderConfigRequest) providers.ValidateProviderConfigResponse {
+	inst := p.instance()
+	resp := inst.ValidateProviderConfig(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "ValidateProviderConfig", func(next providers.Interface) (providers.ValidateProviderConfigResponse, tfdiags.Diagnostics) {
+		r := next.ValidateProviderConfig(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) ValidateResourceConfig(req providers.ValidateResourceConfigRequest) providers.ValidateResourceConfigResponse {
+	inst := p.instance()
+	resp := inst.ValidateResourceConfig(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "ValidateResourceConfig", func(next providers.Interface) (providers.ValidateResourceConfigResponse, tfdiags.Diagnostics) {
+		r := next.ValidateResourceConfig(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) ValidateDataResourceConfig(req providers.ValidateDataResourceConfigRequest) providers.ValidateDataResourceConfigResponse {
+	inst := p.instance()
+	resp := inst.ValidateDataResourceConfig(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "ValidateDataResourceConfig", func(next providers.Interface) (providers.ValidateDataResourceConfigResponse, tfdiags.Diagnostics) {
+		r := next.ValidateDataResourceConfig(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) UpgradeResourceState(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+	inst := p.instance()
+	resp := inst.UpgradeResourceState(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "UpgradeResourceState", func(next providers.Interface) (providers.UpgradeResourceStateResponse, tfdiags.Diagnostics) {
+		r := next.UpgradeResourceState(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) ConfigureProvider(req providers.ConfigureProviderRequest) providers.ConfigureProviderResponse {
+	p.mu.Lock()
+	p.configure = &req
+	inst := p.current
+	p.mu.Unlock()
+	return inst.ConfigureProvider(req)
+}
+
+func (p *restartingProvider) Stop() error {
+	return p.instance().Stop()
+}
+
+func (p *restartingProvider) ReadResource(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+	inst := p.instance()
+	resp := inst.ReadResource(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "ReadResource", func(next providers.Interface) (providers.ReadResourceResponse, tfdiags.Diagnostics) {
+		r := next.ReadResource(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) PlanResourceChange(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+	inst := p.instance()
+	resp := inst.PlanResourceChange(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "PlanResourceChange", func(next providers.Interface) (providers.PlanResourceChangeResponse, tfdiags.Diagnostics) {
+		r := next.PlanResourceChange(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) ApplyResourceChange(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	// Never retried: see the restartingProvider documentation.
+	return p.instance().ApplyResourceChange(req)
+}
+
+func (p *restartingProvider) ImportResourceState(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	inst := p.instance()
+	resp := inst.ImportResourceState(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "ImportResourceState", func(next providers.Interface) (providers.ImportResourceStateResponse, tfdiags.Diagnostics) {
+		r := next.ImportResourceState(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) ReadDataSource(req providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
+	inst := p.instance()
+	resp := inst.ReadDataSource(req)
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "ReadDataSource", func(next providers.Interface) (providers.ReadDataSourceResponse, tfdiags.Diagnostics) {
+		r := next.ReadDataSource(req)
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) GetFunctions() providers.GetFunctionsResponse {
+	inst := p.instance()
+	resp := inst.GetFunctions()
+	if next, diags, ok := retryAfterRestart(p, inst, resp.Diagnostics, "GetFunctions", func(next providers.Interface) (providers.GetFunctionsResponse, tfdiags.Diagnostics) {
+		r := next.GetFunctions()
+		return r, r.Diagnostics
+	}); ok {
+		resp, resp.Diagnostics = next, diags
+	}
+	return resp
+}
+
+func (p *restartingProvider) CallFunction(req providers.CallFunctionRequest) providers.CallFunctionResponse {
+	// Provider functions report errors as a single error rather than as
+	// diagnostics, and so can't tell us that the plugin stopped responding.
+	return p.instance().CallFunction(req)
+}
+
+func (p *restartingProvider) Close() error {
+	return p.instance().Close()
+}
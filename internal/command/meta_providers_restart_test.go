@@ -0,0 +1,115 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// crashingProvider returns a mock provider whose ReadResource and
+// ApplyResourceChange requests fail as if the plugin had stopped responding.
+func crashingProvider() *tofu.MockProvider {
+	p := testProvider()
+	crashed := tfdiags.Diagnostics{}.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		pluginDidNotRespondSummary,
+		"The plugin encountered an error, and failed to respond to the plugin.(*GRPCProvider).ReadResource call.",
+	))
+	p.ReadResourceFn = func(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+		return providers.ReadResourceResponse{Diagnostics: crashed}
+	}
+	p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+		return providers.ApplyResourceChangeResponse{Diagnostics: crashed}
+	}
+	return p
+}
+
+func TestRestartingProvider(t *testing.T) {
+	addr := addrs.NewDefaultProvider("test")
+	first := crashingProvider()
+	var started []*tofu.MockProvider
+	start := func() (providers.Interface, error) {
+		p := testProvider()
+		started = append(started, p)
+		return p, nil
+	}
+	p := newRestartingProvider(addr, first, start)
+
+	config := cty.ObjectVal(map[string]cty.Value{"region": cty.StringVal("eu-west-1")})
+	p.ConfigureProvider(providers.ConfigureProviderRequest{Config: config})
+
+	prior := cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("foo")})
+	resp := p.ReadResource(providers.ReadResourceRequest{TypeName: "test_instance", PriorState: prior})
+	if resp.Diagnostics.HasErrors() {
+		t.Fatalf("unexpected errors: %s", resp.Diagnostics.Err())
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Description().Summary != "Provider restarted" {
+		t.Fatalf("expected a warning about the restart, got %#v", resp.Diagnostics)
+	}
+	if !resp.NewState.RawEquals(prior) {
+		t.Fatalf("wrong new state %#v", resp.NewState)
+	}
+
+	if !first.CloseCalled {
+		t.Error("the failed provider was not closed")
+	}
+	if len(started) != 1 {
+		t.Fatalf("expected the provider to be started once, not %d times", len(started))
+	}
+	if got := started[0].ConfigureProviderRequest.Config; !got.RawEquals(config) {
+		t.Errorf("the restarted provider was configured with %#v", got)
+	}
+}
+
+func TestRestartingProvider_once(t *testing.T) {
+	addr := addrs.NewDefaultProvider("test")
+	start := func() (providers.Interface, error) {
+		p := crashingProvider()
+		p.ConfigureProviderCalled = true
+		return p, nil
+	}
+	first, _ := start()
+	p := newRestartingProvider(addr, first, start)
+
+	// The first crash restarts the provider, but the restarted provider
+	// crashes too, and so the error is returned.
+	resp := p.ReadResource(providers.ReadResourceRequest{TypeName: "test_instance"})
+	if !pluginDidNotRespond(resp.Diagnostics) {
+		t.Fatalf("expected the plugin error, got %#v", resp.Diagnostics)
+	}
+
+	// The provider is not restarted again.
+	resp = p.ReadResource(providers.ReadResourceRequest{TypeName: "test_instance"})
+	if !pluginDidNotRespond(resp.Diagnostics) {
+		t.Fatalf("expected the plugin error, got %#v", resp.Diagnostics)
+	}
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity() == tfdiags.Warning {
+			t.Fatalf("unexpected warning %#v", diag)
+		}
+	}
+}
+
+func TestRestartingProvider_apply(t *testing.T) {
+	addr := addrs.NewDefaultProvider("test")
+	first := crashingProvider()
+	first.ConfigureProviderCalled = true
+	start := func() (providers.Interface, error) {
+		t.Fatal("the provider must not be restarted during apply")
+		return nil, nil
+	}
+	p := newRestartingProvider(addr, first, start)
+
+	resp := p.ApplyResourceChange(providers.ApplyResourceChangeRequest{TypeName: "test_instance"})
+	if !pluginDidNotRespond(resp.Diagnostics) {
+		t.Fatalf("expected the plugin error, got %#v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// cachedSchemaProviderFactories is a variant of providerFactories that
+// produces providers serving only the schemas saved in the provider schema
+// cache for the versions selected in the dependency lock file, without
+// starting any provider plugins.
+//
+// The resulting providers can be used only for validation.
+func (m *Meta) cachedSchemaProviderFactories() (map[addrs.Provider]providers.Factory, error) {
+	locks, diags := m.lockedDependencies()
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to read dependency lock file: %w", diags.Err())
+	}
+
+	errs := make(map[addrs.Provider]error)
+	providerLocks := locks.AllProviders()
+	schemaDir := m.providerSchemaCacheDir()
+	internalFactories := m.internalProviders()
+
+	factories := make(map[addrs.Provider]providers.Factory, len(providerLocks)+len(internalFactories))
+	for name, factory := range internalFactories {
+		// The internal providers are built in to OpenTofu, so we can always
+		// use them directly.
+		factories[addrs.NewBuiltInProvider(name)] = factory
+	}
+	for provider, lock := range providerLocks {
+		reportError := func(thisErr error) {
+			errs[provider] = thisErr
+			factories[provider] = providerFactoryError(thisErr)
+		}
+
+		if locks.ProviderIsOverridden(provider) {
+			reportError(errors.New("cached schemas cannot be used for providers with development overrides"))
+			continue
+		}
+
+		version := lock.Version()
+		schema, err := schemaDir.ProviderSchema(provider, version)
+		if err != nil {
+			reportError(fmt.Errorf("failed to read cached schema: %w", err))
+			continue
+		}
+		if schema == nil {
+			reportError(fmt.Errorf(
+				"there is no schema for %s %s cached in %s; run \"tofu validate\" without -cached-schemas first",
+				provider, version, schemaDir.BasePath(),
+			))
+			continue
+		}
+		factories[provider] = schemaOnlyProviderFactory(provider, *schema)
+	}
+
+	var err error
+	if len(errs) > 0 {
+		err = providerPluginErrors(errs)
+	}
+	return factories, err
+}
+
+// saveProviderSchemas writes the given schemas to the provider schema cache
+// for any of the providers that were installed for the versions selected in
+// the dependency lock file, so that they can be used later by
+// cachedSchemaProviderFactories.
+func (m *Meta) saveProviderSchemas(schemas *tofu.Schemas) error {
+	locks, diags := m.lockedDependencies()
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to read dependency lock file: %w", diags.Err())
+	}
+
+	schemaDir := m.providerSchemaCacheDir()
+	for provider, schema := range schemas.Providers {
+		lock := locks.Provider(provider)
+		if lock == nil || locks.ProviderIsOverridden(provider) {
+			// We can only save schemas for providers whose version we know.
+			continue
+		}
+		if err := schemaDir.SetProviderSchema(provider, lock.Version(), schema); err != nil {
+			return fmt.Errorf("failed to save schema for %s: %w", provider, err)
+		}
+		log.Printf("[TRACE] Meta.saveProviderSchemas: saved schema for %s %s", provider, lock.Version())
+	}
+	return nil
+}
+
+func schemaOnlyProviderFactory(addr addrs.Provider, schema providers.ProviderSchema) providers.Factory {
+	return func() (providers.Interface, error) {
+		return &schemaOnlyProvider{addr: addr, schema: schema}, nil
+	}
+}
+
+// schemaOnlyProvider is a providers.Interface implementation that serves a
+// previously-saved schema but can't do anything else. Provider-specific
+// validation always succeeds, so only the checks that OpenTofu itself makes
+// against the schema have any effect.
+type schemaOnlyProvider struct {
+	addr   addrs.Provider
+	schema providers.ProviderSchema
+}
+
+var _ providers.Interface = (*schemaOnlyProvider)(nil)
+
+func (p *schemaOnlyProvider) unsupported() error {
+	return fmt.Errorf("provider %s is only available as a cached schema, which can be used only for validation", p.addr)
+}
+
+func (p *schemaOnlyProvider) GetProviderSchema() providers.GetProviderSchemaResponse {
+	return p.schema
+}
+
+func (p *schemaOnlyProvider) ValidateProviderConfig(req providers.ValidateProviderConfigRequest) providers.ValidateProviderConfigResponse {
+	return providers.ValidateProviderConfigResponse{PreparedConfig: req.Config}
+}
+
+func (p *schemaOnlyProvider) ValidateResourceConfig(providers.ValidateResourceConfigRequest) providers.ValidateResourceConfigResponse {
+	return providers.ValidateResourceConfigResponse{}
+}
+
+func (p *schemaOnlyProvider) ValidateDataResourceConfig(providers.ValidateDataResourceConfigRequest) providers.ValidateDataResourceConfigResponse {
+	return providers.ValidateDataResourceConfigResponse{}
+}
+
+func (p *schemaOnlyProvider) UpgradeResourceState(providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+	var resp providers.UpgradeResourceStateResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) ConfigureProvider(providers.ConfigureProviderRequest) providers.ConfigureProviderResponse {
+	var resp providers.ConfigureProviderResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) Stop() error {
+	return nil
+}
+
+func (p *schemaOnlyProvider) ReadResource(providers.ReadResourceRequest) providers.ReadResourceResponse {
+	var resp providers.ReadResourceResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) PlanResourceChange(providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+	var resp providers.PlanResourceChangeResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) ApplyResourceChange(providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	var resp providers.ApplyResourceChangeResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) ImportResourceState(providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	var resp providers.ImportResourceStateResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) ReadDataSource(providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
+	var resp providers.ReadDataSourceResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.unsupported())
+	return resp
+}
+
+func (p *schemaOnlyProvider) GetFunctions() providers.GetFunctionsResponse {
+	return providers.GetFunctionsResponse{Functions: p.schema.Functions}
+}
+
+func (p *schemaOnlyProvider) CallFunction(providers.CallFunctionRequest) providers.CallFunctionResponse {
+	return providers.CallFunctionResponse{Error: p.unsupported()}
+}
+
+func (p *schemaOnlyProvider) Close() error {
+	return nil
+}
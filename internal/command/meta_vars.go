@@ -7,6 +7,7 @@ package command
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -83,6 +84,19 @@ func (m *Meta) collectVariableValues() (map[string]backend.UnparsedVariableValue
 	// search for all files ending in .auto.tfvars.
 	diags = diags.Append(m.addVarsFromDir(".", ret))
 
+	// Values in the file for the current workspace, if any, take precedence
+	// over the other automatically-loaded files, so that a configuration can
+	// set defaults for all workspaces and then override them per workspace.
+	diags = diags.Append(m.addVarsFromWorkspaceFiles(".", ret))
+
+	// The files named in the project file come next, as defaults that
+	// values given on the command line can override.
+	if m.Project != nil {
+		for _, filename := range m.Project.VarFiles {
+			diags = diags.Append(m.addVarsFromFile(filename, tofu.ValueFromNamedFile, ret))
+		}
+	}
+
 	// Finally we process values given explicitly on the command line, either
 	// as individual literal settings or as additional files to read.
 	for _, rawFlag := range m.variableArgs.AllItems() {
@@ -163,6 +177,39 @@ func (m *Meta) addVarsFromDir(currDir string, ret map[string]backend.UnparsedVar
 	return diags
 }
 
+// addVarsFromWorkspaceFiles loads the variable definitions files specific to
+// the current workspace from the given directory, if they exist. For the
+// workspace "prod" these are terraform.prod.tfvars and
+// terraform.prod.tfvars.json, loaded in that order.
+func (m *Meta) addVarsFromWorkspaceFiles(currDir string, ret map[string]backend.UnparsedVariableValue) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	workspace, err := m.Workspace()
+	if err != nil {
+		// An invalid workspace name will be reported when we go on to
+		// select the workspace, so we don't need to report it here too.
+		return diags
+	}
+
+	base := workspaceVarsFilename(workspace)
+	for _, name := range []string{base, base + ".json"} {
+		filename := filepath.Join(currDir, name)
+		if _, err := os.Stat(filename); err != nil {
+			continue
+		}
+		log.Printf("[TRACE] Meta.addVarsFromWorkspaceFiles: loading %s for workspace %q", filename, workspace)
+		diags = diags.Append(m.addVarsFromFile(filename, tofu.ValueFromAutoFile, ret))
+	}
+
+	return diags
+}
+
+// workspaceVarsFilename returns the name of the variable definitions file
+// that is automatically loaded when the given workspace is selected.
+func workspaceVarsFilename(workspace string) string {
+	return strings.TrimSuffix(DefaultVarsFilename, DefaultVarsExtension) + "." + workspace + DefaultVarsExtension
+}
+
 func (m *Meta) addVarsFromFile(filename string, sourceType tofu.ValueSourceType, to map[string]backend.UnparsedVariableValue) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/tofu"
 )
 
@@ -76,3 +77,54 @@ func TestMeta_addVarsFromFile(t *testing.T) {
 		})
 	}
 }
+
+func TestMeta_collectVariableValues_workspaceFile(t *testing.T) {
+	d := t.TempDir()
+	defer testChdir(t, d)()
+	t.Setenv(WorkspaceNameEnvVar, "staging")
+	t.Setenv(VarEnvPrefix+"env", "from env")
+
+	files := map[string]string{
+		"terraform.tfvars":              "default = \"from default file\"\nauto = \"from default file\"\nstaging = \"from default file\"\n",
+		"a.auto.tfvars":                 "auto = \"from auto file\"\nstaging = \"from auto file\"\n",
+		"terraform.staging.tfvars":      "staging = \"from staging file\"\nenv = \"from staging file\"\n",
+		"terraform.staging.tfvars.json": `{"json": "from staging json file"}`,
+		"terraform.prod.tfvars":         "prod = \"from prod file\"\n",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(d, name), []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := new(Meta)
+	values, diags := m.collectVariableValues()
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	want := map[string]string{
+		"default": "from default file",
+		"auto":    "from auto file",
+		"staging": "from staging file",
+		"env":     "from staging file",
+		"json":    "from staging json file",
+	}
+	for name, wantVal := range want {
+		raw, ok := values[name]
+		if !ok {
+			t.Errorf("missing value for %q", name)
+			continue
+		}
+		val, diags := raw.ParseVariableValue(configs.VariableParseLiteral)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		if got := val.Value.AsString(); got != wantVal {
+			t.Errorf("wrong value for %q: got %q, want %q", name, got, wantVal)
+		}
+	}
+	if _, ok := values["prod"]; ok {
+		t.Errorf("loaded values from the file for another workspace")
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/jsonmodule"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ModulesSchemaCommand is a Command implementation that prints a
+// machine-readable description of the interface of a single module.
+type ModulesSchemaCommand struct {
+	Meta
+}
+
+func (c *ModulesSchemaCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("modules schema")
+	var jsonOutput bool
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "produce JSON output")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	if !jsonOutput {
+		c.Ui.Error(
+			"The `tofu modules schema` command requires the `-json` flag.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) > 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Too many command line arguments",
+			"The modules schema command expects at most one argument: the directory containing the module.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	dir = c.normalizePath(dir)
+
+	if !c.dirIsConfigPath(dir) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No module configuration",
+			fmt.Sprintf("The directory %s does not contain any OpenTofu configuration files.", dir),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	mod, moreDiags := c.loadSingleModule(dir, configs.SelectiveLoadAll)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	out, err := jsonmodule.Marshal(mod, c.configSources())
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to describe module",
+			err.Error(),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	c.showDiagnostics(diags)
+	c.Ui.Output(string(out))
+	return 0
+}
+
+func (c *ModulesSchemaCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] modules schema -json [DIR]
+
+  Prints a machine-readable description of the interface of the module in
+  DIR, or in the current working directory if DIR is not given.
+
+  The description includes the module's input variables with their types,
+  defaults and validation rules, its outputs, its provider requirements,
+  and the resources and module calls it declares. The module is only
+  loaded from its source files, so it doesn't need to be initialized.
+
+Options:
+
+  -json    Required. Produce JSON output.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModulesSchemaCommand) Synopsis() string {
+	return "Show a machine-readable description of a module's interface"
+}
@@ -0,0 +1,146 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mitchellh/cli"
+)
+
+func TestModulesSchema(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "vpc")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	src := `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+variable "cidr" {
+  type        = string
+  description = "The CIDR block of the VPC."
+
+  validation {
+    condition     = can(cidrhost(var.cidr, 0))
+    error_message = "Must be a valid CIDR block."
+  }
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+output "id" {
+  value       = aws_vpc.this.id
+  description = "The ID of the VPC."
+}
+
+resource "aws_vpc" "this" {
+  cidr_block = var.cidr
+}
+
+data "aws_region" "current" {
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	c := &ModulesSchemaCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+	if code := c.Run([]string{"-json", dir}); code != 0 {
+		t.Fatalf("wrong exit status %d; want 0\nstderr: %s", code, ui.ErrorWriter.String())
+	}
+
+	var got struct {
+		FormatVersion string `json:"format_version"`
+		Variables     map[string]struct {
+			Type        json.RawMessage `json:"type"`
+			Default     json.RawMessage `json:"default"`
+			Required    bool            `json:"required"`
+			Description string          `json:"description"`
+			Validations []struct {
+				Condition    string `json:"condition"`
+				ErrorMessage string `json:"error_message"`
+			} `json:"validations"`
+		} `json:"variables"`
+		Outputs map[string]struct {
+			Description string `json:"description"`
+		} `json:"outputs"`
+		RequiredProviders map[string]struct {
+			Source             string   `json:"source"`
+			VersionConstraints []string `json:"version_constraints"`
+		} `json:"required_providers"`
+		ManagedResources []struct {
+			Address  string `json:"address"`
+			Provider string `json:"provider"`
+		} `json:"managed_resources"`
+		DataResources []struct {
+			Address string `json:"address"`
+		} `json:"data_resources"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, ui.OutputWriter.String())
+	}
+
+	if got.FormatVersion != "1.0" {
+		t.Errorf("wrong format version %q", got.FormatVersion)
+	}
+
+	cidr := got.Variables["cidr"]
+	if !cidr.Required || string(cidr.Type) != `"string"` || cidr.Description != "The CIDR block of the VPC." {
+		t.Errorf("wrong cidr variable: %#v", cidr)
+	}
+	if len(cidr.Validations) != 1 || cidr.Validations[0].Condition != "can(cidrhost(var.cidr, 0))" || cidr.Validations[0].ErrorMessage != `"Must be a valid CIDR block."` {
+		t.Errorf("wrong cidr validations: %#v", cidr.Validations)
+	}
+	tags := got.Variables["tags"]
+	if tags.Required || string(tags.Type) != `["map","string"]` || string(tags.Default) != `{}` {
+		t.Errorf("wrong tags variable: %#v", tags)
+	}
+
+	if got.Outputs["id"].Description != "The ID of the VPC." {
+		t.Errorf("wrong outputs: %#v", got.Outputs)
+	}
+
+	aws := got.RequiredProviders["aws"]
+	if diff := cmp.Diff([]string{">= 5.0"}, aws.VersionConstraints); aws.Source != "registry.opentofu.org/hashicorp/aws" || diff != "" {
+		t.Errorf("wrong aws provider requirement: %#v", aws)
+	}
+
+	if len(got.ManagedResources) != 1 || got.ManagedResources[0].Address != "aws_vpc.this" || got.ManagedResources[0].Provider != "registry.opentofu.org/hashicorp/aws" {
+		t.Errorf("wrong managed resources: %#v", got.ManagedResources)
+	}
+	if len(got.DataResources) != 1 || got.DataResources[0].Address != "data.aws_region.current" {
+		t.Errorf("wrong data resources: %#v", got.DataResources)
+	}
+}
+
+func TestModulesSchema_requiresJSON(t *testing.T) {
+	ui := cli.NewMockUi()
+	c := &ModulesSchemaCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("wrong exit status %d; want 1", code)
+	}
+}
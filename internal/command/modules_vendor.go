@@ -0,0 +1,207 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/getmodules"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ModulesCommand is a Command implementation that just shows help for the
+// subcommands nested below it.
+type ModulesCommand struct {
+	Meta
+}
+
+func (c *ModulesCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *ModulesCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] modules <subcommand> [options]
+
+  This command has subcommands for managing the modules that the
+  configuration in the current working directory depends on.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModulesCommand) Synopsis() string {
+	return "Manage the modules the configuration depends on"
+}
+
+// ModulesVendorCommand is a Command implementation that implements the
+// "tofu modules vendor" command, which saves copies of all of the remote
+// module packages the configuration depends on alongside the configuration,
+// so that the modules can be installed without network access.
+type ModulesVendorCommand struct {
+	Meta
+}
+
+func (c *ModulesVendorCommand) Run(args []string) int {
+	var testsDirectory string
+
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("modules vendor")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.StringVar(&testsDirectory, "test-directory", "tests", "test-directory")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	// Installation steps can be cancelled by SIGINT and similar.
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	path, err := modulePath(cmdFlags.Args())
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	path = c.normalizePath(path)
+
+	vendor, diags := c.vendorModules(ctx, path, testsDirectory)
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+
+	if vendor.Len() == 0 {
+		c.Ui.Output(fmt.Sprintf("\nThe configuration has no remote modules, so %s is empty.", getmodules.VendorDir))
+		return 0
+	}
+	c.Ui.Output(fmt.Sprintf(
+		"\nVendored %d module packages into %s.\n\n"+
+			"\"tofu init\" and \"tofu get\" will now install these modules from the vendored\n"+
+			"copies instead of downloading them. Run this command again after changing\n"+
+			"the source or version of any module.",
+		vendor.Len(), getmodules.VendorDir,
+	))
+	return 0
+}
+
+// vendorModules installs all of the modules that the configuration in the
+// given directory depends on into a temporary directory, and replaces the
+// configuration's vendor directory with copies of the remote packages that
+// were fetched.
+func (c *ModulesVendorCommand) vendorModules(ctx context.Context, rootDir, testsDir string) (*getmodules.Vendor, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	loader, err := c.initConfigLoader()
+	if err != nil {
+		diags = diags.Append(err)
+		return nil, diags
+	}
+	call, vDiags := c.rootModuleCall(rootDir)
+	diags = diags.Append(vDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	// Installing into an empty modules directory, rather than the usual
+	// one, makes the installer fetch every package rather than reusing the
+	// modules that are already installed.
+	modsDir, err := os.MkdirTemp("", "tofu-modules-vendor")
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to create temporary modules directory: %w", err))
+		return nil, diags
+	}
+	defer os.RemoveAll(modsDir)
+
+	// The new vendor directory is populated alongside the old one, which
+	// stays in place until the new one is complete.
+	vendorDir := filepath.Join(rootDir, getmodules.VendorDir)
+	newVendorDir := vendorDir + ".new"
+	if err := os.RemoveAll(newVendorDir); err != nil {
+		diags = diags.Append(fmt.Errorf("failed to remove %s: %w", newVendorDir, err))
+		return nil, diags
+	}
+	vendor := getmodules.NewVendor(newVendorDir)
+
+	inst := initwd.NewModuleInstaller(modsDir, loader, c.registryClient())
+	inst.VendorInto(vendor)
+	hooks := uiModuleInstallHooks{
+		Ui: c.Ui,
+	}
+	_, moreDiags := inst.InstallModules(ctx, rootDir, testsDir, false, true, hooks, call)
+	diags = diags.Append(moreDiags)
+	if ctx.Err() == context.Canceled {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Module vendoring was interrupted",
+			"Module vendoring was canceled by an interrupt signal, so the vendored modules were not changed.",
+		))
+	}
+	if diags.HasErrors() {
+		os.RemoveAll(newVendorDir)
+		return nil, diags
+	}
+
+	if err := vendor.Save(); err != nil {
+		os.RemoveAll(newVendorDir)
+		diags = diags.Append(fmt.Errorf("failed to write the vendored modules manifest: %w", err))
+		return nil, diags
+	}
+	if err := os.RemoveAll(vendorDir); err != nil {
+		diags = diags.Append(fmt.Errorf("failed to remove the previously vendored modules from %s: %w", vendorDir, err))
+		return nil, diags
+	}
+	if err := os.Rename(newVendorDir, vendorDir); err != nil {
+		diags = diags.Append(fmt.Errorf("failed to move the vendored modules from %s to %s: %w", newVendorDir, vendorDir, err))
+		return nil, diags
+	}
+	return vendor, diags
+}
+
+func (c *ModulesVendorCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] modules vendor [options]
+
+  Saves copies of all of the remote modules that the configuration in the
+  current working directory depends on, including those of any nested
+  modules, in the vendor/modules directory.
+
+  Once the modules are vendored, "tofu init" and "tofu get" install them
+  from the vendored copies instead of downloading them, without needing
+  to change any module source addresses. Modules from a module registry
+  use the vendored version, without contacting the registry. This allows
+  initializing the configuration without network access, for example for
+  hermetic builds.
+
+  Each run replaces any previously vendored modules, so run this command
+  again after changing the source or version of any module.
+
+Options:
+
+  -test-directory=path  Set the OpenTofu test directory, defaults to "tests".
+
+  -var 'foo=bar'        Set a value for one of the input variables in the root
+                        module of the configuration. Use this option more than
+                        once to set more than one variable.
+
+  -var-file=filename    Load variable values from the given file, in addition
+                        to the default files terraform.tfvars and *.auto.tfvars.
+                        Use this option more than once to include more than one
+                        variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModulesVendorCommand) Synopsis() string {
+	return "Save local copies of all remote modules"
+}
@@ -0,0 +1,70 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestModulesVendor(t *testing.T) {
+	// An absolute path is a remote module source, which the installer
+	// "downloads" by copying it.
+	moduleDir := filepath.Join(t.TempDir(), "network")
+	if err := os.MkdirAll(moduleDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "main.tf"), []byte("output \"name\" {\n  value = \"network\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd := tempWorkingDir(t)
+	defer testChdir(t, wd.RootModuleDir())()
+	config := fmt.Sprintf("module \"network\" {\n  source = %q\n}\n", filepath.ToSlash(moduleDir))
+	if err := os.WriteFile("main.tf", []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	vendorCmd := &ModulesVendorCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			WorkingDir:       wd,
+		},
+	}
+	if code := vendorCmd.Run(nil); code != 0 {
+		t.Fatalf("vendor failed: \n%s", ui.ErrorWriter.String())
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, "Vendored 1 module packages") {
+		t.Fatalf("wrong output:\n%s", output)
+	}
+	if _, err := os.Stat(filepath.Join("vendor", "modules", "modules.json")); err != nil {
+		t.Fatalf("no vendored modules manifest: %s", err)
+	}
+
+	// Once vendored, the module installs even though its source is gone.
+	if err := os.RemoveAll(moduleDir); err != nil {
+		t.Fatal(err)
+	}
+	ui = cli.NewMockUi()
+	getCmd := &GetCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+			WorkingDir:       wd,
+		},
+	}
+	if code := getCmd.Run(nil); code != 0 {
+		t.Fatalf("get failed: \n%s", ui.ErrorWriter.String())
+	}
+	if _, err := os.Stat(filepath.Join(".terraform", "modules", "network", "main.tf")); err != nil {
+		t.Fatalf("module was not installed from the vendor directory: %s", err)
+	}
+}
@@ -39,6 +39,8 @@ func (c *PlanCommand) Run(rawArgs []string) int {
 	args, diags := arguments.ParsePlan(rawArgs)
 
 	c.View.SetShowSensitive(args.ShowSensitive)
+	c.View.SetExpandCollapsed(args.ExpandCollapsed)
+	c.View.SetProgressTUI(args.TUI)
 
 	// Instantiate the view, even if there are flag errors, so that we render
 	// diagnostics according to the desired view
@@ -169,7 +171,10 @@ func (c *PlanCommand) OperationRequest(
 	opReq.GenerateConfigOut = generateConfigOut
 	opReq.Targets = args.Targets
 	opReq.Excludes = args.Excludes
+	opReq.TargetProviders = args.TargetProviders
 	opReq.ForceReplace = args.ForceReplace
+	opReq.ReplaceDependents = args.ReplaceDependents
+	opReq.NoInputSummary = args.NoInputSummary
 	opReq.Type = backend.OperationTypePlan
 	opReq.View = view.Operation()
 
@@ -237,6 +242,10 @@ Plan Customization Options:
                       OpenTofu will plan to replace it instead. You can use
                       this option multiple times to replace more than one object.
 
+  -replace-dependents Together with -replace, also replace any resource
+                      instances with arguments derived from the ones being
+                      replaced that can't be updated in-place.
+
   -target=resource    Limit the planning operation to only the given module,
                       resource, or resource instance and all of its
                       dependencies. You can use this option multiple times to
@@ -250,6 +259,14 @@ Plan Customization Options:
                       This is for exceptional use only. Cannot be used alongside
                       the -target flag
 
+  -target-provider=aws.us_east_1
+                      Limit the planning operation to the resources that use
+                      the given provider configuration and all of their
+                      dependencies. You can use this option multiple times to
+                      include more than one provider configuration. This is for
+                      exceptional use only. Cannot be used alongside the
+                      -exclude flag
+
   -var 'foo=bar'      Set a value for one of the input variables in the root
                       module of the configuration. Use this option more than
                       once to set more than one variable.
@@ -288,6 +305,9 @@ Other Options:
 
   -input=true                Ask for input for variables if not directly set.
 
+  -no-input-summary          Don't list all of the missing variables before
+                             asking for their values.
+
   -lock=false                Don't hold a state lock during the operation. This
                              is dangerous if others might concurrently run
                              commands against the same workspace.
@@ -311,6 +331,15 @@ Other Options:
 
   -show-sensitive            If specified, sensitive values will be displayed.
 
+  -expand-collapsed          Show in full the changes to attributes that the
+                             collapse_attributes CLI configuration setting
+                             would otherwise collapse.
+
+  -ui=tui                    Show the progress of the operation as a live table
+                             of the resource instances being worked on, instead
+                             of a message for each step. Has no effect unless
+                             the output is a terminal.
+
   -json                      Produce output in a machine-readable JSON format, 
                              suitable for use in text editor integrations and 
                              other automated systems. Always disables color.
@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	tfversion "github.com/opentofu/opentofu/version"
+)
+
+// ProjectFilename is the name of the optional project file in a root module
+// directory, which sets defaults for the commands run in that directory.
+const ProjectFilename = ".opentofu.hcl"
+
+// Project represents the settings in a project file.
+//
+// Relative paths in the settings are relative to the directory containing
+// the project file, which is always the current working directory.
+type Project struct {
+	// VarFiles are variable definitions files that are loaded before any
+	// given with -var-file options.
+	VarFiles []string
+
+	// BackendConfig are the -backend-config values "tofu init" uses when
+	// none are given on the command line.
+	BackendConfig []string
+
+	// RequiredVersion, if not nil, constrains the OpenTofu versions that may
+	// be used in this directory.
+	RequiredVersion      version.Constraints
+	RequiredVersionRange hcl.Range
+
+	// PluginCacheDir is the plugin cache directory to use when none is set
+	// in the CLI configuration.
+	PluginCacheDir string
+
+	// LimitChanges and LimitDestroys, if not nil, are the defaults for the
+	// -limit-changes and -limit-destroys options of "tofu apply" and
+	// "tofu destroy".
+	LimitChanges  *int
+	LimitDestroys *int
+}
+
+var projectSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "var_files"},
+		{Name: "backend_config"},
+		{Name: "required_version"},
+		{Name: "plugin_cache_dir"},
+		{Name: "limit_changes"},
+		{Name: "limit_destroys"},
+	},
+}
+
+// LoadProject loads the project file in the given directory, returning nil
+// if there isn't one.
+func LoadProject(dir string) (*Project, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	filename := filepath.Join(dir, ProjectFilename)
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		return nil, diags
+	}
+
+	parser := hclparse.NewParser()
+	f, hclDiags := parser.ParseHCLFile(filename)
+	diags = diags.Append(hclDiags)
+	if f == nil || hclDiags.HasErrors() {
+		return nil, diags
+	}
+	content, hclDiags := f.Body.Content(projectSchema)
+	diags = diags.Append(hclDiags)
+
+	ret := &Project{}
+	if attr, ok := content.Attributes["var_files"]; ok {
+		diags = diags.Append(gohcl.DecodeExpression(attr.Expr, nil, &ret.VarFiles))
+	}
+	if attr, ok := content.Attributes["backend_config"]; ok {
+		diags = diags.Append(gohcl.DecodeExpression(attr.Expr, nil, &ret.BackendConfig))
+	}
+	if attr, ok := content.Attributes["plugin_cache_dir"]; ok {
+		hclDiags := gohcl.DecodeExpression(attr.Expr, nil, &ret.PluginCacheDir)
+		diags = diags.Append(hclDiags)
+		if !hclDiags.HasErrors() {
+			ret.PluginCacheDir = os.ExpandEnv(ret.PluginCacheDir)
+		}
+	}
+	if attr, ok := content.Attributes["limit_changes"]; ok {
+		ret.LimitChanges, diags = decodeProjectLimit(attr, diags)
+	}
+	if attr, ok := content.Attributes["limit_destroys"]; ok {
+		ret.LimitDestroys, diags = decodeProjectLimit(attr, diags)
+	}
+	if attr, ok := content.Attributes["required_version"]; ok {
+		var raw string
+		hclDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = diags.Append(hclDiags)
+		if !hclDiags.HasErrors() {
+			constraints, err := version.NewConstraint(raw)
+			if err != nil {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid version constraint",
+					Detail:   fmt.Sprintf("The required_version argument must be a valid version constraint: %s.", err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				ret.RequiredVersion = constraints
+				ret.RequiredVersionRange = attr.Expr.Range()
+			}
+		}
+	}
+
+	return ret, diags
+}
+
+// decodeProjectLimit decodes the value of a change limit attribute, which
+// must be a whole number that is zero or greater.
+func decodeProjectLimit(attr *hcl.Attribute, diags tfdiags.Diagnostics) (*int, tfdiags.Diagnostics) {
+	var limit int
+	hclDiags := gohcl.DecodeExpression(attr.Expr, nil, &limit)
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return nil, diags
+	}
+	if limit < 0 {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid change limit",
+			Detail:   fmt.Sprintf("The %s argument must be a number that is zero or greater.", attr.Name),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+		return nil, diags
+	}
+	return &limit, diags
+}
+
+// CheckVersion returns an error if the running version of OpenTofu doesn't
+// meet the project's version constraint.
+func (p *Project) CheckVersion() tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if p == nil || p.RequiredVersion == nil {
+		return diags
+	}
+	if !p.RequiredVersion.Check(tfversion.SemVer) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported OpenTofu version",
+			Detail: fmt.Sprintf(
+				"The project file %s requires OpenTofu %s, but this is OpenTofu v%s. Use a supported version of OpenTofu to work in this directory.\n\n%s",
+				ProjectFilename, p.RequiredVersion, tfversion.SemVer, configs.CoreVersionRemediation,
+			),
+			Subject: p.RequiredVersionRange.Ptr(),
+		})
+	}
+	return diags
+}
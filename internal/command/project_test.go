@@ -0,0 +1,144 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+func TestLoadProject(t *testing.T) {
+	t.Run("no project file", func(t *testing.T) {
+		project, diags := LoadProject(t.TempDir())
+		if len(diags) != 0 {
+			t.Fatal(diags.Err())
+		}
+		if project != nil {
+			t.Errorf("unexpected project %#v", project)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("TEST_CACHE_ROOT", "/var/cache")
+		writeProjectFile(t, dir, `
+var_files        = ["common.tfvars", "prod.tfvars"]
+backend_config   = ["backend.hcl", "key=prod.tfstate"]
+required_version = ">= 1.6.0"
+plugin_cache_dir = "$TEST_CACHE_ROOT/opentofu"
+limit_destroys   = 0
+`)
+
+		project, diags := LoadProject(dir)
+		if len(diags) != 0 {
+			t.Fatal(diags.Err())
+		}
+		if want := []string{"common.tfvars", "prod.tfvars"}; !reflect.DeepEqual(project.VarFiles, want) {
+			t.Errorf("wrong var files %#v", project.VarFiles)
+		}
+		if want := []string{"backend.hcl", "key=prod.tfstate"}; !reflect.DeepEqual(project.BackendConfig, want) {
+			t.Errorf("wrong backend config %#v", project.BackendConfig)
+		}
+		if got := project.RequiredVersion.String(); got != ">= 1.6.0" {
+			t.Errorf("wrong required version %q", got)
+		}
+		if got := project.PluginCacheDir; got != "/var/cache/opentofu" {
+			t.Errorf("wrong plugin cache dir %q", got)
+		}
+		if project.LimitChanges != nil {
+			t.Errorf("unexpected change limit %d", *project.LimitChanges)
+		}
+		if project.LimitDestroys == nil || *project.LimitDestroys != 0 {
+			t.Errorf("wrong destroy limit %v", project.LimitDestroys)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFile(t, dir, `
+var_files        = "common.tfvars"
+required_version = "latest"
+limit_changes    = -1
+unknown          = true
+`)
+
+		_, diags := LoadProject(dir)
+		if got, want := len(diags), 4; got != want {
+			t.Fatalf("wrong number of diagnostics %d; want %d\n%s", got, want, diags.ErrWithWarnings())
+		}
+		if got := diags.Err().Error(); !strings.Contains(got, "Invalid version constraint") {
+			t.Errorf("wrong error: %s", got)
+		}
+	})
+}
+
+func TestProjectCheckVersion(t *testing.T) {
+	var nilProject *Project
+	if diags := nilProject.CheckVersion(); diags.HasErrors() {
+		t.Fatalf("unexpected error for nil project: %s", diags.Err())
+	}
+
+	project := &Project{
+		RequiredVersion: version.MustConstraints(version.NewConstraint("< 0.1.0")),
+	}
+	diags := project.CheckVersion()
+	if !diags.HasErrors() {
+		t.Fatal("expected error")
+	}
+	if got := diags.Err().Error(); !strings.Contains(got, "Unsupported OpenTofu version") {
+		t.Errorf("wrong error: %s", got)
+	}
+}
+
+func TestProjectVarFiles(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+
+	if err := os.WriteFile("common.tfvars", []byte("region = \"eu-west-1\"\nsize = \"small\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("override.tfvars", []byte("size = \"large\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := &Meta{
+		Project: &Project{VarFiles: []string{"common.tfvars"}},
+	}
+	meta.variableArgs = newRawFlags("-var")
+	meta.variableArgs.Alias("-var-file").Set("override.tfvars")
+
+	values, diags := meta.collectVariableValues()
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	for name, want := range map[string]string{"region": "eu-west-1", "size": "large"} {
+		value, ok := values[name]
+		if !ok {
+			t.Errorf("missing value for %s", name)
+			continue
+		}
+		got, moreDiags := value.ParseVariableValue(configs.VariableParseHCL)
+		if moreDiags.HasErrors() {
+			t.Fatal(moreDiags.Err())
+		}
+		if !got.Value.RawEquals(cty.StringVal(want)) {
+			t.Errorf("wrong value for %s: %#v, want %q", name, got.Value, want)
+		}
+	}
+}
+
+func writeProjectFile(t *testing.T, dir, src string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ProjectFilename), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
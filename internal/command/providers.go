@@ -6,14 +6,21 @@
 package command
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	svchost "github.com/hashicorp/terraform-svchost"
 	"github.com/xlab/treeprint"
 
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonprovidertree"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/registry/response"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -33,17 +40,26 @@ func (c *ProvidersCommand) Synopsis() string {
 
 func (c *ProvidersCommand) Run(args []string) int {
 	var testsDirectory string
+	var jsonOutput bool
+	var checkDeprecations bool
 
 	args = c.Meta.process(args)
 	cmdFlags := c.Meta.defaultFlagSet("providers")
 	c.Meta.varFlagSet(cmdFlags)
 	cmdFlags.StringVar(&testsDirectory, "test-directory", "tests", "test-directory")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "produce JSON output")
+	cmdFlags.BoolVar(&checkDeprecations, "check-deprecations", false, "check-deprecations")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
 		return 1
 	}
 
+	if checkDeprecations && !jsonOutput {
+		c.Ui.Error("The -check-deprecations option can only be used with -json.")
+		return 1
+	}
+
 	configPath, err := modulePath(cmdFlags.Args())
 	if err != nil {
 		c.Ui.Error(err.Error())
@@ -133,6 +149,21 @@ func (c *ProvidersCommand) Run(args []string) int {
 		stateReqs = state.ProviderRequirements()
 	}
 
+	if jsonOutput {
+		c.showDiagnostics(diags)
+		var deprecations map[addrs.Provider]*response.Deprecation
+		if checkDeprecations {
+			deprecations = c.providerDeprecations(reqs, stateReqs)
+		}
+		out, err := jsonprovidertree.Marshal(config, reqs, stateReqs, deprecations)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to marshal provider tree to JSON: %s", err))
+			return 1
+		}
+		c.Ui.Output(string(out))
+		return 0
+	}
+
 	printRoot := treeprint.New()
 	c.populateTreeNode(printRoot, reqs)
 
@@ -153,6 +184,65 @@ func (c *ProvidersCommand) Run(args []string) int {
 	return 0
 }
 
+// providerDeprecations asks the registries of the given providers whether
+// they are deprecated. The lookup is best-effort: a provider whose registry
+// can't be reached is reported as not deprecated, so that the command still
+// works offline. After the first failure other than an unknown provider, the
+// other providers of the same registry are skipped, so that an unreachable
+// registry is only waited for once.
+func (c *ProvidersCommand) providerDeprecations(reqs *configs.ModuleRequirements, stateReqs getproviders.Requirements) map[addrs.Provider]*response.Deprecation {
+	if c.Services == nil {
+		return nil
+	}
+
+	seen := make(map[addrs.Provider]bool)
+	var walk func(node *configs.ModuleRequirements)
+	walk = func(node *configs.ModuleRequirements) {
+		for provider := range node.Requirements {
+			seen[provider] = true
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(reqs)
+	for provider := range stateReqs {
+		seen[provider] = true
+	}
+	providers := make([]addrs.Provider, 0, len(seen))
+	for provider := range seen {
+		if provider.IsBuiltIn() || provider.IsLegacy() {
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].LessThan(providers[j])
+	})
+
+	source := getproviders.NewRegistrySource(c.Services)
+	ret := make(map[addrs.Provider]*response.Deprecation)
+	failedHosts := make(map[svchost.Hostname]bool)
+	for _, provider := range providers {
+		if failedHosts[provider.Hostname] {
+			continue
+		}
+		deprecation, err := source.ProviderDeprecation(c.CommandContext(), provider)
+		if err != nil {
+			log.Printf("[DEBUG] providers: failed to check whether %s is deprecated: %s", provider, err)
+			var notKnown getproviders.ErrRegistryProviderNotKnown
+			if !errors.As(err, &notKnown) {
+				failedHosts[provider.Hostname] = true
+			}
+			continue
+		}
+		if deprecation != nil {
+			ret[provider] = deprecation
+		}
+	}
+	return ret
+}
+
 func (c *ProvidersCommand) populateTreeNode(tree treeprint.Tree, node *configs.ModuleRequirements) {
 	for fqn, dep := range node.Requirements {
 		versionsStr := getproviders.VersionConstraintsString(dep)
@@ -197,6 +287,16 @@ Usage: tofu [global options] providers [options] [DIR]
 
 Options:
 
+  -json                 Produce JSON output describing the provider
+                        requirements of each module and which provider
+                        configuration each module uses for each of its local
+                        provider names. Test files are not included.
+
+  -check-deprecations   With -json, also ask the registry of each provider
+                        whether it is deprecated, and include the
+                        deprecations in the output. Registries that can't be
+                        reached are skipped.
+
   -test-directory=path  Set the OpenTofu test directory, defaults to "tests". When set, the
                         test command will search for test files in the current directory and
                         in the one specified by the flag.
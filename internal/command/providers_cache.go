@@ -0,0 +1,195 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersCacheCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type ProvidersCacheCommand struct {
+	Meta
+}
+
+func (c *ProvidersCacheCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *ProvidersCacheCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] providers cache <subcommand> [options]
+
+  This command has subcommands for maintaining the global provider plugin
+  cache directory configured by the plugin_cache_dir CLI configuration
+  setting or the TF_PLUGIN_CACHE_DIR environment variable.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ProvidersCacheCommand) Synopsis() string {
+	return "Maintain the global provider plugin cache"
+}
+
+// ProvidersCacheVerifyCommand is a Command implementation that checks the
+// packages in the global provider plugin cache for corruption.
+type ProvidersCacheVerifyCommand struct {
+	Meta
+}
+
+func (c *ProvidersCacheVerifyCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("providers cache verify")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	cacheDir := c.providerGlobalCacheDir()
+	if cacheDir == nil {
+		diags = diags.Append(errNoPluginCacheDir)
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	problems, err := cacheDir.Verify()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to verify provider plugin cache",
+			fmt.Sprintf("Could not verify the provider plugin cache at %s: %s.", cacheDir.BasePath(), err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if len(problems) == 0 {
+		c.Ui.Output(fmt.Sprintf("All packages in the provider plugin cache at %s are valid.", cacheDir.BasePath()))
+		return 0
+	}
+
+	var buf strings.Builder
+	for _, problem := range problems {
+		fmt.Fprintf(&buf, "\n  - %s v%s: %s", problem.Entry.Provider.ForDisplay(), problem.Entry.Version, problem.Message)
+	}
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Invalid packages in provider plugin cache",
+		fmt.Sprintf("The provider plugin cache at %s contains packages that may have been corrupted or modified:%s\n\nDelete the affected package directories and then run \"tofu init\" again to download fresh copies.", cacheDir.BasePath(), buf.String()),
+	))
+	c.showDiagnostics(diags)
+	return 1
+}
+
+func (c *ProvidersCacheVerifyCommand) Help() string {
+	return `
+Usage: tofu [global options] providers cache verify
+
+  Checks every package for the current platform in the global provider plugin
+  cache directory, reporting any package whose executable is missing or whose
+  contents no longer match the checksum recorded when it was added to the
+  cache.
+
+  Packages added to the cache by earlier versions of OpenTofu have no
+  recorded checksum, and so only their executable is checked.
+`
+}
+
+func (c *ProvidersCacheVerifyCommand) Synopsis() string {
+	return "Check the global provider plugin cache for corrupted packages"
+}
+
+// ProvidersCacheGCCommand is a Command implementation that removes packages
+// from the global provider plugin cache that haven't been used recently.
+type ProvidersCacheGCCommand struct {
+	Meta
+}
+
+func (c *ProvidersCacheGCCommand) Run(args []string) int {
+	var maxAgeDays int
+
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("providers cache gc")
+	cmdFlags.IntVar(&maxAgeDays, "max-age", 30, "max-age")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	if maxAgeDays < 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid maximum age",
+			"The -max-age option must be a non-negative number of days.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	cacheDir := c.providerGlobalCacheDir()
+	if cacheDir == nil {
+		diags = diags.Append(errNoPluginCacheDir)
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	removed, err := cacheDir.RemoveUnused(time.Duration(maxAgeDays)*24*time.Hour, time.Now())
+	for _, entry := range removed {
+		c.Ui.Output(fmt.Sprintf("- Removed %s v%s", entry.Provider.ForDisplay(), entry.Version))
+	}
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to clean provider plugin cache",
+			fmt.Sprintf("Could not remove unused packages from the provider plugin cache at %s: %s.", cacheDir.BasePath(), err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if len(removed) == 0 {
+		c.Ui.Output(fmt.Sprintf("No packages in the provider plugin cache at %s were unused for more than %d days.", cacheDir.BasePath(), maxAgeDays))
+	}
+	return 0
+}
+
+func (c *ProvidersCacheGCCommand) Help() string {
+	return `
+Usage: tofu [global options] providers cache gc [options]
+
+  Removes packages for the current platform from the global provider plugin
+  cache directory that have not been used by "tofu init" recently.
+
+  OpenTofu records each time "tofu init" selects a package from the cache.
+  Packages added to the cache by earlier versions of OpenTofu have no usage
+  records, and so the modification time of their package directory is used
+  instead.
+
+Options:
+
+  -max-age=days  Remove packages that have not been used for this many
+                 days. Defaults to 30.
+`
+}
+
+func (c *ProvidersCacheGCCommand) Synopsis() string {
+	return "Remove unused packages from the global provider plugin cache"
+}
+
+var errNoPluginCacheDir = tfdiags.Sourceless(
+	tfdiags.Error,
+	"No provider plugin cache directory",
+	"There is no global provider plugin cache directory configured. Set plugin_cache_dir in the CLI configuration or the TF_PLUGIN_CACHE_DIR environment variable to use a plugin cache.",
+)
@@ -6,9 +6,12 @@
 package command
 
 import (
+	"bufio"
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/depsfile"
@@ -43,9 +46,11 @@ func (c *ProvidersLockCommand) Run(args []string) int {
 	cmdFlags := c.Meta.defaultFlagSet("providers lock")
 	c.Meta.varFlagSet(cmdFlags)
 	var optPlatforms FlagStringSlice
+	var platformsFile string
 	var fsMirrorDir string
 	var netMirrorURL string
 	cmdFlags.Var(&optPlatforms, "platform", "target platform")
+	cmdFlags.StringVar(&platformsFile, "platforms-file", "", "file listing target platforms")
 	cmdFlags.StringVar(&fsMirrorDir, "fs-mirror", "", "filesystem mirror directory")
 	cmdFlags.StringVar(&netMirrorURL, "net-mirror", "", "network mirror base URL")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
@@ -68,24 +73,8 @@ func (c *ProvidersLockCommand) Run(args []string) int {
 
 	providerStrs := cmdFlags.Args()
 
-	var platforms []getproviders.Platform
-	if len(optPlatforms) == 0 {
-		platforms = []getproviders.Platform{getproviders.CurrentPlatform}
-	} else {
-		platforms = make([]getproviders.Platform, 0, len(optPlatforms))
-		for _, platformStr := range optPlatforms {
-			platform, err := getproviders.ParsePlatform(platformStr)
-			if err != nil {
-				diags = diags.Append(tfdiags.Sourceless(
-					tfdiags.Error,
-					"Invalid target platform",
-					fmt.Sprintf("The string %q given in the -platform option is not a valid target platform: %s.", platformStr, err),
-				))
-				continue
-			}
-			platforms = append(platforms, platform)
-		}
-	}
+	platforms, moreDiags := providersLockPlatforms(optPlatforms, platformsFile)
+	diags = diags.Append(moreDiags)
 
 	// Installation steps can be cancelled by SIGINT and similar.
 	ctx, done := c.InterruptibleContext(c.CommandContext())
@@ -331,6 +320,18 @@ func (c *ProvidersLockCommand) Run(args []string) int {
 		newLocks.SetProvider(provider, version, constraints, hashes)
 	}
 
+	// Summarize which hash schemes each provider's lock entry now covers,
+	// because a lock entry without any "zh:" checksums can only verify
+	// packages for the platforms that were explicitly requested.
+	c.Ui.Output("\nChecksum schemes recorded in the lock file:")
+	for _, provider := range providersLockSortedProviders(reqs) {
+		lock := newLocks.Provider(provider)
+		if lock == nil {
+			continue
+		}
+		c.Ui.Output(fmt.Sprintf("- %s %s: %s", provider.ForDisplay(), lock.Version(), providersLockHashSchemeSummary(lock.AllHashes())))
+	}
+
 	moreDiags = c.replaceLockedDependencies(newLocks)
 	diags = diags.Append(moreDiags)
 
@@ -405,6 +406,15 @@ Options:
                      CPU. Each provider is available only for a limited
                      set of target platforms.
 
+                     Use "all-common" to select linux_amd64, linux_arm64,
+                     darwin_amd64, darwin_arm64, windows_amd64 and
+                     windows_arm64 at once.
+
+  -platforms-file=path Read additional target platforms from the given file,
+                     one per line. Blank lines and lines starting with "#"
+                     are ignored, and "all-common" is accepted as in the
+                     -platform option.
+
   -var 'foo=bar'     Set a value for one of the input variables in the root
                      module of the configuration. Use this option more than
                      once to set more than one variable.
@@ -440,3 +450,133 @@ func providersLockCalculateChangeType(oldLock *depsfile.ProviderLock, platformLo
 	}
 	return providersLockChangeTypeNewHashes
 }
+
+// providersLockAllCommonPlatforms are the platforms selected by the
+// "all-common" alias accepted by the -platform option and in platforms files.
+var providersLockAllCommonPlatforms = []getproviders.Platform{
+	{OS: "linux", Arch: "amd64"},
+	{OS: "linux", Arch: "arm64"},
+	{OS: "darwin", Arch: "amd64"},
+	{OS: "darwin", Arch: "arm64"},
+	{OS: "windows", Arch: "amd64"},
+	{OS: "windows", Arch: "arm64"},
+}
+
+const providersLockAllCommonAlias = "all-common"
+
+// providersLockPlatforms returns the target platforms selected by the
+// -platform and -platforms-file options, expanding any "all-common" aliases
+// and removing duplicates. If neither option is set, it returns only the
+// current platform.
+func providersLockPlatforms(optPlatforms []string, platformsFile string) ([]getproviders.Platform, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	type platformOption struct {
+		str    string
+		source string
+	}
+	var opts []platformOption
+	for _, platformStr := range optPlatforms {
+		opts = append(opts, platformOption{platformStr, "given in the -platform option"})
+	}
+	if platformsFile != "" {
+		f, err := os.Open(platformsFile)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to read platforms file",
+				fmt.Sprintf("Could not read the target platforms from %s: %s.", platformsFile, err),
+			))
+			return nil, diags
+		}
+		defer f.Close()
+
+		sc := bufio.NewScanner(f)
+		for line := 1; sc.Scan(); line++ {
+			platformStr := strings.TrimSpace(sc.Text())
+			if platformStr == "" || strings.HasPrefix(platformStr, "#") {
+				continue
+			}
+			opts = append(opts, platformOption{platformStr, fmt.Sprintf("on line %d of %s", line, platformsFile)})
+		}
+		if err := sc.Err(); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to read platforms file",
+				fmt.Sprintf("Could not read the target platforms from %s: %s.", platformsFile, err),
+			))
+			return nil, diags
+		}
+	}
+
+	if len(opts) == 0 {
+		return []getproviders.Platform{getproviders.CurrentPlatform}, diags
+	}
+
+	platforms := make([]getproviders.Platform, 0, len(opts))
+	seen := make(map[getproviders.Platform]struct{})
+	add := func(platform getproviders.Platform) {
+		if _, exists := seen[platform]; exists {
+			return
+		}
+		seen[platform] = struct{}{}
+		platforms = append(platforms, platform)
+	}
+	for _, opt := range opts {
+		if opt.str == providersLockAllCommonAlias {
+			for _, platform := range providersLockAllCommonPlatforms {
+				add(platform)
+			}
+			continue
+		}
+		platform, err := getproviders.ParsePlatform(opt.str)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid target platform",
+				fmt.Sprintf("The string %q %s is not a valid target platform: %s.", opt.str, opt.source, err),
+			))
+			continue
+		}
+		add(platform)
+	}
+	return platforms, diags
+}
+
+func providersLockSortedProviders(reqs getproviders.Requirements) []addrs.Provider {
+	providers := make([]addrs.Provider, 0, len(reqs))
+	for provider := range reqs {
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].LessThan(providers[j])
+	})
+	return providers
+}
+
+// providersLockHashSchemeSummary describes how many of the given hashes use
+// each hash scheme, for the summary printed after updating the lock file.
+func providersLockHashSchemeSummary(hashes []getproviders.Hash) string {
+	var h1, zh, other int
+	for _, hash := range hashes {
+		switch {
+		case strings.HasPrefix(string(hash), string(getproviders.HashScheme1)):
+			h1++
+		case strings.HasPrefix(string(hash), string(getproviders.HashSchemeZip)):
+			zh++
+		default:
+			other++
+		}
+	}
+
+	summary := fmt.Sprintf("%d h1, %d zh", h1, zh)
+	if other != 0 {
+		summary += fmt.Sprintf(", %d other", other)
+	}
+	if zh == 0 {
+		// "zh:" checksums come only from the origin registry's signed
+		// checksums, which cover every platform the provider supports.
+		summary += " (only the selected platforms can be verified)"
+	}
+	return summary
+}
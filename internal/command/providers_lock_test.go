@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/mitchellh/cli"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -260,3 +261,103 @@ func TestProvidersLockCalculateChangeType(t *testing.T) {
 		}
 	})
 }
+
+func TestProvidersLockPlatforms(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		got, diags := providersLockPlatforms(nil, "")
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Err())
+		}
+		if len(got) != 1 || got[0] != getproviders.CurrentPlatform {
+			t.Fatalf("wrong platforms %v", got)
+		}
+	})
+
+	t.Run("all-common", func(t *testing.T) {
+		got, diags := providersLockPlatforms([]string{"linux_amd64", "all-common", "freebsd_amd64"}, "")
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Err())
+		}
+		want := []getproviders.Platform{
+			{OS: "linux", Arch: "amd64"},
+			{OS: "linux", Arch: "arm64"},
+			{OS: "darwin", Arch: "amd64"},
+			{OS: "darwin", Arch: "arm64"},
+			{OS: "windows", Arch: "amd64"},
+			{OS: "windows", Arch: "arm64"},
+			{OS: "freebsd", Arch: "amd64"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("wrong platforms\n%s", diff)
+		}
+	})
+
+	t.Run("platforms file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "platforms")
+		content := "# Workstations\ndarwin_arm64\n\n  windows_amd64  \n# CI\nlinux_amd64\ndarwin_arm64\n"
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, diags := providersLockPlatforms([]string{"linux_arm64"}, filename)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Err())
+		}
+		want := []getproviders.Platform{
+			{OS: "linux", Arch: "arm64"},
+			{OS: "darwin", Arch: "arm64"},
+			{OS: "windows", Arch: "amd64"},
+			{OS: "linux", Arch: "amd64"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("wrong platforms\n%s", diff)
+		}
+	})
+
+	t.Run("invalid platform in file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "platforms")
+		if err := os.WriteFile(filename, []byte("linux_amd64\nnonsense\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, diags := providersLockPlatforms(nil, filename)
+		if !diags.HasErrors() {
+			t.Fatal("expected an error")
+		}
+		if got, want := diags.Err().Error(), "on line 2 of "+filename; !strings.Contains(got, want) {
+			t.Fatalf("error %q does not contain %q", got, want)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, diags := providersLockPlatforms(nil, filepath.Join(t.TempDir(), "missing"))
+		if !diags.HasErrors() {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestProvidersLockHashSchemeSummary(t *testing.T) {
+	tests := map[string]struct {
+		hashes []getproviders.Hash
+		want   string
+	}{
+		"registry": {
+			[]getproviders.Hash{"h1:a", "h1:b", "zh:c", "zh:d", "zh:e"},
+			"2 h1, 3 zh",
+		},
+		"mirror": {
+			[]getproviders.Hash{"h1:a"},
+			"1 h1, 0 zh (only the selected platforms can be verified)",
+		},
+		"other": {
+			[]getproviders.Hash{"h1:a", "zh:b", "x9:c"},
+			"1 h1, 1 zh, 1 other",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := providersLockHashSchemeSummary(test.hashes); got != test.want {
+				t.Fatalf("wrong summary\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
@@ -6,15 +6,19 @@
 package command
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/apparentlymart/go-versions/versions"
 	"github.com/hashicorp/go-getter"
+	"github.com/mitchellh/cli"
 
+	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -37,7 +41,10 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 	cmdFlags := c.Meta.defaultFlagSet("providers mirror")
 	c.Meta.varFlagSet(cmdFlags)
 	var optPlatforms FlagStringSlice
+	var optVerify, optRepair bool
 	cmdFlags.Var(&optPlatforms, "platform", "target platform")
+	cmdFlags.BoolVar(&optVerify, "verify", false, "verify an existing mirror")
+	cmdFlags.BoolVar(&optRepair, "repair", false, "repair an existing mirror")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
@@ -77,6 +84,17 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 		}
 	}
 
+	if optVerify || optRepair {
+		if c.verifyMirror(outputDir, optRepair) {
+			return 1
+		}
+		if !optRepair {
+			return 0
+		}
+		// When repairing, we continue with a normal mirror operation to
+		// replace the archives we just removed and rewrite the indexes.
+	}
+
 	// Installation steps can be cancelled by SIGINT and similar.
 	ctx, done := c.InterruptibleContext(c.CommandContext())
 	defer done()
@@ -115,6 +133,84 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 		getproviders.NewRegistrySource(c.Services),
 	)
 
+	diags = diags.Append(mirrorProviderPackages(ctx, c.Ui, source, reqs, lockedDeps, platforms, outputDir))
+	diags = diags.Append(writeProviderMirrorIndexes(outputDir))
+
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+// verifyMirror checks the existing mirror in outputDir, reporting any
+// problems it finds. If repair is set, any broken files that can be replaced
+// by mirroring again are deleted.
+//
+// The result is true if the command should fail immediately.
+func (c *ProvidersMirrorCommand) verifyMirror(outputDir string, repair bool) bool {
+	var diags tfdiags.Diagnostics
+
+	lockedDeps, lockedDepsDiags := c.Meta.lockedDependencies()
+	diags = diags.Append(lockedDepsDiags)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return true
+	}
+
+	problems, err := verifyProviderMirror(outputDir, lockedDeps)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to verify mirror",
+			fmt.Sprintf("Could not scan the mirror directory %s: %s.", outputDir, err),
+		))
+		c.showDiagnostics(diags)
+		return true
+	}
+	if len(problems) == 0 {
+		c.Ui.Output(fmt.Sprintf("No problems found in the mirror directory %s.", outputDir))
+		return false
+	}
+
+	var buf strings.Builder
+	for _, problem := range problems {
+		fmt.Fprintf(&buf, "\n  - %s: %s", problem.Path, problem.Message)
+		if repair && problem.Remove {
+			if err := os.Remove(problem.Path); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(&buf, " (failed to remove: %s)", err)
+			} else {
+				buf.WriteString(" (removed)")
+			}
+		}
+	}
+	if repair {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Repairing provider mirror",
+			fmt.Sprintf("Found %d problem(s) in the mirror directory %s:%s\n\nOpenTofu will now download any missing packages and regenerate the index files.", len(problems), outputDir, buf.String()),
+		))
+		c.showDiagnostics(diags)
+		return false
+	}
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Provider mirror is inconsistent",
+		fmt.Sprintf("Found %d problem(s) in the mirror directory %s:%s\n\nTo repair the mirror, run this command again with the -repair option.", len(problems), outputDir, buf.String()),
+	))
+	c.showDiagnostics(diags)
+	return true
+}
+
+// mirrorProviderPackages downloads the packages for each of the given
+// provider requirements and target platforms from the given source, placing
+// them into outputDir using the packed filesystem mirror layout.
+//
+// If lockedDeps is not empty then the locked version of each provider is
+// mirrored, rather than the newest version matching its constraints.
+func mirrorProviderPackages(ctx context.Context, ui cli.Ui, source getproviders.Source, reqs getproviders.Requirements, lockedDeps *depsfile.Locks, platforms []getproviders.Platform, outputDir string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
 	// Providers from registries always use HTTP, so we don't need the full
 	// generality of go-getter but it's still handy to use the HTTP getter
 	// as an easy way to download over HTTP into a file on disk.
@@ -141,11 +237,11 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 
 	for provider, constraints := range reqs {
 		if provider.IsBuiltIn() {
-			c.Ui.Output(fmt.Sprintf("- Skipping %s because it is built in to OpenTofu CLI", provider.ForDisplay()))
+			ui.Output(fmt.Sprintf("- Skipping %s because it is built in to OpenTofu CLI", provider.ForDisplay()))
 			continue
 		}
 		constraintsStr := getproviders.VersionConstraintsString(constraints)
-		c.Ui.Output(fmt.Sprintf("- Mirroring %s...", provider.ForDisplay()))
+		ui.Output(fmt.Sprintf("- Mirroring %s...", provider.ForDisplay()))
 		// First we'll look for the latest version that matches the given
 		// constraint, which we'll then try to mirror for each target platform.
 		acceptable := versions.MeetingConstraints(constraints)
@@ -173,14 +269,14 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 				continue
 			}
 			selected = lockedDeps.Provider(provider).Version()
-			c.Ui.Output(fmt.Sprintf("  - Selected v%s to match dependency lock file", selected.String()))
+			ui.Output(fmt.Sprintf("  - Selected v%s to match dependency lock file", selected.String()))
 		} else if len(constraintsStr) > 0 {
-			c.Ui.Output(fmt.Sprintf("  - Selected v%s to meet constraints %s", selected.String(), constraintsStr))
+			ui.Output(fmt.Sprintf("  - Selected v%s to meet constraints %s", selected.String(), constraintsStr))
 		} else {
-			c.Ui.Output(fmt.Sprintf("  - Selected v%s with no constraints", selected.String()))
+			ui.Output(fmt.Sprintf("  - Selected v%s with no constraints", selected.String()))
 		}
 		for _, platform := range platforms {
-			c.Ui.Output(fmt.Sprintf("  - Downloading package for %s...", platform.String()))
+			ui.Output(fmt.Sprintf("  - Downloading package for %s...", platform.String()))
 			meta, err := source.PackageMeta(ctx, provider, selected, platform)
 			if err != nil {
 				diags = diags.Append(tfdiags.Sourceless(
@@ -240,7 +336,7 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 					))
 					continue
 				}
-				c.Ui.Output(fmt.Sprintf("  - Package authenticated: %s", result))
+				ui.Output(fmt.Sprintf("  - Package authenticated: %s", result))
 			}
 			os.Remove(targetPath) // okay if it fails because we're going to try to rename over it next anyway
 			err = os.Rename(stagingPath, targetPath)
@@ -255,6 +351,15 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 		}
 	}
 
+	return diags
+}
+
+// writeProviderMirrorIndexes generates or updates the JSON index files for
+// every provider package found in the given packed mirror directory, so that
+// the directory can also be served as a network mirror.
+func writeProviderMirrorIndexes(outputDir string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
 	// Now we'll generate or update the JSON index files in the directory.
 	// We do this by scanning the directory to see what is present, rather than
 	// by relying on the selections we made above, because we want to still
@@ -355,11 +460,7 @@ func (c *ProvidersMirrorCommand) Run(args []string) int {
 		}
 	}
 
-	c.showDiagnostics(diags)
-	if diags.HasErrors() {
-		return 1
-	}
-	return 0
+	return diags
 }
 
 func (c *ProvidersMirrorCommand) Help() string {
@@ -390,6 +491,16 @@ Options:
                      CPU. Each provider is available only for a limited
                      set of target platforms.
 
+  -verify            Instead of updating the mirror, check the existing
+                     contents of the target directory for incomplete or
+                     corrupt packages, packages whose checksums don't match
+                     the dependency lock file, and JSON index files that are
+                     missing entries.
+
+  -repair            Like -verify, but delete any broken packages found and
+                     then update the mirror as normal, which downloads
+                     replacements and regenerates the JSON index files.
+
   -var 'foo=bar'     Set a value for one of the input variables in the root
                      module of the configuration. Use this option more than
                      once to set more than one variable.
@@ -6,10 +6,16 @@
 package command
 
 import (
+	"archive/zip"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/depsfile"
 )
 
 // More thorough tests for providers mirror can be found in the e2etest
@@ -39,3 +45,55 @@ func TestProvidersMirror(t *testing.T) {
 		}
 	})
 }
+
+func TestProvidersMirror_verify(t *testing.T) {
+	dir := t.TempDir()
+	providerDir := filepath.Join(dir, "registry.opentofu.org", "hashicorp", "null")
+	if err := os.MkdirAll(providerDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A valid package that isn't listed in any index file.
+	f, err := os.Create(filepath.Join(providerDir, "terraform-provider-null_2.1.0_linux_amd64.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("terraform-provider-null")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("provider"))
+	zw.Close()
+	f.Close()
+
+	// A truncated package, and a leftover partial download.
+	if err := os.WriteFile(filepath.Join(providerDir, "terraform-provider-null_2.1.0_darwin_arm64.zip"), []byte("PK"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(providerDir, ".terraform-provider-null_2.1.0_windows_amd64.zip"), []byte("PK"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := verifyProviderMirror(dir, depsfile.NewLocks())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	type problem struct {
+		File   string
+		Remove bool
+	}
+	var got []problem
+	for _, p := range problems {
+		got = append(got, problem{filepath.Base(p.Path), p.Remove})
+	}
+	want := []problem{
+		{".terraform-provider-null_2.1.0_windows_amd64.zip", true},
+		{"index.json", false},
+		{"terraform-provider-null_2.1.0_darwin_arm64.zip", true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong problems\n%s", diff)
+	}
+}
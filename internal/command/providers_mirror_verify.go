@@ -0,0 +1,241 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-versions/versions"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// providerMirrorProblem describes a single problem found while verifying an
+// existing provider mirror directory.
+type providerMirrorProblem struct {
+	// Path is the file that the problem relates to.
+	Path string
+
+	// Message is a user-facing description of the problem.
+	Message string
+
+	// Remove is true if the problem can be repaired by deleting the file at
+	// Path and then mirroring the provider again.
+	Remove bool
+}
+
+// verifyProviderMirror checks the packed filesystem mirror in the given
+// directory for common kinds of corruption: leftover partial downloads,
+// archives that are not valid zip files, archives that don't match the
+// checksums recorded in the given dependency locks, and JSON index files that
+// don't describe the archives that are actually present.
+//
+// The result is sorted by path so that it's suitable for direct display.
+func verifyProviderMirror(dir string, locks *depsfile.Locks) ([]providerMirrorProblem, error) {
+	var problems []providerMirrorProblem
+
+	// "tofu providers mirror" downloads each archive to a hidden staging
+	// path before moving it into place, so any remaining staging files are
+	// from an interrupted run.
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasPrefix(d.Name(), ".terraform-provider-") {
+			problems = append(problems, providerMirrorProblem{
+				Path:    path,
+				Message: "incomplete download left by an interrupted mirror operation",
+				Remove:  true,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := getproviders.SearchLocalDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+	for provider, metas := range available {
+		var lockedHashes []getproviders.Hash
+		if lock := locks.Provider(provider); lock != nil && hasZipHash(lock.AllHashes()) {
+			// We can only check archives against locked hashes if there
+			// are "zh:" hashes, which cover all platforms. Otherwise we'd
+			// report false mismatches for platforms other than the ones
+			// where "tofu init" happened to run.
+			lockedHashes = lock.AllHashes()
+		}
+
+		archives := make(map[getproviders.Version]map[getproviders.Platform]getproviders.PackageMeta)
+		for _, meta := range metas {
+			archivePath, ok := meta.Location.(getproviders.PackageLocalArchive)
+			if !ok {
+				continue // only packed archives are relevant to a mirror
+			}
+			if err := checkZipArchive(string(archivePath)); err != nil {
+				problems = append(problems, providerMirrorProblem{
+					Path:    string(archivePath),
+					Message: fmt.Sprintf("not a valid provider package: %s", err),
+					Remove:  true,
+				})
+				continue
+			}
+			lock := locks.Provider(provider)
+			if lockedHashes != nil && lock.Version().Same(meta.Version) {
+				matches, err := meta.MatchesAnyHash(lockedHashes)
+				if err != nil {
+					problems = append(problems, providerMirrorProblem{
+						Path:    string(archivePath),
+						Message: fmt.Sprintf("failed to verify checksum: %s", err),
+						Remove:  true,
+					})
+					continue
+				}
+				if !matches {
+					problems = append(problems, providerMirrorProblem{
+						Path:    string(archivePath),
+						Message: fmt.Sprintf("checksum does not match any of the checksums for %s v%s in the dependency lock file", provider, meta.Version),
+						Remove:  true,
+					})
+					continue
+				}
+			}
+			if archives[meta.Version] == nil {
+				archives[meta.Version] = make(map[getproviders.Platform]getproviders.PackageMeta)
+			}
+			archives[meta.Version][meta.TargetPlatform] = meta
+		}
+
+		problems = append(problems, verifyProviderMirrorIndexes(dir, provider, archives)...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Path < problems[j].Path
+	})
+	return problems, nil
+}
+
+// verifyProviderMirrorIndexes checks that the JSON index files for the given
+// provider list exactly the given valid archives, with correct hashes.
+func verifyProviderMirrorIndexes(dir string, provider addrs.Provider, archives map[getproviders.Version]map[getproviders.Platform]getproviders.PackageMeta) []providerMirrorProblem {
+	var problems []providerMirrorProblem
+
+	indexDir := filepath.Dir(getproviders.PackedFilePathForPackage(
+		dir, provider, versions.Unspecified, getproviders.CurrentPlatform,
+	))
+
+	mainIndexPath := filepath.Join(indexDir, "index.json")
+	var mainIndex struct {
+		Versions map[string]json.RawMessage `json:"versions"`
+	}
+	if err := readProviderMirrorIndex(mainIndexPath, &mainIndex); err != nil {
+		return append(problems, providerMirrorProblem{
+			Path:    mainIndexPath,
+			Message: err.Error(),
+		})
+	}
+
+	for version, platforms := range archives {
+		if _, ok := mainIndex.Versions[version.String()]; !ok {
+			problems = append(problems, providerMirrorProblem{
+				Path:    mainIndexPath,
+				Message: fmt.Sprintf("version %s is present in the mirror but not listed in the index", version),
+			})
+		}
+
+		versionIndexPath := filepath.Join(indexDir, version.String()+".json")
+		var versionIndex struct {
+			Archives map[string]struct {
+				URL    string   `json:"url"`
+				Hashes []string `json:"hashes"`
+			} `json:"archives"`
+		}
+		if err := readProviderMirrorIndex(versionIndexPath, &versionIndex); err != nil {
+			problems = append(problems, providerMirrorProblem{
+				Path:    versionIndexPath,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		for platform, meta := range platforms {
+			entry, ok := versionIndex.Archives[platform.String()]
+			if !ok {
+				problems = append(problems, providerMirrorProblem{
+					Path:    versionIndexPath,
+					Message: fmt.Sprintf("missing entry for platform %s", platform),
+				})
+				continue
+			}
+			archiveFilename := filepath.Base(string(meta.Location.(getproviders.PackageLocalArchive)))
+			if entry.URL != archiveFilename {
+				problems = append(problems, providerMirrorProblem{
+					Path:    versionIndexPath,
+					Message: fmt.Sprintf("entry for platform %s refers to %q, but the archive is %q", platform, entry.URL, archiveFilename),
+				})
+			}
+			hash, err := meta.Hash()
+			if err != nil {
+				continue // already reported as an invalid archive
+			}
+			found := false
+			for _, h := range entry.Hashes {
+				if h == hash.String() {
+					found = true
+					break
+				}
+			}
+			if !found {
+				problems = append(problems, providerMirrorProblem{
+					Path:    versionIndexPath,
+					Message: fmt.Sprintf("entry for platform %s does not include the archive's checksum %s", platform, hash),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+func readProviderMirrorIndex(path string, into interface{}) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("index file is missing")
+		}
+		return fmt.Errorf("failed to read index file: %w", err)
+	}
+	if err := json.Unmarshal(src, into); err != nil {
+		return fmt.Errorf("invalid index file: %w", err)
+	}
+	return nil
+}
+
+func checkZipArchive(path string) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	return r.Close()
+}
+
+func hasZipHash(hashes []getproviders.Hash) bool {
+	for _, h := range hashes {
+		if h.HasScheme(getproviders.HashSchemeZip) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,10 +6,16 @@
 package command
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/mitchellh/cli"
 )
 
@@ -132,6 +138,201 @@ func TestProviders_modules(t *testing.T) {
 	}
 }
 
+func TestProviders_json(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("providers/wiring"), td)
+	defer testChdir(t, td)()
+
+	// first run init with mock provider sources to install the modules
+	initUi := new(cli.MockUi)
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"foo": {"1.0.0"},
+	})
+	defer close()
+	m := Meta{
+		testingOverrides: metaOverridesForProvider(testProvider()),
+		Ui:               initUi,
+		ProviderSource:   providerSource,
+	}
+	ic := &InitCommand{
+		Meta: m,
+	}
+	if code := ic.Run([]string{}); code != 0 {
+		t.Fatalf("init failed\n%s", initUi.ErrorWriter)
+	}
+
+	ui := new(cli.MockUi)
+	c := &ProvidersCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+	if code := c.Run([]string{"-json"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, ui.OutputWriter.String())
+	}
+	var want map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"format_version": "1.0",
+		"root_module": {
+			"required_providers": {
+				"registry.opentofu.org/hashicorp/foo": "1.0.0"
+			},
+			"provider_configs": [
+				{
+					"name": "foo",
+					"provider": "registry.opentofu.org/hashicorp/foo",
+					"source": "declared",
+					"configuration": "provider[\"registry.opentofu.org/hashicorp/foo\"]"
+				},
+				{
+					"name": "foo.east",
+					"provider": "registry.opentofu.org/hashicorp/foo",
+					"source": "declared",
+					"configuration": "provider[\"registry.opentofu.org/hashicorp/foo\"].east"
+				}
+			],
+			"module_calls": {
+				"child": {
+					"required_providers": {
+						"registry.opentofu.org/hashicorp/foo": ""
+					},
+					"provider_configs": [
+						{
+							"name": "foo.west",
+							"provider": "registry.opentofu.org/hashicorp/foo",
+							"source": "passed",
+							"configuration": "provider[\"registry.opentofu.org/hashicorp/foo\"].east"
+						}
+					],
+					"module_calls": {
+						"grandchild": {
+							"required_providers": {
+								"registry.opentofu.org/hashicorp/foo": ""
+							},
+							"provider_configs": [
+								{
+									"name": "foo",
+									"provider": "registry.opentofu.org/hashicorp/foo",
+									"source": "inherited",
+									"configuration": "provider[\"registry.opentofu.org/hashicorp/foo\"]"
+								}
+							]
+						}
+					}
+				}
+			}
+		}
+	}`), &want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong output\n%s", diff)
+	}
+}
+
+func TestProviders_jsonDeprecations(t *testing.T) {
+	defer testChdir(t, testFixturePath("providers/basic"))()
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/providers/v1/hashicorp/foo/versions":
+			resp.Write([]byte(`{
+				"versions": [{"version": "1.0.0", "protocols": ["5.0"]}],
+				"deprecation": {
+					"reason": "Use acme/foo instead.",
+					"link": "https://example.com/foo",
+					"end_of_life": "2025-01-01"
+				}
+			}`))
+		case "/providers/v1/hashicorp/bar/versions":
+			resp.Write([]byte(`{"versions": [{"version": "2.0.0", "protocols": ["5.0"]}]}`))
+		default:
+			// The lookup is best-effort, so a failure for baz must not
+			// prevent reporting the others.
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	services := disco.New()
+	services.ForceHostServices(svchost.Hostname("registry.opentofu.org"), map[string]interface{}{
+		"providers.v1": server.URL + "/providers/v1/",
+	})
+
+	ui := new(cli.MockUi)
+	c := &ProvidersCommand{
+		Meta: Meta{
+			Ui:       ui,
+			Services: services,
+		},
+	}
+	if code := c.Run([]string{"-json", "-check-deprecations"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var got struct {
+		Deprecations map[string]interface{} `json:"deprecations"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, ui.OutputWriter.String())
+	}
+	want := map[string]interface{}{
+		"registry.opentofu.org/hashicorp/foo": map[string]interface{}{
+			"reason":      "Use acme/foo instead.",
+			"link":        "https://example.com/foo",
+			"end_of_life": "2025-01-01",
+			"message":     "hashicorp/foo is deprecated and reaches its end of life on 2025-01-01. Use acme/foo instead. For more information, see https://example.com/foo.",
+		},
+	}
+	if diff := cmp.Diff(want, got.Deprecations); diff != "" {
+		t.Errorf("wrong deprecations\n%s", diff)
+	}
+}
+
+func TestProviders_jsonDeprecationsOptIn(t *testing.T) {
+	defer testChdir(t, testFixturePath("providers/basic"))()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		resp.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	services := disco.New()
+	services.ForceHostServices(svchost.Hostname("registry.opentofu.org"), map[string]interface{}{
+		"providers.v1": server.URL + "/providers/v1/",
+	})
+
+	ui := new(cli.MockUi)
+	c := &ProvidersCommand{
+		Meta: Meta{
+			Ui:       ui,
+			Services: services,
+		},
+	}
+	if code := c.Run([]string{"-json"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if requests != 0 {
+		t.Errorf("made %d registry requests without -check-deprecations", requests)
+	}
+	if strings.Contains(ui.OutputWriter.String(), `"deprecations"`) {
+		t.Errorf("unexpected deprecations in the output:\n%s", ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	c.Meta.Ui = ui
+	if code := c.Run([]string{"-check-deprecations"}); code != 1 {
+		t.Fatalf("expected -check-deprecations without -json to fail, got %d", code)
+	}
+}
+
 func TestProviders_state(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
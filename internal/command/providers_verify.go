@@ -0,0 +1,261 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersVerifyCommand is a Command implementation that checks the
+// provider packages installed in the working directory against the
+// checksums recorded in the dependency lock file.
+type ProvidersVerifyCommand struct {
+	Meta
+}
+
+// providerVerifyResult describes the outcome of verifying a single provider
+// package installed in the working directory.
+type providerVerifyResult struct {
+	Provider addrs.Provider
+	Version  getproviders.Version
+
+	// Problem is a user-facing description of why the package failed
+	// verification, or empty if it didn't.
+	Problem string
+
+	// Unverified is true if the package could not be checked at all, such
+	// as when it would require network access that isn't allowed.
+	Unverified bool
+}
+
+func (c *ProvidersVerifyCommand) Synopsis() string {
+	return "Check installed providers against the dependency lock file"
+}
+
+func (c *ProvidersVerifyCommand) Run(args []string) int {
+	var offline bool
+
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("providers verify")
+	cmdFlags.BoolVar(&offline, "offline", false, "offline")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	locks, moreDiags := c.lockedDependencies()
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if len(locks.AllProviders()) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No dependency lock file",
+			"There are no providers recorded in the dependency lock file for this working directory. Run \"tofu init\" to install providers and create the lock file.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	var source getproviders.Source
+	if !offline {
+		source = c.providerInstallSource()
+	}
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	dir := c.providerLocalCacheDir()
+	results := verifyInstalledProviders(ctx, dir, locks, source)
+
+	var problems, unverified strings.Builder
+	for _, result := range results {
+		switch {
+		case result.Problem == "":
+			c.Ui.Output(fmt.Sprintf("- %s v%s: ok", result.Provider.ForDisplay(), result.Version))
+		case result.Unverified:
+			fmt.Fprintf(&unverified, "\n  - %s v%s: %s", result.Provider.ForDisplay(), result.Version, result.Problem)
+		default:
+			fmt.Fprintf(&problems, "\n  - %s v%s: %s", result.Provider.ForDisplay(), result.Version, result.Problem)
+		}
+	}
+	if unverified.Len() > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Some providers could not be verified",
+			fmt.Sprintf("The following provider packages could not be checked:%s", unverified.String()),
+		))
+	}
+	if problems.Len() > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Installed providers failed verification",
+			fmt.Sprintf("The following provider packages in %s do not match the dependency lock file, and may have been corrupted or tampered with:%s\n\nDelete the affected package directories and then run \"tofu init\" again to reinstall them.", dir.BasePath(), problems.String()),
+		))
+	}
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+func (c *ProvidersVerifyCommand) Help() string {
+	return `
+Usage: tofu [global options] providers verify [options]
+
+  Checks that each provider package installed in the working directory by
+  "tofu init" still matches the checksums recorded in the dependency lock
+  file, reporting any package that appears to have been corrupted or
+  tampered with.
+
+  Packages are verified without network access using the "h1:" checksums in
+  the lock file where possible. A package whose lock file entry has only
+  "zh:" checksums, which are the registry-signed checksums of the original
+  package archive, is verified by downloading that archive again and
+  comparing it with the installed package.
+
+Options:
+
+  -offline  Never download packages. Packages that can't be verified
+            without network access are reported as warnings.
+`
+}
+
+// verifyInstalledProviders checks the package installed in the given
+// directory for each provider in the given dependency locks.
+//
+// If source is non-nil then it is used to retrieve the original package
+// archive for any provider whose lock file entry has no "h1:" checksums, so
+// that the installed package can be compared with it. Otherwise such
+// packages are reported as unverified.
+//
+// The result is sorted by provider address so that it's suitable for
+// direct display.
+func verifyInstalledProviders(ctx context.Context, dir *providercache.Dir, locks *depsfile.Locks, source getproviders.Source) []providerVerifyResult {
+	allLocks := locks.AllProviders()
+	providers := make([]addrs.Provider, 0, len(allLocks))
+	for provider := range allLocks {
+		if locks.ProviderIsOverridden(provider) {
+			// Development overrides aren't installed by "tofu init".
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].LessThan(providers[j])
+	})
+
+	results := make([]providerVerifyResult, 0, len(providers))
+	for _, provider := range providers {
+		lock := allLocks[provider]
+		result := providerVerifyResult{
+			Provider: provider,
+			Version:  lock.Version(),
+		}
+
+		installed := dir.ProviderVersion(provider, lock.Version())
+		if installed == nil {
+			result.Problem = "not installed; run \"tofu init\" to install it"
+			results = append(results, result)
+			continue
+		}
+		if _, err := installed.ExecutableFile(); err != nil {
+			result.Problem = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		var localHashes, zipHashes []getproviders.Hash
+		for _, hash := range lock.AllHashes() {
+			switch hash.Scheme() {
+			case getproviders.HashScheme1:
+				localHashes = append(localHashes, hash)
+			case getproviders.HashSchemeZip:
+				zipHashes = append(zipHashes, hash)
+			}
+		}
+
+		switch {
+		case len(localHashes) > 0:
+			matches, err := installed.MatchesAnyHash(localHashes)
+			if err != nil {
+				result.Problem = fmt.Sprintf("failed to compute checksum: %s", err)
+			} else if !matches {
+				result.Problem = "contents do not match any of the checksums in the dependency lock file"
+			}
+		case len(zipHashes) == 0:
+			result.Problem = "the dependency lock file records no checksums for this provider"
+			result.Unverified = true
+		case source == nil:
+			result.Problem = "the dependency lock file has only \"zh:\" checksums, which can't be verified without network access"
+			result.Unverified = true
+		default:
+			problem, err := verifyInstalledProviderUpstream(ctx, installed, zipHashes, source)
+			if err != nil {
+				result.Problem = fmt.Sprintf("failed to retrieve the original package: %s", err)
+				result.Unverified = true
+			} else {
+				result.Problem = problem
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// verifyInstalledProviderUpstream downloads the original package for the
+// given installed provider, checking it against the given registry-signed
+// checksums, and returns a problem description if the installed package
+// doesn't match it.
+func verifyInstalledProviderUpstream(ctx context.Context, installed *providercache.CachedProvider, zipHashes []getproviders.Hash, source getproviders.Source) (string, error) {
+	meta, err := source.PackageMeta(ctx, installed.Provider, installed.Version, getproviders.CurrentPlatform)
+	if err != nil {
+		return "", err
+	}
+
+	tempDir, err := os.MkdirTemp("", "tofu-providers-verify")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	// InstallPackage verifies the downloaded archive against both the
+	// package's signature and the locked checksums before unpacking it.
+	upstreamDir := providercache.NewDir(tempDir)
+	if _, err := upstreamDir.InstallPackage(ctx, meta, zipHashes); err != nil {
+		return "", err
+	}
+	upstream := upstreamDir.ProviderVersion(installed.Provider, installed.Version)
+	if upstream == nil {
+		return "", fmt.Errorf("package was not found after download; this is a bug in OpenTofu")
+	}
+	want, err := upstream.Hash()
+	if err != nil {
+		return "", err
+	}
+	matches, err := installed.MatchesHash(want)
+	if err != nil {
+		return fmt.Sprintf("failed to compute checksum: %s", err), nil
+	}
+	if !matches {
+		return "contents do not match the original package, whose archive matches the checksums in the dependency lock file", nil
+	}
+	return "", nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providercache"
+)
+
+func TestProvidersVerify(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+
+	provider := addrs.NewDefaultProvider("null")
+	version := getproviders.MustParseVersion("1.0.0")
+	packageDir := getproviders.UnpackedDirectoryPathForPackage(
+		filepath.Join(".terraform", "providers"), provider, version, getproviders.CurrentPlatform,
+	)
+	if err := os.MkdirAll(packageDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	executable := filepath.Join(packageDir, "terraform-provider-null")
+	if err := os.WriteFile(executable, []byte("original"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	installed := providercache.NewDir(filepath.Join(".terraform", "providers")).ProviderVersion(provider, version)
+	if installed == nil {
+		t.Fatal("test package not detected")
+	}
+	hash, err := installed.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	c := &ProvidersVerifyCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+	locks := depsfile.NewLocks()
+	locks.SetProvider(provider, version, nil, []getproviders.Hash{hash})
+	if err := c.replaceLockedDependencies(locks); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ui.OutputWriter.Reset()
+		ui.ErrorWriter.Reset()
+		if code := c.Run([]string{"-offline"}); code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, ui.ErrorWriter.String())
+		}
+		want := "- hashicorp/null v1.0.0: ok"
+		if got := strings.TrimSpace(ui.OutputWriter.String()); got != want {
+			t.Fatalf("wrong output\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		if err := os.WriteFile(executable, []byte("tampered"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		ui.OutputWriter.Reset()
+		ui.ErrorWriter.Reset()
+		if code := c.Run([]string{"-offline"}); code != 1 {
+			t.Fatalf("wrong exit code %d; want 1", code)
+		}
+		got := ui.ErrorWriter.String()
+		if !strings.Contains(got, "Installed providers failed verification") || !strings.Contains(got, "hashicorp/null v1.0.0") {
+			t.Fatalf("missing verification error in output:\n%s", got)
+		}
+	})
+
+	t.Run("zh only offline", func(t *testing.T) {
+		locks := depsfile.NewLocks()
+		locks.SetProvider(provider, version, nil, []getproviders.Hash{
+			getproviders.HashSchemeZip.New("4fe81ba1b2ac6bbd5f6bd2fa0ae3e8b9cb2b9d5d23b4a8e8bb1d7b3d41f66f1e"),
+		})
+		if err := c.replaceLockedDependencies(locks); err != nil {
+			t.Fatal(err)
+		}
+		ui.OutputWriter.Reset()
+		ui.ErrorWriter.Reset()
+		if code := c.Run([]string{"-offline"}); code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, ui.ErrorWriter.String())
+		}
+		if got := ui.ErrorWriter.String(); !strings.Contains(got, "Some providers could not be verified") {
+			t.Fatalf("missing warning in output:\n%s", got)
+		}
+	})
+}
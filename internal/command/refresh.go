@@ -152,6 +152,8 @@ func (c *RefreshCommand) OperationRequest(be backend.Enhanced, view views.Refres
 	opReq.Hooks = view.Hooks()
 	opReq.Targets = args.Targets
 	opReq.Excludes = args.Excludes
+	opReq.TargetProviders = args.TargetProviders
+	opReq.NoInputSummary = args.NoInputSummary
 	opReq.Type = backend.OperationTypeRefresh
 	opReq.View = view.Operation()
 
@@ -214,6 +216,9 @@ Options:
 
   -input=true            Ask for input for variables if not directly set.
 
+  -no-input-summary      Don't list all of the missing variables before
+                         asking for their values.
+
   -lock=false            Don't hold a state lock during the operation. This is
                          dangerous if others might concurrently run commands
                          against the same workspace.
@@ -229,6 +234,12 @@ Options:
                          multiple times.  Cannot be used alongside the -exclude
                          flag.
 
+  -target-provider=aws.us_east_1
+                         Limit the operation to the resources that use the
+                         given provider configuration and their dependencies.
+                         This flag can be used multiple times. Cannot be used
+                         alongside the -exclude flag.
+
   -var 'foo=bar'         Set a variable in the OpenTofu configuration. This
                          flag can be set multiple times.
 
@@ -0,0 +1,35 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// RegistryCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type RegistryCommand struct {
+	Meta
+}
+
+func (c *RegistryCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *RegistryCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] registry <subcommand> [options] [args]
+
+  This command has subcommands for interacting with module and provider
+  registries.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RegistryCommand) Synopsis() string {
+	return "Interact with module and provider registries"
+}
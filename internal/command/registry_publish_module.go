@@ -0,0 +1,219 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apparentlymart/go-versions/versions"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/registry"
+	"github.com/opentofu/opentofu/internal/registry/regsrc"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RegistryPublishModuleCommand is a Command implementation that packages the
+// module in the current working directory and uploads it to a private module
+// registry.
+type RegistryPublishModuleCommand struct {
+	Meta
+}
+
+func (c *RegistryPublishModuleCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("registry publish-module")
+	var dryRun bool
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "dry-run")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid number of arguments",
+			"The registry publish-module command expects the registry address of the module and the version to publish, like \"example.com/namespace/name/system 1.0.0\".",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	module, err := regsrc.ParseModuleSource(args[0])
+	if err == nil && (module.RawHost == nil || module.RawSubmodule != "") {
+		err = fmt.Errorf("expected hostname/namespace/name/system")
+	}
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid module address",
+			fmt.Sprintf("The address %q is not a valid module registry address: %s. Publishing requires the full address, including the hostname of the private registry, like \"example.com/namespace/name/system\".", args[0], err),
+		))
+	}
+	version, err := versions.ParseVersion(args[1])
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid module version",
+			fmt.Sprintf("The version %q is not a valid semantic version: %s.", args[1], err),
+		))
+	}
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	dir := c.normalizePath(".")
+	diags = diags.Append(c.validateModuleForPublish(dir))
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	var pkg bytes.Buffer
+	files, err := registry.PackageModule(&pkg, dir)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to package module",
+			err.Error(),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	c.showDiagnostics(diags)
+
+	c.Ui.Output(fmt.Sprintf("Packaged %d files (%d bytes):", len(files), pkg.Len()))
+	for _, name := range files {
+		c.Ui.Output("  " + name)
+	}
+	if dryRun {
+		c.Ui.Output(fmt.Sprintf("\nDry run: %s version %s was not published.", module.Display(), version))
+		return 0
+	}
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	// Uploads can take much longer than the other registry requests, so we
+	// rely on the interruptible context rather than the default timeout.
+	client := registry.NewClient(c.Services, httpclient.New())
+	err = client.PublishModule(ctx, module, version.String(), pkg.Bytes())
+	if err != nil {
+		summary := "Failed to publish module"
+		if registry.IsModuleVersionExists(err) {
+			summary = "Module version already exists"
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			summary,
+			fmt.Sprintf("Could not publish %s version %s: %s.", module.Display(), version, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"\n[reset][bold][green]Published %s version %s.", module.Display(), version,
+	)))
+	return 0
+}
+
+// validateModuleForPublish checks that the module in dir can be loaded and
+// has the files that a registry needs to present it to users.
+func (c *RegistryPublishModuleCommand) validateModuleForPublish(dir string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if _, err := os.Stat(filepath.Join(dir, "README.md")); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Missing README.md",
+			"A published module must have a README.md file in its root directory describing what the module does and how to use it.",
+		))
+	}
+	if !hasLicenseFile(dir) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Missing license file",
+			"The module has no LICENSE file in its root directory. Consumers of the module will not know under which terms they may use it.",
+		))
+	}
+
+	mod, moreDiags := c.loadSingleModule(dir, configs.SelectiveLoadAll)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return diags
+	}
+	if len(mod.ManagedResources) == 0 && len(mod.DataResources) == 0 && len(mod.ModuleCalls) == 0 && len(mod.Outputs) == 0 && len(mod.Variables) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No module configuration",
+			"The current directory does not contain any configuration to publish. Run this command from the root directory of the module.",
+		))
+	}
+	if mod.Backend != nil || mod.CloudConfig != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Module declares a backend",
+			"Backend and cloud blocks are ignored when a module is called from another configuration, so they usually do not belong in a published module.",
+		))
+	}
+
+	return diags
+}
+
+func hasLicenseFile(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := strings.ToUpper(entry.Name())
+		if !entry.IsDir() && (strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *RegistryPublishModuleCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] registry publish-module [options] ADDRESS VERSION
+
+  Package the module in the current working directory and publish it to a
+  private module registry.
+
+  ADDRESS is the registry address of the module, including the hostname of
+  the registry, like "example.com/namespace/name/system". VERSION is the
+  semantic version to publish the package as.
+
+  The module must have a README.md file in its root directory and its
+  configuration must be valid. Hidden files and directories, such as
+  .terraform and .git, and state files are not included in the package.
+
+  The registry must support module publishing, and the credentials for its
+  hostname are taken from the CLI configuration, as set by "tofu login".
+
+Options:
+
+  -dry-run    Validate and package the module, and list the files that
+              would be published, without uploading anything.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RegistryPublishModuleCommand) Synopsis() string {
+	return "Publish a module to a private registry"
+}
@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/hashicorp/terraform-svchost/auth"
+	"github.com/hashicorp/terraform-svchost/disco"
+	"github.com/mitchellh/cli"
+)
+
+func testRegistryPublishServices(t *testing.T, handler http.HandlerFunc) *disco.Disco {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host := svchost.Hostname("registry.example.com")
+	services := disco.NewWithCredentialsSource(auth.StaticCredentialsSource(map[svchost.Hostname]map[string]interface{}{
+		host: {"token": "secret"},
+	}))
+	services.ForceHostServices(host, map[string]interface{}{
+		"modules-publish.v1":   fmt.Sprintf("%s/v1/publish/", server.URL),
+		"providers-publish.v1": fmt.Sprintf("%s/v1/publish/", server.URL),
+	})
+	return services
+}
+
+func TestRegistryPublishModule(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("registry-publish-module"), td)
+	defer testChdir(t, td)()
+
+	var gotPath, gotAuth string
+	services := testRegistryPublishServices(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishModuleCommand{
+		Meta: Meta{
+			Ui:       ui,
+			Services: services,
+		},
+	}
+
+	if code := c.Run([]string{"registry.example.com/acme/greeting/null", "1.2.0"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if gotPath != "/v1/publish/acme/greeting/null/1.2.0" {
+		t.Errorf("wrong upload path %q", gotPath)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("wrong authorization header %q", gotAuth)
+	}
+	output := ui.OutputWriter.String()
+	for _, want := range []string{"Packaged 3 files", "README.md", "main.tf", "Published registry.example.com/acme/greeting/null version 1.2.0."} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRegistryPublishModule_dryRun(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("registry-publish-module"), td)
+	defer testChdir(t, td)()
+
+	services := testRegistryPublishServices(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	})
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishModuleCommand{
+		Meta: Meta{
+			Ui:       ui,
+			Services: services,
+		},
+	}
+
+	if code := c.Run([]string{"-dry-run", "registry.example.com/acme/greeting/null", "1.2.0"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, "was not published") {
+		t.Errorf("wrong output:\n%s", output)
+	}
+}
+
+func TestRegistryPublishModule_versionExists(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("registry-publish-module"), td)
+	defer testChdir(t, td)()
+
+	services := testRegistryPublishServices(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishModuleCommand{
+		Meta: Meta{
+			Ui:       ui,
+			Services: services,
+		},
+	}
+
+	if code := c.Run([]string{"registry.example.com/acme/greeting/null", "1.2.0"}); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "Module version already exists") {
+		t.Errorf("wrong error:\n%s", got)
+	}
+}
+
+func TestRegistryPublishModule_missingReadme(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("registry-publish-module"), td)
+	defer testChdir(t, td)()
+	if err := os.Remove("README.md"); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishModuleCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+
+	if code := c.Run([]string{"registry.example.com/acme/greeting/null", "1.2.0"}); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "Missing README.md") {
+		t.Errorf("wrong error:\n%s", got)
+	}
+}
+
+func TestRegistryPublishModule_invalidArgs(t *testing.T) {
+	tests := map[string][]string{
+		"no args":       {},
+		"no hostname":   {"acme/greeting/null", "1.0.0"},
+		"bad version":   {"registry.example.com/acme/greeting/null", "latest"},
+		"extra args":    {"registry.example.com/acme/greeting/null", "1.0.0", "extra"},
+		"has submodule": {"registry.example.com/acme/greeting/null//child", "1.0.0"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := &RegistryPublishModuleCommand{
+				Meta: Meta{
+					Ui: ui,
+				},
+			}
+			if code := c.Run(args); code != 1 {
+				t.Fatalf("expected failure, got %d\n\n%s", code, ui.OutputWriter.String())
+			}
+		})
+	}
+}
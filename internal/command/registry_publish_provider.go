@@ -0,0 +1,290 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/registry"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RegistryPublishProviderCommand is a Command implementation that validates
+// the release artifacts of a provider and either uploads them to a private
+// provider registry or adds them to a network mirror directory.
+type RegistryPublishProviderCommand struct {
+	Meta
+}
+
+func (c *RegistryPublishProviderCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("registry publish-provider")
+	var releaseDir, keyPath, mirrorDir string
+	var dryRun bool
+	cmdFlags.StringVar(&releaseDir, "dir", ".", "release directory")
+	cmdFlags.StringVar(&keyPath, "signing-key", "", "public key file")
+	cmdFlags.StringVar(&mirrorDir, "mirror-dir", "", "network mirror directory")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "dry-run")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid number of arguments",
+			"The registry publish-provider command expects the source address of the provider and the version to publish, like \"example.com/namespace/type 1.0.0\".",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	provider, moreDiags := addrs.ParseProviderSourceString(args[0])
+	diags = diags.Append(moreDiags)
+	if !moreDiags.HasErrors() && (provider.IsBuiltIn() || provider.IsLegacy()) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid provider address",
+			fmt.Sprintf("Cannot publish %s, because it is not a provider that can be installed from a registry.", provider.ForDisplay()),
+		))
+	}
+	version, err := getproviders.ParseVersion(args[1])
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid provider version",
+			fmt.Sprintf("The version %q is not a valid semantic version: %s.", args[1], err),
+		))
+	}
+	if keyPath == "" && mirrorDir == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Signing key required",
+			"Publishing to a provider registry requires the -signing-key option, giving the ASCII-armored public key that the release checksums are signed with.",
+		))
+	}
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	release, err := getproviders.LoadRelease(releaseDir, provider, version)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid provider release",
+			fmt.Sprintf("The release artifacts for %s v%s in %s are not valid:\n%s", provider.ForDisplay(), version, releaseDir, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	var key, keyID string
+	if keyPath != "" {
+		src, err := os.ReadFile(keyPath)
+		if err == nil {
+			key = string(src)
+			keyID, err = release.VerifySignature(key)
+		}
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid release signature",
+				fmt.Sprintf("Failed to verify the signature of the release checksums with the key in %s: %s.", keyPath, err),
+			))
+			c.showDiagnostics(diags)
+			return 1
+		}
+	} else {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Release signature not verified",
+			"Without the -signing-key option, the signature of the release checksums is not verified. Network mirrors don't publish signatures, so OpenTofu will rely on the checksums recorded in the mirror index instead.",
+		))
+	}
+	c.showDiagnostics(diags)
+
+	c.Ui.Output(fmt.Sprintf("Release %s v%s (protocols %s):", provider.ForDisplay(), version, strings.Join(release.Protocols, ", ")))
+	for _, platform := range release.SortedPlatforms() {
+		c.Ui.Output(fmt.Sprintf("  - %s: %s", platform, release.Archives[platform].Filename()))
+	}
+	if keyID != "" {
+		c.Ui.Output(fmt.Sprintf("  Checksums signed by key %s", keyID))
+	}
+	if dryRun {
+		c.Ui.Output("\nDry run: nothing was published.")
+		return 0
+	}
+
+	if mirrorDir != "" {
+		return c.publishToMirror(release, mirrorDir)
+	}
+	return c.publishToRegistry(release, key, keyID)
+}
+
+func (c *RegistryPublishProviderCommand) publishToRegistry(release *getproviders.Release, key, keyID string) int {
+	var diags tfdiags.Diagnostics
+	provider := release.Provider
+
+	req := &registry.ProviderPublishRequest{
+		Protocols:                release.Protocols,
+		SHASumsFilename:          filepath.Base(release.SHA256SumsPath),
+		SHASumsSignatureFilename: filepath.Base(release.SignaturePath),
+		SigningKeys: registry.ProviderPublishKeys{
+			GPGPublicKeys: []registry.ProviderPublishGPGKey{
+				{KeyID: keyID, ASCIIArmor: key},
+			},
+		},
+	}
+	files := map[string]string{
+		req.SHASumsFilename:          release.SHA256SumsPath,
+		req.SHASumsSignatureFilename: release.SignaturePath,
+	}
+	for _, platform := range release.SortedPlatforms() {
+		archive := release.Archives[platform]
+		req.Platforms = append(req.Platforms, registry.ProviderPublishPlatform{
+			OS:       platform.OS,
+			Arch:     platform.Arch,
+			Filename: archive.Filename(),
+			SHASum:   archive.SHA256Sum,
+		})
+		files[archive.Filename()] = archive.Path
+	}
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	// Uploads can take much longer than the other registry requests, so we
+	// rely on the interruptible context rather than the default timeout.
+	client := registry.NewClient(c.Services, httpclient.New())
+	err := client.PublishProvider(ctx, provider.Hostname, provider.Namespace, provider.Type, release.Version.String(), req, files)
+	if err != nil {
+		summary := "Failed to publish provider"
+		if registry.IsProviderVersionExists(err) {
+			summary = "Provider version already exists"
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			summary,
+			fmt.Sprintf("Could not publish %s v%s: %s.", provider.ForDisplay(), release.Version, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"\n[reset][bold][green]Published %s v%s.", provider.ForDisplay(), release.Version,
+	)))
+	return 0
+}
+
+// publishToMirror copies the release archives into mirrorDir using the packed
+// filesystem mirror layout and regenerates the JSON indexes, so that the
+// directory can be served as a network mirror.
+func (c *RegistryPublishProviderCommand) publishToMirror(release *getproviders.Release, mirrorDir string) int {
+	var diags tfdiags.Diagnostics
+
+	for _, platform := range release.SortedPlatforms() {
+		archive := release.Archives[platform]
+		targetPath := getproviders.PackedFilePathForPackage(mirrorDir, release.Provider, release.Version, platform)
+		if err := copyReleaseArchive(archive.Path, targetPath); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to add package to mirror",
+				fmt.Sprintf("Could not copy %s into the mirror directory: %s.", archive.Filename(), err),
+			))
+		}
+	}
+	if !diags.HasErrors() {
+		diags = diags.Append(writeProviderMirrorIndexes(mirrorDir))
+	}
+
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"\n[reset][bold][green]Added %s v%s to the mirror in %s.", release.Provider.ForDisplay(), release.Version, mirrorDir,
+	)))
+	return 0
+}
+
+func copyReleaseArchive(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func (c *RegistryPublishProviderCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] registry publish-provider [options] ADDRESS VERSION
+
+  Validate the release artifacts of a provider and publish them to a private
+  provider registry, or add them to a network mirror directory.
+
+  ADDRESS is the source address of the provider, like
+  "example.com/namespace/type". VERSION is the version being released.
+
+  The release directory must contain the files produced by the usual
+  provider release process, all named with the prefix
+  "terraform-provider-TYPE_VERSION":
+
+    _OS_ARCH.zip       The provider package for each platform.
+    _SHA256SUMS        The checksums of the packages.
+    _SHA256SUMS.sig    A detached signature of the checksums file.
+    _manifest.json     Optional; declares the supported plugin protocols.
+
+  The registry must support provider publishing, and the credentials for its
+  hostname are taken from the CLI configuration, as set by "tofu login".
+
+Options:
+
+  -dir=path            Directory containing the release artifacts. Defaults
+                       to the current directory.
+
+  -signing-key=path    File containing the ASCII-armored public key that
+                       the checksums are signed with. The signature is
+                       verified before publishing, and the key is sent to the
+                       registry. Required unless -mirror-dir is set.
+
+  -mirror-dir=path     Add the packages to the network mirror layout in the
+                       given directory and regenerate its JSON indexes,
+                       instead of publishing to a registry.
+
+  -dry-run             Validate the release without publishing it.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RegistryPublishProviderCommand) Synopsis() string {
+	return "Publish a provider release to a private registry or mirror"
+}
@@ -0,0 +1,224 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/registry"
+)
+
+// testProviderReleaseDir writes a release of example.com/acme/widget 1.0.0
+// for linux_amd64 and darwin_arm64 into a new temporary directory, signed with
+// a newly-generated key. It returns the directory, and the path of a file
+// containing the public key along with its ID.
+func testProviderReleaseDir(t *testing.T) (string, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	var sums strings.Builder
+	for _, platform := range []string{"darwin_arm64", "linux_amd64"} {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create("terraform-provider-widget_v1.0.0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(w, "executable for %s", platform)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		filename := fmt.Sprintf("terraform-provider-widget_1.0.0_%s.zip", platform)
+		if err := os.WriteFile(filepath.Join(dir, filename), buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		fmt.Fprintf(&sums, "%s  %s\n", hex.EncodeToString(sum[:]), filename)
+	}
+
+	entity, err := openpgp.NewEntity("Widget Releases", "", "releases@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signature bytes.Buffer
+	if err := openpgp.DetachSign(&signature, entity, strings.NewReader(sums.String()), nil); err != nil {
+		t.Fatal(err)
+	}
+	var key bytes.Buffer
+	aw, err := armor.Encode(&key, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(aw); err != nil {
+		t.Fatal(err)
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		"terraform-provider-widget_1.0.0_SHA256SUMS":     []byte(sums.String()),
+		"terraform-provider-widget_1.0.0_SHA256SUMS.sig": signature.Bytes(),
+		"terraform-provider-widget_1.0.0_manifest.json":  []byte(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.asc")
+	if err := os.WriteFile(keyPath, key.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, keyPath, entity.PrimaryKey.KeyIdString()
+}
+
+func TestRegistryPublishProvider(t *testing.T) {
+	dir, keyPath, keyID := testProviderReleaseDir(t)
+
+	var gotPaths []string
+	var gotDoc registry.ProviderPublishRequest
+	services := testRegistryPublishServices(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		if !strings.Contains(r.URL.Path, "/files/") {
+			if err := json.NewDecoder(r.Body).Decode(&gotDoc); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishProviderCommand{
+		Meta: Meta{
+			Ui:       ui,
+			Services: services,
+		},
+	}
+
+	args := []string{"-dir", dir, "-signing-key", keyPath, "registry.example.com/acme/widget", "1.0.0"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if len(gotPaths) != 5 || gotPaths[4] != "/v1/publish/acme/widget/1.0.0" {
+		t.Fatalf("wrong requests: %#v", gotPaths)
+	}
+	if got := strings.Join(gotDoc.Protocols, ","); got != "6.0" {
+		t.Errorf("wrong protocols %q", got)
+	}
+	if len(gotDoc.Platforms) != 2 || gotDoc.Platforms[0].OS != "darwin" || gotDoc.Platforms[1].Filename != "terraform-provider-widget_1.0.0_linux_amd64.zip" {
+		t.Errorf("wrong platforms %#v", gotDoc.Platforms)
+	}
+	if gotDoc.SHASumsFilename != "terraform-provider-widget_1.0.0_SHA256SUMS" {
+		t.Errorf("wrong shasums filename %q", gotDoc.SHASumsFilename)
+	}
+	if keys := gotDoc.SigningKeys.GPGPublicKeys; len(keys) != 1 || keys[0].KeyID != keyID || !strings.Contains(keys[0].ASCIIArmor, "PGP PUBLIC KEY BLOCK") {
+		t.Errorf("wrong signing keys %#v", gotDoc.SigningKeys)
+	}
+	if output := ui.OutputWriter.String(); !strings.Contains(output, "Published registry.example.com/acme/widget v1.0.0.") {
+		t.Errorf("wrong output:\n%s", output)
+	}
+}
+
+func TestRegistryPublishProvider_mirror(t *testing.T) {
+	dir, _, _ := testProviderReleaseDir(t)
+	mirrorDir := t.TempDir()
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishProviderCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+
+	args := []string{"-dir", dir, "-mirror-dir", mirrorDir, "registry.example.com/acme/widget", "1.0.0"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	providerDir := filepath.Join(mirrorDir, "registry.example.com", "acme", "widget")
+	for _, name := range []string{
+		"terraform-provider-widget_1.0.0_darwin_arm64.zip",
+		"terraform-provider-widget_1.0.0_linux_amd64.zip",
+		"index.json",
+		"1.0.0.json",
+	} {
+		if _, err := os.Stat(filepath.Join(providerDir, name)); err != nil {
+			t.Errorf("missing %s in mirror: %s", name, err)
+		}
+	}
+
+	src, err := os.ReadFile(filepath.Join(providerDir, "1.0.0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index struct {
+		Archives map[string]struct {
+			URL string `json:"url"`
+		} `json:"archives"`
+	}
+	if err := json.Unmarshal(src, &index); err != nil {
+		t.Fatal(err)
+	}
+	if got := index.Archives["linux_amd64"].URL; got != "terraform-provider-widget_1.0.0_linux_amd64.zip" {
+		t.Errorf("wrong archive URL %q", got)
+	}
+}
+
+func TestRegistryPublishProvider_invalidRelease(t *testing.T) {
+	dir, keyPath, _ := testProviderReleaseDir(t)
+	if err := os.WriteFile(filepath.Join(dir, "terraform-provider-widget_1.0.0_linux_amd64.zip"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishProviderCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+
+	args := []string{"-dir", dir, "-signing-key", keyPath, "registry.example.com/acme/widget", "1.0.0"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "Invalid provider release") {
+		t.Errorf("wrong error:\n%s", got)
+	}
+}
+
+func TestRegistryPublishProvider_requiresSigningKey(t *testing.T) {
+	dir, _, _ := testProviderReleaseDir(t)
+
+	ui := cli.NewMockUi()
+	c := &RegistryPublishProviderCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+
+	args := []string{"-dir", dir, "registry.example.com/acme/widget", "1.0.0"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "Signing key required") {
+		t.Errorf("wrong error:\n%s", got)
+	}
+}
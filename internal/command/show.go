@@ -10,12 +10,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	backendInit "github.com/opentofu/opentofu/internal/backend/init"
 	"github.com/opentofu/opentofu/internal/cloud"
 	"github.com/opentofu/opentofu/internal/cloud/cloudplan"
 	"github.com/opentofu/opentofu/internal/command/arguments"
@@ -54,6 +56,11 @@ func (e *errUnusableDataMisc) Unwrap() error {
 type ShowCommand struct {
 	Meta
 	viewType arguments.ViewType
+
+	// workspace and backendConfigFlags, if set, override where the latest
+	// state snapshot is read from when no plan or state file is given.
+	workspace          string
+	backendConfigFlags rawFlags
 }
 
 func (c *ShowCommand) Run(rawArgs []string) int {
@@ -70,6 +77,12 @@ func (c *ShowCommand) Run(rawArgs []string) int {
 	}
 	c.viewType = args.ViewType
 	c.View.SetShowSensitive(args.ShowSensitive)
+	c.View.SetExpandCollapsed(args.ExpandCollapsed)
+	c.workspace = args.Workspace
+	c.backendConfigFlags = newRawFlags("-backend-config")
+	for _, v := range args.BackendConfig {
+		c.backendConfigFlags.Set(v)
+	}
 
 	// Set up view
 	view := views.NewShow(args.ViewType, c.View)
@@ -119,8 +132,25 @@ Options:
   -json               If specified, output the OpenTofu plan or state in
                       a machine-readable form.
 
+  -format=patch       If specified, output the changes in the given plan
+                      file as a unified diff between the rendered body of
+                      each resource instance before and after its change.
+
   -show-sensitive     If specified, sensitive values will be displayed.
 
+  -expand-collapsed   Show in full the changes to attributes that the
+                      collapse_attributes CLI configuration setting would
+                      otherwise collapse.
+
+  -workspace=name     Show the latest state snapshot of the given workspace
+                      instead of the currently selected workspace.
+
+  -backend-config=... Override the backend configuration used to read the
+                      latest state snapshot, in the same forms that
+                      "tofu init" accepts. The saved backend configuration
+                      is not changed. Use this option more than once to
+                      give more than one override.
+
   -var 'foo=bar'      Set a value for one of the input variables in the root
                       module of the configuration. Use this option more than
                       once to set more than one variable.
@@ -206,7 +236,7 @@ func (c *ShowCommand) showFromLatestStateSnapshot(enc encryption.Encryption) (*s
 	var diags tfdiags.Diagnostics
 
 	// Load the backend
-	b, backendDiags := c.Backend(nil, enc.State())
+	b, backendDiags := c.showBackend(enc)
 	diags = diags.Append(backendDiags)
 	if backendDiags.HasErrors() {
 		return nil, diags
@@ -214,13 +244,33 @@ func (c *ShowCommand) showFromLatestStateSnapshot(enc encryption.Encryption) (*s
 	c.ignoreRemoteVersionConflict(b)
 
 	// Load the workspace
-	workspace, err := c.Workspace()
-	if err != nil {
-		diags = diags.Append(fmt.Errorf("error selecting workspace: %w", err))
-		return nil, diags
+	workspace := c.workspace
+	if workspace == "" {
+		var err error
+		workspace, err = c.Workspace()
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("error selecting workspace: %w", err))
+			return nil, diags
+		}
+	} else {
+		// Reading the state of a workspace that doesn't exist would create
+		// it in some backends, so we must check first.
+		workspaces, err := b.Workspaces()
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("Failed to list workspaces: %w", err))
+			return nil, diags
+		}
+		if !slices.Contains(workspaces, workspace) {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Workspace doesn't exist",
+				fmt.Sprintf("There is no workspace named %q. The available workspaces are: %s.", workspace, strings.Join(workspaces, ", ")),
+			))
+			return nil, diags
+		}
 	}
 
-	// Get the latest state snapshot from the backend for the current workspace
+	// Get the latest state snapshot from the backend for the selected workspace
 	stateFile, stateErr := getStateFromBackend(b, workspace)
 	if stateErr != nil {
 		diags = diags.Append(stateErr)
@@ -230,6 +280,49 @@ func (c *ShowCommand) showFromLatestStateSnapshot(enc encryption.Encryption) (*s
 	return stateFile, diags
 }
 
+// showBackend returns the backend to read the latest state snapshot from.
+//
+// If there are -backend-config options then the backend is configured from
+// the backend block in the root module merged with those options, instead of
+// from the configuration saved by "tofu init", which remains unchanged.
+func (c *ShowCommand) showBackend(enc encryption.Encryption) (backend.Backend, tfdiags.Diagnostics) {
+	if c.backendConfigFlags.Empty() {
+		return c.Backend(nil, enc.State())
+	}
+
+	var diags tfdiags.Diagnostics
+	config, _, configDiags := c.backendConfig(&BackendOpts{})
+	diags = diags.Append(configDiags)
+	if configDiags.HasErrors() {
+		return nil, diags
+	}
+	if config == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Missing backend configuration",
+			`The -backend-config option overrides settings in the "backend" block of the root module, but the configuration has no "backend" block.`,
+		))
+		return nil, diags
+	}
+
+	schema := backendInit.Backend(config.Type)(nil).ConfigSchema()
+	override, overrideDiags := c.backendConfigOverrideBody(c.backendConfigFlags, schema)
+	diags = diags.Append(overrideDiags)
+	if overrideDiags.HasErrors() {
+		return nil, diags
+	}
+	if override != nil {
+		config.Config = configs.MergeBodies(config.Config, override)
+	}
+
+	b, _, initDiags := c.backendInitFromConfig(config, enc.State())
+	diags = diags.Append(initDiags)
+	if initDiags.HasErrors() {
+		return nil, diags
+	}
+	return b, diags
+}
+
 func (c *ShowCommand) showFromPath(path string, enc encryption.Encryption) (*plans.Plan, *cloudplan.RemotePlanJSON, *statefile.File, *configs.Config, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 	var planErr, stateErr error
@@ -18,7 +18,10 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backend"
+	backendLocal "github.com/opentofu/opentofu/internal/backend/local"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/states"
@@ -101,6 +104,179 @@ func TestShow_noArgsWithState(t *testing.T) {
 	}
 }
 
+func TestShow_workspace(t *testing.T) {
+	testCwd(t)
+	testStateFileDefault(t, testState())
+	testStateFileWorkspaceDefault(t, "staging", showWorkspaceState("staging"))
+
+	t.Run("existing", func(t *testing.T) {
+		view, done := testView(t)
+		c := &ShowCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+				View:             view,
+			},
+		}
+
+		code := c.Run([]string{"-workspace=staging"})
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("unexpected exit status %d; want 0\ngot: %s", code, output.Stderr())
+		}
+		got := output.Stdout()
+		if !strings.Contains(got, "# test_instance.staging:") || strings.Contains(got, "test_instance.foo") {
+			t.Fatalf("unexpected output\n%s", got)
+		}
+
+		// The selected workspace must not change.
+		if ws, err := c.Workspace(); err != nil || ws != "default" {
+			t.Fatalf("wrong selected workspace %q (%v)", ws, err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		view, done := testView(t)
+		c := &ShowCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+				View:             view,
+			},
+		}
+
+		code := c.Run([]string{"-workspace=missing"})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("unexpected exit status %d; want 1\ngot: %s", code, output.Stdout())
+		}
+		if got, want := output.Stderr(), `There is no workspace named "missing"`; !strings.Contains(got, want) {
+			t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+		if _, err := os.Stat(filepath.Join("terraform.tfstate.d", "missing")); !os.IsNotExist(err) {
+			t.Fatalf("workspace was created: %v", err)
+		}
+	})
+}
+
+func TestShow_workspacePerWorkspaceKeys(t *testing.T) {
+	testCwd(t)
+	err := os.WriteFile("main.tf", []byte(`
+terraform {
+  encryption {
+    key_provider "pbkdf2" "basic" {
+      passphrase    = "correct-horse-battery-staple"
+      iterations    = 200000
+      per_workspace = true
+    }
+    method "aes_gcm" "basic" {
+      keys = key_provider.pbkdf2.basic
+    }
+    state {
+      method = method.aes_gcm.basic
+    }
+  }
+}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the state of each workspace with the keys of that workspace.
+	for workspace, path := range map[string]string{
+		backend.DefaultStateName: DefaultStateFilename,
+		"staging":                filepath.Join(backendLocal.DefaultWorkspaceDir, "staging", DefaultStateFilename),
+	} {
+		t.Setenv(WorkspaceNameEnvVar, workspace)
+		enc, diags := (&Meta{}).Encryption()
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := statemgr.WriteAndPersist(statemgr.NewFilesystem(path, enc.State()), showWorkspaceState(workspace), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv(WorkspaceNameEnvVar, "")
+
+	view, done := testView(t)
+	c := &ShowCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-workspace=staging"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit status %d; want 0\ngot: %s", code, output.Stderr())
+	}
+	if got := output.Stdout(); !strings.Contains(got, "# test_instance.staging:") {
+		t.Fatalf("unexpected output\n%s", got)
+	}
+}
+
+func TestShow_backendConfig(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("show-backend-config"), td)
+	defer testChdir(t, td)()
+
+	writeState := func(path string, state *states.State) {
+		t.Helper()
+		if err := statemgr.WriteAndPersist(statemgr.NewFilesystem(path, encryption.StateEncryptionDisabled()), state, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeState("primary.tfstate", testState())
+	writeState("other.tfstate", showWorkspaceState("other"))
+
+	view, done := testView(t)
+	c := &ShowCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-json", "-backend-config=path=other.tfstate"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit status %d; want 0\ngot: %s", code, output.Stderr())
+	}
+	if got := output.Stdout(); !strings.Contains(got, `"address":"test_instance.other"`) {
+		t.Fatalf("unexpected output\n%s", got)
+	}
+
+	// Showing the state must not initialize the backend.
+	if _, err := os.Stat(filepath.Join(DefaultDataDir, DefaultStateFilename)); !os.IsNotExist(err) {
+		t.Fatalf("backend configuration was saved: %v", err)
+	}
+}
+
+// showWorkspaceState returns a state with a single resource instance named
+// after the given workspace.
+func showWorkspaceState(name string) *states.State {
+	return states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: name,
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"` + name + `"}`),
+				Status:    states.ObjectReady,
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+			addrs.NoKey,
+		)
+	})
+}
+
 func TestShow_argsWithState(t *testing.T) {
 	// Create the default state
 	statePath := testStateFile(t, testState())
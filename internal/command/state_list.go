@@ -7,6 +7,7 @@ package command
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/cli"
@@ -30,6 +31,7 @@ func (c *StateListCommand) Run(args []string) int {
 	c.Meta.varFlagSet(cmdFlags)
 	cmdFlags.StringVar(&statePath, "state", "", "path")
 	lookupId := cmdFlags.String("id", "", "Restrict output to paths with a resource having the specified ID.")
+	deposed := cmdFlags.Bool("deposed", false, "List only deposed objects.")
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
 		return cli.RunResultHelp
@@ -92,10 +94,20 @@ func (c *StateListCommand) Run(args []string) int {
 	}
 
 	for _, addr := range addrs {
-		if is := state.ResourceInstance(addr); is != nil {
-			if *lookupId == "" || *lookupId == states.LegacyInstanceObjectID(is.Current) {
-				c.Ui.Output(addr.String())
+		is := state.ResourceInstance(addr)
+		if is == nil {
+			continue
+		}
+		if *deposed {
+			for _, key := range sortedDeposedKeys(is) {
+				if *lookupId == "" || *lookupId == states.LegacyInstanceObjectID(is.Deposed[key]) {
+					c.Ui.Output(fmt.Sprintf("%s (deposed object %s)", addr, key))
+				}
 			}
+			continue
+		}
+		if *lookupId == "" || *lookupId == states.LegacyInstanceObjectID(is.Current) {
+			c.Ui.Output(addr.String())
 		}
 	}
 
@@ -135,6 +147,12 @@ Options:
                       resource types have an attribute named "id" whose value
                       equals the given id string.
 
+  -deposed            List only the deposed objects of the matching instances,
+                      which are objects left behind by a create-before-destroy
+                      replacement that failed to destroy them. Each line
+                      includes the deposed key that identifies the object,
+                      for use with "tofu state rm-deposed".
+
   -var 'foo=bar'      Set a value for one of the input variables in the root
                       module of the configuration. Use this option more than
                       once to set more than one variable.
@@ -152,6 +170,19 @@ func (c *StateListCommand) Synopsis() string {
 	return "List resources in the state"
 }
 
+// sortedDeposedKeys returns the keys of the deposed objects of the given
+// instance in lexical order, for consistent output.
+func sortedDeposedKeys(is *states.ResourceInstance) []states.DeposedKey {
+	keys := make([]states.DeposedKey, 0, len(is.Deposed))
+	for key := range is.Deposed {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
 const errStateLoadingState = `Error loading the state: %[1]s
 
 Please ensure that your OpenTofu state exists and that you've
@@ -10,6 +10,9 @@ import (
 	"testing"
 
 	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
 )
 
 func TestStateList(t *testing.T) {
@@ -99,6 +102,80 @@ func TestStateListWithNonExistentID(t *testing.T) {
 	}
 }
 
+func TestStateListDeposed(t *testing.T) {
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "foo",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	providerAddr := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			addr,
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"bar"}`),
+				Status:    states.ObjectReady,
+			},
+			providerAddr,
+			addrs.NoKey,
+		)
+		for key, id := range map[states.DeposedKey]string{"00000002": "baz", "00000001": "foo"} {
+			s.SetResourceInstanceDeposed(
+				addr,
+				key,
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON: []byte(`{"id":"` + id + `"}`),
+					Status:    states.ObjectReady,
+				},
+				providerAddr,
+				addrs.NoKey,
+			)
+		}
+	})
+	statePath := testStateFile(t, state)
+
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"all": {
+			nil,
+			"test_instance.foo (deposed object 00000001)\ntest_instance.foo (deposed object 00000002)\n",
+		},
+		"id": {
+			[]string{"-id", "baz"},
+			"test_instance.foo (deposed object 00000002)\n",
+		},
+		"id of current object": {
+			[]string{"-id", "bar"},
+			"",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := testProvider()
+			ui := cli.NewMockUi()
+			c := &StateListCommand{
+				Meta: Meta{
+					testingOverrides: metaOverridesForProvider(p),
+					Ui:               ui,
+				},
+			}
+
+			args := append([]string{"-state", statePath, "-deposed"}, test.args...)
+			if code := c.Run(args); code != 0 {
+				t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+			}
+			if got := ui.OutputWriter.String(); got != test.want {
+				t.Fatalf("wrong output\ngot:  %q\nwant: %q", got, test.want)
+			}
+		})
+	}
+}
+
 func TestStateList_backendDefaultState(t *testing.T) {
 	// Create a temporary working directory that is empty
 	td := t.TempDir()
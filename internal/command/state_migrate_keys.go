@@ -0,0 +1,413 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// StateMigrateKeysCommand is a Command implementation that rewrites the
+// instance keys of a resource in the state when its configuration switches
+// from count to for_each, so that OpenTofu doesn't plan to destroy and
+// recreate every instance.
+type StateMigrateKeysCommand struct {
+	StateMeta
+}
+
+func (c *StateMigrateKeysCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var dryRun bool
+	var from, to, keyer string
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state migrate-keys")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "dry run")
+	cmdFlags.StringVar(&from, "from", "count", "from")
+	cmdFlags.StringVar(&to, "to", "for_each", "to")
+	cmdFlags.StringVar(&keyer, "keyer", "", "keyer")
+	cmdFlags.StringVar(&c.backupPath, "backup", "-", "backup")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	cmdFlags.StringVar(&c.statePath, "state", "", "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("Exactly one argument expected: the address of a resource.\n")
+		return cli.RunResultHelp
+	}
+	if from != "count" || to != "for_each" {
+		c.Ui.Error("Only -from=count -to=for_each is supported.\n")
+		return cli.RunResultHelp
+	}
+	if keyer == "" {
+		c.Ui.Error("The -keyer option is required.\n")
+		return cli.RunResultHelp
+	}
+
+	addr, diags := addrs.ParseAbsResourceStr(args[0])
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	keyerExpr, hclDiags := hclsyntax.ParseExpression([]byte(keyer), "<keyer>", hcl.InitialPos)
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	// Get the state
+	stateMgr, err := c.State(enc)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+
+	if c.stateLock {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(stateMgr, "state-migrate-keys"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+
+	state := stateMgr.State()
+	if state == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+
+	rs := state.Resource(addr)
+	if rs == nil {
+		c.showDiagnostics(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid target address",
+			fmt.Sprintf("The state does not contain %s.", addr),
+		))
+		return 1
+	}
+
+	moves, moveDiags := migrateKeysToForEach(rs, keyerExpr)
+	diags = diags.Append(moveDiags)
+	if moveDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	verb := "Moved"
+	if dryRun {
+		verb = "Would move"
+	}
+	var output strings.Builder
+	for _, move := range moves {
+		fmt.Fprintf(&output, "%s %s to %s\n", verb, addr.Instance(move.from), addr.Instance(move.to))
+	}
+	fmt.Fprintf(&output, "\nTo migrate any other states of this configuration the next time they are planned, add the following to the module that declares %s:\n\n", addr.Resource)
+	output.WriteString(migrateKeysMovedBlocks(addr.Resource, moves))
+
+	if dryRun {
+		c.showDiagnostics(diags)
+		c.Ui.Output(output.String())
+		return 0 // This is as far as we go in dry-run mode
+	}
+
+	instances := make(map[addrs.InstanceKey]*states.ResourceInstance, len(rs.Instances))
+	for _, move := range moves {
+		instances[move.to] = rs.Instances[move.from]
+	}
+	rs.Instances = instances
+
+	b, backendDiags := c.Backend(nil, enc.State())
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Get schemas, if possible, before writing state
+	var schemas *tofu.Schemas
+	if isCloudMode(b) {
+		var schemaDiags tfdiags.Diagnostics
+		schemas, schemaDiags = c.MaybeGetSchemas(state, nil)
+		diags = diags.Append(schemaDiags)
+	}
+
+	if err := stateMgr.WriteState(state); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRmPersist, err))
+		return 1
+	}
+	if err := stateMgr.PersistState(schemas); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRmPersist, err))
+		return 1
+	}
+
+	c.showDiagnostics(diags)
+	c.Ui.Output(output.String())
+	return 0
+}
+
+// migrateKeysMove is a change of the instance key of a single resource
+// instance.
+type migrateKeysMove struct {
+	from, to addrs.InstanceKey
+}
+
+// migrateKeysToForEach evaluates the keyer expression for each instance of
+// the given resource, which must all have integer keys, and returns the
+// resulting changes of instance keys, ordered by the original keys.
+//
+// The expression can refer to count.index, the current key of the instance,
+// and to self, the attributes of the instance as recorded in the state.
+func migrateKeysToForEach(rs *states.Resource, keyer hcl.Expression) ([]migrateKeysMove, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	keys := make([]addrs.IntKey, 0, len(rs.Instances))
+	for key := range rs.Instances {
+		intKey, ok := key.(addrs.IntKey)
+		if !ok {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Resource doesn't use count",
+				fmt.Sprintf("The instance %s doesn't have an integer key, so %s is not currently using count.", rs.Addr.Instance(key), rs.Addr),
+			))
+			return nil, diags
+		}
+		keys = append(keys, intKey)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	funcs := (&lang.Scope{BaseDir: "."}).Functions()
+	moves := make([]migrateKeysMove, 0, len(keys))
+	seen := make(map[addrs.InstanceKey]addrs.InstanceKey, len(keys))
+	for _, key := range keys {
+		instAddr := rs.Addr.Instance(key)
+		self := cty.NullVal(cty.DynamicPseudoType)
+		if obj := rs.Instances[key].Current; obj != nil {
+			var err error
+			self, err = migrateKeysDecodeAttrs(obj)
+			if err != nil {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Failed to decode instance attributes",
+					fmt.Sprintf("The attributes of %s in the state are invalid: %s.", instAddr, err),
+				))
+				return nil, diags
+			}
+		}
+
+		ctx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				"count": cty.ObjectVal(map[string]cty.Value{
+					"index": cty.NumberIntVal(int64(key)),
+				}),
+				"self": self,
+			},
+			Functions: funcs,
+		}
+		val, hclDiags := keyer.Value(ctx)
+		diags = diags.Append(hclDiags)
+		if hclDiags.HasErrors() {
+			return nil, diags
+		}
+
+		newKey, err := migrateKeysInstanceKey(val)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid instance key",
+				fmt.Sprintf("The -keyer expression produced an invalid key for %s: %s.", instAddr, err),
+			))
+			return nil, diags
+		}
+		if prev, exists := seen[newKey]; exists {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Duplicate instance key",
+				fmt.Sprintf("The -keyer expression produced the same new address %s for both %s and %s. Each instance must have a unique key.", rs.Addr.Instance(newKey), rs.Addr.Instance(prev), instAddr),
+			))
+			return nil, diags
+		}
+		seen[newKey] = key
+		moves = append(moves, migrateKeysMove{from: key, to: newKey})
+	}
+	return moves, diags
+}
+
+// migrateKeysDecodeAttrs decodes the attributes of a resource instance
+// object without its schema, marking the values the state records as
+// sensitive.
+func migrateKeysDecodeAttrs(obj *states.ResourceInstanceObjectSrc) (cty.Value, error) {
+	ty, err := ctyjson.ImpliedType(obj.AttrsJSON)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	val, err := ctyjson.Unmarshal(obj.AttrsJSON, ty)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return val.MarkWithPaths(obj.AttrSensitivePaths), nil
+}
+
+// migrateKeysInstanceKey converts the result of the keyer expression to a
+// for_each instance key, with the same rules that apply to the keys of a
+// for_each argument.
+func migrateKeysInstanceKey(val cty.Value) (addrs.InstanceKey, error) {
+	if val.HasMark(marks.Sensitive) || val.ContainsMarked() {
+		return nil, fmt.Errorf("instance keys can't be sensitive")
+	}
+	if val.IsNull() {
+		return nil, fmt.Errorf("the key is null")
+	}
+	if !val.IsKnown() {
+		return nil, fmt.Errorf("the key is unknown")
+	}
+	strVal, err := convert.Convert(val, cty.String)
+	if err != nil {
+		return nil, fmt.Errorf("a string is required, but the result is %s", val.Type().FriendlyName())
+	}
+	if strVal.AsString() == "" {
+		return nil, fmt.Errorf("the key is an empty string")
+	}
+	return addrs.StringKey(strVal.AsString()), nil
+}
+
+// migrateKeysMovedBlocks returns moved blocks that record the given changes
+// of instance keys, to be added to the configuration of the resource.
+func migrateKeysMovedBlocks(addr addrs.Resource, moves []migrateKeysMove) string {
+	var buf strings.Builder
+	for i, move := range moves {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "moved {\n  from = %s\n  to   = %s\n}\n", addr.Instance(move.from), addr.Instance(move.to))
+	}
+	return buf.String()
+}
+
+func (c *StateMigrateKeysCommand) AutocompleteArgs() complete.Predictor {
+	return c.completePredictResourceAddress()
+}
+
+func (c *StateMigrateKeysCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-dry-run":      complete.PredictNothing,
+		"-from":         complete.PredictSet("count"),
+		"-to":           complete.PredictSet("for_each"),
+		"-keyer":        complete.PredictAnything,
+		"-backup":       complete.PredictFiles("*"),
+		"-lock":         completePredictBoolean,
+		"-lock-timeout": complete.PredictAnything,
+		"-state":        complete.PredictFiles("*"),
+	}
+}
+
+func (c *StateMigrateKeysCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state migrate-keys [options] -keyer=EXPRESSION ADDRESS
+
+  Rewrite the instance keys of a resource in the state when its configuration
+  changes from using count to using for_each.
+
+  Without this, OpenTofu would plan to destroy every instance with an integer
+  key and create new instances with the for_each keys. The -keyer expression
+  is evaluated once for each instance to produce its new key. It can refer to
+  count.index, the current key of the instance, and to self, the attributes
+  of the instance as recorded in the state. For example:
+
+      tofu state migrate-keys -keyer=self.name aws_instance.web
+
+  The command also prints a moved block for each instance, which you can add
+  to the configuration so that other states of the same configuration, such
+  as those of other workspaces, are migrated the next time they are planned.
+
+Options:
+
+  -keyer=EXPRESSION       Required. The expression that produces the new key
+                          of each instance.
+
+  -from=count             The way the resource currently declares its
+                          instances. Only "count" is supported.
+
+  -to=for_each            The way the resource will declare its instances.
+                          Only "for_each" is supported.
+
+  -dry-run                If set, prints out the new keys and the moved
+                          blocks but doesn't change the state.
+
+  -backup=PATH            Path where OpenTofu should write the backup
+                          state.
+
+  -lock=false             Don't hold a state lock during the operation. This is
+                          dangerous if others might concurrently run commands
+                          against the same workspace.
+
+  -lock-timeout=0s        Duration to retry a state lock.
+
+  -state=PATH             Path to the state file to update. Defaults to the
+                          current workspace state.
+
+  -ignore-remote-version  Continue even if remote and local OpenTofu versions
+                          are incompatible. This may result in an unusable
+                          workspace, and should be used with extreme caution.
+
+  -var 'foo=bar'          Set a value for one of the input variables in the root
+                          module of the configuration. Use this option more than
+                          once to set more than one variable.
+
+  -var-file=filename      Load variable values from the given file, in addition
+                          to the default files terraform.tfvars and *.auto.tfvars.
+                          Use this option more than once to include more than one
+                          variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateMigrateKeysCommand) Synopsis() string {
+	return "Rewrite instance keys when a resource switches from count to for_each"
+}
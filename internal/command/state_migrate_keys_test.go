@@ -0,0 +1,164 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+var testStateMigrateKeysAddr = addrs.Resource{
+	Mode: addrs.ManagedResourceMode,
+	Type: "test_instance",
+	Name: "foo",
+}.Absolute(addrs.RootModuleInstance)
+
+func testStateMigrateKeysState(names ...string) *states.State {
+	providerAddr := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	return states.BuildState(func(s *states.SyncState) {
+		for i, name := range names {
+			s.SetResourceInstanceCurrent(
+				testStateMigrateKeysAddr.Instance(addrs.IntKey(i)),
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON: []byte(`{"id":"` + name + `-id","name":"` + name + `","secret":"` + name + `"}`),
+					AttrSensitivePaths: []cty.PathValueMarks{
+						{Path: cty.GetAttrPath("secret"), Marks: cty.NewValueMarks("sensitive")},
+					},
+					Status: states.ObjectReady,
+				},
+				providerAddr,
+				addrs.NoKey,
+			)
+		}
+	})
+}
+
+func testStateMigrateKeysCommand(t *testing.T) (*StateMigrateKeysCommand, *cli.MockUi) {
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	return &StateMigrateKeysCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+				View:             view,
+			},
+		},
+	}, ui
+}
+
+func TestStateMigrateKeys(t *testing.T) {
+	statePath := testStateFile(t, testStateMigrateKeysState("web", "db"))
+
+	c, ui := testStateMigrateKeysCommand(t)
+	args := []string{
+		"-state", statePath,
+		"-from=count", "-to=for_each",
+		"-keyer=self.name",
+		"test_instance.foo",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	for _, want := range []string{
+		`Moved test_instance.foo[0] to test_instance.foo["web"]`,
+		`Moved test_instance.foo[1] to test_instance.foo["db"]`,
+		"moved {\n  from = test_instance.foo[1]\n  to   = test_instance.foo[\"db\"]\n}\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	rs := testStateRead(t, statePath).Resource(testStateMigrateKeysAddr)
+	if rs == nil || len(rs.Instances) != 2 {
+		t.Fatalf("wrong instances in state: %#v", rs)
+	}
+	is := rs.Instance(addrs.StringKey("db"))
+	if is == nil || is.Current == nil || !strings.Contains(string(is.Current.AttrsJSON), `"db-id"`) {
+		t.Fatalf("instance was not moved to its new key: %#v", is)
+	}
+}
+
+func TestStateMigrateKeys_countIndex(t *testing.T) {
+	statePath := testStateFile(t, testStateMigrateKeysState("web", "db"))
+
+	c, ui := testStateMigrateKeysCommand(t)
+	args := []string{
+		"-state", statePath,
+		`-keyer=format("node-%d", count.index)`,
+		"-dry-run",
+		"test_instance.foo",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), `Would move test_instance.foo[1] to test_instance.foo["node-1"]`; !strings.Contains(got, want) {
+		t.Errorf("wrong output\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// A dry run doesn't change the state.
+	rs := testStateRead(t, statePath).Resource(testStateMigrateKeysAddr)
+	if rs.Instance(addrs.IntKey(1)) == nil {
+		t.Fatal("dry run changed the state")
+	}
+}
+
+func TestStateMigrateKeys_invalidKeys(t *testing.T) {
+	tests := map[string]struct {
+		keyer string
+		want  string
+	}{
+		"duplicate": {
+			keyer: `"same"`,
+			want:  `test_instance.foo["same"]`,
+		},
+		"sensitive": {
+			keyer: "self.secret",
+			want:  "instance keys can't be sensitive",
+		},
+		"not a string": {
+			keyer: "[self.name]",
+			want:  "the result is tuple",
+		},
+		"unknown reference": {
+			keyer: "each.key",
+			want:  "Unknown variable",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			statePath := testStateFile(t, testStateMigrateKeysState("web", "db"))
+
+			c, ui := testStateMigrateKeysCommand(t)
+			args := []string{
+				"-state", statePath,
+				"-keyer=" + test.keyer,
+				"test_instance.foo",
+			}
+			if code := c.Run(args); code != 1 {
+				t.Fatalf("wrong exit code %d\n\n%s", code, ui.OutputWriter.String())
+			}
+			if got := ui.ErrorWriter.String(); !strings.Contains(got, test.want) {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.want)
+			}
+
+			rs := testStateRead(t, statePath).Resource(testStateMigrateKeysAddr)
+			if rs.Instance(addrs.IntKey(0)) == nil {
+				t.Fatal("state was changed despite the error")
+			}
+		})
+	}
+}
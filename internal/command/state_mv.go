@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/backend"
@@ -526,6 +527,25 @@ func (c *StateMvCommand) validateResourceMove(addrFrom, addrTo addrs.AbsResource
 	return diags
 }
 
+func (c *StateMvCommand) AutocompleteArgs() complete.Predictor {
+	return completePredictSequence{
+		c.completePredictResourceAddress(),
+		complete.PredictAnything,
+	}
+}
+
+func (c *StateMvCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-dry-run":      complete.PredictNothing,
+		"-backup":       complete.PredictFiles("*"),
+		"-backup-out":   complete.PredictFiles("*"),
+		"-lock":         completePredictBoolean,
+		"-lock-timeout": complete.PredictAnything,
+		"-state":        complete.PredictFiles("*"),
+		"-state-out":    complete.PredictFiles("*"),
+	}
+}
+
 func (c *StateMvCommand) Help() string {
 	helpText := `
 Usage: tofu [global options] state (move|mv) [options] SOURCE DESTINATION
@@ -0,0 +1,291 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+// StateOrphansCommand is a Command implementation that compares the
+// resources in the state with the resources declared in the configuration
+// and reports the ones that only appear on one side.
+type StateOrphansCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateOrphansCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var statePath string
+	cmdFlags := c.Meta.defaultFlagSet("state orphans")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.StringVar(&statePath, "state", "", "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return cli.RunResultHelp
+	}
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("The state orphans command expects no arguments.")
+		return cli.RunResultHelp
+	}
+
+	if statePath != "" {
+		c.Meta.statePath = statePath
+	}
+
+	config, diags := c.loadConfig(".")
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(&BackendOpts{
+		Config: config.Module.Backend,
+	}, enc.State())
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// This is a read-only command
+	c.ignoreRemoteVersionConflict(b)
+
+	// Get the state
+	env, err := c.Workspace()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
+		return 1
+	}
+	stateMgr, err := b.StateMgr(env)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	state := stateMgr.State()
+	if state == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+
+	orphans, unapplied := findOrphanedResources(config, state)
+
+	c.showDiagnostics(diags)
+
+	if len(orphans) == 0 && len(unapplied) == 0 {
+		c.Ui.Output("The state and the configuration contain the same managed resources.")
+		return 0
+	}
+
+	if len(orphans) != 0 {
+		c.Ui.Output("Resources in the state but not in the configuration:")
+		for _, addr := range orphans {
+			c.Ui.Output("  " + addr.String())
+		}
+		c.Ui.Output("")
+	}
+	if len(unapplied) != 0 {
+		c.Ui.Output("Resources in the configuration but not in the state:")
+		for _, addr := range unapplied {
+			c.Ui.Output("  " + addr.String())
+		}
+		c.Ui.Output("")
+	}
+
+	if len(orphans) != 0 {
+		c.Ui.Output("To forget the orphaned resources without destroying them, add the following")
+		c.Ui.Output("blocks to the root module:")
+		c.Ui.Output("")
+		for _, addr := range orphans {
+			c.Ui.Output(fmt.Sprintf("removed {\n  from = %s\n}\n", addr))
+		}
+	}
+	if len(unapplied) != 0 {
+		c.Ui.Output("To adopt existing infrastructure for the configured resources, add the")
+		c.Ui.Output("following blocks to the root module and fill in the import IDs:")
+		c.Ui.Output("")
+		for _, addr := range unapplied {
+			var buf strings.Builder
+			buf.WriteString("import {\n")
+			if resourceExpands(config, addr) {
+				buf.WriteString("  # Add the instance keys of the object to import to this address.\n")
+			}
+			fmt.Fprintf(&buf, "  to = %s\n", addr)
+			buf.WriteString("  id = \"\"\n")
+			buf.WriteString("}\n")
+			c.Ui.Output(buf.String())
+		}
+	}
+
+	return 0
+}
+
+// findOrphanedResources returns the managed resources that are tracked in
+// the given state but not declared in the given configuration, and the
+// managed resources that are declared in the configuration but have no
+// objects in the state.
+//
+// Resources that are already accounted for by a moved, removed, or import
+// block are not reported, because the next plan will reconcile them anyway.
+func findOrphanedResources(config *configs.Config, state *states.State) ([]addrs.ConfigResource, []addrs.ConfigResource) {
+	var covering []addrs.Targetable
+	addCovering := func(subject addrs.ConfigMoveable) {
+		if subject, ok := subject.(addrs.Targetable); ok {
+			covering = append(covering, subject)
+		}
+	}
+	config.DeepEach(func(c *configs.Config) {
+		for _, mc := range c.Module.Moved {
+			addCovering(mc.From.ConfigMoveable(c.Path))
+			addCovering(mc.To.ConfigMoveable(c.Path))
+		}
+		for _, rc := range c.Module.Removed {
+			// Removed blocks are relative to the module they are declared in.
+			base := make(addrs.Module, 0, len(c.Path))
+			base = append(base, c.Path...)
+			switch subject := rc.From.RelSubject.(type) {
+			case addrs.Module:
+				addCovering(append(base, subject...))
+			case addrs.ConfigResource:
+				addCovering(subject.Resource.InModule(append(base, subject.Module...)))
+			}
+		}
+		for _, ic := range c.Module.Import {
+			addCovering(ic.StaticTo)
+		}
+	})
+	covered := func(addr addrs.ConfigResource) bool {
+		for _, subject := range covering {
+			if subject.TargetContains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var orphans, unapplied []addrs.ConfigResource
+	inState := make(map[string]bool)
+	for _, ms := range state.Modules {
+		for _, rs := range ms.Resources {
+			if rs.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				continue
+			}
+			addr := rs.Addr.Config()
+			if inState[addr.String()] {
+				continue
+			}
+			inState[addr.String()] = true
+
+			if cfg := config.Descendent(addr.Module); cfg != nil && cfg.Module.ResourceByAddr(addr.Resource) != nil {
+				continue
+			}
+			if !covered(addr) {
+				orphans = append(orphans, addr)
+			}
+		}
+	}
+
+	config.DeepEach(func(c *configs.Config) {
+		for _, rc := range c.Module.ManagedResources {
+			addr := rc.Addr().InModule(c.Path)
+			if !inState[addr.String()] && !covered(addr) {
+				unapplied = append(unapplied, addr)
+			}
+		}
+	})
+
+	sortConfigResources(orphans)
+	sortConfigResources(unapplied)
+	return orphans, unapplied
+}
+
+// resourceExpands returns true if the given resource, or any of the module
+// calls that contain it, uses count or for_each, in which case an import
+// block must name a specific instance.
+func resourceExpands(config *configs.Config, addr addrs.ConfigResource) bool {
+	cfg := config.Descendent(addr.Module)
+	if cfg == nil {
+		return false
+	}
+	if rc := cfg.Module.ResourceByAddr(addr.Resource); rc != nil && (rc.Count != nil || rc.ForEach != nil) {
+		return true
+	}
+	for ; cfg.Parent != nil; cfg = cfg.Parent {
+		call := cfg.Parent.Module.ModuleCalls[cfg.Path[len(cfg.Path)-1]]
+		if call != nil && (call.Count != nil || call.ForEach != nil) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortConfigResources(list []addrs.ConfigResource) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+}
+
+func (c *StateOrphansCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state orphans [options]
+
+  Compare the OpenTofu state with the configuration in the current
+  directory.
+
+  This command lists the managed resources that are tracked in the state
+  but no longer declared in the configuration, and the managed resources
+  that are declared in the configuration but have not been created yet.
+
+  For each of them it suggests a "removed" or "import" block that can be
+  used to reconcile the state with the configuration without destroying
+  or recreating infrastructure. Resources that are already covered by a
+  "moved", "removed", or "import" block are not reported.
+
+  This command does not modify the state or the configuration.
+
+Options:
+
+  -state=statefile    Path to a OpenTofu state file to use to look
+                      up OpenTofu-managed resources. By default, OpenTofu
+                      will consult the state of the currently-selected
+                      workspace.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateOrphansCommand) Synopsis() string {
+	return "Compare the resources in the state and the configuration"
+}
@@ -0,0 +1,114 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestStateOrphans(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-orphans"), td)
+	defer testChdir(t, td)()
+
+	state := testState()
+	for _, name := range []string{"gone", "old_name"} {
+		state.RootModule().SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: name,
+			}.Instance(addrs.NoKey),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"` + name + `"}`),
+				Status:    states.ObjectReady,
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+			addrs.NoKey,
+		)
+	}
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	ui := cli.NewMockUi()
+	c := &StateOrphansCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	expected := strings.TrimSpace(testStateOrphansOutput)
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	if actual != expected {
+		t.Fatalf("Expected:\n%s\n\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestStateOrphans_none(t *testing.T) {
+	td := t.TempDir()
+	if err := os.WriteFile(filepath.Join(td, "main.tf"), []byte(`resource "test_instance" "foo" {}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer testChdir(t, td)()
+
+	statePath := testStateFile(t, testState())
+
+	ui := cli.NewMockUi()
+	c := &StateOrphansCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	if code := c.Run([]string{"-state", statePath}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := strings.TrimSpace(ui.OutputWriter.String()), "The state and the configuration contain the same managed resources."; got != want {
+		t.Fatalf("wrong output\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+const testStateOrphansOutput = `
+Resources in the state but not in the configuration:
+  test_instance.gone
+
+Resources in the configuration but not in the state:
+  test_instance.new
+
+To forget the orphaned resources without destroying them, add the following
+blocks to the root module:
+
+removed {
+  from = test_instance.gone
+}
+
+To adopt existing infrastructure for the configured resources, add the
+following blocks to the root module and fill in the import IDs:
+
+import {
+  # Add the instance keys of the object to import to this address.
+  to = test_instance.new
+  id = ""
+}
+`
@@ -6,6 +6,7 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -31,9 +32,10 @@ type StatePushCommand struct {
 
 func (c *StatePushCommand) Run(args []string) int {
 	args = c.Meta.process(args)
-	var flagForce bool
+	var flagForce, flagDryRun bool
 	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state push")
 	cmdFlags.BoolVar(&flagForce, "force", false, "")
+	cmdFlags.BoolVar(&flagDryRun, "dry-run", false, "")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
 	if err := cmdFlags.Parse(args); err != nil {
@@ -114,7 +116,8 @@ func (c *StatePushCommand) Run(args []string) int {
 		return 1
 	}
 
-	if c.stateLock {
+	// A dry run only reads the destination state, so it doesn't lock it.
+	if c.stateLock && !flagDryRun {
 		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
 		if diags := stateLocker.Lock(stateMgr, "state-push"); diags.HasErrors() {
 			c.showDiagnostics(diags)
@@ -137,6 +140,10 @@ func (c *StatePushCommand) Run(args []string) int {
 		srcStateFile = statemgr.NewStateFile()
 	}
 
+	if flagDryRun {
+		return c.dryRun(srcStateFile, statemgr.Export(stateMgr), workspace, flagForce)
+	}
+
 	// Import it, forcing through the lineage/serial if requested and possible.
 	if err := statemgr.Import(srcStateFile, stateMgr, flagForce); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to write state: %s", err))
@@ -163,6 +170,105 @@ func (c *StatePushCommand) Run(args []string) int {
 	return 0
 }
 
+// dryRun describes, as JSON, how pushing the given source state would
+// compare with the current destination state, without writing anything.
+//
+// It returns a non-zero exit code if the push would be rejected.
+func (c *StatePushCommand) dryRun(src, dst *statefile.File, workspace string, force bool) int {
+	result := statePushDryRun{
+		FormatVersion: "1.0",
+		Workspace:     workspace,
+		Source:        newStatePushSnapshot(src),
+		Destination:   newStatePushSnapshot(dst),
+		Force:         force,
+	}
+	result.Relationship = statePushRelationship(src, dst)
+	result.ResourcesDelta = result.Source.Resources - result.Destination.Resources
+	result.InstancesDelta = result.Source.Instances - result.Destination.Instances
+	if err := statemgr.CheckValidImport(src, dst); err != nil {
+		result.Rejection = err.Error()
+	}
+	result.WouldWrite = force || result.Rejection == ""
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to marshal dry run result: %s", err))
+		return 1
+	}
+	c.Ui.Output(string(out))
+
+	if !result.WouldWrite {
+		return 1
+	}
+	return 0
+}
+
+// statePushDryRun is the JSON output of "tofu state push -dry-run".
+type statePushDryRun struct {
+	FormatVersion string            `json:"format_version"`
+	Workspace     string            `json:"workspace"`
+	Source        statePushSnapshot `json:"source"`
+	Destination   statePushSnapshot `json:"destination"`
+
+	// Relationship is how the source snapshot relates to the destination
+	// snapshot: "newer", "older", "equal", "unrelated" (different lineage),
+	// or "legacy" (one of them has no lineage).
+	Relationship string `json:"relationship"`
+
+	ResourcesDelta int `json:"resources_delta"`
+	InstancesDelta int `json:"instances_delta"`
+
+	// Rejection explains why the push would be rejected without -force,
+	// or is empty if it wouldn't be.
+	Rejection  string `json:"rejection,omitempty"`
+	Force      bool   `json:"force"`
+	WouldWrite bool   `json:"would_write"`
+}
+
+type statePushSnapshot struct {
+	Lineage   string `json:"lineage"`
+	Serial    uint64 `json:"serial"`
+	Resources int    `json:"resources"`
+	Instances int    `json:"instances"`
+}
+
+func newStatePushSnapshot(f *statefile.File) statePushSnapshot {
+	var ret statePushSnapshot
+	if f == nil {
+		return ret
+	}
+	ret.Lineage = f.Lineage
+	ret.Serial = f.Serial
+	if f.State != nil {
+		stats := collectStateStats(f.State, 0)
+		ret.Resources = stats.Resources
+		ret.Instances = stats.Instances
+	}
+	return ret
+}
+
+func statePushRelationship(src, dst *statefile.File) string {
+	var srcMeta, dstMeta statemgr.SnapshotMeta
+	if src != nil {
+		srcMeta = statemgr.SnapshotMeta{Lineage: src.Lineage, Serial: src.Serial}
+	}
+	if dst != nil {
+		dstMeta = statemgr.SnapshotMeta{Lineage: dst.Lineage, Serial: dst.Serial}
+	}
+	switch srcMeta.Compare(dstMeta) {
+	case statemgr.SnapshotNewer:
+		return "newer"
+	case statemgr.SnapshotOlder:
+		return "older"
+	case statemgr.SnapshotEqual:
+		return "equal"
+	case statemgr.SnapshotUnrelated:
+		return "unrelated"
+	default:
+		return "legacy"
+	}
+}
+
 func (c *StatePushCommand) Help() string {
 	helpText := `
 Usage: tofu [global options] state push [options] PATH
@@ -183,6 +289,11 @@ Usage: tofu [global options] state push [options] PATH
 
 Options:
 
+  -dry-run            Don't write the state. Instead, describe as JSON how
+                      its lineage, serial and resource counts compare with
+                      the current state, and whether it would be written.
+                      Exits with a non-zero status if it wouldn't be.
+
   -force              Write the state even if lineages don't match or the
                       remote serial is higher.
 
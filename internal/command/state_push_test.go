@@ -7,6 +7,7 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -320,3 +321,68 @@ func TestStatePush_checkRequiredVersion(t *testing.T) {
 		t.Fatalf("output should not point to met version constraint, but is:\n\n%s", errStr)
 	}
 }
+
+func TestStatePush_dryRun(t *testing.T) {
+	// Create a temporary working directory that is empty
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-push-bad-lineage"), td)
+	defer testChdir(t, td)()
+
+	expected := testStateRead(t, "local-state.tfstate")
+
+	p := testProvider()
+	ui := cli.NewMockUi()
+	view, _ := testView(t)
+	c := &StatePushCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+
+	args := []string{"-dry-run", "replace.tfstate"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var got statePushDryRun
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &got); err != nil {
+		t.Fatalf("invalid output: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+	if got.Relationship != "unrelated" || got.WouldWrite || got.Rejection == "" {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+	if got.Source.Lineage != "hello" || got.Destination.Lineage != "mismatch" {
+		t.Fatalf("unexpected lineages: %#v", got)
+	}
+
+	// A dry run never writes the state.
+	actual := testStateRead(t, "local-state.tfstate")
+	if !actual.Equal(expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	ui = cli.NewMockUi()
+	c = &StatePushCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+			View:             view,
+		},
+	}
+	args = []string{"-dry-run", "-force", "replace.tfstate"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &got); err != nil {
+		t.Fatalf("invalid output: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+	if !got.WouldWrite || !got.Force {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+	actual = testStateRead(t, "local-state.tfstate")
+	if !actual.Equal(expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
@@ -6,7 +6,9 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/cli"
@@ -15,6 +17,8 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/clistate"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -32,9 +36,10 @@ type StateReplaceProviderCommand struct {
 func (c *StateReplaceProviderCommand) Run(args []string) int {
 	args = c.Meta.process(args)
 
-	var autoApprove bool
+	var autoApprove, dryRun bool
 	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state replace-provider")
 	cmdFlags.BoolVar(&autoApprove, "auto-approve", false, "skip interactive approval of replacements")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "only report the replacements as JSON")
 	cmdFlags.StringVar(&c.backupPath, "backup", "-", "backup")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock states")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
@@ -56,8 +61,9 @@ func (c *StateReplaceProviderCommand) Run(args []string) int {
 
 	var diags tfdiags.Diagnostics
 
-	// Parse from/to arguments into providers
-	from, fromDiags := addrs.ParseProviderSourceString(args[0])
+	// Parse from/to arguments into provider patterns, which are exact
+	// provider addresses unless they contain wildcards.
+	from, fromDiags := parseProviderPattern(args[0])
 	if fromDiags.HasErrors() {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
@@ -65,13 +71,19 @@ func (c *StateReplaceProviderCommand) Run(args []string) int {
 			fromDiags.Err().Error(),
 		))
 	}
-	to, toDiags := addrs.ParseProviderSourceString(args[1])
+	to, toDiags := parseProviderPattern(args[1])
 	if toDiags.HasErrors() {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
 			fmt.Sprintf(`Invalid "to" provider %q`, args[1]),
 			toDiags.Err().Error(),
 		))
+	} else if err := to.CheckReplacement(from); err != nil && !fromDiags.HasErrors() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			fmt.Sprintf(`Invalid "to" provider %q`, args[1]),
+			err.Error(),
+		))
 	}
 	if diags.HasErrors() {
 		c.showDiagnostics(diags)
@@ -126,17 +138,27 @@ func (c *StateReplaceProviderCommand) Run(args []string) int {
 		return 1
 	}
 
-	var willReplace []*states.Resource
+	// Find the resources whose provider matches, grouped by the provider
+	// replacement, since a pattern can match several different providers.
+	replacements := planProviderReplacements(resources, from, to)
 
-	// Update all matching resources with new provider
-	for _, resource := range resources {
-		if resource.ProviderConfig.Provider.Equals(from) {
-			willReplace = append(willReplace, resource)
+	if dryRun {
+		locks, lockDiags := c.lockedDependencies()
+		diags = diags.Append(lockDiags)
+		report, err := providerReplacementsReport(replacements, replacementProviderLocks(locks, replacements))
+		if err != nil {
+			diags = diags.Append(err)
 		}
+		c.showDiagnostics(diags)
+		if diags.HasErrors() {
+			return 1
+		}
+		c.Ui.Output(report)
+		return 0
 	}
 	c.showDiagnostics(diags)
 
-	if len(willReplace) == 0 {
+	if len(replacements) == 0 {
 		c.Ui.Output("No matching resources found.")
 		return 0
 	}
@@ -144,13 +166,18 @@ func (c *StateReplaceProviderCommand) Run(args []string) int {
 	// Explain the changes
 	colorize := c.Colorize()
 	c.Ui.Output("OpenTofu will perform the following actions:\n")
-	c.Ui.Output(colorize.Color("  [yellow]~[reset] Updating provider:"))
-	c.Ui.Output(colorize.Color(fmt.Sprintf("    [red]-[reset] %s", from)))
-	c.Ui.Output(colorize.Color(fmt.Sprintf("    [green]+[reset] %s\n", to)))
-
-	c.Ui.Output(colorize.Color(fmt.Sprintf("[bold]Changing[reset] %d resources:\n", len(willReplace))))
-	for _, resource := range willReplace {
-		c.Ui.Output(colorize.Color(fmt.Sprintf("  %s", resource.Addr)))
+	willReplace := 0
+	for _, replacement := range replacements {
+		c.Ui.Output(colorize.Color("  [yellow]~[reset] Updating provider:"))
+		c.Ui.Output(colorize.Color(fmt.Sprintf("    [red]-[reset] %s", replacement.From)))
+		c.Ui.Output(colorize.Color(fmt.Sprintf("    [green]+[reset] %s\n", replacement.To)))
+
+		c.Ui.Output(colorize.Color(fmt.Sprintf("[bold]Changing[reset] %d resources:\n", len(replacement.Resources))))
+		for _, resource := range replacement.Resources {
+			c.Ui.Output(colorize.Color(fmt.Sprintf("  %s", resource.Addr)))
+		}
+		c.Ui.Output("")
+		willReplace += len(replacement.Resources)
 	}
 
 	// Confirm
@@ -171,8 +198,10 @@ func (c *StateReplaceProviderCommand) Run(args []string) int {
 	}
 
 	// Update the provider for each resource
-	for _, resource := range willReplace {
-		resource.ProviderConfig.Provider = to
+	for _, replacement := range replacements {
+		for _, resource := range replacement.Resources {
+			resource.ProviderConfig.Provider = replacement.To
+		}
 	}
 
 	b, backendDiags := c.Backend(nil, enc.State())
@@ -200,8 +229,24 @@ func (c *StateReplaceProviderCommand) Run(args []string) int {
 		return 1
 	}
 
+	// The new providers are locked at the same versions as the providers
+	// they replace, so that the next "tofu init" installs them without
+	// needing -upgrade. Their checksums are recorded when they're installed.
+	locks, lockDiags := c.lockedDependencies()
+	diags = diags.Append(lockDiags)
+	newLocks := replacementProviderLocks(locks, replacements)
+	if !lockDiags.HasErrors() && len(newLocks) > 0 {
+		for _, lock := range newLocks {
+			locks.SetProvider(lock.Provider(), lock.Version(), lock.VersionConstraints(), nil)
+		}
+		diags = diags.Append(c.replaceLockedDependencies(locks))
+	}
+
 	c.showDiagnostics(diags)
-	c.Ui.Output(fmt.Sprintf("\nSuccessfully replaced provider for %d resources.", len(willReplace)))
+	c.Ui.Output(fmt.Sprintf("\nSuccessfully replaced provider for %d resources.", willReplace))
+	if len(newLocks) > 0 && !diags.HasErrors() {
+		c.Ui.Output(fmt.Sprintf("Added %d providers to the dependency lock file. Run \"tofu init\" to install them.", len(newLocks)))
+	}
 	return 0
 }
 
@@ -215,6 +260,11 @@ Options:
 
   -auto-approve           Skip interactive approval.
 
+  -dry-run                Don't change the state, and instead print a JSON
+                          report of the resources whose provider would be
+                          replaced and the providers that would be added to
+                          the dependency lock file.
+
   -lock=false             Don't hold a state lock during the operation. This is
                           dangerous if others might concurrently run commands
                           against the same workspace.
@@ -243,3 +293,112 @@ Options:
 func (c *StateReplaceProviderCommand) Synopsis() string {
 	return "Replace provider in the state"
 }
+
+// providerReplacement is a replacement of one provider address in the state
+// with another, for the given resources.
+type providerReplacement struct {
+	From, To  addrs.Provider
+	Resources []*states.Resource
+}
+
+// planProviderReplacements finds the resources whose provider matches the
+// from pattern, grouped by their replacement provider and sorted by address.
+func planProviderReplacements(resources []*states.Resource, from, to providerPattern) []*providerReplacement {
+	byFrom := make(map[addrs.Provider]*providerReplacement)
+	var ret []*providerReplacement
+	for _, resource := range resources {
+		addr := resource.ProviderConfig.Provider
+		if !from.Matches(addr) {
+			continue
+		}
+		replacement, exists := byFrom[addr]
+		if !exists {
+			replacement = &providerReplacement{From: addr, To: to.Replace(addr)}
+			if replacement.To.Equals(addr) {
+				continue
+			}
+			byFrom[addr] = replacement
+			ret = append(ret, replacement)
+		}
+		replacement.Resources = append(replacement.Resources, resource)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].From.String() < ret[j].From.String()
+	})
+	for _, replacement := range ret {
+		sort.Slice(replacement.Resources, func(i, j int) bool {
+			return replacement.Resources[i].Addr.String() < replacement.Resources[j].Addr.String()
+		})
+	}
+	return ret
+}
+
+// replacementProviderLocks returns the dependency locks to add for the new
+// providers of the given replacements, for each replaced provider that is
+// locked when its replacement isn't. The new locks have the same version and
+// constraints as the replaced providers, but no checksums.
+func replacementProviderLocks(locks *depsfile.Locks, replacements []*providerReplacement) []*depsfile.ProviderLock {
+	if locks == nil {
+		return nil
+	}
+	var ret []*depsfile.ProviderLock
+	added := make(map[addrs.Provider]bool)
+	for _, replacement := range replacements {
+		old := locks.Provider(replacement.From)
+		if old == nil || locks.Provider(replacement.To) != nil || added[replacement.To] {
+			continue
+		}
+		ret = append(ret, depsfile.NewProviderLock(replacement.To, old.Version(), old.VersionConstraints(), nil))
+		added[replacement.To] = true
+	}
+	return ret
+}
+
+// providerReplacementsReport returns the JSON report of the given
+// replacements and new dependency locks that the -dry-run option prints.
+func providerReplacementsReport(replacements []*providerReplacement, newLocks []*depsfile.ProviderLock) (string, error) {
+	type replacementJSON struct {
+		From      string   `json:"from"`
+		To        string   `json:"to"`
+		Resources []string `json:"resources"`
+	}
+	type lockJSON struct {
+		Provider    string `json:"provider"`
+		Version     string `json:"version"`
+		Constraints string `json:"constraints,omitempty"`
+	}
+	report := struct {
+		FormatVersion     string            `json:"format_version"`
+		Replacements      []replacementJSON `json:"replacements"`
+		LockFileAdditions []lockJSON        `json:"lock_file_additions"`
+	}{
+		FormatVersion:     "1.0",
+		Replacements:      []replacementJSON{},
+		LockFileAdditions: []lockJSON{},
+	}
+	for _, replacement := range replacements {
+		r := replacementJSON{
+			From:      replacement.From.String(),
+			To:        replacement.To.String(),
+			Resources: make([]string, 0, len(replacement.Resources)),
+		}
+		for _, resource := range replacement.Resources {
+			r.Resources = append(r.Resources, resource.Addr.String())
+		}
+		report.Replacements = append(report.Replacements, r)
+	}
+	for _, lock := range newLocks {
+		report.LockFileAdditions = append(report.LockFileAdditions, lockJSON{
+			Provider:    lock.Provider().String(),
+			Version:     lock.Version().String(),
+			Constraints: getproviders.VersionConstraintsString(lock.VersionConstraints()),
+		})
+	}
+
+	src, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the report: %w", err)
+	}
+	return string(src), nil
+}
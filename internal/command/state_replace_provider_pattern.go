@@ -0,0 +1,142 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// providerPatternWildcard is the value of a part of a provider pattern that
+// matches any value.
+const providerPatternWildcard = "*"
+
+// providerPattern is a provider source address in which any of the hostname,
+// namespace and type may be a wildcard, as accepted by the arguments of
+// "tofu state replace-provider".
+type providerPattern struct {
+	Hostname, Namespace, Type string
+}
+
+// parseProviderPattern parses a provider pattern of the form
+// [HOSTNAME/]NAMESPACE/TYPE. A pattern without wildcards is parsed in the
+// same way as a provider source address.
+func parseProviderPattern(str string) (providerPattern, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if !strings.Contains(str, providerPatternWildcard) {
+		addr, moreDiags := addrs.ParseProviderSourceString(str)
+		diags = diags.Append(moreDiags)
+		return providerPattern{
+			Hostname:  addr.Hostname.String(),
+			Namespace: addr.Namespace,
+			Type:      addr.Type,
+		}, diags
+	}
+
+	parts := strings.Split(str, "/")
+	var ret providerPattern
+	switch len(parts) {
+	case 2:
+		ret = providerPattern{Hostname: addrs.DefaultProviderRegistryHost.String(), Namespace: parts[0], Type: parts[1]}
+	case 3:
+		ret = providerPattern{Hostname: parts[0], Namespace: parts[1], Type: parts[2]}
+	default:
+		diags = diags.Append(fmt.Errorf("invalid provider pattern: must be of the form [HOSTNAME/]NAMESPACE/TYPE, where each part may be %q to match any value", providerPatternWildcard))
+		return ret, diags
+	}
+
+	for _, part := range []*string{&ret.Hostname, &ret.Namespace, &ret.Type} {
+		if *part == providerPatternWildcard {
+			continue
+		}
+		if strings.Contains(*part, providerPatternWildcard) {
+			diags = diags.Append(fmt.Errorf("invalid provider pattern: a wildcard must be a whole part of the address, but %q contains %q", *part, providerPatternWildcard))
+			continue
+		}
+
+		var err error
+		if part == &ret.Hostname {
+			var hostname svchost.Hostname
+			hostname, err = svchost.ForComparison(*part)
+			if err != nil {
+				err = fmt.Errorf("invalid provider source hostname: %w", err)
+			}
+			*part = hostname.String()
+		} else {
+			given := *part
+			*part, err = addrs.ParseProviderPart(given)
+			if err != nil {
+				err = fmt.Errorf("invalid provider name %q: %w", given, err)
+			}
+		}
+		if err != nil {
+			diags = diags.Append(err)
+		}
+	}
+	return ret, diags
+}
+
+// HasWildcard returns true if any part of the pattern is a wildcard.
+func (p providerPattern) HasWildcard() bool {
+	return p.Hostname == providerPatternWildcard || p.Namespace == providerPatternWildcard || p.Type == providerPatternWildcard
+}
+
+// Matches returns true if the given provider address matches the pattern.
+//
+// A pattern with wildcards never matches legacy or built-in providers, which
+// must be replaced by naming them explicitly.
+func (p providerPattern) Matches(addr addrs.Provider) bool {
+	if p.HasWildcard() && (addr.IsLegacy() || addr.IsBuiltIn()) {
+		return false
+	}
+	return matchProviderPatternPart(p.Hostname, addr.Hostname.String()) &&
+		matchProviderPatternPart(p.Namespace, addr.Namespace) &&
+		matchProviderPatternPart(p.Type, addr.Type)
+}
+
+// CheckReplacement returns an error if the pattern can't be used as the
+// replacement for providers matching the given pattern, because it has a
+// wildcard where the other pattern has none.
+func (p providerPattern) CheckReplacement(from providerPattern) error {
+	for _, part := range []struct {
+		name     string
+		from, to string
+	}{
+		{"hostname", from.Hostname, p.Hostname},
+		{"namespace", from.Namespace, p.Namespace},
+		{"type", from.Type, p.Type},
+	} {
+		if part.to == providerPatternWildcard && part.from != providerPatternWildcard {
+			return fmt.Errorf("the %s of the new provider can only be %q if the %s of the old provider is also %q", part.name, providerPatternWildcard, part.name, providerPatternWildcard)
+		}
+	}
+	return nil
+}
+
+// Replace returns the address that replaces the given provider address, which
+// must match the pattern that the receiver was checked against using
+// CheckReplacement. Wildcard parts keep the value from the given address.
+func (p providerPattern) Replace(addr addrs.Provider) addrs.Provider {
+	ret := addr
+	if p.Hostname != providerPatternWildcard {
+		ret.Hostname = svchost.Hostname(p.Hostname)
+	}
+	if p.Namespace != providerPatternWildcard {
+		ret.Namespace = p.Namespace
+	}
+	if p.Type != providerPatternWildcard {
+		ret.Type = p.Type
+	}
+	return ret
+}
+
+func matchProviderPatternPart(pattern, value string) bool {
+	return pattern == providerPatternWildcard || pattern == value
+}
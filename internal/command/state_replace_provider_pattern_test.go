@@ -0,0 +1,123 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+func TestProviderPattern(t *testing.T) {
+	tests := map[string]struct {
+		from, to string
+		provider addrs.Provider
+		want     string
+	}{
+		"exact": {
+			"hashicorp/aws", "acmecorp/aws",
+			addrs.NewDefaultProvider("aws"),
+			"registry.opentofu.org/acmecorp/aws",
+		},
+		"any namespace and type": {
+			"registry.terraform.io/*/*", "registry.opentofu.org/*/*",
+			addrs.MustParseProviderSourceString("registry.terraform.io/hashicorp/aws"),
+			"registry.opentofu.org/hashicorp/aws",
+		},
+		"any hostname": {
+			"*/hashicorp/aws", "*/acmecorp/aws",
+			addrs.MustParseProviderSourceString("example.com/hashicorp/aws"),
+			"example.com/acmecorp/aws",
+		},
+		"no match": {
+			"registry.terraform.io/*/*", "registry.opentofu.org/*/*",
+			addrs.NewDefaultProvider("aws"),
+			"",
+		},
+		"legacy provider": {
+			"*/*", "hashicorp/*",
+			addrs.NewLegacyProvider("aws"),
+			"",
+		},
+		"built-in provider": {
+			"*/*/terraform", "hashicorp/terraform",
+			addrs.NewBuiltInProvider("terraform"),
+			"",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, diags := parseProviderPattern(test.from)
+			if diags.HasErrors() {
+				t.Fatal(diags.Err())
+			}
+			to, diags := parseProviderPattern(test.to)
+			if diags.HasErrors() {
+				t.Fatal(diags.Err())
+			}
+			if err := to.CheckReplacement(from); err != nil {
+				t.Fatal(err)
+			}
+
+			if !from.Matches(test.provider) {
+				if test.want != "" {
+					t.Fatalf("%s doesn't match %s", test.from, test.provider)
+				}
+				return
+			}
+			if test.want == "" {
+				t.Fatalf("%s unexpectedly matches %s", test.from, test.provider)
+			}
+			if got := to.Replace(test.provider).String(); got != test.want {
+				t.Errorf("wrong replacement %s; want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProviderPattern_invalid(t *testing.T) {
+	tests := map[string]struct {
+		from, to string
+		wantErr  string
+	}{
+		"partial wildcard": {
+			"hashi*/aws", "acmecorp/aws",
+			"a wildcard must be a whole part of the address",
+		},
+		"too many parts": {
+			"example.com/hashicorp/aws/*", "acmecorp/aws",
+			"must be of the form [HOSTNAME/]NAMESPACE/TYPE",
+		},
+		"invalid name": {
+			"*/aws_v2", "acmecorp/aws",
+			`invalid provider name "aws_v2"`,
+		},
+		"wildcard only in new provider": {
+			"registry.terraform.io/hashicorp/*", "*/acmecorp/*",
+			"the hostname of the new provider can only be",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, diags := parseProviderPattern(test.from)
+			to, toDiags := parseProviderPattern(test.to)
+			diags = diags.Append(toDiags)
+			var err error
+			if diags.HasErrors() {
+				err = diags.Err()
+			} else {
+				err = to.CheckReplacement(from)
+			}
+			if err == nil {
+				t.Fatal("unexpected success")
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -7,13 +7,17 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/mitchellh/cli"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/states"
 )
 
@@ -141,6 +145,133 @@ func TestStateReplaceProvider(t *testing.T) {
 		testStateOutput(t, backups[0], testStateReplaceProviderOutputOriginal)
 	})
 
+	t.Run("pattern", func(t *testing.T) {
+		statePath := testStateFile(t, state)
+
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		c := &StateReplaceProviderCommand{
+			StateMeta{
+				Meta: Meta{
+					Ui:   ui,
+					View: view,
+				},
+			},
+		}
+
+		// The wildcard doesn't match the legacy azurerm provider.
+		args := []string{
+			"-state", statePath,
+			"-auto-approve",
+			"registry.opentofu.org/hashicorp/*",
+			"acmecorp/*",
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("return code: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+
+		testStateOutput(t, statePath, testStateReplaceProviderOutput)
+	})
+
+	t.Run("dry run with lock file", func(t *testing.T) {
+		td := t.TempDir()
+		defer testChdir(t, td)()
+		statePath := testStateFile(t, state)
+
+		locks := depsfile.NewLocks()
+		locks.SetProvider(
+			addrs.NewDefaultProvider("aws"),
+			getproviders.MustParseVersion("4.2.0"),
+			getproviders.MustParseVersionConstraints("~> 4.0"),
+			[]getproviders.Hash{"h1:example"},
+		)
+		if diags := depsfile.SaveLocksToFile(locks, dependencyLockFilename); diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		c := &StateReplaceProviderCommand{
+			StateMeta{
+				Meta: Meta{
+					Ui:   ui,
+					View: view,
+				},
+			},
+		}
+		args := []string{
+			"-state", statePath,
+			"-dry-run",
+			"hashicorp/aws",
+			"acmecorp/aws",
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("return code: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+
+		var report struct {
+			Replacements []struct {
+				From, To  string
+				Resources []string
+			}
+			LockFileAdditions []struct {
+				Provider, Version string
+			} `json:"lock_file_additions"`
+		}
+		if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &report); err != nil {
+			t.Fatalf("invalid report: %s\n%s", err, ui.OutputWriter.String())
+		}
+		if len(report.Replacements) != 1 || report.Replacements[0].To != "registry.opentofu.org/acmecorp/aws" {
+			t.Fatalf("wrong replacements in report:\n%s", ui.OutputWriter.String())
+		}
+		if got, want := report.Replacements[0].Resources, []string{"aws_instance.alpha", "aws_instance.beta"}; !cmp.Equal(got, want) {
+			t.Fatalf("wrong resources in report\n%s", cmp.Diff(want, got))
+		}
+		if len(report.LockFileAdditions) != 1 || report.LockFileAdditions[0].Version != "4.2.0" {
+			t.Fatalf("wrong lock file additions in report:\n%s", ui.OutputWriter.String())
+		}
+		testStateOutput(t, statePath, testStateReplaceProviderOutputOriginal)
+
+		// Without -dry-run, the new provider is locked at the same version.
+		// The providers in the lock file aren't installed, so we override
+		// them to be able to load the backend.
+		ui = cli.NewMockUi()
+		c = &StateReplaceProviderCommand{
+			StateMeta{
+				Meta: Meta{
+					testingOverrides: metaOverridesForProvider(testProvider()),
+					Ui:               ui,
+					View:             view,
+				},
+			},
+		}
+		args = []string{
+			"-state", statePath,
+			"-auto-approve",
+			"hashicorp/aws",
+			"acmecorp/aws",
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("return code: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+		testStateOutput(t, statePath, testStateReplaceProviderOutput)
+
+		locks, diags := depsfile.LoadLocksFromFile(dependencyLockFilename)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		lock := locks.Provider(addrs.MustParseProviderSourceString("acmecorp/aws"))
+		if lock == nil {
+			t.Fatal("new provider was not added to the lock file")
+		}
+		if got, want := lock.Version().String(), "4.2.0"; got != want {
+			t.Errorf("wrong locked version %s; want %s", got, want)
+		}
+		if len(lock.AllHashes()) != 0 {
+			t.Errorf("new provider has checksums %s", lock.AllHashes())
+		}
+	})
+
 	t.Run("cancel at approval step", func(t *testing.T) {
 		statePath := testStateFile(t, state)
 
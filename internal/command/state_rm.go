@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/command/arguments"
@@ -166,6 +167,20 @@ func (c *StateRmCommand) Run(args []string) int {
 	return 0
 }
 
+func (c *StateRmCommand) AutocompleteArgs() complete.Predictor {
+	return c.completePredictResourceAddress()
+}
+
+func (c *StateRmCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-dry-run":      complete.PredictNothing,
+		"-backup":       complete.PredictFiles("*"),
+		"-lock":         completePredictBoolean,
+		"-lock-timeout": complete.PredictAnything,
+		"-state":        complete.PredictFiles("*"),
+	}
+}
+
 func (c *StateRmCommand) Help() string {
 	helpText := `
 Usage: tofu [global options] state (remove|rm) [options] ADDRESS...
@@ -0,0 +1,218 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// StateRmDeposedCommand is a Command implementation that removes a single
+// deposed object from the state.
+type StateRmDeposedCommand struct {
+	StateMeta
+}
+
+func (c *StateRmDeposedCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var dryRun bool
+	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("state rm-deposed")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "dry run")
+	cmdFlags.StringVar(&c.backupPath, "backup", "-", "backup")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	cmdFlags.StringVar(&c.statePath, "state", "", "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("Exactly two arguments expected: the address of a resource instance and the key of one of its deposed objects.\n")
+		return cli.RunResultHelp
+	}
+
+	addr, diags := addrs.ParseAbsResourceInstanceStr(args[0])
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+		c.showDiagnostics(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid target address",
+			fmt.Sprintf("%s is not a managed resource instance. Only managed resources can have deposed objects.", addr),
+		))
+		return 1
+	}
+	key := states.DeposedKey(args[1])
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	// Get the state
+	stateMgr, err := c.State(enc)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+
+	if c.stateLock {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(stateMgr, "state-rm-deposed"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+
+	state := stateMgr.State()
+	if state == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+
+	is := state.ResourceInstance(addr)
+	if is == nil || !is.HasDeposed(key) {
+		c.showDiagnostics(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid target address",
+			fmt.Sprintf("%s has no deposed object with the key %q. To view the available deposed objects, use \"tofu state list -deposed\".", addr, key),
+		))
+		return 1
+	}
+
+	if dryRun {
+		c.Ui.Output(fmt.Sprintf("Would remove deposed object %s of %s", key, addr))
+		return 0 // This is as far as we go in dry-run mode
+	}
+
+	state.SyncWrapper().ForgetResourceInstanceDeposed(addr, key)
+
+	b, backendDiags := c.Backend(nil, enc.State())
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Get schemas, if possible, before writing state
+	var schemas *tofu.Schemas
+	if isCloudMode(b) {
+		var schemaDiags tfdiags.Diagnostics
+		schemas, schemaDiags = c.MaybeGetSchemas(state, nil)
+		diags = diags.Append(schemaDiags)
+	}
+
+	if err := stateMgr.WriteState(state); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRmPersist, err))
+		return 1
+	}
+	if err := stateMgr.PersistState(schemas); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRmPersist, err))
+		return 1
+	}
+
+	c.showDiagnostics(diags)
+	c.Ui.Output(fmt.Sprintf("Removed deposed object %s of %s", key, addr))
+	return 0
+}
+
+func (c *StateRmDeposedCommand) AutocompleteArgs() complete.Predictor {
+	return c.completePredictResourceAddress()
+}
+
+func (c *StateRmDeposedCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-dry-run":      complete.PredictNothing,
+		"-backup":       complete.PredictFiles("*"),
+		"-lock":         completePredictBoolean,
+		"-lock-timeout": complete.PredictAnything,
+		"-state":        complete.PredictFiles("*"),
+	}
+}
+
+func (c *StateRmDeposedCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state rm-deposed [options] ADDRESS KEY
+
+  Remove a deposed object from the OpenTofu state, causing OpenTofu to
+  "forget" it without first destroying it in the remote system.
+
+  A deposed object is the previous object of a resource instance that was
+  replaced using create-before-destroy, when OpenTofu failed to destroy it
+  after creating its replacement. OpenTofu plans to destroy deposed objects
+  on the next apply. Use this command when the object has already been
+  destroyed outside of OpenTofu, or should be left in place.
+
+  The address and key of each deposed object are shown by
+  "tofu state list -deposed".
+
+Options:
+
+  -dry-run                If set, prints out what would've been removed but
+                          doesn't actually remove anything.
+
+  -backup=PATH            Path where OpenTofu should write the backup
+                          state.
+
+  -lock=false             Don't hold a state lock during the operation. This is
+                          dangerous if others might concurrently run commands
+                          against the same workspace.
+
+  -lock-timeout=0s        Duration to retry a state lock.
+
+  -state=PATH             Path to the state file to update. Defaults to the
+                          current workspace state.
+
+  -ignore-remote-version  Continue even if remote and local OpenTofu versions
+                          are incompatible. This may result in an unusable
+                          workspace, and should be used with extreme caution.
+
+  -var 'foo=bar'          Set a value for one of the input variables in the root
+                          module of the configuration. Use this option more than
+                          once to set more than one variable.
+
+  -var-file=filename      Load variable values from the given file, in addition
+                          to the default files terraform.tfvars and *.auto.tfvars.
+                          Use this option more than once to include more than one
+                          variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRmDeposedCommand) Synopsis() string {
+	return "Remove a deposed object from the state"
+}
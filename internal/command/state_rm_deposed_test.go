@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+var testStateRmDeposedAddr = addrs.Resource{
+	Mode: addrs.ManagedResourceMode,
+	Type: "test_instance",
+	Name: "foo",
+}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+func testStateRmDeposedState(addr addrs.AbsResourceInstance, withCurrent bool) *states.State {
+	providerAddr := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	return states.BuildState(func(s *states.SyncState) {
+		if withCurrent {
+			s.SetResourceInstanceCurrent(
+				addr,
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON: []byte(`{"id":"bar"}`),
+					Status:    states.ObjectReady,
+				},
+				providerAddr,
+				addrs.NoKey,
+			)
+		}
+		s.SetResourceInstanceDeposed(
+			addr,
+			states.DeposedKey("00000001"),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"foo"}`),
+				Status:    states.ObjectReady,
+			},
+			providerAddr,
+			addrs.NoKey,
+		)
+	})
+}
+
+func testStateRmDeposedCommand(t *testing.T) (*StateRmDeposedCommand, *cli.MockUi) {
+	ui := new(cli.MockUi)
+	view, _ := testView(t)
+	return &StateRmDeposedCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+				View:             view,
+			},
+		},
+	}, ui
+}
+
+func TestStateRmDeposed(t *testing.T) {
+	addr := testStateRmDeposedAddr
+	statePath := testStateFile(t, testStateRmDeposedState(addr, true))
+
+	c, ui := testStateRmDeposedCommand(t)
+	args := []string{
+		"-state", statePath,
+		"test_instance.foo", "00000001",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "Removed deposed object 00000001 of test_instance.foo"; !strings.Contains(got, want) {
+		t.Errorf("wrong output\ngot:  %s\nwant: %s", got, want)
+	}
+
+	is := testStateRead(t, statePath).ResourceInstance(addr)
+	if is == nil || is.Current == nil {
+		t.Fatal("current object was removed")
+	}
+	if len(is.Deposed) != 0 {
+		t.Fatalf("deposed object was not removed: %#v", is.Deposed)
+	}
+
+	backups := testStateBackups(t, filepath.Dir(statePath))
+	if len(backups) != 1 {
+		t.Fatalf("bad: %#v", backups)
+	}
+}
+
+func TestStateRmDeposed_onlyObject(t *testing.T) {
+	addr := testStateRmDeposedAddr
+	statePath := testStateFile(t, testStateRmDeposedState(addr, false))
+
+	c, ui := testStateRmDeposedCommand(t)
+	args := []string{
+		"-state", statePath,
+		"test_instance.foo", "00000001",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// Removing the last object of the instance removes the resource too.
+	if rs := testStateRead(t, statePath).Resource(addr.ContainingResource()); rs != nil {
+		t.Fatalf("resource was not removed: %#v", rs)
+	}
+}
+
+func TestStateRmDeposed_dryRun(t *testing.T) {
+	addr := testStateRmDeposedAddr
+	statePath := testStateFile(t, testStateRmDeposedState(addr, true))
+
+	c, ui := testStateRmDeposedCommand(t)
+	args := []string{
+		"-state", statePath,
+		"-dry-run",
+		"test_instance.foo", "00000001",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "Would remove deposed object 00000001 of test_instance.foo"; !strings.Contains(got, want) {
+		t.Errorf("wrong output\ngot:  %s\nwant: %s", got, want)
+	}
+
+	is := testStateRead(t, statePath).ResourceInstance(addr)
+	if is == nil || !is.HasDeposed("00000001") {
+		t.Fatal("deposed object was removed in dry-run mode")
+	}
+}
+
+func TestStateRmDeposed_notFound(t *testing.T) {
+	addr := testStateRmDeposedAddr
+	statePath := testStateFile(t, testStateRmDeposedState(addr, true))
+
+	for name, args := range map[string][]string{
+		"wrong key":      {"test_instance.foo", "00000002"},
+		"wrong instance": {"test_instance.bar", "00000001"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, ui := testStateRmDeposedCommand(t)
+			if code := c.Run(append([]string{"-state", statePath}, args...)); code != 1 {
+				t.Fatalf("wrong exit code %d; want 1\n\n%s", code, ui.OutputWriter.String())
+			}
+			if got, want := ui.ErrorWriter.String(), "Invalid target address"; !strings.Contains(got, want) {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestStateRmDeposed_args(t *testing.T) {
+	c, ui := testStateRmDeposedCommand(t)
+	if code := c.Run([]string{"test_instance.foo"}); code != cli.RunResultHelp {
+		t.Fatalf("wrong exit code %d; want %d", code, cli.RunResultHelp)
+	}
+	if got, want := ui.ErrorWriter.String(), "Exactly two arguments expected"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+
+	"github.com/opentofu/opentofu/internal/command/stateapi"
+	"github.com/opentofu/opentofu/internal/logging"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+// stateServeTokenEnvVar is the environment variable that sets the token
+// clients of "tofu state serve" must present, instead of a random one.
+const stateServeTokenEnvVar = "TOFU_STATE_SERVE_TOKEN"
+
+// StateServeCommand is a Command implementation that serves a read-only
+// HTTP API for the current state on the local host.
+type StateServeCommand struct {
+	StateMeta
+}
+
+func (c *StateServeCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var address string
+	var refreshInterval, lifetime time.Duration
+	cmdFlags := c.Meta.defaultFlagSet("state serve")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.StringVar(&address, "address", "127.0.0.1:0", "address")
+	cmdFlags.DurationVar(&refreshInterval, "refresh-interval", 30*time.Second, "refresh interval")
+	cmdFlags.DurationVar(&lifetime, "lifetime", 15*time.Minute, "lifetime")
+	cmdFlags.StringVar(&c.statePath, "state", "", "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return cli.RunResultHelp
+	}
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("The state serve command expects no arguments.\n")
+		return cli.RunResultHelp
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid -address %q: %s", address, err))
+		return 1
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		c.Ui.Error(fmt.Sprintf("Invalid -address %q: the state API can only listen on a loopback address, such as 127.0.0.1.", address))
+		return 1
+	}
+
+	token := os.Getenv(stateServeTokenEnvVar)
+	if token == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to generate an access token: %s", err))
+			return 1
+		}
+		token = hex.EncodeToString(buf)
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	// Get the state manager for the current workspace
+	stateMgr, err := c.State(enc)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	load := func() (*states.State, error) {
+		if err := stateMgr.RefreshState(); err != nil {
+			return nil, err
+		}
+		return stateMgr.State(), nil
+	}
+
+	// Read the state once before listening, so that problems with the
+	// backend are reported straight away rather than to the first client.
+	if _, err := load(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to listen on %s: %s", address, err))
+		return 1
+	}
+	server := &http.Server{
+		Handler:           stateapi.NewHandler(load, token, refreshInterval),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serveErr := make(chan error, 1)
+	panicHandler := logging.PanicHandlerWithTraceFn()
+	go func() {
+		defer panicHandler()
+		serveErr <- server.Serve(listener)
+	}()
+	defer func() {
+		if err := server.Close(); err != nil {
+			log.Printf("[WARN] state serve: server can't shut down: %s", err)
+		}
+	}()
+
+	c.Ui.Output(fmt.Sprintf("Serving the state API at http://%s/v1/", listener.Addr()))
+	if os.Getenv(stateServeTokenEnvVar) == "" {
+		c.Ui.Output(fmt.Sprintf("Access token: %s", token))
+	}
+
+	var timeout <-chan time.Time
+	if lifetime > 0 {
+		c.Ui.Output(fmt.Sprintf("The server will stop after %s, or when interrupted.", lifetime))
+		timer := time.NewTimer(lifetime)
+		defer timer.Stop()
+		timeout = timer.C
+	} else {
+		c.Ui.Output("The server will stop when interrupted.")
+	}
+
+	select {
+	case <-c.ShutdownCh:
+	case <-timeout:
+	case err := <-serveErr:
+		c.Ui.Error(fmt.Sprintf("The state API server failed: %s", err))
+		return 1
+	}
+	c.Ui.Output("Stopped serving the state API.")
+	return 0
+}
+
+func (c *StateServeCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state serve [options]
+
+  Serve a read-only HTTP API for the current state on the local host, for
+  short-lived use by scripts and other tools that would otherwise need to read
+  the whole state again for each query.
+
+  Every request must include the access token printed on startup as a bearer
+  token, in an "Authorization: Bearer TOKEN" header. To choose the token
+  instead, set the TOFU_STATE_SERVE_TOKEN environment variable.
+
+  The API has the following endpoints, which all return JSON:
+
+      GET /v1/resources[?type=TYPE]         List resource instances.
+      GET /v1/resource?address=ADDRESS      Show one resource instance.
+      GET /v1/search?attribute=PATH&value=V List resource instances with an
+                                            attribute of the given value.
+      GET /v1/outputs                       List root module output values.
+      GET /v1/outputs/NAME                  Show one output value.
+
+  The values of sensitive attributes and output values are never included.
+
+Options:
+
+  -address=ADDR           The loopback address and port to listen on. Defaults
+                          to 127.0.0.1 with a random free port.
+
+  -refresh-interval=30s   How long to keep using a state snapshot before
+                          reading the state again for the next request.
+
+  -lifetime=15m           How long to serve the API before stopping. Set to 0
+                          to serve until interrupted.
+
+  -state=PATH             Path to a OpenTofu state file to use to look up
+                          OpenTofu-managed resources. By default, OpenTofu
+                          will consult the state of the currently-selected
+                          workspace.
+
+  -var 'foo=bar'          Set a value for one of the input variables in the root
+                          module of the configuration. Use this option more than
+                          once to set more than one variable.
+
+  -var-file=filename      Load variable values from the given file, in addition
+                          to the default files terraform.tfvars and *.auto.tfvars.
+                          Use this option more than once to include more than one
+                          variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateServeCommand) Synopsis() string {
+	return "Serve a read-only HTTP API for the state"
+}
+
+func (c *StateServeCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *StateServeCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-address":          complete.PredictAnything,
+		"-refresh-interval": complete.PredictAnything,
+		"-lifetime":         complete.PredictAnything,
+		"-state":            complete.PredictFiles("*.tfstate"),
+	}
+}
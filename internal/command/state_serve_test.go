@@ -0,0 +1,111 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStateServe(t *testing.T) {
+	testCwd(t)
+	statePath := testStateFile(t, testState())
+	t.Setenv(stateServeTokenEnvVar, "test-token")
+
+	// Find a free port for the server to listen on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	l.Close()
+
+	shutdownCh := make(chan struct{})
+	ui := cli.NewMockUi()
+	c := &StateServeCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+				ShutdownCh:       shutdownCh,
+			},
+		},
+	}
+	codeCh := make(chan int, 1)
+	go func() {
+		codeCh <- c.Run([]string{"-state", statePath, "-address", address})
+	}()
+
+	get := func(token string) (int, string, error) {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/v1/resources", address), nil)
+		if err != nil {
+			return 0, "", err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), err
+	}
+
+	var status int
+	var body string
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(50 * time.Millisecond) {
+		if status, body, err = get("test-token"); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("server didn't start: %s", err)
+	}
+	if status != http.StatusOK || !strings.Contains(body, `"address": "test_instance.foo"`) {
+		t.Errorf("wrong response %d\n%s", status, body)
+	}
+	if status, _, _ := get("wrong-token"); status != http.StatusUnauthorized {
+		t.Errorf("wrong status %d for a wrong token", status)
+	}
+
+	close(shutdownCh)
+	if code := <-codeCh; code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "Serving the state API at http://"+address+"/v1/") {
+		t.Errorf("wrong output\n%s", output)
+	}
+	if strings.Contains(output, "test-token") {
+		t.Errorf("output includes a token chosen by the user\n%s", output)
+	}
+}
+
+func TestStateServe_nonLoopbackAddress(t *testing.T) {
+	testCwd(t)
+	statePath := testStateFile(t, testState())
+
+	ui := cli.NewMockUi()
+	c := &StateServeCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+			},
+		},
+	}
+	if code := c.Run([]string{"-state", statePath, "-address", "0.0.0.0:8080"}); code != 1 {
+		t.Fatalf("wrong exit code %d; want 1", code)
+	}
+	if got, want := ui.ErrorWriter.String(), "can only listen on a loopback address"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
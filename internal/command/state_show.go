@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/backend"
@@ -195,12 +196,26 @@ func (c *StateShowCommand) Run(args []string) int {
 		Colorize:            c.Colorize(),
 		RunningInAutomation: c.RunningInAutomation,
 		ShowSensitive:       showSensitive,
+		NoUnicode:           c.NoUnicode,
 	}
 
 	renderer.RenderHumanState(jstate)
 	return 0
 }
 
+func (c *StateShowCommand) AutocompleteArgs() complete.Predictor {
+	return completePredictSequence{
+		c.completePredictResourceAddress(),
+	}
+}
+
+func (c *StateShowCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-state":          complete.PredictFiles("*"),
+		"-show-sensitive": complete.PredictNothing,
+	}
+}
+
 func (c *StateShowCommand) Help() string {
 	helpText := `
 Usage: tofu [global options] state show [options] ADDRESS
@@ -0,0 +1,462 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// StateSplitCommand is a Command implementation that copies the resources of
+// a module subtree into the state of a separate backend, as the first step of
+// breaking up a large configuration.
+type StateSplitCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateSplitCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var byModule, toPath, stubOut string
+	cmdFlags := c.Meta.defaultFlagSet("state split")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.StringVar(&c.Meta.statePath, "state", "", "path")
+	cmdFlags.StringVar(&byModule, "by-module", "", "module address")
+	cmdFlags.StringVar(&toPath, "to", "", "backend configuration file")
+	cmdFlags.StringVar(&stubOut, "stub-out", "", "path")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return cli.RunResultHelp
+	}
+	if len(cmdFlags.Args()) != 0 || byModule == "" || toPath == "" {
+		c.Ui.Error("The state split command requires the -by-module and -to options, and no arguments.\n")
+		return cli.RunResultHelp
+	}
+
+	var diags tfdiags.Diagnostics
+
+	moduleAddr, moduleDiags := parseStateSplitModule(byModule)
+	diags = diags.Append(moduleDiags)
+	if moduleDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	_, call := moduleAddr.Call()
+	if stubOut == "" {
+		stubOut = call.Name + "_remote_state.tf"
+	}
+
+	config, configDiags := c.loadConfig(".")
+	diags = diags.Append(configDiags)
+	if configDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	targetConfig, targetSrc, targetDiags := loadStateSplitBackend(toPath, config.Module.StaticEvaluator)
+	diags = diags.Append(targetDiags)
+	if targetDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration. The new state is written with the
+	// same encryption as the current one, so that splitting never leaves an
+	// unencrypted copy of encrypted resources behind.
+	enc, encDiags := c.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Load the source state
+	b, backendDiags := c.Backend(&BackendOpts{
+		Config: config.Module.Backend,
+	}, enc.State())
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	c.ignoreRemoteVersionConflict(b)
+
+	env, err := c.Workspace()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
+		return 1
+	}
+	sourceMgr, err := b.StateMgr(env)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	if err := sourceMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+	sourceState := sourceMgr.State()
+	if sourceState == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+
+	newState, count := splitStateByModule(sourceState, moduleAddr)
+	if count == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No resources to split",
+			fmt.Sprintf("The state does not contain any resources in %s.", moduleAddr),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Initialize the target backend and write the new state there
+	targetBackend, _, targetDiags := c.backendInitFromConfig(targetConfig, enc.State())
+	diags = diags.Append(targetDiags)
+	if targetDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	targetMgr, err := targetBackend.StateMgr(backend.DefaultStateName)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load destination state: %s", err))
+		return 1
+	}
+
+	if c.stateLock {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(targetMgr, "state-split"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	if err := targetMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh destination state: %s", err))
+		return 1
+	}
+	if existing := targetMgr.State(); existing != nil && !existing.Empty() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Destination state is not empty",
+			fmt.Sprintf("The %s backend configured in %s already has a state with resources or outputs. The state split command only writes to a new, empty state.", targetConfig.Type, toPath),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if err := targetMgr.WriteState(newState); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to write state: %s", err))
+		return 1
+	}
+	if err := targetMgr.PersistState(nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to persist state: %s", err))
+		return 1
+	}
+
+	// Write the data source that the remaining configuration can use instead
+	// of the module outputs.
+	if _, err := os.Stat(stubOut); err == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Remote state stub not written",
+			fmt.Sprintf("The file %s already exists, so the terraform_remote_state data source for the new state was not written.", stubOut),
+		))
+	} else {
+		stub, stubDiags := stateSplitRemoteStateStub(moduleAddr, call.Name, targetConfig, targetSrc)
+		diags = diags.Append(stubDiags)
+		if err := os.WriteFile(stubOut, stub, 0o644); err != nil {
+			diags = diags.Append(fmt.Errorf("Failed to write remote state stub: %w", err))
+		}
+	}
+
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+
+	c.Ui.Output(stateSplitGuidance(moduleAddr, call.Name, count, targetConfig.Type, stubOut, newState))
+	return 0
+}
+
+// parseStateSplitModule parses the -by-module option, which must be the
+// address of a module call without any instance keys because the removed
+// block we suggest can only refer to whole module calls.
+func parseStateSplitModule(raw string) (addrs.Module, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	addr, addrDiags := addrs.ParseModuleInstanceStr(raw)
+	diags = diags.Append(addrDiags)
+	if addrDiags.HasErrors() {
+		return nil, diags
+	}
+	if addr.IsRoot() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid module address",
+			"The -by-module option must refer to a module call, not the root module.",
+		))
+		return nil, diags
+	}
+	for _, step := range addr {
+		if step.InstanceKey != addrs.NoKey {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid module address",
+				fmt.Sprintf("The -by-module option must refer to a whole module call, without instance keys, such as %s.", addr.Module()),
+			))
+			return nil, diags
+		}
+	}
+	return addr.Module(), diags
+}
+
+// loadStateSplitBackend reads the file given in the -to option, which must
+// contain exactly one backend block, the same as would appear in the terraform
+// block of the configuration that will own the new state.
+func loadStateSplitBackend(path string, eval *configs.StaticEvaluator) (*configs.Backend, []byte, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read backend configuration",
+			fmt.Sprintf("Could not read %s: %s.", path, err),
+		))
+		return nil, nil, diags
+	}
+	file, hclDiags := hclsyntax.ParseConfig(src, filepath.Base(path), hcl.InitialPos)
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	content, hclDiags := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "backend", LabelNames: []string{"type"}},
+		},
+	})
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return nil, nil, diags
+	}
+	if len(content.Blocks) != 1 {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid backend configuration file",
+			Detail:   "The file given in the -to option must contain exactly one backend block.",
+			Subject:  file.Body.MissingItemRange().Ptr(),
+		})
+		return nil, nil, diags
+	}
+
+	block := content.Blocks[0]
+	return &configs.Backend{
+		Type:      block.Labels[0],
+		TypeRange: block.LabelRanges[0],
+		Config:    block.Body,
+		Eval:      eval,
+		DeclRange: block.DefRange,
+	}, src, diags
+}
+
+// splitStateByModule returns a new state that contains copies of all of the
+// resources in the given module and its descendants, along with the number of
+// resource instances that were copied.
+//
+// Resource addresses are kept unchanged, so that the new configuration can
+// call the same module under the same name and see no changes.
+func splitStateByModule(state *states.State, module addrs.Module) (*states.State, int) {
+	newState := states.NewState()
+	count := 0
+	for _, ms := range state.Modules {
+		if !module.TargetContains(ms.Addr) || len(ms.Resources) == 0 {
+			continue
+		}
+		newModule := newState.EnsureModule(ms.Addr)
+		for key, rs := range ms.Resources {
+			newModule.Resources[key] = rs.DeepCopy()
+			count += len(rs.Instances)
+		}
+	}
+	return newState, count
+}
+
+// stateSplitRemoteStateStub renders a terraform_remote_state data source that
+// reads the state written to the given backend.
+//
+// The backend arguments are copied verbatim from the source of the -to file.
+// Nested blocks can't be represented in the data source's config object, so
+// they are left out with a warning.
+func stateSplitRemoteStateStub(module addrs.Module, name string, config *configs.Backend, src []byte) ([]byte, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "# Reads the state that \"tofu state split\" copied out of %s.\n", module)
+	fmt.Fprintf(&buf, "# Replace references to %s.<output> with\n", module)
+	fmt.Fprintf(&buf, "# data.terraform_remote_state.%s.outputs.<output>.\n", name)
+	fmt.Fprintf(&buf, "data \"terraform_remote_state\" %q {\n", name)
+	fmt.Fprintf(&buf, "  backend = %q\n", config.Type)
+
+	body, ok := config.Config.(*hclsyntax.Body)
+	if ok && len(body.Attributes) != 0 {
+		buf.WriteString("  config = {\n")
+		names := make([]string, 0, len(body.Attributes))
+		for name := range body.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&buf, "    %s = %s\n", name, body.Attributes[name].Expr.Range().SliceBytes(src))
+		}
+		buf.WriteString("  }\n")
+	}
+	if ok && len(body.Blocks) != 0 {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Incomplete remote state stub",
+			Detail:   "The backend configuration contains nested blocks, which were not copied into the terraform_remote_state data source. Add them to its config argument as object attributes.",
+			Subject:  body.Blocks[0].DefRange().Ptr(),
+		})
+	}
+	buf.WriteString("}\n")
+
+	return []byte(buf.String()), diags
+}
+
+// stateSplitGuidance describes the configuration changes that complete a
+// split, including the moved blocks the new configuration needs if it
+// declares the module's resources directly rather than calling the module.
+func stateSplitGuidance(module addrs.Module, name string, count int, backendType, stubOut string, newState *states.State) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Copied %d resource instances from %s into the new %s state.\n\n", count, module, backendType)
+	buf.WriteString("The current state still tracks these resources. To complete the split:\n\n")
+	fmt.Fprintf(&buf, "  1. Create a configuration that uses the new %s backend and calls the module\n", backendType)
+	fmt.Fprintf(&buf, "     as %s, then run \"tofu plan\" there to confirm it has no changes.\n", module)
+	fmt.Fprintf(&buf, "  2. In this configuration, replace the module block with the following, so\n")
+	fmt.Fprintf(&buf, "     that the next apply forgets the resources without destroying them:\n\n")
+	fmt.Fprintf(&buf, "       removed {\n         from = %s\n       }\n\n", module)
+	fmt.Fprintf(&buf, "  3. Replace references to the outputs of %s with the\n", module)
+	fmt.Fprintf(&buf, "     data.terraform_remote_state.%s data source written to %s.\n", name, stubOut)
+
+	// Collect the direct children of the split module, which are what would
+	// need to move if the new configuration inlines the module.
+	seen := make(map[string]bool)
+	var moves []string
+	for _, ms := range newState.Modules {
+		if len(ms.Addr) < len(module) {
+			// The new state always has a root module, even if it's empty.
+			continue
+		}
+		rel := ms.Addr.Module()[len(module):]
+		if len(rel) != 0 {
+			child := module.Child(rel[0])
+			if !seen[child.String()] {
+				seen[child.String()] = true
+				moves = append(moves, fmt.Sprintf("moved {\n  from = %s\n  to   = module.%s\n}\n", child, rel[0]))
+			}
+			continue
+		}
+		for _, rs := range ms.Resources {
+			if rs.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				continue
+			}
+			moves = append(moves, fmt.Sprintf("moved {\n  from = %s\n  to   = %s\n}\n", rs.Addr.Config(), rs.Addr.Resource))
+		}
+	}
+	if len(moves) != 0 {
+		sort.Strings(moves)
+		buf.WriteString("\nIf the new configuration declares the contents of the module directly instead\n")
+		buf.WriteString("of calling it, add the following blocks to it:\n\n")
+		buf.WriteString(strings.Join(moves, "\n"))
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (c *StateSplitCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state split [options] -by-module=ADDRESS -to=FILE
+
+  Copy the resources of a module into a new, separate state.
+
+  This command copies every resource in the given module call, including its
+  nested modules, into the default workspace of the backend described in
+  FILE. FILE must contain a single backend block, in the same form as it
+  would appear in a terraform block, and the backend must not contain any
+  state yet.
+
+  The current state is not modified. Instead, the command prints the
+  removed block that makes the next apply of this configuration forget the
+  resources, and the moved blocks that the new configuration needs if it
+  declares the module's resources directly. It also writes a
+  terraform_remote_state data source that reads the new state, which can
+  replace references to the module's outputs.
+
+  The new state is written with the same state encryption settings as the
+  current configuration.
+
+Options:
+
+  -by-module=ADDRESS  Address of the module call to split out, such as
+                      module.networking. Instance keys are not allowed.
+
+  -to=FILE            Path to a file containing the backend block for the
+                      new state.
+
+  -stub-out=FILE      Path to write the terraform_remote_state data source
+                      to. Defaults to NAME_remote_state.tf, where NAME is the
+                      name of the module call. An existing file is never
+                      overwritten.
+
+  -lock=false         Don't hold a state lock on the new state during the
+                      operation.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
+  -state=statefile    Path to a OpenTofu state file to split. By default,
+                      OpenTofu will consult the state of the currently-selected
+                      workspace.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateSplitCommand) Synopsis() string {
+	return "Copy the resources of a module into a new state"
+}
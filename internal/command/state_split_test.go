@@ -0,0 +1,131 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+func TestStateSplit(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+
+	files := map[string]string{
+		"main.tf":     `resource "test_instance" "foo" {}`,
+		"backend.hcl": "backend \"local\" {\n  path = \"networking.tfstate\"\n}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(td, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state := testState()
+	child := state.EnsureModule(addrs.RootModuleInstance.Child("networking", addrs.NoKey))
+	child.SetResourceInstanceCurrent(
+		addrs.Resource{
+			Mode: addrs.ManagedResourceMode,
+			Type: "test_instance",
+			Name: "vpc",
+		}.Instance(addrs.NoKey),
+		&states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{"id":"vpc"}`),
+			Status:    states.ObjectReady,
+		},
+		addrs.AbsProviderConfig{
+			Provider: addrs.NewDefaultProvider("test"),
+			Module:   addrs.RootModule,
+		},
+		addrs.NoKey,
+	)
+	statePath := testStateFile(t, state)
+
+	ui := cli.NewMockUi()
+	c := &StateSplitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-by-module", "module.networking",
+		"-to", "backend.hcl",
+		"-lock=false",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	f, err := os.Open(filepath.Join(td, "networking.tfstate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	newState, err := statefile.Read(f, encryption.StateEncryptionDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := newState.State.AllResourceInstanceObjectAddrs()
+	if len(got) != 1 || got[0].Instance.String() != "module.networking.test_instance.vpc" {
+		t.Fatalf("wrong resources in the new state: %#v", got)
+	}
+
+	// The current state must not be modified.
+	sourceState := testStateRead(t, statePath)
+	if got := len(sourceState.AllResourceInstanceObjectAddrs()); got != 2 {
+		t.Fatalf("the current state has %d resource instances, want 2", got)
+	}
+
+	stub, err := os.ReadFile(filepath.Join(td, "networking_remote_state.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "data \"terraform_remote_state\" \"networking\" {\n  backend = \"local\"\n  config = {\n    path = \"networking.tfstate\"\n  }\n}\n"; !strings.HasSuffix(string(stub), want) {
+		t.Fatalf("wrong remote state stub\ngot:\n%s\nwant suffix:\n%s", stub, want)
+	}
+
+	output := ui.OutputWriter.String()
+	for _, want := range []string{
+		"Copied 1 resource instances from module.networking into the new local state.",
+		"removed {\n         from = module.networking\n       }",
+		"moved {\n  from = module.networking.test_instance.vpc\n  to   = test_instance.vpc\n}",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output is missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestStateSplit_instanceKey(t *testing.T) {
+	ui := cli.NewMockUi()
+	c := &StateSplitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-by-module", "module.networking[0]",
+		"-to", "backend.hcl",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("expected exit status 1, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "must refer to a whole module call") {
+		t.Fatalf("wrong error output:\n%s", got)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// StateStatsCommand is a Command implementation that summarizes the size
+// and composition of a state, to help find out what makes it large.
+type StateStatsCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateStatsCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var statePath string
+	var top int
+	cmdFlags := c.Meta.defaultFlagSet("state stats")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.StringVar(&statePath, "state", "", "path")
+	cmdFlags.IntVar(&top, "top", 10, "top")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return cli.RunResultHelp
+	}
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("The state stats command expects no arguments.")
+		return cli.RunResultHelp
+	}
+	if top < 0 {
+		c.Ui.Error("The -top option must not be negative.")
+		return cli.RunResultHelp
+	}
+
+	if statePath != "" {
+		c.Meta.statePath = statePath
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil, enc.State())
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(backendDiags)
+		return 1
+	}
+
+	// This is a read-only command
+	c.ignoreRemoteVersionConflict(b)
+
+	// Get the state
+	env, err := c.Workspace()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
+		return 1
+	}
+	stateMgr, err := b.StateMgr(env)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	stateFile := statemgr.Export(stateMgr)
+	if stateFile == nil || stateFile.State == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+
+	// The size is measured on the unencrypted snapshot, because that is what
+	// OpenTofu has to decode and hold in memory for every operation.
+	var buf bytes.Buffer
+	if err := statefile.Write(stateFile, &buf, encryption.StateEncryptionDisabled()); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to serialize state: %s", err))
+		return 1
+	}
+
+	stats := collectStateStats(stateFile.State, top)
+	c.Ui.Output(stats.render(buf.Len()))
+	return 0
+}
+
+// stateStats is a summary of the contents of a state, as produced by
+// collectStateStats.
+type stateStats struct {
+	Resources int
+	Instances int
+	Deposed   int
+
+	// ByModule and ByType are keyed by module path and resource type
+	// respectively.
+	ByModule map[string]*stateStatsCount
+	ByType   map[string]*stateStatsCount
+
+	// LargestAttributes contains the largest top-level attribute values in
+	// the state, largest first.
+	LargestAttributes []stateStatsAttribute
+
+	// DeposedInstances contains the resource instances that have deposed
+	// objects, along with how many they have.
+	DeposedInstances map[string]int
+}
+
+type stateStatsCount struct {
+	Resources int
+	Instances int
+}
+
+type stateStatsAttribute struct {
+	Path string
+	Size int
+}
+
+// collectStateStats walks the given state and summarizes its contents,
+// keeping the given number of largest attribute values.
+func collectStateStats(state *states.State, top int) *stateStats {
+	ret := &stateStats{
+		ByModule:         make(map[string]*stateStatsCount),
+		ByType:           make(map[string]*stateStatsCount),
+		DeposedInstances: make(map[string]int),
+	}
+	count := func(m map[string]*stateStatsCount, key string) *stateStatsCount {
+		if m[key] == nil {
+			m[key] = &stateStatsCount{}
+		}
+		return m[key]
+	}
+
+	var attrs []stateStatsAttribute
+	addAttrs := func(addr string, obj *states.ResourceInstanceObjectSrc) {
+		if obj == nil || top == 0 {
+			return
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(obj.AttrsJSON, &values); err != nil {
+			// Objects from legacy state formats may only have flatmap
+			// attributes, which we don't try to measure.
+			return
+		}
+		for name, raw := range values {
+			attrs = append(attrs, stateStatsAttribute{
+				Path: addr + "." + name,
+				Size: len(raw),
+			})
+		}
+	}
+
+	for _, ms := range state.Modules {
+		module := ms.Addr.Module().String()
+		if module == "" {
+			module = "(root module)"
+		}
+		for _, rs := range ms.Resources {
+			typeName := rs.Addr.Resource.Type
+			if rs.Addr.Resource.Mode == addrs.DataResourceMode {
+				typeName = "data." + typeName
+			}
+			byModule, byType := count(ret.ByModule, module), count(ret.ByType, typeName)
+			ret.Resources++
+			byModule.Resources++
+			byType.Resources++
+
+			for key, is := range rs.Instances {
+				addr := rs.Addr.Instance(key).String()
+				ret.Instances++
+				byModule.Instances++
+				byType.Instances++
+
+				addAttrs(addr, is.Current)
+				for dk, obj := range is.Deposed {
+					addAttrs(fmt.Sprintf("%s (deposed %s)", addr, dk), obj)
+				}
+				if len(is.Deposed) != 0 {
+					ret.Deposed += len(is.Deposed)
+					ret.DeposedInstances[addr] = len(is.Deposed)
+				}
+			}
+		}
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i].Size != attrs[j].Size {
+			return attrs[i].Size > attrs[j].Size
+		}
+		return attrs[i].Path < attrs[j].Path
+	})
+	ret.LargestAttributes = attrs[:min(top, len(attrs))]
+
+	return ret
+}
+
+func (s *stateStats) render(size int) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "State size: %d bytes\n", size)
+	fmt.Fprintf(&buf, "Resources: %d (%d instances, %d deposed objects)\n", s.Resources, s.Instances, s.Deposed)
+
+	renderCounts := func(title string, counts map[string]*stateStatsCount) {
+		if len(counts) == 0 {
+			return
+		}
+		keys := make([]string, 0, len(counts))
+		width := 0
+		for k := range counts {
+			keys = append(keys, k)
+			width = max(width, len(k))
+		}
+		// Largest groups first, since those are the candidates for splitting.
+		sort.Slice(keys, func(i, j int) bool {
+			if counts[keys[i]].Instances != counts[keys[j]].Instances {
+				return counts[keys[i]].Instances > counts[keys[j]].Instances
+			}
+			return keys[i] < keys[j]
+		})
+		fmt.Fprintf(&buf, "\n%s:\n", title)
+		for _, k := range keys {
+			fmt.Fprintf(&buf, "  %-*s  %d resources, %d instances\n", width, k, counts[k].Resources, counts[k].Instances)
+		}
+	}
+	renderCounts("Resources by module", s.ByModule)
+	renderCounts("Resources by type", s.ByType)
+
+	if len(s.LargestAttributes) != 0 {
+		buf.WriteString("\nLargest attribute values:\n")
+		for _, attr := range s.LargestAttributes {
+			fmt.Fprintf(&buf, "  %10d bytes  %s\n", attr.Size, attr.Path)
+		}
+	}
+
+	if len(s.DeposedInstances) != 0 {
+		instances := make([]string, 0, len(s.DeposedInstances))
+		for addr := range s.DeposedInstances {
+			instances = append(instances, addr)
+		}
+		sort.Strings(instances)
+		buf.WriteString("\nInstances with deposed objects:\n")
+		for _, addr := range instances {
+			fmt.Fprintf(&buf, "  %s (%d)\n", addr, s.DeposedInstances[addr])
+		}
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (c *StateStatsCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state stats [options]
+
+  Summarize the size and contents of the OpenTofu state.
+
+  This command reports the size of the state snapshot, the number of
+  resources and instances per module and per resource type, the largest
+  attribute values stored in the state, and the resource instances that
+  have deposed objects waiting to be destroyed.
+
+  Use it to find out why operations on a large state are slow and which
+  parts of the configuration are good candidates to split out.
+
+Options:
+
+  -state=statefile    Path to a OpenTofu state file to use to look
+                      up OpenTofu-managed resources. By default, OpenTofu
+                      will consult the state of the currently-selected
+                      workspace.
+
+  -top=n              Number of largest attribute values to show.
+                      Defaults to 10.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateStatsCommand) Synopsis() string {
+	return "Summarize the size and contents of the state"
+}
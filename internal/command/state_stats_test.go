@@ -0,0 +1,121 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestStateStats(t *testing.T) {
+	state := testState()
+	provider := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	child := state.EnsureModule(addrs.RootModuleInstance.Child("child", addrs.NoKey))
+	for i := 0; i < 2; i++ {
+		child.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "many",
+			}.Instance(addrs.IntKey(i)),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"many","policy":"a very long policy document"}`),
+				Status:    states.ObjectReady,
+			},
+			provider,
+			addrs.NoKey,
+		)
+	}
+	state.RootModule().SetResourceInstanceDeposed(
+		addrs.Resource{
+			Mode: addrs.ManagedResourceMode,
+			Type: "test_instance",
+			Name: "foo",
+		}.Instance(addrs.NoKey),
+		states.DeposedKey("00000001"),
+		&states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{"id":"old"}`),
+			Status:    states.ObjectReady,
+		},
+		provider,
+		addrs.NoKey,
+	)
+	statePath := testStateFile(t, state)
+
+	ui := cli.NewMockUi()
+	c := &StateStatsCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-top", "3",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual := ui.OutputWriter.String()
+	// The serialized size depends on the state file format details, so we
+	// only check that it is reported.
+	if !strings.HasPrefix(actual, "State size: ") {
+		t.Fatalf("missing state size in output:\n%s", actual)
+	}
+	actual = actual[strings.Index(actual, "\n")+1:]
+
+	expected := strings.TrimSpace(testStateStatsOutput) + "\n"
+	if actual != expected {
+		t.Fatalf("Expected:\n%s\n\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestStateStats_noState(t *testing.T) {
+	td := t.TempDir()
+	defer testChdir(t, td)()
+
+	ui := cli.NewMockUi()
+	c := &StateStatsCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("expected exit status 1, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "No state file was found!") {
+		t.Fatalf("wrong error output:\n%s", got)
+	}
+}
+
+const testStateStatsOutput = `
+Resources: 2 (3 instances, 1 deposed objects)
+
+Resources by module:
+  module.child   1 resources, 2 instances
+  (root module)  1 resources, 1 instances
+
+Resources by type:
+  test_instance  2 resources, 3 instances
+
+Largest attribute values:
+          29 bytes  module.child.test_instance.many[0].policy
+          29 bytes  module.child.test_instance.many[1].policy
+           6 bytes  module.child.test_instance.many[0].id
+
+Instances with deposed objects:
+  test_instance.foo (1)
+`
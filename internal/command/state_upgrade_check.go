@@ -0,0 +1,194 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// StateUpgradeCheckCommand is a Command implementation that reports which
+// resource instances in the state were saved with an older schema version
+// than the installed providers use, and whether upgrading them would fail.
+type StateUpgradeCheckCommand struct {
+	Meta
+}
+
+func (c *StateUpgradeCheckCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("state upgrade-check")
+	c.Meta.varFlagSet(cmdFlags)
+	cmdFlags.StringVar(&c.Meta.statePath, "state", "", "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return cli.RunResultHelp
+	}
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("The state upgrade-check command expects no arguments.\n")
+		return cli.RunResultHelp
+	}
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil, enc.State())
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(backendDiags)
+		return 1
+	}
+
+	// This is a read-only command
+	c.ignoreRemoteVersionConflict(b)
+
+	// Get the state
+	env, err := c.Workspace()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
+		return 1
+	}
+	stateMgr, err := b.StateMgr(env)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+	state := stateMgr.State()
+	if state == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+
+	opts, err := c.contextOpts()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load the installed providers: %s", err))
+		return 1
+	}
+	tfCtx, diags := tofu.NewContext(opts)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+	results, moreDiags := tfCtx.UpgradeCheck(state)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	if len(results) == 0 {
+		c.showDiagnostics(diags)
+		c.Ui.Output("All resource instances in the state use the current schema versions of their resource types.")
+		return 0
+	}
+
+	var out strings.Builder
+	failed := 0
+	fmt.Fprintf(&out, "Resource instances with a different schema version than the installed providers use: %d\n\n", len(results))
+	for _, result := range results {
+		status := "upgrade succeeds"
+		if result.Diagnostics.HasErrors() {
+			status = "upgrade fails"
+			failed++
+		}
+		fmt.Fprintf(&out, "  %s: version %d -> %d, %s\n", upgradeCheckAddr(result), result.FromVersion, result.ToVersion, status)
+
+		for _, diag := range result.Diagnostics {
+			desc := diag.Description()
+			detail := fmt.Sprintf("While upgrading %s from schema version %d to %d.", upgradeCheckAddr(result), result.FromVersion, result.ToVersion)
+			if desc.Detail != "" {
+				detail = fmt.Sprintf("%s %s", detail, desc.Detail)
+			}
+			diags = diags.Append(tfdiags.Sourceless(diag.Severity(), desc.Summary, detail))
+		}
+	}
+	if failed == 0 {
+		out.WriteString("\nAll of these resource instances can be upgraded. Nothing was saved to the state.")
+	} else {
+		fmt.Fprintf(&out, "\n%d of these resource instances can't be upgraded. Nothing was saved to the state.", failed)
+	}
+
+	c.showDiagnostics(diags)
+	c.Ui.Output(out.String())
+	if failed != 0 {
+		return 1
+	}
+	return 0
+}
+
+// upgradeCheckAddr returns the display address of the object described by
+// the given result.
+func upgradeCheckAddr(result tofu.UpgradeCheckResult) string {
+	if result.DeposedKey != states.NotDeposed {
+		return fmt.Sprintf("%s (deposed object %s)", result.Addr, result.DeposedKey)
+	}
+	return result.Addr.String()
+}
+
+func (c *StateUpgradeCheckCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state upgrade-check [options]
+
+  Report the resource instances in the state that were saved with a different
+  schema version than the installed providers use for their resource types,
+  and check whether the providers can upgrade them.
+
+  OpenTofu upgrades these resource instances the next time it reads the
+  state for a plan, and can't continue if an upgrade fails. This command
+  runs the same upgrades without saving the results, so that you can find
+  problems before upgrading a provider for real, for example after running
+  "tofu init -upgrade" in a separate working directory.
+
+  The providers are not given their configuration, so upgrades that depend on
+  it might fail here even though they would succeed during a plan.
+
+  The exit code is 1 if any of the upgrades would fail.
+
+Options:
+
+  -state=PATH         Path to a OpenTofu state file to use to look up
+                      OpenTofu-managed resources. By default, OpenTofu
+                      will consult the state of the currently-selected
+                      workspace.
+
+  -var 'foo=bar'      Set a value for one of the input variables in the root
+                      module of the configuration. Use this option more than
+                      once to set more than one variable.
+
+  -var-file=filename  Load variable values from the given file, in addition
+                      to the default files terraform.tfvars and *.auto.tfvars.
+                      Use this option more than once to include more than one
+                      variables file.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateUpgradeCheckCommand) Synopsis() string {
+	return "Check whether resource schema upgrades would succeed"
+}
+
+func (c *StateUpgradeCheckCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *StateUpgradeCheckCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-state": complete.PredictFiles("*.tfstate"),
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestStateUpgradeCheck(t *testing.T) {
+	provider := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	state := states.BuildState(func(s *states.SyncState) {
+		for name, version := range map[string]uint64{"current": 1, "old": 0, "broken": 0} {
+			s.SetResourceInstanceCurrent(
+				addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: "test_instance",
+					Name: name,
+				}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON:     []byte(`{"id":"` + name + `"}`),
+					SchemaVersion: version,
+					Status:        states.ObjectReady,
+				},
+				provider,
+				addrs.NoKey,
+			)
+		}
+	})
+	statePath := testStateFile(t, state)
+
+	p := upgradeCheckTestProvider(1)
+	p.UpgradeResourceStateFn = func(req providers.UpgradeResourceStateRequest) (resp providers.UpgradeResourceStateResponse) {
+		if bytes.Contains(req.RawStateJSON, []byte("broken")) {
+			resp.Diagnostics = resp.Diagnostics.Append(errors.New("unsupported legacy format"))
+			return resp
+		}
+		resp.UpgradedState = cty.ObjectVal(map[string]cty.Value{
+			"id": cty.StringVal("upgraded"),
+		})
+		return resp
+	}
+
+	ui := cli.NewMockUi()
+	c := &StateUpgradeCheckCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+	if code := c.Run([]string{"-state", statePath}); code != 1 {
+		t.Fatalf("wrong exit code %d; want 1\n%s%s", code, ui.ErrorWriter.String(), ui.OutputWriter.String())
+	}
+
+	got := ui.OutputWriter.String()
+	for _, want := range []string{
+		"Resource instances with a different schema version than the installed providers use: 2",
+		"test_instance.broken: version 0 -> 1, upgrade fails",
+		"test_instance.old: version 0 -> 1, upgrade succeeds",
+		"1 of these resource instances can't be upgraded.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output is missing %q\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "test_instance.current") {
+		t.Errorf("output includes an instance at the current schema version\n%s", got)
+	}
+	errOut := ui.ErrorWriter.String()
+	for _, want := range []string{
+		"Error: unsupported legacy format",
+		"While upgrading test_instance.broken from schema version 0 to 1.",
+	} {
+		if !strings.Contains(errOut, want) {
+			t.Errorf("errors are missing %q\n%s", want, errOut)
+		}
+	}
+
+	// Nothing is written to the state.
+	oldAddr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "old",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	if got := testStateRead(t, statePath).ResourceInstance(oldAddr).Current.SchemaVersion; got != 0 {
+		t.Errorf("state was modified, with schema version %d", got)
+	}
+}
+
+func TestStateUpgradeCheck_upToDate(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	ui := cli.NewMockUi()
+	c := &StateUpgradeCheckCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(upgradeCheckTestProvider(0)),
+			Ui:               ui,
+		},
+	}
+	if code := c.Run([]string{"-state", statePath}); code != 0 {
+		t.Fatalf("wrong exit code %d\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "All resource instances in the state use the current schema versions"; !strings.Contains(got, want) {
+		t.Errorf("output is missing %q\n%s", want, got)
+	}
+}
+
+// upgradeCheckTestProvider returns a test provider whose test_instance
+// resource type has the given schema version.
+func upgradeCheckTestProvider(version int64) *tofu.MockProvider {
+	p := testProvider()
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Version: version,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id": {Type: cty.String, Computed: true},
+					},
+				},
+			},
+		},
+	}
+	return p
+}
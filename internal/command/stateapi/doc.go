@@ -0,0 +1,6 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package stateapi implements a read-only HTTP API for the resources and
+// output values in a state snapshot, as served by "tofu state serve".
+package stateapi
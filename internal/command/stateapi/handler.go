@@ -0,0 +1,436 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package stateapi
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Loader returns the latest state snapshot.
+type Loader func() (*states.State, error)
+
+// Handler is an http.Handler serving the state API.
+//
+// Every request must carry the handler's token as a bearer token in its
+// Authorization header. The handler only reads the state again once the
+// snapshot it has is older than its refresh interval, so that clients
+// making many requests don't cause a full state read for each of them.
+type Handler struct {
+	load     Loader
+	token    string
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    *states.State
+	loadedAt time.Time
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler returns a handler that serves the state returned by the given
+// loader to clients that present the given token.
+//
+// A refresh interval of zero reads the state again for every request.
+func NewHandler(load Loader, token string, interval time.Duration) *Handler {
+	return &Handler{
+		load:     load,
+		token:    token,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Resource is the description of a resource instance in responses.
+type Resource struct {
+	Address  string `json:"address"`
+	Module   string `json:"module,omitempty"`
+	Mode     string `json:"mode"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Index    any    `json:"index,omitempty"`
+	Provider string `json:"provider"`
+
+	// Attributes and SensitiveAttributes are only included when a single
+	// resource instance is requested. The values of sensitive attributes are
+	// replaced with null.
+	Attributes          json.RawMessage `json:"attributes,omitempty"`
+	SensitiveAttributes []string        `json:"sensitive_attributes,omitempty"`
+}
+
+// Output is the description of a root module output value in responses. The
+// value of a sensitive output value is not included.
+type Output struct {
+	Sensitive bool            `json:"sensitive"`
+	Type      json.RawMessage `json:"type,omitempty"`
+	Value     json.RawMessage `json:"value,omitempty"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="tofu state serve"`)
+		writeError(w, http.StatusUnauthorized, "a valid bearer token is required")
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "the state API is read-only")
+		return
+	}
+
+	state, err := h.currentState()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to read state: %s", err))
+		return
+	}
+
+	query := r.URL.Query()
+	switch path := r.URL.Path; {
+	case path == "/v1/resources":
+		h.serveResources(w, state, query.Get("type"))
+	case path == "/v1/resource":
+		h.serveResource(w, state, query.Get("address"))
+	case path == "/v1/search":
+		h.serveSearch(w, state, query.Get("attribute"), query.Get("value"))
+	case path == "/v1/outputs":
+		h.serveOutputs(w, state)
+	case strings.HasPrefix(path, "/v1/outputs/"):
+		h.serveOutput(w, state, strings.TrimPrefix(path, "/v1/outputs/"))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no API endpoint at %s", path))
+	}
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+func (h *Handler) currentState() (*states.State, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	if h.state != nil && now.Sub(h.loadedAt) < h.interval {
+		return h.state, nil
+	}
+	state, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state = states.NewState()
+	}
+	h.state, h.loadedAt = state, now
+	return state, nil
+}
+
+func (h *Handler) serveResources(w http.ResponseWriter, state *states.State, typeName string) {
+	resources := []Resource{}
+	for _, addr := range resourceInstanceAddrs(state) {
+		if typeName != "" && addr.Resource.Resource.Type != typeName {
+			continue
+		}
+		resources = append(resources, describeResource(state, addr))
+	}
+	writeJSON(w, map[string]any{"resources": resources})
+}
+
+func (h *Handler) serveResource(w http.ResponseWriter, state *states.State, rawAddr string) {
+	addr, diags := addrs.ParseAbsResourceInstanceStr(rawAddr)
+	if diags.HasErrors() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid resource instance address %q: %s", rawAddr, diags.Err()))
+		return
+	}
+	is := state.ResourceInstance(addr)
+	if is == nil || is.Current == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no resource instance %s in the state", addr))
+		return
+	}
+
+	resource := describeResource(state, addr)
+	attrs, sensitive, err := redactedAttributes(is.Current)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to decode attributes of %s: %s", addr, err))
+		return
+	}
+	resource.Attributes, err = json.Marshal(attrs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resource.SensitiveAttributes = sensitive
+	writeJSON(w, resource)
+}
+
+func (h *Handler) serveSearch(w http.ResponseWriter, state *states.State, rawPath, value string) {
+	if rawPath == "" {
+		writeError(w, http.StatusBadRequest, "the attribute query parameter is required")
+		return
+	}
+	path, hclDiags := hclsyntax.ParseTraversalAbs([]byte(rawPath), "<attribute>", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid attribute path %q: %s", rawPath, hclDiags.Error()))
+		return
+	}
+
+	resources := []Resource{}
+	for _, addr := range resourceInstanceAddrs(state) {
+		obj := state.ResourceInstance(addr).Current
+		// Sensitive values are redacted before searching, so that searches
+		// can't be used to discover them.
+		attrs, _, err := redactedAttributes(obj)
+		if err != nil {
+			continue
+		}
+		got, ok := lookupPath(attrs, path)
+		if !ok {
+			continue
+		}
+		if got, ok := scalarString(got); ok && got == value {
+			resources = append(resources, describeResource(state, addr))
+		}
+	}
+	writeJSON(w, map[string]any{"resources": resources})
+}
+
+func (h *Handler) serveOutputs(w http.ResponseWriter, state *states.State) {
+	outputs := make(map[string]Output)
+	for name, ov := range state.RootModule().OutputValues {
+		output, err := describeOutput(ov)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode output value %q: %s", name, err))
+			return
+		}
+		outputs[name] = output
+	}
+	writeJSON(w, map[string]any{"outputs": outputs})
+}
+
+func (h *Handler) serveOutput(w http.ResponseWriter, state *states.State, name string) {
+	ov := state.RootModule().OutputValues[name]
+	if ov == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no output value %q in the state", name))
+		return
+	}
+	output, err := describeOutput(ov)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode output value %q: %s", name, err))
+		return
+	}
+	writeJSON(w, output)
+}
+
+// resourceInstanceAddrs returns the addresses of all of the resource
+// instances in the state that have a current object, in a stable order.
+func resourceInstanceAddrs(state *states.State) []addrs.AbsResourceInstance {
+	var ret []addrs.AbsResourceInstance
+	for _, ms := range state.Modules {
+		for _, rs := range ms.Resources {
+			for key, is := range rs.Instances {
+				if is.Current == nil {
+					continue
+				}
+				ret = append(ret, rs.Addr.Instance(key))
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Less(ret[j])
+	})
+	return ret
+}
+
+func describeResource(state *states.State, addr addrs.AbsResourceInstance) Resource {
+	rs := state.Resource(addr.ContainingResource())
+	ret := Resource{
+		Address:  addr.String(),
+		Mode:     "managed",
+		Type:     addr.Resource.Resource.Type,
+		Name:     addr.Resource.Resource.Name,
+		Provider: rs.ProviderConfig.String(),
+	}
+	if !addr.Module.IsRoot() {
+		ret.Module = addr.Module.String()
+	}
+	if addr.Resource.Resource.Mode == addrs.DataResourceMode {
+		ret.Mode = "data"
+	}
+	switch key := addr.Resource.Key.(type) {
+	case addrs.IntKey:
+		ret.Index = int(key)
+	case addrs.StringKey:
+		ret.Index = string(key)
+	}
+	return ret
+}
+
+func describeOutput(ov *states.OutputValue) (Output, error) {
+	if ov.Sensitive {
+		return Output{Sensitive: true}, nil
+	}
+	ty, err := ctyjson.MarshalType(ov.Value.Type())
+	if err != nil {
+		return Output{}, err
+	}
+	val, err := ctyjson.Marshal(ov.Value, ov.Value.Type())
+	if err != nil {
+		return Output{}, err
+	}
+	return Output{Type: ty, Value: val}, nil
+}
+
+// redactedAttributes decodes the attributes of the given object, replacing
+// the values of sensitive attributes with nil. It also returns the paths of
+// the sensitive attributes.
+func redactedAttributes(obj *states.ResourceInstanceObjectSrc) (any, []string, error) {
+	if obj.AttrsJSON == nil {
+		// Objects from very old versions of Terraform only have flatmap
+		// attributes, which are meaningless without the resource type schema.
+		return map[string]any{}, nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(obj.AttrsJSON))
+	dec.UseNumber()
+	var attrs any
+	if err := dec.Decode(&attrs); err != nil {
+		return nil, nil, err
+	}
+
+	var sensitive []string
+	for _, pvm := range obj.AttrSensitivePaths {
+		attrs = redactPath(attrs, pvm.Path)
+		sensitive = append(sensitive, strings.TrimPrefix(tfdiags.FormatCtyPath(pvm.Path), "."))
+	}
+	sort.Strings(sensitive)
+	return attrs, sensitive, nil
+}
+
+func redactPath(v any, path cty.Path) any {
+	if len(path) == 0 {
+		return nil
+	}
+	switch step := path[0].(type) {
+	case cty.GetAttrStep:
+		if m, ok := v.(map[string]any); ok {
+			if _, exists := m[step.Name]; exists {
+				m[step.Name] = redactPath(m[step.Name], path[1:])
+			}
+			return v
+		}
+	case cty.IndexStep:
+		switch c := v.(type) {
+		case map[string]any:
+			if step.Key.Type() == cty.String {
+				if _, exists := c[step.Key.AsString()]; exists {
+					c[step.Key.AsString()] = redactPath(c[step.Key.AsString()], path[1:])
+				}
+				return v
+			}
+		case []any:
+			if step.Key.Type() == cty.Number {
+				idx, _ := step.Key.AsBigFloat().Int64()
+				if idx >= 0 && int(idx) < len(c) {
+					c[idx] = redactPath(c[idx], path[1:])
+				}
+				return v
+			}
+		}
+	}
+	// If we can't find the sensitive part of the value, such as an element
+	// of a set, we must redact all of it.
+	return nil
+}
+
+// lookupPath returns the part of the given decoded JSON value at the given
+// path, if there is one.
+func lookupPath(v any, path hcl.Traversal) (any, bool) {
+	for _, step := range path {
+		var key any
+		switch step := step.(type) {
+		case hcl.TraverseRoot:
+			key = step.Name
+		case hcl.TraverseAttr:
+			key = step.Name
+		case hcl.TraverseIndex:
+			switch {
+			case step.Key.Type() == cty.String:
+				key = step.Key.AsString()
+			case step.Key.Type() == cty.Number:
+				idx, _ := step.Key.AsBigFloat().Int64()
+				key = int(idx)
+			default:
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+
+		switch c := v.(type) {
+		case map[string]any:
+			name, ok := key.(string)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = c[name]; !ok {
+				return nil, false
+			}
+		case []any:
+			idx, ok := key.(int)
+			if !ok || idx < 0 || idx >= len(c) {
+				return nil, false
+			}
+			v = c[idx]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// scalarString returns the string form of a decoded JSON string, number, or
+// bool, for comparing with search values. The second result is false for
+// any other value, which never matches a search.
+func scalarString(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,258 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package stateapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+const testToken = "secret-token"
+
+func testState() *states.State {
+	provider := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	return states.BuildState(func(s *states.SyncState) {
+		for i, name := range []string{"web", "db"} {
+			s.SetResourceInstanceCurrent(
+				addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: "test_instance",
+					Name: "server",
+				}.Instance(addrs.IntKey(i)).Absolute(addrs.RootModuleInstance),
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON: []byte(`{"id":"i-` + name + `","tags":{"Name":"` + name + `"},"password":"hunter2","ports":[80,443]}`),
+					AttrSensitivePaths: []cty.PathValueMarks{
+						{Path: cty.GetAttrPath("password"), Marks: cty.NewValueMarks(marks.Sensitive)},
+					},
+					Status: states.ObjectReady,
+				},
+				provider,
+				addrs.NoKey,
+			)
+		}
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.DataResourceMode,
+				Type: "test_data",
+				Name: "lookup",
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance.Child("child", addrs.StringKey("a"))),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"d-1"}`),
+				Status:    states.ObjectReady,
+			},
+			provider,
+			addrs.NoKey,
+		)
+		s.SetOutputValue(addrs.OutputValue{Name: "endpoint"}.Absolute(addrs.RootModuleInstance), cty.StringVal("https://example.com"), false)
+		s.SetOutputValue(addrs.OutputValue{Name: "password"}.Absolute(addrs.RootModuleInstance), cty.StringVal("hunter2"), true)
+	})
+}
+
+func testRequest(t *testing.T, h http.Handler, path string, query url.Values) (int, map[string]any) {
+	t.Helper()
+	target := path
+	if query != nil {
+		target += "?" + query.Encode()
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON response: %s\n%s", err, rec.Body.String())
+	}
+	return rec.Code, body
+}
+
+func resourceAddresses(body map[string]any) []string {
+	var ret []string
+	for _, r := range body["resources"].([]any) {
+		ret = append(ret, r.(map[string]any)["address"].(string))
+	}
+	return ret
+}
+
+func TestHandler_auth(t *testing.T) {
+	h := NewHandler(func() (*states.State, error) { return testState(), nil }, testToken, 0)
+
+	for name, header := range map[string]string{
+		"missing": "",
+		"wrong":   "Bearer nope",
+		"scheme":  "Basic " + testToken,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/resources", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("wrong status %d; want %d", rec.Code, http.StatusUnauthorized)
+			}
+			if strings.Contains(rec.Body.String(), "test_instance") {
+				t.Errorf("unauthorized response includes state data: %s", rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("read-only", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/resources", nil)
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("wrong status %d; want %d", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestHandler_resources(t *testing.T) {
+	h := NewHandler(func() (*states.State, error) { return testState(), nil }, testToken, 0)
+
+	code, body := testRequest(t, h, "/v1/resources", nil)
+	if code != http.StatusOK {
+		t.Fatalf("wrong status %d: %v", code, body)
+	}
+	want := []string{
+		"test_instance.server[0]",
+		"test_instance.server[1]",
+		`module.child["a"].data.test_data.lookup`,
+	}
+	if diff := cmp.Diff(want, resourceAddresses(body)); diff != "" {
+		t.Errorf("wrong resources\n%s", diff)
+	}
+
+	_, body = testRequest(t, h, "/v1/resources", url.Values{"type": {"test_data"}})
+	first := body["resources"].([]any)[0].(map[string]any)
+	if first["mode"] != "data" || first["module"] != `module.child["a"]` || first["index"] != nil {
+		t.Errorf("wrong resource description %#v", first)
+	}
+}
+
+func TestHandler_resource(t *testing.T) {
+	h := NewHandler(func() (*states.State, error) { return testState(), nil }, testToken, 0)
+
+	code, body := testRequest(t, h, "/v1/resource", url.Values{"address": {"test_instance.server[1]"}})
+	if code != http.StatusOK {
+		t.Fatalf("wrong status %d: %v", code, body)
+	}
+	attrs := body["attributes"].(map[string]any)
+	if attrs["id"] != "i-db" {
+		t.Errorf("wrong id %#v", attrs["id"])
+	}
+	if v, ok := attrs["password"]; !ok || v != nil {
+		t.Errorf("sensitive attribute was not redacted: %#v", v)
+	}
+	if diff := cmp.Diff([]any{"password"}, body["sensitive_attributes"]); diff != "" {
+		t.Errorf("wrong sensitive attributes\n%s", diff)
+	}
+	if body["index"] != float64(1) {
+		t.Errorf("wrong index %#v", body["index"])
+	}
+
+	code, _ = testRequest(t, h, "/v1/resource", url.Values{"address": {"test_instance.missing"}})
+	if code != http.StatusNotFound {
+		t.Errorf("wrong status %d for missing resource", code)
+	}
+	code, _ = testRequest(t, h, "/v1/resource", url.Values{"address": {"not an address"}})
+	if code != http.StatusBadRequest {
+		t.Errorf("wrong status %d for invalid address", code)
+	}
+}
+
+func TestHandler_search(t *testing.T) {
+	h := NewHandler(func() (*states.State, error) { return testState(), nil }, testToken, 0)
+
+	tests := map[string]struct {
+		attribute, value string
+		want             []string
+	}{
+		"nested attribute": {"tags.Name", "web", []string{"test_instance.server[0]"}},
+		"index syntax":     {`tags["Name"]`, "db", []string{"test_instance.server[1]"}},
+		"list element":     {"ports[1]", "443", []string{"test_instance.server[0]", "test_instance.server[1]"}},
+		"sensitive":        {"password", "hunter2", nil},
+		"no match":         {"id", "i-none", nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, body := testRequest(t, h, "/v1/search", url.Values{"attribute": {test.attribute}, "value": {test.value}})
+			if code != http.StatusOK {
+				t.Fatalf("wrong status %d: %v", code, body)
+			}
+			if diff := cmp.Diff(test.want, resourceAddresses(body)); diff != "" {
+				t.Errorf("wrong resources\n%s", diff)
+			}
+		})
+	}
+
+	code, _ := testRequest(t, h, "/v1/search", url.Values{"value": {"x"}})
+	if code != http.StatusBadRequest {
+		t.Errorf("wrong status %d without an attribute", code)
+	}
+}
+
+func TestHandler_outputs(t *testing.T) {
+	h := NewHandler(func() (*states.State, error) { return testState(), nil }, testToken, 0)
+
+	code, body := testRequest(t, h, "/v1/outputs", nil)
+	if code != http.StatusOK {
+		t.Fatalf("wrong status %d: %v", code, body)
+	}
+	want := map[string]any{
+		"endpoint": map[string]any{"sensitive": false, "type": "string", "value": "https://example.com"},
+		"password": map[string]any{"sensitive": true},
+	}
+	if diff := cmp.Diff(want, body["outputs"]); diff != "" {
+		t.Errorf("wrong outputs\n%s", diff)
+	}
+
+	_, body = testRequest(t, h, "/v1/outputs/endpoint", nil)
+	if body["value"] != "https://example.com" {
+		t.Errorf("wrong output %#v", body)
+	}
+	code, _ = testRequest(t, h, "/v1/outputs/missing", nil)
+	if code != http.StatusNotFound {
+		t.Errorf("wrong status %d for missing output", code)
+	}
+}
+
+func TestHandler_refreshInterval(t *testing.T) {
+	loads := 0
+	h := NewHandler(func() (*states.State, error) {
+		loads++
+		return testState(), nil
+	}, testToken, time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	testRequest(t, h, "/v1/outputs", nil)
+	testRequest(t, h, "/v1/resources", nil)
+	if loads != 1 {
+		t.Fatalf("state was read %d times; want 1", loads)
+	}
+
+	now = now.Add(time.Minute)
+	testRequest(t, h, "/v1/outputs", nil)
+	if loads != 2 {
+		t.Fatalf("state was read %d times after the refresh interval; want 2", loads)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/posener/complete"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/clistate"
@@ -26,9 +28,10 @@ type TaintCommand struct {
 
 func (c *TaintCommand) Run(args []string) int {
 	args = c.Meta.process(args)
-	var allowMissing bool
+	var allowMissing, dryRun bool
 	cmdFlags := c.Meta.ignoreRemoteVersionFlagSet("taint")
 	cmdFlags.BoolVar(&allowMissing, "allow-missing", false, "allow missing")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "dry run")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
@@ -42,24 +45,26 @@ func (c *TaintCommand) Run(args []string) int {
 
 	var diags tfdiags.Diagnostics
 
-	// Require the one argument for the resource to taint
+	// Require at least one address or pattern for the resources to taint
 	args = cmdFlags.Args()
-	if len(args) != 1 {
-		c.Ui.Error("The taint command expects exactly one argument.")
+	if len(args) == 0 {
+		c.Ui.Error("The taint command expects at least one argument.")
 		cmdFlags.Usage()
 		return 1
 	}
 
-	addr, addrDiags := addrs.ParseAbsResourceInstanceStr(args[0])
+	taintAddrs, addrDiags := parseTaintAddrs(args)
 	diags = diags.Append(addrDiags)
 	if addrDiags.HasErrors() {
 		c.showDiagnostics(diags)
 		return 1
 	}
 
-	if addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
-		c.Ui.Error(fmt.Sprintf("Resource instance %s cannot be tainted", addr))
-		return 1
+	for _, ta := range taintAddrs {
+		if ta.pattern == nil && ta.addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			c.Ui.Error(fmt.Sprintf("Resource instance %s cannot be tainted", ta.addr))
+			return 1
+		}
 	}
 
 	if diags := c.Meta.checkRequiredVersion(); diags != nil {
@@ -126,7 +131,7 @@ func (c *TaintCommand) Run(args []string) int {
 	state := stateMgr.State()
 	if state.Empty() {
 		if allowMissing {
-			return c.allowMissingExit(addr)
+			return c.allowMissingExit(taintAddrs)
 		}
 
 		diags = diags.Append(tfdiags.Sourceless(
@@ -148,45 +153,75 @@ func (c *TaintCommand) Run(args []string) int {
 
 	ss := state.SyncWrapper()
 
-	// Get the resource and instance we're going to taint
-	rs := ss.Resource(addr.ContainingResource())
-	is := ss.ResourceInstance(addr)
-	if is == nil {
-		if allowMissing {
-			return c.allowMissingExit(addr)
+	// Find all of the resource instances we're going to taint
+	var selected []addrs.AbsResourceInstance
+	seen := make(map[string]bool)
+	for _, ta := range taintAddrs {
+		var found []addrs.AbsResourceInstance
+		if ta.pattern != nil {
+			found = ta.matchingInstances(state, func(*states.ResourceInstanceObjectSrc) bool {
+				return true
+			})
+			if len(found) == 0 {
+				diags = diags.Append(noTaintPatternMatchesDiag(ta.raw, allowMissing))
+			}
+		} else {
+			addr := ta.addr
+			is := ss.ResourceInstance(addr)
+			switch {
+			case is == nil && allowMissing:
+				diags = diags.Append(allowMissingDiag(addr.String()))
+			case is == nil:
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"No such resource instance",
+					fmt.Sprintf("There is no resource instance in the state with the address %s. If the resource configuration has just been added, you must run \"tofu apply\" once to create the corresponding instance(s) before they can be tainted.", addr),
+				))
+			case is.Current == nil && len(is.Deposed) != 0:
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"No such resource instance",
+					fmt.Sprintf("Resource instance %s is currently part-way through a create_before_destroy replacement action. Run \"tofu apply\" to complete its replacement before tainting it.", addr),
+				))
+			case is.Current == nil:
+				// Don't know why we're here, but we'll produce a generic error message anyway.
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"No such resource instance",
+					fmt.Sprintf("Resource instance %s does not currently have a remote object associated with it, so it cannot be tainted.", addr),
+				))
+			default:
+				found = []addrs.AbsResourceInstance{addr}
+			}
 		}
 
-		diags = diags.Append(tfdiags.Sourceless(
-			tfdiags.Error,
-			"No such resource instance",
-			fmt.Sprintf("There is no resource instance in the state with the address %s. If the resource configuration has just been added, you must run \"tofu apply\" once to create the corresponding instance(s) before they can be tainted.", addr),
-		))
+		for _, addr := range found {
+			if key := addr.String(); !seen[key] {
+				seen[key] = true
+				selected = append(selected, addr)
+			}
+		}
+	}
+	if diags.HasErrors() {
 		c.showDiagnostics(diags)
 		return 1
 	}
 
-	obj := is.Current
-	if obj == nil {
-		if len(is.Deposed) != 0 {
-			diags = diags.Append(tfdiags.Sourceless(
-				tfdiags.Error,
-				"No such resource instance",
-				fmt.Sprintf("Resource instance %s is currently part-way through a create_before_destroy replacement action. Run \"tofu apply\" to complete its replacement before tainting it.", addr),
-			))
-		} else {
-			// Don't know why we're here, but we'll produce a generic error message anyway.
-			diags = diags.Append(tfdiags.Sourceless(
-				tfdiags.Error,
-				"No such resource instance",
-				fmt.Sprintf("Resource instance %s does not currently have a remote object associated with it, so it cannot be tainted.", addr),
-			))
-		}
+	if dryRun || len(selected) == 0 {
 		c.showDiagnostics(diags)
-		return 1
+		for _, addr := range selected {
+			c.Ui.Output(fmt.Sprintf("Resource instance %s would be marked as tainted.", addr))
+		}
+		return 0
 	}
 
-	obj.Status = states.ObjectTainted
-	ss.SetResourceInstanceCurrent(addr, obj, rs.ProviderConfig, is.ProviderKey)
+	for _, addr := range selected {
+		rs := ss.Resource(addr.ContainingResource())
+		is := ss.ResourceInstance(addr)
+		obj := is.Current
+		obj.Status = states.ObjectTainted
+		ss.SetResourceInstanceCurrent(addr, obj, rs.ProviderConfig, is.ProviderKey)
+	}
 
 	if err := stateMgr.WriteState(state); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
@@ -198,13 +233,31 @@ func (c *TaintCommand) Run(args []string) int {
 	}
 
 	c.showDiagnostics(diags)
-	c.Ui.Output(fmt.Sprintf("Resource instance %s has been marked as tainted.", addr))
+	for _, addr := range selected {
+		c.Ui.Output(fmt.Sprintf("Resource instance %s has been marked as tainted.", addr))
+	}
 	return 0
 }
 
+func (c *TaintCommand) AutocompleteArgs() complete.Predictor {
+	return c.completePredictResourceAddress()
+}
+
+func (c *TaintCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-allow-missing": complete.PredictNothing,
+		"-dry-run":       complete.PredictNothing,
+		"-backup":        complete.PredictFiles("*"),
+		"-lock":          completePredictBoolean,
+		"-lock-timeout":  complete.PredictAnything,
+		"-state":         complete.PredictFiles("*"),
+		"-state-out":     complete.PredictFiles("*"),
+	}
+}
+
 func (c *TaintCommand) Help() string {
 	helpText := `
-Usage: tofu [global options] taint [options] <address>
+Usage: tofu [global options] taint [options] <address>...
 
   OpenTofu uses the term "tainted" to describe a resource instance
   which may not be fully functional, either because its creation
@@ -223,6 +276,13 @@ Usage: tofu [global options] taint [options] <address>
     aws_instance.bar[1]
     module.foo.module.bar.aws_instance.baz
 
+  You can give more than one address to taint several resource
+  instances at once. An address can also be a pattern, where "[*]"
+  matches any instance key and any other "*" matches any part of a
+  resource type, resource name, or module name, such as:
+    module.workers.aws_instance.node[*]
+    module.*.aws_instance.web
+
   Use your shell's quoting or escaping syntax to ensure that the
   address will reach OpenTofu correctly, without any special
   interpretation.
@@ -232,6 +292,9 @@ Options:
   -allow-missing          If specified, the command will succeed (exit code 0)
                           even if the resource is missing.
 
+  -dry-run                List the resource instances that would be tainted,
+                          without changing the state.
+
   -lock=false             Don't hold a state lock during the operation. This is
                           dangerous if others might concurrently run commands
                           against the same workspace.
@@ -261,11 +324,11 @@ func (c *TaintCommand) Synopsis() string {
 	return "Mark a resource instance as not fully functional"
 }
 
-func (c *TaintCommand) allowMissingExit(name addrs.AbsResourceInstance) int {
-	c.showDiagnostics(tfdiags.Sourceless(
-		tfdiags.Warning,
-		"No such resource instance",
-		fmt.Sprintf("Resource instance %s was not found, but this is not an error because -allow-missing was set.", name),
-	))
+func (c *TaintCommand) allowMissingExit(taintAddrs []taintAddr) int {
+	var diags tfdiags.Diagnostics
+	for _, ta := range taintAddrs {
+		diags = diags.Append(allowMissingDiag(ta.raw))
+	}
+	c.showDiagnostics(diags)
 	return 0
 }
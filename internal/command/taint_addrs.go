@@ -0,0 +1,139 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// taintAddr is one of the addresses given on the command line of the taint
+// and untaint commands. It is either the address of a single resource
+// instance or a pattern that may match any number of resource instances.
+type taintAddr struct {
+	raw string
+
+	// addr is the address of the selected resource instance. It is valid
+	// only if pattern is nil.
+	addr addrs.AbsResourceInstance
+
+	// pattern matches the string representation of the addresses of the
+	// selected resource instances, or is nil if raw is a single address.
+	pattern *regexp.Regexp
+}
+
+// parseTaintAddrs parses the arguments of the taint and untaint commands.
+//
+// Any argument containing an asterisk is a glob-style pattern, where "[*]"
+// matches any instance key and any other "*" matches any part of a single
+// step of the address, such as a resource type or name. For example,
+// module.workers.aws_instance.node[*] matches all of the instances of
+// that resource.
+func parseTaintAddrs(args []string) ([]taintAddr, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	ret := make([]taintAddr, 0, len(args))
+	for _, raw := range args {
+		if strings.Contains(raw, "*") {
+			ret = append(ret, taintAddr{
+				raw:     raw,
+				pattern: compileTaintPattern(raw),
+			})
+			continue
+		}
+
+		addr, addrDiags := addrs.ParseAbsResourceInstanceStr(raw)
+		diags = diags.Append(addrDiags)
+		if addrDiags.HasErrors() {
+			continue
+		}
+		ret = append(ret, taintAddr{
+			raw:  raw,
+			addr: addr,
+		})
+	}
+	return ret, diags
+}
+
+// compileTaintPattern converts the given glob-style address pattern into
+// an equivalent regular expression.
+func compileTaintPattern(raw string) *regexp.Regexp {
+	var buf strings.Builder
+	buf.WriteString("^")
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case strings.HasPrefix(raw[i:], "[*]"):
+			buf.WriteString(`\[(?:[0-9]+|"(?:[^"\\]|\\.)*")\]`)
+			i += len("[*]") - 1
+		case raw[i] == '*':
+			buf.WriteString(`[^.\[\]"]*`)
+		default:
+			buf.WriteString(regexp.QuoteMeta(raw[i : i+1]))
+		}
+	}
+	buf.WriteString("$")
+
+	// Everything other than our wildcards is quoted, so the result is
+	// always a valid regular expression.
+	return regexp.MustCompile(buf.String())
+}
+
+// matchingInstances returns the addresses of all of the managed resource
+// instances in the given state that match the receiver's pattern and for
+// which the given filter function returns true, sorted by address.
+func (a taintAddr) matchingInstances(state *states.State, filter func(obj *states.ResourceInstanceObjectSrc) bool) []addrs.AbsResourceInstance {
+	var ret []addrs.AbsResourceInstance
+	for _, ms := range state.Modules {
+		for _, rs := range ms.Resources {
+			if rs.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				continue
+			}
+			for key, is := range rs.Instances {
+				if is.Current == nil || !filter(is.Current) {
+					continue
+				}
+				addr := rs.Addr.Instance(key)
+				if a.pattern.MatchString(addr.String()) {
+					ret = append(ret, addr)
+				}
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Less(ret[j])
+	})
+	return ret
+}
+
+// noTaintPatternMatchesDiag returns the diagnostic for a pattern that
+// matched no resource instances.
+func noTaintPatternMatchesDiag(pattern string, allowMissing bool) tfdiags.Diagnostic {
+	if allowMissing {
+		return tfdiags.Sourceless(
+			tfdiags.Warning,
+			"No matching resource instances",
+			fmt.Sprintf("The pattern %s doesn't match any resource instances in the state, but this is not an error because -allow-missing was set.", pattern),
+		)
+	}
+	return tfdiags.Sourceless(
+		tfdiags.Error,
+		"No matching resource instances",
+		fmt.Sprintf("The pattern %s doesn't match any resource instances in the state.", pattern),
+	)
+}
+
+// allowMissingDiag returns the warning for an address that doesn't match
+// any resource instance when -allow-missing is set.
+func allowMissingDiag(addr string) tfdiags.Diagnostic {
+	return tfdiags.Sourceless(
+		tfdiags.Warning,
+		"No such resource instance",
+		fmt.Sprintf("Resource instance %s was not found, but this is not an error because -allow-missing was set.", addr),
+	)
+}
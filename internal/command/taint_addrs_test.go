@@ -0,0 +1,37 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"testing"
+)
+
+func TestCompileTaintPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		addr    string
+		want    bool
+	}{
+		{`aws_instance.node[*]`, `aws_instance.node[0]`, true},
+		{`aws_instance.node[*]`, `aws_instance.node["a.b"]`, true},
+		{`aws_instance.node[*]`, `aws_instance.node`, false},
+		{`aws_instance.node[*]`, `aws_instance.nodes[0]`, false},
+		{`aws_instance.node[*]`, `module.m.aws_instance.node[0]`, false},
+		{`module.workers.aws_instance.node[*]`, `module.workers.aws_instance.node[12]`, true},
+		{`module.*.aws_instance.web`, `module.a.aws_instance.web`, true},
+		{`module.*.aws_instance.web`, `module.a.module.b.aws_instance.web`, false},
+		{`module.m[*].aws_instance.*`, `module.m["x"].aws_instance.web`, true},
+		{`aws_instance.web_*`, `aws_instance.web_1`, true},
+		{`aws_instance.web_*`, `aws_instance.db_1`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.addr, func(t *testing.T) {
+			got := compileTaintPattern(test.pattern).MatchString(test.addr)
+			if got != test.want {
+				t.Errorf("wrong result %t; want %t", got, test.want)
+			}
+		})
+	}
+}
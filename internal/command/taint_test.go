@@ -502,6 +502,139 @@ func TestTaint_module(t *testing.T) {
 	testStateOutput(t, statePath, testTaintModuleStr)
 }
 
+func TestTaint_pattern(t *testing.T) {
+	provider := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	workers := addrs.RootModuleInstance.Child("workers", addrs.NoKey)
+	node := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "node",
+	}
+	other := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "other",
+	}
+	state := states.BuildState(func(s *states.SyncState) {
+		for i := 0; i < 3; i++ {
+			s.SetResourceInstanceCurrent(
+				node.Instance(addrs.IntKey(i)).Absolute(workers),
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON: []byte(`{"id":"node"}`),
+					Status:    states.ObjectReady,
+				},
+				provider,
+				addrs.NoKey,
+			)
+		}
+		s.SetResourceInstanceCurrent(
+			other.Instance(addrs.NoKey).Absolute(workers),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"other"}`),
+				Status:    states.ObjectReady,
+			},
+			provider,
+			addrs.NoKey,
+		)
+	})
+	statePath := testStateFile(t, state)
+
+	t.Run("dry run", func(t *testing.T) {
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		c := &TaintCommand{
+			Meta: Meta{
+				Ui:   ui,
+				View: view,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			"-dry-run",
+			"module.workers.test_instance.node[*]",
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+
+		got := strings.TrimSpace(ui.OutputWriter.String())
+		want := strings.TrimSpace(`
+Resource instance module.workers.test_instance.node[0] would be marked as tainted.
+Resource instance module.workers.test_instance.node[1] would be marked as tainted.
+Resource instance module.workers.test_instance.node[2] would be marked as tainted.
+`)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("wrong output\n%s", diff)
+		}
+
+		// The state must not have changed.
+		for _, ms := range testStateRead(t, statePath).Modules {
+			for _, rs := range ms.Resources {
+				for _, is := range rs.Instances {
+					if is.Current.Status != states.ObjectReady {
+						t.Errorf("%s was tainted during a dry run", rs.Addr)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("taint", func(t *testing.T) {
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		c := &TaintCommand{
+			Meta: Meta{
+				Ui:   ui,
+				View: view,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			"module.workers.test_instance.node[*]",
+			"module.workers.test_instance.node[1]",
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+
+		got := testStateRead(t, statePath)
+		for i := 0; i < 3; i++ {
+			is := got.ResourceInstance(node.Instance(addrs.IntKey(i)).Absolute(workers))
+			if is.Current.Status != states.ObjectTainted {
+				t.Errorf("instance %d was not tainted", i)
+			}
+		}
+		is := got.ResourceInstance(other.Instance(addrs.NoKey).Absolute(workers))
+		if is.Current.Status != states.ObjectReady {
+			t.Errorf("non-matching instance was tainted")
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		ui := new(cli.MockUi)
+		view, _ := testView(t)
+		c := &TaintCommand{
+			Meta: Meta{
+				Ui:   ui,
+				View: view,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			"module.*.test_instance.missing[*]",
+		}
+		if code := c.Run(args); code == 0 {
+			t.Fatalf("succeeded; want error")
+		}
+	})
+}
+
 func TestTaint_checkRequiredVersion(t *testing.T) {
 	// Create a temporary working directory that is empty
 	td := t.TempDir()
@@ -10,7 +10,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"os"
 	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -30,6 +33,7 @@ import (
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
 )
@@ -129,6 +133,16 @@ func (c *TestCommand) Run(rawArgs []string) int {
 
 	view := views.NewTest(args.ViewType, c.View)
 
+	if c.ReadOnly {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Tests are not allowed in read-only mode",
+			"The test command creates and destroys real infrastructure, which is not allowed when OpenTofu is running in read-only mode.",
+		))
+		view.Diagnostics(nil, nil, diags)
+		return 1
+	}
+
 	// Users can also specify variables via the command line, so we'll parse
 	// all that here.
 	var items []rawFlag
@@ -393,8 +407,14 @@ func (runner *TestSuiteRunner) Start(ctx context.Context) {
 			},
 		}
 
-		fileRunner.ExecuteTestFile(ctx, file)
-		fileRunner.Cleanup(ctx, file)
+		func() {
+			// The temporary state directory must be removed even if the
+			// test file panics, so that we never leave state behind.
+			defer fileRunner.removeStateDir()
+
+			fileRunner.ExecuteTestFile(ctx, file)
+			fileRunner.Cleanup(ctx, file)
+		}()
 		runner.Suite.Status = runner.Suite.Status.Merge(file.Status)
 	}
 }
@@ -403,6 +423,18 @@ type TestFileRunner struct {
 	Suite *TestSuiteRunner
 
 	States map[string]*TestFileState
+
+	// RunStates holds the state each run block finished with, keyed by run
+	// name. For run blocks that used the plan command this is the planned
+	// state. These states are never applied or destroyed, but later run
+	// blocks, and the cleanup of the tracked states, can refer to their
+	// values.
+	RunStates map[string]*TestFileState
+
+	// StateDir is the temporary directory holding the state files of run
+	// blocks that use the temporary_directory state backend. It's created
+	// when first needed, and removed when the test file completes.
+	StateDir string
 }
 
 type TestFileState struct {
@@ -478,7 +510,12 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 			// during graph walk since those values are not stored in the
 			// state file. This is more of a weird workaround instead of a
 			// proper fix, unfortunately.
-			state, err = simulateStateSerialization(state)
+			if run.Config.StateBackend == configs.TemporaryDirectoryTestStateBackend {
+				// Then we really write the state to disk and read it back.
+				state, err = runner.persistState(key, state)
+			} else {
+				state, err = simulateStateSerialization(state)
+			}
 			if err != nil {
 				run.Diagnostics = run.Diagnostics.Append(&hcl.Diagnostic{
 					Severity: hcl.DiagError,
@@ -496,6 +533,7 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 			// configuration.
 			runner.States[key].State = state
 			runner.States[key].Run = run
+			runner.recordRunState(run, state)
 		}
 
 		file.Status = file.Status.Merge(run.Status)
@@ -597,6 +635,8 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 		}
 
 		planCtx.TestContext(config, plan.PlannedState, plan, variables).EvaluateAgainstPlan(run)
+		runner.recordResources(planCtx, config, plan.PlannedState, plan.Changes, run)
+		runner.recordRunState(run, plan.PlannedState)
 		return state, false
 	}
 
@@ -629,6 +669,7 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 	applyCtx, updated, applyDiags := runner.apply(ctx, plan, state, config, run, file)
 
 	// Remove expected diagnostics, and add diagnostics in case anything that should have failed didn't.
+	applyDiags = run.ValidateExpectedApplyErrors(expectedFailures, applyDiags)
 	applyDiags = run.ValidateExpectedFailures(expectedFailures, sourceRanges, applyDiags)
 
 	run.Diagnostics = run.Diagnostics.Append(applyDiags)
@@ -674,9 +715,62 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 	}
 
 	applyCtx.TestContext(config, updated, plan, variables).EvaluateAgainstState(run)
+	runner.recordResources(applyCtx, config, updated, nil, run)
 	return updated, true
 }
 
+// recordResources saves the values of the resource instances in the given
+// state into the run, overridden by the planned values in changes if it is
+// not nil, so that later run blocks can refer to them.
+//
+// This is best-effort: if the schemas can't be loaded then later run blocks
+// just won't be able to refer to the resources of this run.
+func (runner *TestFileRunner) recordResources(tfCtx *tofu.Context, config *configs.Config, state *states.State, changes *plans.Changes, run *moduletest.Run) {
+	schemas, diags := tfCtx.Schemas(config, state)
+	if diags.HasErrors() {
+		log.Printf("[WARN] TestFileRunner: failed to load schemas to record resources for %s: %s", run.Name, diags.Err())
+		return
+	}
+	resources, err := testRunResources(state, changes, schemas)
+	if err != nil {
+		log.Printf("[WARN] TestFileRunner: failed to record resources for %s: %s", run.Name, err)
+		return
+	}
+	run.Resources = resources
+}
+
+// recordRunState saves the state that the given run block finished with, so
+// that it can still be referred to after a later run block updates the same
+// tracked state.
+func (runner *TestFileRunner) recordRunState(run *moduletest.Run, state *states.State) {
+	if runner.RunStates == nil {
+		runner.RunStates = make(map[string]*TestFileState)
+	}
+	runner.RunStates[run.Name] = &TestFileState{
+		Run:   run,
+		State: state,
+	}
+}
+
+// referenceableStates returns all of the states that run blocks can refer to,
+// including both the tracked states and the states of every completed run
+// block.
+func (runner *TestFileRunner) referenceableStates() map[string]*TestFileState {
+	if len(runner.RunStates) == 0 {
+		return runner.States
+	}
+	ret := make(map[string]*TestFileState, len(runner.States)+len(runner.RunStates))
+	for key, state := range runner.States {
+		ret[key] = state
+	}
+	for name, state := range runner.RunStates {
+		// The keys of States are module sources, so we use a prefix that
+		// can't appear in one to keep these separate.
+		ret["run:"+name] = state
+	}
+	return ret
+}
+
 func (runner *TestFileRunner) validate(ctx context.Context, config *configs.Config, run *moduletest.Run, file *moduletest.File) tfdiags.Diagnostics {
 	log.Printf("[TRACE] TestFileRunner: called validate for %s/%s", file.Name, run.Name)
 
@@ -722,7 +816,7 @@ func (runner *TestFileRunner) destroy(ctx context.Context, config *configs.Confi
 
 	var diags tfdiags.Diagnostics
 
-	evalCtx, ctxDiags := getEvalContextForTest(runner.States, config, runner.Suite.GlobalVariables)
+	evalCtx, ctxDiags := getEvalContextForTest(runner.referenceableStates(), config, runner.Suite.GlobalVariables)
 	diags = diags.Append(ctxDiags)
 
 	variables, variableDiags := buildInputVariablesForTest(run, file, config, runner.Suite.GlobalVariables, evalCtx)
@@ -788,7 +882,7 @@ func (runner *TestFileRunner) plan(ctx context.Context, config *configs.Config,
 	references, referenceDiags := run.GetReferences()
 	diags = diags.Append(referenceDiags)
 
-	evalCtx, ctxDiags := getEvalContextForTest(runner.States, config, runner.Suite.GlobalVariables)
+	evalCtx, ctxDiags := getEvalContextForTest(runner.referenceableStates(), config, runner.Suite.GlobalVariables)
 	diags = diags.Append(ctxDiags)
 
 	variables, variableDiags := buildInputVariablesForTest(run, file, config, runner.Suite.GlobalVariables, evalCtx)
@@ -1118,6 +1212,11 @@ func getEvalContextForTest(states map[string]*TestFileState, config *configs.Con
 		for outName, out := range mod.OutputValues {
 			outputs[outName] = out.Value
 		}
+		// The resources are available alongside the outputs, unless the
+		// module has an output of the same name.
+		if _, exists := outputs["resources"]; !exists && state.Run.Resources != nil {
+			outputs["resources"] = cty.ObjectVal(state.Run.Resources)
+		}
 		runCtx[state.Run.Name] = cty.ObjectVal(outputs)
 	}
 
@@ -1140,6 +1239,65 @@ func getEvalContextForTest(states map[string]*TestFileState, config *configs.Con
 	return ctx, diags
 }
 
+// testRunResources returns the values of all of the current resource instance
+// objects in the given state, keyed by instance address, for use as the
+// "resources" attribute of a run block. If changes is not nil then the planned
+// values of the changes take precedence, so that unknown values are preserved.
+//
+// Each element is an object with a "values" attribute holding the object
+// value of the resource instance.
+func testRunResources(state *states.State, changes *plans.Changes, schemas *tofu.Schemas) (map[string]cty.Value, error) {
+	values := make(map[string]cty.Value)
+	for _, mod := range state.Modules {
+		for _, rs := range mod.Resources {
+			schema, _ := schemas.ResourceTypeConfig(rs.ProviderConfig.Provider, rs.Addr.Resource.Mode, rs.Addr.Resource.Type)
+			if schema == nil {
+				continue
+			}
+			for key, is := range rs.Instances {
+				if is.Current == nil {
+					continue
+				}
+				obj, err := is.Current.Decode(schema.ImpliedType())
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode %s: %w", rs.Addr.Instance(key), err)
+				}
+				values[rs.Addr.Instance(key).String()] = obj.Value
+			}
+		}
+	}
+
+	if changes != nil {
+		for _, change := range changes.Resources {
+			if change.DeposedKey != states.NotDeposed {
+				continue
+			}
+			addr := change.Addr.String()
+			if change.Action == plans.Delete {
+				delete(values, addr)
+				continue
+			}
+			schema, _ := schemas.ResourceTypeConfig(change.ProviderAddr.Provider, change.Addr.Resource.Resource.Mode, change.Addr.Resource.Resource.Type)
+			if schema == nil {
+				continue
+			}
+			decoded, err := change.Decode(schema.ImpliedType())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode planned change for %s: %w", addr, err)
+			}
+			values[addr] = decoded.After
+		}
+	}
+
+	ret := make(map[string]cty.Value, len(values))
+	for addr, val := range values {
+		ret[addr] = cty.ObjectVal(map[string]cty.Value{
+			"values": val,
+		})
+	}
+	return ret, nil
+}
+
 type testVariableValueExpression struct {
 	expr       hcl.Expression
 	sourceType tofu.ValueSourceType
@@ -1219,7 +1377,7 @@ func parseAndApplyDefaultValues(unparsedVariables map[string]backend.UnparsedVar
 // the config which must be called so the config can be reused going forward.
 func (runner *TestFileRunner) prepareInputVariablesForAssertions(config *configs.Config, run *moduletest.Run, file *moduletest.File, globals map[string]backend.UnparsedVariableValue) (tofu.InputValues, func(), tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
-	ctx, ctxDiags := getEvalContextForTest(runner.States, config, globals)
+	ctx, ctxDiags := getEvalContextForTest(runner.referenceableStates(), config, globals)
 	diags = diags.Append(ctxDiags)
 
 	variables := make(map[string]backend.UnparsedVariableValue)
@@ -1324,6 +1482,47 @@ func checkProblematicPlanErrors(expectedFailures addrs.Map[addrs.Referenceable,
 	return planDiags
 }
 
+// persistState writes the given state to a state file for the given state key
+// within the file runner's temporary state directory, and then reads it back
+// again, returning the result.
+func (runner *TestFileRunner) persistState(key string, state *states.State) (*states.State, error) {
+	if runner.StateDir == "" {
+		dir, err := os.MkdirTemp("", "tofu-test-state-")
+		if err != nil {
+			return nil, fmt.Errorf("creating temporary state directory: %w", err)
+		}
+		runner.StateDir = dir
+	}
+
+	filename := "terraform.tfstate"
+	if key != MainStateIdentifier {
+		filename = url.PathEscape(key) + ".tfstate"
+	}
+	mgr := statemgr.NewFilesystem(filepath.Join(runner.StateDir, filename), encryption.StateEncryptionDisabled())
+	if err := mgr.WriteState(state); err != nil {
+		return nil, fmt.Errorf("writing state to temporary directory: %w", err)
+	}
+	if err := mgr.PersistState(nil); err != nil {
+		return nil, fmt.Errorf("writing state to temporary directory: %w", err)
+	}
+	if err := mgr.RefreshState(); err != nil {
+		return nil, fmt.Errorf("reading state from temporary directory: %w", err)
+	}
+	return mgr.State(), nil
+}
+
+// removeStateDir removes the file runner's temporary state directory, if it
+// was created.
+func (runner *TestFileRunner) removeStateDir() {
+	if runner.StateDir == "" {
+		return
+	}
+	if err := os.RemoveAll(runner.StateDir); err != nil {
+		log.Printf("[ERROR] TestFileRunner: failed to remove temporary state directory %s: %s", runner.StateDir, err)
+	}
+	runner.StateDir = ""
+}
+
 // simulateStateSerialization takes a state, serializes it, deserializes it
 // and then returns. This is useful for state writing side effects without
 // actually writing a state file.
@@ -6,7 +6,9 @@
 package command
 
 import (
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -66,6 +68,10 @@ func TestTest(t *testing.T) {
 			expected: "2 passed, 0 failed.",
 			code:     0,
 		},
+		"pass_with_run_resources": {
+			expected: "3 passed, 0 failed.",
+			code:     0,
+		},
 		"plan_then_apply": {
 			expected: "2 passed, 0 failed.",
 			code:     0,
@@ -102,6 +108,14 @@ func TestTest(t *testing.T) {
 			expected: "1 passed, 0 failed.",
 			code:     0,
 		},
+		"expect_apply_errors": {
+			expected: "3 passed, 0 failed.",
+			code:     0,
+		},
+		"expect_apply_errors_missing": {
+			expected: "0 passed, 1 failed.",
+			code:     1,
+		},
 		"multiple_files": {
 			expected: "2 passed, 0 failed",
 			code:     0,
@@ -213,6 +227,51 @@ func TestTest(t *testing.T) {
 		})
 	}
 }
+func TestTest_TemporaryDirectoryState(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "temporary_directory_state")), td)
+	defer testChdir(t, td)()
+
+	// The runner creates its state directory within the system temporary
+	// directory, so we'll point that somewhere we can check afterwards.
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	provider := testing_command.NewProvider(nil)
+	view, done := testView(t)
+
+	c := &TestCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(provider.Provider),
+			View:             view,
+		},
+	}
+
+	code := c.Run(nil)
+	output := done(t)
+
+	if code != 0 {
+		t.Errorf("expected status code 0 but got %d", code)
+	}
+	if !strings.Contains(output.Stdout(), "2 passed, 0 failed.") {
+		t.Errorf("output didn't contain expected string:\n\n%s", output.All())
+	}
+	if provider.ResourceCount() > 0 {
+		t.Errorf("should have deleted all resources on completion but left %v", provider.ResourceString())
+	}
+
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("temporary state directory was not removed: found %s", entries[0].Name())
+	}
+	if _, err := os.Stat(filepath.Join(td, "terraform.tfstate")); !os.IsNotExist(err) {
+		t.Errorf("state was written to the working directory")
+	}
+}
+
 func TestTest_Full_Output(t *testing.T) {
 	tcs := map[string]struct {
 		override string
@@ -981,6 +1040,7 @@ plan. Resource actions are indicated with the following symbols:
 OpenTofu will perform the following actions:
 
   # test_resource.resource will be updated in-place
+  # (remote object: id = "598318e0")
   ~ resource "test_resource" "resource" {
         id    = "598318e0"
       ~ value = "start" -> "update"
@@ -996,6 +1056,7 @@ plan. Resource actions are indicated with the following symbols:
 OpenTofu will perform the following actions:
 
   # test_resource.module_resource will be updated in-place
+  # (remote object: id = "df6h8as9")
   ~ resource "test_resource" "module_resource" {
         id    = "df6h8as9"
       ~ value = "start" -> "update"
@@ -0,0 +1,15 @@
+terraform {
+  backend "local" {
+    path = "local-state.tfstate"
+  }
+
+  required_providers {
+    foo = {
+      source = "example.com/acme/foo"
+    }
+  }
+}
+
+module "network" {
+  source = "example.com/acme/network/aws"
+}
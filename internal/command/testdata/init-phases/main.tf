@@ -0,0 +1,9 @@
+terraform {
+    backend "local" {
+        path = "foo.tfstate"
+    }
+}
+
+module "foo" {
+    source = "./foo"
+}
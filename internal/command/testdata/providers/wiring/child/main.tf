@@ -0,0 +1,16 @@
+terraform {
+  required_providers {
+    foo = {
+      source                = "hashicorp/foo"
+      configuration_aliases = [foo.west]
+    }
+  }
+}
+
+resource "foo_resource" "a" {
+  provider = foo.west
+}
+
+module "grandchild" {
+  source = "./grandchild"
+}
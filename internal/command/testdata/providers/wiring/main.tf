@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    foo = {
+      source  = "hashicorp/foo"
+      version = "1.0.0"
+    }
+  }
+}
+
+provider "foo" {
+}
+
+provider "foo" {
+  alias = "east"
+}
+
+module "child" {
+  source = "./child"
+  providers = {
+    foo.west = foo.east
+  }
+}
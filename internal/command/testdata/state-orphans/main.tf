@@ -0,0 +1,14 @@
+resource "test_instance" "foo" {
+}
+
+resource "test_instance" "new" {
+  count = 2
+}
+
+resource "test_instance" "renamed" {
+}
+
+moved {
+  from = test_instance.old_name
+  to   = test_instance.renamed
+}
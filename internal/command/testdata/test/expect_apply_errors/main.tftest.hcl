@@ -0,0 +1,30 @@
+run "by_message" {
+  variables {
+    failure = "invalid value for region"
+  }
+
+  expect_apply_error {
+    message = "invalid value"
+  }
+}
+
+run "by_address_and_message" {
+  variables {
+    failure = "quota exceeded"
+  }
+
+  expect_apply_error {
+    address = test_resource.resource
+    message = "^quota exceeded$"
+  }
+}
+
+run "by_expect_failures" {
+  variables {
+    failure = "access denied"
+  }
+
+  expect_failures = [
+    test_resource.resource
+  ]
+}
@@ -0,0 +1,10 @@
+
+variable "failure" {
+  type    = string
+  default = null
+}
+
+resource "test_resource" "resource" {
+  value         = "value"
+  fail_on_apply = var.failure
+}
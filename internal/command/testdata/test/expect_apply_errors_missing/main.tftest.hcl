@@ -0,0 +1,6 @@
+run "test" {
+  expect_apply_error {
+    address = test_resource.resource
+    message = "invalid value"
+  }
+}
@@ -0,0 +1,24 @@
+run "setup" {
+  variables {
+    value = "applied"
+  }
+}
+
+run "planned" {
+  command = plan
+
+  variables {
+    value = "planned"
+  }
+}
+
+run "verify" {
+  variables {
+    value = "${run.setup.resources["test_resource.foo"].values.value}-${run.planned.resources["test_resource.foo"].values.value}"
+  }
+
+  assert {
+    condition     = test_resource.foo.value == "applied-planned"
+    error_message = "invalid value"
+  }
+}
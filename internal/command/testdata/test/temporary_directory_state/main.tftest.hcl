@@ -0,0 +1,20 @@
+run "first" {
+  state_backend = temporary_directory
+
+  variables {
+    value = "first"
+  }
+}
+
+run "second" {
+  state_backend = temporary_directory
+
+  variables {
+    value = "${run.first.value}-second"
+  }
+
+  assert {
+    condition     = test_resource.foo.value == "first-second"
+    error_message = "invalid value"
+  }
+}
@@ -0,0 +1,4 @@
+resource "test_instance" "foo" {
+  ami     = "bar"
+  unknown = "baz"
+}
@@ -0,0 +1,3 @@
+provider "registry.opentofu.org/hashicorp/test" {
+  version = "1.0.0"
+}
@@ -0,0 +1,3 @@
+locals {
+  unused = "nothing"
+}
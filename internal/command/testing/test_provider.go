@@ -38,6 +38,7 @@ var (
 						"id":              {Type: cty.String, Optional: true, Computed: true},
 						"value":           {Type: cty.String, Optional: true},
 						"interrupt_count": {Type: cty.Number, Optional: true},
+						"fail_on_apply":   {Type: cty.String, Optional: true},
 					},
 				},
 			},
@@ -49,6 +50,7 @@ var (
 						"id":              {Type: cty.String, Required: true},
 						"value":           {Type: cty.String, Computed: true},
 						"interrupt_count": {Type: cty.Number, Computed: true},
+						"fail_on_apply":   {Type: cty.String, Computed: true},
 					},
 				},
 			},
@@ -209,6 +211,15 @@ func (provider *TestProvider) ApplyResourceChange(request providers.ApplyResourc
 		}
 	}
 
+	if failure := request.PlannedState.GetAttr("fail_on_apply"); !failure.IsNull() && failure.IsKnown() {
+		var diags tfdiags.Diagnostics
+		diags = diags.Append(tfdiags.WholeContainingBody(tfdiags.Error, "Failed to apply resource", failure.AsString()))
+		return providers.ApplyResourceChangeResponse{
+			NewState:    request.PriorState,
+			Diagnostics: diags,
+		}
+	}
+
 	resource := request.PlannedState
 	id := resource.GetAttr("id")
 	if !id.IsKnown() {
@@ -0,0 +1,223 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/selfupdate"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// UpgradeCommand is a Command implementation that replaces the running
+// OpenTofu executable with a newer, signature-verified release.
+type UpgradeCommand struct {
+	Meta
+
+	Version           string
+	VersionPrerelease string
+	Platform          getproviders.Platform
+
+	// Executable, if set, is the path of the executable to replace instead
+	// of the running one. This is intended for testing.
+	Executable string
+}
+
+func (c *UpgradeCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("upgrade")
+	var checkOnly bool
+	var channel string
+	cmdFlags.BoolVar(&checkOnly, "check", false, "check")
+	cmdFlags.StringVar(&channel, "channel", "", "channel")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	args = cmdFlags.Args()
+	if len(args) > 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Too many command line arguments",
+			"The upgrade command expects at most one argument: the version to install.",
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	var policy selfupdate.Policy
+	if c.UpgradePolicy != nil {
+		policy = *c.UpgradePolicy
+	}
+	switch selfupdate.Channel(channel) {
+	case "":
+		// Use the channel from the CLI configuration, if any.
+	case selfupdate.ChannelStable, selfupdate.ChannelPrerelease:
+		policy.Channel = selfupdate.Channel(channel)
+	default:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid release channel",
+			fmt.Sprintf("The channel %q is not valid: must be either \"stable\" or \"prerelease\".", channel),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	currentStr := c.Version
+	if c.VersionPrerelease != "" {
+		currentStr += "-" + c.VersionPrerelease
+	}
+	current, err := getproviders.ParseVersion(currentStr)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported OpenTofu build",
+			fmt.Sprintf("This build of OpenTofu has the invalid version %q, so it cannot be upgraded in place.", currentStr),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	updater := selfupdate.NewUpdater(policy, httpclient.New())
+	updater.Platform = c.Platform
+
+	available, err := updater.AvailableVersions(ctx)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to query OpenTofu releases",
+			err.Error(),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	var target getproviders.Version
+	if len(args) == 1 {
+		target, err = getproviders.ParseVersion(strings.TrimPrefix(args[0], "v"))
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid version",
+				fmt.Sprintf("The version %q is not a valid semantic version: %s.", args[0], err),
+			))
+			c.showDiagnostics(diags)
+			return 1
+		}
+		allowed := false
+		for _, v := range available {
+			if v.Same(target) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Version not available",
+				fmt.Sprintf("OpenTofu v%s is not an available release, or is not allowed by the upgrade settings in the CLI configuration.", target),
+			))
+			c.showDiagnostics(diags)
+			return 1
+		}
+	} else {
+		if len(available) == 0 || !current.LessThan(available.Newest()) {
+			c.Ui.Output(fmt.Sprintf("OpenTofu v%s is already the newest available version.", current))
+			return 0
+		}
+		target = available.Newest()
+	}
+
+	if checkOnly {
+		c.Ui.Output(fmt.Sprintf("OpenTofu v%s is available (currently v%s). Run \"tofu upgrade\" to install it.", target, current))
+		return 0
+	}
+	if target.Same(current) {
+		c.Ui.Output(fmt.Sprintf("OpenTofu v%s is already installed.", current))
+		return 0
+	}
+
+	exe := c.Executable
+	if exe == "" {
+		exe, err = selfupdate.Executable()
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to locate the OpenTofu executable",
+				err.Error(),
+			))
+			c.showDiagnostics(diags)
+			return 1
+		}
+	}
+
+	c.Ui.Output(fmt.Sprintf("Downloading OpenTofu v%s for %s...", target, c.Platform))
+	// The new executable is written next to the current one so that it can
+	// be moved into place atomically.
+	newPath, err := updater.Download(ctx, target, filepath.Dir(exe))
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to download OpenTofu",
+			fmt.Sprintf("Could not download and verify OpenTofu v%s: %s.", target, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+	if err := selfupdate.Install(newPath, exe); err != nil {
+		os.Remove(newPath)
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to install OpenTofu",
+			fmt.Sprintf("Could not replace %s: %s. The current version has been left in place.", exe, err),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][bold][green]OpenTofu has been upgraded from v%s to v%s.", current, target,
+	)))
+	return 0
+}
+
+func (c *UpgradeCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] upgrade [options] [VERSION]
+
+  Replaces this OpenTofu executable with the newest release, or with the
+  given VERSION.
+
+  The release checksums are verified against the OpenTofu release signing
+  key before anything is installed. The "upgrade" block in the CLI
+  configuration can select a release channel, pin a version constraint, or
+  point at an internal mirror of the releases.
+
+Options:
+
+  -check             Only report whether a newer version is available,
+                     without installing it.
+
+  -channel=CHANNEL   The release channel to use: "stable" (the default) or
+                     "prerelease". Overrides the CLI configuration.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *UpgradeCommand) Synopsis() string {
+	return "Upgrade OpenTofu to a newer version"
+}
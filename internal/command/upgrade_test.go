@@ -0,0 +1,92 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/selfupdate"
+)
+
+func testUpgradeCommand(t *testing.T, version string) (*UpgradeCommand, *cli.MockUi) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tofu/api.json" {
+			t.Errorf("unexpected request to %s", r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"versions":[{"id":"1.7.3"},{"id":"1.8.0"},{"id":"1.9.0-beta1"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ui := cli.NewMockUi()
+	return &UpgradeCommand{
+		Meta: Meta{
+			Ui: ui,
+			UpgradePolicy: &selfupdate.Policy{
+				APIURL: server.URL + "/tofu/api.json",
+			},
+		},
+		Version:    version,
+		Platform:   getproviders.Platform{OS: "linux", Arch: "amd64"},
+		Executable: "/nonexistent/tofu",
+	}, ui
+}
+
+func TestUpgrade_check(t *testing.T) {
+	c, ui := testUpgradeCommand(t, "1.7.3")
+	if code := c.Run([]string{"-check"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "OpenTofu v1.8.0 is available (currently v1.7.3)"; !strings.Contains(got, want) {
+		t.Errorf("output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestUpgrade_checkPrerelease(t *testing.T) {
+	c, ui := testUpgradeCommand(t, "1.8.0")
+	if code := c.Run([]string{"-check", "-channel=prerelease"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "OpenTofu v1.9.0-beta1 is available"; !strings.Contains(got, want) {
+		t.Errorf("output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestUpgrade_upToDate(t *testing.T) {
+	c, ui := testUpgradeCommand(t, "1.8.0")
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "already the newest available version"; !strings.Contains(got, want) {
+		t.Errorf("output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestUpgrade_unavailableVersion(t *testing.T) {
+	c, ui := testUpgradeCommand(t, "1.7.3")
+	if code := c.Run([]string{"1.9.0-beta1"}); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "Version not available") {
+		t.Errorf("wrong error:\n%s", got)
+	}
+}
+
+func TestUpgrade_invalidChannel(t *testing.T) {
+	c, ui := testUpgradeCommand(t, "1.7.3")
+	if code := c.Run([]string{"-channel=nightly"}); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if got := ui.ErrorWriter.String(); !strings.Contains(got, "Invalid release channel") {
+		t.Errorf("wrong error:\n%s", got)
+	}
+}
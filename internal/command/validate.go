@@ -8,6 +8,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"log"
 	"path/filepath"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configlint"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
 )
@@ -62,7 +64,7 @@ func (c *ValidateCommand) Run(rawArgs []string) int {
 	// Inject variables from args into meta for static evaluation
 	c.GatherVariables(args.Vars)
 
-	validateDiags := c.validate(ctx, dir, args.TestDirectory, args.NoTests)
+	validateDiags := c.validate(ctx, dir, args)
 	diags = diags.Append(validateDiags)
 
 	// Validating with dev overrides in effect means that the result might
@@ -91,14 +93,14 @@ func (c *ValidateCommand) GatherVariables(args *arguments.Vars) {
 	c.Meta.variableArgs = rawFlags{items: &items}
 }
 
-func (c *ValidateCommand) validate(ctx context.Context, dir, testDir string, noTests bool) tfdiags.Diagnostics {
+func (c *ValidateCommand) validate(ctx context.Context, dir string, args *arguments.Validate) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 	var cfg *configs.Config
 
-	if noTests {
+	if args.NoTests {
 		cfg, diags = c.loadConfig(dir)
 	} else {
-		cfg, diags = c.loadConfigWithTests(dir, testDir)
+		cfg, diags = c.loadConfigWithTests(dir, args.TestDirectory)
 	}
 	if diags.HasErrors() {
 		return diags
@@ -108,6 +110,11 @@ func (c *ValidateCommand) validate(ctx context.Context, dir, testDir string, noT
 		var diags tfdiags.Diagnostics
 
 		opts, err := c.contextOpts()
+		if args.CachedSchemas && opts != nil && c.testingOverrides == nil {
+			// Replace the real provider plugins with ones that only serve
+			// the schemas saved by an earlier run.
+			opts.Providers, err = c.cachedSchemaProviderFactories()
+		}
 		if err != nil {
 			diags = diags.Append(err)
 			return diags
@@ -119,12 +126,32 @@ func (c *ValidateCommand) validate(ctx context.Context, dir, testDir string, noT
 			return diags
 		}
 
-		return diags.Append(tfCtx.Validate(ctx, cfg))
+		diags = diags.Append(tfCtx.Validate(ctx, cfg))
+		if args.CachedSchemas || diags.HasErrors() {
+			return diags
+		}
+
+		// Save the schemas we just loaded so that a later run can use
+		// them with -cached-schemas. This is only an optimization, so
+		// we don't fail validation if it doesn't work.
+		schemas, schemaDiags := tfCtx.Schemas(cfg, nil)
+		if schemaDiags.HasErrors() {
+			log.Printf("[WARN] Failed to load provider schemas for caching: %s", schemaDiags.Err())
+			return diags
+		}
+		if err := c.saveProviderSchemas(schemas); err != nil {
+			log.Printf("[WARN] Failed to cache provider schemas: %s", err)
+		}
+		return diags
 	}
 
 	diags = diags.Append(validate(cfg))
 
-	if noTests {
+	if args.Lint && !diags.HasErrors() {
+		diags = diags.Append(c.lint(cfg))
+	}
+
+	if args.NoTests {
 		return diags
 	}
 
@@ -167,6 +194,18 @@ func (c *ValidateCommand) validate(ctx context.Context, dir, testDir string, noT
 	return diags
 }
 
+// lint reports unused declarations in the local modules of the given
+// configuration, which must have been loaded by this command's config loader.
+func (c *ValidateCommand) lint(cfg *configs.Config) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	loader, err := c.initConfigLoader()
+	if err != nil {
+		return diags.Append(err)
+	}
+	return diags.Append(configlint.Lint(cfg, loader.Parser()))
+}
+
 func (c *ValidateCommand) Synopsis() string {
 	return "Check whether the configuration is valid"
 }
@@ -199,6 +238,11 @@ Usage: tofu [global options] validate [options]
 
 Options:
 
+  -cached-schemas       Validate using the provider schemas saved by an
+                        earlier run of this command, instead of starting the
+                        provider plugins. Provider-specific validation rules
+                        are not checked in this mode.
+
   -compact-warnings     If OpenTofu produces any warnings that are not
                         accompanied by errors, show them in a more compact
                         form that includes only the summary messages.
@@ -215,6 +259,10 @@ Options:
                         suitable for use in text editor integrations and other 
                         automated systems. Always disables color.
 
+  -lint                 Also report unused local values and input variables,
+                        and outputs of child modules that the calling module
+                        never refers to.
+
   -no-color             If specified, output won't contain any color.
 
   -no-tests             If specified, OpenTofu will not validate test files.
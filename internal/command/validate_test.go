@@ -17,9 +17,12 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	testing_command "github.com/opentofu/opentofu/internal/command/testing"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providercache"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/terminal"
 )
@@ -93,6 +96,111 @@ func TestValidateCommandWithTfvarsFile(t *testing.T) {
 	}
 }
 
+func TestValidateCommand_lint(t *testing.T) {
+	output, code := setupTest(t, "validate-lint", "-lint")
+	if code != 0 {
+		t.Fatalf("unexpected non-successful exit code %d\n\n%s", code, output.Stderr())
+	}
+	if got, want := output.All(), "Unused local value"; !strings.Contains(got, want) {
+		t.Fatalf("output does not contain %q\n\n%s", want, got)
+	}
+
+	// Without -lint, unused declarations are not reported at all.
+	output, code = setupTest(t, "validate-lint")
+	if code != 0 {
+		t.Fatalf("unexpected non-successful exit code %d\n\n%s", code, output.Stderr())
+	}
+	if got, notWant := output.All(), "Unused local value"; strings.Contains(got, notWant) {
+		t.Fatalf("output unexpectedly contains %q\n\n%s", notWant, got)
+	}
+}
+
+func TestValidateCommand_cachedSchemas(t *testing.T) {
+	schema := providers.ProviderSchema{
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"ami": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	provider := addrs.NewDefaultProvider("test")
+	version := getproviders.MustParseVersion("1.0.0")
+
+	t.Run("valid", func(t *testing.T) {
+		td := t.TempDir()
+		testCopyDir(t, testFixturePath("validate-cached-schemas/valid"), td)
+		defer testChdir(t, td)()
+
+		schemaDir := providercache.NewSchemaDir(".terraform/provider-schemas")
+		if err := schemaDir.SetProviderSchema(provider, version, schema); err != nil {
+			t.Fatal(err)
+		}
+
+		view, done := testView(t)
+		c := &ValidateCommand{
+			Meta: Meta{
+				View: view,
+			},
+		}
+		code := c.Run([]string{"-cached-schemas", "-no-color"})
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("unexpected non-successful exit code %d\n\n%s", code, output.Stderr())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		td := t.TempDir()
+		testCopyDir(t, testFixturePath("validate-cached-schemas/invalid"), td)
+		defer testChdir(t, td)()
+
+		schemaDir := providercache.NewSchemaDir(".terraform/provider-schemas")
+		if err := schemaDir.SetProviderSchema(provider, version, schema); err != nil {
+			t.Fatal(err)
+		}
+
+		view, done := testView(t)
+		c := &ValidateCommand{
+			Meta: Meta{
+				View: view,
+			},
+		}
+		code := c.Run([]string{"-cached-schemas", "-no-color"})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("unexpected exit code %d; want 1\n\n%s", code, output.All())
+		}
+		if got, want := output.Stderr(), "Unsupported argument"; !strings.Contains(got, want) {
+			t.Fatalf("output does not contain %q\n\n%s", want, got)
+		}
+	})
+
+	t.Run("not cached", func(t *testing.T) {
+		td := t.TempDir()
+		testCopyDir(t, testFixturePath("validate-cached-schemas/valid"), td)
+		defer testChdir(t, td)()
+
+		view, done := testView(t)
+		c := &ValidateCommand{
+			Meta: Meta{
+				View: view,
+			},
+		}
+		code := c.Run([]string{"-cached-schemas", "-no-color"})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("unexpected exit code %d; want 1\n\n%s", code, output.All())
+		}
+		if got, want := output.Stderr(), "there is no schema for registry.opentofu.org/hashicorp/test 1.0.0 cached"; !strings.Contains(got, want) {
+			t.Fatalf("output does not contain %q\n\n%s", want, got)
+		}
+	})
+}
+
 func TestValidateFailingCommand(t *testing.T) {
 	if output, code := setupTest(t, "validate-invalid"); code != 1 {
 		t.Fatalf("Should have failed: %d\n\n%s", code, output.Stderr())
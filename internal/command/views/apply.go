@@ -63,6 +63,7 @@ type ApplyHuman struct {
 var _ Apply = (*ApplyHuman)(nil)
 
 func (v *ApplyHuman) ResourceCount(stateOutPath string) {
+	v.view.finishProgress()
 	if v.destroy {
 		v.view.streams.Printf(
 			v.view.colorize.Color("[reset][bold][green]\nDestroy complete! Resources: %d destroyed.\n"),
@@ -104,7 +105,7 @@ func (v *ApplyHuman) Operation() Operation {
 func (v *ApplyHuman) Hooks() []tofu.Hook {
 	return []tofu.Hook{
 		v.countHook,
-		NewUiHook(v.view),
+		v.view.progressHook(),
 	}
 }
 
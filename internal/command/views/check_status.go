@@ -0,0 +1,115 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsonchecks"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// The CheckStatus view renders the check results recorded in the state by the
+// most recent plan or apply.
+type CheckStatus interface {
+	CheckStatus(results *states.CheckResults)
+	Diagnostics(diags tfdiags.Diagnostics)
+}
+
+// NewCheckStatus returns an initialized CheckStatus implementation for the
+// given ViewType.
+func NewCheckStatus(vt arguments.ViewType, view *View) CheckStatus {
+	switch vt {
+	case arguments.ViewJSON:
+		return &CheckStatusJSON{view: view}
+	case arguments.ViewHuman:
+		return &CheckStatusHuman{view: view}
+	default:
+		panic(fmt.Sprintf("unknown view type %v", vt))
+	}
+}
+
+// The CheckStatusHuman implementation renders one line per checkable object
+// and its instances, followed by the failure messages of failing instances.
+type CheckStatusHuman struct {
+	view *View
+}
+
+var _ CheckStatus = (*CheckStatusHuman)(nil)
+
+func (v *CheckStatusHuman) CheckStatus(results *states.CheckResults) {
+	if results == nil || results.ConfigResults.Len() == 0 {
+		v.view.streams.Println("The state does not contain any check results.")
+		return
+	}
+
+	configElems := results.ConfigResults.Elements()
+	sort.Slice(configElems, func(i, j int) bool {
+		return configElems[i].Key.String() < configElems[j].Key.String()
+	})
+
+	var buf strings.Builder
+	for _, configElem := range configElems {
+		fmt.Fprintf(&buf, "%s: %s\n", configElem.Key, v.status(configElem.Value.Status))
+
+		objectElems := configElem.Value.ObjectResults.Elements()
+		sort.Slice(objectElems, func(i, j int) bool {
+			return objectElems[i].Key.String() < objectElems[j].Key.String()
+		})
+		for _, objectElem := range objectElems {
+			fmt.Fprintf(&buf, "  %s: %s\n", objectElem.Key, v.status(objectElem.Value.Status))
+			messages := make([]string, len(objectElem.Value.FailureMessages))
+			copy(messages, objectElem.Value.FailureMessages)
+			sort.Strings(messages)
+			for _, msg := range messages {
+				fmt.Fprintf(&buf, "    - %s\n", msg)
+			}
+		}
+	}
+	v.view.streams.Print(buf.String())
+}
+
+func (v *CheckStatusHuman) status(status checks.Status) string {
+	switch status {
+	case checks.StatusPass:
+		return v.view.colorize.Color("[green]pass[reset]")
+	case checks.StatusFail:
+		return v.view.colorize.Color("[red]fail[reset]")
+	case checks.StatusError:
+		return v.view.colorize.Color("[red]error[reset]")
+	default:
+		return v.view.colorize.Color("[yellow]unknown[reset]")
+	}
+}
+
+func (v *CheckStatusHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+// The CheckStatusJSON implementation renders the check results in the same
+// format as the "checks" property of the "tofu show -json" output.
+type CheckStatusJSON struct {
+	view *View
+}
+
+var _ CheckStatus = (*CheckStatusJSON)(nil)
+
+func (v *CheckStatusJSON) CheckStatus(results *states.CheckResults) {
+	if results == nil {
+		v.view.streams.Println("[]")
+		return
+	}
+	v.view.streams.Println(string(jsonchecks.MarshalCheckStates(results)))
+}
+
+// Diagnostics should only be called if check-status is unable to produce
+// results, and as such does not attempt to render diagnostics in JSON format.
+func (v *CheckStatusJSON) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
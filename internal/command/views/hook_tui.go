@@ -0,0 +1,365 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// defaultTUIRefreshInterval is how often the progress display is redrawn to
+// update the elapsed times while it is active.
+const defaultTUIRefreshInterval = time.Second
+
+// maxTUIRows is the maximum number of resource instances that the progress
+// display lists individually, so that it fits in a typical terminal.
+const maxTUIRows = 20
+
+// NewTUIHook returns a hook that shows the progress of an operation as a
+// live table of the resource instances that are being worked on, redrawn in
+// place on the terminal, instead of the scrolling messages of UiHook.
+//
+// The view's output stream must be a terminal. Anything else that the view
+// writes while the table is active must first call View.finishProgress, so
+// that the table isn't drawn over that output.
+func NewTUIHook(view *View) *TUIHook {
+	return &TUIHook{
+		view:            view,
+		refreshInterval: defaultTUIRefreshInterval,
+		now:             time.Now,
+		rows:            make(map[string]*tuiRow),
+		completed:       make(map[string]bool),
+	}
+}
+
+type TUIHook struct {
+	tofu.NilHook
+
+	view *View
+
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu sync.Mutex
+
+	// rows are the resource instances that are in progress or have failed,
+	// by the string representation of their address. Completed instances
+	// are removed, and only counted in completed.
+	rows      map[string]*tuiRow
+	completed map[string]bool
+
+	// drawnLines is the number of lines of the table currently on the
+	// terminal, which the next redraw replaces. It is zero when the table
+	// isn't active.
+	drawnLines int
+
+	// stopTicker, if not nil, stops the goroutine that periodically redraws
+	// the table.
+	stopTicker chan struct{}
+}
+
+var _ tofu.Hook = (*TUIHook)(nil)
+
+// tuiRow is the state of a single resource instance in the progress display.
+type tuiRow struct {
+	addr    string
+	action  string
+	start   time.Time
+	end     time.Time
+	failed  bool
+	message string
+}
+
+func (h *TUIHook) PreRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value) (tofu.HookAction, error) {
+	h.begin(addr, gen, "Refreshing")
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PostRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value, newState cty.Value) (tofu.HookAction, error) {
+	h.end(addr, gen, nil)
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (tofu.HookAction, error) {
+	h.begin(addr, gen, "Planning")
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PostDiff(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	h.end(addr, gen, nil)
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	switch action {
+	case plans.Delete:
+		h.begin(addr, gen, "Destroying")
+	case plans.Create:
+		h.begin(addr, gen, "Creating")
+	case plans.Update:
+		h.begin(addr, gen, "Modifying")
+	case plans.Read:
+		h.begin(addr, gen, "Reading")
+	}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, applyerr error) (tofu.HookAction, error) {
+	h.end(addr, gen, applyerr)
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PreProvisionInstanceStep(addr addrs.AbsResourceInstance, typeName string) (tofu.HookAction, error) {
+	h.setMessage(addr, states.CurrentGen, fmt.Sprintf("Provisioning with '%s'...", typeName))
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) ProvisionOutput(addr addrs.AbsResourceInstance, typeName string, msg string) {
+	// Only the most recent line of output fits in the table.
+	lines := strings.Split(strings.TrimRight(msg, "\r\n"), "\n")
+	last := strings.TrimSpace(strings.TrimSuffix(lines[len(lines)-1], "\r"))
+	if last == "" {
+		return
+	}
+	h.setMessage(addr, states.CurrentGen, fmt.Sprintf("(%s): %s", typeName, last))
+}
+
+func (h *TUIHook) PrePlanImport(addr addrs.AbsResourceInstance, importID string) (tofu.HookAction, error) {
+	h.begin(addr, states.CurrentGen, "Preparing import")
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PostPlanImport(addr addrs.AbsResourceInstance, imported []providers.ImportedResource) (tofu.HookAction, error) {
+	h.end(addr, states.CurrentGen, nil)
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PreApplyImport(addr addrs.AbsResourceInstance, importing plans.ImportingSrc) (tofu.HookAction, error) {
+	h.begin(addr, states.CurrentGen, "Importing")
+	return tofu.HookActionContinue, nil
+}
+
+func (h *TUIHook) PostApplyImport(addr addrs.AbsResourceInstance, importing plans.ImportingSrc) (tofu.HookAction, error) {
+	h.end(addr, states.CurrentGen, nil)
+	return tofu.HookActionContinue, nil
+}
+
+// Finish draws the table one last time and leaves it on the terminal, so that
+// any output that follows is written below it. The next hook call starts a
+// new table, for the next phase of the operation.
+//
+// Instances that are still in progress, for example because the operation is
+// stopping, are carried over to the new table.
+func (h *TUIHook) Finish() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopTicker != nil {
+		close(h.stopTicker)
+		h.stopTicker = nil
+	}
+	if h.drawnLines == 0 {
+		return
+	}
+
+	h.draw()
+	for key, row := range h.rows {
+		if row.failed {
+			delete(h.rows, key)
+		}
+	}
+	h.completed = make(map[string]bool)
+	h.drawnLines = 0
+}
+
+func (h *TUIHook) begin(addr addrs.AbsResourceInstance, gen states.Generation, action string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := tuiRowKey(addr, gen)
+	delete(h.completed, key)
+	h.rows[key] = &tuiRow{
+		addr:   key,
+		action: action,
+		start:  h.now(),
+	}
+
+	if h.stopTicker == nil {
+		h.stopTicker = make(chan struct{})
+		go h.tick(h.stopTicker)
+	}
+	h.draw()
+}
+
+func (h *TUIHook) end(addr addrs.AbsResourceInstance, gen states.Generation, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := tuiRowKey(addr, gen)
+	row, ok := h.rows[key]
+	if !ok {
+		// Actions that the table doesn't show, such as no-op changes,
+		// have no row.
+		return
+	}
+	if err == nil {
+		delete(h.rows, key)
+		h.completed[key] = true
+	} else {
+		row.failed = true
+		row.end = h.now()
+		row.message, _, _ = strings.Cut(err.Error(), "\n")
+	}
+	h.draw()
+}
+
+func (h *TUIHook) setMessage(addr addrs.AbsResourceInstance, gen states.Generation, msg string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if row, ok := h.rows[tuiRowKey(addr, gen)]; ok {
+		row.message = msg
+		h.draw()
+	}
+}
+
+// tick redraws the table periodically to update the elapsed times, until
+// the given channel is closed.
+func (h *TUIHook) tick(stop <-chan struct{}) {
+	ticker := time.NewTicker(h.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			h.mu.Lock()
+			select {
+			case <-stop:
+				// Finish was called while we were waiting for the lock.
+			default:
+				h.draw()
+			}
+			h.mu.Unlock()
+		}
+	}
+}
+
+// draw replaces the table on the terminal with its current content. The
+// caller must hold h.mu.
+func (h *TUIHook) draw() {
+	var buf strings.Builder
+	if h.drawnLines > 0 {
+		// Move the cursor to the start of the table and clear everything
+		// below it.
+		fmt.Fprintf(&buf, "\x1b[%dA\r\x1b[J", h.drawnLines)
+	}
+	lines := h.render(h.now(), h.view.outputColumns())
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	h.view.streams.Print(buf.String())
+	h.drawnLines = len(lines)
+}
+
+// render returns the lines of the table. Each line fits in the given width,
+// so that the table can be redrawn by moving the cursor up by the number of
+// lines. The caller must hold h.mu.
+func (h *TUIHook) render(now time.Time, width int) []string {
+	rows := make([]*tuiRow, 0, len(h.rows))
+	running, failed := 0, 0
+	for _, row := range h.rows {
+		rows = append(rows, row)
+		if row.failed {
+			failed++
+		} else {
+			running++
+		}
+	}
+	// Failed instances first, since they need attention, and then the
+	// others in the order they started.
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].failed != rows[j].failed {
+			return rows[i].failed
+		}
+		if !rows[i].start.Equal(rows[j].start) {
+			return rows[i].start.Before(rows[j].start)
+		}
+		return rows[i].addr < rows[j].addr
+	})
+
+	summary := fmt.Sprintf("%d in progress, %d complete", running, len(h.completed))
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	lines := []string{fmt.Sprintf(h.view.colorize.Color("[reset][bold]%s[reset]"), tuiTruncate(summary, width))}
+
+	addrWidth := 0
+	for i, row := range rows {
+		if i == maxTUIRows {
+			break
+		}
+		addrWidth = max(addrWidth, len(row.addr))
+	}
+	addrWidth = min(addrWidth, max(width/2, 10))
+
+	for i, row := range rows {
+		if i == maxTUIRows {
+			lines = append(lines, tuiTruncate(fmt.Sprintf("  ... and %d more", len(rows)-i), width))
+			break
+		}
+
+		action, end := row.action+"...", now
+		if row.failed {
+			action, end = "Failed", row.end
+		}
+		line := fmt.Sprintf(
+			"  %-*s  %-18s %8s  %s",
+			addrWidth, tuiTruncate(row.addr, addrWidth+1),
+			action,
+			end.Sub(row.start).Round(time.Second),
+			row.message,
+		)
+		line = tuiTruncate(strings.TrimRight(line, " "), width)
+		if row.failed {
+			line = fmt.Sprintf(h.view.colorize.Color("[red]%s[reset]"), line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func tuiRowKey(addr addrs.AbsResourceInstance, gen states.Generation) string {
+	if depKey, ok := gen.(states.DeposedKey); ok {
+		return fmt.Sprintf("%s (deposed object %s)", addr, depKey)
+	}
+	return addr.String()
+}
+
+// tuiTruncate shortens the given string to fit within the given number of
+// terminal columns, leaving the last column free so that the terminal
+// doesn't wrap the line.
+func tuiTruncate(s string, width int) string {
+	limit := width - 1
+	if limit < 4 {
+		return s
+	}
+	r := []rune(s)
+	if len(r) <= limit {
+		return s
+	}
+	return string(r[:limit-3]) + "..."
+}
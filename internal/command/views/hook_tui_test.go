@@ -0,0 +1,123 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/terminal"
+)
+
+func testTUIHook(t *testing.T) (*TUIHook, *time.Time, func(*testing.T) *terminal.TestOutput) {
+	t.Helper()
+	streams, done := terminal.StreamsForTesting(t)
+	h := NewTUIHook(NewView(streams))
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	// The tests check each redraw, so the periodic one must not happen.
+	h.refreshInterval = time.Hour
+	t.Cleanup(h.Finish)
+	return h, &now, done
+}
+
+func testTUIAddr(name string) addrs.AbsResourceInstance {
+	return addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: name,
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+}
+
+func TestTUIHook_render(t *testing.T) {
+	h, now, _ := testTUIHook(t)
+	val := cty.NullVal(cty.EmptyObject)
+
+	h.PreApply(testTUIAddr("a"), states.CurrentGen, plans.Create, val, val)
+	*now = now.Add(5 * time.Second)
+	h.PreApply(testTUIAddr("long_name"), states.CurrentGen, plans.Update, val, val)
+	h.PreApply(testTUIAddr("c"), states.DeposedKey("00000001"), plans.Delete, val, val)
+	h.PreApply(testTUIAddr("d"), states.CurrentGen, plans.NoOp, val, val)
+	*now = now.Add(3 * time.Second)
+	h.PostApply(testTUIAddr("c"), states.DeposedKey("00000001"), val, nil)
+	h.PostApply(testTUIAddr("d"), states.CurrentGen, val, nil)
+	h.PostApply(testTUIAddr("long_name"), states.CurrentGen, val, errors.New("something went wrong\nwith details"))
+	h.ProvisionOutput(testTUIAddr("a"), "local-exec", "starting\nstill going\n")
+	*now = now.Add(2 * time.Second)
+
+	got := h.render(*now, 200)
+	want := []string{
+		"1 in progress, 1 complete, 1 failed",
+		"  test_instance.long_name  Failed                   3s  something went wrong",
+		"  test_instance.a          Creating...             10s  (local-exec): still going",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	// Lines must be truncated to fit the terminal, so that redrawing
+	// doesn't have to account for wrapped lines.
+	for _, line := range h.render(*now, 40) {
+		if len(line) > 39 {
+			t.Errorf("line is too long for the width: %q", line)
+		}
+	}
+}
+
+func TestTUIHook_manyRows(t *testing.T) {
+	h, _, _ := testTUIHook(t)
+	val := cty.NullVal(cty.EmptyObject)
+
+	for i := 0; i < maxTUIRows+5; i++ {
+		h.PreDiff(testTUIAddr(fmt.Sprintf("r%02d", i)), states.CurrentGen, val, val)
+	}
+
+	got := h.render(h.now(), 200)
+	if want := maxTUIRows + 2; len(got) != want {
+		t.Fatalf("wrong number of lines %d; want %d", len(got), want)
+	}
+	if want := "  ... and 5 more"; got[len(got)-1] != want {
+		t.Errorf("wrong last line %q; want %q", got[len(got)-1], want)
+	}
+}
+
+func TestTUIHook_redrawAndFinish(t *testing.T) {
+	h, _, done := testTUIHook(t)
+	val := cty.NullVal(cty.EmptyObject)
+
+	h.PreRefresh(testTUIAddr("a"), states.CurrentGen, val)
+	h.PreRefresh(testTUIAddr("b"), states.CurrentGen, val)
+	h.PostRefresh(testTUIAddr("a"), states.CurrentGen, val, val)
+	h.Finish()
+
+	// The next phase starts a new table below the previous one, carrying
+	// over the instance that is still in progress.
+	h.PostRefresh(testTUIAddr("b"), states.CurrentGen, val, val)
+	h.Finish()
+
+	// Finishing again without any new progress must not draw anything.
+	h.Finish()
+
+	want := strings.Join([]string{
+		"1 in progress, 0 complete\n  test_instance.a  Refreshing...            0s\n",
+		"\x1b[2A\r\x1b[J2 in progress, 0 complete\n  test_instance.a  Refreshing...            0s\n  test_instance.b  Refreshing...            0s\n",
+		"\x1b[3A\r\x1b[J1 in progress, 1 complete\n  test_instance.b  Refreshing...            0s\n",
+		"\x1b[2A\r\x1b[J1 in progress, 1 complete\n  test_instance.b  Refreshing...            0s\n",
+		"0 in progress, 1 complete\n",
+		"\x1b[1A\r\x1b[J0 in progress, 1 complete\n",
+	}, "")
+	if got := done(t).Stdout(); got != want {
+		t.Errorf("wrong output\ngot:  %q\nwant: %q", got, want)
+	}
+}
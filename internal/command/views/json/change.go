@@ -19,6 +19,13 @@ func NewResourceInstanceChange(change *plans.ResourceInstanceChangeSrc) *Resourc
 		GeneratedConfig: change.GeneratedConfig,
 	}
 
+	if len(change.DriftCauses) != 0 {
+		c.DriftCauses = make(map[string]string, len(change.DriftCauses))
+		for name, cause := range change.DriftCauses {
+			c.DriftCauses[name] = string(cause)
+		}
+	}
+
 	// The order here matters, we want the moved action to take precedence over
 	// the import action. We're basically taking "the most recent action" as the
 	// primary action in the streamed logs. That is to say, that if a resource
@@ -49,12 +56,13 @@ func NewResourceInstanceChange(change *plans.ResourceInstanceChangeSrc) *Resourc
 }
 
 type ResourceInstanceChange struct {
-	Resource         ResourceAddr  `json:"resource"`
-	PreviousResource *ResourceAddr `json:"previous_resource,omitempty"`
-	Action           ChangeAction  `json:"action"`
-	Reason           ChangeReason  `json:"reason,omitempty"`
-	Importing        *Importing    `json:"importing,omitempty"`
-	GeneratedConfig  string        `json:"generated_config,omitempty"`
+	Resource         ResourceAddr      `json:"resource"`
+	PreviousResource *ResourceAddr     `json:"previous_resource,omitempty"`
+	Action           ChangeAction      `json:"action"`
+	Reason           ChangeReason      `json:"reason,omitempty"`
+	Importing        *Importing        `json:"importing,omitempty"`
+	GeneratedConfig  string            `json:"generated_config,omitempty"`
+	DriftCauses      map[string]string `json:"drift_causes,omitempty"`
 }
 
 func (c *ResourceInstanceChange) String() string {
@@ -40,6 +40,12 @@ type Diagnostic struct {
 	Address  string             `json:"address,omitempty"`
 	Range    *DiagnosticRange   `json:"range,omitempty"`
 	Snippet  *DiagnosticSnippet `json:"snippet,omitempty"`
+
+	// Code is the stable code of the class of diagnostics that this
+	// diagnostic belongs to, if any, and DocsURL is the URL of the
+	// documentation about that class of diagnostics, if any.
+	Code    string `json:"code,omitempty"`
+	DocsURL string `json:"docs_url,omitempty"`
 }
 
 // Pos represents a position in the source code.
@@ -152,6 +158,10 @@ func NewDiagnostic(diag tfdiags.Diagnostic, sources map[string]*hcl.File) *Diagn
 		Detail:   desc.Detail,
 		Address:  desc.Address,
 	}
+	if code := tfdiags.DiagnosticCode(diag); code != "" {
+		diagnostic.Code = string(code)
+		diagnostic.DocsURL = code.DocsURL()
+	}
 
 	sourceRefs := diag.Source()
 	if sourceRefs.Subject != nil {
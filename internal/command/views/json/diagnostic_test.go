@@ -67,6 +67,20 @@ func TestNewDiagnostic(t *testing.T) {
 				Detail:   "Something is broken",
 			},
 		},
+		"sourceless warning with code": {
+			tfdiags.WithCode(tfdiags.Sourceless(
+				tfdiags.Warning,
+				"Resource targeting is in effect",
+				"Some changes may be ignored",
+			), tfdiags.CodeResourceTargeting),
+			&Diagnostic{
+				Severity: "warning",
+				Summary:  "Resource targeting is in effect",
+				Detail:   "Some changes may be ignored",
+				Code:     "TOFU1001",
+				DocsURL:  "https://opentofu.org/docs/cli/commands/plan/#resource-targeting",
+			},
+		},
 		"error with source code unavailable": {
 			&hcl.Diagnostic{
 				Severity: hcl.DiagError,
@@ -0,0 +1,252 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// InitEvent is a structured progress event emitted by "tofu init -json".
+type InitEvent interface {
+	InitEventType() MessageType
+	String() string
+}
+
+// initBackend: the backend or cloud backend was initialized.
+type initBackend struct {
+	Type    string `json:"type"`
+	Success bool   `json:"success"`
+}
+
+var _ InitEvent = (*initBackend)(nil)
+
+func (e *initBackend) InitEventType() MessageType {
+	return MessageInitBackend
+}
+
+func (e *initBackend) String() string {
+	if !e.Success {
+		return fmt.Sprintf("Failed to initialize %q backend", e.Type)
+	}
+	return fmt.Sprintf("Initialized %q backend", e.Type)
+}
+
+func NewInitBackend(backendType string, success bool) InitEvent {
+	return &initBackend{
+		Type:    backendType,
+		Success: success,
+	}
+}
+
+// initModuleDownload: a module package is about to be downloaded from a
+// remote source.
+type initModuleDownload struct {
+	Module  string `json:"module"`
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+}
+
+var _ InitEvent = (*initModuleDownload)(nil)
+
+func (e *initModuleDownload) InitEventType() MessageType {
+	return MessageInitModuleDownload
+}
+
+func (e *initModuleDownload) String() string {
+	if e.Version != "" {
+		return fmt.Sprintf("Downloading %s %s for %s...", e.Source, e.Version, e.Module)
+	}
+	return fmt.Sprintf("Downloading %s for %s...", e.Source, e.Module)
+}
+
+func NewInitModuleDownload(module, source, version string) InitEvent {
+	return &initModuleDownload{
+		Module:  module,
+		Source:  source,
+		Version: version,
+	}
+}
+
+// initModuleInstalled: a module was installed, whether or not it needed to be
+// downloaded. Source is empty for modules that were not downloaded, such as
+// those with local source addresses.
+type initModuleInstalled struct {
+	Module  string `json:"module"`
+	Source  string `json:"source,omitempty"`
+	Version string `json:"version,omitempty"`
+	Dir     string `json:"dir"`
+	Bytes   int64  `json:"bytes"`
+}
+
+var _ InitEvent = (*initModuleInstalled)(nil)
+
+func (e *initModuleInstalled) InitEventType() MessageType {
+	return MessageInitModuleInstalled
+}
+
+func (e *initModuleInstalled) String() string {
+	return fmt.Sprintf("Installed %s in %s", e.Module, e.Dir)
+}
+
+func NewInitModuleInstalled(module, source, version, dir string, bytes int64) InitEvent {
+	return &initModuleInstalled{
+		Module:  module,
+		Source:  source,
+		Version: version,
+		Dir:     dir,
+		Bytes:   bytes,
+	}
+}
+
+// initProviderSelected: a provider version was selected for installation.
+type initProviderSelected struct {
+	Provider    string `json:"provider"`
+	Version     string `json:"version"`
+	Constraints string `json:"constraints,omitempty"`
+	Locked      bool   `json:"locked"`
+}
+
+var _ InitEvent = (*initProviderSelected)(nil)
+
+func (e *initProviderSelected) InitEventType() MessageType {
+	return MessageInitProviderSelected
+}
+
+func (e *initProviderSelected) String() string {
+	return fmt.Sprintf("Selected %s v%s", e.Provider, e.Version)
+}
+
+func NewInitProviderSelected(provider addrs.Provider, version getproviders.Version, constraints getproviders.VersionConstraints, locked bool) InitEvent {
+	return &initProviderSelected{
+		Provider:    provider.String(),
+		Version:     version.String(),
+		Constraints: getproviders.VersionConstraintsString(constraints),
+		Locked:      locked,
+	}
+}
+
+// initProviderInstalled: a provider package is available in the working
+// directory, having been fetched from its location, linked from the shared
+// cache directory, or found already installed.
+type initProviderInstalled struct {
+	Provider       string `json:"provider"`
+	Version        string `json:"version"`
+	Source         string `json:"source"`
+	Location       string `json:"location,omitempty"`
+	Authentication string `json:"authentication,omitempty"`
+	KeyID          string `json:"key_id,omitempty"`
+}
+
+var _ InitEvent = (*initProviderInstalled)(nil)
+
+func (e *initProviderInstalled) InitEventType() MessageType {
+	return MessageInitProviderInstalled
+}
+
+func (e *initProviderInstalled) String() string {
+	return fmt.Sprintf("Installed %s v%s", e.Provider, e.Version)
+}
+
+// The possible values of the source of an installed provider.
+const (
+	InitProviderSourceFetched          = "fetched"
+	InitProviderSourceCache            = "cache"
+	InitProviderSourceAlreadyInstalled = "already_installed"
+	InitProviderSourceBuiltIn          = "built_in"
+)
+
+// NewInitProviderInstalled returns an event for an installed provider. The
+// location, if not empty, is where the package was fetched from or the path
+// of the shared cache directory, and authResult is nil unless the package
+// was fetched.
+func NewInitProviderInstalled(provider addrs.Provider, version getproviders.Version, source, location string, authResult *getproviders.PackageAuthenticationResult) InitEvent {
+	e := &initProviderInstalled{
+		Provider: provider.String(),
+		Source:   source,
+		Location: location,
+	}
+	if version != getproviders.UnspecifiedVersion {
+		e.Version = version.String()
+	}
+	if authResult != nil {
+		e.Authentication = authResult.String()
+		if authResult.Signed() {
+			e.KeyID = authResult.KeyID
+		}
+	}
+	return e
+}
+
+// initProviderErrored: selecting or installing a provider failed.
+type initProviderErrored struct {
+	Provider string `json:"provider"`
+	Version  string `json:"version,omitempty"`
+	Location string `json:"location,omitempty"`
+	Error    string `json:"error"`
+}
+
+var _ InitEvent = (*initProviderErrored)(nil)
+
+func (e *initProviderErrored) InitEventType() MessageType {
+	return MessageInitProviderErrored
+}
+
+func (e *initProviderErrored) String() string {
+	return fmt.Sprintf("Failed to install %s: %s", e.Provider, e.Error)
+}
+
+// NewInitProviderErrored returns an event for a provider that could not be
+// installed. The version is getproviders.UnspecifiedVersion if the failure
+// happened before a version was selected, and the location is where the
+// package was being fetched from, if known.
+func NewInitProviderErrored(provider addrs.Provider, version getproviders.Version, location string, err error) InitEvent {
+	e := &initProviderErrored{
+		Provider: provider.String(),
+		Location: location,
+		Error:    err.Error(),
+	}
+	if version != getproviders.UnspecifiedVersion {
+		e.Version = version.String()
+	}
+	return e
+}
+
+// initLockUpdated: the dependency lock file entry for a provider was updated.
+type initLockUpdated struct {
+	Provider     string   `json:"provider"`
+	Version      string   `json:"version"`
+	LocalHashes  []string `json:"local_hashes"`
+	SignedHashes []string `json:"signed_hashes"`
+	PriorHashes  []string `json:"prior_hashes"`
+}
+
+var _ InitEvent = (*initLockUpdated)(nil)
+
+func (e *initLockUpdated) InitEventType() MessageType {
+	return MessageInitLockUpdated
+}
+
+func (e *initLockUpdated) String() string {
+	return fmt.Sprintf("Updated lock file entry for %s v%s", e.Provider, e.Version)
+}
+
+func NewInitLockUpdated(provider addrs.Provider, version getproviders.Version, localHashes, signedHashes, priorHashes []getproviders.Hash) InitEvent {
+	hashStrings := func(hashes []getproviders.Hash) []string {
+		ret := make([]string, len(hashes))
+		for i, hash := range hashes {
+			ret[i] = hash.String()
+		}
+		return ret
+	}
+	return &initLockUpdated{
+		Provider:     provider.String(),
+		Version:      version.String(),
+		LocalHashes:  hashStrings(localHashes),
+		SignedHashes: hashStrings(signedHashes),
+		PriorHashes:  hashStrings(priorHashes),
+	}
+}
@@ -14,10 +14,11 @@ const (
 	MessageDiagnostic MessageType = "diagnostic"
 
 	// Operation results
-	MessageResourceDrift MessageType = "resource_drift"
-	MessagePlannedChange MessageType = "planned_change"
-	MessageChangeSummary MessageType = "change_summary"
-	MessageOutputs       MessageType = "outputs"
+	MessageResourceDrift   MessageType = "resource_drift"
+	MessagePlannedChange   MessageType = "planned_change"
+	MessageChangeSummary   MessageType = "change_summary"
+	MessageOutputs         MessageType = "outputs"
+	MessageRemainingChange MessageType = "remaining_change"
 
 	// Hook-driven messages
 	MessageApplyStart        MessageType = "apply_start"
@@ -31,6 +32,15 @@ const (
 	MessageRefreshStart      MessageType = "refresh_start"
 	MessageRefreshComplete   MessageType = "refresh_complete"
 
+	// Init messages
+	MessageInitBackend           MessageType = "init_backend"
+	MessageInitModuleDownload    MessageType = "init_module_download"
+	MessageInitModuleInstalled   MessageType = "init_module_installed"
+	MessageInitProviderSelected  MessageType = "init_provider_selected"
+	MessageInitProviderInstalled MessageType = "init_provider_installed"
+	MessageInitProviderErrored   MessageType = "init_provider_errored"
+	MessageInitLockUpdated       MessageType = "init_lock_updated"
+
 	// Test messages
 	MessageTestAbstract  MessageType = "test_abstract"
 	MessageTestFile      MessageType = "test_file"
@@ -19,7 +19,7 @@ import (
 // This version describes the schema of JSON UI messages. This version must be
 // updated after making any changes to this view, the jsonHook, or any of the
 // command/views/json package.
-const JSON_UI_VERSION = "1.2"
+const JSON_UI_VERSION = "1.3"
 
 func NewJSONView(view *View) *JSONView {
 	log := hclog.New(&hclog.LoggerOptions{
@@ -74,6 +74,7 @@ func (v *JSONView) StateDump(state string) {
 }
 
 func (v *JSONView) Diagnostics(diags tfdiags.Diagnostics, metadata ...interface{}) {
+	diags = v.view.FilterDiagnostics(diags)
 	sources := v.view.configSources()
 	for _, diag := range diags {
 		diagnostic := json.NewDiagnostic(diag, sources)
@@ -98,6 +99,14 @@ func (v *JSONView) PlannedChange(c *json.ResourceInstanceChange) {
 	)
 }
 
+func (v *JSONView) RemainingChange(c *json.ResourceInstanceChange) {
+	v.log.Info(
+		fmt.Sprintf("%s: Not applied (%s)", c.Resource.Addr, c.Action),
+		"type", json.MessageRemainingChange,
+		"change", c,
+	)
+}
+
 func (v *JSONView) ResourceDrift(c *json.ResourceInstanceChange) {
 	v.log.Info(
 		fmt.Sprintf("%s: Drift detected (%s)", c.Resource.Addr, c.Action),
@@ -122,6 +131,14 @@ func (v *JSONView) Hook(h json.Hook) {
 	)
 }
 
+func (v *JSONView) InitEvent(e json.InitEvent) {
+	v.log.Info(
+		e.String(),
+		"type", e.InitEventType(),
+		"init", e,
+	)
+}
+
 func (v *JSONView) Outputs(outputs json.Outputs) {
 	v.log.Info(
 		outputs.String(),
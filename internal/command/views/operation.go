@@ -7,6 +7,7 @@ package views
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/views/json"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -33,8 +35,12 @@ type Operation interface {
 	EmergencyDumpState(stateFile *statefile.File, enc encryption.StateEncryption) error
 
 	PlannedChange(change *plans.ResourceInstanceChangeSrc)
-	Plan(plan *plans.Plan, schemas *tofu.Schemas)
+	// Plan renders the given plan. The context is used for any requests
+	// made while rendering it, such as for cost estimates, and cancelling it
+	// stops them.
+	Plan(ctx context.Context, plan *plans.Plan, schemas *tofu.Schemas)
 	PlanNextStep(planPath string, genConfigPath string)
+	RemainingChanges(changes []*plans.ResourceInstanceChangeSrc)
 
 	Diagnostics(diags tfdiags.Diagnostics)
 }
@@ -64,18 +70,22 @@ type OperationHuman struct {
 var _ Operation = (*OperationHuman)(nil)
 
 func (v *OperationHuman) Interrupted() {
+	v.view.finishProgress()
 	v.view.streams.Println(format.WordWrap(interrupted, v.view.outputColumns()))
 }
 
 func (v *OperationHuman) FatalInterrupt() {
+	v.view.finishProgress()
 	v.view.streams.Eprintln(format.WordWrap(fatalInterrupt, v.view.errorColumns()))
 }
 
 func (v *OperationHuman) Stopping() {
+	v.view.finishProgress()
 	v.view.streams.Println("Stopping operation...")
 }
 
 func (v *OperationHuman) Cancelled(planMode plans.Mode) {
+	v.view.finishProgress()
 	switch planMode {
 	case plans.DestroyMode:
 		v.view.streams.Println("Destroy cancelled.")
@@ -85,6 +95,7 @@ func (v *OperationHuman) Cancelled(planMode plans.Mode) {
 }
 
 func (v *OperationHuman) EmergencyDumpState(stateFile *statefile.File, enc encryption.StateEncryption) error {
+	v.view.finishProgress()
 	stateBuf := new(bytes.Buffer)
 	jsonErr := statefile.Write(stateFile, stateBuf, enc)
 	if jsonErr != nil {
@@ -94,18 +105,35 @@ func (v *OperationHuman) EmergencyDumpState(stateFile *statefile.File, enc encry
 	return nil
 }
 
-func (v *OperationHuman) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
+func (v *OperationHuman) Plan(ctx context.Context, plan *plans.Plan, schemas *tofu.Schemas) {
+	v.view.finishProgress()
 	outputs, changed, drift, attrs, err := jsonplan.MarshalForRenderer(plan, schemas)
 	if err != nil {
 		v.view.streams.Eprintf("Failed to marshal plan to json: %s", err)
 		return
 	}
 
+	if v.view.costEstimator != nil && len(changed) > 0 {
+		if err := v.view.costEstimator.Annotate(ctx, changed); err != nil {
+			// Cost estimates are only informational, so we don't let a
+			// failing estimator block the plan.
+			var diags tfdiags.Diagnostics
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Warning,
+				"Failed to estimate costs",
+				fmt.Sprintf("The configured cost estimator failed, so the plan doesn't include cost estimates.\n\n%s", err),
+			))
+			v.view.Diagnostics(diags)
+		}
+	}
+
 	renderer := jsonformat.Renderer{
 		Colorize:            v.view.colorize,
 		Streams:             v.view.streams,
 		RunningInAutomation: v.inAutomation,
 		ShowSensitive:       v.view.showSensitive,
+		CollapsedAttributes: v.view.renderCollapsedAttributes(),
+		NoUnicode:           v.view.noUnicode,
 	}
 
 	jplan := jsonformat.Plan{
@@ -143,6 +171,7 @@ func (v *OperationHuman) PlanNextStep(planPath string, genConfigPath string) {
 	if v.inAutomation {
 		return
 	}
+	v.view.finishProgress()
 	v.view.outputHorizRule()
 
 	if genConfigPath != "" {
@@ -170,6 +199,24 @@ func (v *OperationHuman) PlanNextStep(planPath string, genConfigPath string) {
 	}
 }
 
+// RemainingChanges lists the planned changes that an apply operation didn't
+// make because it reached its maximum runtime.
+func (v *OperationHuman) RemainingChanges(changes []*plans.ResourceInstanceChangeSrc) {
+	if len(changes) == 0 {
+		return
+	}
+	v.view.finishProgress()
+	v.view.streams.Println(v.view.colorize.Color("\n[bold]Changes not applied:[reset]"))
+	for _, change := range changes {
+		c := json.NewResourceInstanceChange(change)
+		if change.DeposedKey != states.NotDeposed {
+			v.view.streams.Printf("  - %s (deposed object %s): %s\n", c.Resource.Addr, change.DeposedKey, c.Action)
+			continue
+		}
+		v.view.streams.Printf("  - %s: %s\n", c.Resource.Addr, c.Action)
+	}
+}
+
 func (v *OperationHuman) Diagnostics(diags tfdiags.Diagnostics) {
 	v.view.Diagnostics(diags)
 }
@@ -213,7 +260,7 @@ func (v *OperationJSON) EmergencyDumpState(stateFile *statefile.File, enc encryp
 
 // Log a change summary and a series of "planned" messages for the changes in
 // the plan.
-func (v *OperationJSON) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
+func (v *OperationJSON) Plan(ctx context.Context, plan *plans.Plan, schemas *tofu.Schemas) {
 	for _, dr := range plan.DriftedResources {
 		// In refresh-only mode, we output all resources marked as drifted,
 		// including those which have moved without other changes. In other plan
@@ -281,6 +328,14 @@ func (v *OperationJSON) PlannedChange(change *plans.ResourceInstanceChangeSrc) {
 func (v *OperationJSON) PlanNextStep(planPath string, genConfigPath string) {
 }
 
+// RemainingChanges logs a "remaining_change" message for each planned change
+// that an apply operation didn't make because it reached its maximum runtime.
+func (v *OperationJSON) RemainingChanges(changes []*plans.ResourceInstanceChangeSrc) {
+	for _, change := range changes {
+		v.view.RemainingChange(json.NewResourceInstanceChange(change))
+	}
+}
+
 func (v *OperationJSON) Diagnostics(diags tfdiags.Diagnostics) {
 	v.view.Diagnostics(diags)
 }
@@ -7,6 +7,7 @@ package views
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -325,7 +326,7 @@ func TestOperation_planNoChanges(t *testing.T) {
 			streams, done := terminal.StreamsForTesting(t)
 			v := NewOperation(arguments.ViewHuman, false, NewView(streams))
 			plan := test.plan(schemas)
-			v.Plan(plan, schemas)
+			v.Plan(context.Background(), plan, schemas)
 			got := done(t).Stdout()
 			if want := test.wantText; want != "" && !strings.Contains(got, want) {
 				t.Errorf("missing expected message\ngot:\n%s\n\nwant substring: %s", got, want)
@@ -340,7 +341,7 @@ func TestOperation_plan(t *testing.T) {
 
 	plan := testPlan(t)
 	schemas := testSchemas()
-	v.Plan(plan, schemas)
+	v.Plan(context.Background(), plan, schemas)
 
 	want := `
 OpenTofu used the selected providers to generate the following execution
@@ -369,7 +370,7 @@ func TestOperation_planWithDatasource(t *testing.T) {
 
 	plan := testPlanWithDatasource(t)
 	schemas := testSchemas()
-	v.Plan(plan, schemas)
+	v.Plan(context.Background(), plan, schemas)
 
 	want := `
 OpenTofu used the selected providers to generate the following execution
@@ -405,7 +406,7 @@ func TestOperation_planWithDatasourceAndDrift(t *testing.T) {
 
 	plan := testPlanWithDatasource(t)
 	schemas := testSchemas()
-	v.Plan(plan, schemas)
+	v.Plan(context.Background(), plan, schemas)
 
 	want := `
 OpenTofu used the selected providers to generate the following execution
@@ -463,6 +464,35 @@ func TestOperation_planNextStep(t *testing.T) {
 	}
 }
 
+func TestOperation_remainingChanges(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := NewOperation(arguments.ViewHuman, false, NewView(streams))
+
+	boop := addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "boop"}
+	v.RemainingChanges([]*plans.ResourceInstanceChangeSrc{
+		{
+			Addr:        boop.Instance(addrs.IntKey(0)).Absolute(addrs.RootModuleInstance),
+			PrevRunAddr: boop.Instance(addrs.IntKey(0)).Absolute(addrs.RootModuleInstance),
+			ChangeSrc:   plans.ChangeSrc{Action: plans.Create},
+		},
+		{
+			Addr:        boop.Instance(addrs.IntKey(1)).Absolute(addrs.RootModuleInstance),
+			PrevRunAddr: boop.Instance(addrs.IntKey(1)).Absolute(addrs.RootModuleInstance),
+			DeposedKey:  states.DeposedKey("00000001"),
+			ChangeSrc:   plans.ChangeSrc{Action: plans.Delete},
+		},
+	})
+
+	want := `
+Changes not applied:
+  - test_instance.boop[0]: create
+  - test_instance.boop[1] (deposed object 00000001): delete
+`
+	if got := done(t).Stdout(); got != want {
+		t.Errorf("wrong result\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
 // The in-automation state is on the view itself, so testing it separately is
 // clearer.
 func TestOperation_planNextStepInAutomation(t *testing.T) {
@@ -569,7 +599,7 @@ func TestOperationJSON_planNoChanges(t *testing.T) {
 	plan := &plans.Plan{
 		Changes: plans.NewChanges(),
 	}
-	v.Plan(plan, nil)
+	v.Plan(context.Background(), plan, nil)
 
 	want := []map[string]interface{}{
 		{
@@ -640,7 +670,7 @@ func TestOperationJSON_plan(t *testing.T) {
 			},
 		},
 	}
-	v.Plan(plan, testSchemas())
+	v.Plan(context.Background(), plan, testSchemas())
 
 	want := []map[string]interface{}{
 		// Create-then-delete should result in replace
@@ -796,7 +826,7 @@ func TestOperationJSON_planWithImport(t *testing.T) {
 			},
 		},
 	}
-	v.Plan(plan, testSchemas())
+	v.Plan(context.Background(), plan, testSchemas())
 
 	want := []map[string]interface{}{
 		// Simple import
@@ -945,7 +975,7 @@ func TestOperationJSON_planDriftWithMove(t *testing.T) {
 			},
 		},
 	}
-	v.Plan(plan, testSchemas())
+	v.Plan(context.Background(), plan, testSchemas())
 
 	want := []map[string]interface{}{
 		// Drift detected: delete
@@ -1076,7 +1106,7 @@ func TestOperationJSON_planDriftWithMoveRefreshOnly(t *testing.T) {
 			},
 		},
 	}
-	v.Plan(plan, testSchemas())
+	v.Plan(context.Background(), plan, testSchemas())
 
 	want := []map[string]interface{}{
 		// Drift detected: delete
@@ -1211,7 +1241,7 @@ func TestOperationJSON_planOutputChanges(t *testing.T) {
 			},
 		},
 	}
-	v.Plan(plan, testSchemas())
+	v.Plan(context.Background(), plan, testSchemas())
 
 	want := []map[string]interface{}{
 		// No resource changes
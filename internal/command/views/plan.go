@@ -55,7 +55,7 @@ func (v *PlanHuman) Operation() Operation {
 
 func (v *PlanHuman) Hooks() []tofu.Hook {
 	return []tofu.Hook{
-		NewUiHook(v.view),
+		v.view.progressHook(),
 	}
 }
 
@@ -37,6 +37,8 @@ func NewShow(vt arguments.ViewType, view *View) Show {
 		return &ShowJSON{view: view}
 	case arguments.ViewHuman:
 		return &ShowHuman{view: view}
+	case arguments.ViewPatch:
+		return &ShowPatch{view: view}
 	default:
 		panic(fmt.Sprintf("unknown view type %v", vt))
 	}
@@ -54,6 +56,8 @@ func (v *ShowHuman) Display(config *configs.Config, plan *plans.Plan, planJSON *
 		Streams:             v.view.streams,
 		RunningInAutomation: v.view.runningInAutomation,
 		ShowSensitive:       v.view.showSensitive,
+		CollapsedAttributes: v.view.renderCollapsedAttributes(),
+		NoUnicode:           v.view.noUnicode,
 	}
 
 	// Prefer to display a pre-built JSON plan, if we got one; then, fall back
@@ -170,3 +174,60 @@ func (v *ShowJSON) Display(config *configs.Config, plan *plans.Plan, planJSON *c
 func (v *ShowJSON) Diagnostics(diags tfdiags.Diagnostics) {
 	v.view.Diagnostics(diags)
 }
+
+type ShowPatch struct {
+	view *View
+}
+
+var _ Show = (*ShowPatch)(nil)
+
+func (v *ShowPatch) Display(config *configs.Config, plan *plans.Plan, planJSON *cloudplan.RemotePlanJSON, stateFile *statefile.File, schemas *tofu.Schemas) int {
+	renderer := jsonformat.Renderer{
+		Streams:       v.view.streams,
+		ShowSensitive: v.view.showSensitive,
+	}
+
+	var jplan jsonformat.Plan
+	switch {
+	case planJSON != nil:
+		if !planJSON.Redacted {
+			v.view.streams.Eprintf("Didn't get renderable JSON plan format for patch display")
+			return 1
+		}
+		if err := json.Unmarshal(planJSON.JSONBytes, &jplan); err != nil {
+			v.view.streams.Eprintf("Couldn't decode renderable JSON plan format: %s", err)
+			return 1
+		}
+	case plan != nil:
+		outputs, changed, drift, attrs, err := jsonplan.MarshalForRenderer(plan, schemas)
+		if err != nil {
+			v.view.streams.Eprintf("Failed to marshal plan to json: %s", err)
+			return 1
+		}
+		jplan = jsonformat.Plan{
+			PlanFormatVersion:     jsonplan.FormatVersion,
+			ProviderFormatVersion: jsonprovider.FormatVersion,
+			OutputChanges:         outputs,
+			ResourceChanges:       changed,
+			ResourceDrift:         drift,
+			ProviderSchemas:       jsonprovider.MarshalForRenderer(schemas),
+			RelevantAttributes:    attrs,
+		}
+	default:
+		var diags tfdiags.Diagnostics
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No plan to show",
+			"The -format=patch option can only be used with a saved plan file.",
+		))
+		v.view.Diagnostics(diags)
+		return 1
+	}
+
+	renderer.RenderPatchPlan(jplan)
+	return 0
+}
+
+func (v *ShowPatch) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
@@ -240,3 +240,44 @@ func testState() *states.State {
 		// operation, and all fields have been copied correctly.
 	}).DeepCopy()
 }
+
+func TestShowPatch(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	view := NewView(streams)
+	view.Configure(&arguments.View{NoColor: true})
+	v := NewShow(arguments.ViewPatch, view)
+
+	code := v.Display(nil, testPlan(t), nil, nil, testSchemas())
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("expected 0 return code, got %d\n%s", code, output.Stderr())
+	}
+
+	got := output.Stdout()
+	for _, want := range []string{
+		"--- /dev/null\n+++ b/test_resource.foo\n@@ -0,0 +1,4 @@\n",
+		"+resource \"test_resource\" \"foo\" {\n",
+		"+    foo = \"bar\"\n",
+		"+    id  = (known after apply)\n",
+		"+}\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output is missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestShowPatch_noPlan(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	view := NewView(streams)
+	view.Configure(&arguments.View{NoColor: true})
+	v := NewShow(arguments.ViewPatch, view)
+
+	code := v.Display(nil, nil, nil, &statefile.File{State: testState()}, testSchemas())
+	if code != 1 {
+		t.Errorf("expected 1 return code, got %d", code)
+	}
+	if got, want := done(t).Stderr(), "can only be used with a saved plan file"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot: %s\nwant substring: %s", got, want)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
 )
 
 // The StateLocker view is used to display locking/unlocking status messages
@@ -18,6 +19,10 @@ import (
 type StateLocker interface {
 	Locking()
 	Unlocking()
+
+	// Waiting reports that the lock is held by another process, and that
+	// we'll wait and try again.
+	Waiting(wait statemgr.LockWait)
 }
 
 // NewStateLocker returns an initialized StateLocker implementation for the given ViewType.
@@ -49,6 +54,24 @@ func (v *StateLockerHuman) Unlocking() {
 	v.view.streams.Println("Releasing state lock. This may take a few moments...")
 }
 
+func (v *StateLockerHuman) Waiting(wait statemgr.LockWait) {
+	msg := "The state is locked"
+	if wait.Holder != nil && wait.Holder.Who != "" {
+		msg = fmt.Sprintf("The state is locked by %s", wait.Holder.Who)
+		if wait.Holder.Operation != "" {
+			msg += fmt.Sprintf(" for %s", wait.Holder.Operation)
+		}
+	}
+	if wait.QueuePosition > 0 {
+		msg += fmt.Sprintf("; this request is number %d in the queue", wait.QueuePosition)
+	}
+	msg += fmt.Sprintf(". Retrying in %s", wait.Delay.Round(time.Second))
+	if wait.Remaining > 0 {
+		msg += fmt.Sprintf(" (giving up in %s)", wait.Remaining.Round(time.Second))
+	}
+	v.view.streams.Println(msg + "...")
+}
+
 // StateLockerJSON is an implementation of StateLocker which prints the state lock status
 // to a terminal in machine-readable JSON form.
 type StateLockerJSON struct {
@@ -82,3 +105,35 @@ func (v *StateLockerJSON) Unlocking() {
 	lock_info_message, _ := json.Marshal(json_data)
 	v.view.streams.Println(string(lock_info_message))
 }
+
+func (v *StateLockerJSON) Waiting(wait statemgr.LockWait) {
+	current_timestamp := time.Now().Format(time.RFC3339)
+
+	json_data := map[string]interface{}{
+		"@level":     "info",
+		"@message":   "The state is locked by another process. Waiting to retry...",
+		"@module":    "tofu.ui",
+		"@timestamp": current_timestamp,
+		"type":       "state_lock_wait",
+		"attempt":    wait.Attempt,
+		"delay_ms":   wait.Delay.Milliseconds(),
+	}
+	if wait.Remaining > 0 {
+		json_data["remaining_ms"] = wait.Remaining.Milliseconds()
+	}
+	if wait.QueuePosition > 0 {
+		json_data["queue_position"] = wait.QueuePosition
+	}
+	if wait.Holder != nil {
+		json_data["lock"] = map[string]string{
+			"id":        wait.Holder.ID,
+			"operation": wait.Holder.Operation,
+			"who":       wait.Holder.Who,
+			"version":   wait.Holder.Version,
+			"created":   wait.Holder.Created.Format(time.RFC3339),
+		}
+	}
+
+	lock_wait_message, _ := json.Marshal(json_data)
+	v.view.streams.Println(string(lock_wait_message))
+}
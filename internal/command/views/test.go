@@ -156,6 +156,7 @@ func (t *TestHuman) Run(run *moduletest.Run, file *moduletest.File) {
 			Streams:             t.view.streams,
 			Colorize:            t.view.colorize,
 			RunningInAutomation: t.view.runningInAutomation,
+			NoUnicode:           t.view.noUnicode,
 		}
 
 		if run.Config.Command == configs.ApplyTestCommand {
@@ -2799,6 +2799,7 @@ func TestTestJSON_Run(t *testing.T) {
 										"mode":             "managed",
 										"name":             "creating",
 										"provider_name":    "registry.opentofu.org/hashicorp/test",
+										"provider_config":  `provider["registry.opentofu.org/hashicorp/test"]`,
 										"schema_version":   0.0,
 										"sensitive_values": map[string]interface{}{},
 										"type":             "test_resource",
@@ -2822,10 +2823,11 @@ func TestTestJSON_Run(t *testing.T) {
 									"before":           nil,
 									"before_sensitive": false,
 								},
-								"mode":          "managed",
-								"name":          "creating",
-								"provider_name": "registry.opentofu.org/hashicorp/test",
-								"type":          "test_resource",
+								"mode":            "managed",
+								"name":            "creating",
+								"provider_name":   "registry.opentofu.org/hashicorp/test",
+								"provider_config": `provider["registry.opentofu.org/hashicorp/test"]`,
+								"type":            "test_resource",
 							},
 						},
 					},
@@ -2919,6 +2921,7 @@ func TestTestJSON_Run(t *testing.T) {
 										"mode":             "managed",
 										"name":             "creating",
 										"provider_name":    "registry.opentofu.org/hashicorp/test",
+										"provider_config":  `provider["registry.opentofu.org/hashicorp/test"]`,
 										"schema_version":   0.0,
 										"sensitive_values": map[string]interface{}{},
 										"type":             "test_resource",
@@ -48,6 +48,7 @@ var _ Validate = (*ValidateHuman)(nil)
 
 func (v *ValidateHuman) Results(diags tfdiags.Diagnostics) int {
 	columns := v.view.outputColumns()
+	diags = v.view.FilterDiagnostics(diags)
 
 	if len(diags) == 0 {
 		v.view.streams.Println(format.WordWrap(v.view.colorize.Color(validateSuccess), columns))
@@ -104,6 +105,7 @@ func (v *ValidateJSON) Results(diags tfdiags.Diagnostics) int {
 		FormatVersion: FormatVersion,
 		Valid:         true, // until proven otherwise
 	}
+	diags = v.view.FilterDiagnostics(diags)
 	configSources := v.view.configSources()
 	for _, diag := range diags {
 		output.Diagnostics = append(output.Diagnostics, viewsjson.NewDiagnostic(diag, configSources))
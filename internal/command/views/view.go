@@ -9,9 +9,11 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/mitchellh/colorstring"
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/costestimate"
 	"github.com/opentofu/opentofu/internal/command/format"
 	"github.com/opentofu/opentofu/internal/terminal"
 	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
 )
 
 // View is the base layer for command views, encapsulating a set of I/O
@@ -39,11 +41,36 @@ type View struct {
 	// showSensitive is used to display the value of variables marked as sensitive.
 	showSensitive bool
 
+	// costEstimator, if set, annotates planned resource changes with
+	// estimated costs before they are rendered.
+	costEstimator *costestimate.Estimator
+
+	// collapsedAttributes lists, for each managed resource type, the
+	// attributes whose changes are rendered as a one-line marker in plans,
+	// unless expandCollapsed is set.
+	collapsedAttributes map[string][]string
+	expandCollapsed     bool
+
+	// noUnicode replaces the box-drawing characters in diagnostics and
+	// horizontal rules with ASCII characters.
+	noUnicode bool
+
+	// progressTUI selects the live table of TUIHook, rather than the
+	// scrolling messages of UiHook, to report the progress of operations.
+	// progress is the TUIHook in use, if any.
+	progressTUI bool
+	progress    *TUIHook
+
 	// This unfortunate wart is required to enable rendering of diagnostics which
 	// have associated source code in the configuration. This function pointer
 	// will be dereferenced as late as possible when rendering diagnostics in
 	// order to access the config loader cache.
 	configSources func() map[string]*hcl.File
+
+	// diagnosticsFilter, if set, is applied to all diagnostics before they
+	// are rendered, to hide or summarize the warnings that the configuration
+	// acknowledges.
+	diagnosticsFilter func(tfdiags.Diagnostics) tfdiags.Diagnostics
 }
 
 // Initialize a View with the given streams, a disabled colorize object, and a
@@ -85,15 +112,64 @@ func (v *View) Configure(view *arguments.View) {
 	v.concise = view.Concise
 }
 
+// SetCostEstimator configures an external program to estimate the cost of
+// planned resource changes, so that the estimates can be included in the
+// rendered plan. A nil estimator disables cost estimation.
+//
+// For convenient use during initialization (in conjunction with NewView),
+// SetCostEstimator returns the receiver after modifying it.
+func (v *View) SetCostEstimator(estimator *costestimate.Estimator) *View {
+	v.costEstimator = estimator
+	return v
+}
+
+// SetColorTheme sets the colors that the view uses when color is enabled, in
+// the form returned by format.ColorThemeColors.
+//
+// For convenient use during initialization (in conjunction with NewView),
+// SetColorTheme returns the receiver after modifying it.
+func (v *View) SetColorTheme(colors map[string]string) *View {
+	v.colorize.Colors = colors
+	return v
+}
+
+// SetNoUnicode configures the view to replace the box-drawing characters in
+// its human-oriented output with ASCII characters, for terminals and screen
+// readers that don't handle them well.
+//
+// For convenient use during initialization (in conjunction with NewView),
+// SetNoUnicode returns the receiver after modifying it.
+func (v *View) SetNoUnicode(noUnicode bool) *View {
+	v.noUnicode = noUnicode
+	return v
+}
+
 // SetConfigSources overrides the default no-op callback with a new function
 // pointer, and should be called when the config loader is initialized.
 func (v *View) SetConfigSources(cb func() map[string]*hcl.File) {
 	v.configSources = cb
 }
 
+// SetDiagnosticsFilter sets a function that is applied to all diagnostics
+// before they are rendered, which may remove or replace some of them.
+func (v *View) SetDiagnosticsFilter(filter func(tfdiags.Diagnostics) tfdiags.Diagnostics) {
+	v.diagnosticsFilter = filter
+}
+
+// FilterDiagnostics applies the filter set with SetDiagnosticsFilter to the
+// given diagnostics, if any.
+func (v *View) FilterDiagnostics(diags tfdiags.Diagnostics) tfdiags.Diagnostics {
+	if v.diagnosticsFilter == nil || len(diags) == 0 {
+		return diags
+	}
+	return v.diagnosticsFilter(diags)
+}
+
 // Diagnostics renders a set of warnings and errors in human-readable form.
 // Warnings are printed to stdout, and errors to stderr.
 func (v *View) Diagnostics(diags tfdiags.Diagnostics) {
+	v.finishProgress()
+	diags = v.FilterDiagnostics(diags)
 	diags.Sort()
 
 	if len(diags) == 0 {
@@ -124,7 +200,7 @@ func (v *View) Diagnostics(diags tfdiags.Diagnostics) {
 		if useCompact {
 			msg := format.DiagnosticWarningsCompact(diags, v.colorize)
 			msg = "\n" + msg + "\nTo see the full warning notes, run OpenTofu without -compact-warnings.\n"
-			v.streams.Print(msg)
+			v.streams.Print(v.asciiOnly(msg))
 			return
 		}
 	}
@@ -136,6 +212,7 @@ func (v *View) Diagnostics(diags tfdiags.Diagnostics) {
 		} else {
 			msg = format.Diagnostic(diag, v.configSources(), v.colorize, v.streams.Stderr.Columns())
 		}
+		msg = v.asciiOnly(msg)
 
 		if diag.Severity() == tfdiags.Error {
 			v.streams.Eprint(msg)
@@ -181,9 +258,74 @@ func (v *View) errorColumns() int {
 // If UI color is enabled, the rule will get a dark grey coloring to try to
 // visually de-emphasize it.
 func (v *View) outputHorizRule() {
-	v.streams.Println(format.HorizontalRule(v.colorize, v.outputColumns()))
+	v.streams.Println(v.asciiOnly(format.HorizontalRule(v.colorize, v.outputColumns())))
+}
+
+// asciiOnly returns the given output unchanged, unless the view is configured
+// to avoid box-drawing characters, in which case it replaces them with ASCII
+// characters.
+func (v *View) asciiOnly(s string) string {
+	if !v.noUnicode {
+		return s
+	}
+	return format.ASCIIOnly(s)
 }
 
 func (v *View) SetShowSensitive(showSensitive bool) {
 	v.showSensitive = showSensitive
 }
+
+// SetCollapsedAttributes configures the attributes, keyed by managed resource
+// type, whose changes are collapsed into a one-line marker when rendering
+// plans.
+//
+// For convenient use during initialization (in conjunction with NewView),
+// SetCollapsedAttributes returns the receiver after modifying it.
+func (v *View) SetCollapsedAttributes(attributes map[string][]string) *View {
+	v.collapsedAttributes = attributes
+	return v
+}
+
+// SetExpandCollapsed disables the collapsing configured with
+// SetCollapsedAttributes, so that plans are rendered in full.
+func (v *View) SetExpandCollapsed(expandCollapsed bool) {
+	v.expandCollapsed = expandCollapsed
+}
+
+// SetProgressTUI selects the interactive progress display of TUIHook for the
+// hooks of the plan and apply views. It only takes effect when the output is
+// a terminal and OpenTofu isn't running in automation, and otherwise the
+// views report progress with UiHook as usual.
+func (v *View) SetProgressTUI(tui bool) {
+	v.progressTUI = tui
+}
+
+// progressHook returns the hook that reports the progress of an operation in
+// human-readable form.
+func (v *View) progressHook() tofu.Hook {
+	if !v.progressTUI || v.runningInAutomation || !v.streams.Stdout.IsTerminal() {
+		return NewUiHook(v)
+	}
+	if v.progress == nil {
+		v.progress = NewTUIHook(v)
+	}
+	return v.progress
+}
+
+// finishProgress leaves the interactive progress display, if it is active,
+// so that other output can follow it. Anything that the view prints while
+// an operation may be in progress must call this first.
+func (v *View) finishProgress() {
+	if v.progress != nil {
+		v.progress.Finish()
+	}
+}
+
+// renderCollapsedAttributes returns the attributes that plan renderers should
+// collapse.
+func (v *View) renderCollapsedAttributes() map[string][]string {
+	if v.expandCollapsed {
+		return nil
+	}
+	return v.collapsedAttributes
+}
@@ -7,6 +7,7 @@ package workdir
 
 import (
 	"path/filepath"
+	"strings"
 )
 
 // NormalizePath attempts to transform the given path so that it's relative
@@ -53,5 +54,41 @@ func (d *Dir) NormalizePath(given string) string {
 		return filepath.Clean(given)
 	}
 
+	if escapesDir(ret) {
+		// The given path might have had its symbolic links resolved, such
+		// as for a module reached through a link, while the working
+		// directory is itself reached through a link, or the other way
+		// around. If the path is beneath the working directory once both
+		// are resolved then we'll prefer that relative path, rather than
+		// one that leaves the working directory and comes back in.
+		if resolved, ok := relResolved(absMain, given); ok {
+			return resolved
+		}
+	}
+
 	return ret
 }
+
+// relResolved is like filepath.Rel, but resolves any symbolic links in both
+// paths first, and only succeeds if the result is beneath base.
+func relResolved(base, target string) (string, bool) {
+	base, err := filepath.EvalSymlinks(base)
+	if err != nil {
+		return "", false
+	}
+	target, err = filepath.EvalSymlinks(target)
+	if err != nil {
+		return "", false
+	}
+	ret, err := filepath.Rel(base, target)
+	if err != nil || escapesDir(ret) {
+		return "", false
+	}
+	return ret, true
+}
+
+// escapesDir returns true if the given relative path refers to something
+// outside of the directory it is relative to.
+func escapesDir(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
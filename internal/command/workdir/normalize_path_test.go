@@ -0,0 +1,56 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package workdir
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDirNormalizePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := NewDir(tmpDir)
+
+	tests := map[string]string{
+		"child":                          "child",
+		filepath.Join(tmpDir, "child"):   "child",
+		filepath.Join(tmpDir, "..", "x"): filepath.Join("..", "x"),
+	}
+	for given, want := range tests {
+		if got := dir.NormalizePath(given); got != want {
+			t.Errorf("wrong result for %s\ngot:  %s\nwant: %s", given, got, want)
+		}
+	}
+}
+
+func TestDirNormalizePath_symlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires extra privileges on Windows")
+	}
+
+	// The working directory is reached through a symlink, but the path
+	// we're normalizing has had its symlinks resolved, as happens for the
+	// directories of local modules.
+	tmpDir := t.TempDir()
+	realDir := filepath.Join(tmpDir, "real")
+	if err := os.MkdirAll(filepath.Join(realDir, "modules", "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	linkDir := filepath.Join(tmpDir, "link")
+	if err := os.Symlink(realDir, linkDir); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(realDir, "modules", "shared"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := NewDir(linkDir)
+	want := filepath.Join("modules", "shared")
+	if got := dir.NormalizePath(resolved); got != want {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -29,6 +29,17 @@ func (d *Dir) ProviderLocalCacheDir() string {
 	return filepath.Join(d.dataDir, "providers")
 }
 
+// ProviderSchemaCacheDir returns the directory we'll use to save the schemas
+// of the providers used in this working directory, so that they can be used
+// without starting the provider plugins.
+//
+// Typically, the caller will pass the result of this method into
+// providercache.NewSchemaDir, to get an object responsible for managing
+// the contents.
+func (d *Dir) ProviderSchemaCacheDir() string {
+	return filepath.Join(d.dataDir, "provider-schemas")
+}
+
 // ForcedPluginDirs returns a list of directories to use to find plugins,
 // instead of the default locations.
 //
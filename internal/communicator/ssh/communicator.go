@@ -175,6 +175,16 @@ func (c *Communicator) Connect(o provisioners.UIOutput) (err error) {
 			))
 		}
 
+		for _, jump := range c.connInfo.JumpHosts {
+			o.Output(fmt.Sprintf(
+				"Using configured jump host...\n"+
+					"  Host: %s\n"+
+					"  Port: %d\n"+
+					"  User: %s",
+				jump.Host, jump.Port, jump.User,
+			))
+		}
+
 		if c.connInfo.ProxyHost != "" {
 			o.Output(fmt.Sprintf(
 				"Using configured proxy host...\n"+
@@ -887,6 +897,77 @@ func (c *bastionConn) Close() error {
 	return c.Bastion.Close()
 }
 
+// jumpHop is one of the SSH servers that a connection passes through on the
+// way to its destination.
+type jumpHop struct {
+	addr   string
+	config *ssh.ClientConfig
+}
+
+// JumpConnectFunc is a convenience method for returning a function that
+// connects to a host through a chain of SSH servers, in order, like OpenSSH's
+// ProxyJump option. Only the connection to the first server uses the proxy
+// server, if one is configured.
+func JumpConnectFunc(hops []jumpHop, proto string, addr string, p *proxyInfo) func() (net.Conn, error) {
+	return func() (net.Conn, error) {
+		log.Printf("[DEBUG] Connecting to jump host: %s", hops[0].addr)
+		conn, err := ConnectFunc("tcp", hops[0].addr, p)()
+		if err != nil {
+			return nil, fmt.Errorf("Error connecting to jump host %s: %w", hops[0].addr, err)
+		}
+
+		jc := &jumpConn{}
+		for i, hop := range hops {
+			cConn, cChans, cReq, err := ssh.NewClientConn(conn, hop.addr, hop.config)
+			if err != nil {
+				conn.Close()
+				jc.closeHops()
+				return nil, fmt.Errorf("Error connecting to jump host %s: %w", hop.addr, err)
+			}
+			client := ssh.NewClient(cConn, cChans, cReq)
+			jc.hops = append(jc.hops, client)
+
+			next, nextProto := addr, proto
+			if i+1 < len(hops) {
+				next, nextProto = hops[i+1].addr, "tcp"
+			}
+			log.Printf("[DEBUG] Connecting via jump host (%s) to host: %s", hop.addr, next)
+			conn, err = client.Dial(nextProto, next)
+			if err != nil {
+				jc.closeHops()
+				return nil, err
+			}
+		}
+
+		jc.Conn = conn
+		return jc, nil
+	}
+}
+
+// jumpConn is a connection made through a chain of jump hosts, which closes
+// the connections to each of them when it is closed.
+type jumpConn struct {
+	net.Conn
+	hops []*ssh.Client
+}
+
+func (c *jumpConn) Close() error {
+	c.Conn.Close()
+	return c.closeHops()
+}
+
+// closeHops closes the connections to the jump hosts, starting with the one
+// nearest the destination.
+func (c *jumpConn) closeHops() error {
+	var err error
+	for i := len(c.hops) - 1; i >= 0; i-- {
+		if hopErr := c.hops[i].Close(); hopErr != nil && err == nil {
+			err = hopErr
+		}
+	}
+	return err
+}
+
 func quoteShell(args []string, targetPlatform string) (string, error) {
 	if targetPlatform == TargetPlatformUnix {
 		return shquot.POSIXShell(args), nil
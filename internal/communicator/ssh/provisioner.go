@@ -14,6 +14,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -80,9 +81,21 @@ type connectionInfo struct {
 	BastionHostKey     string
 	BastionPort        uint16
 
+	// JumpHosts are the hosts to connect through after the bastion host,
+	// if any, in order, like OpenSSH's ProxyJump option. They use the
+	// bastion host's credentials.
+	JumpHosts []jumpHost
+
 	AgentIdentity string
 }
 
+// jumpHost is one of the hosts in the jump_hosts connection argument.
+type jumpHost struct {
+	User string
+	Host string
+	Port uint16
+}
+
 // decodeConnInfo decodes the given cty.Value using the same behavior as the
 // legacy mapstructure decoder in order to preserve as much of the existing
 // logic as possible for compatibility.
@@ -150,6 +163,17 @@ func decodeConnInfo(v cty.Value) (*connectionInfo, error) {
 			if err := gocty.FromCtyValue(v, &connInfo.BastionPort); err != nil {
 				return nil, err
 			}
+		case "jump_hosts":
+			for _, spec := range v.AsValueSlice() {
+				if spec.IsNull() {
+					continue
+				}
+				jump, err := parseJumpHost(spec.AsString())
+				if err != nil {
+					return nil, err
+				}
+				connInfo.JumpHosts = append(connInfo.JumpHosts, jump)
+			}
 		case "agent_identity":
 			connInfo.AgentIdentity = v.AsString()
 		}
@@ -218,11 +242,7 @@ func parseConnectionInfo(v cty.Value) (*connectionInfo, error) {
 	}
 
 	// Default all bastion config attrs to their non-bastion counterparts
-	if connInfo.BastionHost != "" {
-		// Format the bastion host if needed.
-		// Needed for IPv6 support.
-		connInfo.BastionHost = shared.IpFormat(connInfo.BastionHost)
-
+	if connInfo.BastionHost != "" || len(connInfo.JumpHosts) != 0 {
 		if connInfo.BastionUser == "" {
 			connInfo.BastionUser = connInfo.User
 		}
@@ -235,14 +255,57 @@ func parseConnectionInfo(v cty.Value) (*connectionInfo, error) {
 		if connInfo.BastionCertificate == "" {
 			connInfo.BastionCertificate = connInfo.Certificate
 		}
+	}
+	if connInfo.BastionHost != "" {
+		// Format the bastion host if needed.
+		// Needed for IPv6 support.
+		connInfo.BastionHost = shared.IpFormat(connInfo.BastionHost)
+
 		if connInfo.BastionPort == 0 {
 			connInfo.BastionPort = connInfo.Port
 		}
 	}
+	for i := range connInfo.JumpHosts {
+		jump := &connInfo.JumpHosts[i]
+		if jump.User == "" {
+			jump.User = connInfo.BastionUser
+		}
+		if jump.Port == 0 {
+			jump.Port = DefaultPort
+		}
+	}
 
 	return connInfo, nil
 }
 
+// parseJumpHost parses a jump host in the same [user@]host[:port] form as
+// OpenSSH's ProxyJump option, where an IPv6 host must be in brackets if it
+// has a port.
+func parseJumpHost(spec string) (jumpHost, error) {
+	var ret jumpHost
+	rest := spec
+	if user, host, ok := strings.Cut(rest, "@"); ok {
+		ret.User = user
+		rest = host
+	}
+
+	host := rest
+	if h, port, err := net.SplitHostPort(rest); err == nil {
+		host = h
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || p == 0 {
+			return ret, fmt.Errorf("invalid port in jump host %q", spec)
+		}
+		ret.Port = uint16(p)
+	}
+	if host == "" || (rest != spec && ret.User == "") {
+		return ret, fmt.Errorf("invalid jump host %q: must be in the form [user@]host[:port]", spec)
+	}
+	// Needed for IPv6 support.
+	ret.Host = shared.IpFormat(host)
+	return ret, nil
+}
+
 // safeDuration returns either the parsed duration or a default value
 func safeDuration(dur string, defaultDur time.Duration) time.Duration {
 	d, err := time.ParseDuration(dur)
@@ -290,6 +353,7 @@ func prepareSSHConfig(connInfo *connectionInfo) (*sshConfig, error) {
 	connectFunc := ConnectFunc("tcp", host, p)
 
 	var bastionConf *ssh.ClientConfig
+	var hops []jumpHop
 	if connInfo.BastionHost != "" {
 		bastionHost := fmt.Sprintf("%s:%d", connInfo.BastionHost, connInfo.BastionPort)
 
@@ -307,6 +371,27 @@ func prepareSSHConfig(connInfo *connectionInfo) (*sshConfig, error) {
 		}
 
 		connectFunc = BastionConnectFunc("tcp", bastionHost, bastionConf, "tcp", host, p)
+		hops = append(hops, jumpHop{addr: bastionHost, config: bastionConf})
+	}
+
+	for _, jump := range connInfo.JumpHosts {
+		jumpAddr := fmt.Sprintf("%s:%d", jump.Host, jump.Port)
+		jumpConf, err := buildSSHClientConfig(sshClientConfigOpts{
+			user:        jump.User,
+			host:        jumpAddr,
+			privateKey:  connInfo.BastionPrivateKey,
+			password:    connInfo.BastionPassword,
+			hostKey:     connInfo.BastionHostKey,
+			certificate: connInfo.BastionCertificate,
+			sshAgent:    sshAgent,
+		})
+		if err != nil {
+			return nil, err
+		}
+		hops = append(hops, jumpHop{addr: jumpAddr, config: jumpConf})
+	}
+	if len(connInfo.JumpHosts) != 0 {
+		connectFunc = JumpConnectFunc(hops, "tcp", host, p)
 	}
 
 	config := &sshConfig{
@@ -361,7 +446,17 @@ func buildSSHClientConfig(opts sshClientConfigOpts) (*ssh.ClientConfig, error) {
 		User:            opts.user,
 	}
 
-	if opts.privateKey != "" {
+	if opts.privateKey != "" && isSecurityKey(opts.privateKey) {
+		// The private key of a FIDO2 security key never leaves the
+		// device, and so we can only use it through an SSH agent.
+		log.Println("using security key for authentication")
+
+		skAuth, err := securityKeyAuth(opts.privateKey, opts.certificate, opts.sshAgent)
+		if err != nil {
+			return nil, err
+		}
+		conf.Auth = append(conf.Auth, skAuth)
+	} else if opts.privateKey != "" {
 		if opts.certificate != "" {
 			log.Println("using client certificate for authentication")
 
@@ -229,3 +229,81 @@ func TestProvisioner_invalidPortNumber(t *testing.T) {
 		t.Errorf("unexpected error\n got: %s\nwant: %s", got, want)
 	}
 }
+
+func TestProvisioner_connInfoJumpHosts(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"type":                cty.StringVal("ssh"),
+		"user":                cty.StringVal("root"),
+		"private_key":         cty.StringVal("someprivatekeycontents"),
+		"host":                cty.StringVal("10.0.0.5"),
+		"bastion_host":        cty.StringVal("bastion.example.com"),
+		"bastion_private_key": cty.StringVal("bastionkeycontents"),
+		"jump_hosts": cty.ListVal([]cty.Value{
+			cty.StringVal("jumper@jump1.internal:2222"),
+			cty.StringVal("jump2.internal"),
+			cty.StringVal("fd00::1"),
+		}),
+	})
+
+	conf, err := parseConnectionInfo(v)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	want := []jumpHost{
+		{User: "jumper", Host: "jump1.internal", Port: 2222},
+		{User: "root", Host: "jump2.internal", Port: 22},
+		{User: "root", Host: "[fd00::1]", Port: 22},
+	}
+	if len(conf.JumpHosts) != len(want) {
+		t.Fatalf("wrong jump hosts: %#v", conf.JumpHosts)
+	}
+	for i := range want {
+		if conf.JumpHosts[i] != want[i] {
+			t.Errorf("wrong jump host %d\ngot:  %#v\nwant: %#v", i, conf.JumpHosts[i], want[i])
+		}
+	}
+	if conf.BastionPrivateKey != "bastionkeycontents" {
+		t.Fatalf("bad: %v", conf)
+	}
+}
+
+func TestProvisioner_connInfoJumpHostsWithoutBastion(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"type":        cty.StringVal("ssh"),
+		"user":        cty.StringVal("root"),
+		"private_key": cty.StringVal("someprivatekeycontents"),
+		"host":        cty.StringVal("10.0.0.5"),
+		"jump_hosts":  cty.ListVal([]cty.Value{cty.StringVal("jump.internal")}),
+	})
+
+	conf, err := parseConnectionInfo(v)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The jump hosts use the bastion credentials, which default to the
+	// credentials for the host even though there is no bastion host.
+	if conf.BastionPrivateKey != "someprivatekeycontents" {
+		t.Fatalf("bad: %v", conf)
+	}
+	if conf.BastionPort != 0 {
+		t.Fatalf("bad: %v", conf)
+	}
+}
+
+func TestProvisioner_invalidJumpHost(t *testing.T) {
+	for _, spec := range []string{"", "@jump.internal", "jump.internal:0", "jump.internal:ssh", "user@"} {
+		t.Run(spec, func(t *testing.T) {
+			v := cty.ObjectVal(map[string]cty.Value{
+				"type":       cty.StringVal("ssh"),
+				"host":       cty.StringVal("10.0.0.5"),
+				"jump_hosts": cty.ListVal([]cty.Value{cty.StringVal(spec)}),
+			})
+
+			if _, err := parseConnectionInfo(v); err == nil {
+				t.Fatalf("expected an error for %q", spec)
+			}
+		})
+	}
+}
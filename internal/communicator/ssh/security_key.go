@@ -0,0 +1,104 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// openSSHKeyMagic starts the contents of a private key file in the OpenSSH
+// format, which is the only format that can describe a security key.
+const openSSHKeyMagic = "openssh-key-v1\x00"
+
+// securityKeyPublicKey returns the public key of the given private key if it
+// describes a FIDO2 security key, such as one generated by ssh-keygen with
+// the ed25519-sk or ecdsa-sk key types, or nil otherwise.
+//
+// The private key file of a security key only contains a handle that the
+// device needs to sign with the real key, but the public key is always
+// stored unencrypted alongside it.
+func securityKeyPublicKey(pk string) ssh.PublicKey {
+	block, _ := pem.Decode([]byte(pk))
+	if block == nil || block.Type != "OPENSSH PRIVATE KEY" || !bytes.HasPrefix(block.Bytes, []byte(openSSHKeyMagic)) {
+		return nil
+	}
+
+	var w struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}
+	if err := ssh.Unmarshal(block.Bytes[len(openSSHKeyMagic):], &w); err != nil || w.NumKeys != 1 {
+		return nil
+	}
+	pub, err := ssh.ParsePublicKey(w.PubKey)
+	if err != nil {
+		return nil
+	}
+	switch pub.Type() {
+	case ssh.KeyAlgoSKED25519, ssh.KeyAlgoSKECDSA256:
+		return pub
+	default:
+		return nil
+	}
+}
+
+func isSecurityKey(pk string) bool {
+	return securityKeyPublicKey(pk) != nil
+}
+
+// securityKeyAuth returns an authentication method that signs with the
+// given security key through the SSH agent, which must already have the key
+// loaded, with ssh-add. If a certificate is given then it is presented along
+// with the key.
+func securityKeyAuth(pk, certificate string, sshAgent *sshAgent) (ssh.AuthMethod, error) {
+	pub := securityKeyPublicKey(pk)
+	if sshAgent == nil {
+		return nil, errors.New(
+			"Failed to use ssh private key: security keys can only be used\n" +
+				"through an SSH agent. Add the key to your agent with ssh-add and\n" +
+				"make sure the agent argument is not false.")
+	}
+
+	var cert *ssh.Certificate
+	if certificate != "" {
+		pcert, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %q: %w", certificate, err)
+		}
+		var ok bool
+		if cert, ok = pcert.(*ssh.Certificate); !ok {
+			return nil, fmt.Errorf("failed to parse certificate %q: not a certificate", certificate)
+		}
+	}
+
+	return ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+		signers, err := sshAgent.agent.Signers()
+		if err != nil {
+			return nil, err
+		}
+		for _, signer := range signers {
+			if !bytes.Equal(signer.PublicKey().Marshal(), pub.Marshal()) {
+				continue
+			}
+			if cert == nil {
+				return []ssh.Signer{signer}, nil
+			}
+			certSigner, err := ssh.NewCertSigner(cert, signer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create cert signer: %w", err)
+			}
+			return []ssh.Signer{certSigner}, nil
+		}
+		return nil, fmt.Errorf("the security key %s is not loaded in the SSH agent; add it with ssh-add", ssh.FingerprintSHA256(pub))
+	}), nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// testSecurityKeyPrivateKey returns an OpenSSH private key file for a FIDO2
+// security key, like one generated by "ssh-keygen -t ed25519-sk". Only the
+// public key is meaningful, since the rest is only useful to the device.
+func testSecurityKeyPrivateKey(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := ssh.Marshal(struct {
+		Name        string
+		KeyBytes    []byte
+		Application string
+	}{ssh.KeyAlgoSKED25519, pub, "ssh:"})
+
+	w := struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{"none", "none", "", 1, pubKey, []byte("key handle")}
+	block := &pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte(openSSHKeyMagic), ssh.Marshal(w)...),
+	}
+	return string(pem.EncodeToMemory(block)), pubKey
+}
+
+func TestSecurityKeyPublicKey(t *testing.T) {
+	skKey, wantPub := testSecurityKeyPrivateKey(t)
+	pub := securityKeyPublicKey(skKey)
+	if pub == nil {
+		t.Fatal("security key was not recognized")
+	}
+	if got := string(pub.Marshal()); got != string(wantPub) {
+		t.Errorf("wrong public key")
+	}
+
+	// Ordinary keys, in either format, are not security keys.
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isSecurityKey(string(pem.EncodeToMemory(block))) {
+		t.Error("ed25519 key recognized as a security key")
+	}
+	if isSecurityKey(testServerPrivateKey) {
+		t.Error("RSA key recognized as a security key")
+	}
+	if isSecurityKey("someprivatekeycontents") {
+		t.Error("invalid key recognized as a security key")
+	}
+}
+
+func TestBuildSSHClientConfig_securityKeyWithoutAgent(t *testing.T) {
+	skKey, _ := testSecurityKeyPrivateKey(t)
+
+	_, err := buildSSHClientConfig(sshClientConfigOpts{
+		user:       "user",
+		host:       "127.0.0.1:22",
+		privateKey: skKey,
+	})
+	if err == nil || !strings.Contains(err.Error(), "SSH agent") {
+		t.Fatalf("expected an error about the SSH agent, got %v", err)
+	}
+}
@@ -7,8 +7,10 @@ package configs
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -39,9 +41,31 @@ type CheckRule struct {
 	// interpolation as the corresponding condition.
 	ErrorMessage hcl.Expression
 
+	// Retry, if set, allows a failing postcondition to be evaluated again
+	// after apply, to wait for changes that take effect eventually. It is
+	// always nil for other kinds of check rules.
+	Retry *CheckRuleRetry
+
 	DeclRange hcl.Range
 }
 
+// CheckRuleRetry represents the "retry" block of a postcondition.
+type CheckRuleRetry struct {
+	// Attempts is the total number of times the postcondition is evaluated
+	// during apply before its failure is reported, including the first
+	// evaluation. It is always at least one.
+	Attempts int
+
+	// Interval is the time to wait before each repeated evaluation.
+	Interval time.Duration
+
+	DeclRange hcl.Range
+}
+
+// defaultCheckRuleRetryInterval is the interval used for a retry block that
+// doesn't set one.
+const defaultCheckRuleRetryInterval = 5 * time.Second
+
 // validateSelfReferences looks for references in the check rule matching the
 // specified resource address, returning error diagnostics if such a reference
 // is found.
@@ -131,9 +155,87 @@ func decodeCheckRuleBlock(block *hcl.Block, override bool) (*CheckRule, hcl.Diag
 		cr.ErrorMessage = attr.Expr
 	}
 
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "retry":
+			if cr.Retry != nil {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate retry block",
+					Detail:   fmt.Sprintf("A retry block was already declared at %s.", cr.Retry.DeclRange),
+					Subject:  block.DefRange.Ptr(),
+				})
+				continue
+			}
+			retry, retryDiags := decodeCheckRuleRetryBlock(block)
+			diags = append(diags, retryDiags...)
+			cr.Retry = retry
+		}
+	}
+
+	if cr.Retry != nil && block.Type != "postcondition" {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported retry block",
+			Detail:   fmt.Sprintf("Retry settings are only supported in postcondition blocks, not in %q blocks.", block.Type),
+			Subject:  cr.Retry.DeclRange.Ptr(),
+		})
+		cr.Retry = nil
+	}
+
 	return cr, diags
 }
 
+func decodeCheckRuleRetryBlock(block *hcl.Block) (*CheckRuleRetry, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	retry := &CheckRuleRetry{
+		Interval:  defaultCheckRuleRetryInterval,
+		DeclRange: block.DefRange,
+	}
+
+	content, moreDiags := block.Body.Content(checkRuleRetryBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["attempts"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &retry.Attempts)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && retry.Attempts < 1 {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid retry attempts",
+				Detail:   "The number of attempts must be at least 1.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+	if retry.Attempts < 1 {
+		// Leave a usable value after an error, so that callers can still
+		// inspect the configuration.
+		retry.Attempts = 1
+	}
+
+	if attr, exists := content.Attributes["interval"]; exists {
+		var raw string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			interval, err := time.ParseDuration(raw)
+			if err != nil || interval < 0 {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid retry interval",
+					Detail:   "The interval must be a non-negative duration string, such as \"10s\" or \"1m30s\".",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				retry.Interval = interval
+			}
+		}
+	}
+
+	return retry, diags
+}
+
 var checkRuleBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		{
@@ -145,6 +247,21 @@ var checkRuleBlockSchema = &hcl.BodySchema{
 			Required: true,
 		},
 	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "retry"},
+	},
+}
+
+var checkRuleRetryBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "attempts",
+			Required: true,
+		},
+		{
+			Name: "interval",
+		},
+	},
 }
 
 // Check represents a configuration defined check block.
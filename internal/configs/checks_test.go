@@ -0,0 +1,118 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestDecodeCheckRuleBlock_retry(t *testing.T) {
+	tests := map[string]struct {
+		src          string
+		wantAttempts int
+		wantInterval time.Duration
+		wantErr      string
+	}{
+		"no retry": {
+			src: `
+condition     = self.ready
+error_message = "Not ready."
+`,
+		},
+		"attempts only": {
+			src: `
+condition     = self.ready
+error_message = "Not ready."
+retry {
+  attempts = 3
+}
+`,
+			wantAttempts: 3,
+			wantInterval: defaultCheckRuleRetryInterval,
+		},
+		"attempts and interval": {
+			src: `
+condition     = self.ready
+error_message = "Not ready."
+retry {
+  attempts = 10
+  interval = "1m30s"
+}
+`,
+			wantAttempts: 10,
+			wantInterval: 90 * time.Second,
+		},
+		"invalid interval": {
+			src: `
+condition     = self.ready
+error_message = "Not ready."
+retry {
+  attempts = 2
+  interval = "soon"
+}
+`,
+			wantErr: "Invalid retry interval",
+		},
+		"duplicate": {
+			src: `
+condition     = self.ready
+error_message = "Not ready."
+retry {
+  attempts = 2
+}
+retry {
+  attempts = 3
+}
+`,
+			wantErr: "Duplicate retry block",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(test.src), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			block := &hcl.Block{
+				Type: "postcondition",
+				Body: file.Body,
+			}
+
+			cr, diags := decodeCheckRuleBlock(block, false)
+			if test.wantErr != "" {
+				if !diags.HasErrors() {
+					t.Fatal("expected errors but got none")
+				}
+				if got := diags[0].Summary; got != test.wantErr {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+
+			if test.wantAttempts == 0 {
+				if cr.Retry != nil {
+					t.Fatalf("unexpected retry settings: %#v", cr.Retry)
+				}
+				return
+			}
+			if cr.Retry == nil {
+				t.Fatal("retry settings were not decoded")
+			}
+			if got := cr.Retry.Attempts; got != test.wantAttempts {
+				t.Errorf("wrong attempts %d; want %d", got, test.wantAttempts)
+			}
+			if got := cr.Retry.Interval; got != test.wantInterval {
+				t.Errorf("wrong interval %s; want %s", got, test.wantInterval)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package configlint contains optional hygiene checks for configuration that
+// is otherwise valid, such as declarations that are never used.
+//
+// These checks are deliberately separate from normal configuration validation
+// because the problems they report don't prevent OpenTofu from working with
+// the configuration; they are intended to be run on request, for example by
+// "tofu validate -lint".
+package configlint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+// Lint checks all of the local modules in the given configuration tree for
+// unused local values, unused input variables, and outputs of child modules
+// that their calling module never refers to.
+//
+// Only modules that are part of the same source tree as the root module are
+// checked, because problems in modules installed from elsewhere are not
+// something the user can reasonably fix.
+//
+// The given parser must be the one that loaded the configuration, so that the
+// source files are already cached. The result contains only warnings.
+func Lint(cfg *configs.Config, parser *configs.Parser) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	refs := make(map[string]*moduleRefs)
+	cfg.DeepEach(func(c *configs.Config) {
+		if !isLocalModule(c) {
+			return
+		}
+		r, ok := collectModuleRefs(c.Module, parser)
+		if !ok {
+			return
+		}
+		refs[c.Path.String()] = r
+	})
+
+	cfg.DeepEach(func(c *configs.Config) {
+		r, ok := refs[c.Path.String()]
+		if !ok {
+			return
+		}
+
+		for name, local := range c.Module.Locals {
+			if r.locals[name] {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unused local value",
+				Detail:   fmt.Sprintf("The local value %q is declared but never referenced in its module.", name),
+				Subject:  local.DeclRange.Ptr(),
+			})
+		}
+
+		for name, variable := range c.Module.Variables {
+			if r.variables[name] {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unused input variable",
+				Detail:   fmt.Sprintf("The input variable %q is declared but never referenced in its module.", name),
+				Subject:  variable.DeclRange.Ptr(),
+			})
+		}
+
+		// Outputs of the root module are its external interface, so we can
+		// only judge outputs of child modules against their calling module.
+		if c.Parent == nil {
+			return
+		}
+		parentRefs, ok := refs[c.Parent.Path.String()]
+		if !ok {
+			return
+		}
+		callName := c.Path[len(c.Path)-1]
+		used := parentRefs.moduleOutputs[callName]
+		if used.all {
+			return
+		}
+		for name, output := range c.Module.Outputs {
+			if used.names[name] {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unreferenced output value",
+				Detail:   fmt.Sprintf("The output value %q is never referenced by the calling module %q.", name, "module."+callName),
+				Subject:  output.DeclRange.Ptr(),
+			})
+		}
+	})
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Subject, diags[j].Subject
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Start.Byte < b.Start.Byte
+	})
+	return diags
+}
+
+// isLocalModule returns true if the given module is the root module or is
+// reached from the root only through calls with local source addresses.
+func isLocalModule(c *configs.Config) bool {
+	for ; c.Parent != nil; c = c.Parent {
+		if _, ok := c.SourceAddr.(addrs.ModuleSourceLocal); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// moduleRefs records which named values a single module refers to.
+type moduleRefs struct {
+	locals        map[string]bool
+	variables     map[string]bool
+	moduleOutputs map[string]moduleOutputRefs
+}
+
+type moduleOutputRefs struct {
+	// all is set if the module call result is used as a whole, in which case
+	// we can't tell which outputs are actually needed.
+	all   bool
+	names map[string]bool
+}
+
+// collectModuleRefs finds all of the references in the files of the given
+// module. The second result is false if some files could not be analyzed,
+// such as JSON configuration files, in which case the caller must not report
+// anything for the module.
+func collectModuleRefs(mod *configs.Module, parser *configs.Parser) (*moduleRefs, bool) {
+	primary, override, diags := parser.ConfigDirFiles(mod.SourceDir)
+	if diags.HasErrors() {
+		return nil, false
+	}
+
+	r := &moduleRefs{
+		locals:        make(map[string]bool),
+		variables:     make(map[string]bool),
+		moduleOutputs: make(map[string]moduleOutputRefs),
+	}
+	for _, filename := range append(primary, override...) {
+		body, diags := parser.LoadHCLFile(filename)
+		if diags.HasErrors() {
+			return nil, false
+		}
+		syntaxBody, ok := body.(*hclsyntax.Body)
+		if !ok {
+			return nil, false
+		}
+
+		// References inside variable blocks can only be a variable referring
+		// to itself in its validation rules, which doesn't count as a use.
+		for _, attr := range syntaxBody.Attributes {
+			r.visit(attr)
+		}
+		for _, block := range syntaxBody.Blocks {
+			if block.Type == "variable" {
+				continue
+			}
+			r.visit(block)
+		}
+	}
+	return r, true
+}
+
+func (r *moduleRefs) visit(node hclsyntax.Node) {
+	hclsyntax.VisitAll(node, func(node hclsyntax.Node) hcl.Diagnostics {
+		if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+			r.addTraversal(expr.Traversal)
+		}
+		return nil
+	})
+}
+
+func (r *moduleRefs) addTraversal(traversal hcl.Traversal) {
+	if len(traversal) < 2 {
+		return
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return
+	}
+	switch traversal.RootName() {
+	case "local":
+		r.locals[name.Name] = true
+	case "var":
+		r.variables[name.Name] = true
+	case "module":
+		used := r.moduleOutputs[name.Name]
+		rest := traversal[2:]
+		// Skip over any instance key for module calls using count or for_each.
+		if len(rest) != 0 {
+			if _, ok := rest[0].(hcl.TraverseIndex); ok {
+				rest = rest[1:]
+			}
+		}
+		output, ok := hcl.TraverseAttr{}, false
+		if len(rest) != 0 {
+			output, ok = rest[0].(hcl.TraverseAttr)
+		}
+		if !ok {
+			used.all = true
+		} else {
+			if used.names == nil {
+				used.names = make(map[string]bool)
+			}
+			used.names[output.Name] = true
+		}
+		r.moduleOutputs[name.Name] = used
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configlint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+func TestLint(t *testing.T) {
+	parser := configs.NewParser(nil)
+	cfg := testConfig(t, parser, "testdata/unused")
+
+	type result struct {
+		Summary  string
+		Detail   string
+		Filename string
+		Line     int
+	}
+	var got []result
+	for _, diag := range Lint(cfg, parser) {
+		if diag.Severity != hcl.DiagWarning {
+			t.Errorf("unexpected non-warning diagnostic: %s", diag.Error())
+		}
+		got = append(got, result{
+			Summary:  diag.Summary,
+			Detail:   diag.Detail,
+			Filename: filepath.ToSlash(diag.Subject.Filename),
+			Line:     diag.Subject.Start.Line,
+		})
+	}
+
+	want := []result{
+		{
+			Summary:  "Unreferenced output value",
+			Detail:   `The output value "unused" is never referenced by the calling module "module.child".`,
+			Filename: "testdata/unused/child/main.tf",
+			Line:     9,
+		},
+		{
+			Summary:  "Unused input variable",
+			Detail:   `The input variable "unused" is declared but never referenced in its module.`,
+			Filename: "testdata/unused/main.tf",
+			Line:     5,
+		},
+		{
+			Summary:  "Unused local value",
+			Detail:   `The local value "unused" is declared but never referenced in its module.`,
+			Filename: "testdata/unused/main.tf",
+			Line:     16,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong diagnostics\n%s", diff)
+	}
+}
+
+func testConfig(t *testing.T, parser *configs.Parser, dir string) *configs.Config {
+	t.Helper()
+
+	mod, diags := parser.LoadConfigDir(dir, configs.RootModuleCallForTesting())
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	cfg, diags := configs.BuildConfig(mod, configs.ModuleWalkerFunc(
+		func(req *configs.ModuleRequest) (*configs.Module, *version.Version, hcl.Diagnostics) {
+			sourceDir := filepath.Join(req.Parent.Module.SourceDir, req.SourceAddr.String())
+			mod, diags := parser.LoadConfigDir(sourceDir, configs.RootModuleCallForTesting())
+			return mod, nil, diags
+		},
+	))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	return cfg
+}
@@ -0,0 +1,11 @@
+variable "name" {
+  type = string
+}
+
+output "used" {
+  value = var.name
+}
+
+output "unused" {
+  value = "${var.name}-unused"
+}
@@ -0,0 +1,27 @@
+variable "used" {
+  type = string
+}
+
+variable "unused" {
+  type = string
+
+  validation {
+    condition     = length(var.unused) > 0
+    error_message = "Must not be empty."
+  }
+}
+
+locals {
+  used   = upper(var.used)
+  unused = "nothing"
+}
+
+module "child" {
+  source = "./child"
+
+  name = local.used
+}
+
+output "result" {
+  value = module.child.used
+}
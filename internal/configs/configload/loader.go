@@ -167,3 +167,16 @@ func (l *Loader) ImportSourcesFromSnapshot(snap *Snapshot) {
 func (l *Loader) AllowLanguageExperiments(allowed bool) {
 	l.parser.AllowLanguageExperiments(allowed)
 }
+
+// IgnoreCoreVersionConstraints specifies whether modules loaded by subsequent
+// LoadConfig (and similar) calls report unmet OpenTofu version constraints as
+// warnings rather than errors.
+func (l *Loader) IgnoreCoreVersionConstraints(ignore bool) {
+	l.parser.IgnoreCoreVersionConstraints(ignore)
+}
+
+// CoreVersionConstraintsIgnored returns the value most recently passed to
+// IgnoreCoreVersionConstraints.
+func (l *Loader) CoreVersionConstraintsIgnored() bool {
+	return l.parser.CoreVersionConstraintsIgnored()
+}
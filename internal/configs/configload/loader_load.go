@@ -11,7 +11,9 @@ import (
 	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/modsdir"
 )
 
 // LoadConfig reads the OpenTofu module in the given directory and uses it as the
@@ -106,6 +108,20 @@ func (l *Loader) moduleWalkerLoad(req *configs.ModuleRequest) (*configs.Module,
 			Subject: &req.SourceAddrRange,
 		})
 	}
+	if addr, ok := req.SourceAddr.(addrs.ModuleSourceLocal); ok {
+		parentRecord := l.modules.manifest[l.modules.manifest.ModuleKey(req.Parent.Path)]
+		if dir, changed := modsdir.LocalModuleDirChanged(parentRecord.Dir, record.Dir, addr); changed {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Module directory has changed",
+				Detail: fmt.Sprintf(
+					"This module's directory was %s when it was installed, but a symbolic link in its path now leads to %s. Run \"tofu init\" to install all modules required by this configuration.",
+					record.Dir, dir,
+				),
+				Subject: &req.SourceAddrRange,
+			})
+		}
+	}
 
 	mod, mDiags := l.parser.LoadConfigDir(record.Dir, req.Call)
 	diags = append(diags, mDiags...)
@@ -24,6 +24,10 @@ var mapLabelNames = []string{"key"}
 // Blocks are used by value and copied when working with NestedBlocks, and the
 // copying of the value prevents any safe synchronisation of the struct itself.
 //
+// The same cache also memoizes the results of Block.ImpliedType, so that all
+// of the objects decoded using a particular schema share a single cty.Type
+// rather than each constructing an identical one of its own.
+//
 // While we are using the *Block pointer as the cache key, and the Block
 // contents are mutable, once a Block is created it is treated as immutable for
 // the duration of its life. Because a Block is a representation of a logical
@@ -31,11 +35,13 @@ var mapLabelNames = []string{"key"}
 // schema during execution would be an error.
 type specCache struct {
 	sync.Mutex
-	specs map[uintptr]hcldec.Spec
+	specs        map[uintptr]hcldec.Spec
+	impliedTypes map[uintptr]cty.Type
 }
 
 var decoderSpecCache = specCache{
-	specs: map[uintptr]hcldec.Spec{},
+	specs:        map[uintptr]hcldec.Spec{},
+	impliedTypes: map[uintptr]cty.Type{},
 }
 
 // get returns the Spec associated with eth given Block, or nil if non is
@@ -59,20 +65,60 @@ func (s *specCache) set(b *Block, spec hcldec.Spec) {
 		return
 	}
 
+	s.track(b)
 	s.specs[k] = spec
+}
+
+// getImpliedType returns the type associated with the given Block, and
+// whether there is one.
+func (s *specCache) getImpliedType(b *Block) (cty.Type, bool) {
+	s.Lock()
+	defer s.Unlock()
+	k := uintptr(unsafe.Pointer(b))
+	ty, ok := s.impliedTypes[k]
+	return ty, ok
+}
+
+// setImpliedType stores the given type as being the result of
+// b.ImpliedType().
+func (s *specCache) setImpliedType(b *Block, ty cty.Type) {
+	s.Lock()
+	defer s.Unlock()
+
+	k := uintptr(unsafe.Pointer(b))
+	if _, ok := s.impliedTypes[k]; ok {
+		return
+	}
+
+	s.track(b)
+	s.impliedTypes[k] = ty
+}
+
+// track arranges for everything cached for the given Block to be deleted
+// once the Block is recycled. The caller must hold the lock.
+func (s *specCache) track(b *Block) {
+	k := uintptr(unsafe.Pointer(b))
+	_, hasSpec := s.specs[k]
+	_, hasType := s.impliedTypes[k]
+	if hasSpec || hasType {
+		// We've already set the finalizer for this block, and a second
+		// call to runtime.SetFinalizer would panic.
+		return
+	}
 
 	// This must use a finalizer tied to the Block, otherwise we'll continue to
-	// build up Spec values as the Blocks are recycled.
+	// build up cached values as the Blocks are recycled.
 	runtime.SetFinalizer(b, s.delete)
 }
 
-// delete removes the spec associated with the given Block.
+// delete removes everything cached for the given Block.
 func (s *specCache) delete(b *Block) {
 	s.Lock()
 	defer s.Unlock()
 
 	k := uintptr(unsafe.Pointer(b))
 	delete(s.specs, k)
+	delete(s.impliedTypes, k)
 }
 
 // DecoderSpec returns a hcldec.Spec that can be used to decode a HCL Body
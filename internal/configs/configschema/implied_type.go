@@ -22,8 +22,21 @@ import (
 // inconsistent. Code that creates configschema.Block objects should be
 // tested using the InternalValidate method to detect any inconsistencies
 // that would cause this method to fall back on defaults and assumptions.
+//
+// The result is memoized, so repeated calls for the same Block return the
+// same cty.Type rather than constructing a new one each time.
 func (b *Block) ImpliedType() cty.Type {
-	return b.specType().WithoutOptionalAttributesDeep()
+	if b == nil {
+		return cty.EmptyObject
+	}
+
+	if ty, ok := decoderSpecCache.getImpliedType(b); ok {
+		return ty
+	}
+
+	ty := b.specType().WithoutOptionalAttributesDeep()
+	decoderSpecCache.setImpliedType(b, ty)
+	return ty
 }
 
 // specType returns the cty.Type used for decoding a configuration
@@ -159,6 +159,43 @@ func TestBlockImpliedType(t *testing.T) {
 	}
 }
 
+func TestBlockImpliedType_memoized(t *testing.T) {
+	schema := &Block{
+		Attributes: map[string]*Attribute{
+			"id": {Type: cty.String, Computed: true},
+		},
+		BlockTypes: map[string]*NestedBlock{
+			"nested": {
+				Nesting: NestingList,
+				Block: Block{
+					Attributes: map[string]*Attribute{
+						"name": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	want := cty.Object(map[string]cty.Type{
+		"id": cty.String,
+		"nested": cty.List(cty.Object(map[string]cty.Type{
+			"name": cty.String,
+		})),
+	})
+
+	// The first call populates the cache, and the second is served from it.
+	for i := 0; i < 2; i++ {
+		got := schema.ImpliedType()
+		if !got.Equals(want) {
+			t.Errorf("wrong result for call %d\ngot:  %#v\nwant: %#v", i, got, want)
+		}
+	}
+
+	var nilSchema *Block
+	if got := nilSchema.ImpliedType(); !got.Equals(cty.EmptyObject) {
+		t.Errorf("wrong result for nil block\ngot:  %#v\nwant: %#v", got, cty.EmptyObject)
+	}
+}
+
 func TestBlockContainsSensitive(t *testing.T) {
 	tests := map[string]struct {
 		Schema *Block
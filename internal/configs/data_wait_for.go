@@ -0,0 +1,183 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// DataWaitFor represents the "wait_for" block of a data resource, which makes
+// OpenTofu read the data source repeatedly until a condition holds, to wait
+// for a remote system to reach a desired state.
+type DataWaitFor struct {
+	// Condition is an expression that must evaluate to true once the data
+	// source result is as desired. It can refer only to the result itself,
+	// via "self", and to count.index, each.key, path and terraform.
+	Condition hcl.Expression
+
+	// Timeout is the maximum time to spend waiting before failing.
+	Timeout time.Duration
+
+	// Interval is the time to wait before reading the data source again
+	// after the first unsuccessful read.
+	Interval time.Duration
+
+	// Backoff is the factor by which the interval grows after each
+	// unsuccessful read. It is always at least 1.
+	Backoff float64
+
+	DeclRange hcl.Range
+}
+
+// These are the defaults for the optional arguments of a wait_for block.
+const (
+	defaultDataWaitForTimeout  = 5 * time.Minute
+	defaultDataWaitForInterval = 5 * time.Second
+	defaultDataWaitForBackoff  = 2
+)
+
+func decodeDataWaitForBlock(block *hcl.Block) (*DataWaitFor, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	wf := &DataWaitFor{
+		Timeout:   defaultDataWaitForTimeout,
+		Interval:  defaultDataWaitForInterval,
+		Backoff:   defaultDataWaitForBackoff,
+		DeclRange: block.DefRange,
+	}
+
+	content, moreDiags := block.Body.Content(dataWaitForBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["condition"]; exists {
+		wf.Condition = attr.Expr
+		diags = append(diags, validateDataWaitForCondition(attr.Expr)...)
+	}
+
+	if attr, exists := content.Attributes["timeout"]; exists {
+		timeout, moreDiags := decodeDataWaitForDuration(attr)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			wf.Timeout = timeout
+		}
+	}
+
+	if attr, exists := content.Attributes["interval"]; exists {
+		interval, moreDiags := decodeDataWaitForDuration(attr)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			wf.Interval = interval
+		}
+	}
+
+	if attr, exists := content.Attributes["backoff"]; exists {
+		var backoff float64
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &backoff)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			if backoff < 1 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid wait_for backoff",
+					Detail:   "The backoff factor must be at least 1. Use 1 to wait for the same interval between all reads.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				wf.Backoff = backoff
+			}
+		}
+	}
+
+	return wf, diags
+}
+
+func decodeDataWaitForDuration(attr *hcl.Attribute) (time.Duration, hcl.Diagnostics) {
+	var raw string
+	diags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+	if diags.HasErrors() {
+		return 0, diags
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Invalid wait_for %s", attr.Name),
+			Detail:   fmt.Sprintf("The %s must be a positive duration string, such as \"30s\" or \"10m\".", attr.Name),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+		return 0, diags
+	}
+	return d, diags
+}
+
+// validateDataWaitForCondition checks that a wait_for condition refers to the
+// data source result, and otherwise only to values that can't change while
+// OpenTofu is waiting.
+func validateDataWaitForCondition(expr hcl.Expression) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	refersToSelf := false
+	for _, v := range expr.Variables() {
+		valid := false
+		switch v.RootName() {
+		case "self":
+			refersToSelf = true
+			valid = true
+		case "path", "terraform", "tofu":
+			valid = true
+		case "count":
+			if len(v) == 2 {
+				if t, ok := v[1].(hcl.TraverseAttr); ok && t.Name == "index" {
+					valid = true
+				}
+			}
+		case "each":
+			if len(v) == 2 {
+				if t, ok := v[1].(hcl.TraverseAttr); ok && t.Name == "key" {
+					valid = true
+				}
+			}
+		}
+		if !valid {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid reference in wait_for condition",
+				Detail:   "A wait_for condition may only refer to the data source result, via 'self', and to 'count.index' or 'each.key'.",
+				Subject:  v.SourceRange().Ptr(),
+			})
+		}
+	}
+
+	if !refersToSelf && !diags.HasErrors() {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid wait_for condition",
+			Detail:   "The condition expression must refer to the data source result using 'self', or else waiting would not change its result.",
+			Subject:  expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+var dataWaitForBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "condition",
+			Required: true,
+		},
+		{
+			Name: "timeout",
+		},
+		{
+			Name: "interval",
+		},
+		{
+			Name: "backoff",
+		},
+	},
+}
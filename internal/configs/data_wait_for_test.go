@@ -0,0 +1,95 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestDecodeDataWaitForBlock(t *testing.T) {
+	tests := map[string]struct {
+		src     string
+		want    DataWaitFor
+		wantErr string
+	}{
+		"defaults": {
+			src: `condition = self.ready`,
+			want: DataWaitFor{
+				Timeout:  defaultDataWaitForTimeout,
+				Interval: defaultDataWaitForInterval,
+				Backoff:  defaultDataWaitForBackoff,
+			},
+		},
+		"all arguments": {
+			src: `
+condition = self.status == "ready"
+timeout   = "20m"
+interval  = "30s"
+backoff   = 1
+`,
+			want: DataWaitFor{
+				Timeout:  20 * time.Minute,
+				Interval: 30 * time.Second,
+				Backoff:  1,
+			},
+		},
+		"no self reference": {
+			src:     `condition = path.module != ""`,
+			wantErr: "Invalid wait_for condition",
+		},
+		"invalid timeout": {
+			src: `
+condition = self.ready
+timeout   = "0s"
+`,
+			wantErr: "Invalid wait_for timeout",
+		},
+		"invalid backoff": {
+			src: `
+condition = self.ready
+backoff   = 0.5
+`,
+			wantErr: "Invalid wait_for backoff",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(test.src), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+
+			got, diags := decodeDataWaitForBlock(&hcl.Block{
+				Type: "wait_for",
+				Body: file.Body,
+			})
+			if test.wantErr != "" {
+				if !diags.HasErrors() {
+					t.Fatal("expected errors but got none")
+				}
+				if got := diags[0].Summary; got != test.wantErr {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+
+			if got.Condition == nil {
+				t.Error("condition was not decoded")
+			}
+			if got.Timeout != test.want.Timeout || got.Interval != test.want.Interval || got.Backoff != test.want.Backoff {
+				t.Errorf("wrong result\ngot:  timeout %s, interval %s, backoff %g\nwant: timeout %s, interval %s, backoff %g",
+					got.Timeout, got.Interval, got.Backoff,
+					test.want.Timeout, test.want.Interval, test.want.Backoff)
+			}
+		})
+	}
+}
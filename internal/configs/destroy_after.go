@@ -0,0 +1,62 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// decodeDestroyAfter decodes the destroy_after lifecycle argument, which is a
+// list of references to managed resources or module calls in the same module
+// that must be destroyed before the object the argument belongs to.
+func decodeDestroyAfter(attr *hcl.Attribute) ([]hcl.Traversal, hcl.Diagnostics) {
+	traversals, diags := decodeDependsOn(attr)
+
+	ret := make([]hcl.Traversal, 0, len(traversals))
+	for _, traversal := range traversals {
+		ref, refDiags := addrs.ParseRef(traversal)
+		if refDiags.HasErrors() {
+			diags = append(diags, refDiags.ToHCL()...)
+			continue
+		}
+
+		switch subject := ref.Subject.(type) {
+		case addrs.Resource:
+			if subject.Mode != addrs.ManagedResourceMode {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid destroy_after reference",
+					Detail:   "Data resources are never destroyed, so they cannot be used in destroy_after.",
+					Subject:  traversal.SourceRange().Ptr(),
+				})
+				continue
+			}
+		case addrs.ModuleCall:
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid destroy_after reference",
+				Detail:   "References in destroy_after must be to a whole managed resource or module call.",
+				Subject:  traversal.SourceRange().Ptr(),
+			})
+			continue
+		}
+
+		if len(ref.Remaining) != 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid destroy_after reference",
+				Detail:   "References in destroy_after must be to a whole managed resource or module call, not to an attribute of an object.",
+				Subject:  ref.Remaining.SourceRange().Ptr(),
+			})
+			continue
+		}
+
+		ret = append(ret, traversal)
+	}
+
+	return ret, diags
+}
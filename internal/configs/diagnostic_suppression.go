@@ -0,0 +1,231 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// DiagnosticSuppressionAction is what OpenTofu does with the warnings that
+// match a DiagnosticSuppression.
+type DiagnosticSuppressionAction string
+
+const (
+	// DiagnosticSuppressionHide hides the matching warnings altogether.
+	DiagnosticSuppressionHide DiagnosticSuppressionAction = "suppress"
+
+	// DiagnosticSuppressionDowngrade shows only the summaries of the
+	// matching warnings, gathered into a single warning.
+	DiagnosticSuppressionDowngrade DiagnosticSuppressionAction = "downgrade"
+)
+
+// DiagnosticSuppression represents a "suppress" block nested in the
+// "diagnostics" block of a "terraform" block, which acknowledges a class of
+// warnings so that they don't drown out new ones.
+//
+// Only the suppressions declared in the root module have any effect, and
+// they never apply to errors.
+type DiagnosticSuppression struct {
+	// Code and Summary select the warnings by their stable diagnostic code
+	// and by their exact summary. At least one of them is set, and a warning
+	// must match both when both are set.
+	Code    tfdiags.Code
+	Summary string
+
+	// Address, if set, is a pattern that the address of the object that a
+	// warning relates to must match. An asterisk matches any sequence of
+	// characters.
+	Address string
+
+	Action DiagnosticSuppressionAction
+
+	// Expires is the last day on which the suppression applies.
+	Expires time.Time
+
+	Reason string
+
+	DeclRange hcl.Range
+}
+
+// diagnosticCodePattern matches the syntax of the stable diagnostic codes.
+var diagnosticCodePattern = regexp.MustCompile(`^TOFU[0-9]{4}$`)
+
+func decodeDiagnosticsBlock(block *hcl.Block) ([]*DiagnosticSuppression, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, moreDiags := block.Body.Content(diagnosticsBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	var ret []*DiagnosticSuppression
+	for _, innerBlock := range content.Blocks {
+		suppression, moreDiags := decodeDiagnosticSuppressionBlock(innerBlock)
+		diags = append(diags, moreDiags...)
+		if suppression != nil {
+			ret = append(ret, suppression)
+		}
+	}
+	return ret, diags
+}
+
+func decodeDiagnosticSuppressionBlock(block *hcl.Block) (*DiagnosticSuppression, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, moreDiags := block.Body.Content(diagnosticSuppressionBlockSchema)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := &DiagnosticSuppression{
+		Action:    DiagnosticSuppressionHide,
+		DeclRange: block.DefRange,
+	}
+	decodeString := func(name string) (string, bool) {
+		attr, exists := content.Attributes[name]
+		if !exists {
+			return "", false
+		}
+		var s string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &s)
+		diags = append(diags, valDiags...)
+		return s, !valDiags.HasErrors()
+	}
+
+	if code, ok := decodeString("code"); ok {
+		if !diagnosticCodePattern.MatchString(code) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid diagnostic code",
+				Detail:   fmt.Sprintf("%q is not a valid diagnostic code. Diagnostic codes consist of \"TOFU\" followed by four digits, such as \"TOFU1001\".", code),
+				Subject:  content.Attributes["code"].Expr.Range().Ptr(),
+			})
+		}
+		ret.Code = tfdiags.Code(code)
+	}
+	if summary, ok := decodeString("summary"); ok {
+		ret.Summary = summary
+	}
+	if ret.Code == "" && ret.Summary == "" && !diags.HasErrors() {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing diagnostic selector",
+			Detail:   "A diagnostic suppression must select the warnings it applies to with the \"code\" argument, the \"summary\" argument, or both.",
+			Subject:  block.DefRange.Ptr(),
+		})
+	}
+
+	if address, ok := decodeString("address"); ok {
+		ret.Address = address
+	}
+	if reason, ok := decodeString("reason"); ok {
+		ret.Reason = reason
+	}
+
+	if action, ok := decodeString("action"); ok {
+		switch DiagnosticSuppressionAction(action) {
+		case DiagnosticSuppressionHide, DiagnosticSuppressionDowngrade:
+			ret.Action = DiagnosticSuppressionAction(action)
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid diagnostic suppression action",
+				Detail:   fmt.Sprintf("The action must be either %q, to hide the matching warnings, or %q, to show only their summaries.", DiagnosticSuppressionHide, DiagnosticSuppressionDowngrade),
+				Subject:  content.Attributes["action"].Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	// The schema requires "expires", so that every suppression is reviewed
+	// eventually rather than hiding warnings forever.
+	if expires, ok := decodeString("expires"); ok {
+		t, err := time.Parse(time.DateOnly, expires)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid expiry date",
+				Detail:   fmt.Sprintf("The expiry date must be a date in the format YYYY-MM-DD, such as \"2025-12-31\"; %q is not valid.", expires),
+				Subject:  content.Attributes["expires"].Expr.Range().Ptr(),
+			})
+		}
+		ret.Expires = t
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return ret, diags
+}
+
+// Expired returns true if the suppression no longer applies at the given
+// time. A suppression applies until the end of its expiry date, in UTC.
+func (s *DiagnosticSuppression) Expired(now time.Time) bool {
+	return !now.UTC().Before(s.Expires.AddDate(0, 0, 1))
+}
+
+// Matches returns true if the given diagnostic is a warning that the
+// suppression selects, regardless of whether the suppression has expired.
+func (s *DiagnosticSuppression) Matches(diag tfdiags.Diagnostic) bool {
+	if diag.Severity() != tfdiags.Warning {
+		return false
+	}
+	desc := diag.Description()
+	if s.Code != "" && tfdiags.DiagnosticCode(diag) != s.Code {
+		return false
+	}
+	if s.Summary != "" && desc.Summary != s.Summary {
+		return false
+	}
+	if s.Address != "" && !matchAddressPattern(s.Address, desc.Address) {
+		return false
+	}
+	return true
+}
+
+// matchAddressPattern returns true if the given address matches the given
+// pattern, in which an asterisk matches any sequence of characters and all
+// other characters match only themselves.
+func matchAddressPattern(pattern, addr string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == addr
+	}
+	if !strings.HasPrefix(addr, parts[0]) {
+		return false
+	}
+	addr = addr[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(addr, part)
+		if i < 0 {
+			return false
+		}
+		addr = addr[i+len(part):]
+	}
+	return len(addr) >= len(last) && strings.HasSuffix(addr, last)
+}
+
+var diagnosticsBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "suppress"},
+	},
+}
+
+var diagnosticSuppressionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "code"},
+		{Name: "summary"},
+		{Name: "address"},
+		{Name: "action"},
+		{Name: "expires", Required: true},
+		{Name: "reason"},
+	},
+}
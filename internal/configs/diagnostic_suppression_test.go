@@ -0,0 +1,231 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestDiagnosticSuppressions(t *testing.T) {
+	tests := map[string]struct {
+		files     map[string]string
+		want      int
+		wantError string
+	}{
+		"unset": {
+			files: map[string]string{
+				"main.tf": `terraform {}`,
+			},
+			want: 0,
+		},
+		"valid": {
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  diagnostics {
+    suppress {
+      code    = "TOFU1005"
+      expires = "2030-01-31"
+      reason  = "Waiting for the upstream module to be updated."
+    }
+    suppress {
+      summary = "Argument is deprecated"
+      address = "module.legacy.*"
+      action  = "downgrade"
+      expires = "2030-01-31"
+    }
+  }
+}
+`,
+			},
+			want: 2,
+		},
+		"multiple files": {
+			files: map[string]string{
+				"a.tf": suppressionConfig(`code = "TOFU1001"`, `expires = "2030-01-31"`),
+				"b.tf": suppressionConfig(`code = "TOFU1002"`, `expires = "2030-01-31"`),
+			},
+			want: 2,
+		},
+		"override": {
+			files: map[string]string{
+				"main.tf":     suppressionConfig(`code = "TOFU1001"`, `expires = "2030-01-31"`),
+				"override.tf": suppressionConfig(`code = "TOFU1002"`, `expires = "2030-01-31"`),
+			},
+			want: 1,
+		},
+		"invalid code": {
+			files: map[string]string{
+				"main.tf": suppressionConfig(`code = "W123"`, `expires = "2030-01-31"`),
+			},
+			wantError: "Invalid diagnostic code",
+		},
+		"missing selector": {
+			files: map[string]string{
+				"main.tf": suppressionConfig(`address = "aws_instance.a"`, `expires = "2030-01-31"`),
+			},
+			wantError: "Missing diagnostic selector",
+		},
+		"invalid action": {
+			files: map[string]string{
+				"main.tf": suppressionConfig(`code = "TOFU1001"`, `action = "ignore"`, `expires = "2030-01-31"`),
+			},
+			wantError: "Invalid diagnostic suppression action",
+		},
+		"invalid expiry date": {
+			files: map[string]string{
+				"main.tf": suppressionConfig(`code = "TOFU1001"`, `expires = "next year"`),
+			},
+			wantError: "Invalid expiry date",
+		},
+		"missing expiry date": {
+			files: map[string]string{
+				"main.tf": suppressionConfig(`code = "TOFU1001"`),
+			},
+			wantError: `The argument "expires" is required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parser := testParser(test.files)
+			mod, diags := parser.LoadConfigDir(".", RootModuleCallForTesting())
+			if test.wantError != "" {
+				if !diags.HasErrors() || !strings.Contains(diags.Error(), test.wantError) {
+					t.Fatalf("wrong diagnostics\ngot:  %s\nwant: %s", diags.Error(), test.wantError)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			if got := len(mod.DiagnosticSuppressions); got != test.want {
+				t.Errorf("wrong number of suppressions %d; want %d", got, test.want)
+			}
+		})
+	}
+}
+
+// suppressionConfig returns a configuration file containing a single
+// diagnostic suppression with the given arguments.
+func suppressionConfig(args ...string) string {
+	return "terraform {\n  diagnostics {\n    suppress {\n      " + strings.Join(args, "\n      ") + "\n    }\n  }\n}\n"
+}
+
+func TestDiagnosticSuppressionMatches(t *testing.T) {
+	newWarning := func() tfdiags.Diagnostic {
+		return tfdiags.WithCode(tfdiags.AttributeValue(
+			tfdiags.Warning,
+			"Argument is deprecated",
+			"Use something else instead.",
+			nil,
+		), tfdiags.CodeDeprecatedProviderVersion)
+	}
+	type elaborator interface {
+		ElaborateFromConfigBody(hcl.Body, string) tfdiags.Diagnostic
+	}
+	warning := newWarning()
+	addressed := newWarning().(elaborator).ElaborateFromConfigBody(hcl.EmptyBody(), "module.legacy.aws_instance.a")
+
+	tests := map[string]struct {
+		suppression DiagnosticSuppression
+		diag        tfdiags.Diagnostic
+		want        bool
+	}{
+		"code": {
+			suppression: DiagnosticSuppression{Code: tfdiags.CodeDeprecatedProviderVersion},
+			diag:        warning,
+			want:        true,
+		},
+		"other code": {
+			suppression: DiagnosticSuppression{Code: tfdiags.CodeResourceTargeting},
+			diag:        warning,
+			want:        false,
+		},
+		"summary": {
+			suppression: DiagnosticSuppression{Summary: "Argument is deprecated"},
+			diag:        warning,
+			want:        true,
+		},
+		"code and other summary": {
+			suppression: DiagnosticSuppression{Code: tfdiags.CodeDeprecatedProviderVersion, Summary: "Something else"},
+			diag:        warning,
+			want:        false,
+		},
+		"error": {
+			suppression: DiagnosticSuppression{Summary: "Argument is deprecated"},
+			diag:        tfdiags.Sourceless(tfdiags.Error, "Argument is deprecated", ""),
+			want:        false,
+		},
+		"address": {
+			suppression: DiagnosticSuppression{Summary: "Argument is deprecated", Address: "module.legacy.*"},
+			diag:        addressed,
+			want:        true,
+		},
+		"other address": {
+			suppression: DiagnosticSuppression{Summary: "Argument is deprecated", Address: "module.other.*"},
+			diag:        addressed,
+			want:        false,
+		},
+		"address without address": {
+			suppression: DiagnosticSuppression{Summary: "Argument is deprecated", Address: "module.legacy.*"},
+			diag:        warning,
+			want:        false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.suppression.Matches(test.diag); got != test.want {
+				t.Errorf("wrong result %t; want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosticSuppressionExpired(t *testing.T) {
+	s := DiagnosticSuppression{
+		Expires: time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	if s.Expired(time.Date(2030, 1, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("suppression expired during its expiry date")
+	}
+	if !s.Expired(time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("suppression didn't expire after its expiry date")
+	}
+}
+
+func TestMatchAddressPattern(t *testing.T) {
+	tests := []struct {
+		pattern, addr string
+		want          bool
+	}{
+		{"aws_instance.a", "aws_instance.a", true},
+		{"aws_instance.a", "aws_instance.ab", false},
+		{"aws_instance.*", "aws_instance.a", true},
+		{"aws_instance.*", "aws_instance.a[0]", true},
+		{"aws_instance.*", "module.a.aws_instance.a", false},
+		{"*.aws_instance.a", "module.a.aws_instance.a", true},
+		{"module.*.aws_instance.*", "module.a.aws_instance.b", true},
+		{"module.*.aws_instance.*", "module.a.null_resource.b", false},
+		{"*[0]", "aws_instance.a[0]", true},
+		{"*[0]", "aws_instance.a[1]", false},
+		{"a*a", "a", false},
+		{"*", "", true},
+	}
+
+	for _, test := range tests {
+		if got := matchAddressPattern(test.pattern, test.addr); got != test.want {
+			t.Errorf("matchAddressPattern(%q, %q) = %t; want %t", test.pattern, test.addr, got, test.want)
+		}
+	}
+}
@@ -33,8 +33,20 @@ type Module struct {
 
 	CoreVersionConstraints []VersionConstraint
 
+	// IgnoreCoreVersionConstraints makes CheckCoreVersionRequirements report
+	// unmet constraints as warnings rather than errors.
+	IgnoreCoreVersionConstraints bool
+
 	ActiveExperiments experiments.Set
 
+	// StrictConversions is set if the module opts in to reporting implicit
+	// type conversions, or nil otherwise.
+	StrictConversions *StrictConversions
+
+	// DiagnosticSuppressions are the warnings that the module acknowledges,
+	// which only has an effect for the root module.
+	DiagnosticSuppressions []*DiagnosticSuppression
+
 	Backend              *Backend
 	CloudConfig          *CloudConfig
 	ProviderConfigs      map[string]*Provider
@@ -89,8 +101,17 @@ func (m *Module) GetProviderConfig(name, alias string) (*Provider, bool) {
 type File struct {
 	CoreVersionConstraints []VersionConstraint
 
+	// IgnoreCoreVersionConstraints is set when the file was loaded by a
+	// parser that ignores the OpenTofu versions required by the
+	// configuration.
+	IgnoreCoreVersionConstraints bool
+
 	ActiveExperiments experiments.Set
 
+	StrictConversions []*StrictConversions
+
+	DiagnosticSuppressions []*DiagnosticSuppression
+
 	Backends          []*Backend
 	CloudConfigs      []*CloudConfig
 	ProviderConfigs   []*Provider
@@ -118,9 +139,10 @@ type File struct {
 type SelectiveLoader int
 
 const (
-	SelectiveLoadAll        SelectiveLoader = 0
-	SelectiveLoadBackend    SelectiveLoader = 1
-	SelectiveLoadEncryption SelectiveLoader = 2
+	SelectiveLoadAll         SelectiveLoader = 0
+	SelectiveLoadBackend     SelectiveLoader = 1
+	SelectiveLoadEncryption  SelectiveLoader = 2
+	SelectiveLoadDiagnostics SelectiveLoader = 3
 )
 
 // Apply the selective filter to the input files
@@ -142,6 +164,8 @@ func (s SelectiveLoader) filter(input []*File) []*File {
 			outFile.CloudConfigs = inFile.CloudConfigs
 		case SelectiveLoadEncryption:
 			outFile.Encryptions = inFile.Encryptions
+		case SelectiveLoadDiagnostics:
+			outFile.DiagnosticSuppressions = inFile.DiagnosticSuppressions
 		}
 		out[i] = outFile
 	}
@@ -283,9 +307,27 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 	// If there are any conflicting requirements then we'll catch them
 	// when we actually check these constraints.
 	m.CoreVersionConstraints = append(m.CoreVersionConstraints, file.CoreVersionConstraints...)
+	if file.IgnoreCoreVersionConstraints {
+		m.IgnoreCoreVersionConstraints = true
+	}
 
 	m.ActiveExperiments = experiments.SetUnion(m.ActiveExperiments, file.ActiveExperiments)
 
+	for _, sc := range file.StrictConversions {
+		if m.StrictConversions != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate strict_conversions setting",
+				Detail:   fmt.Sprintf("A module may set strict_conversions only once. It was previously set at %s.", m.StrictConversions.DeclRange),
+				Subject:  &sc.DeclRange,
+			})
+			continue
+		}
+		m.StrictConversions = sc
+	}
+
+	m.DiagnosticSuppressions = append(m.DiagnosticSuppressions, file.DiagnosticSuppressions...)
+
 	for _, b := range file.Backends {
 		if m.Backend != nil {
 			diags = append(diags, &hcl.Diagnostic{
@@ -561,6 +603,14 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		m.CoreVersionConstraints = append(m.CoreVersionConstraints, file.CoreVersionConstraints...)
 	}
 
+	if len(file.StrictConversions) != 0 {
+		m.StrictConversions = file.StrictConversions[len(file.StrictConversions)-1]
+	}
+
+	if len(file.DiagnosticSuppressions) != 0 {
+		m.DiagnosticSuppressions = file.DiagnosticSuppressions
+	}
+
 	if len(file.Backends) != 0 {
 		switch len(file.Backends) {
 		case 1:
@@ -809,6 +859,10 @@ func (m *Module) ImpliedProviderForUnqualifiedType(pType string) addrs.Provider
 	return addrs.ImpliedProviderForUnqualifiedType(pType)
 }
 
+// CoreVersionRemediation is appended to the diagnostics reporting an unmet
+// OpenTofu version constraint, to suggest how to obtain a supported version.
+const CoreVersionRemediation = "Releases of OpenTofu are available for download from https://github.com/opentofu/opentofu/releases. In an emergency, the -ignore-version-constraint global option makes OpenTofu proceed anyway, reporting unmet version constraints as warnings."
+
 func (m *Module) CheckCoreVersionRequirements(path addrs.Module, sourceAddr addrs.ModuleSource) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 
@@ -837,28 +891,34 @@ func (m *Module) CheckCoreVersionRequirements(path addrs.Module, sourceAddr addr
 		}
 
 		if !constraint.Required.Check(tfversion.SemVer) {
+			var what string
 			switch {
 			case len(path) == 0:
-				diags = diags.Append(&hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  "Unsupported OpenTofu Core version",
-					Detail: fmt.Sprintf(
-						"This configuration does not support OpenTofu version %s. To proceed, either choose another supported OpenTofu version or update this version constraint. Version constraints are normally set for good reason, so updating the constraint may lead to other errors or unexpected behavior.",
-						tfversion.String(),
-					),
-					Subject: constraint.DeclRange.Ptr(),
-				})
+				what = "This configuration does"
 			default:
+				what = fmt.Sprintf("Module %s (from %s) does", path, sourceAddr)
+			}
+			if m.IgnoreCoreVersionConstraints {
 				diags = diags.Append(&hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  "Unsupported OpenTofu Core version",
+					Severity: hcl.DiagWarning,
+					Summary:  "Ignoring unsupported OpenTofu Core version",
 					Detail: fmt.Sprintf(
-						"Module %s (from %s) does not support OpenTofu version %s. To proceed, either choose another supported OpenTofu version or update this version constraint. Version constraints are normally set for good reason, so updating the constraint may lead to other errors or unexpected behavior.",
-						path, sourceAddr, tfversion.String(),
+						"%s not support OpenTofu version %s, because it requires %s. OpenTofu is ignoring this version constraint as requested by the -ignore-version-constraint option, so it may report other errors or behave unexpectedly.",
+						what, tfversion.String(), constraint.Required,
 					),
 					Subject: constraint.DeclRange.Ptr(),
 				})
+				continue
 			}
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported OpenTofu Core version",
+				Detail: fmt.Sprintf(
+					"%s not support OpenTofu version %s, because it requires %s. To proceed, either choose another supported OpenTofu version or update this version constraint. Version constraints are normally set for good reason, so updating the constraint may lead to other errors or unexpected behavior.\n\n%s",
+					what, tfversion.String(), constraint.Required, CoreVersionRemediation,
+				),
+				Subject: constraint.DeclRange.Ptr(),
+			})
 		}
 	}
 
@@ -42,6 +42,11 @@ type ModuleCall struct {
 
 	DependsOn []hcl.Traversal
 
+	// DestroyAfter are references to managed resources or module calls in
+	// the same module whose objects must be destroyed before any of the
+	// objects declared in this module.
+	DestroyAfter []hcl.Traversal
+
 	DeclRange hcl.Range
 }
 
@@ -110,8 +115,30 @@ func decodeModuleBlock(block *hcl.Block, override bool) (*ModuleCall, hcl.Diagno
 	}
 
 	var seenEscapeBlock *hcl.Block
+	var seenLifecycle *hcl.Block
 	for _, block := range content.Blocks {
 		switch block.Type {
+		case "lifecycle":
+			if seenLifecycle != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate lifecycle block",
+					Detail:   fmt.Sprintf("This module call already has a lifecycle block at %s.", seenLifecycle.DefRange),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			seenLifecycle = block
+
+			lcContent, lcDiags := block.Body.Content(moduleLifecycleBlockSchema)
+			diags = append(diags, lcDiags...)
+
+			if attr, exists := lcContent.Attributes["destroy_after"]; exists {
+				deps, depsDiags := decodeDestroyAfter(attr)
+				diags = append(diags, depsDiags...)
+				mc.DestroyAfter = append(mc.DestroyAfter, deps...)
+			}
+
 		case "_":
 			if seenEscapeBlock != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -353,13 +380,22 @@ var moduleBlockSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "_"}, // meta-argument escaping block
 
-		// These are all reserved for future use.
 		{Type: "lifecycle"},
+
+		// These are all reserved for future use.
 		{Type: "locals"},
 		{Type: "provider", LabelNames: []string{"type"}},
 	},
 }
 
+var moduleLifecycleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "destroy_after",
+		},
+	},
+}
+
 func moduleSourceAddrEntersNewPackage(addr addrs.ModuleSource) bool {
 	switch addr.(type) {
 	case nil:
@@ -35,6 +35,10 @@ func (p *Provider) merge(op *Provider) hcl.Diagnostics {
 		p.Version = op.Version
 	}
 
+	if op.PlanOnly != nil {
+		p.PlanOnly = op.PlanOnly
+	}
+
 	p.Config = MergeBodies(p.Config, op.Config)
 
 	return diags
@@ -65,6 +69,10 @@ func (v *Variable) merge(ov *Variable) hcl.Diagnostics {
 		v.Nullable = ov.Nullable
 		v.NullableSet = ov.NullableSet
 	}
+	if ov.MergeDefaultSet {
+		v.MergeDefault = ov.MergeDefault
+		v.MergeDefaultSet = ov.MergeDefaultSet
+	}
 
 	// If the override file overrode type without default or vice-versa then
 	// it may have created an invalid situation, which we'll catch now by
@@ -121,6 +129,10 @@ func (v *Variable) merge(ov *Variable) hcl.Diagnostics {
 		}
 	}
 
+	if ov.MergeDefaultSet || ov.Default != cty.NilVal || ov.Type != cty.NilType {
+		diags = append(diags, v.validateMergeDefault()...)
+	}
+
 	return diags
 }
 
@@ -191,6 +203,10 @@ func (mc *ModuleCall) merge(omc *ModuleCall) hcl.Diagnostics {
 		mc.Providers = omc.Providers
 	}
 
+	if len(omc.DestroyAfter) != 0 {
+		mc.DestroyAfter = omc.DestroyAfter
+	}
+
 	// We don't allow depends_on to be overridden because that is likely to
 	// cause confusing misbehavior.
 	if len(omc.DependsOn) != 0 {
@@ -252,11 +268,18 @@ func (r *Resource) merge(or *Resource, rps map[string]*RequiredProvider) hcl.Dia
 			r.Managed.PreventDestroy = or.Managed.PreventDestroy
 			r.Managed.PreventDestroySet = or.Managed.PreventDestroySet
 		}
+		if len(or.Managed.DestroyAfter) != 0 {
+			r.Managed.DestroyAfter = or.Managed.DestroyAfter
+		}
 		if len(or.Managed.Provisioners) != 0 {
 			r.Managed.Provisioners = or.Managed.Provisioners
 		}
 	}
 
+	if or.WaitFor != nil {
+		r.WaitFor = or.WaitFor
+	}
+
 	r.Config = MergeBodies(r.Config, or.Config)
 
 	// We don't allow depends_on to be overridden because that is likely to
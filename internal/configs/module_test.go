@@ -9,6 +9,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -418,3 +420,38 @@ func TestModule_cloud_duplicate_overrides(t *testing.T) {
 		t.Fatalf("expected module error to contain %q\nerror was:\n%s", want, got)
 	}
 }
+
+func TestModule_CheckCoreVersionRequirements_ignore(t *testing.T) {
+	parser := testParser(map[string]string{
+		"mod/main.tf": `
+terraform {
+  required_version = "< 0.0.1"
+}
+`,
+	})
+
+	mod, diags := parser.LoadConfigDir("mod", RootModuleCallForTesting())
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	diags = mod.CheckCoreVersionRequirements(nil, nil)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for the unmet version constraint")
+	}
+	if got := diags[0].Detail; !strings.Contains(got, CoreVersionRemediation) {
+		t.Errorf("error detail does not include the remediation hint: %s", got)
+	}
+
+	parser.IgnoreCoreVersionConstraints(true)
+	mod, diags = parser.LoadConfigDir("mod", RootModuleCallForTesting())
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	diags = mod.CheckCoreVersionRequirements(nil, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	if len(diags) != 1 || diags[0].Severity != hcl.DiagWarning {
+		t.Fatalf("expected a single warning, got %#v", diags)
+	}
+}
@@ -47,6 +47,12 @@ type Variable struct {
 	Nullable    bool
 	NullableSet bool
 
+	// MergeDefault indicates that a value given for this variable is merged
+	// with its default value: any object attributes that the given value
+	// omits or sets to null, at any depth, are taken from the default.
+	MergeDefault    bool
+	MergeDefaultSet bool
+
 	DeclRange hcl.Range
 }
 
@@ -176,6 +182,18 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 		v.Default = val
 	}
 
+	if attr, exists := content.Attributes["merge_default"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &v.MergeDefault)
+		diags = append(diags, valDiags...)
+		v.MergeDefaultSet = true
+	}
+
+	if !override {
+		// Override files may set merge_default and default separately, so
+		// we check them together after merging in that case.
+		diags = append(diags, v.validateMergeDefault()...)
+	}
+
 	for _, block := range content.Blocks {
 		switch block.Type {
 
@@ -279,6 +297,24 @@ func (v *Variable) Required() bool {
 	return v.Default == cty.NilVal
 }
 
+// validateMergeDefault returns an error if the variable merges its default
+// value, but doesn't have a default value that could be merged.
+func (v *Variable) validateMergeDefault() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if !v.MergeDefault {
+		return diags
+	}
+	if v.Default == cty.NilVal || v.Default.IsNull() || !v.Default.Type().IsObjectType() {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid merge_default for variable",
+			Detail:   "A variable can merge its default value only if the default value is an object.",
+			Subject:  v.DeclRange.Ptr(),
+		})
+	}
+	return diags
+}
+
 // VariableParsingMode defines how values of a particular variable given by
 // text-only mechanisms (command line arguments and environment variables)
 // should be parsed to produce the final value.
@@ -537,6 +573,9 @@ var variableBlockSchema = &hcl.BodySchema{
 		{
 			Name: "nullable",
 		},
+		{
+			Name: "merge_default",
+		},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -29,6 +29,10 @@ type Parser struct {
 	// for itself whether to enable it so that tests can cover both the
 	// allowed and not-allowed situations.
 	allowExperiments bool
+
+	// ignoreCoreVersionConstraints makes modules loaded by this parser
+	// report unmet OpenTofu version constraints as warnings.
+	ignoreCoreVersionConstraints bool
 }
 
 // NewParser creates and returns a new Parser that reads files from the given
@@ -123,3 +127,19 @@ func (p *Parser) ForceFileSource(filename string, src []byte) {
 func (p *Parser) AllowLanguageExperiments(allowed bool) {
 	p.allowExperiments = allowed
 }
+
+// IgnoreCoreVersionConstraints specifies whether modules loaded by subsequent
+// LoadConfigDir (and similar) calls report unmet OpenTofu version constraints
+// as warnings rather than errors.
+//
+// This is an escape hatch for emergencies, such as when a fix must be applied
+// with whichever version of OpenTofu is at hand.
+func (p *Parser) IgnoreCoreVersionConstraints(ignore bool) {
+	p.ignoreCoreVersionConstraints = ignore
+}
+
+// CoreVersionConstraintsIgnored returns the value most recently passed to
+// IgnoreCoreVersionConstraints.
+func (p *Parser) CoreVersionConstraintsIgnored() bool {
+	return p.ignoreCoreVersionConstraints
+}
@@ -62,6 +62,7 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 	var reqDiags hcl.Diagnostics
 	file.CoreVersionConstraints, reqDiags = sniffCoreVersionRequirements(body)
 	diags = append(diags, reqDiags...)
+	file.IgnoreCoreVersionConstraints = p.ignoreCoreVersionConstraints
 
 	// We'll load the experiments first because other decoding logic in the
 	// loop below might depend on these experiments.
@@ -83,6 +84,14 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 			// attributes here because sniffCoreVersionRequirements and
 			// sniffActiveExperiments already dealt with those above.
 
+			if attr, exists := content.Attributes["strict_conversions"]; exists {
+				strict, strictDiags := decodeStrictConversions(attr)
+				diags = append(diags, strictDiags...)
+				if strict != nil {
+					file.StrictConversions = append(file.StrictConversions, strict)
+				}
+			}
+
 			for _, innerBlock := range content.Blocks {
 				switch innerBlock.Type {
 
@@ -119,6 +128,11 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 						file.Encryptions = append(file.Encryptions, encryptionCfg)
 					}
 
+				case "diagnostics":
+					suppressions, suppressionDiags := decodeDiagnosticsBlock(innerBlock)
+					diags = append(diags, suppressionDiags...)
+					file.DiagnosticSuppressions = append(file.DiagnosticSuppressions, suppressions...)
+
 				default:
 					// Should never happen because the above cases should be exhaustive
 					// for all block type names in our schema.
@@ -321,6 +335,7 @@ var terraformBlockSchema = &hcl.BodySchema{
 		{Name: "required_version"},
 		{Name: "experiments"},
 		{Name: "language"},
+		{Name: "strict_conversions"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -340,6 +355,9 @@ var terraformBlockSchema = &hcl.BodySchema{
 		{
 			Type: "encryption",
 		},
+		{
+			Type: "diagnostics",
+		},
 	},
 }
 
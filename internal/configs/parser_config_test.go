@@ -134,6 +134,26 @@ func TestParserLoadConfigFileFailureMessages(t *testing.T) {
 			hcl.DiagError,
 			"Unsuitable value type",
 		},
+		{
+			"invalid-files/postcondition-retry-attempts.tf",
+			hcl.DiagError,
+			"Invalid retry attempts",
+		},
+		{
+			"invalid-files/precondition-retry.tf",
+			hcl.DiagError,
+			"Unsupported retry block",
+		},
+		{
+			"invalid-files/data-wait-for-ref.tf",
+			hcl.DiagError,
+			"Invalid reference in wait_for condition",
+		},
+		{
+			"invalid-files/variable-merge-default.tf",
+			hcl.DiagError,
+			"Invalid merge_default for variable",
+		},
 	}
 
 	for _, test := range tests {
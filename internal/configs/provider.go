@@ -49,6 +49,12 @@ type Provider struct {
 
 	ForEach   hcl.Expression
 	Instances map[addrs.InstanceKey]instances.RepetitionData
+
+	// PlanOnly, if set, is an expression that evaluates to a bool declaring
+	// whether this provider configuration may only be used for planning.
+	// OpenTofu then refuses to apply changes or import resources with it,
+	// even if its credentials would allow that.
+	PlanOnly hcl.Expression
 }
 
 func decodeProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
@@ -96,6 +102,7 @@ func decodeProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
 			Summary:  "Version constraints inside provider configuration blocks are deprecated",
 			Detail:   "OpenTofu 0.13 and earlier allowed provider version constraints inside the provider configuration block, but that is now deprecated and will be removed in a future version of OpenTofu. To silence this warning, move the provider version constraint into the required_providers block.",
 			Subject:  attr.Expr.Range().Ptr(),
+			Extra:    tfdiags.CodeDeprecatedProviderVersion,
 		})
 		var versionDiags hcl.Diagnostics
 		provider.Version, versionDiags = decodeVersionConstraint(attr)
@@ -106,6 +113,10 @@ func decodeProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
 		provider.ForEach = attr.Expr
 	}
 
+	if attr, exists := content.Attributes["plan_only"]; exists {
+		provider.PlanOnly = attr.Expr
+	}
+
 	if len(provider.Alias) == 0 && provider.ForEach != nil {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -302,6 +313,9 @@ var providerBlockSchema = &hcl.BodySchema{
 		{
 			Name: "for_each",
 		},
+		{
+			Name: "plan_only",
+		},
 
 		// Attribute names reserved for future expansion.
 		{Name: "count"},
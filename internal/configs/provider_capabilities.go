@@ -0,0 +1,89 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ProviderCapability is the name of an optional provider feature that a
+// module can require from the provider versions selected for it, using the
+// "capabilities" argument of a required_providers entry.
+type ProviderCapability string
+
+const (
+	// ProviderCapabilityFunctions requires the provider to offer
+	// provider-defined functions.
+	ProviderCapabilityFunctions ProviderCapability = "functions"
+
+	// ProviderCapabilityPlanDestroy requires the provider to take part in
+	// planning the destruction of its resources.
+	ProviderCapabilityPlanDestroy ProviderCapability = "plan_destroy"
+
+	// ProviderCapabilityOptionalSchema requires the provider to allow
+	// OpenTofu to reuse a cached copy of its schema.
+	ProviderCapabilityOptionalSchema ProviderCapability = "get_provider_schema_optional"
+)
+
+// providerCapabilities lists all of the valid ProviderCapability values, in
+// the order they are described in error messages.
+var providerCapabilities = []ProviderCapability{
+	ProviderCapabilityFunctions,
+	ProviderCapabilityPlanDestroy,
+	ProviderCapabilityOptionalSchema,
+}
+
+func decodeProviderCapabilities(expr hcl.Expression) ([]ProviderCapability, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	exprs, listDiags := hcl.ExprList(expr)
+	if listDiags.HasErrors() {
+		return nil, listDiags
+	}
+
+	var ret []ProviderCapability
+	for _, expr := range exprs {
+		val, valDiags := expr.Value(nil)
+		if valDiags.HasErrors() || !val.Type().Equals(cty.String) || val.IsNull() {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider capability",
+				Detail:   "Provider capabilities must be specified as static strings.",
+				Subject:  expr.Range().Ptr(),
+			})
+			continue
+		}
+
+		capability := ProviderCapability(val.AsString())
+		if !capability.valid() {
+			names := make([]string, len(providerCapabilities))
+			for i, c := range providerCapabilities {
+				names[i] = fmt.Sprintf("%q", c)
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider capability",
+				Detail:   fmt.Sprintf("Unsupported provider capability %q. The supported capabilities are %s.", capability, strings.Join(names, ", ")),
+				Subject:  expr.Range().Ptr(),
+			})
+			continue
+		}
+		ret = append(ret, capability)
+	}
+
+	return ret, diags
+}
+
+func (c ProviderCapability) valid() bool {
+	for _, valid := range providerCapabilities {
+		if c == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -25,6 +25,10 @@ type RequiredProvider struct {
 	Requirement VersionConstraint
 	DeclRange   hcl.Range
 	Aliases     []addrs.LocalProviderConfig
+
+	// Capabilities are the optional provider features that the module
+	// relies on, which the selected provider version must support.
+	Capabilities []ProviderCapability
 }
 
 type RequiredProviders struct {
@@ -212,11 +216,16 @@ func decodeRequiredProvidersBlock(block *hcl.Block) (*RequiredProviders, hcl.Dia
 					rp.Aliases = append(rp.Aliases, addr)
 				}
 
+			case "capabilities":
+				capabilities, capDiags := decodeProviderCapabilities(kv.Value)
+				diags = append(diags, capDiags...)
+				rp.Capabilities = capabilities
+
 			default:
 				diags = append(diags, &hcl.Diagnostic{
 					Severity: hcl.DiagError,
 					Summary:  "Invalid required_providers object",
-					Detail:   `required_providers objects can only contain "version", "source" and "configuration_aliases" attributes. To configure a provider, use a "provider" block.`,
+					Detail:   `required_providers objects can only contain "version", "source", "configuration_aliases" and "capabilities" attributes. To configure a provider, use a "provider" block.`,
 					Subject:  kv.Key.Range().Ptr(),
 				})
 				break LOOP
@@ -6,6 +6,7 @@
 package configs
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -35,6 +36,9 @@ var (
 		if x.DeclRange != y.DeclRange {
 			return false
 		}
+		if !slices.Equal(x.Capabilities, y.Capabilities) {
+			return false
+		}
 		return true
 	})
 	blockRange = hcl.Range{
@@ -322,6 +326,57 @@ func TestDecodeRequiredProvidersBlock(t *testing.T) {
 			},
 			Error: "Invalid required_providers object",
 		},
+		"capabilities": {
+			Block: &hcl.Block{
+				Type: "required_providers",
+				Body: hcltest.MockBody(&hcl.BodyContent{
+					Attributes: hcl.Attributes{
+						"my-test": {
+							Name: "my-test",
+							Expr: hcltest.MockExprLiteral(cty.ObjectVal(map[string]cty.Value{
+								"source":       cty.StringVal("mycloud/test"),
+								"capabilities": cty.ListVal([]cty.Value{cty.StringVal("functions"), cty.StringVal("plan_destroy")}),
+							})),
+						},
+					},
+				}),
+				DefRange: blockRange,
+			},
+			Want: &RequiredProviders{
+				RequiredProviders: map[string]*RequiredProvider{
+					"my-test": {
+						Name:         "my-test",
+						Source:       "mycloud/test",
+						Type:         addrs.NewProvider(addrs.DefaultProviderRegistryHost, "mycloud", "test"),
+						DeclRange:    mockRange,
+						Capabilities: []ProviderCapability{ProviderCapabilityFunctions, ProviderCapabilityPlanDestroy},
+					},
+				},
+				DeclRange: blockRange,
+			},
+		},
+		"invalid capability": {
+			Block: &hcl.Block{
+				Type: "required_providers",
+				Body: hcltest.MockBody(&hcl.BodyContent{
+					Attributes: hcl.Attributes{
+						"my-test": {
+							Name: "my-test",
+							Expr: hcltest.MockExprLiteral(cty.ObjectVal(map[string]cty.Value{
+								"source":       cty.StringVal("mycloud/test"),
+								"capabilities": cty.ListVal([]cty.Value{cty.StringVal("time_travel")}),
+							})),
+						},
+					},
+				}),
+				DefRange: blockRange,
+			},
+			Want: &RequiredProviders{
+				RequiredProviders: map[string]*RequiredProvider{},
+				DeclRange:         blockRange,
+			},
+			Error: "Invalid provider capability",
+		},
 	}
 
 	for name, test := range tests {
@@ -627,9 +627,10 @@ func validateProviderConfigs(parentCall *ModuleCall, cfg *Config, noProviderConf
 			Severity: hcl.DiagError,
 			Summary:  "Missing required provider configuration",
 			Detail: fmt.Sprintf(
-				"The child module requires an additional configuration for provider %s, with the local name %q.\n\nRefer to the module's documentation to understand the intended purpose of this additional provider configuration, and then add an entry for %s in the \"providers\" meta-argument in the module block to choose which provider configuration the module should use for that purpose.",
+				"The child module requires an additional configuration for provider %s, with the local name %q.\n\nRefer to the module's documentation to understand the intended purpose of this additional provider configuration, and then add an entry for %s in the \"providers\" meta-argument in the module block to choose which provider configuration the module should use for that purpose. For example:\n\n  module %q {\n    # ...\n    providers = {\n      %s = %s\n    }\n  }",
 				providerAddr.Provider.ForDisplay(), name,
 				name,
+				parentCall.Name, name, suggestParentProviderConfig(cfg.Parent.Module, providerAddr),
 			),
 			Subject: &parentCall.DeclRange,
 		})
@@ -667,7 +668,20 @@ func validateProviderConfigs(parentCall *ModuleCall, cfg *Config, noProviderConf
 		if !(localName || configAlias || emptyConfig) {
 
 			// we still allow default configs, so switch to a warning if the incoming provider is a default
-			if addrs.IsDefaultProvider(providerAddr.Provider) {
+			if addrs.IsDefaultProvider(providerAddr.Provider) && !providerConfigUsed(cfg, passed.InChild.Addr(), providerAddr.Provider) {
+				// Nothing in the child module refers to this configuration.
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Unused provider configuration entry",
+					Detail: fmt.Sprintf(
+						"The configuration for %s doesn't declare or use a provider configuration named %q, so this entry in the \"providers\" argument of the module %q block has no effect.\n\nRemove this entry, or if %s is meant to use this configuration, add to it a required_providers entry named %q.",
+						moduleText, name, parentCall.Name,
+						moduleText, passed.InChild.Name,
+					),
+					Subject: &passed.InChild.NameRange,
+				})
+				continue
+			} else if addrs.IsDefaultProvider(providerAddr.Provider) {
 				diags = append(diags, &hcl.Diagnostic{
 					Severity: hcl.DiagWarning,
 					Summary:  "Reference to undefined provider",
@@ -982,3 +996,69 @@ func providerIterationIdentical(a, b hcl.Expression) bool {
 
 	return false
 }
+
+// suggestParentProviderConfig returns a reference to a provider configuration
+// in the given parent module that could be passed to satisfy the given
+// configuration alias of a child module, to use in diagnostic messages. It
+// prefers a configuration with the same alias, and otherwise suggests the
+// default configuration of the same provider.
+func suggestParentProviderConfig(parent *Module, want addrs.AbsProviderConfig) string {
+	localName := parent.LocalNameForProvider(want.Provider)
+	if _, ok := parent.GetProviderConfig(localName, want.Alias); ok {
+		return localName + "." + want.Alias
+	}
+	if parent.ProviderRequirements == nil {
+		return localName
+	}
+	if req, ok := parent.ProviderRequirements.RequiredProviders[localName]; ok {
+		for _, alias := range req.Aliases {
+			if alias.Alias == want.Alias {
+				return alias.StringCompact()
+			}
+		}
+	}
+	return localName
+}
+
+// providerConfigUsed returns true if the given provider configuration of the
+// given module is used by any of its resources or module calls, or, for a
+// default configuration, if any resource in a descendant module might inherit
+// it.
+func providerConfigUsed(cfg *Config, addr addrs.LocalProviderConfig, provider addrs.Provider) bool {
+	if reqs := cfg.Module.ProviderRequirements; reqs != nil && reqs.RequiredProviders[addr.LocalName] != nil {
+		// Provider functions can refer to any configuration of a provider
+		// with a required_providers entry, and we don't track those.
+		return true
+	}
+	for _, mc := range cfg.Module.ModuleCalls {
+		for _, passed := range mc.Providers {
+			if passed.InParent.Addr() == addr {
+				return true
+			}
+		}
+	}
+
+	used := false
+	checkResources := func(c *Config, resources map[string]*Resource) {
+		for _, r := range resources {
+			if c == cfg && r.ProviderConfigAddr() == addr {
+				used = true
+			}
+			// Descendant modules can only inherit default configurations,
+			// which they find by provider type rather than by local name.
+			if c != cfg && addr.Alias == "" && r.Provider.Equals(provider) {
+				used = true
+			}
+		}
+	}
+	cfg.DeepEach(func(c *Config) {
+		checkResources(c, c.Module.ManagedResources)
+		checkResources(c, c.Module.DataResources)
+		for _, check := range c.Module.Checks {
+			if check.DataResource != nil {
+				checkResources(c, map[string]*Resource{"": check.DataResource})
+			}
+		}
+	})
+	return used
+}
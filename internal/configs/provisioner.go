@@ -7,8 +7,11 @@ package configs
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 // Provisioner represents a "provisioner" block when used within a
@@ -20,6 +23,10 @@ type Provisioner struct {
 	When       ProvisionerWhen
 	OnFailure  ProvisionerOnFailure
 
+	// RedactOutput are patterns for text to remove from the provisioner's
+	// output, and from its error messages, before they are shown anywhere.
+	RedactOutput []*regexp.Regexp
+
 	DeclRange hcl.Range
 	TypeRange hcl.Range
 }
@@ -90,6 +97,12 @@ func decodeProvisionerBlock(block *hcl.Block) (*Provisioner, hcl.Diagnostics) {
 		}
 	}
 
+	if attr, exists := content.Attributes["redact_output"]; exists {
+		patterns, patternDiags := decodeRedactOutput(attr)
+		diags = append(diags, patternDiags...)
+		pv.RedactOutput = patterns
+	}
+
 	var seenConnection *hcl.Block
 	var seenEscapeBlock *hcl.Block
 	for _, block := range content.Blocks {
@@ -151,6 +164,51 @@ func decodeProvisionerBlock(block *hcl.Block) (*Provisioner, hcl.Diagnostics) {
 	return pv, diags
 }
 
+// decodeRedactOutput decodes the redact_output argument of a provisioner
+// block, which must be a constant list of regular expressions so that the
+// patterns are known before any provisioner runs.
+func decodeRedactOutput(attr *hcl.Attribute) ([]*regexp.Regexp, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	val, valDiags := attr.Expr.Value(nil)
+	diags = append(diags, valDiags...)
+	if valDiags.HasErrors() {
+		return nil, diags
+	}
+	val, err := convert.Convert(val, cty.List(cty.String))
+	if err != nil || !val.IsWhollyKnown() {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid \"redact_output\" argument",
+			Detail:   "The \"redact_output\" argument must be a list of regular expressions, given as strings.",
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+		return nil, diags
+	}
+	if val.IsNull() {
+		return nil, diags
+	}
+
+	var patterns []*regexp.Regexp
+	for _, v := range val.AsValueSlice() {
+		if v.IsNull() {
+			continue
+		}
+		pattern, err := regexp.Compile(v.AsString())
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"redact_output\" pattern",
+				Detail:   fmt.Sprintf("The pattern %q is not a valid regular expression: %s.", v.AsString(), err),
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, diags
+}
+
 func onlySelfRefs(body hcl.Body) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 
@@ -232,6 +290,7 @@ var provisionerBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		{Name: "when"},
 		{Name: "on_failure"},
+		{Name: "redact_output"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "_"}, // meta-argument escaping block
@@ -12,6 +12,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hcltest"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestProvisionerBlock_decode(t *testing.T) {
@@ -121,3 +122,47 @@ func TestProvisionerBlock_decode(t *testing.T) {
 		})
 	}
 }
+
+func TestProvisionerBlock_decodeRedactOutput(t *testing.T) {
+	block := func(patterns cty.Value) *hcl.Block {
+		return &hcl.Block{
+			Type:   "provisioner",
+			Labels: []string{"remote-exec"},
+			Body: hcltest.MockBody(&hcl.BodyContent{
+				Attributes: hcl.Attributes{
+					"redact_output": {
+						Name: "redact_output",
+						Expr: hcltest.MockExprLiteral(patterns),
+					},
+				},
+			}),
+			DefRange:    blockRange,
+			LabelRanges: []hcl.Range{{}},
+		}
+	}
+
+	got, diags := decodeProvisionerBlock(block(cty.TupleVal([]cty.Value{
+		cty.StringVal(`AKIA[0-9A-Z]{16}`),
+		cty.StringVal(`(?i)password=\S+`),
+	})))
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Errs())
+	}
+	var patterns []string
+	for _, pattern := range got.RedactOutput {
+		patterns = append(patterns, pattern.String())
+	}
+	if diff := cmp.Diff([]string{`AKIA[0-9A-Z]{16}`, `(?i)password=\S+`}, patterns); diff != "" {
+		t.Errorf("wrong patterns\n%s", diff)
+	}
+
+	_, diags = decodeProvisionerBlock(block(cty.TupleVal([]cty.Value{cty.StringVal(`(unclosed`)})))
+	if !diags.HasErrors() || diags[0].Summary != `Invalid "redact_output" pattern` {
+		t.Errorf("expected an invalid pattern error, got %s", diags.Errs())
+	}
+
+	_, diags = decodeProvisionerBlock(block(cty.StringVal(`password`)))
+	if !diags.HasErrors() || diags[0].Summary != `Invalid "redact_output" argument` {
+		t.Errorf("expected an invalid argument error, got %s", diags.Errs())
+	}
+}
@@ -35,6 +35,10 @@ type Resource struct {
 	Preconditions  []*CheckRule
 	Postconditions []*CheckRule
 
+	// WaitFor is populated only for data resources that have a "wait_for"
+	// block. It is always nil for managed resources.
+	WaitFor *DataWaitFor
+
 	DependsOn []hcl.Traversal
 
 	TriggersReplacement []hcl.Expression
@@ -73,6 +77,11 @@ type ManagedResource struct {
 	IgnoreChanges       []hcl.Traversal
 	IgnoreAllChanges    bool
 
+	// DestroyAfter are references to managed resources or module calls in
+	// the same module whose objects must be destroyed before the objects of
+	// this resource.
+	DestroyAfter []hcl.Traversal
+
 	CreateBeforeDestroySet bool
 	PreventDestroySet      bool
 }
@@ -210,6 +219,12 @@ func decodeResourceBlock(block *hcl.Block, override bool) (*Resource, hcl.Diagno
 				r.Managed.PreventDestroySet = true
 			}
 
+			if attr, exists := lcContent.Attributes["destroy_after"]; exists {
+				deps, depsDiags := decodeDestroyAfter(attr)
+				diags = append(diags, depsDiags...)
+				r.Managed.DestroyAfter = append(r.Managed.DestroyAfter, deps...)
+			}
+
 			if attr, exists := lcContent.Attributes["replace_triggered_by"]; exists {
 				exprs, hclDiags := decodeReplaceTriggeredBy(attr.Expr)
 				diags = diags.Extend(hclDiags)
@@ -452,6 +467,29 @@ func decodeDataBlock(block *hcl.Block, override, nested bool) (*Resource, hcl.Di
 	for _, block := range content.Blocks {
 		switch block.Type {
 
+		case "wait_for":
+			if nested {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid wait_for block",
+					Detail:   `Nested data blocks do not support "wait_for" blocks. Use assertions in the containing check block instead.`,
+					Subject:  block.DefRange.Ptr(),
+				})
+				continue
+			}
+			if r.WaitFor != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate wait_for block",
+					Detail:   fmt.Sprintf("This data resource already has a wait_for block at %s.", r.WaitFor.DeclRange),
+					Subject:  block.DefRange.Ptr(),
+				})
+				continue
+			}
+			waitFor, moreDiags := decodeDataWaitForBlock(block)
+			diags = append(diags, moreDiags...)
+			r.WaitFor = waitFor
+
 		case "_":
 			if seenEscapeBlock != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -869,6 +907,7 @@ var dataBlockSchema = &hcl.BodySchema{
 	Attributes: commonResourceAttributes,
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "lifecycle"},
+		{Type: "wait_for"},
 		{Type: "locals"}, // reserved for future use
 		{Type: "_"},      // meta-argument escaping block
 	},
@@ -892,6 +931,9 @@ var resourceLifecycleBlockSchema = &hcl.BodySchema{
 		{
 			Name: "replace_triggered_by",
 		},
+		{
+			Name: "destroy_after",
+		},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "precondition"},
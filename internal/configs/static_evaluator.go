@@ -83,6 +83,19 @@ func NewStaticEvaluator(mod *Module, call StaticModuleCall) *StaticEvaluator {
 	}
 }
 
+// Workspace returns the name of the workspace the module is being evaluated in.
+func (s *StaticEvaluator) Workspace() string {
+	return s.call.workspace
+}
+
+// ForWorkspace returns a static evaluator for the same module and module call
+// that evaluates the module as if the given workspace were selected.
+func (s *StaticEvaluator) ForWorkspace(workspace string) *StaticEvaluator {
+	call := s.call
+	call.workspace = workspace
+	return NewStaticEvaluator(s.cfg, call)
+}
+
 func (s *StaticEvaluator) scope(ident StaticIdentifier) *lang.Scope {
 	return newStaticScope(s, ident)
 }
@@ -282,6 +282,14 @@ resource "foo" "bar" {}
 		if value.AsString() != "my-workspace" {
 			t.Errorf("Expected %s got %s", "my-workspace", value.AsString())
 		}
+
+		value, diags = eval.ForWorkspace("other").Evaluate(mod.Locals["ws"].Expr, dummyIdentifier)
+		if diags.HasErrors() {
+			t.Error(diags)
+		}
+		if value.AsString() != "other" {
+			t.Errorf("Expected %s got %s", "other", value.AsString())
+		}
 	})
 
 	t.Run("Functions", func(t *testing.T) {
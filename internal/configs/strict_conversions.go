@@ -0,0 +1,53 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// StrictConversions represents the strict_conversions argument of a
+// "terraform" block, which makes OpenTofu report the values in a module that
+// it implicitly converts between the string, number and bool types.
+type StrictConversions struct {
+	// Severity is the severity of the diagnostics reported for each implicit
+	// conversion: hcl.DiagWarning for "warn" or hcl.DiagError for "error".
+	Severity hcl.DiagnosticSeverity
+
+	DeclRange hcl.Range
+}
+
+func decodeStrictConversions(attr *hcl.Attribute) (*StrictConversions, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	val, valDiags := attr.Expr.Value(nil)
+	diags = append(diags, valDiags...)
+	if valDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := &StrictConversions{
+		DeclRange: attr.Range,
+	}
+	if !val.IsNull() && val.Type() == cty.String {
+		switch val.AsString() {
+		case "warn":
+			ret.Severity = hcl.DiagWarning
+			return ret, diags
+		case "error":
+			ret.Severity = hcl.DiagError
+			return ret, diags
+		}
+	}
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid strict_conversions setting",
+		Detail:   fmt.Sprintf("The strict_conversions argument must be either %q, to report implicit type conversions as warnings, or %q, to report them as errors.", "warn", "error"),
+		Subject:  attr.Expr.Range().Ptr(),
+	})
+	return nil, diags
+}
@@ -0,0 +1,82 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestStrictConversions(t *testing.T) {
+	tests := map[string]struct {
+		files     map[string]string
+		want      hcl.DiagnosticSeverity
+		wantError string
+	}{
+		"unset": {
+			files: map[string]string{
+				"main.tf": `terraform {}`,
+			},
+			want: hcl.DiagInvalid,
+		},
+		"warn": {
+			files: map[string]string{
+				"main.tf": `terraform { strict_conversions = "warn" }`,
+			},
+			want: hcl.DiagWarning,
+		},
+		"error": {
+			files: map[string]string{
+				"main.tf": `terraform { strict_conversions = "error" }`,
+			},
+			want: hcl.DiagError,
+		},
+		"override": {
+			files: map[string]string{
+				"main.tf":     `terraform { strict_conversions = "warn" }`,
+				"override.tf": `terraform { strict_conversions = "error" }`,
+			},
+			want: hcl.DiagError,
+		},
+		"invalid": {
+			files: map[string]string{
+				"main.tf": `terraform { strict_conversions = true }`,
+			},
+			wantError: "Invalid strict_conversions setting",
+		},
+		"duplicate": {
+			files: map[string]string{
+				"a.tf": `terraform { strict_conversions = "warn" }`,
+				"b.tf": `terraform { strict_conversions = "error" }`,
+			},
+			wantError: "Duplicate strict_conversions setting",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parser := testParser(test.files)
+			mod, diags := parser.LoadConfigDir(".", RootModuleCallForTesting())
+			if test.wantError != "" {
+				if !diags.HasErrors() || !strings.Contains(diags.Error(), test.wantError) {
+					t.Fatalf("wrong diagnostics\ngot:  %s\nwant: %s", diags.Error(), test.wantError)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			got := hcl.DiagInvalid
+			if mod.StrictConversions != nil {
+				got = mod.StrictConversions.Severity
+			}
+			if got != test.want {
+				t.Errorf("wrong severity %v; want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package configs
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
@@ -26,6 +27,13 @@ type TestCommand rune
 // block, normal or refresh-only. Defaults to normal.
 type TestMode rune
 
+// TestStateBackend represents where OpenTofu keeps the state produced by a
+// given run block, memory or a temporary directory. Defaults to memory.
+//
+// The test command never uses the backend configured for the module under
+// test, regardless of this setting.
+type TestStateBackend rune
+
 const (
 	// ApplyTestCommand causes the run block to execute a OpenTofu apply
 	// operation.
@@ -35,6 +43,15 @@ const (
 	// operation.
 	PlanTestCommand TestCommand = 'P'
 
+	// MemoryTestStateBackend causes the state produced by the run block to be
+	// kept only in memory.
+	MemoryTestStateBackend TestStateBackend = 0
+
+	// TemporaryDirectoryTestStateBackend causes the state produced by the run
+	// block to be written to a state file in a temporary directory, which is
+	// removed when the test file completes.
+	TemporaryDirectoryTestStateBackend TestStateBackend = 'T'
+
 	// NormalTestMode causes the run block to execute in plans.NormalMode.
 	NormalTestMode TestMode = 0
 
@@ -128,6 +145,11 @@ type TestRun struct {
 	// One of ['apply', 'plan'].
 	Command TestCommand
 
+	// StateBackend is where OpenTofu keeps the state produced by this run.
+	//
+	// One of ['memory', 'temporary_directory'].
+	StateBackend TestStateBackend
+
 	// Options contains the embedded plan options that will affect the given
 	// Command. These should map to the options documented here:
 	//   - https://opentofu.org/docs/cli/commands/plan/#planning-options
@@ -176,6 +198,10 @@ type TestRun struct {
 	// run.
 	ExpectFailures []hcl.Traversal
 
+	// ExpectApplyErrors is a list of errors that providers are expected to
+	// return while applying the changes for this test run.
+	ExpectApplyErrors []*TestRunExpectedError
+
 	// OverrideResources is a list of resources to be overridden with static values.
 	// Underlying providers shouldn't be called for overridden resources.
 	OverrideResources []*OverrideResource
@@ -219,6 +245,45 @@ func (run *TestRun) Validate() tfdiags.Diagnostics {
 
 	}
 
+	for _, expected := range run.ExpectApplyErrors {
+		if run.Command == PlanTestCommand {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid `expect_apply_error` block",
+				Detail:   "Apply errors can only be expected from run blocks that use `command = apply`.",
+				Subject:  expected.DeclRange.Ptr(),
+			})
+			continue
+		}
+
+		if expected.Address == nil {
+			continue
+		}
+
+		reference, refDiags := addrs.ParseRefFromTestingScope(expected.Address)
+		diags = diags.Append(refDiags)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		switch subject := reference.Subject.(type) {
+		case addrs.Resource:
+			if subject.Mode == addrs.ManagedResourceMode {
+				continue
+			}
+		case addrs.ResourceInstance:
+			if subject.Resource.Mode == addrs.ManagedResourceMode {
+				continue
+			}
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid `expect_apply_error` address",
+			Detail:   fmt.Sprintf("You cannot expect apply errors from %s. You can only expect apply errors from managed resources.", reference.Subject.String()),
+			Subject:  reference.SourceRange.ToHCL().Ptr(),
+		})
+	}
+
 	// It's not allowed to have multiple `override_resource`, `override_data` or `override_module` blocks
 	// inside a single run block with the same target address so we want to ensure there's no such cases.
 	diags = diags.Append(checkForDuplicatedOverrideResources(run.OverrideResources))
@@ -227,6 +292,23 @@ func (run *TestRun) Validate() tfdiags.Diagnostics {
 	return diags
 }
 
+// TestRunExpectedError describes an error that a provider is expected to
+// return while applying the changes for a run block.
+//
+// An expected error matches any error diagnostic returned for a resource
+// instance that satisfies all of the criteria that are set.
+type TestRunExpectedError struct {
+	// Address is a reference to the managed resource or resource instance that
+	// the error is expected from, or nil to match an error from any resource.
+	Address hcl.Traversal
+
+	// Message is a regular expression that must match either the summary or
+	// the detail of the error, or nil to match any error message.
+	Message *regexp.Regexp
+
+	DeclRange hcl.Range
+}
+
 // TestRunModuleCall specifies which module should be executed by a given run
 // block.
 type TestRunModuleCall struct {
@@ -558,6 +640,12 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 			for _, v := range vars {
 				r.Variables[v.Name] = v.Expr
 			}
+		case "expect_apply_error":
+			expected, expectedDiags := decodeTestRunExpectedErrorBlock(block)
+			diags = append(diags, expectedDiags...)
+			if !expectedDiags.HasErrors() {
+				r.ExpectApplyErrors = append(r.ExpectApplyErrors, expected)
+			}
 		case "module":
 			if r.Module != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -624,6 +712,22 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 		r.Command = ApplyTestCommand // Default to apply
 	}
 
+	if attr, exists := content.Attributes["state_backend"]; exists {
+		switch hcl.ExprAsKeyword(attr.Expr) {
+		case "memory":
+			r.StateBackend = MemoryTestStateBackend
+		case "temporary_directory":
+			r.StateBackend = TemporaryDirectoryTestStateBackend
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"state_backend\" keyword",
+				Detail:   "The \"state_backend\" argument requires one of the following keywords without quotes: memory or temporary_directory.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
 	if attr, exists := content.Attributes["providers"]; exists {
 		providers, providerDiags := decodePassedProviderConfigs(attr)
 		diags = append(diags, providerDiags...)
@@ -639,6 +743,46 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 	return &r, diags
 }
 
+func decodeTestRunExpectedErrorBlock(block *hcl.Block) (*TestRunExpectedError, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, contentDiags := block.Body.Content(testRunExpectedErrorBlockSchema)
+	diags = append(diags, contentDiags...)
+
+	expected := TestRunExpectedError{
+		DeclRange: block.DefRange,
+	}
+
+	if attr, exists := content.Attributes["address"]; exists {
+		traversal, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
+		diags = append(diags, traversalDiags...)
+		if !traversalDiags.HasErrors() {
+			expected.Address = traversal
+		}
+	}
+
+	if attr, exists := content.Attributes["message"]; exists {
+		var pattern string
+		patternDiags := gohcl.DecodeExpression(attr.Expr, nil, &pattern)
+		diags = append(diags, patternDiags...)
+		if !patternDiags.HasErrors() {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid \"message\" pattern",
+					Detail:   fmt.Sprintf("The \"message\" argument must be a valid regular expression: %s.", err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				expected.Message = re
+			}
+		}
+	}
+
+	return &expected, diags
+}
+
 func decodeTestRunModuleBlock(block *hcl.Block) (*TestRunModuleCall, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
@@ -1076,6 +1220,8 @@ var testRunBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		// command specifies the shell command or script to execute during the test.
 		{Name: "command"},
+		// state_backend specifies where the state produced by the run is kept.
+		{Name: "state_backend"},
 		// providers defines the list of infrastructure providers used during the test.
 		{Name: "providers"},
 		// expect_failures indicates whether test failures are expected.
@@ -1098,6 +1244,11 @@ var testRunBlockSchema = &hcl.BodySchema{
 			// module block specifies the module to be tested.
 			Type: "module",
 		},
+		{
+			// expect_apply_error block describes an error that a provider is
+			// expected to return during the apply.
+			Type: "expect_apply_error",
+		},
 		{
 			Type: blockNameOverrideResource,
 		},
@@ -1125,6 +1276,17 @@ var testRunOptionsBlockSchema = &hcl.BodySchema{
 	},
 }
 
+// testRunExpectedErrorBlockSchema defines the structure of the
+// expect_apply_error block within a test run.
+var testRunExpectedErrorBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		// address refers to the resource the error is expected from.
+		{Name: "address"},
+		// message is a regular expression matching the error message.
+		{Name: "message"},
+	},
+}
+
 // testRunModuleBlockSchema defines the structure of the module block within a test run,
 // including attributes for the module's source and version.
 var testRunModuleBlockSchema = &hcl.BodySchema{
@@ -11,6 +11,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/opentofu/opentofu/internal/addrs"
 )
 
 func TestTestRun_Validate(t *testing.T) {
@@ -97,3 +99,160 @@ func parseTraversal(t *testing.T, addr string) hcl.Traversal {
 	}
 	return traversal
 }
+
+func TestTestRun_StateBackend(t *testing.T) {
+	tcs := map[string]struct {
+		src       string
+		want      TestStateBackend
+		wantError string
+	}{
+		"default": {
+			src:  `run "test" {}`,
+			want: MemoryTestStateBackend,
+		},
+		"memory": {
+			src:  `run "test" { state_backend = memory }`,
+			want: MemoryTestStateBackend,
+		},
+		"temporary_directory": {
+			src:  `run "test" { state_backend = temporary_directory }`,
+			want: TemporaryDirectoryTestStateBackend,
+		},
+		"invalid": {
+			src:       `run "test" { state_backend = "s3" }`,
+			wantError: `Invalid "state_backend" keyword`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			parser := testParser(map[string]string{
+				"main.tftest.hcl": tc.src,
+			})
+			file, diags := parser.LoadTestFile("main.tftest.hcl")
+
+			if tc.wantError != "" {
+				if !diags.HasErrors() {
+					t.Fatal("expected error, got none")
+				}
+				if got := diags[0].Summary; got != tc.wantError {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, tc.wantError)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			if got := file.Runs[0].StateBackend; got != tc.want {
+				t.Errorf("wrong state backend %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTestRun_ExpectApplyErrors(t *testing.T) {
+	tcs := map[string]struct {
+		src         string
+		wantAddress string
+		wantMessage string
+		wantError   string
+	}{
+		"address_and_message": {
+			src: `run "test" {
+  expect_apply_error {
+    address = test_resource.a
+    message = "^invalid"
+  }
+}`,
+			wantAddress: "test_resource.a",
+			wantMessage: "^invalid",
+		},
+		"message_only": {
+			src: `run "test" {
+  expect_apply_error {
+    message = "quota"
+  }
+}`,
+			wantMessage: "quota",
+		},
+		"invalid_message": {
+			src: `run "test" {
+  expect_apply_error {
+    message = "("
+  }
+}`,
+			wantError: `Invalid "message" pattern`,
+		},
+		"plan_command": {
+			src: `run "test" {
+  command = plan
+
+  expect_apply_error {
+    message = "quota"
+  }
+}`,
+			wantError: "Invalid `expect_apply_error` block",
+		},
+		"data_source": {
+			src: `run "test" {
+  expect_apply_error {
+    address = data.test_data_source.a
+  }
+}`,
+			wantError: "Invalid `expect_apply_error` address",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			parser := testParser(map[string]string{
+				"main.tftest.hcl": tc.src,
+			})
+			file, hclDiags := parser.LoadTestFile("main.tftest.hcl")
+			if hclDiags.HasErrors() {
+				if tc.wantError == "" {
+					t.Fatal(hclDiags.Error())
+				}
+				if got := hclDiags[0].Summary; got != tc.wantError {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, tc.wantError)
+				}
+				return
+			}
+
+			run := file.Runs[0]
+			diags := run.Validate()
+			if tc.wantError != "" {
+				if !diags.HasErrors() {
+					t.Fatal("expected error, got none")
+				}
+				if got := diags[0].Description().Summary; got != tc.wantError {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, tc.wantError)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags.Err())
+			}
+
+			if len(run.ExpectApplyErrors) != 1 {
+				t.Fatalf("expected 1 expected error, got %d", len(run.ExpectApplyErrors))
+			}
+			expected := run.ExpectApplyErrors[0]
+
+			var gotAddress string
+			if expected.Address != nil {
+				ref, refDiags := addrs.ParseRefFromTestingScope(expected.Address)
+				if refDiags.HasErrors() {
+					t.Fatal(refDiags.Err())
+				}
+				gotAddress = ref.Subject.String()
+			}
+			if gotAddress != tc.wantAddress {
+				t.Errorf("wrong address %q; want %q", gotAddress, tc.wantAddress)
+			}
+			if got := expected.Message.String(); got != tc.wantMessage {
+				t.Errorf("wrong message %q; want %q", got, tc.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    foo = {
+      source = "hashicorp/foo"
+    }
+  }
+}
+
+provider "foo" {
+}
+
+provider "foo" {
+  alias = "east"
+}
+
+module "mod" {
+  source = "./mod"
+  providers = {
+    foo      = foo
+    foo.west = foo.east
+    bar      = foo
+  }
+}
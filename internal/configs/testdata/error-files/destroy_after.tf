@@ -0,0 +1,16 @@
+resource "aws_iam_role" "a" {
+  lifecycle {
+    destroy_after = [
+      data.aws_iam_policy.b, # ERROR: Invalid destroy_after reference
+      var.c, # ERROR: Invalid destroy_after reference
+    ]
+  }
+}
+
+module "d" {
+  source = "./d"
+
+  lifecycle {
+    destroy_after = [aws_iam_role.a.arn] # ERROR: Invalid destroy_after reference
+  }
+}
@@ -0,0 +1,9 @@
+variable "want" {
+  type = string
+}
+
+data "example" "example" {
+  wait_for {
+    condition = self.status == var.want
+  }
+}
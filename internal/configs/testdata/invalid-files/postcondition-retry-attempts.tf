@@ -0,0 +1,12 @@
+data "example" "example" {
+  lifecycle {
+    postcondition {
+      condition     = self.ready
+      error_message = "Must be ready."
+
+      retry {
+        attempts = 0
+      }
+    }
+  }
+}
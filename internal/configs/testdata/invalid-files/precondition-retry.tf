@@ -0,0 +1,12 @@
+resource "example" "example" {
+  lifecycle {
+    precondition {
+      condition     = path.module != ""
+      error_message = "Must be true."
+
+      retry {
+        attempts = 3
+      }
+    }
+  }
+}
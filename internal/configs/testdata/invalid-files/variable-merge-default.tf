@@ -0,0 +1,5 @@
+variable "names" {
+  type          = list(string)
+  default       = ["a"]
+  merge_default = true
+}
@@ -13,3 +13,14 @@ data "http" "example2" {
     data.http.example1,
   ]
 }
+
+data "http" "example3" {
+  url = "http://example.com/status"
+
+  wait_for {
+    condition = self.status_code == 200
+    timeout   = "10m"
+    interval  = "10s"
+    backoff   = 1.5
+  }
+}
@@ -0,0 +1,23 @@
+resource "aws_iam_role" "a" {
+  lifecycle {
+    destroy_after = [
+      aws_iam_role_policy_attachment.b,
+      module.c,
+    ]
+  }
+}
+
+resource "aws_iam_role_policy_attachment" "b" {
+}
+
+module "c" {
+  source = "./c"
+}
+
+module "d" {
+  source = "./d"
+
+  lifecycle {
+    destroy_after = [module.c]
+  }
+}
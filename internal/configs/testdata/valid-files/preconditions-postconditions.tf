@@ -20,6 +20,11 @@ data "test" "test" {
     postcondition {
       condition     = path.module != ""
       error_message = "Must be true."
+
+      retry {
+        attempts = 5
+        interval = "10s"
+      }
     }
   }
 }
@@ -20,7 +20,8 @@ resource "aws_security_group" "firewall" {
   }
 
   provisioner "local-exec" {
-    command = "echo hello"
+    command       = "echo hello"
+    redact_output = ["token=\\S+"]
   }
 }
 
@@ -42,3 +42,15 @@ variable "nullable_default_null" {
   nullable = true
   default = null
 }
+
+variable "merged" {
+  type = object({
+    name = optional(string)
+    size = optional(number)
+  })
+  default = {
+    name = "example"
+    size = 1
+  }
+  merge_default = true
+}
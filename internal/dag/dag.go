@@ -122,10 +122,22 @@ func (g *AcyclicGraph) Validate() error {
 	if _, err := g.Root(); err != nil {
 		return err
 	}
+	return g.validateCycles(g.Cycles())
+}
+
+// ValidateWithCycles is like Validate, but uses the given cycles, as
+// returned by Cycles, rather than finding them again. This saves the cost of
+// a second search when the caller needs the cycles too.
+func (g *AcyclicGraph) ValidateWithCycles(cycles [][]Vertex) error {
+	if _, err := g.Root(); err != nil {
+		return err
+	}
+	return g.validateCycles(cycles)
+}
 
+func (g *AcyclicGraph) validateCycles(cycles [][]Vertex) error {
 	// Look for cycles of more than 1 component
 	var err error
-	cycles := g.Cycles()
 	if len(cycles) > 0 {
 		for _, cycle := range cycles {
 			cycleStr := make([]string, len(cycle))
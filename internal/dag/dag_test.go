@@ -230,6 +230,28 @@ func TestAcyclicGraphValidate_cycleSelf(t *testing.T) {
 	}
 }
 
+func TestAcyclicGraphValidateWithCycles(t *testing.T) {
+	var g AcyclicGraph
+	g.Add(1)
+	g.Add(2)
+	g.Add(3)
+	g.Connect(BasicEdge(3, 2))
+	g.Connect(BasicEdge(3, 1))
+	g.Connect(BasicEdge(1, 2))
+	g.Connect(BasicEdge(2, 1))
+
+	cycles := g.Cycles()
+	if len(cycles) != 1 {
+		t.Fatalf("wrong number of cycles %d; want 1", len(cycles))
+	}
+	if err := g.ValidateWithCycles(cycles); err == nil {
+		t.Fatal("should error")
+	}
+	if err := g.ValidateWithCycles(nil); err != nil {
+		t.Fatalf("unexpected error without cycles: %s", err)
+	}
+}
+
 func TestAcyclicGraphAncestors(t *testing.T) {
 	var g AcyclicGraph
 	g.Add(1)
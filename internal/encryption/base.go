@@ -28,21 +28,29 @@ type baseEncryption struct {
 	target        *config.TargetConfig
 	enforced      bool
 	name          string
+	methodCtx     method.Context
 	encMethods    []method.Method
 	inputEncMeta  map[keyprovider.MetaStorageKey][]byte
 	outputEncMeta map[keyprovider.MetaStorageKey][]byte
 	staticEval    *configs.StaticEvaluator
+
+	// decryptionCache holds the methods built for decrypting data, so that the keys don't need to be provided again
+	// when decrypting data with the same metadata.
+	decryptionCache *decryptionCache
 }
 
-func newBaseEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, staticEval *configs.StaticEvaluator) (*baseEncryption, hcl.Diagnostics) {
+func newBaseEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, methodCtx method.Context, staticEval *configs.StaticEvaluator) (*baseEncryption, hcl.Diagnostics) {
 	base := &baseEncryption{
 		enc:           enc,
 		target:        target,
 		enforced:      enforced,
 		name:          name,
+		methodCtx:     methodCtx,
 		inputEncMeta:  make(map[keyprovider.MetaStorageKey][]byte),
 		outputEncMeta: make(map[keyprovider.MetaStorageKey][]byte),
 		staticEval:    staticEval,
+
+		decryptionCache: newDecryptionCache(decryptionCacheTTL),
 	}
 	// Setup the encryptor
 	//
@@ -169,23 +177,16 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 		// Decrypted and pending migration
 		return data, StatusMigration, nil
 	}
-	// This is not actually used, only the map inside the Meta parameter is. This is because we are passing the map
-	// around.
-	outputData := basedata{
-		Meta: make(map[keyprovider.MetaStorageKey][]byte),
+	if inputData.Version != encryptionVersion && inputData.Version != encryptionVersionStream {
+		return nil, StatusUnknown, fmt.Errorf("invalid encrypted payload version: %s is not one of %s, %s", inputData.Version, encryptionVersion, encryptionVersionStream)
 	}
 
-	if inputData.Version != encryptionVersion {
-		return nil, StatusUnknown, fmt.Errorf("invalid encrypted payload version: %s != %s", inputData.Version, encryptionVersion)
-	}
-
-	// TODO Discuss if we should potentially cache this based on a json-encoded version of inputData.Meta and reduce overhead dramatically
-	methods, diags := base.buildTargetMethods(inputData.Meta, outputData.Meta)
-	if diags.HasErrors() {
-		// This cast to error here is safe as we know that at least one error exists
-		// This is also quite unlikely to happen as the constructor already has checked this code path
-		return nil, StatusUnknown, diags
+	cached, err := base.decryptionMethods(inputData.Meta)
+	if err != nil {
+		return nil, StatusUnknown, err
 	}
+	defer base.decryptionCache.release(cached)
+	methods := cached.methods
 
 	errs := make([]error, 0)
 	for i, method := range methods {
@@ -193,7 +194,7 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 			// Not applicable
 			continue
 		}
-		uncd, err := method.Decrypt(inputData.Data)
+		uncd, err := decryptData(method, inputData.Version, inputData.Data)
 		if err == nil {
 			// Success
 			if i == 0 {
@@ -216,3 +217,25 @@ func (base *baseEncryption) decrypt(data []byte, validator func([]byte) error) (
 	}
 	return nil, StatusUnknown, errors.New(errMessage)
 }
+
+// decryptionMethods returns the methods for decrypting data with the given metadata. Building the methods calls the
+// key providers, which may derive keys or call a key management system. The result only depends on the metadata, so
+// we reuse it for a while when the same data is decrypted repeatedly.
+//
+// The caller must release the returned entry once it no longer uses the methods.
+func (base *baseEncryption) decryptionMethods(meta map[keyprovider.MetaStorageKey][]byte) (*decryptionCacheEntry, error) {
+	cacheKey, err := decryptionCacheKey(meta)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode encryption metadata as json: %w", err)
+	}
+	if cached, ok := base.decryptionCache.get(cacheKey); ok {
+		return cached, nil
+	}
+	methods, diags := base.buildTargetMethods(meta, make(map[keyprovider.MetaStorageKey][]byte))
+	if diags.HasErrors() {
+		// This cast to error here is safe as we know that at least one error exists
+		// This is also quite unlikely to happen as the constructor already has checked this code path
+		return nil, diags
+	}
+	return base.decryptionCache.put(cacheKey, methods), nil
+}
@@ -14,6 +14,9 @@ import (
 // EncryptionConfig describes the terraform.encryption HCL block you can use to configure the state and plan encryption.
 // The individual fields of this struct match the HCL structure directly.
 type EncryptionConfig struct {
+	// Enforced makes encryption mandatory for all targets: any unencrypted state or plan read or write is an error.
+	Enforced bool `hcl:"enforced,optional"`
+
 	KeyProviderConfigs []KeyProviderConfig `hcl:"key_provider,block"`
 	MethodConfigs      []MethodConfig      `hcl:"method,block"`
 
@@ -45,10 +48,12 @@ func (c *EncryptionConfig) GetKeyProvider(kpType, kpName string) (KeyProviderCon
 // encryption. The Body field will contain the remaining undeclared fields the key provider can consume.
 type KeyProviderConfig struct {
 	// EncryptedMetadataAlias contains the key to identify the metadata by.
-	EncryptedMetadataAlias string   `hcl:"encrypted_metadata_alias,optional"`
-	Type                   string   `hcl:"type,label"`
-	Name                   string   `hcl:"name,label"`
-	Body                   hcl.Body `hcl:",remain"`
+	EncryptedMetadataAlias string `hcl:"encrypted_metadata_alias,optional"`
+	// PerWorkspace mixes the current workspace name into the keys the key provider produces.
+	PerWorkspace bool     `hcl:"per_workspace,optional"`
+	Type         string   `hcl:"type,label"`
+	Name         string   `hcl:"name,label"`
+	Body         hcl.Body `hcl:",remain"`
 }
 
 // Addr returns a keyprovider.Addr from the current configuration.
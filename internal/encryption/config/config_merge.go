@@ -19,6 +19,8 @@ func MergeConfigs(cfg *EncryptionConfig, override *EncryptionConfig) *Encryption
 		return cfg
 	}
 	return &EncryptionConfig{
+		Enforced: cfg.Enforced || override.Enforced,
+
 		KeyProviderConfigs: mergeKeyProviderConfigs(cfg.KeyProviderConfigs, override.KeyProviderConfigs),
 		MethodConfigs:      mergeMethodConfigs(cfg.MethodConfigs, override.MethodConfigs),
 
@@ -67,6 +69,7 @@ func mergeKeyProviderConfigs(configs []KeyProviderConfig, overrides []KeyProvide
 			if keyProvider.Type == override.Type && keyProvider.Name == override.Name {
 				// Override the existing key provider.
 				merged[i].Body = mergeBody(keyProvider.Body, override.Body)
+				merged[i].PerWorkspace = keyProvider.PerWorkspace || override.PerWorkspace
 				wasOverridden = true
 				break
 			}
@@ -0,0 +1,155 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+// decryptionCacheTTL is how long the methods built for decrypting data with a specific set of metadata are reused.
+// Within this time, decrypting data with the same metadata again doesn't call the key providers, which avoids
+// repeating expensive key derivations and key management system calls.
+const decryptionCacheTTL = 5 * time.Minute
+
+// decryptionCache holds the methods built for decryption, keyed by a digest of the metadata they were built from.
+// Methods implementing method.Zeroizer have their keys overwritten once they expire, or when ZeroizeCachedKeys is
+// called.
+type decryptionCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*decryptionCacheEntry
+	ttl     time.Duration
+}
+
+type decryptionCacheEntry struct {
+	methods []method.Method
+	timer   *time.Timer
+	// users is the number of callers currently using the methods. Evicted methods are only zeroized once it drops
+	// to zero.
+	users   int
+	evicted bool
+}
+
+// liveDecryptionCaches tracks the caches that currently hold methods, so that ZeroizeCachedKeys can find them.
+var liveDecryptionCaches = struct {
+	sync.Mutex
+	caches map[*decryptionCache]struct{}
+}{caches: make(map[*decryptionCache]struct{})}
+
+// ZeroizeCachedKeys discards all methods cached for decryption and overwrites their keys. Call this before exiting.
+func ZeroizeCachedKeys() {
+	liveDecryptionCaches.Lock()
+	caches := make([]*decryptionCache, 0, len(liveDecryptionCaches.caches))
+	for c := range liveDecryptionCaches.caches {
+		caches = append(caches, c)
+	}
+	liveDecryptionCaches.Unlock()
+
+	for _, c := range caches {
+		c.mu.Lock()
+		for key, entry := range c.entries {
+			c.evictLocked(key, entry)
+		}
+		c.mu.Unlock()
+	}
+}
+
+func newDecryptionCache(ttl time.Duration) *decryptionCache {
+	return &decryptionCache{
+		entries: make(map[[sha256.Size]byte]*decryptionCacheEntry),
+		ttl:     ttl,
+	}
+}
+
+// decryptionCacheKey returns the cache key for the given metadata. encoding/json sorts map keys, so the encoding is
+// stable.
+func decryptionCacheKey(meta map[keyprovider.MetaStorageKey][]byte) ([sha256.Size]byte, error) {
+	encoded, err := json.Marshal(meta)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(encoded), nil
+}
+
+// get returns the methods cached for the given key. If it returns true, the caller must call release with the
+// returned entry once it no longer uses the methods.
+func (c *decryptionCache) get(key [sha256.Size]byte) (*decryptionCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry.users++
+	return entry, true
+}
+
+// put caches the given methods and returns the new entry, which the caller must release once it no longer uses the
+// methods.
+func (c *decryptionCache) put(key [sha256.Size]byte, methods []method.Method) *decryptionCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.entries[key]; ok {
+		c.evictLocked(key, existing)
+	}
+	entry := &decryptionCacheEntry{
+		methods: methods,
+		users:   1,
+	}
+	entry.timer = time.AfterFunc(c.ttl, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.evictLocked(key, entry)
+	})
+	c.entries[key] = entry
+
+	liveDecryptionCaches.Lock()
+	liveDecryptionCaches.caches[c] = struct{}{}
+	liveDecryptionCaches.Unlock()
+	return entry
+}
+
+// release marks the methods of the given entry as no longer used by one caller.
+func (c *decryptionCache) release(entry *decryptionCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry.users--
+	if entry.evicted && entry.users == 0 {
+		zeroizeMethods(entry.methods)
+	}
+}
+
+// evictLocked removes the given entry from the cache, zeroizing its methods unless they are still in use. The caller
+// must hold c.mu.
+func (c *decryptionCache) evictLocked(key [sha256.Size]byte, entry *decryptionCacheEntry) {
+	if entry.evicted {
+		return
+	}
+	entry.evicted = true
+	entry.timer.Stop()
+	if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+	if entry.users == 0 {
+		zeroizeMethods(entry.methods)
+	}
+	if len(c.entries) == 0 {
+		liveDecryptionCaches.Lock()
+		delete(liveDecryptionCaches.caches, c)
+		liveDecryptionCaches.Unlock()
+	}
+}
+
+func zeroizeMethods(methods []method.Method) {
+	for _, m := range methods {
+		if z, ok := m.(method.Zeroizer); ok {
+			z.Zeroize()
+		}
+	}
+}
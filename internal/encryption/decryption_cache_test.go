@@ -0,0 +1,103 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+type zeroizeTestMethod struct {
+	key []byte
+}
+
+func (m *zeroizeTestMethod) Encrypt(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func (m *zeroizeTestMethod) Decrypt(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func (m *zeroizeTestMethod) Zeroize() {
+	clear(m.key)
+}
+
+func (m *zeroizeTestMethod) zeroized() bool {
+	for _, b := range m.key {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDecryptionCache(t *testing.T) {
+	key, err := decryptionCacheKey(map[keyprovider.MetaStorageKey][]byte{"key_provider.static.foo": []byte("meta")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := decryptionCacheKey(map[keyprovider.MetaStorageKey][]byte{"key_provider.static.foo": []byte("other")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("reuse", func(t *testing.T) {
+		cache := newDecryptionCache(time.Hour)
+		m := &zeroizeTestMethod{key: []byte{1, 2, 3}}
+		cache.release(cache.put(key, []method.Method{m}))
+
+		entry, ok := cache.get(key)
+		if !ok || entry.methods[0] != m {
+			t.Fatal("cached methods not returned")
+		}
+		cache.release(entry)
+		if _, ok := cache.get(otherKey); ok {
+			t.Fatal("methods returned for different metadata")
+		}
+		if m.zeroized() {
+			t.Fatal("cached method zeroized while still cached")
+		}
+		ZeroizeCachedKeys()
+		if !m.zeroized() {
+			t.Fatal("method not zeroized by ZeroizeCachedKeys")
+		}
+		if _, ok := cache.get(key); ok {
+			t.Fatal("methods returned after ZeroizeCachedKeys")
+		}
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		cache := newDecryptionCache(10 * time.Millisecond)
+		m := &zeroizeTestMethod{key: []byte{1, 2, 3}}
+		cache.release(cache.put(key, []method.Method{m}))
+		deadline := time.Now().Add(5 * time.Second)
+		for !m.zeroized() {
+			if time.Now().After(deadline) {
+				t.Fatal("method not zeroized after expiry")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if _, ok := cache.get(key); ok {
+			t.Fatal("expired methods returned")
+		}
+	})
+
+	t.Run("in use", func(t *testing.T) {
+		cache := newDecryptionCache(time.Hour)
+		m := &zeroizeTestMethod{key: []byte{1, 2, 3}}
+		entry := cache.put(key, []method.Method{m})
+		ZeroizeCachedKeys()
+		if m.zeroized() {
+			t.Fatal("method zeroized while in use")
+		}
+		cache.release(entry)
+		if !m.zeroized() {
+			t.Fatal("method not zeroized after release")
+		}
+	})
+}
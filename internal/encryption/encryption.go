@@ -52,23 +52,36 @@ func New(reg registry.Registry, cfg *config.EncryptionConfig, staticEval *config
 	var diags hcl.Diagnostics
 	var encDiags hcl.Diagnostics
 
+	// The state and plan targets are for the selected workspace. The state managers for other workspaces use
+	// StateForWorkspace to get the encryption for the workspace they are for.
+	var workspace string
+	if staticEval != nil {
+		workspace = staticEval.Workspace()
+	}
+
 	if cfg.State != nil {
-		enc.state, encDiags = newStateEncryption(enc, cfg.State.AsTargetConfig(), cfg.State.Enforced, "state", staticEval)
+		enc.state, encDiags = newStateEncryption(enc, cfg.State.AsTargetConfig(), cfg.State.Enforced || cfg.Enforced, "state", workspace, staticEval)
 		diags = append(diags, encDiags...)
+	} else if cfg.Enforced {
+		enc.state = stateEncryptionEnforced("state")
 	} else {
 		enc.state = StateEncryptionDisabled()
 	}
 
 	if cfg.Plan != nil {
-		enc.plan, encDiags = newPlanEncryption(enc, cfg.Plan.AsTargetConfig(), cfg.Plan.Enforced, "plan", staticEval)
+		enc.plan, encDiags = newPlanEncryption(enc, cfg.Plan.AsTargetConfig(), cfg.Plan.Enforced || cfg.Enforced, "plan", workspace, staticEval)
 		diags = append(diags, encDiags...)
+	} else if cfg.Enforced {
+		enc.plan = planEncryptionEnforced()
 	} else {
 		enc.plan = PlanEncryptionDisabled()
 	}
 
 	if cfg.Remote != nil && cfg.Remote.Default != nil {
-		enc.remoteDefault, encDiags = newStateEncryption(enc, cfg.Remote.Default, false, "remote.default", staticEval)
+		enc.remoteDefault, encDiags = newStateEncryption(enc, cfg.Remote.Default, cfg.Enforced, "remote.default", workspace, staticEval)
 		diags = append(diags, encDiags...)
+	} else if cfg.Enforced {
+		enc.remoteDefault = stateEncryptionEnforced("remote_state_data_sources")
 	} else {
 		enc.remoteDefault = StateEncryptionDisabled()
 	}
@@ -77,7 +90,7 @@ func New(reg registry.Registry, cfg *config.EncryptionConfig, staticEval *config
 		for _, remoteTarget := range cfg.Remote.Targets {
 			// TODO the addr here should be generated in one place.
 			addr := "remote.remote_state_datasource." + remoteTarget.Name
-			enc.remotes[remoteTarget.Name], encDiags = newStateEncryption(enc, remoteTarget.AsTargetConfig(), false, addr, staticEval)
+			enc.remotes[remoteTarget.Name], encDiags = newStateEncryption(enc, remoteTarget.AsTargetConfig(), cfg.Enforced, addr, workspace, staticEval)
 			diags = append(diags, encDiags...)
 		}
 	}
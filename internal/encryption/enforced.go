@@ -0,0 +1,70 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"fmt"
+	"io"
+)
+
+// stateEncryptionEnforced returns a StateEncryption for a target that has no encryption configured even though
+// encryption is enforced for the whole configuration. Since it can neither encrypt nor accept unencrypted data, it
+// refuses every read and write.
+func stateEncryptionEnforced(name string) StateEncryption {
+	return &stateEnforced{name: name}
+}
+
+type stateEnforced struct {
+	name string
+}
+
+func (s *stateEnforced) EncryptState(_ []byte) ([]byte, error) {
+	return nil, errEncryptionEnforced(s.name)
+}
+
+func (s *stateEnforced) DecryptState(_ []byte) ([]byte, EncryptionStatus, error) {
+	return nil, StatusUnknown, errEncryptionEnforced(s.name)
+}
+
+// planEncryptionEnforced is the PlanEncryption equivalent of stateEncryptionEnforced.
+func planEncryptionEnforced() PlanEncryption {
+	return &planEnforced{}
+}
+
+type planEnforced struct{}
+
+func (s *planEnforced) EncryptPlan(_ []byte) ([]byte, error) {
+	return nil, errEncryptionEnforced("plan")
+}
+
+func (s *planEnforced) DecryptPlan(_ []byte) ([]byte, error) {
+	return nil, errEncryptionEnforced("plan")
+}
+
+func (s *planEnforced) EncryptPlanStream(_ io.Writer) (io.WriteCloser, error) {
+	return nil, errEncryptionEnforced("plan")
+}
+
+func (s *planEnforced) DecryptPlanStream(_ io.Reader) (io.Reader, error) {
+	return nil, errEncryptionEnforced("plan")
+}
+
+func errEncryptionEnforced(name string) error {
+	return fmt.Errorf("encryption is enforced, but no encryption is configured for %s", name)
+}
+
+// StateForUserFile returns the encryption for a state file that the user gave explicitly or that OpenTofu writes for
+// the user to inspect, outside of the configured backend. Such files are unencrypted, unless encryption is enforced
+// for the given state encryption, in which case it is returned as-is so that no unencrypted state is ever written.
+func StateForUserFile(enc StateEncryption) StateEncryption {
+	switch s := enc.(type) {
+	case *stateEncryption:
+		if s.base.enforced {
+			return s
+		}
+	case *stateEnforced:
+		return s
+	}
+	return StateEncryptionDisabled()
+}
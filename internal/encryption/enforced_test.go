@@ -0,0 +1,135 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/static"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+func TestEncryption_enforcedRoot(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(static.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterMethod(unencrypted.New()); err != nil {
+		t.Fatal(err)
+	}
+	staticEval := configs.NewStaticEvaluator(&configs.Module{}, configs.RootModuleCallForTesting())
+
+	t.Run("unencrypted method forbidden", func(t *testing.T) {
+		cfg, diags := config.LoadConfigFromString("Test Config Source", `
+			enforced = true
+			method "unencrypted" "example" {
+			}
+			state {
+				method = method.unencrypted.example
+			}
+		`)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		_, diags = New(reg, cfg, staticEval)
+		if !hasDiagWithMsg(diags, "<nil>: Unencrypted method is forbidden; Unable to use `unencrypted` method since the `enforced` flag is used.") {
+			t.Fatalf("expected an unencrypted method error, got: %v", diags)
+		}
+	})
+
+	t.Run("unconfigured targets refused", func(t *testing.T) {
+		cfg, diags := config.LoadConfigFromString("Test Config Source", `
+			enforced = true
+			key_provider "static" "basic" {
+				key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+			}
+			method "aes_gcm" "example" {
+				keys = key_provider.static.basic
+			}
+			state {
+				method = method.aes_gcm.example
+			}
+		`)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+
+		plain := []byte(`{"serial": 1, "lineage": "abc"}`)
+		if _, _, err := enc.State().DecryptState(plain); err == nil {
+			t.Error("expected an error when reading an unencrypted state")
+		}
+		if _, err := enc.Plan().EncryptPlan([]byte("plan")); err == nil || !strings.Contains(err.Error(), "no encryption is configured for plan") {
+			t.Errorf("expected an error when writing an unencrypted plan, got: %v", err)
+		}
+		if _, _, err := enc.RemoteState("other").DecryptState(plain); err == nil {
+			t.Error("expected an error when reading an unencrypted remote state")
+		}
+	})
+
+	t.Run("user files", func(t *testing.T) {
+		plain := []byte(`{"serial": 1, "lineage": "abc"}`)
+		for name, src := range map[string]string{
+			"not enforced": `
+				key_provider "static" "basic" {
+					key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+				}
+				method "aes_gcm" "example" {
+					keys = key_provider.static.basic
+				}
+				state {
+					method = method.aes_gcm.example
+				}
+			`,
+			"enforced": `
+				enforced = true
+				key_provider "static" "basic" {
+					key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+				}
+				method "aes_gcm" "example" {
+					keys = key_provider.static.basic
+				}
+				state {
+					method = method.aes_gcm.example
+				}
+			`,
+			"enforced without state": `
+				enforced = true
+			`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				cfg, diags := config.LoadConfigFromString("Test Config Source", src)
+				if diags.HasErrors() {
+					t.Fatal(diags.Error())
+				}
+				enc, diags := New(reg, cfg, staticEval)
+				if diags.HasErrors() {
+					t.Fatal(diags.Error())
+				}
+
+				encrypted, err := StateForUserFile(enc.State()).EncryptState(plain)
+				if name == "not enforced" {
+					if err != nil || string(encrypted) != string(plain) {
+						t.Fatalf("expected the user file to be written unencrypted, got %q (error: %v)", encrypted, err)
+					}
+					return
+				}
+				if err == nil && string(encrypted) == string(plain) {
+					t.Fatalf("expected the user file not to be written unencrypted")
+				}
+			})
+		}
+	})
+}
@@ -243,6 +243,24 @@ func (e *targetBuilder) setupKeyProvider(cfg config.KeyProviderConfig, stack []c
 		})
 	}
 
+	if cfg.PerWorkspace {
+		if e.workspace() == "" {
+			return append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unable to derive per-workspace encryption key",
+				Detail:   fmt.Sprintf("%s has per_workspace enabled, but the workspace the data belongs to is not known.", metaKey),
+			})
+		}
+		output, err = deriveWorkspaceOutput(output, e.workspace())
+		if err != nil {
+			return append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unable to derive per-workspace encryption key",
+				Detail:   fmt.Sprintf("%s failed with error: %s", metaKey, err.Error()),
+			})
+		}
+	}
+
 	if keyMetaOut != nil {
 		if _, ok := e.outputKeyProviderMetadata[metaKey]; ok {
 			return append(diags, &hcl.Diagnostic{
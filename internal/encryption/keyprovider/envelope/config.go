@@ -0,0 +1,61 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package envelope
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+// dataKeyLength is the length of randomly generated data keys, suitable
+// for AES-256.
+const dataKeyLength = 32
+
+// Config contains the configuration for this key provider supplied by the user. This struct must have hcl tags in order
+// to function.
+type Config struct {
+	// KEK is the output of the key provider whose key is used to wrap the data key.
+	KEK keyprovider.Output `hcl:"kek"`
+	// Key is the optional output of a key provider supplying the data key. If not set, a random data key is generated
+	// for every encryption.
+	Key *keyprovider.Output `hcl:"key,optional"`
+}
+
+// Build will create the usable key provider.
+func (c Config) Build() (keyprovider.KeyProvider, keyprovider.KeyMeta, error) {
+	if len(c.KEK.EncryptionKey) == 0 {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: "Missing kek encryption key",
+		}
+	}
+	if err := validateKEKLength(len(c.KEK.EncryptionKey)); err != nil {
+		return nil, nil, err
+	}
+	if len(c.KEK.DecryptionKey) != 0 {
+		if err := validateKEKLength(len(c.KEK.DecryptionKey)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.Key != nil && len(c.Key.EncryptionKey) == 0 {
+		return nil, nil, &keyprovider.ErrInvalidConfiguration{
+			Message: "Missing key encryption key",
+		}
+	}
+	return &envelopeKeyProvider{
+		kek: c.KEK,
+		key: c.Key,
+	}, new(Metadata), nil
+}
+
+func validateKEKLength(length int) error {
+	switch length {
+	case 16, 24, 32:
+		return nil
+	default:
+		return &keyprovider.ErrInvalidConfiguration{
+			Message: fmt.Sprintf("The kek key must be 16, 24 or 32 bytes long for AES, but it is %d bytes long", length),
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package envelope
+
+// keySource values record where the wrapped data key came from.
+const (
+	keySourceGenerated = "generated"
+	keySourceChained   = "chained"
+)
+
+// Metadata is stored alongside the encrypted data. The key-encryption key
+// provider stores its own metadata separately, so together they describe
+// the whole chain needed to recover the data key.
+type Metadata struct {
+	// WrappedKey is the data key, encrypted with AES-GCM using the
+	// key-encryption key. The nonce is stored as a prefix.
+	WrappedKey []byte `json:"wrapped_key,omitempty"`
+
+	// KeySource is either "generated" if the data key was randomly generated
+	// or "chained" if it was provided by the key provider set in "key".
+	KeySource string `json:"key_source,omitempty"`
+}
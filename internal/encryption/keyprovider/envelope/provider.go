@@ -0,0 +1,134 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package envelope contains a key provider that wraps a data key with a key from another key provider.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+type envelopeKeyProvider struct {
+	kek keyprovider.Output
+	key *keyprovider.Output
+}
+
+func (p envelopeKeyProvider) Provide(rawMeta keyprovider.KeyMeta) (keyprovider.Output, keyprovider.KeyMeta, error) {
+	if rawMeta == nil {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{
+			Message: "bug: no metadata struct provided",
+		}
+	}
+	inMeta, ok := rawMeta.(*Metadata)
+	if !ok {
+		return keyprovider.Output{}, nil, &keyprovider.ErrInvalidMetadata{
+			Message: fmt.Sprintf("bug: incorrect metadata type of %T provided", rawMeta),
+		}
+	}
+
+	// Note: the WrappedKey may be empty if OpenTofu isn't decrypting anything.
+	var decryptionKey []byte
+	if len(inMeta.WrappedKey) != 0 {
+		var err error
+		decryptionKey, err = p.unwrap(inMeta.WrappedKey)
+		if err != nil {
+			return keyprovider.Output{}, nil, err
+		}
+	}
+
+	outMeta := &Metadata{
+		KeySource: keySourceGenerated,
+	}
+	var encryptionKey []byte
+	if p.key != nil {
+		outMeta.KeySource = keySourceChained
+		encryptionKey = p.key.EncryptionKey
+	} else {
+		encryptionKey = make([]byte, dataKeyLength)
+		if _, err := rand.Read(encryptionKey); err != nil {
+			return keyprovider.Output{}, nil, &keyprovider.ErrKeyProviderFailure{
+				Message: "failed to generate data key",
+				Cause:   err,
+			}
+		}
+	}
+	wrappedKey, err := p.wrap(encryptionKey)
+	if err != nil {
+		return keyprovider.Output{}, nil, err
+	}
+	outMeta.WrappedKey = wrappedKey
+
+	return keyprovider.Output{
+		EncryptionKey: encryptionKey,
+		DecryptionKey: decryptionKey,
+	}, outMeta, nil
+}
+
+func (p envelopeKeyProvider) wrap(dataKey []byte) ([]byte, error) {
+	gcm, err := newGCM(p.kek.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, &keyprovider.ErrKeyProviderFailure{
+			Message: "failed to generate nonce",
+			Cause:   err,
+		}
+	}
+	return gcm.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+func (p envelopeKeyProvider) unwrap(wrappedKey []byte) ([]byte, error) {
+	if len(p.kek.DecryptionKey) == 0 {
+		// The key-encryption key provider has no decryption key if it could not find its own metadata, so the data
+		// can only be decrypted if the data key is also provided directly.
+		if p.key != nil && len(p.key.DecryptionKey) != 0 {
+			return p.key.DecryptionKey, nil
+		}
+		return nil, &keyprovider.ErrInvalidMetadata{
+			Message: "the kek key provider did not provide a decryption key for the wrapped data key",
+		}
+	}
+	gcm, err := newGCM(p.kek.DecryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrappedKey) < gcm.NonceSize() {
+		return nil, &keyprovider.ErrInvalidMetadata{
+			Message: "the wrapped data key is too short",
+		}
+	}
+	nonce, ciphertext := wrappedKey[:gcm.NonceSize()], wrappedKey[gcm.NonceSize():]
+	dataKey, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, &keyprovider.ErrInvalidMetadata{
+			Message: "failed to unwrap the data key (did the kek key change?)",
+			Cause:   err,
+		}
+	}
+	return dataKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, &keyprovider.ErrKeyProviderFailure{
+			Message: "failed to create AES cipher for the kek key",
+			Cause:   err,
+		}
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, &keyprovider.ErrKeyProviderFailure{
+			Message: "failed to create AES-GCM cipher for the kek key",
+			Cause:   err,
+		}
+	}
+	return gcm, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package envelope
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+func TestProvide(t *testing.T) {
+	kek := keyprovider.Output{
+		EncryptionKey: bytes.Repeat([]byte{1}, 32),
+		DecryptionKey: bytes.Repeat([]byte{1}, 32),
+	}
+	chained := &keyprovider.Output{
+		EncryptionKey: bytes.Repeat([]byte{2}, 32),
+		DecryptionKey: bytes.Repeat([]byte{2}, 32),
+	}
+
+	for name, key := range map[string]*keyprovider.Output{"generated": nil, "chained": chained} {
+		t.Run(name, func(t *testing.T) {
+			provider, meta, err := Config{KEK: kek, Key: key}.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			encrypt, encryptMeta, err := provider.Provide(meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(encrypt.DecryptionKey) != 0 {
+				t.Fatalf("unexpected decryption key without metadata")
+			}
+			if key != nil && !bytes.Equal(encrypt.EncryptionKey, key.EncryptionKey) {
+				t.Fatalf("encryption key does not match the chained key")
+			}
+			if got := encryptMeta.(*Metadata).KeySource; got != name {
+				t.Fatalf("wrong key source %q; want %q", got, name)
+			}
+
+			// Round-trip the metadata the way it is stored alongside the encrypted data.
+			storedMeta, err := json.Marshal(encryptMeta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			provider, meta, err = Config{KEK: kek, Key: key}.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(storedMeta, meta); err != nil {
+				t.Fatal(err)
+			}
+			decrypt, _, err := provider.Provide(meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decrypt.DecryptionKey, encrypt.EncryptionKey) {
+				t.Fatalf("decryption key does not match the original encryption key")
+			}
+		})
+	}
+}
+
+func TestProvide_wrongKEK(t *testing.T) {
+	kek := keyprovider.Output{
+		EncryptionKey: bytes.Repeat([]byte{1}, 32),
+	}
+	provider, meta, err := Config{KEK: kek}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, encryptMeta, err := provider.Provide(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKEK := keyprovider.Output{
+		EncryptionKey: bytes.Repeat([]byte{3}, 32),
+		DecryptionKey: bytes.Repeat([]byte{3}, 32),
+	}
+	provider, _, err = Config{KEK: otherKEK}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := provider.Provide(encryptMeta); err == nil {
+		t.Fatal("expected an error when unwrapping with the wrong kek")
+	}
+}
+
+func TestBuild_invalidKEK(t *testing.T) {
+	_, _, err := Config{KEK: keyprovider.Output{EncryptionKey: []byte("too short")}}.Build()
+	if err == nil {
+		t.Fatal("expected an error for a kek of invalid length")
+	}
+}
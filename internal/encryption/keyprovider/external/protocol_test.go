@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestProtocolSchemas checks that the JSON schemas published for the external program protocol describe the same
+// fields as the types OpenTofu uses to read and write the protocol messages, so that programs written against the
+// schemas work with OpenTofu.
+func TestProtocolSchemas(t *testing.T) {
+	testCases := map[string]struct {
+		schema string
+		value  any
+	}{
+		"header": {
+			schema: "header.schema.json",
+			value:  Header{},
+		},
+		"output": {
+			schema: "output.schema.json",
+			value:  OutputV1{},
+		},
+		"output keys": {
+			schema: "output.schema.json#keys",
+			value:  OutputV1{}.Keys,
+		},
+		"output meta": {
+			schema: "output.schema.json#meta",
+			value:  MetadataV1{},
+		},
+		"input": {
+			schema: "input.schema.json#1",
+			value:  MetadataV1{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schema := readProtocolSchema(t, tc.schema)
+
+			var wantProperties []string
+			for property := range schema.Properties {
+				wantProperties = append(wantProperties, property)
+			}
+			sort.Strings(wantProperties)
+			if got := jsonFields(reflect.TypeOf(tc.value)); !reflect.DeepEqual(got, wantProperties) {
+				t.Errorf("the protocol type has the fields %v, but the schema has the properties %v", got, wantProperties)
+			}
+			for _, required := range schema.Required {
+				if _, ok := schema.Properties[required]; !ok {
+					t.Errorf("the schema requires %q, but doesn't describe it", required)
+				}
+			}
+		})
+	}
+}
+
+// TestProtocolExamples checks that the messages shown in the documentation of the protocol are accepted by OpenTofu
+// with the same strict decoding that it uses for the messages of an external program.
+func TestProtocolExamples(t *testing.T) {
+	testCases := map[string]struct {
+		message string
+		value   any
+	}{
+		"header": {
+			message: `{"magic":"OpenTofu-External-Key-Provider","version":1}`,
+			value:   &Header{},
+		},
+		"input": {
+			message: `{"external_data":{"key1":"value1","key2":"value2"}}`,
+			value:   new(InputV1),
+		},
+		"null input": {
+			message: `null`,
+			value:   new(InputV1),
+		},
+		"output": {
+			message: `{
+				"keys": {
+					"encryption_key": "c29tZSBlbmNyeXB0aW9uIGtleQ==",
+					"decryption_key": "c29tZSBkZWNyeXB0aW9uIGtleQ=="
+				},
+				"meta": {
+					"external_data": {"key1": "value1", "key2": "value2"}
+				}
+			}`,
+			value: &OutputV1{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			decoder := json.NewDecoder(bytes.NewReader([]byte(tc.message)))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(tc.value); err != nil {
+				t.Fatalf("failed to decode the example message: %v", err)
+			}
+		})
+	}
+
+	// The key material must be in the "keys" property, any other property is a protocol error.
+	decoder := json.NewDecoder(strings.NewReader(`{"key": {"encryption_key": "Zm9v"}, "meta": {}}`))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&OutputV1{}); err == nil {
+		t.Fatalf("expected an error for an output with misnamed keys")
+	}
+}
+
+type protocolSchema struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+	OneOf      []json.RawMessage          `json:"oneOf"`
+}
+
+// readProtocolSchema reads the schema from the given file in the protocol directory. A fragment after a "#" selects
+// either a nested property by name or an alternative of a "oneOf" by index.
+func readProtocolSchema(t *testing.T, name string) protocolSchema {
+	t.Helper()
+
+	file, fragment, _ := strings.Cut(name, "#")
+	raw, err := os.ReadFile(filepath.Join("protocol", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema protocolSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatalf("invalid schema %s: %v", file, err)
+	}
+	if fragment == "" {
+		return schema
+	}
+
+	nested, ok := schema.Properties[fragment]
+	if i, err := strconv.Atoi(fragment); !ok && err == nil && i < len(schema.OneOf) {
+		nested = schema.OneOf[i]
+	}
+	if nested == nil {
+		t.Fatalf("schema %s has no %q", file, fragment)
+	}
+	var ret protocolSchema
+	if err := json.Unmarshal(nested, &ret); err != nil {
+		t.Fatalf("invalid schema %s: %v", name, err)
+	}
+	return ret
+}
+
+// jsonFields returns the sorted names of the JSON properties of the given struct type.
+func jsonFields(typ reflect.Type) []string {
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
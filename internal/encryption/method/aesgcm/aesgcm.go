@@ -19,6 +19,12 @@ type aesgcm struct {
 	encryptionKey []byte
 	decryptionKey []byte
 	aad           []byte
+
+	// encryptionContext and decryptionContext are the contexts bound to the data by adding them to the AAD, if any.
+	encryptionContext *contextMeta
+	decryptionContext *contextMeta
+	// decryptionContextErr is set if the stored context doesn't allow decrypting the data in the current context.
+	decryptionContextErr error
 }
 
 // Encrypt encrypts the passed data with AES-GCM. If the data the encryption fails, it returns an error.
@@ -38,7 +44,7 @@ func (a aesgcm) Encrypt(data []byte) ([]byte, error) {
 				}}
 			}
 
-			encrypted := gcm.Seal(nil, nonce, data, a.aad)
+			encrypted := gcm.Seal(nil, nonce, data, a.contextAAD(a.encryptionContext))
 
 			return append(nonce, encrypted...), nil
 		},
@@ -58,6 +64,9 @@ func (a aesgcm) Decrypt(data []byte) ([]byte, error) {
 	if len(a.decryptionKey) == 0 {
 		return nil, &method.ErrDecryptionKeyUnavailable{}
 	}
+	if a.decryptionContextErr != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: a.decryptionContextErr}
+	}
 	result, err := handlePanic(
 		func() ([]byte, error) {
 			if len(data) == 0 {
@@ -86,7 +95,7 @@ func (a aesgcm) Decrypt(data []byte) ([]byte, error) {
 			nonce := data[:gcm.NonceSize()]
 			data = data[gcm.NonceSize():]
 
-			decrypted, err := gcm.Open(nil, nonce, data, a.aad)
+			decrypted, err := gcm.Open(nil, nonce, data, a.contextAAD(a.decryptionContext))
 			if err != nil {
 				return nil, &method.ErrDecryptionFailed{Cause: err}
 			}
@@ -105,6 +114,20 @@ func (a aesgcm) Decrypt(data []byte) ([]byte, error) {
 	return result, nil
 }
 
+// Zeroize implements method.Zeroizer.
+func (a aesgcm) Zeroize() {
+	clear(a.encryptionKey)
+	clear(a.decryptionKey)
+}
+
+// contextAAD returns the configured AAD with the given context appended.
+func (a aesgcm) contextAAD(ctx *contextMeta) []byte {
+	if ctx == nil {
+		return a.aad
+	}
+	return append(append([]byte{}, a.aad...), ctx.aad()...)
+}
+
 func (a aesgcm) getGCM(key []byte) (cipher.AEAD, error) {
 	cipherBlock, err := aes.NewCipher(key)
 	if err != nil {
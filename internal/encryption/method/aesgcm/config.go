@@ -29,6 +29,16 @@ type Config struct {
 	// otherwise the decryption will fail. (Note: this is Go-specific and differs from the NIST SP 800-38D description
 	// of the AAD.)
 	AAD []byte `hcl:"aad,optional" json:"aad,omitempty" yaml:"aad,omitempty"`
+
+	// BindContext adds the encryption target (state or plan) and the workspace to the AAD, so that the encrypted data
+	// can't be replayed into a different context. The values are recorded in the metadata for decryption.
+	BindContext bool `hcl:"bind_context,optional" json:"bind_context,omitempty" yaml:"bind_context,omitempty"`
+
+	// The following fields are set by ApplyContext.
+	contextApplied       bool
+	encryptionContext    *contextMeta
+	decryptionContext    *contextMeta
+	decryptionContextErr error
 }
 
 // Build checks the validity of the configuration and returns a ready-to-use AES-GCM implementation.
@@ -58,9 +68,18 @@ func (c *Config) Build() (method.Method, error) {
 		}
 	}
 
+	if c.BindContext && !c.contextApplied {
+		return nil, &method.ErrInvalidConfiguration{
+			Cause: fmt.Errorf("bind_context requires details on the encryption target, which were not provided"),
+		}
+	}
+
 	return &aesgcm{
-		encryptionKey,
-		decryptionKey,
-		c.AAD,
+		encryptionKey:        encryptionKey,
+		decryptionKey:        decryptionKey,
+		aad:                  c.AAD,
+		encryptionContext:    c.encryptionContext,
+		decryptionContext:    c.decryptionContext,
+		decryptionContextErr: c.decryptionContextErr,
 	}, nil
 }
@@ -0,0 +1,85 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+// contextSchemeV1 is the AAD scheme that binds the encrypted data to the target and workspace it is stored in.
+const contextSchemeV1 = "context_v1"
+
+// contextMeta is the metadata the AES-GCM method stores alongside data it encrypted with a bound context. It records
+// the AAD scheme and the values used, so that the data can still be decrypted after bind_context is turned off.
+type contextMeta struct {
+	AADScheme string `json:"aad_scheme"`
+	Target    string `json:"target"`
+	Workspace string `json:"workspace"`
+}
+
+// aad returns the additional authenticated data for this context, which is appended to the configured AAD.
+func (m *contextMeta) aad() []byte {
+	if m == nil {
+		return nil
+	}
+	// Neither the scheme, nor the target, nor workspace names can contain a null byte.
+	return []byte("opentofu:" + m.AADScheme + "\x00" + m.Target + "\x00" + m.Workspace)
+}
+
+// ApplyContext implements method.ContextualConfig. If bind_context is enabled, it binds encrypted data to the given
+// context. Data that was previously encrypted with a bound context can only be decrypted in the same context,
+// regardless of the current bind_context setting.
+func (c *Config) ApplyContext(ctx method.Context, inputMeta []byte) ([]byte, error) {
+	c.contextApplied = true
+
+	if len(inputMeta) > 0 {
+		var meta contextMeta
+		if err := json.Unmarshal(inputMeta, &meta); err != nil {
+			c.decryptionContextErr = fmt.Errorf("failed to parse the stored AES-GCM metadata (%w)", err)
+		} else {
+			c.decryptionContext, c.decryptionContextErr = checkContext(&meta, ctx)
+		}
+	} else if c.BindContext {
+		// Never fall back to an unbound AAD here, otherwise removing the metadata would let an attacker bypass the
+		// binding. Use a fallback method without bind_context to migrate existing data.
+		c.decryptionContext = &contextMeta{
+			AADScheme: contextSchemeV1,
+			Target:    ctx.Target,
+			Workspace: ctx.Workspace,
+		}
+	}
+
+	if !c.BindContext {
+		return nil, nil
+	}
+	c.encryptionContext = &contextMeta{
+		AADScheme: contextSchemeV1,
+		Target:    ctx.Target,
+		Workspace: ctx.Workspace,
+	}
+	outputMeta, err := json.Marshal(c.encryptionContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the AES-GCM metadata (%w)", err)
+	}
+	return outputMeta, nil
+}
+
+// checkContext checks that the context stored with the encrypted data matches the context it is being decrypted in.
+// The stored values are authenticated as part of the AAD, so they can't be altered to pass this check.
+func checkContext(meta *contextMeta, ctx method.Context) (*contextMeta, error) {
+	if meta.AADScheme != contextSchemeV1 {
+		return nil, fmt.Errorf("unsupported AAD scheme %q in the stored AES-GCM metadata", meta.AADScheme)
+	}
+	if meta.Target != ctx.Target {
+		return nil, fmt.Errorf("the data was encrypted as %s data and cannot be decrypted as %s data", meta.Target, ctx.Target)
+	}
+	// The workspace is empty when it is not known.
+	if ctx.Workspace != "" && meta.Workspace != ctx.Workspace {
+		return nil, fmt.Errorf("the data was encrypted for the workspace %q and cannot be decrypted in the workspace %q", meta.Workspace, ctx.Workspace)
+	}
+	return meta, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+func TestBindContext(t *testing.T) {
+	key := []byte("bohwu9zoo7Zool5olaileef1eibeathe")
+	build := func(t *testing.T, bindContext bool, ctx method.Context, inputMeta []byte) (method.Method, []byte) {
+		t.Helper()
+		config := &Config{
+			Keys: keyprovider.Output{
+				EncryptionKey: key,
+				DecryptionKey: key,
+			},
+			BindContext: bindContext,
+		}
+		outputMeta, err := config.ApplyContext(ctx, inputMeta)
+		if err != nil {
+			t.Fatalf("Unexpected error applying context: %v", err)
+		}
+		m, err := config.Build()
+		if err != nil {
+			t.Fatalf("Unexpected error building method: %v", err)
+		}
+		return m, outputMeta
+	}
+
+	stateDefault := method.Context{Target: method.TargetState, Workspace: "default"}
+	encryptor, meta := build(t, true, stateDefault, nil)
+	if meta == nil {
+		t.Fatalf("No metadata returned with bind_context enabled")
+	}
+	encrypted, err := encryptor.Encrypt([]byte("Hello world!"))
+	if err != nil {
+		t.Fatalf("Unexpected error encrypting: %v", err)
+	}
+
+	testCases := map[string]struct {
+		bindContext bool
+		ctx         method.Context
+		inputMeta   []byte
+		valid       bool
+	}{
+		"same-context": {
+			bindContext: true,
+			ctx:         stateDefault,
+			inputMeta:   meta,
+			valid:       true,
+		},
+		"binding-disabled": {
+			bindContext: false,
+			ctx:         stateDefault,
+			inputMeta:   meta,
+			valid:       true,
+		},
+		"unknown-workspace": {
+			ctx:       method.Context{Target: method.TargetState},
+			inputMeta: meta,
+			valid:     true,
+		},
+		"other-workspace": {
+			bindContext: true,
+			ctx:         method.Context{Target: method.TargetState, Workspace: "prod"},
+			inputMeta:   meta,
+			valid:       false,
+		},
+		"other-target": {
+			bindContext: true,
+			ctx:         method.Context{Target: method.TargetPlan, Workspace: "default"},
+			inputMeta:   meta,
+			valid:       false,
+		},
+		"altered-metadata": {
+			bindContext: true,
+			ctx:         method.Context{Target: method.TargetState, Workspace: "prod"},
+			inputMeta:   []byte(`{"aad_scheme":"context_v1","target":"state","workspace":"prod"}`),
+			valid:       false,
+		},
+		"missing-metadata": {
+			bindContext: true,
+			ctx:         method.Context{Target: method.TargetState, Workspace: "prod"},
+			inputMeta:   nil,
+			valid:       false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			decryptor, _ := build(t, tc.bindContext, tc.ctx, tc.inputMeta)
+			decrypted, err := decryptor.Decrypt(encrypted)
+			if !tc.valid {
+				var decryptionFailed *method.ErrDecryptionFailed
+				if !errors.As(err, &decryptionFailed) {
+					t.Fatalf("Expected %T, got %v", decryptionFailed, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error decrypting: %v", err)
+			}
+			if string(decrypted) != "Hello world!" {
+				t.Fatalf("Incorrect decrypted string: %s", decrypted)
+			}
+		})
+	}
+}
+
+func TestBindContext_noContext(t *testing.T) {
+	config := &Config{
+		Keys: keyprovider.Output{
+			EncryptionKey: []byte("bohwu9zoo7Zool5olaileef1eibeathe"),
+		},
+		BindContext: true,
+	}
+	_, err := config.Build()
+	var invalidConfig *method.ErrInvalidConfiguration
+	if !errors.As(err, &invalidConfig) {
+		t.Fatalf("Expected %T, got %v", invalidConfig, err)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcm
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+// The streamed form follows the STREAM construction (Hoang, Reyhanitabar, Rogaway and Vizár, 2015). It consists of a
+// random nonce prefix, followed by the plaintext in chunks of streamChunkSize bytes, each sealed separately. The nonce
+// of each chunk is the prefix, followed by the big-endian chunk counter and a byte that is 1 for the final chunk and
+// 0 otherwise. This prevents reordering, dropping, and truncating chunks. The final chunk may be empty.
+const (
+	streamChunkSize   = 64 * 1024
+	streamPrefixSize  = 7
+	streamCounterSize = 4
+)
+
+// EncryptStream implements method.StreamMethod.
+func (a aesgcm) EncryptStream(dst io.Writer) (io.WriteCloser, error) {
+	gcm, err := a.getGCM(a.encryptionKey)
+	if err != nil {
+		return nil, &method.ErrEncryptionFailed{Cause: err}
+	}
+	prefix := make([]byte, streamPrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, &method.ErrEncryptionFailed{Cause: &method.ErrCryptoFailure{
+			Message: "could not generate nonce prefix",
+			Cause:   err,
+		}}
+	}
+	if _, err := dst.Write(prefix); err != nil {
+		return nil, &method.ErrEncryptionFailed{Cause: err}
+	}
+	return &streamEncryptor{
+		dst:    dst,
+		gcm:    gcm,
+		aad:    a.contextAAD(a.encryptionContext),
+		prefix: prefix,
+		buf:    make([]byte, 0, streamChunkSize),
+	}, nil
+}
+
+// DecryptStream implements method.StreamMethod.
+func (a aesgcm) DecryptStream(src io.Reader) (io.Reader, error) {
+	if len(a.decryptionKey) == 0 {
+		return nil, &method.ErrDecryptionKeyUnavailable{}
+	}
+	if a.decryptionContextErr != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: a.decryptionContextErr}
+	}
+	gcm, err := a.getGCM(a.decryptionKey)
+	if err != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: err}
+	}
+	prefix := make([]byte, streamPrefixSize)
+	if _, err := io.ReadFull(src, prefix); err != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: &method.ErrCryptoFailure{
+			Message: "cannot decrypt data because it is too small (likely data corruption)",
+			Cause:   err,
+		}}
+	}
+	return &streamDecryptor{
+		src:    bufio.NewReaderSize(src, streamChunkSize+gcm.Overhead()+1),
+		gcm:    gcm,
+		aad:    a.contextAAD(a.decryptionContext),
+		prefix: prefix,
+		chunk:  make([]byte, streamChunkSize+gcm.Overhead()),
+	}, nil
+}
+
+// streamNonce returns the nonce for the chunk with the given counter.
+func streamNonce(prefix []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, 0, streamPrefixSize+streamCounterSize+1)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if final {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+type streamEncryptor struct {
+	dst     io.Writer
+	gcm     cipher.AEAD
+	aad     []byte
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	closed  bool
+	err     error
+}
+
+func (s *streamEncryptor) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.closed {
+		return 0, &method.ErrEncryptionFailed{Cause: errors.New("write after close")}
+	}
+	n := len(p)
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data arrives, since the final chunk must be sealed differently.
+		if len(s.buf) == streamChunkSize {
+			if err := s.seal(false); err != nil {
+				return 0, err
+			}
+		}
+		free := streamChunkSize - len(s.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		s.buf = append(s.buf, p[:free]...)
+		p = p[free:]
+	}
+	return n, nil
+}
+
+func (s *streamEncryptor) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.seal(true)
+}
+
+func (s *streamEncryptor) seal(final bool) error {
+	if s.counter == math.MaxUint32 {
+		s.err = &method.ErrEncryptionFailed{Cause: &method.ErrCryptoFailure{Message: "too much data to encrypt"}}
+		return s.err
+	}
+	sealed := s.gcm.Seal(nil, streamNonce(s.prefix, s.counter, final), s.buf, s.aad)
+	if _, err := s.dst.Write(sealed); err != nil {
+		s.err = &method.ErrEncryptionFailed{Cause: err}
+		return s.err
+	}
+	s.counter++
+	s.buf = s.buf[:0]
+	return nil
+}
+
+type streamDecryptor struct {
+	src     *bufio.Reader
+	gcm     cipher.AEAD
+	aad     []byte
+	prefix  []byte
+	counter uint32
+	chunk   []byte
+	plain   []byte
+	done    bool
+	err     error
+}
+
+func (s *streamDecryptor) Read(p []byte) (int, error) {
+	for len(s.plain) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		if s.done {
+			return 0, io.EOF
+		}
+		s.err = s.open()
+	}
+	n := copy(p, s.plain)
+	s.plain = s.plain[n:]
+	return n, nil
+}
+
+// open reads and authenticates the next chunk.
+func (s *streamDecryptor) open() error {
+	n, err := io.ReadFull(s.src, s.chunk)
+	final := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case errors.Is(err, io.EOF):
+		return &method.ErrDecryptionFailed{Cause: &method.ErrCryptoFailure{
+			Message: "the encrypted data is truncated",
+		}}
+	case err != nil:
+		return &method.ErrDecryptionFailed{Cause: err}
+	default:
+		// A full chunk is the final one if no more data follows.
+		if _, peekErr := s.src.Peek(1); errors.Is(peekErr, io.EOF) {
+			final = true
+		} else if peekErr != nil {
+			return &method.ErrDecryptionFailed{Cause: peekErr}
+		}
+	}
+	if s.counter == math.MaxUint32 {
+		return &method.ErrDecryptionFailed{Cause: &method.ErrCryptoFailure{Message: "too many chunks in the encrypted data"}}
+	}
+	plain, err := s.gcm.Open(nil, streamNonce(s.prefix, s.counter, final), s.chunk[:n], s.aad)
+	if err != nil {
+		return &method.ErrDecryptionFailed{Cause: err}
+	}
+	s.counter++
+	s.plain = plain
+	s.done = final
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package aesgcm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+func TestStream(t *testing.T) {
+	a := &aesgcm{
+		encryptionKey: []byte("aeshi1quahb2Rua0ooquaiwahbonedoh"),
+		decryptionKey: []byte("aeshi1quahb2Rua0ooquaiwahbonedoh"),
+		aad:           []byte("foo"),
+	}
+	for _, size := range []int{0, 1, streamChunkSize - 1, streamChunkSize, streamChunkSize + 1, 3*streamChunkSize + 5} {
+		t.Run(fmt.Sprintf("size-%d", size), func(t *testing.T) {
+			plain := make([]byte, size)
+			for i := range plain {
+				plain[i] = byte(i)
+			}
+			encrypted := encryptStream(t, a, plain)
+
+			decrypted, err := decryptStream(a, encrypted)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !bytes.Equal(plain, decrypted) {
+				t.Fatalf("Incorrect decrypted data")
+			}
+
+			// Removing the final chunk must be detected, as well as modifications.
+			if size > streamChunkSize {
+				if _, err := decryptStream(a, encrypted[:streamPrefixSize+streamChunkSize+16]); err == nil {
+					t.Fatalf("Truncated data decrypted without error")
+				}
+			}
+			if _, err := decryptStream(a, encrypted[:len(encrypted)-1]); err == nil {
+				t.Fatalf("Truncated data decrypted without error")
+			}
+			modified := bytes.Clone(encrypted)
+			modified[streamPrefixSize] ^= 1
+			if _, err := decryptStream(a, modified); err == nil {
+				t.Fatalf("Modified data decrypted without error")
+			}
+		})
+	}
+}
+
+func TestStream_wrongAAD(t *testing.T) {
+	a := &aesgcm{
+		encryptionKey: []byte("aeshi1quahb2Rua0ooquaiwahbonedoh"),
+		decryptionKey: []byte("aeshi1quahb2Rua0ooquaiwahbonedoh"),
+		aad:           []byte("foo"),
+	}
+	encrypted := encryptStream(t, a, []byte("Hello world!"))
+	a.aad = []byte("bar")
+	_, err := decryptStream(a, encrypted)
+	var decryptionFailed *method.ErrDecryptionFailed
+	if !errors.As(err, &decryptionFailed) {
+		t.Fatalf("Expected %T, got %v", decryptionFailed, err)
+	}
+}
+
+func TestStream_noDecryptionKey(t *testing.T) {
+	a := &aesgcm{
+		encryptionKey: []byte("aeshi1quahb2Rua0ooquaiwahbonedoh"),
+	}
+	_, err := a.DecryptStream(bytes.NewReader(encryptStream(t, a, []byte("Hello world!"))))
+	var keyUnavailable *method.ErrDecryptionKeyUnavailable
+	if !errors.As(err, &keyUnavailable) {
+		t.Fatalf("Expected %T, got %v", keyUnavailable, err)
+	}
+}
+
+func encryptStream(t *testing.T, a *aesgcm, plain []byte) []byte {
+	t.Helper()
+	var encrypted bytes.Buffer
+	w, err := a.EncryptStream(&encrypted)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Write in uneven pieces to exercise the chunk buffering.
+	for len(plain) > 0 {
+		n := min(len(plain), 1000)
+		if _, err := w.Write(plain[:n]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		plain = plain[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return encrypted.Bytes()
+}
+
+func decryptStream(a *aesgcm, encrypted []byte) ([]byte, error) {
+	r, err := a.DecryptStream(bytes.NewReader(encrypted))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package method
+
+const (
+	// TargetState is the Context target for state files.
+	TargetState = "state"
+	// TargetPlan is the Context target for plan files.
+	TargetPlan = "plan"
+)
+
+// Context describes where the data encrypted or decrypted by a method is stored.
+type Context struct {
+	// Target is the kind of data, either TargetState or TargetPlan.
+	Target string
+	// Workspace is the name of the workspace the data belongs to. It is empty if the workspace is not known.
+	Workspace string
+}
+
+// ContextualConfig is an optional interface for method configurations that can bind the encrypted data to the context
+// it is stored in, so that it can't be replayed into a different context. The encryption calls ApplyContext before
+// Build.
+type ContextualConfig interface {
+	Config
+
+	// ApplyContext passes the context the method is used in, along with the metadata the method stored alongside
+	// the data being decrypted, if any. It returns the metadata to store alongside the data the method encrypts, or
+	// nil if there is none.
+	ApplyContext(ctx Context, inputMeta []byte) ([]byte, error)
+}
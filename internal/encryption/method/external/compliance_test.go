@@ -0,0 +1,129 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method/compliancetest"
+	"github.com/opentofu/opentofu/internal/encryption/method/external/testmethod"
+)
+
+func TestComplianceBinary(t *testing.T) {
+	runTest(t, testmethod.Go(t))
+}
+
+func TestCompliancePython(t *testing.T) {
+	runTest(t, testmethod.Python(t))
+}
+
+func runTest(t *testing.T, command []string) {
+	encryptCommand := append(append([]string{}, command...), "--encrypt")
+	decryptCommand := append(append([]string{}, command...), "--decrypt")
+	compliancetest.ComplianceTest(t, compliancetest.TestConfiguration[*descriptor, *Config, *external]{
+		Descriptor: New().(*descriptor), //nolint:errcheck //No clue why errcheck fires here.
+		HCLParseTestCases: map[string]compliancetest.HCLParseTestCase[*descriptor, *Config, *external]{
+			"empty": {
+				HCL:        `method "external" "foo" {}`,
+				ValidHCL:   false,
+				ValidBuild: false,
+				Validate:   nil,
+			},
+			"empty-commands": {
+				HCL: `method "external" "foo" {
+						encrypt_command = []
+						decrypt_command = []
+					}`,
+				ValidHCL:   true,
+				ValidBuild: false,
+				Validate:   nil,
+			},
+			"empty-encryption-key": {
+				HCL: `method "external" "foo" {
+						keys = {
+							encryption_key = []
+							decryption_key = []
+						}
+						encrypt_command = ["test-method", "--encrypt"]
+						decrypt_command = ["test-method", "--decrypt"]
+					}`,
+				ValidHCL:   true,
+				ValidBuild: false,
+				Validate:   nil,
+			},
+			"no-keys": {
+				HCL: `method "external" "foo" {
+						encrypt_command = ["test-method", "--encrypt"]
+						decrypt_command = ["test-method", "--decrypt"]
+					}`,
+				ValidHCL:   true,
+				ValidBuild: true,
+				Validate: func(config *Config, method *external) error {
+					if config.Keys != nil {
+						return fmt.Errorf("keys found in config despite no keys being provided")
+					}
+					if len(method.encryptCommand) != 2 || method.encryptCommand[0] != "test-method" {
+						return fmt.Errorf("invalid encrypt command after parsing")
+					}
+					if len(method.decryptCommand) != 2 || method.decryptCommand[1] != "--decrypt" {
+						return fmt.Errorf("invalid decrypt command after parsing")
+					}
+					return nil
+				},
+			},
+			"keys": {
+				HCL: `method "external" "foo" {
+						keys = {
+							encryption_key = [1,2,3,4]
+							decryption_key = [5,6,7,8]
+						}
+						encrypt_command = ["test-method", "--encrypt"]
+						decrypt_command = ["test-method", "--decrypt"]
+					}`,
+				ValidHCL:   true,
+				ValidBuild: true,
+				Validate: func(config *Config, method *external) error {
+					if method.keys == nil || len(method.keys.EncryptionKey) != 4 || len(method.keys.DecryptionKey) != 4 {
+						return fmt.Errorf("incorrect keys found in method after HCL parsing")
+					}
+					return nil
+				},
+			},
+		},
+		ConfigStructTestCases: map[string]compliancetest.ConfigStructTestCase[*Config, *external]{
+			"empty": {
+				Config:     &Config{},
+				ValidBuild: false,
+				Validate:   nil,
+			},
+			"missing-decrypt-command": {
+				Config: &Config{
+					EncryptCommand: encryptCommand,
+				},
+				ValidBuild: false,
+				Validate:   nil,
+			},
+		},
+		EncryptDecryptTestCase: compliancetest.EncryptDecryptTestCase[*Config, *external]{
+			ValidEncryptOnlyConfig: &Config{
+				Keys: &keyprovider.Output{
+					EncryptionKey: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+					DecryptionKey: nil,
+				},
+				EncryptCommand: encryptCommand,
+				DecryptCommand: decryptCommand,
+			},
+			ValidFullConfig: &Config{
+				Keys: &keyprovider.Output{
+					EncryptionKey: []byte{17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32},
+					DecryptionKey: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				},
+				EncryptCommand: encryptCommand,
+				DecryptCommand: decryptCommand,
+			},
+		},
+	})
+}
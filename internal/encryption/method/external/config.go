@@ -0,0 +1,48 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+// Config is the configuration for the external method.
+type Config struct {
+	// Keys is the optional key material passed to the external programs. If it is not set, the external programs
+	// must obtain their keys themselves.
+	Keys *keyprovider.Output `hcl:"keys,optional" json:"keys,omitempty" yaml:"keys,omitempty"`
+
+	// EncryptCommand is the command to run to encrypt data, with each argument as a separate item.
+	EncryptCommand []string `hcl:"encrypt_command" json:"encrypt_command" yaml:"encrypt_command"`
+
+	// DecryptCommand is the command to run to decrypt data, with each argument as a separate item.
+	DecryptCommand []string `hcl:"decrypt_command" json:"decrypt_command" yaml:"decrypt_command"`
+}
+
+// Build checks the validity of the configuration and returns a ready-to-use external method.
+func (c *Config) Build() (method.Method, error) {
+	if len(c.EncryptCommand) < 1 {
+		return nil, &method.ErrInvalidConfiguration{
+			Cause: fmt.Errorf("the encrypt_command option is required"),
+		}
+	}
+	if len(c.DecryptCommand) < 1 {
+		return nil, &method.ErrInvalidConfiguration{
+			Cause: fmt.Errorf("the decrypt_command option is required"),
+		}
+	}
+	if c.Keys != nil && len(c.Keys.EncryptionKey) == 0 {
+		return nil, &method.ErrInvalidConfiguration{
+			Cause: fmt.Errorf("the keys option must contain an encryption key when set"),
+		}
+	}
+	return &external{
+		encryptCommand: c.EncryptCommand,
+		decryptCommand: c.DecryptCommand,
+		keys:           c.Keys,
+	}, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+)
+
+type external struct {
+	encryptCommand []string
+	decryptCommand []string
+	keys           *keyprovider.Output
+}
+
+func (e *external) Encrypt(data []byte) ([]byte, error) {
+	input := InputV1{
+		Payload: data,
+	}
+	if e.keys != nil {
+		input.Key = e.keys.EncryptionKey
+	}
+	result, err := e.run(e.encryptCommand, input)
+	if err != nil {
+		return nil, &method.ErrEncryptionFailed{Cause: err}
+	}
+	return result, nil
+}
+
+func (e *external) Decrypt(data []byte) ([]byte, error) {
+	input := InputV1{
+		Payload: data,
+	}
+	if e.keys != nil {
+		if len(e.keys.DecryptionKey) == 0 {
+			return nil, &method.ErrDecryptionKeyUnavailable{}
+		}
+		input.Key = e.keys.DecryptionKey
+	}
+	if len(data) == 0 {
+		return nil, &method.ErrDecryptionFailed{Cause: &method.ErrCryptoFailure{
+			Message: "cannot decrypt empty data",
+		}}
+	}
+	result, err := e.run(e.decryptCommand, input)
+	if err != nil {
+		return nil, &method.ErrDecryptionFailed{Cause: err}
+	}
+	return result, nil
+}
+
+// run executes the given command according to the external method protocol and returns the payload it produced.
+func (e *external) run(command []string, input InputV1) ([]byte, error) {
+	inputData, err := json.Marshal(input)
+	if err != nil {
+		return nil, &method.ErrCryptoFailure{
+			Message: "bug: cannot JSON-marshal the external method input",
+			Cause:   err,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec //Launching external commands here is the entire point.
+
+	handler := &ioHandler{
+		false,
+		bytes.NewBuffer(inputData),
+		[]byte{},
+		cancel,
+		nil,
+	}
+
+	cmd.Stdin = handler
+	cmd.Stdout = handler
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if handler.err != nil {
+			return nil, &method.ErrCryptoFailure{
+				Message: "external method protocol failure",
+				Cause:   handler.err,
+			}
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() != 0 {
+			return nil, &method.ErrCryptoFailure{
+				Message: fmt.Sprintf("the external command exited with a non-zero exit code (%v)\n\nStderr:\n-------\n%s", err, stderr),
+			}
+		}
+		return nil, &method.ErrCryptoFailure{
+			Message: fmt.Sprintf("the external command exited with an error (%v)\n\nStderr:\n-------\n%s", err, stderr),
+		}
+	}
+	if !handler.headerFinished {
+		return nil, &method.ErrCryptoFailure{
+			Message: fmt.Sprintf("the external command did not output a header\n\nStderr:\n-------\n%s", stderr),
+		}
+	}
+
+	var result OutputV1
+	decoder := json.NewDecoder(bytes.NewReader(handler.output))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, &method.ErrCryptoFailure{
+			Message: fmt.Sprintf("the external command returned an invalid JSON response (%v)\n\nStderr:\n-------\n%s", err, stderr),
+		}
+	}
+	return result.Payload, nil
+}
+
+type ioHandler struct {
+	headerFinished bool
+	input          *bytes.Buffer
+	output         []byte
+	cancel         func()
+	err            error
+}
+
+func (i *ioHandler) Write(p []byte) (int, error) {
+	i.output = append(i.output, p...)
+	n := len(p)
+	if i.headerFinished {
+		// Header is finished, just collect the output.
+		return n, nil
+	}
+	// Check if the full header is present.
+	parts := strings.SplitN(string(i.output), "\n", 2) //nolint:mnd //This rule is dumb.
+	if len(parts) == 1 {
+		return n, nil
+	}
+	var header Header
+	// Note: this is intentionally not using strict decoding. Later protocol versions may introduce additional header
+	// fields.
+	if jsonErr := json.Unmarshal([]byte(parts[0]), &header); jsonErr != nil {
+		err := fmt.Errorf("failed to unmarshal header from external binary (%w)", jsonErr)
+		i.err = err
+		i.cancel()
+		return n, err
+	}
+
+	if header.Magic != HeaderMagic {
+		err := fmt.Errorf("invalid magic received from external method: %s", header.Magic)
+		i.err = err
+		i.cancel()
+		return n, err
+	}
+	if header.Version != 1 {
+		err := fmt.Errorf("invalid version number received from external method: %d", header.Version)
+		i.err = err
+		i.cancel()
+		return n, err
+	}
+	i.headerFinished = true
+	i.output = []byte(parts[1])
+	return n, nil
+}
+
+func (i *ioHandler) Read(p []byte) (int, error) {
+	return i.input.Read(p)
+}
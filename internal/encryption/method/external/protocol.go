@@ -0,0 +1,33 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package external
+
+// HeaderMagic is the magic string that needs to be present in the header to identify
+// the external program as an external encryption method for OpenTofu.
+const HeaderMagic = "OpenTofu-External-Encryption-Method"
+
+// Header describes the initial header the external program must output as a single line,
+// followed by a single newline.
+type Header struct {
+	// Magic must always be "OpenTofu-External-Encryption-Method".
+	Magic string `json:"magic"`
+	// Version is the protocol version number. This currently must be 1.
+	Version int `json:"version"`
+}
+
+// InputV1 describes the input datastructure passed in over stdin.
+// This structure is valid for protocol version 1.
+type InputV1 struct {
+	// Key is the encryption or decryption key, if the method is configured with keys.
+	Key []byte `json:"key,omitempty"`
+	// Payload is the data to encrypt or decrypt.
+	Payload []byte `json:"payload"`
+}
+
+// OutputV1 describes the output datastructure written to stdout by the external program.
+// This structure is valid for protocol version 1.
+type OutputV1 struct {
+	// Payload is the encrypted or decrypted data.
+	Payload []byte `json:"payload"`
+}
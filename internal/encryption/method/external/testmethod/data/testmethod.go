@@ -0,0 +1,86 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+)
+
+// Header is the initial line that needs to be written as JSON when the program starts.
+type Header struct {
+	Magic   string `json:"magic"`
+	Version int    `json:"version"`
+}
+
+// Input is the input data received from OpenTofu on the standard input.
+type Input struct {
+	Key     []byte `json:"key,omitempty"`
+	Payload []byte `json:"payload"`
+}
+
+// Output is the data structure that should be written to the output.
+type Output struct {
+	Payload []byte `json:"payload"`
+}
+
+// marker is prepended to the payload before encryption so that decryption can detect data it didn't encrypt.
+var marker = []byte("TEST")
+
+// xor is a trivial stand-in for a real cipher. Never use this for anything but testing.
+func xor(data []byte, key []byte) []byte {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		if len(key) > 0 {
+			b ^= key[i%len(key)]
+		}
+		result[i] = b
+	}
+	return result
+}
+
+func main() {
+	if len(os.Args) != 2 || (os.Args[1] != "--encrypt" && os.Args[1] != "--decrypt") {
+		log.Fatalf("Usage: %s --encrypt|--decrypt", os.Args[0])
+	}
+
+	header := Header{
+		"OpenTofu-External-Encryption-Method",
+		1,
+	}
+	marshalledHeader, err := json.Marshal(header)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	_, _ = os.Stdout.Write(append(marshalledHeader, []byte("\n")...))
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalf("Failed to read stdin: %v", err)
+	}
+	var input Input
+	if err := json.Unmarshal(data, &input); err != nil {
+		log.Fatalf("Failed to parse stdin: %v", err)
+	}
+
+	var output Output
+	if os.Args[1] == "--encrypt" {
+		output.Payload = xor(append(append([]byte{}, marker...), input.Payload...), input.Key)
+	} else {
+		decrypted := xor(input.Payload, input.Key)
+		if !bytes.HasPrefix(decrypted, marker) {
+			log.Fatalf("The payload was not encrypted by this method.")
+		}
+		output.Payload = decrypted[len(marker):]
+	}
+
+	outputData, err := json.Marshal(output)
+	if err != nil {
+		log.Fatalf("Failed to stringify output: %v", err)
+	}
+	_, _ = os.Stdout.Write(outputData)
+}
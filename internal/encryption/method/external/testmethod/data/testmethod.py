@@ -0,0 +1,43 @@
+#!/usr/bin/python
+# Copyright (c) The OpenTofu Authors
+# SPDX-License-Identifier: MPL-2.0
+
+import base64
+import json
+import sys
+
+MARKER = b"TEST"
+
+
+def xor(data, key):
+    """A trivial stand-in for a real cipher. Never use this for anything but testing."""
+    if not key:
+        return bytes(data)
+    return bytes(b ^ key[i % len(key)] for i, b in enumerate(data))
+
+
+if __name__ == "__main__":
+    if len(sys.argv) != 2 or sys.argv[1] not in ("--encrypt", "--decrypt"):
+        sys.stderr.write("Usage: testmethod.py --encrypt|--decrypt\n")
+        sys.exit(1)
+
+    # Write the header:
+    sys.stdout.write((json.dumps({"magic": "OpenTofu-External-Encryption-Method", "version": 1}) + "\n"))
+    sys.stdout.flush()
+
+    # Read the input:
+    inputData = json.loads(sys.stdin.read())
+    key = base64.b64decode(inputData["key"]) if "key" in inputData else b""
+    payload = base64.b64decode(inputData["payload"])
+
+    if sys.argv[1] == "--encrypt":
+        result = xor(MARKER + payload, key)
+    else:
+        decrypted = xor(payload, key)
+        if not decrypted.startswith(MARKER):
+            sys.stderr.write("The payload was not encrypted by this method.\n")
+            sys.exit(1)
+        result = decrypted[len(MARKER):]
+
+    # Write the output:
+    sys.stdout.write(json.dumps({"payload": base64.b64encode(result).decode("ascii")}))
@@ -0,0 +1,27 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package method
+
+import "io"
+
+// StreamMethod is an optional interface for methods that can encrypt and decrypt data in chunks, without holding the
+// whole plaintext and encrypted form in memory at the same time.
+//
+// The streamed encrypted form may differ from the one produced by Encrypt, so data encrypted with EncryptStream must
+// be decrypted with DecryptStream.
+type StreamMethod interface {
+	Method
+
+	// EncryptStream returns a writer that encrypts the data written to it and writes the encrypted form to dst. The
+	// caller must call Close on the returned writer to finish the encrypted form, but Close doesn't close dst.
+	EncryptStream(dst io.Writer) (io.WriteCloser, error)
+
+	// DecryptStream returns a reader that decrypts the encrypted form read from src. The reader returns an
+	// ErrDecryptionFailed if any part of the data fails authentication, or if the data is truncated. Truncation can
+	// only be detected at the end of the data, so callers must read until io.EOF before acting on the data.
+	//
+	// The returned reader must not depend on the keys of the method after DecryptStream returns, because they may be
+	// zeroized (see Zeroizer) while the data is still being read.
+	DecryptStream(src io.Reader) (io.Reader, error)
+}
@@ -0,0 +1,13 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package method
+
+// Zeroizer is an optional interface for methods that hold key material in memory. OpenTofu calls Zeroize when it
+// discards a method that it kept around for reuse, such as one built for decryption.
+type Zeroizer interface {
+	Method
+
+	// Zeroize overwrites the key material held by the method. The method must not be used afterwards.
+	Zeroize()
+}
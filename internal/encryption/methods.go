@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
 	"github.com/opentofu/opentofu/internal/encryption/method"
 	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
 	"github.com/opentofu/opentofu/internal/encryption/registry"
@@ -81,6 +82,23 @@ func (e *targetBuilder) setupMethod(cfg config.MethodConfig) hcl.Diagnostics {
 	}
 
 	e.methodValues[cfg.Type][cfg.Name] = cty.StringVal(string(addr))
+
+	if contextual, ok := methodConfig.(method.ContextualConfig); ok {
+		// Methods store their metadata next to the key provider metadata, using their address as the key.
+		metaKey := keyprovider.MetaStorageKey(addr)
+		outputMeta, err := contextual.ApplyContext(e.methodCtx, e.inputKeyProviderMetadata[metaKey])
+		if err != nil {
+			return append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Encryption method configuration failed",
+				Detail:   err.Error(),
+			})
+		}
+		if outputMeta != nil {
+			e.outputKeyProviderMetadata[metaKey] = outputMeta
+		}
+	}
+
 	m, err := methodConfig.Build()
 	if err != nil {
 		// TODO this error handling could use some work
@@ -7,10 +7,12 @@ package encryption
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/method"
 )
 
 // PlanEncryption describes the methods that you can use for encrypting a plan file. Plan files are opaque values with
@@ -44,14 +46,26 @@ type PlanEncryption interface {
 	// Pass a potentially encrypted plan file as an input, and you will receive the decrypted plan file or an error as
 	// a result.
 	DecryptPlan([]byte) ([]byte, error)
+
+	// EncryptPlanStream returns a writer that encrypts the plan file written to it and writes the encrypted form to
+	// dst, without holding both the plan file and its encrypted form in memory if the encryption method supports
+	// streaming. The caller must close the returned writer to finish the encrypted form. This does not close dst.
+	EncryptPlanStream(dst io.Writer) (io.WriteCloser, error)
+
+	// DecryptPlanStream returns a reader for the decrypted form of the potentially encrypted plan file read from src,
+	// following the same rules as DecryptPlan. The reader returns an error if the encrypted form fails
+	// authentication, so callers must read until io.EOF before using the plan file.
+	DecryptPlanStream(src io.Reader) (io.Reader, error)
 }
 
 type planEncryption struct {
 	base *baseEncryption
 }
 
-func newPlanEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, staticEval *configs.StaticEvaluator) (PlanEncryption, hcl.Diagnostics) {
-	base, diags := newBaseEncryption(enc, target, enforced, name, staticEval)
+// newPlanEncryption creates the encryption for the plan target in the given workspace.
+func newPlanEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, workspace string, staticEval *configs.StaticEvaluator) (PlanEncryption, hcl.Diagnostics) {
+	methodCtx := method.Context{Target: method.TargetPlan, Workspace: workspace}
+	base, diags := newBaseEncryption(enc, target, enforced, name, methodCtx, staticEval)
 	return &planEncryption{base}, diags
 }
 
@@ -70,6 +84,14 @@ func (p planEncryption) DecryptPlan(data []byte) ([]byte, error) {
 	return data, err
 }
 
+func (p planEncryption) EncryptPlanStream(dst io.Writer) (io.WriteCloser, error) {
+	return p.base.encryptStream(dst, p.EncryptPlan)
+}
+
+func (p planEncryption) DecryptPlanStream(src io.Reader) (io.Reader, error) {
+	return p.base.decryptStream(src, p.DecryptPlan)
+}
+
 func PlanEncryptionDisabled() PlanEncryption {
 	return &planDisabled{}
 }
@@ -82,3 +104,9 @@ func (s *planDisabled) EncryptPlan(plainPlan []byte) ([]byte, error) {
 func (s *planDisabled) DecryptPlan(encryptedPlan []byte) ([]byte, error) {
 	return encryptedPlan, nil
 }
+func (s *planDisabled) EncryptPlanStream(dst io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{dst}, nil
+}
+func (s *planDisabled) DecryptPlanStream(src io.Reader) (io.Reader, error) {
+	return src, nil
+}
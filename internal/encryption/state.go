@@ -8,10 +8,12 @@ package encryption
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/method"
 )
 
 // StateEncryption describes the interface for encrypting state files.
@@ -56,11 +58,55 @@ type StateEncryption interface {
 
 type stateEncryption struct {
 	base *baseEncryption
+
+	// workspaces holds the encryption for the states of other workspaces, built by forWorkspace.
+	workspacesLock sync.Mutex
+	workspaces     map[string]StateEncryption
+}
+
+// newStateEncryption creates the encryption for a state target. The workspace is the one the state belongs to, or
+// empty if it is not known.
+func newStateEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, workspace string, staticEval *configs.StaticEvaluator) (StateEncryption, hcl.Diagnostics) {
+	methodCtx := method.Context{Target: method.TargetState, Workspace: workspace}
+	base, diags := newBaseEncryption(enc, target, enforced, name, methodCtx, workspaceEvaluator(staticEval, workspace))
+	return &stateEncryption{base: base}, diags
 }
 
-func newStateEncryption(enc *encryption, target *config.TargetConfig, enforced bool, name string, staticEval *configs.StaticEvaluator) (StateEncryption, hcl.Diagnostics) {
-	base, diags := newBaseEncryption(enc, target, enforced, name, staticEval)
-	return &stateEncryption{base}, diags
+// StateForWorkspace returns the encryption for the state of the given workspace. The encryption configuration is
+// evaluated for the selected workspace, so the state managers of a backend must use this to get the encryption for
+// the workspace they are for. Otherwise, the keys of key providers with per_workspace enabled and the context bound
+// by methods such as aes_gcm would be those of the selected workspace.
+//
+// If the encryption for the workspace can't be built, the returned StateEncryption fails all reads and writes with
+// the reason.
+func StateForWorkspace(enc StateEncryption, workspace string) StateEncryption {
+	if s, ok := enc.(*stateEncryption); ok {
+		return s.forWorkspace(workspace)
+	}
+	// Disabled and enforced encryption don't depend on the workspace.
+	return enc
+}
+
+func (s *stateEncryption) forWorkspace(workspace string) StateEncryption {
+	if workspace == s.base.methodCtx.Workspace {
+		return s
+	}
+
+	s.workspacesLock.Lock()
+	defer s.workspacesLock.Unlock()
+	if enc, ok := s.workspaces[workspace]; ok {
+		return enc
+	}
+
+	enc, diags := newStateEncryption(s.base.enc, s.base.target, s.base.enforced, s.base.name, workspace, s.base.staticEval)
+	if diags.HasErrors() {
+		enc = &stateFailed{err: fmt.Errorf("unable to set up the encryption for the state of the workspace %q: %w", workspace, diags)}
+	}
+	if s.workspaces == nil {
+		s.workspaces = make(map[string]StateEncryption)
+	}
+	s.workspaces[workspace] = enc
+	return enc
 }
 
 type statedata struct {
@@ -133,6 +179,20 @@ func (s *stateEncryption) DecryptState(encryptedState []byte) ([]byte, Encryptio
 	return decryptedState, status, nil
 }
 
+// stateFailed is a StateEncryption for a target whose encryption couldn't be set up, which refuses every read and
+// write with the reason.
+type stateFailed struct {
+	err error
+}
+
+func (s *stateFailed) EncryptState(_ []byte) ([]byte, error) {
+	return nil, s.err
+}
+
+func (s *stateFailed) DecryptState(_ []byte) ([]byte, EncryptionStatus, error) {
+	return nil, StatusUnknown, s.err
+}
+
 func StateEncryptionDisabled() StateEncryption {
 	return &stateDisabled{}
 }
@@ -0,0 +1,273 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/method"
+	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
+)
+
+// encryptionVersionStream is the version of payloads whose encrypted data was produced by a method.StreamMethod.
+//
+// The payload has the same JSON structure as the original format, but its fields are always written in a fixed order
+// with the encrypted data last, so that it can be written and read without holding the whole payload in memory:
+//
+//	{"encryption_version":"v1","meta":{...},"encrypted_data":"..."}
+const encryptionVersionStream = "v1"
+
+var (
+	streamPayloadPrefix = []byte(`{"encryption_version":"` + encryptionVersionStream + `","meta":`)
+	streamDataPrefix    = []byte(`,"encrypted_data":"`)
+	streamPayloadSuffix = []byte(`"}`)
+)
+
+// encryptStream returns a writer that encrypts the data written to it with the primary method of the target and
+// writes the payload to dst. If the method doesn't support streaming, the data is buffered and passed to
+// encryptBytes on Close instead.
+func (base *baseEncryption) encryptStream(dst io.Writer, encryptBytes func([]byte) ([]byte, error)) (io.WriteCloser, error) {
+	// newBaseEncryption guarantees that there will be at least one encryption method.
+	encryptor := base.encMethods[0]
+
+	if unencrypted.Is(encryptor) {
+		return nopWriteCloser{dst}, nil
+	}
+	streamer, ok := encryptor.(method.StreamMethod)
+	if !ok {
+		return &bufferedEncryptor{dst: dst, encrypt: encryptBytes}, nil
+	}
+
+	meta, err := json.Marshal(base.outputEncMeta)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode encryption metadata as json: %w", err)
+	}
+	header := make([]byte, 0, len(streamPayloadPrefix)+len(meta)+len(streamDataPrefix))
+	header = append(header, streamPayloadPrefix...)
+	header = append(header, meta...)
+	header = append(header, streamDataPrefix...)
+	if _, err := dst.Write(header); err != nil {
+		return nil, err
+	}
+
+	encoder := base64.NewEncoder(base64.StdEncoding, dst)
+	w, err := streamer.EncryptStream(encoder)
+	if err != nil {
+		return nil, fmt.Errorf("encryption failed for %s: %w", base.name, err)
+	}
+	return &streamEncryptor{
+		name:    base.name,
+		dst:     dst,
+		encoder: encoder,
+		w:       w,
+	}, nil
+}
+
+// decryptStream returns a reader for the decrypted form of the payload read from src. Payloads that are not in the
+// streamed format are read fully and passed to decryptBytes instead.
+func (base *baseEncryption) decryptStream(src io.Reader, decryptBytes func([]byte) ([]byte, error)) (io.Reader, error) {
+	r := bufio.NewReader(src)
+	prefix, _ := r.Peek(len(streamPayloadPrefix))
+	if !bytes.Equal(prefix, streamPayloadPrefix) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		decrypted, err := decryptBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(decrypted), nil
+	}
+
+	if _, err := r.Discard(len(streamPayloadPrefix)); err != nil {
+		return nil, err
+	}
+	var meta map[keyprovider.MetaStorageKey][]byte
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&meta); err != nil {
+		return nil, fmt.Errorf("invalid encryption metadata in encrypted payload: %w", err)
+	}
+	r = bufio.NewReader(io.MultiReader(decoder.Buffered(), r))
+	dataPrefix := make([]byte, len(streamDataPrefix))
+	if _, err := io.ReadFull(r, dataPrefix); err != nil || !bytes.Equal(dataPrefix, streamDataPrefix) {
+		return nil, fmt.Errorf("invalid data format for decryption: missing encrypted data")
+	}
+	data := base64.NewDecoder(base64.StdEncoding, &streamDataReader{src: r})
+
+	cached, err := base.decryptionMethods(meta)
+	if err != nil {
+		return nil, err
+	}
+	// The methods derive everything they need from their keys when a stream is created, so the keys may be zeroized
+	// once we return, even if the caller is still reading the stream.
+	defer base.decryptionCache.release(cached)
+	var candidates []method.Method
+	for _, m := range cached.methods {
+		if !unencrypted.Is(m) {
+			candidates = append(candidates, m)
+		}
+	}
+
+	if len(candidates) == 1 {
+		// With a single method, there is no need to keep the encrypted data around for a retry.
+		streamer, ok := candidates[0].(method.StreamMethod)
+		if !ok {
+			return nil, fmt.Errorf("decryption failed for %s: the configured method does not support the %s format", base.name, encryptionVersionStream)
+		}
+		decrypted, err := streamer.DecryptStream(data)
+		if err != nil {
+			return nil, fmt.Errorf("decryption failed for %s: %w", base.name, err)
+		}
+		return &errorPrefixReader{r: decrypted, prefix: "decryption failed for " + base.name}, nil
+	}
+
+	// With fallback methods configured, each attempt needs to start from the beginning of the encrypted data.
+	encrypted, err := io.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data format for decryption: %w", err)
+	}
+	errs := make([]error, 0, len(candidates))
+	for _, m := range candidates {
+		decrypted, err := decryptData(m, encryptionVersionStream, encrypted)
+		if err == nil {
+			return bytes.NewReader(decrypted), nil
+		}
+		errs = append(errs, fmt.Errorf("attempted decryption failed for %s: %w", base.name, err))
+	}
+	return nil, fmt.Errorf("decryption failed for all provided methods: %w", errors.Join(errs...))
+}
+
+// decryptData decrypts the encrypted data of a payload of the given version with the given method.
+func decryptData(m method.Method, version string, data []byte) ([]byte, error) {
+	if version != encryptionVersionStream {
+		return m.Decrypt(data)
+	}
+	streamer, ok := m.(method.StreamMethod)
+	if !ok {
+		return nil, fmt.Errorf("the method does not support the %s format", version)
+	}
+	r, err := streamer.DecryptStream(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+type streamEncryptor struct {
+	name    string
+	dst     io.Writer
+	encoder io.WriteCloser
+	w       io.WriteCloser
+}
+
+func (s *streamEncryptor) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("encryption failed for %s: %w", s.name, err)
+	}
+	return n, nil
+}
+
+func (s *streamEncryptor) Close() error {
+	if err := s.w.Close(); err != nil {
+		return fmt.Errorf("encryption failed for %s: %w", s.name, err)
+	}
+	if err := s.encoder.Close(); err != nil {
+		return err
+	}
+	_, err := s.dst.Write(streamPayloadSuffix)
+	return err
+}
+
+// bufferedEncryptor collects all data written to it and encrypts it in one piece on Close.
+type bufferedEncryptor struct {
+	dst     io.Writer
+	encrypt func([]byte) ([]byte, error)
+	buf     bytes.Buffer
+}
+
+func (b *bufferedEncryptor) Write(p []byte) (int, error) {
+	return b.buf.Write(p)
+}
+
+func (b *bufferedEncryptor) Close() error {
+	encrypted, err := b.encrypt(b.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = b.dst.Write(encrypted)
+	return err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// streamDataReader reads the base64-encoded encrypted data of a streamed payload up to its closing quote, and then
+// makes sure that nothing but the end of the payload follows.
+type streamDataReader struct {
+	src  *bufio.Reader
+	done bool
+}
+
+func (s *streamDataReader) Read(p []byte) (int, error) {
+	if s.done {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if _, err := s.src.Peek(1); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("invalid data format for decryption: the encrypted data is truncated")
+		}
+		return 0, err
+	}
+	buf, _ := s.src.Peek(min(len(p), s.src.Buffered()))
+	end := bytes.IndexByte(buf, '"')
+	if end < 0 {
+		n := copy(p, buf)
+		_, _ = s.src.Discard(n)
+		return n, nil
+	}
+	n := copy(p, buf[:end])
+	_, _ = s.src.Discard(end + 1)
+	s.done = true
+	// The closing brace is all that may follow, apart from whitespace.
+	rest, err := io.ReadAll(io.LimitReader(s.src, 64)) //nolint:mnd // Any more than a little whitespace is invalid anyway.
+	if err != nil {
+		return n, err
+	}
+	if strings.TrimSpace(string(rest)) != "}" {
+		return n, fmt.Errorf("invalid data format for decryption: unexpected data after the encrypted data")
+	}
+	return n, nil
+}
+
+// errorPrefixReader adds a prefix to all errors returned by the wrapped reader, except io.EOF.
+type errorPrefixReader struct {
+	r      io.Reader
+	prefix string
+}
+
+func (e *errorPrefixReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("%s: %w", e.prefix, err)
+	}
+	return n, err
+}
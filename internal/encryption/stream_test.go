@@ -0,0 +1,202 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/static"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/method/unencrypted"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+func TestPlanEncryptionStream(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(static.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterMethod(unencrypted.New()); err != nil {
+		t.Fatal(err)
+	}
+	staticEval := configs.NewStaticEvaluator(&configs.Module{}, configs.RootModuleCallForTesting())
+
+	newPlanEncryption := func(t *testing.T, plan string) PlanEncryption {
+		t.Helper()
+		cfg, diags := config.LoadConfigFromString("Test Config Source", `
+			key_provider "static" "old" {
+				key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+			}
+			key_provider "static" "new" {
+				key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653170"
+			}
+			method "aes_gcm" "old" {
+				keys = key_provider.static.old
+			}
+			method "aes_gcm" "new" {
+				keys = key_provider.static.new
+			}
+			method "unencrypted" "migrate" {}
+		`+plan)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		enc, diags := New(reg, cfg, staticEval)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		return enc.Plan()
+	}
+
+	// The payload spans multiple chunks of the AES-GCM streamed form.
+	plainPlan := append([]byte("PK"), bytes.Repeat([]byte("plan data "), 20000)...)
+
+	old := newPlanEncryption(t, `plan { method = method.aes_gcm.old }`)
+	var buf bytes.Buffer
+	w, err := old.EncryptPlanStream(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(plainPlan); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	encrypted := buf.Bytes()
+	if !bytes.HasPrefix(encrypted, streamPayloadPrefix) {
+		t.Fatalf("encrypted plan is not in the streamed format: %.50s", encrypted)
+	}
+	if ok, err := IsEncryptionPayload(encrypted); !ok || err != nil {
+		t.Fatalf("encrypted plan not recognized as an encryption payload: %v", err)
+	}
+
+	decryptStream := func(enc PlanEncryption, data []byte) ([]byte, error) {
+		r, err := enc.DecryptPlanStream(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	}
+
+	t.Run("stream", func(t *testing.T) {
+		decrypted, err := decryptStream(old, encrypted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, plainPlan) {
+			t.Fatal("incorrect decrypted plan")
+		}
+	})
+
+	t.Run("cached methods", func(t *testing.T) {
+		enc := newPlanEncryption(t, `plan { method = method.aes_gcm.old }`)
+		base := enc.(*planEncryption).base
+		r, err := enc.DecryptPlanStream(bytes.NewReader(encrypted))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(base.decryptionCache.entries); got != 1 {
+			t.Fatalf("wrong number of cached method sets %d; want 1", got)
+		}
+
+		// The stream must remain readable after its keys are zeroized.
+		ZeroizeCachedKeys()
+		decrypted, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, plainPlan) {
+			t.Fatal("incorrect decrypted plan")
+		}
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		decrypted, err := old.DecryptPlan(encrypted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, plainPlan) {
+			t.Fatal("incorrect decrypted plan")
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		rollover := newPlanEncryption(t, `plan {
+			method = method.aes_gcm.new
+			fallback {
+				method = method.aes_gcm.old
+			}
+		}`)
+		decrypted, err := decryptStream(rollover, encrypted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, plainPlan) {
+			t.Fatal("incorrect decrypted plan")
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := decryptStream(newPlanEncryption(t, `plan { method = method.aes_gcm.new }`), encrypted)
+		if err == nil {
+			t.Fatal("decrypted plan with the wrong key")
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		truncated := bytes.TrimSuffix(encrypted, streamPayloadSuffix)
+		_, err := decryptStream(old, truncated)
+		if err == nil || !strings.Contains(err.Error(), "truncated") {
+			t.Fatalf("expected truncation error, got %v", err)
+		}
+	})
+
+	t.Run("v0 payload", func(t *testing.T) {
+		v0, err := old.EncryptPlan(plainPlan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := decryptStream(old, v0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, plainPlan) {
+			t.Fatal("incorrect decrypted plan")
+		}
+	})
+
+	t.Run("unencrypted", func(t *testing.T) {
+		migrate := newPlanEncryption(t, `plan { method = method.unencrypted.migrate }`)
+		var buf bytes.Buffer
+		w, err := migrate.EncryptPlanStream(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(plainPlan); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), plainPlan) {
+			t.Fatal("unencrypted plan was modified")
+		}
+		decrypted, err := decryptStream(migrate, buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, plainPlan) {
+			t.Fatal("incorrect decrypted plan")
+		}
+	})
+}
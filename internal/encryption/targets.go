@@ -33,6 +33,7 @@ type targetBuilder struct {
 	keyValues    map[string]map[string]cty.Value
 	methodValues map[string]map[string]cty.Value
 	methods      map[method.Addr]method.Method
+	methodCtx    method.Context
 	staticEval   *configs.StaticEvaluator
 }
 
@@ -43,6 +44,7 @@ func (base *baseEncryption) buildTargetMethods(inputMeta map[keyprovider.MetaSto
 		cfg: base.enc.cfg,
 		reg: base.enc.reg,
 
+		methodCtx:  base.methodCtx,
 		staticEval: base.staticEval,
 		ctx: &hcl.EvalContext{
 			Variables: map[string]cty.Value{},
@@ -0,0 +1,60 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+)
+
+// workspace returns the name of the workspace the data being encrypted or decrypted belongs to, or an empty string if
+// it is not known.
+func (e *targetBuilder) workspace() string {
+	return e.methodCtx.Workspace
+}
+
+// workspaceEvaluator returns a static evaluator that evaluates the configuration for the given workspace.
+func workspaceEvaluator(staticEval *configs.StaticEvaluator, workspace string) *configs.StaticEvaluator {
+	if staticEval == nil || staticEval.Workspace() == workspace {
+		return staticEval
+	}
+	return staticEval.ForWorkspace(workspace)
+}
+
+// deriveWorkspaceOutput derives new keys from the output of a key provider with per_workspace enabled, using
+// HKDF-SHA256 with the workspace name as context. This makes the same key provider configuration yield distinct keys
+// in each workspace, so that ciphertext from one workspace can't be decrypted in another.
+//
+// The derived keys have the same length as the original ones, and empty keys remain empty.
+func deriveWorkspaceOutput(output keyprovider.Output, workspace string) (keyprovider.Output, error) {
+	encryptionKey, err := deriveWorkspaceKey(output.EncryptionKey, workspace)
+	if err != nil {
+		return keyprovider.Output{}, err
+	}
+	decryptionKey, err := deriveWorkspaceKey(output.DecryptionKey, workspace)
+	if err != nil {
+		return keyprovider.Output{}, err
+	}
+	return keyprovider.Output{
+		EncryptionKey: encryptionKey,
+		DecryptionKey: decryptionKey,
+	}, nil
+}
+
+func deriveWorkspaceKey(key []byte, workspace string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+	derived := make([]byte, len(key))
+	reader := hkdf.New(sha256.New, key, nil, []byte("opentofu workspace key: "+workspace))
+	if _, err := io.ReadFull(reader, derived); err != nil {
+		return nil, err
+	}
+	return derived, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption/config"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider"
+	"github.com/opentofu/opentofu/internal/encryption/keyprovider/static"
+	"github.com/opentofu/opentofu/internal/encryption/method/aesgcm"
+	"github.com/opentofu/opentofu/internal/encryption/registry/lockingencryptionregistry"
+)
+
+func TestDeriveWorkspaceOutput(t *testing.T) {
+	output := keyprovider.Output{
+		EncryptionKey: bytes.Repeat([]byte{1}, 32),
+	}
+
+	dev, err := deriveWorkspaceOutput(output, "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod, err := deriveWorkspaceOutput(output, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	devAgain, err := deriveWorkspaceOutput(output, "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dev.EncryptionKey) != len(output.EncryptionKey) {
+		t.Fatalf("derived key has length %d; want %d", len(dev.EncryptionKey), len(output.EncryptionKey))
+	}
+	if bytes.Equal(dev.EncryptionKey, output.EncryptionKey) {
+		t.Fatalf("derived key is identical to the original key")
+	}
+	if bytes.Equal(dev.EncryptionKey, prod.EncryptionKey) {
+		t.Fatalf("different workspaces produced the same key")
+	}
+	if !bytes.Equal(dev.EncryptionKey, devAgain.EncryptionKey) {
+		t.Fatalf("the same workspace produced different keys")
+	}
+	if dev.DecryptionKey != nil {
+		t.Fatalf("empty decryption key should remain empty")
+	}
+}
+
+func TestStateForWorkspace(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(static.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]string{
+		"per_workspace": `
+			key_provider "static" "basic" {
+				key           = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+				per_workspace = true
+			}
+			method "aes_gcm" "example" {
+				keys = key_provider.static.basic
+			}
+			state {
+				method = method.aes_gcm.example
+			}
+		`,
+		"bind_context": `
+			key_provider "static" "basic" {
+				key = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+			}
+			method "aes_gcm" "example" {
+				keys         = key_provider.static.basic
+				bind_context = true
+			}
+			state {
+				method = method.aes_gcm.example
+			}
+		`,
+	}
+	for name, src := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, diags := config.LoadConfigFromString("Test Config Source", src)
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			// newEncryption returns the encryption as it is set up when the given workspace is selected.
+			newEncryption := func(workspace string) Encryption {
+				call := configs.NewStaticModuleCall(addrs.RootModule, nil, "<testing>", workspace)
+				enc, diags := New(reg, cfg, configs.NewStaticEvaluator(&configs.Module{}, call))
+				if diags.HasErrors() {
+					t.Fatal(diags.Error())
+				}
+				return enc
+			}
+
+			// The state of another workspace, such as one being migrated, must be encrypted as if that
+			// workspace were selected.
+			selectedDev := newEncryption("dev")
+			prodState := StateForWorkspace(selectedDev.State(), "prod")
+			if StateForWorkspace(selectedDev.State(), "prod") != prodState {
+				t.Errorf("the encryption for a workspace is not reused")
+			}
+			encrypted, err := prodState.EncryptState([]byte(`{"serial": 1, "lineage": "abc", "terraform_version": "1.0.0"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := newEncryption("prod").State().DecryptState(encrypted); err != nil {
+				t.Errorf("the state can't be decrypted in its own workspace: %v", err)
+			}
+			if _, _, err := StateForWorkspace(newEncryption("other").State(), "prod").DecryptState(encrypted); err != nil {
+				t.Errorf("the state can't be decrypted for its own workspace: %v", err)
+			}
+			if _, _, err := selectedDev.State().DecryptState(encrypted); err == nil {
+				t.Errorf("the state of one workspace was decrypted as the state of another workspace")
+			}
+		})
+	}
+}
+
+func TestPerWorkspaceUnknownWorkspace(t *testing.T) {
+	reg := lockingencryptionregistry.New()
+	if err := reg.RegisterKeyProvider(static.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterMethod(aesgcm.New()); err != nil {
+		t.Fatal(err)
+	}
+	cfg, diags := config.LoadConfigFromString("Test Config Source", `
+		key_provider "static" "basic" {
+			key           = "6f6f706830656f67686f6834616872756f3751756165686565796f6f72653169"
+			per_workspace = true
+		}
+		method "aes_gcm" "example" {
+			keys = key_provider.static.basic
+		}
+		state {
+			method = method.aes_gcm.example
+		}
+	`)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	// Without a workspace there is no key to derive, which must not fall back to the key of some workspace.
+	_, diags = New(reg, cfg, configs.NewStaticEvaluator(&configs.Module{}, configs.RootModuleCallForTesting()))
+	if !diags.HasErrors() || !strings.Contains(diags.Error(), "per_workspace enabled, but the workspace the data belongs to is not known") {
+		t.Fatalf("expected an error for the unknown workspace, got: %v", diags)
+	}
+}
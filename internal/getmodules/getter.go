@@ -9,11 +9,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	getter "github.com/hashicorp/go-getter"
 	"github.com/opentofu/opentofu/internal/copy"
+	"github.com/opentofu/opentofu/internal/httpclient"
 )
 
 // We configure our own go-getter detector and getter sets here, because
@@ -78,14 +80,22 @@ var goGetterDecompressors = map[string]getter.Decompressor{
 var goGetterGetters = map[string]getter.Getter{
 	"file":  new(getter.FileGetter),
 	"gcs":   new(getter.GCSGetter),
-	"git":   new(getter.GitGetter),
+	"git":   new(gitGetter),
 	"hg":    new(getter.HgGetter),
 	"s3":    new(getter.S3Getter),
 	"http":  getterHTTPGetter,
 	"https": getterHTTPGetter,
 }
 
-var getterHTTPClient = cleanhttp.DefaultClient()
+var getterHTTPClient = newGetterHTTPClient()
+
+func newGetterHTTPClient() *http.Client {
+	client := cleanhttp.DefaultClient()
+	transport := client.Transport.(*http.Transport)
+	transport.Proxy = httpclient.Proxy
+	client.Transport = httpclient.WithClientCertificates(transport)
+	return client
+}
 
 var getterHTTPGetter = &getter.HttpGetter{
 	Client:             getterHTTPClient,
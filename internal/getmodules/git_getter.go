@@ -0,0 +1,263 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	getter "github.com/hashicorp/go-getter"
+)
+
+const (
+	// gitRetryEnvName is the name of the environment variable that selects
+	// how many times a git module fetch that failed for a transient reason,
+	// such as a dropped connection, is retried.
+	gitRetryEnvName = "TF_MODULE_GIT_RETRY"
+
+	defaultGitRetry = 2
+
+	// gitRetryInitialWait is the time to wait before the first retry of a
+	// git fetch, which doubles with each subsequent retry.
+	gitRetryInitialWait = time.Second
+)
+
+// gitGetter extends go-getter's git getter with some additional query string
+// arguments, which are removed before the URL is passed to git:
+//
+//   - "submodules=false" skips fetching the repository's submodules, which
+//     go-getter otherwise always fetches recursively.
+//   - "sparse" selects a directory of the repository to check out, leaving
+//     out the rest. It can be given more than once to select several
+//     directories, and is most useful for large repositories containing many
+//     modules. The files directly in the root of the repository are always
+//     checked out.
+//
+// These combine with go-getter's own "ref" and "depth" arguments, so for
+// example a shallow clone of a single directory of a repository also avoids
+// downloading the history of the rest of it.
+//
+// gitGetter also retries fetches that fail for reasons that seem likely to be
+// transient, up to the number of times selected by the TF_MODULE_GIT_RETRY
+// environment variable.
+type gitGetter struct {
+	getter.GitGetter
+}
+
+// gitFetchOptions are the query string arguments that control how gitGetter
+// fetches a repository.
+type gitFetchOptions struct {
+	ref        string
+	depth      int
+	submodules bool
+	sparse     []string
+}
+
+func (g *gitGetter) Get(dst string, u *url.URL) error {
+	opts, u, err := parseGitFetchOptions(u)
+	if err != nil {
+		return err
+	}
+
+	retries := configuredGitRetry()
+	wait := gitRetryInitialWait
+	for attempt := 0; ; attempt++ {
+		_, statErr := os.Stat(dst)
+		existed := statErr == nil
+
+		if opts.submodules && len(opts.sparse) == 0 {
+			// Without any of our additional arguments we leave everything
+			// to go-getter, so that the result is exactly as before.
+			err = g.GitGetter.Get(dst, u)
+		} else {
+			err = g.fetch(dst, u, opts)
+		}
+		if err == nil || attempt >= retries || !transientGitError(err) {
+			return err
+		}
+
+		log.Printf("[WARN] getmodules: fetching %s failed, so retrying in %s: %s", getter.RedactURL(u), wait, err)
+		if !existed {
+			// Remove any partial clone, so that the next attempt starts
+			// from a clean slate.
+			if err := os.RemoveAll(dst); err != nil {
+				return fmt.Errorf("failed to remove partial clone in %s: %w", dst, err)
+			}
+		}
+		select {
+		case <-time.After(wait):
+		case <-g.Context().Done():
+			return g.Context().Err()
+		}
+		wait *= 2
+	}
+}
+
+// fetch clones the repository at the given URL into dst, for the options
+// that go-getter's own git getter doesn't support.
+func (g *gitGetter) fetch(dst string, u *url.URL, opts gitFetchOptions) error {
+	ctx := g.Context()
+	if g.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.Timeout)
+		defer cancel()
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git must be available and on the PATH")
+	}
+
+	// The ref and depth arguments are still in the query string, for
+	// go-getter, so we must remove them before passing the URL to git.
+	q := u.Query()
+	q.Del("ref")
+	q.Del("depth")
+	repoURL := *u
+	repoURL.RawQuery = q.Encode()
+
+	args := []string{"clone"}
+	if opts.depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.depth))
+		if opts.ref != "" {
+			args = append(args, "--branch", opts.ref)
+		}
+	}
+	if len(opts.sparse) != 0 {
+		// Fetching the file contents lazily means that only the selected
+		// directories are downloaded, on servers that support it. Other
+		// servers ignore the filter and send everything.
+		args = append(args, "--filter=blob:none", "--sparse")
+	}
+	args = append(args, "--", repoURL.String(), dst)
+	if err := runGit(ctx, "", args...); err != nil {
+		if opts.depth > 0 && gitCommitIDRegex.MatchString(opts.ref) {
+			return fmt.Errorf("%w (note that setting 'depth' requires 'ref' to be a branch or tag name)", err)
+		}
+		return err
+	}
+
+	if len(opts.sparse) != 0 {
+		if err := runGit(ctx, dst, append([]string{"sparse-checkout", "set", "--"}, opts.sparse...)...); err != nil {
+			return err
+		}
+	}
+	if opts.depth < 1 && opts.ref != "" {
+		// Without --branch we're on the remote's default branch, so we
+		// must switch to the selected ref.
+		if err := runGit(ctx, dst, "checkout", opts.ref); err != nil {
+			return err
+		}
+	}
+	if opts.submodules {
+		args := []string{"submodule", "update", "--init", "--recursive"}
+		if opts.depth > 0 {
+			args = append(args, "--depth", strconv.Itoa(opts.depth))
+		}
+		if err := runGit(ctx, dst, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseGitFetchOptions extracts the arguments that gitGetter handles from the
+// query string of the given URL, returning a copy of the URL without them.
+// The "ref" and "depth" arguments are left in place for go-getter.
+func parseGitFetchOptions(u *url.URL) (gitFetchOptions, *url.URL, error) {
+	opts := gitFetchOptions{submodules: true}
+	q := u.Query()
+
+	opts.ref = q.Get("ref")
+	if v := q.Get("depth"); v != "" {
+		// go-getter ignores an invalid depth, so we do too.
+		opts.depth, _ = strconv.Atoi(v)
+	}
+
+	if q.Has("submodules") {
+		submodules, err := strconv.ParseBool(q.Get("submodules"))
+		if err != nil {
+			return opts, nil, fmt.Errorf("invalid value %q for the submodules argument: must be true or false", q.Get("submodules"))
+		}
+		opts.submodules = submodules
+		q.Del("submodules")
+	}
+
+	for _, dir := range q["sparse"] {
+		clean := path.Clean(strings.Trim(dir, "/"))
+		if dir == "" || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return opts, nil, fmt.Errorf("invalid sparse directory %q: must be a subdirectory of the repository", dir)
+		}
+		opts.sparse = append(opts.sparse, clean)
+	}
+	q.Del("sparse")
+
+	if q.Has("sshkey") && (!opts.submodules || len(opts.sparse) != 0) {
+		return opts, nil, fmt.Errorf("the sshkey argument can't be combined with the submodules or sparse arguments")
+	}
+
+	ret := *u
+	ret.RawQuery = q.Encode()
+	return opts, &ret, nil
+}
+
+// configuredGitRetry returns the number of times to retry a git fetch that
+// failed for a transient reason.
+func configuredGitRetry() int {
+	if v := os.Getenv(gitRetryEnvName); v != "" {
+		retry, err := strconv.Atoi(v)
+		if err == nil && retry >= 0 {
+			return retry
+		}
+	}
+	return defaultGitRetry
+}
+
+// transientGitErrorRegexp matches the messages that git prints for failures
+// that are likely to succeed if retried, such as network interruptions and
+// server errors. Failures such as authentication errors and missing
+// repositories or refs are not retried.
+var transientGitErrorRegexp = regexp.MustCompile(`(?i)(could not resolve host|connection (timed out|reset|refused)|operation timed out|early eof|the remote end hung up unexpectedly|rpc failed|unexpected disconnect|gnutls_handshake|the requested url returned error: (5\d\d|429))`)
+
+func transientGitError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return transientGitErrorRegexp.MatchString(err.Error())
+}
+
+// gitCommitIDRegex matches strings that seem likely to be git commit IDs
+// rather than named refs, matching the heuristic go-getter uses.
+var gitCommitIDRegex = regexp.MustCompile("^[0-9a-fA-F]{7,40}$")
+
+// runGit runs git with the given arguments in the given directory, returning
+// an error including git's output if it fails.
+func runGit(ctx context.Context, dir string, args ...string) error {
+	var buf bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("git exited with %d: %s", exitErr.ExitCode(), buf.String())
+		}
+		return fmt.Errorf("error running git: %w", err)
+	}
+	return nil
+}
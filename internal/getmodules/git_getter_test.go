@@ -0,0 +1,154 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGitFetchOptions(t *testing.T) {
+	tests := map[string]struct {
+		URL       string
+		Want      gitFetchOptions
+		WantURL   string
+		WantError bool
+	}{
+		"no arguments": {
+			URL:     "https://example.com/repo.git",
+			Want:    gitFetchOptions{submodules: true},
+			WantURL: "https://example.com/repo.git",
+		},
+		"go-getter arguments are kept": {
+			URL:     "https://example.com/repo.git?depth=1&ref=v1.0.0",
+			Want:    gitFetchOptions{ref: "v1.0.0", depth: 1, submodules: true},
+			WantURL: "https://example.com/repo.git?depth=1&ref=v1.0.0",
+		},
+		"submodules and sparse": {
+			URL: "https://example.com/repo.git?ref=main&submodules=false&sparse=modules/vpc/&sparse=/modules/shared",
+			Want: gitFetchOptions{
+				ref:    "main",
+				sparse: []string{"modules/vpc", "modules/shared"},
+			},
+			WantURL: "https://example.com/repo.git?ref=main",
+		},
+		"invalid submodules": {
+			URL:       "https://example.com/repo.git?submodules=no",
+			WantError: true,
+		},
+		"sparse outside repository": {
+			URL:       "https://example.com/repo.git?sparse=modules/../..",
+			WantError: true,
+		},
+		"sparse root": {
+			URL:       "https://example.com/repo.git?sparse=/",
+			WantError: true,
+		},
+		"sshkey with sparse": {
+			URL:       "ssh://git@example.com/repo.git?sshkey=a2V5&sparse=modules",
+			WantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(test.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, gotURL, err := parseGitFetchOptions(u)
+			if test.WantError {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.Want, got, cmp.AllowUnexported(gitFetchOptions{})); diff != "" {
+				t.Errorf("wrong options\n%s", diff)
+			}
+			if gotURL.String() != test.WantURL {
+				t.Errorf("wrong URL %q; want %q", gotURL, test.WantURL)
+			}
+		})
+	}
+}
+
+func TestTransientGitError(t *testing.T) {
+	tests := map[string]bool{
+		"git exited with 128: fatal: unable to access 'https://example.com/repo.git/': Could not resolve host: example.com":   true,
+		"git exited with 128: fatal: the remote end hung up unexpectedly":                                                     true,
+		"git exited with 128: fatal: unable to access 'https://example.com/repo.git/': The requested URL returned error: 503": true,
+		"git exited with 128: fatal: unable to access 'https://example.com/repo.git/': The requested URL returned error: 403": false,
+		"git exited with 128: fatal: repository 'https://example.com/repo.git/' not found":                                    false,
+		"git exited with 1: error: pathspec 'v9.9.9' did not match any file(s) known to git":                                  false,
+	}
+	for msg, want := range tests {
+		if got := transientGitError(errors.New(msg)); got != want {
+			t.Errorf("wrong result %t for %q", got, msg)
+		}
+	}
+}
+
+func TestGitGetterSparse(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s\n%s", args, err, out)
+		}
+	}
+	for _, name := range []string{"README.md", "modules/vpc/main.tf", "modules/dns/main.tf"} {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	git("tag", "v1.0.0")
+
+	u := &url.URL{
+		Scheme:   "file",
+		Path:     filepath.ToSlash(repo),
+		RawQuery: "ref=v1.0.0&depth=1&submodules=false&sparse=modules/vpc",
+	}
+	dst := filepath.Join(t.TempDir(), "module")
+	if err := new(gitGetter).Get(dst, u); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]bool{
+		"README.md":           true,
+		"modules/vpc/main.tf": true,
+		"modules/dns/main.tf": false,
+	} {
+		_, err := os.Stat(filepath.Join(dst, name))
+		if got := err == nil; got != want {
+			t.Errorf("%s present is %t; want %t", name, got, want)
+		}
+	}
+}
@@ -7,6 +7,11 @@ package getmodules
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/opentofu/opentofu/internal/copy"
 )
 
 // PackageFetcher is a low-level utility for fetching remote module packages
@@ -24,6 +29,13 @@ import (
 // live only for the duration of a single initialization process.
 type PackageFetcher struct {
 	getter reusingGetter
+
+	// vendor, if set, contains vendored copies of packages, which are used
+	// in preference to fetching them from their origins.
+	vendor *Vendor
+
+	// vendorInto, if set, receives a copy of each package fetched.
+	vendorInto *Vendor
 }
 
 func NewPackageFetcher() *PackageFetcher {
@@ -45,5 +57,37 @@ func NewPackageFetcher() *PackageFetcher {
 // caller must resolve that itself, possibly with the help of the
 // getmodules.SplitPackageSubdir and getmodules.ExpandSubdirGlobs functions.
 func (f *PackageFetcher) FetchPackage(ctx context.Context, instDir string, packageAddr string) error {
-	return f.getter.getWithGoGetter(ctx, instDir, packageAddr)
+	if f.vendor != nil {
+		if vendorDir, ok := f.vendor.PackageDir(packageAddr); ok {
+			log.Printf("[TRACE] getmodules: copying vendored %q from %s to %s", packageAddr, vendorDir, instDir)
+			if err := os.Mkdir(instDir, os.ModePerm); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", instDir, err)
+			}
+			if err := copy.CopyDir(instDir, vendorDir); err != nil {
+				return fmt.Errorf("failed to copy from %s to %s: %w", vendorDir, instDir, err)
+			}
+			return nil
+		}
+	}
+
+	if err := f.getter.getWithGoGetter(ctx, instDir, packageAddr); err != nil {
+		return err
+	}
+	if f.vendorInto != nil {
+		return f.vendorInto.AddPackage(packageAddr, instDir)
+	}
+	return nil
+}
+
+// UseVendor makes the fetcher copy packages from the given vendor directory,
+// when it contains them, instead of fetching them from their origins.
+func (f *PackageFetcher) UseVendor(v *Vendor) {
+	f.vendor = v
+}
+
+// VendorInto makes the fetcher add a copy of every package it fetches to the
+// given vendor directory. The caller must call Save on the Vendor once all
+// packages have been fetched.
+func (f *PackageFetcher) VendorInto(v *Vendor) {
+	f.vendorInto = v
 }
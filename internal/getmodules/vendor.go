@@ -0,0 +1,221 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/copy"
+)
+
+// VendorDir is the directory, relative to the root module directory, where
+// "tofu modules vendor" saves copies of the remote module packages that the
+// configuration depends on.
+var VendorDir = filepath.Join("vendor", "modules")
+
+// vendorManifestFilename is the name of the file within a vendor directory
+// that records the package address of each vendored package.
+const vendorManifestFilename = "modules.json"
+
+// Vendor is a directory containing copies of module packages, which the
+// module installer prefers over fetching the packages from their origins so
+// that a configuration can be initialized without network access.
+//
+// A Vendor's manifest is written only by Save, so a Vendor being populated
+// by a PackageFetcher is not visible to other processes until it is
+// complete.
+type Vendor struct {
+	dir      string
+	packages map[string]string
+	registry []VendoredRegistryModule
+}
+
+// VendoredRegistryModule records a version of a module from a module registry
+// whose package has been vendored, so that the version can be selected and
+// its package found without asking the registry.
+type VendoredRegistryModule struct {
+	// Source is the module's registry package address, without any
+	// subdirectory.
+	Source string `json:"source"`
+
+	// Version is the selected version of the module.
+	Version string `json:"version"`
+
+	// Location is the remote source address that the registry returned for
+	// the version. Its package, without any subdirectory, is the address
+	// that the vendored package is recorded under.
+	Location string `json:"location"`
+}
+
+type vendorManifest struct {
+	Packages        []vendorManifestPackage  `json:"packages"`
+	RegistryModules []VendoredRegistryModule `json:"registry_modules,omitempty"`
+}
+
+type vendorManifestPackage struct {
+	Source string `json:"source"`
+	Dir    string `json:"dir"`
+}
+
+// NewVendor returns an empty Vendor whose packages will be saved in the
+// given directory.
+func NewVendor(dir string) *Vendor {
+	return &Vendor{
+		dir:      dir,
+		packages: make(map[string]string),
+	}
+}
+
+// LoadVendor reads the manifest of the vendor directory at the given path.
+// It returns nil if the directory doesn't contain a manifest, which means
+// that the configuration has no vendored modules.
+func LoadVendor(dir string) (*Vendor, error) {
+	src, err := os.ReadFile(filepath.Join(dir, vendorManifestFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var manifest vendorManifest
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid vendored modules manifest %s: %w", filepath.Join(dir, vendorManifestFilename), err)
+	}
+
+	v := NewVendor(dir)
+	for _, pkg := range manifest.Packages {
+		clean := path.Clean(pkg.Dir)
+		if pkg.Source == "" || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") || path.IsAbs(clean) {
+			return nil, fmt.Errorf("invalid vendored modules manifest %s: package %q has invalid directory %q", filepath.Join(dir, vendorManifestFilename), pkg.Source, pkg.Dir)
+		}
+		v.packages[pkg.Source] = clean
+	}
+	v.registry = manifest.RegistryModules
+	return v, nil
+}
+
+// Dir returns the path of the vendor directory.
+func (v *Vendor) Dir() string {
+	return v.dir
+}
+
+// Len returns the number of packages in the vendor directory.
+func (v *Vendor) Len() int {
+	return len(v.packages)
+}
+
+// PackageDir returns the path of the vendored copy of the package at the
+// given address, or false if the package hasn't been vendored.
+func (v *Vendor) PackageDir(packageAddr string) (string, bool) {
+	dir, ok := v.packages[packageAddr]
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(v.dir, filepath.FromSlash(dir)), true
+}
+
+// AddPackage copies the package at the given address, which has been
+// installed in srcDir, into the vendor directory. It does nothing if the
+// package is already vendored.
+//
+// As with other package copies in OpenTofu, files and directories whose names
+// start with a dot, such as ".git", are not copied.
+func (v *Vendor) AddPackage(packageAddr, srcDir string) error {
+	if _, exists := v.packages[packageAddr]; exists {
+		return nil
+	}
+
+	dir := vendorPackageDir(packageAddr)
+	dst := filepath.Join(v.dir, filepath.FromSlash(dir))
+	if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dst, err)
+	}
+	if err := copy.CopyDir(dst, srcDir); err != nil {
+		return fmt.Errorf("failed to copy from %s to %s: %w", srcDir, dst, err)
+	}
+	v.packages[packageAddr] = dir
+	return nil
+}
+
+// RegistryModules returns the vendored versions of registry modules.
+func (v *Vendor) RegistryModules() []VendoredRegistryModule {
+	return v.registry
+}
+
+// AddRegistryModule records a vendored version of a registry module. The
+// module's package must also be added with AddPackage.
+func (v *Vendor) AddRegistryModule(mod VendoredRegistryModule) {
+	for _, existing := range v.registry {
+		if existing == mod {
+			return
+		}
+	}
+	v.registry = append(v.registry, mod)
+}
+
+// Save writes the manifest of the vendor directory, making its packages
+// available to later calls to LoadVendor.
+func (v *Vendor) Save() error {
+	manifest := vendorManifest{
+		Packages:        make([]vendorManifestPackage, 0, len(v.packages)),
+		RegistryModules: v.registry,
+	}
+	for source, dir := range v.packages {
+		manifest.Packages = append(manifest.Packages, vendorManifestPackage{
+			Source: source,
+			Dir:    dir,
+		})
+	}
+	sort.Slice(manifest.Packages, func(i, j int) bool {
+		return manifest.Packages[i].Source < manifest.Packages[j].Source
+	})
+	sort.Slice(manifest.RegistryModules, func(i, j int) bool {
+		a, b := manifest.RegistryModules[i], manifest.RegistryModules[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Version < b.Version
+	})
+
+	src, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(v.dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", v.dir, err)
+	}
+	return os.WriteFile(filepath.Join(v.dir, vendorManifestFilename), append(src, '\n'), 0644)
+}
+
+var vendorDirNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// vendorPackageDir returns the directory, relative to the vendor directory,
+// for the package at the given address. The directory is named after the
+// last path segment of the address, to help humans find their way around,
+// followed by a hash of the whole address to keep it unique.
+func vendorPackageDir(packageAddr string) string {
+	name := packageAddr
+	if i := strings.Index(name, "?"); i >= 0 {
+		name = name[:i]
+	}
+	name = path.Base(strings.TrimRight(name, "/"))
+	name = strings.TrimSuffix(name, ".git")
+	name = strings.Trim(vendorDirNameUnsafe.ReplaceAllString(name, "-"), "-.")
+	if name == "" {
+		name = "package"
+	}
+
+	sum := sha256.Sum256([]byte(packageAddr))
+	return name + "-" + hex.EncodeToString(sum[:])[:12]
+}
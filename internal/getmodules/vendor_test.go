@@ -0,0 +1,103 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVendor(t *testing.T) {
+	src := t.TempDir()
+	for name, content := range map[string]string{
+		"main.tf":             "# root",
+		"modules/vpc/main.tf": "# vpc",
+		".git/HEAD":           "ref: refs/heads/main",
+	} {
+		path := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const packageAddr = "git::https://example.com/network.git?ref=v1.2.0"
+	dir := filepath.Join(t.TempDir(), "vendor", "modules")
+	vendor := NewVendor(dir)
+	if err := vendor.AddPackage(packageAddr, src); err != nil {
+		t.Fatal(err)
+	}
+	vendor.AddRegistryModule(VendoredRegistryModule{
+		Source:   "registry.opentofu.org/example/network/aws",
+		Version:  "1.2.0",
+		Location: packageAddr,
+	})
+	if err := vendor.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadVendor(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.Len(), 1; got != want {
+		t.Fatalf("wrong number of packages %d; want %d", got, want)
+	}
+	if diff := cmp.Diff(vendor.RegistryModules(), loaded.RegistryModules()); diff != "" {
+		t.Errorf("wrong registry modules\n%s", diff)
+	}
+	if _, ok := loaded.PackageDir("git::https://example.com/network.git?ref=v1.3.0"); ok {
+		t.Error("found a package that was not vendored")
+	}
+
+	// A fetcher using the vendor directory copies the vendored package,
+	// which doesn't include dot files.
+	fetcher := NewPackageFetcher()
+	fetcher.UseVendor(loaded)
+	instDir := filepath.Join(t.TempDir(), "network")
+	if err := fetcher.FetchPackage(context.Background(), instDir, packageAddr); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]bool{
+		"main.tf":             true,
+		"modules/vpc/main.tf": true,
+		".git/HEAD":           false,
+	} {
+		_, err := os.Stat(filepath.Join(instDir, filepath.FromSlash(name)))
+		if got := err == nil; got != want {
+			t.Errorf("%s present is %t; want %t", name, got, want)
+		}
+	}
+}
+
+func TestLoadVendor_missing(t *testing.T) {
+	vendor, err := LoadVendor(filepath.Join(t.TempDir(), "vendor", "modules"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vendor != nil {
+		t.Fatal("loaded a vendor directory that doesn't exist")
+	}
+}
+
+func TestVendorPackageDir(t *testing.T) {
+	tests := map[string]string{
+		"git::https://example.com/network.git?ref=v1.2.0": "network-",
+		"https://example.com/modules/vpc.zip":             "vpc.zip-",
+		"s3::https://s3.amazonaws.com/bucket/":            "bucket-",
+		"/":                                               "package-",
+	}
+	for addr, wantPrefix := range tests {
+		got := vendorPackageDir(addr)
+		if len(got) != len(wantPrefix)+12 || got[:len(wantPrefix)] != wantPrefix {
+			t.Errorf("wrong directory %q for %q; want %q followed by a hash", got, addr, wantPrefix)
+		}
+	}
+}
@@ -216,6 +216,41 @@ func (err ErrQueryFailed) Unwrap() error {
 	return err.Wrapped
 }
 
+// ErrSourcesUnavailable is an error type used to indicate that none of the
+// installation methods that could provide a particular provider could be
+// reached, such as when a network mirror is down.
+type ErrSourcesUnavailable struct {
+	Provider addrs.Provider
+
+	// Attempts describes each of the sources that OpenTofu tried, in the
+	// order it tried them.
+	Attempts []SourceAttempt
+}
+
+// SourceAttempt describes a failed attempt to query a source.
+type SourceAttempt struct {
+	// Source is the source's display name, as returned by its ForDisplay
+	// method.
+	Source string
+	Err    error
+}
+
+func (err ErrSourcesUnavailable) Error() string {
+	return fmt.Sprintf(
+		"none of the %d installation methods for %s could be reached",
+		len(err.Attempts), err.Provider,
+	)
+}
+
+// Unwrap returns the errors from each of the attempted sources.
+func (err ErrSourcesUnavailable) Unwrap() []error {
+	errs := make([]error, len(err.Attempts))
+	for i, attempt := range err.Attempts {
+		errs[i] = attempt.Err
+	}
+	return errs
+}
+
 // ErrRequestCanceled is an error type used to indicate that an operation
 // failed due to being cancelled via the given context.Context object.
 //
@@ -7,7 +7,9 @@ package getproviders
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 
 	svchost "github.com/hashicorp/terraform-svchost"
@@ -24,6 +26,10 @@ import (
 // a given provider version then the earliest one in the sequence takes
 // priority for deciding the package metadata for the provider.
 //
+// If a source can't be reached, such as when a network mirror is down, the
+// MultiSource fails over to the next selector that matches the provider, and
+// reports ErrSourcesUnavailable if none of them could provide a result.
+//
 // For underlying sources that make network requests, consider wrapping each
 // one in a MemoizeSource so that availability information retrieved in
 // AvailableVersions can be reused in PackageMeta.
@@ -44,6 +50,7 @@ func (s MultiSource) AvailableVersions(ctx context.Context, provider addrs.Provi
 	vs := make(map[Version]struct{})
 	var registryError bool
 	var warnings []string
+	var unavailable []SourceAttempt
 	for _, selector := range s {
 		if !selector.CanHandleProvider(provider) {
 			continue // doesn't match the given patterns
@@ -58,7 +65,12 @@ func (s MultiSource) AvailableVersions(ctx context.Context, provider addrs.Provi
 		case ErrProviderNotFound:
 			continue // ignore, then
 		default:
-			return nil, nil, err
+			if !sourceUnavailable(err) {
+				return nil, nil, err
+			}
+			log.Printf("[WARN] Failed to query %s for available versions of %s, so trying the next installation method: %s", selector.Source.ForDisplay(provider), provider, err)
+			unavailable = append(unavailable, SourceAttempt{Source: selector.Source.ForDisplay(provider), Err: err})
+			continue
 		}
 		for _, v := range thisSourceVersions {
 			vs[v] = struct{}{}
@@ -69,6 +81,9 @@ func (s MultiSource) AvailableVersions(ctx context.Context, provider addrs.Provi
 	}
 
 	if len(vs) == 0 {
+		if len(unavailable) != 0 {
+			return nil, nil, errSourcesUnavailable(provider, unavailable)
+		}
 		if registryError {
 			return nil, nil, ErrRegistryProviderNotKnown{provider}
 		} else {
@@ -81,6 +96,10 @@ func (s MultiSource) AvailableVersions(ctx context.Context, provider addrs.Provi
 	}
 	ret.Sort()
 
+	for _, attempt := range unavailable {
+		warnings = append(warnings, fmt.Sprintf("Could not query %s, so the available versions are only those offered by the other installation methods: %s", attempt.Source, attempt.Err))
+	}
+
 	return ret, warnings, nil
 }
 
@@ -91,6 +110,7 @@ func (s MultiSource) PackageMeta(ctx context.Context, provider addrs.Provider, v
 		return PackageMeta{}, ErrProviderNotFound{provider, s.sourcesForProvider(provider)}
 	}
 
+	var unavailable []SourceAttempt
 	for _, selector := range s {
 		if !selector.CanHandleProvider(provider) {
 			continue // doesn't match the given patterns
@@ -102,12 +122,19 @@ func (s MultiSource) PackageMeta(ctx context.Context, provider addrs.Provider, v
 		case ErrProviderNotFound, ErrRegistryProviderNotKnown, ErrPlatformNotSupported:
 			continue // ignore, then
 		default:
-			return PackageMeta{}, err
+			if !sourceUnavailable(err) {
+				return PackageMeta{}, err
+			}
+			log.Printf("[WARN] Failed to query %s for %s v%s, so trying the next installation method: %s", selector.Source.ForDisplay(provider), provider, version, err)
+			unavailable = append(unavailable, SourceAttempt{Source: selector.Source.ForDisplay(provider), Err: err})
 		}
 	}
 
 	// If we fall out here then none of the sources have the requested
-	// package.
+	// package, or none of those that might have it could be reached.
+	if len(unavailable) != 0 {
+		return PackageMeta{}, errSourcesUnavailable(provider, unavailable)
+	}
 	return PackageMeta{}, ErrPlatformNotSupported{
 		Provider: provider,
 		Version:  version,
@@ -115,6 +142,29 @@ func (s MultiSource) PackageMeta(ctx context.Context, provider addrs.Provider, v
 	}
 }
 
+// sourceUnavailable returns true if the given error from a source suggests
+// that the source is unreachable or malfunctioning, rather than that it
+// doesn't have the requested provider, and so MultiSource should try the
+// next matching source instead.
+func sourceUnavailable(err error) bool {
+	switch err.(type) {
+	case ErrQueryFailed, ErrHostUnreachable:
+		return true
+	default:
+		return errors.As(err, new(errCircuitOpen))
+	}
+}
+
+// errSourcesUnavailable returns the error to report when the given sources
+// couldn't be reached. If there was only one, its own error describes the
+// problem well enough.
+func errSourcesUnavailable(provider addrs.Provider, attempts []SourceAttempt) error {
+	if len(attempts) == 1 {
+		return attempts[0].Err
+	}
+	return ErrSourcesUnavailable{Provider: provider, Attempts: attempts}
+}
+
 // MultiSourceSelector is an element of the source selection configuration on
 // MultiSource. A MultiSource has zero or more of these to configure which
 // underlying sources it should consult for a given provider.
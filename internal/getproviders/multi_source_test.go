@@ -7,6 +7,8 @@ package getproviders
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -367,6 +369,94 @@ func TestMultiSourcePackageMeta(t *testing.T) {
 	})
 }
 
+// unreachableSource is a Source that fails every request as if its host
+// were down.
+type unreachableSource struct {
+	name string
+}
+
+func (s unreachableSource) AvailableVersions(ctx context.Context, provider addrs.Provider) (VersionList, Warnings, error) {
+	return nil, nil, ErrQueryFailed{Provider: provider, Wrapped: errors.New("connection refused")}
+}
+
+func (s unreachableSource) PackageMeta(ctx context.Context, provider addrs.Provider, version Version, target Platform) (PackageMeta, error) {
+	return PackageMeta{}, ErrQueryFailed{Provider: provider, Wrapped: errors.New("connection refused")}
+}
+
+func (s unreachableSource) ForDisplay(provider addrs.Provider) string {
+	return s.name
+}
+
+func TestMultiSourceFailover(t *testing.T) {
+	platform := Platform{OS: "amigaos", Arch: "m68k"}
+	provider := addrs.NewDefaultProvider("foo")
+	meta := FakePackageMeta(provider, MustParseVersion("1.0.0"), VersionList{MustParseVersion("5.0")}, platform)
+	available := NewMockSource([]PackageMeta{meta}, nil)
+
+	t.Run("fails over to the next source", func(t *testing.T) {
+		multi := MultiSource{
+			{Source: unreachableSource{"mirror"}},
+			{Source: available},
+		}
+
+		versions, warnings, err := multi.AvailableVersions(context.Background(), provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(VersionList{MustParseVersion("1.0.0")}, versions); diff != "" {
+			t.Errorf("wrong versions\n%s", diff)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "Could not query mirror") {
+			t.Errorf("wrong warnings %#v", warnings)
+		}
+
+		got, err := multi.PackageMeta(context.Background(), provider, MustParseVersion("1.0.0"), platform)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(meta, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+
+	t.Run("all sources unavailable", func(t *testing.T) {
+		multi := MultiSource{
+			{Source: unreachableSource{"mirror a"}},
+			{Source: unreachableSource{"mirror b"}},
+			{Source: available, Exclude: MultiSourceMatchingPatterns{provider}},
+		}
+
+		_, _, err := multi.AvailableVersions(context.Background(), provider)
+		var unavailable ErrSourcesUnavailable
+		if !errors.As(err, &unavailable) {
+			t.Fatalf("wrong error type %T", err)
+		}
+		var got []string
+		for _, attempt := range unavailable.Attempts {
+			got = append(got, attempt.Source)
+		}
+		if diff := cmp.Diff([]string{"mirror a", "mirror b"}, got); diff != "" {
+			t.Errorf("wrong attempts\n%s", diff)
+		}
+
+		_, err = multi.PackageMeta(context.Background(), provider, MustParseVersion("1.0.0"), platform)
+		if !errors.As(err, &unavailable) {
+			t.Fatalf("wrong error type %T", err)
+		}
+	})
+
+	t.Run("single source unavailable", func(t *testing.T) {
+		multi := MultiSource{
+			{Source: unreachableSource{"registry"}},
+		}
+
+		_, _, err := multi.AvailableVersions(context.Background(), provider)
+		if _, ok := err.(ErrQueryFailed); !ok {
+			t.Fatalf("wrong error type %T", err)
+		}
+	})
+}
+
 func TestMultiSourceSelector(t *testing.T) {
 	emptySource := NewMockSource(nil, nil)
 
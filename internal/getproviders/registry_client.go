@@ -13,7 +13,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
@@ -27,7 +26,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/httpclient"
-	"github.com/opentofu/opentofu/internal/logging"
+	"github.com/opentofu/opentofu/internal/registry/response"
 	"github.com/opentofu/opentofu/version"
 )
 
@@ -76,46 +75,39 @@ func newRegistryClient(baseURL *url.URL, creds svcauth.HostCredentials) *registr
 	httpClient := httpclient.New()
 	httpClient.Timeout = requestTimeout
 
-	retryableClient := retryablehttp.NewClient()
-	retryableClient.HTTPClient = httpClient
-	retryableClient.RetryMax = discoveryRetry
-	retryableClient.RequestLogHook = requestLogHook
-	retryableClient.ErrorHandler = maxRetryErrorHandler
-
-	retryableClient.Logger = log.New(logging.LogOutput(), "", log.Flags())
-
 	return &registryClient{
 		baseURL:    baseURL,
 		creds:      creds,
-		httpClient: retryableClient,
+		httpClient: newRetryableClient(httpClient),
 	}
 }
 
 // ProviderVersions returns the raw version and protocol strings produced by the
-// registry for the given provider.
+// registry for the given provider, along with any warnings and the deprecation
+// the registry announced for the provider, if any.
 //
 // The returned error will be ErrRegistryProviderNotKnown if the registry responds with
 // 404 Not Found to indicate that the namespace or provider type are not known,
 // ErrUnauthorized if the registry responds with 401 or 403 status codes, or
 // ErrQueryFailed for any other protocol or operational problem.
-func (c *registryClient) ProviderVersions(ctx context.Context, addr addrs.Provider) (map[string][]string, []string, error) {
+func (c *registryClient) ProviderVersions(ctx context.Context, addr addrs.Provider) (map[string][]string, []string, *response.Deprecation, error) {
 	endpointPath, err := url.Parse(path.Join(addr.Namespace, addr.Type, "versions"))
 	if err != nil {
 		// Should never happen because we're constructing this from
 		// already-validated components.
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	endpointURL := c.baseURL.ResolveReference(endpointPath)
 	req, err := retryablehttp.NewRequest("GET", endpointURL.String(), nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	req = req.WithContext(ctx)
 	c.addHeadersToRequest(req.Request)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, nil, c.errQueryFailed(addr, err)
+		return nil, nil, nil, c.errQueryFailed(addr, err)
 	}
 	defer resp.Body.Close()
 
@@ -123,13 +115,13 @@ func (c *registryClient) ProviderVersions(ctx context.Context, addr addrs.Provid
 	case http.StatusOK:
 		// Great!
 	case http.StatusNotFound:
-		return nil, nil, ErrRegistryProviderNotKnown{
+		return nil, nil, nil, ErrRegistryProviderNotKnown{
 			Provider: addr,
 		}
 	case http.StatusUnauthorized, http.StatusForbidden:
-		return nil, nil, c.errUnauthorized(addr.Hostname)
+		return nil, nil, nil, c.errUnauthorized(addr.Hostname)
 	default:
-		return nil, nil, c.errQueryFailed(addr, errors.New(resp.Status))
+		return nil, nil, nil, c.errQueryFailed(addr, errors.New(resp.Status))
 	}
 
 	// We ignore the platforms portion of the response body, because the
@@ -140,17 +132,18 @@ func (c *registryClient) ProviderVersions(ctx context.Context, addr addrs.Provid
 			Version   string   `json:"version"`
 			Protocols []string `json:"protocols"`
 		} `json:"versions"`
-		Warnings []string `json:"warnings"`
+		Warnings    []string              `json:"warnings"`
+		Deprecation *response.Deprecation `json:"deprecation"`
 	}
 	var body ResponseBody
 
 	dec := json.NewDecoder(resp.Body)
 	if err := dec.Decode(&body); err != nil {
-		return nil, nil, c.errQueryFailed(addr, err)
+		return nil, nil, nil, c.errQueryFailed(addr, err)
 	}
 
 	if len(body.Versions) == 0 {
-		return nil, body.Warnings, nil
+		return nil, body.Warnings, body.Deprecation, nil
 	}
 
 	ret := make(map[string][]string, len(body.Versions))
@@ -158,7 +151,7 @@ func (c *registryClient) ProviderVersions(ctx context.Context, addr addrs.Provid
 		ret[v.Version] = v.Protocols
 	}
 
-	return ret, body.Warnings, nil
+	return ret, body.Warnings, body.Deprecation, nil
 }
 
 // PackageMeta returns metadata about a distribution package for a provider.
@@ -368,7 +361,7 @@ func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provide
 // findClosestProtocolCompatibleVersion searches for the provider version with the closest protocol match.
 func (c *registryClient) findClosestProtocolCompatibleVersion(ctx context.Context, provider addrs.Provider, version Version) (Version, error) {
 	var match Version
-	available, _, err := c.ProviderVersions(ctx, provider)
+	available, _, _, err := c.ProviderVersions(ctx, provider)
 	if err != nil {
 		return UnspecifiedVersion, err
 	}
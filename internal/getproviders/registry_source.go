@@ -13,6 +13,7 @@ import (
 	disco "github.com/hashicorp/terraform-svchost/disco"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/registry/response"
 )
 
 // RegistrySource is a Source that knows how to find and install providers from
@@ -44,11 +45,18 @@ func (s *RegistrySource) AvailableVersions(ctx context.Context, provider addrs.P
 		return nil, nil, err
 	}
 
-	versionsResponse, warnings, err := client.ProviderVersions(ctx, provider)
+	versionsResponse, warnings, deprecation, err := client.ProviderVersions(ctx, provider)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// A registry may optionally announce that a provider is deprecated or
+	// has reached its end of life. We surface that to the user as an
+	// additional warning, alongside any free-form warnings.
+	if deprecation != nil {
+		warnings = append(warnings, deprecation.Describe(provider.ForDisplay()))
+	}
+
 	if len(versionsResponse) == 0 {
 		return nil, warnings, nil
 	}
@@ -81,6 +89,19 @@ func (s *RegistrySource) AvailableVersions(ctx context.Context, provider addrs.P
 	return ret, warnings, nil
 }
 
+// ProviderDeprecation returns the deprecation that the registry of the given
+// provider announces for it, or nil if the provider isn't deprecated.
+//
+// The errors are the same as for AvailableVersions.
+func (s *RegistrySource) ProviderDeprecation(ctx context.Context, provider addrs.Provider) (*response.Deprecation, error) {
+	client, err := s.registryClient(provider.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	_, _, deprecation, err := client.ProviderVersions(ctx, provider)
+	return deprecation, err
+}
+
 // PackageMeta returns metadata about the location and capabilities of
 // a distribution package for a particular provider at a particular version
 // targeting a particular platform.
@@ -19,6 +19,7 @@ import (
 	svchost "github.com/hashicorp/terraform-svchost"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/registry/response"
 )
 
 func TestSourceAvailableVersions(t *testing.T) {
@@ -114,6 +115,50 @@ func TestSourceAvailableVersions_warnings(t *testing.T) {
 
 }
 
+func TestSourceAvailableVersions_deprecation(t *testing.T) {
+	source, _, close := testRegistrySource(t)
+	defer close()
+
+	provider := addrs.MustParseProviderSourceString("example.com/weaksauce/deprecated")
+	_, warnings, err := source.AvailableVersions(context.Background(), provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	want := Warnings{
+		"example.com/weaksauce/deprecated is deprecated and reaches its end of life on 2025-01-01. Use example.com/awesomesauce/happycloud instead. For more information, see https://example.com/deprecated.",
+	}
+	if diff := cmp.Diff(want, warnings); diff != "" {
+		t.Errorf("wrong warnings\n%s", diff)
+	}
+}
+
+func TestSourceProviderDeprecation(t *testing.T) {
+	source, _, close := testRegistrySource(t)
+	defer close()
+
+	got, err := source.ProviderDeprecation(context.Background(), addrs.MustParseProviderSourceString("example.com/weaksauce/deprecated"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	want := &response.Deprecation{
+		Reason:    "Use example.com/awesomesauce/happycloud instead.",
+		Link:      "https://example.com/deprecated",
+		EndOfLife: "2025-01-01",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong deprecation\n%s", diff)
+	}
+
+	got, err = source.ProviderDeprecation(context.Background(), addrs.MustParseProviderSourceString("example.com/awesomesauce/happycloud"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got != nil {
+		t.Errorf("unexpected deprecation %#v", got)
+	}
+}
+
 func TestSourcePackageMeta(t *testing.T) {
 	source, baseURL, close := testRegistrySource(t)
 	defer close()
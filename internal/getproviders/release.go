@@ -0,0 +1,269 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// Release describes the artifacts of one version of a provider, as produced by
+// the usual provider release tooling: one zip archive per platform, a
+// SHA256SUMS file listing their checksums, a detached signature of that file,
+// and optionally a manifest declaring the supported plugin protocols.
+type Release struct {
+	Provider addrs.Provider
+	Version  Version
+
+	// Protocols are the plugin protocol versions the provider supports, as
+	// declared in the release manifest. Releases without a manifest are
+	// assumed to support protocol 5.0 only.
+	Protocols []string
+
+	// Archives are the provider packages in the release, by target platform.
+	Archives map[Platform]ReleaseArchive
+
+	// SHA256SumsPath and SignaturePath are the paths to the checksums file
+	// and to its detached signature.
+	SHA256SumsPath string
+	SignaturePath  string
+}
+
+// ReleaseArchive is a single provider package within a Release.
+type ReleaseArchive struct {
+	Path      string
+	SHA256Sum string
+}
+
+// Filename returns the base name of the archive file.
+func (a ReleaseArchive) Filename() string {
+	return filepath.Base(a.Path)
+}
+
+// LoadRelease finds the artifacts for the given version of the given provider
+// in dir and checks that they are consistent with each other: every archive
+// must be listed in the checksums file with a matching checksum, every file
+// listed there must be present, and every archive must contain the provider
+// executable.
+//
+// The files are expected to follow the naming convention used by provider
+// registries, with "terraform-provider-TYPE_VERSION" as their common prefix.
+// LoadRelease reports all of the problems it finds at once.
+func LoadRelease(dir string, provider addrs.Provider, version Version) (*Release, error) {
+	prefix := fmt.Sprintf("terraform-provider-%s_%s", provider.Type, version)
+	ret := &Release{
+		Provider:       provider,
+		Version:        version,
+		Archives:       make(map[Platform]ReleaseArchive),
+		SHA256SumsPath: filepath.Join(dir, prefix+"_SHA256SUMS"),
+		SignaturePath:  filepath.Join(dir, prefix+"_SHA256SUMS.sig"),
+	}
+
+	sums, err := os.ReadFile(ret.SHA256SumsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checksums file: %w", err)
+	}
+	if _, err := os.Stat(ret.SignaturePath); err != nil {
+		return nil, fmt.Errorf("failed to find checksums signature: %w", err)
+	}
+	listed, err := parseReleaseSHA256Sums(sums)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksums file %s: %w", filepath.Base(ret.SHA256SumsPath), err)
+	}
+
+	var errs []error
+	protocols, err := loadReleaseProtocols(filepath.Join(dir, prefix+"_manifest.json"))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	ret.Protocols = protocols
+
+	archives, err := filepath.Glob(filepath.Join(dir, prefix+"_*.zip"))
+	if err != nil {
+		return nil, err
+	}
+	for _, archivePath := range archives {
+		filename := filepath.Base(archivePath)
+		platform, err := ParsePlatform(strings.TrimSuffix(strings.TrimPrefix(filename, prefix+"_"), ".zip"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s does not follow the naming convention for provider packages: %w", filename, err))
+			continue
+		}
+		sum, err := releaseFileSHA256Sum(archivePath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		want, ok := listed[filename]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s is not listed in the checksums file", filename))
+			continue
+		case want != sum:
+			errs = append(errs, fmt.Errorf("%s has checksum %s, but the checksums file lists %s", filename, sum, want))
+			continue
+		}
+		if err := checkReleaseArchive(archivePath, provider.Type); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ret.Archives[platform] = ReleaseArchive{
+			Path:      archivePath,
+			SHA256Sum: sum,
+		}
+	}
+
+	filenames := make([]string, 0, len(listed))
+	for filename := range listed {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		if _, err := os.Stat(filepath.Join(dir, filename)); err != nil {
+			errs = append(errs, fmt.Errorf("%s is listed in the checksums file but is missing", filename))
+		}
+	}
+
+	if len(ret.Archives) == 0 && len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("no packages named %s_OS_ARCH.zip found in %s", prefix, dir))
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	return ret, nil
+}
+
+// VerifySignature checks that the release checksums file is signed by the
+// given ASCII-armored OpenPGP public key, returning the ID of the key.
+func (r *Release) VerifySignature(key string) (string, error) {
+	sums, err := os.ReadFile(r.SHA256SumsPath)
+	if err != nil {
+		return "", err
+	}
+	signature, err := os.ReadFile(r.SignaturePath)
+	if err != nil {
+		return "", err
+	}
+	meta := PackageMeta{
+		Provider: r.Provider,
+		Version:  r.Version,
+	}
+	auth := NewSignatureAuthentication(meta, sums, signature, []SigningKey{{ASCIIArmor: key}}, &r.Provider)
+	result, err := auth.AuthenticatePackage(PackageLocalArchive(r.SHA256SumsPath))
+	if err != nil {
+		return "", err
+	}
+	return result.KeyID, nil
+}
+
+// SortedPlatforms returns the platforms of the release archives in a
+// consistent order.
+func (r *Release) SortedPlatforms() []Platform {
+	ret := make([]Platform, 0, len(r.Archives))
+	for platform := range r.Archives {
+		ret = append(ret, platform)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].LessThan(ret[j])
+	})
+	return ret
+}
+
+func parseReleaseSHA256Sums(document []byte) (map[string]string, error) {
+	ret := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(document))
+	for sc.Scan() {
+		parts := strings.Fields(sc.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) != 2 || len(parts[0]) != sha256.Size*2 {
+			return nil, fmt.Errorf("invalid line %q", sc.Text())
+		}
+		if _, err := hex.DecodeString(parts[0]); err != nil {
+			return nil, fmt.Errorf("invalid checksum for %s: %w", parts[1], err)
+		}
+		ret[parts[1]] = strings.ToLower(parts[0])
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// loadReleaseProtocols reads the plugin protocol versions from the release
+// manifest at the given path, if it exists.
+func loadReleaseProtocols(manifestPath string) ([]string, error) {
+	src, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return []string{"5.0"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Metadata struct {
+			ProtocolVersions []string `json:"protocol_versions"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid release manifest %s: %w", filepath.Base(manifestPath), err)
+	}
+	protocols := manifest.Metadata.ProtocolVersions
+	if len(protocols) == 0 {
+		return nil, fmt.Errorf("release manifest %s does not declare any protocol versions", filepath.Base(manifestPath))
+	}
+	for _, protocol := range protocols {
+		v, err := ParseVersion(protocol)
+		if err != nil || (v.Major != 5 && v.Major != 6) {
+			return nil, fmt.Errorf("release manifest %s declares unsupported protocol version %q; OpenTofu supports protocol versions 5 and 6", filepath.Base(manifestPath), protocol)
+		}
+	}
+	return protocols, nil
+}
+
+func releaseFileSHA256Sum(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filepath.Base(filename), err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// checkReleaseArchive checks that the given archive is a zip file containing
+// the provider executable at its root.
+func checkReleaseArchive(filename string, typeName string) error {
+	r, err := zip.OpenReader(filename)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid zip archive: %w", filepath.Base(filename), err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if !strings.Contains(f.Name, "/") && strings.HasPrefix(f.Name, "terraform-provider-"+typeName) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not contain a terraform-provider-%s executable", filepath.Base(filename), typeName)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// testReleaseDir writes a release of example.com/acme/widget 1.0.0 for the
+// given platforms into a new temporary directory, with a placeholder
+// signature file.
+func testReleaseDir(t *testing.T, platforms ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	var sums strings.Builder
+	for _, platform := range platforms {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create("terraform-provider-widget_v1.0.0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(w, "executable for %s", platform)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		filename := fmt.Sprintf("terraform-provider-widget_1.0.0_%s.zip", platform)
+		if err := os.WriteFile(filepath.Join(dir, filename), buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		fmt.Fprintf(&sums, "%s  %s\n", hex.EncodeToString(sum[:]), filename)
+	}
+
+	files := map[string]string{
+		"terraform-provider-widget_1.0.0_SHA256SUMS":     sums.String(),
+		"terraform-provider-widget_1.0.0_SHA256SUMS.sig": "signature",
+		"terraform-provider-widget_1.0.0_manifest.json":  `{"version":1,"metadata":{"protocol_versions":["6.0"]}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadRelease(t *testing.T) {
+	provider := addrs.NewProvider("example.com", "acme", "widget")
+	version := MustParseVersion("1.0.0")
+
+	t.Run("valid", func(t *testing.T) {
+		dir := testReleaseDir(t, "linux_amd64", "darwin_arm64")
+		release, err := LoadRelease(dir, provider, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		platforms := release.SortedPlatforms()
+		if len(platforms) != 2 || platforms[0].String() != "darwin_arm64" || platforms[1].String() != "linux_amd64" {
+			t.Errorf("wrong platforms %v", platforms)
+		}
+		if got := strings.Join(release.Protocols, ","); got != "6.0" {
+			t.Errorf("wrong protocols %q", got)
+		}
+		archive := release.Archives[Platform{OS: "linux", Arch: "amd64"}]
+		if archive.Filename() != "terraform-provider-widget_1.0.0_linux_amd64.zip" {
+			t.Errorf("wrong archive filename %q", archive.Filename())
+		}
+	})
+
+	t.Run("default protocol", func(t *testing.T) {
+		dir := testReleaseDir(t, "linux_amd64")
+		if err := os.Remove(filepath.Join(dir, "terraform-provider-widget_1.0.0_manifest.json")); err != nil {
+			t.Fatal(err)
+		}
+		release, err := LoadRelease(dir, provider, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(release.Protocols, ","); got != "5.0" {
+			t.Errorf("wrong protocols %q", got)
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		dir := testReleaseDir(t, "linux_amd64")
+		if err := os.WriteFile(filepath.Join(dir, "terraform-provider-widget_1.0.0_linux_amd64.zip"), []byte("tampered"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadRelease(dir, provider, version)
+		if err == nil || !strings.Contains(err.Error(), "but the checksums file lists") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("missing archive", func(t *testing.T) {
+		dir := testReleaseDir(t, "linux_amd64", "windows_amd64")
+		if err := os.Remove(filepath.Join(dir, "terraform-provider-widget_1.0.0_windows_amd64.zip")); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadRelease(dir, provider, version)
+		if err == nil || !strings.Contains(err.Error(), "windows_amd64.zip is listed in the checksums file but is missing") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("unsupported protocol", func(t *testing.T) {
+		dir := testReleaseDir(t, "linux_amd64")
+		manifest := `{"version":1,"metadata":{"protocol_versions":["4.0"]}}`
+		if err := os.WriteFile(filepath.Join(dir, "terraform-provider-widget_1.0.0_manifest.json"), []byte(manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadRelease(dir, provider, version)
+		if err == nil || !strings.Contains(err.Error(), "unsupported protocol version") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("no checksums", func(t *testing.T) {
+		_, err := LoadRelease(t.TempDir(), provider, version)
+		if err == nil || !strings.Contains(err.Error(), "failed to read checksums file") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+}
+
+func TestReleaseVerifySignature(t *testing.T) {
+	dir := testReleaseDir(t, "linux_amd64")
+	release, err := LoadRelease(dir, addrs.NewProvider("example.com", "acme", "widget"), MustParseVersion("1.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The placeholder signature written by testReleaseDir is not valid.
+	if _, err := release.VerifySignature(testAuthorKeyArmor); err == nil {
+		t.Fatal("expected error for invalid signature")
+	}
+
+	// A document signed by the test author key verifies successfully.
+	signature, err := base64.StdEncoding.DecodeString(testAuthorSignatureGoodBase64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(release.SHA256SumsPath, []byte(testShaSumsPlaceholder), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(release.SignaturePath, signature, 0644); err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := release.VerifySignature(testAuthorKeyArmor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyID != testAuthorKeyID {
+		t.Errorf("wrong key ID %q", keyID)
+	}
+}
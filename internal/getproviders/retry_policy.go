@@ -0,0 +1,261 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/logging"
+)
+
+const (
+	// registryRetryBudgetEnvName is the name of the environment variable that
+	// can be configured to customize the total number of retries that all of
+	// the provider registry and network mirror requests made by a single
+	// command may share.
+	registryRetryBudgetEnvName = "TF_REGISTRY_RETRY_BUDGET"
+	defaultRetryBudget         = 20
+
+	// circuitBreakerThreshold is the number of consecutive failed attempts to
+	// reach a host after which further requests to the same host fail
+	// immediately, until circuitBreakerCooldown has passed.
+	circuitBreakerThreshold = 5
+	circuitBreakerCooldown  = 30 * time.Second
+
+	retryWaitMin = 500 * time.Millisecond
+	retryWaitMax = 10 * time.Second
+)
+
+// sharedRetryBudget is the retry budget shared by all of the registry and
+// network mirror clients in this process, so that a host that fails every
+// request can't multiply the time a command takes by the number of providers
+// being installed.
+var sharedRetryBudget = newRetryBudget(configuredRetryBudget())
+
+// sharedCircuitBreakers are the circuit breakers shared by all of the registry
+// and network mirror clients in this process, keyed by host.
+var sharedCircuitBreakers = &circuitBreakers{
+	threshold: circuitBreakerThreshold,
+	cooldown:  circuitBreakerCooldown,
+}
+
+// configuredRetryBudget returns the retry budget from the environment
+// variables, or the default budget if it's not set.
+func configuredRetryBudget() int {
+	if v := os.Getenv(registryRetryBudgetEnvName); v != "" {
+		budget, err := strconv.Atoi(v)
+		if err == nil && budget >= 0 {
+			return budget
+		}
+		log.Printf("[WARN] Ignoring invalid %s value %q", registryRetryBudgetEnvName, v)
+	}
+	return defaultRetryBudget
+}
+
+// newRetryableClient returns a retrying client for registry and network
+// mirror requests that uses the given client for each attempt.
+//
+// Each request is retried up to discoveryRetry times, while retries remain in
+// the shared retry budget, waiting for an exponentially-growing duration with
+// random jitter between attempts. Requests to a host that has failed too many
+// times in a row fail immediately for a while, so that OpenTofu can move on to
+// the next installation method quickly.
+func newRetryableClient(httpClient *http.Client) *retryablehttp.Client {
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = httpclient.NewTransport()
+	}
+	breakerClient := *httpClient
+	breakerClient.Transport = &circuitBreakerTransport{
+		breakers: sharedCircuitBreakers,
+		next:     transport,
+	}
+
+	retryableClient := retryablehttp.NewClient()
+	retryableClient.HTTPClient = &breakerClient
+	retryableClient.RetryMax = discoveryRetry
+	retryableClient.RetryWaitMin = retryWaitMin
+	retryableClient.RetryWaitMax = retryWaitMax
+	retryableClient.Backoff = jitteredBackoff
+	retryableClient.CheckRetry = sharedRetryBudget.checkRetry
+	retryableClient.RequestLogHook = requestLogHook
+	retryableClient.ErrorHandler = maxRetryErrorHandler
+
+	retryableClient.Logger = log.New(logging.LogOutput(), "", log.Flags())
+
+	return retryableClient
+}
+
+// jitteredBackoff is a retryablehttp.Backoff that waits for a random duration
+// between half and all of an exponentially-growing delay, so that clients
+// that failed at the same time don't all retry at the same time. It respects
+// the Retry-After header of rate-limiting responses, like
+// retryablehttp.DefaultBackoff.
+func jitteredBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if resp.Header.Get("Retry-After") != "" {
+			return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+		}
+	}
+
+	delay := float64(min) * math.Pow(2, float64(attemptNum))
+	if delay > float64(max) || math.IsInf(delay, 0) {
+		delay = float64(max)
+	}
+	half := time.Duration(delay / 2)
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryBudget limits the total number of retries made by all of the clients
+// that share it.
+type retryBudget struct {
+	mu        sync.Mutex
+	remaining int
+	exhausted bool
+}
+
+func newRetryBudget(size int) *retryBudget {
+	return &retryBudget{remaining: size}
+}
+
+// take uses one retry from the budget, returning false if there are none
+// left.
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.remaining > 0 {
+		b.remaining--
+		return true
+	}
+	if !b.exhausted {
+		b.exhausted = true
+		log.Printf("[WARN] Provider registry and mirror requests have used all of their retries; failed requests will no longer be retried. Set %s to allow more retries.", registryRetryBudgetEnvName)
+	}
+	return false
+}
+
+// checkRetry is a retryablehttp.CheckRetry that retries the requests that
+// retryablehttp.DefaultRetryPolicy would, as long as the budget allows it.
+func (b *retryBudget) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if errors.As(err, new(errCircuitOpen)) {
+		return false, err
+	}
+	retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !retry || checkErr != nil {
+		return retry, checkErr
+	}
+	return b.take(), nil
+}
+
+// errCircuitOpen is the error returned for requests to a host that has failed
+// too many times in a row.
+type errCircuitOpen struct {
+	Host  string
+	Until time.Time
+}
+
+func (err errCircuitOpen) Error() string {
+	return fmt.Sprintf("%s failed %d consecutive requests, so OpenTofu will not contact it again until %s", err.Host, circuitBreakerThreshold, err.Until.Format(time.TimeOnly))
+}
+
+// circuitBreakers tracks consecutive failures to reach each host.
+type circuitBreakers struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*circuitBreakerState
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+type circuitBreakerState struct {
+	failures  int
+	openUntil time.Time
+}
+
+func (b *circuitBreakers) currentTime() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+// allow returns an error if requests to the given host should fail
+// immediately.
+func (b *circuitBreakers) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := b.hosts[host]
+	if state == nil || state.openUntil.IsZero() {
+		return nil
+	}
+	if b.currentTime().Before(state.openUntil) {
+		return errCircuitOpen{Host: host, Until: state.openUntil}
+	}
+	// The cooldown has passed, so we'll let the next request through. A
+	// single further failure will open the circuit again.
+	state.openUntil = time.Time{}
+	state.failures = b.threshold - 1
+	return nil
+}
+
+// record records the outcome of an attempt to reach the given host.
+func (b *circuitBreakers) record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.hosts == nil {
+		b.hosts = make(map[string]*circuitBreakerState)
+	}
+	state := b.hosts[host]
+	if state == nil {
+		state = &circuitBreakerState{}
+		b.hosts[host] = state
+	}
+	if !failed {
+		state.failures = 0
+		return
+	}
+	state.failures++
+	if state.failures >= b.threshold {
+		state.openUntil = b.currentTime().Add(b.cooldown)
+		log.Printf("[WARN] %s failed %d consecutive requests; further requests will fail immediately until %s", host, state.failures, state.openUntil.Format(time.TimeOnly))
+	}
+}
+
+// circuitBreakerTransport is an http.RoundTripper that records the outcome of
+// each request in circuitBreakers, and fails requests immediately while the
+// circuit for their host is open.
+type circuitBreakerTransport struct {
+	breakers *circuitBreakers
+	next     http.RoundTripper
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.breakers.allow(host); err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if req.Context().Err() != nil {
+		// Cancellation says nothing about the host's health.
+		return resp, err
+	}
+	t.breakers.record(host, err != nil || (resp != nil && resp.StatusCode >= 500))
+	return resp, err
+}
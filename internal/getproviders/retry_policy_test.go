@@ -0,0 +1,147 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConfiguredRetryBudget(t *testing.T) {
+	tests := map[string]int{
+		"":     defaultRetryBudget,
+		"0":    0,
+		"50":   50,
+		"-1":   defaultRetryBudget,
+		"many": defaultRetryBudget,
+	}
+	for value, want := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv(registryRetryBudgetEnvName, value)
+			if got := configuredRetryBudget(); got != want {
+				t.Fatalf("wrong budget %d; want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	budget := newRetryBudget(2)
+	ctx := context.Background()
+	unavailable := &http.Response{StatusCode: http.StatusBadGateway}
+
+	for i := 0; i < 2; i++ {
+		retry, err := budget.checkRetry(ctx, unavailable, nil)
+		if !retry || err != nil {
+			t.Fatalf("attempt %d was not retried: %v", i, err)
+		}
+	}
+	if retry, _ := budget.checkRetry(ctx, unavailable, nil); retry {
+		t.Fatal("retried after the budget was used up")
+	}
+
+	// Requests that wouldn't be retried anyway don't use up the budget.
+	budget = newRetryBudget(1)
+	if retry, _ := budget.checkRetry(ctx, &http.Response{StatusCode: http.StatusNotFound}, nil); retry {
+		t.Fatal("retried a request that was not found")
+	}
+	if retry, _ := budget.checkRetry(ctx, nil, errCircuitOpen{Host: "example.com"}); retry {
+		t.Fatal("retried a request to a host with an open circuit")
+	}
+	if retry, _ := budget.checkRetry(ctx, unavailable, nil); !retry {
+		t.Fatal("did not retry with budget remaining")
+	}
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	min, max := 100*time.Millisecond, time.Second
+	for attempt, wantMax := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		for i := 0; i < 20; i++ {
+			got := jitteredBackoff(min, max, attempt, nil)
+			if got < wantMax/2 || got > wantMax {
+				t.Fatalf("attempt %d waited %s; want between %s and %s", attempt, got, wantMax/2, wantMax)
+			}
+		}
+	}
+
+	// A Retry-After header on a rate-limiting response is respected.
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"3"}},
+	}
+	if got, want := jitteredBackoff(min, max, 0, resp), 3*time.Second; got != want {
+		t.Fatalf("waited %s; want %s", got, want)
+	}
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 10, 16, 10, 0, 0, 0, time.UTC)
+	breakers := &circuitBreakers{
+		threshold: 3,
+		cooldown:  time.Minute,
+		now:       func() time.Time { return now },
+	}
+	client := &http.Client{
+		Transport: &circuitBreakerTransport{
+			breakers: breakers,
+			next:     http.DefaultTransport,
+		},
+	}
+	get := func() error {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("request %d failed: %s", i, err)
+		}
+	}
+	if err := get(); !errors.As(err, new(errCircuitOpen)) {
+		t.Fatalf("expected the circuit to be open, got %v", err)
+	}
+	if requests != 3 {
+		t.Fatalf("server received %d requests; want 3", requests)
+	}
+
+	// After the cooldown, a request is allowed through again, and a success
+	// closes the circuit.
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	if err := get(); err != nil {
+		t.Fatalf("request after cooldown failed: %s", err)
+	}
+	status = http.StatusServiceUnavailable
+	for i := 0; i < 2; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("request %d failed: %s", i, err)
+		}
+	}
+	if requests != 6 {
+		t.Fatalf("server received %d requests; want 6", requests)
+	}
+}
@@ -13,12 +13,14 @@ import (
 )
 
 // New returns the DefaultPooledClient from the cleanhttp
-// package that will also send a OpenTofu User-Agent string.
+// package that will also send a OpenTofu User-Agent string, that selects
+// proxies using Proxy, and that presents the client certificates given to
+// SetClientCertificates.
 func New() *http.Client {
 	cli := cleanhttp.DefaultPooledClient()
 	cli.Transport = &userAgentRoundTripper{
 		userAgent: OpenTofuUserAgent(version.Version),
-		inner:     cli.Transport,
+		inner:     WithClientCertificates(NewTransport()),
 	}
 	return cli
 }
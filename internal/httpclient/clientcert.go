@@ -0,0 +1,82 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+)
+
+var clientCertificates atomic.Pointer[map[svchost.Hostname]tls.Certificate]
+
+// SetClientCertificates selects the TLS client certificates that the clients
+// created by this package present to each host, for services that require
+// mutual TLS authentication. Hosts not in the given map are sent no client
+// certificate.
+//
+// This must be called before making any requests to the given hosts, since
+// clients reuse their connections to each host.
+func SetClientCertificates(certs map[svchost.Hostname]tls.Certificate) {
+	clientCertificates.Store(&certs)
+}
+
+// WithClientCertificates returns an http.RoundTripper that sends requests
+// using the given transport, except that requests to hosts given to
+// SetClientCertificates use a copy of it that presents the host's client
+// certificate.
+func WithClientCertificates(base *http.Transport) http.RoundTripper {
+	return &clientCertTransport{
+		base:   base,
+		byHost: make(map[svchost.Hostname]*http.Transport),
+	}
+}
+
+type clientCertTransport struct {
+	base *http.Transport
+
+	mu     sync.Mutex
+	byHost map[svchost.Hostname]*http.Transport
+}
+
+func (t *clientCertTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	certs := clientCertificates.Load()
+	if certs == nil || len(*certs) == 0 {
+		return t.base.RoundTrip(req)
+	}
+	host, err := svchost.ForComparison(req.URL.Host)
+	if err != nil {
+		return t.base.RoundTrip(req)
+	}
+	cert, ok := (*certs)[host]
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	return t.transportFor(host, cert).RoundTrip(req)
+}
+
+// transportFor returns the transport for requests to the given host, which
+// presents the given certificate. Each host has its own transport, so that
+// connections presenting a host's certificate are never reused for another
+// host.
+func (t *clientCertTransport) transportFor(host svchost.Hostname, cert tls.Certificate) *http.Transport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if transport, ok := t.byHost[host]; ok {
+		return transport
+	}
+
+	log.Printf("[DEBUG] Using the configured TLS client certificate for %s", host.ForDisplay())
+	transport := t.base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	t.byHost[host] = transport
+	return transport
+}
@@ -0,0 +1,88 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+)
+
+func testClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "opentofu-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestWithClientCertificates(t *testing.T) {
+	var gotClient string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClient = ""
+		if len(r.TLS.PeerCertificates) != 0 {
+			gotClient = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	base := server.Client().Transport.(*http.Transport)
+	client := &http.Client{Transport: WithClientCertificates(base)}
+	get := func() {
+		t.Helper()
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	host, err := svchost.ForComparison(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetClientCertificates(map[svchost.Hostname]tls.Certificate{
+		svchost.Hostname("other.example.com"): testClientCertificate(t),
+	})
+	defer SetClientCertificates(nil)
+	get()
+	if gotClient != "" {
+		t.Fatalf("sent client certificate %q to a host without one", gotClient)
+	}
+
+	// A separate client, since the first one reuses its connection.
+	client = &http.Client{Transport: WithClientCertificates(base.Clone())}
+	SetClientCertificates(map[svchost.Hostname]tls.Certificate{
+		host: testClientCertificate(t),
+	})
+	get()
+	if gotClient != "opentofu-test-client" {
+		t.Fatalf("wrong client certificate %q", gotClient)
+	}
+}
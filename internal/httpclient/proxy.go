@@ -0,0 +1,95 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// ProxyConfig selects the proxy to use for outbound HTTP requests, in place
+// of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+type ProxyConfig struct {
+	// Rules are checked in order, and the first rule matching the request's
+	// host selects its proxy.
+	Rules []ProxyRule
+}
+
+// ProxyRule selects the proxy for requests to a set of hosts.
+type ProxyRule struct {
+	// Hosts are the host names the rule applies to. A name starting with
+	// "*." matches any subdomain of the rest of the name, and "*" matches
+	// any host.
+	Hosts []string
+
+	// Proxy is the URL of the proxy to use, or nil to connect directly.
+	Proxy *url.URL
+}
+
+var proxyConfig atomic.Pointer[ProxyConfig]
+
+// SetProxyConfig selects the proxy configuration used by Proxy. Requests
+// that the configuration doesn't select a proxy for, or all requests if the
+// given configuration is nil, use the proxy selected by the environment
+// variables.
+//
+// The configuration only applies to the transports that use Proxy, such as
+// those returned by NewTransport. http.DefaultTransport is left unchanged, so
+// that libraries with their own proxy settings aren't affected.
+func SetProxyConfig(config *ProxyConfig) {
+	proxyConfig.Store(config)
+}
+
+// Proxy selects the proxy for the given request using the configuration
+// given to SetProxyConfig, falling back to http.ProxyFromEnvironment. It can
+// be used as the Proxy function of an http.Transport.
+func Proxy(req *http.Request) (*url.URL, error) {
+	if config := proxyConfig.Load(); config != nil {
+		if proxy, ok := config.proxyFor(req.URL); ok {
+			return proxy, nil
+		}
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// proxyFor returns the proxy that the configuration selects for the given
+// URL, or false if it doesn't select one.
+func (c *ProxyConfig) proxyFor(u *url.URL) (*url.URL, bool) {
+	host := strings.ToLower(u.Hostname())
+	for _, rule := range c.Rules {
+		if rule.matches(host) {
+			return rule.Proxy, true
+		}
+	}
+	return nil, false
+}
+
+func (r ProxyRule) matches(host string) bool {
+	for _, pattern := range r.Hosts {
+		pattern = strings.ToLower(pattern)
+		switch {
+		case pattern == "*":
+			return true
+		case strings.HasPrefix(pattern, "*."):
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+		case pattern == host:
+			return true
+		}
+	}
+	return false
+}
+
+// NewTransport returns the DefaultPooledTransport from the cleanhttp package,
+// using Proxy to select the proxy for each request.
+func NewTransport() *http.Transport {
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.Proxy = Proxy
+	return transport
+}
@@ -0,0 +1,55 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+
+	SetProxyConfig(&ProxyConfig{
+		Rules: []ProxyRule{
+			{
+				Hosts: []string{"registry.opentofu.org", "*.corp.example.com"},
+				Proxy: &url.URL{Scheme: "http", Host: "corp-proxy:3128"},
+			},
+			{
+				Hosts: []string{"direct.example.net"},
+			},
+		},
+	})
+	defer SetProxyConfig(nil)
+
+	tests := map[string]string{
+		"https://registry.opentofu.org/v1/providers/": "http://corp-proxy:3128",
+		"https://git.corp.example.com/":               "http://corp-proxy:3128",
+		"https://corp.example.com/":                   "",
+		"https://direct.example.net/":                 "",
+		"https://github.com/":                         "",
+	}
+	for rawURL, want := range tests {
+		t.Run(rawURL, func(t *testing.T) {
+			req, err := http.NewRequest("GET", rawURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Proxy(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			gotStr := ""
+			if got != nil {
+				gotStr = got.String()
+			}
+			if gotStr != want {
+				t.Fatalf("wrong proxy %q; want %q", gotStr, want)
+			}
+		})
+	}
+}
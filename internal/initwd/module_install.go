@@ -14,6 +14,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 
 	"github.com/apparentlymart/go-versions/versions"
@@ -25,6 +26,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/getmodules"
+	"github.com/opentofu/opentofu/internal/longpath"
 	"github.com/opentofu/opentofu/internal/modsdir"
 	"github.com/opentofu/opentofu/internal/registry"
 	"github.com/opentofu/opentofu/internal/registry/regsrc"
@@ -44,6 +46,13 @@ type ModuleInstaller struct {
 	// The keys in moduleVersionsUrl are the moduleVersion struct below and
 	// addresses and the values are underlying remote source addresses.
 	registryPackageSources map[moduleVersion]addrs.ModuleSourceRemote
+
+	// vendor, if set, contains vendored module packages that are used in
+	// preference to the registry and the packages' origins.
+	vendor *getmodules.Vendor
+
+	// vendorInto, if set, receives a copy of every module package installed.
+	vendorInto *getmodules.Vendor
 }
 
 type moduleVersion struct {
@@ -61,6 +70,54 @@ func NewModuleInstaller(modsDir string, loader *configload.Loader, reg *registry
 	}
 }
 
+// UseVendor makes the installer prefer the module packages in the given
+// vendor directory over fetching them from their origins.
+//
+// For modules from a registry, only the vendored versions are considered
+// and the registry isn't contacted at all, so that a configuration whose
+// modules are all vendored can be installed without network access.
+func (i *ModuleInstaller) UseVendor(v *getmodules.Vendor) {
+	i.vendor = v
+
+	for _, mod := range v.RegistryModules() {
+		source, err := addrs.ParseModuleSource(mod.Source)
+		regSource, ok := source.(addrs.ModuleSourceRegistry)
+		if err != nil || !ok {
+			log.Printf("[WARN] ModuleInstaller: ignoring vendored module with invalid registry address %q", mod.Source)
+			continue
+		}
+		location, err := addrs.ParseModuleSource(mod.Location)
+		remoteLocation, ok := location.(addrs.ModuleSourceRemote)
+		if err != nil || !ok {
+			log.Printf("[WARN] ModuleInstaller: ignoring vendored %s %s with invalid location %q", mod.Source, mod.Version, mod.Location)
+			continue
+		}
+
+		resp, exists := i.registryPackageVersions[regSource.Package]
+		if !exists {
+			resp = &response.ModuleVersions{
+				Modules: []*response.ModuleProviderVersions{
+					{Source: regSource.Package.String()},
+				},
+			}
+			i.registryPackageVersions[regSource.Package] = resp
+		}
+		resp.Modules[0].Versions = append(resp.Modules[0].Versions, &response.ModuleVersion{Version: mod.Version})
+		i.registryPackageSources[moduleVersion{module: regSource.Package, version: mod.Version}] = remoteLocation
+	}
+}
+
+// VendorInto makes the installer add a copy of every module package it
+// installs to the given vendor directory, along with the versions selected
+// for modules from a registry. The caller must call Save on the Vendor after
+// installation succeeds.
+//
+// Only packages that are actually fetched are vendored, so the installer
+// should be given an empty modules directory.
+func (i *ModuleInstaller) VendorInto(v *getmodules.Vendor) {
+	i.vendorInto = v
+}
+
 // InstallModules analyses the root module in the given directory and installs
 // all of its direct and transitive dependencies into the given modules
 // directory, which must already exist.
@@ -125,6 +182,12 @@ func (i *ModuleInstaller) InstallModules(ctx context.Context, rootDir, testsDir
 	}
 
 	fetcher := getmodules.NewPackageFetcher()
+	if i.vendor != nil {
+		fetcher.UseVendor(i.vendor)
+	}
+	if i.vendorInto != nil {
+		fetcher.VendorInto(i.vendorInto)
+	}
 
 	if hooks == nil {
 		// Use our no-op implementation as a placeholder
@@ -142,10 +205,21 @@ func (i *ModuleInstaller) InstallModules(ctx context.Context, rootDir, testsDir
 	cfg, instDiags := i.installDescendentModules(rootMod, manifest, walker, installErrsOnly)
 	diags = append(diags, instDiags...)
 
+	if i.vendorInto != nil {
+		for mv, remoteAddr := range i.registryPackageSources {
+			i.vendorInto.AddRegistryModule(getmodules.VendoredRegistryModule{
+				Source:   mv.module.String(),
+				Version:  mv.version,
+				Location: remoteAddr.String(),
+			})
+		}
+	}
+
 	return cfg, diags
 }
 
 func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest modsdir.Manifest, upgrade bool, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) configs.ModuleWalker {
+	claims := make(moduleDirClaims)
 	return configs.ModuleWalkerFunc(
 		func(req *configs.ModuleRequest) (*configs.Module, *version.Version, hcl.Diagnostics) {
 			var diags hcl.Diagnostics
@@ -178,6 +252,23 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 
 			log.Printf("[DEBUG] Module installer: begin %s", key)
 
+			// Modules from local paths are not copied into the module cache,
+			// so only the others can have conflicting cache directories.
+			if _, local := req.SourceAddr.(addrs.ModuleSourceLocal); !local {
+				if other := claims.claim(key); other != "" {
+					diags = diags.Append(&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Conflicting module cache directories",
+						Detail: fmt.Sprintf(
+							"Module %s would be installed in the same directory as module %s, because this system's filesystem doesn't distinguish between uppercase and lowercase letters. Rename one of the module calls so that their names differ by more than letter case.",
+							key, other,
+						),
+						Subject: req.CallRange.Ptr(),
+					})
+					return nil, nil, diags
+				}
+			}
+
 			// First we'll check if we need to upgrade/replace an existing
 			// installed module, and delete it out of the way if so.
 			replace := upgrade
@@ -193,6 +284,14 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 				case record.Version != nil && !req.VersionConstraint.Required.Check(record.Version):
 					log.Printf("[TRACE] ModuleInstaller: %s version %s no longer compatible with constraints %s", key, record.Version, req.VersionConstraint.Required)
 					replace = true
+				default:
+					if addr, ok := req.SourceAddr.(addrs.ModuleSourceLocal); ok {
+						parentRecord := manifest[manifest.ModuleKey(req.Parent.Path)]
+						if dir, changed := modsdir.LocalModuleDirChanged(parentRecord.Dir, record.Dir, addr); changed {
+							log.Printf("[TRACE] ModuleInstaller: %s directory has changed from %s to %s", key, record.Dir, dir)
+							replace = true
+						}
+					}
 				}
 			}
 
@@ -222,7 +321,7 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 				// If this is a local (relative) source then the dir will
 				// not exist, but we'll ignore that.
 				log.Printf("[TRACE] ModuleInstaller: cleaning directory %s prior to install of %s", instPath, key)
-				err := os.RemoveAll(instPath)
+				err := os.RemoveAll(longpath.Fix(instPath))
 				if err != nil && !os.IsNotExist(err) {
 					log.Printf("[TRACE] ModuleInstaller: failed to remove %s: %s", key, err)
 					diags = diags.Append(&hcl.Diagnostic{
@@ -368,11 +467,9 @@ func (i *ModuleInstaller) installLocalModule(req *configs.ModuleRequest, key str
 	// For local sources we don't actually need to modify the
 	// filesystem at all because the parent already wrote
 	// the files we need, and so we just load up what's already here.
-	newDir := filepath.Join(parentRecord.Dir, req.SourceAddr.String())
-
-	log.Printf("[TRACE] ModuleInstaller: %s uses directory from parent: %s", key, newDir)
+	log.Printf("[TRACE] ModuleInstaller: %s uses directory from parent: %s", key, filepath.Join(parentRecord.Dir, req.SourceAddr.String()))
 	// it is possible that the local directory is a symlink
-	newDir, err := filepath.EvalSymlinks(newDir)
+	newDir, err := modsdir.LocalModuleDir(parentRecord.Dir, req.SourceAddr.(addrs.ModuleSourceLocal))
 	if err != nil {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -631,6 +728,19 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 		return nil, nil, diags
 	}
 
+	// The registry may have flagged either the whole module or the specific
+	// version we selected as deprecated, in which case we'll let the user
+	// know so they can plan a migration before it stops being available.
+	for _, mv := range modMeta.Versions {
+		if mv.Deprecation == nil || mv.Version != latestMatch.Original() {
+			continue
+		}
+		diags = diags.Append(moduleDeprecationDiagnostic(req, fmt.Sprintf("Version %s of module %s", latestMatch, addr), mv.Deprecation))
+	}
+	if modMeta.Deprecation != nil {
+		diags = diags.Append(moduleDeprecationDiagnostic(req, fmt.Sprintf("Module %s", addr), modMeta.Deprecation))
+	}
+
 	// Report up to the caller that we're about to start downloading.
 	hooks.Download(key, packageAddr.String(), latestMatch)
 
@@ -681,7 +791,7 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 
 	log.Printf("[TRACE] ModuleInstaller: %s %s %s is available at %q", key, packageAddr, latestMatch, dlAddr.Package)
 
-	err := fetcher.FetchPackage(ctx, instPath, dlAddr.Package.String())
+	err := fetcher.FetchPackage(ctx, fetchDir(instPath), dlAddr.Package.String())
 	if errors.Is(err, context.Canceled) {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -769,7 +879,7 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 		return nil, diags
 	}
 
-	err := fetcher.FetchPackage(ctx, instPath, packageAddr.String())
+	err := fetcher.FetchPackage(ctx, fetchDir(instPath), packageAddr.String())
 	if err != nil {
 		// go-getter generates a poor error for an invalid relative path, so
 		// we'll detect that case and generate a better one.
@@ -855,6 +965,46 @@ func (i *ModuleInstaller) packageInstallPath(modulePath addrs.Module) string {
 	return filepath.Join(i.modsDir, strings.Join(modulePath, "."))
 }
 
+// fetchDir returns the absolute form of the given module installation path,
+// for passing to the package fetcher.
+//
+// The installation paths of deeply-nested modules are often longer than
+// Windows' traditional path length limit, which Go's os package only lifts
+// for absolute paths. We can't use longpath.Fix here, because some getters
+// pass the path to other programs, such as git, that might not accept the
+// extended-length form it returns.
+func fetchDir(instPath string) string {
+	abs, err := filepath.Abs(instPath)
+	if err != nil {
+		return instPath
+	}
+	return abs
+}
+
+// caseInsensitiveFilesystem is true on platforms whose filesystems don't
+// distinguish between uppercase and lowercase letters by default.
+var caseInsensitiveFilesystem = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+// moduleDirClaims tracks the module keys whose packages have been installed
+// in the module cache, to detect modules that would share a cache directory
+// on a case-insensitive filesystem.
+type moduleDirClaims map[string]string
+
+// claim records that the module with the given key is being installed, and
+// returns the key of another module using the same cache directory, or an
+// empty string if there is none.
+func (c moduleDirClaims) claim(key string) string {
+	if !caseInsensitiveFilesystem {
+		return ""
+	}
+	folded := strings.ToLower(key)
+	if other, exists := c[folded]; exists && other != key {
+		return other
+	}
+	c[folded] = key
+	return ""
+}
+
 // maybeImproveLocalInstallError is a helper function which can recognize
 // some specific situations where it can return a more helpful error message
 // and thus replace the given errors with those if so.
@@ -996,3 +1146,14 @@ func splitAddrSubdir(addr addrs.ModuleSource) (string, string) {
 		return addr.String(), ""
 	}
 }
+
+// moduleDeprecationDiagnostic returns a warning diagnostic describing a
+// deprecation announced by a module registry for the given module request.
+func moduleDeprecationDiagnostic(req *configs.ModuleRequest, name string, dep *response.Deprecation) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Deprecated module",
+		Detail:   dep.Describe(name),
+		Subject:  req.CallRange.Ptr(),
+	}
+}
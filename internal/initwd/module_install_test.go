@@ -19,6 +19,7 @@ import (
 	"github.com/go-test/deep"
 	"github.com/google/go-cmp/cmp"
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
 	svchost "github.com/hashicorp/terraform-svchost"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -26,6 +27,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/copy"
 	"github.com/opentofu/opentofu/internal/registry"
+	"github.com/opentofu/opentofu/internal/registry/response"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 
 	_ "github.com/opentofu/opentofu/internal/logging"
@@ -923,6 +925,74 @@ func TestLoadInstallModules_registryFromTest(t *testing.T) {
 	}
 }
 
+func TestModuleDirClaims(t *testing.T) {
+	for _, insensitive := range []bool{true, false} {
+		t.Run(fmt.Sprintf("case insensitive %t", insensitive), func(t *testing.T) {
+			old := caseInsensitiveFilesystem
+			caseInsensitiveFilesystem = insensitive
+			t.Cleanup(func() {
+				caseInsensitiveFilesystem = old
+			})
+
+			claims := make(moduleDirClaims)
+			for _, key := range []string{"network", "network.subnet", "network"} {
+				if other := claims.claim(key); other != "" {
+					t.Fatalf("unexpected conflict between %s and %s", key, other)
+				}
+			}
+
+			want := ""
+			if insensitive {
+				want = "network.subnet"
+			}
+			if got := claims.claim("Network.Subnet"); got != want {
+				t.Errorf("wrong conflict %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestModuleDeprecationDiagnostic(t *testing.T) {
+	req := &configs.ModuleRequest{
+		CallRange: hcl.Range{
+			Filename: "main.tf",
+			Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+			End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+		},
+	}
+
+	tests := map[string]struct {
+		dep        *response.Deprecation
+		wantDetail string
+	}{
+		"reason only": {
+			&response.Deprecation{Reason: "Use example/network/aws instead."},
+			"Module example/old/aws is deprecated. Use example/network/aws instead.",
+		},
+		"end of life and link": {
+			&response.Deprecation{
+				EndOfLife: "2025-01-01",
+				Link:      "https://example.com/deprecated",
+			},
+			"Module example/old/aws is deprecated and reaches its end of life on 2025-01-01. For more information, see https://example.com/deprecated.",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := moduleDeprecationDiagnostic(req, "Module example/old/aws", test.dep)
+			want := &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Deprecated module",
+				Detail:   test.wantDetail,
+				Subject:  req.CallRange.Ptr(),
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("wrong diagnostic\n%s", diff)
+			}
+		})
+	}
+}
+
 type testInstallHooks struct {
 	Calls []testInstallHookCall
 }
@@ -340,6 +340,36 @@ func (e *Expander) knowsResource(want addrs.AbsResource) bool {
 	return e.exps.knowsResource(want)
 }
 
+func (e *Expander) moduleCallInstanceKeys(call addrs.AbsModuleCall) ([]addrs.InstanceKey, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	modInst := e.exps.getModuleInstance(call.Module)
+	if modInst == nil {
+		return nil, false
+	}
+	exp, ok := modInst.moduleCalls[call.Call]
+	if !ok {
+		return nil, false
+	}
+	return exp.instanceKeys(), true
+}
+
+func (e *Expander) resourceInstanceKeys(addr addrs.AbsResource) ([]addrs.InstanceKey, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	modInst := e.exps.getModuleInstance(addr.Module)
+	if modInst == nil {
+		return nil, false
+	}
+	exp, ok := modInst.resources[addr.Resource]
+	if !ok {
+		return nil, false
+	}
+	return exp.instanceKeys(), true
+}
+
 type expanderModule struct {
 	moduleCalls    map[addrs.ModuleCall]expansion
 	resources      map[addrs.Resource]expansion
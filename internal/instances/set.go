@@ -54,3 +54,17 @@ func (s Set) HasResource(want addrs.AbsResource) bool {
 func (s Set) InstancesForModule(modAddr addrs.Module) []addrs.ModuleInstance {
 	return s.exp.expandModule(modAddr, true)
 }
+
+// ModuleCallInstanceKeys returns the instance keys of all of the instances
+// of the given module call, and true, if the set has an expansion for the
+// module call. Otherwise it returns nil and false.
+func (s Set) ModuleCallInstanceKeys(call addrs.AbsModuleCall) ([]addrs.InstanceKey, bool) {
+	return s.exp.moduleCallInstanceKeys(call)
+}
+
+// ResourceInstanceKeys returns the instance keys of all of the instances of
+// the given resource, and true, if the set has an expansion for the
+// resource. Otherwise it returns nil and false.
+func (s Set) ResourceInstanceKeys(addr addrs.AbsResource) ([]addrs.InstanceKey, bool) {
+	return s.exp.resourceInstanceKeys(addr)
+}
@@ -213,4 +213,26 @@ func TestSet(t *testing.T) {
 	if set.InstancesForModule(addrs.RootModule.Child("missing")) != nil {
 		t.Error("unexpected instances from missing module")
 	}
+
+	// ModuleCallInstanceKeys tests
+	if got, ok := set.ModuleCallInstanceKeys(addrs.RootModuleInstance.ChildCall("for_each")); !ok || len(got) != 2 || got[0] != addrs.StringKey("a") || got[1] != addrs.StringKey("b") {
+		t.Errorf("wrong keys for module.for_each: %#v, %t", got, ok)
+	}
+	if got, ok := set.ModuleCallInstanceKeys(addrs.RootModuleInstance.ChildCall("count")); !ok || len(got) != 2 || got[0] != addrs.IntKey(0) || got[1] != addrs.IntKey(1) {
+		t.Errorf("wrong keys for module.count: %#v, %t", got, ok)
+	}
+	if got, ok := set.ModuleCallInstanceKeys(addrs.RootModuleInstance.ChildCall("missing")); ok {
+		t.Errorf("unexpected keys for module.missing: %#v", got)
+	}
+
+	// ResourceInstanceKeys tests
+	if got, ok := set.ResourceInstanceKeys(rAddr("for_each").Absolute(addrs.RootModuleInstance)); !ok || len(got) != 1 || got[0] != addrs.StringKey("c") {
+		t.Errorf("wrong keys for test_thing.for_each: %#v, %t", got, ok)
+	}
+	if got, ok := set.ResourceInstanceKeys(rAddr("count").Absolute(addrs.RootModuleInstance.Child("for_each", addrs.StringKey("a")))); !ok || len(got) != 0 {
+		t.Errorf("wrong keys for module.for_each[\"a\"].test_thing.count: %#v, %t", got, ok)
+	}
+	if got, ok := set.ResourceInstanceKeys(rAddr("nonexist").Absolute(addrs.RootModuleInstance)); ok {
+		t.Errorf("unexpected keys for test_thing.nonexist: %#v", got)
+	}
 }
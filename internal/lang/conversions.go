@@ -0,0 +1,165 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+// ImplicitConversion describes a part of a value that must be converted
+// between the string, number and bool types for the value to conform to a
+// type constraint.
+type ImplicitConversion struct {
+	// Path is where the converted part is within the whole value, in index
+	// and attribute syntax such as [0].name, or an empty string if the whole
+	// value is converted. An index of [*] stands for every element of a
+	// collection.
+	Path string
+
+	From, To cty.Type
+}
+
+// ImplicitConversions returns the conversions between primitive types that
+// are needed to convert a value of the given type to the wanted type.
+//
+// Conversions between collection and structural types are not reported,
+// because they never change the values of the elements.
+func ImplicitConversions(given, want cty.Type) []ImplicitConversion {
+	var ret []ImplicitConversion
+	findImplicitConversions(given, want, "", &ret)
+	return ret
+}
+
+func findImplicitConversions(given, want cty.Type, path string, ret *[]ImplicitConversion) {
+	if given == cty.DynamicPseudoType || want == cty.DynamicPseudoType {
+		return
+	}
+
+	switch {
+	case want.IsPrimitiveType():
+		if given.IsPrimitiveType() && !given.Equals(want) {
+			*ret = append(*ret, ImplicitConversion{Path: path, From: given, To: want})
+		}
+
+	case want.IsListType() || want.IsSetType():
+		switch {
+		case given.IsListType() || given.IsSetType():
+			findImplicitConversions(given.ElementType(), want.ElementType(), path+"[*]", ret)
+		case given.IsTupleType():
+			for i, ety := range given.TupleElementTypes() {
+				findImplicitConversions(ety, want.ElementType(), fmt.Sprintf("%s[%d]", path, i), ret)
+			}
+		}
+
+	case want.IsMapType():
+		switch {
+		case given.IsMapType():
+			findImplicitConversions(given.ElementType(), want.ElementType(), path+"[*]", ret)
+		case given.IsObjectType():
+			for _, name := range sortedAttributeNames(given) {
+				findImplicitConversions(given.AttributeType(name), want.ElementType(), fmt.Sprintf("%s[%q]", path, name), ret)
+			}
+		}
+
+	case want.IsObjectType():
+		for _, name := range sortedAttributeNames(want) {
+			switch {
+			case given.IsObjectType() && given.HasAttribute(name):
+				findImplicitConversions(given.AttributeType(name), want.AttributeType(name), path+"."+name, ret)
+			case given.IsMapType():
+				findImplicitConversions(given.ElementType(), want.AttributeType(name), path+"."+name, ret)
+			}
+		}
+
+	case want.IsTupleType():
+		if given.IsTupleType() && given.Length() == want.Length() {
+			wantTypes := want.TupleElementTypes()
+			for i, ety := range given.TupleElementTypes() {
+				findImplicitConversions(ety, wantTypes[i], fmt.Sprintf("%s[%d]", path, i), ret)
+			}
+		}
+	}
+}
+
+func sortedAttributeNames(ty cty.Type) []string {
+	names := make([]string, 0, len(ty.AttributeTypes()))
+	for name := range ty.AttributeTypes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ImplicitConversionDiagnostics returns a diagnostic with the given severity
+// for each implicit conversion that converting a value of the given type to
+// the wanted type requires, attributed to the given source range.
+func ImplicitConversionDiagnostics(severity hcl.DiagnosticSeverity, given, want cty.Type, subject hcl.Range) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, conv := range ImplicitConversions(given, want) {
+		what := "The value of this expression"
+		if conv.Path != "" {
+			what = fmt.Sprintf("The value at %s in this expression", conv.Path)
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: severity,
+			Summary:  "Implicit type conversion",
+			Detail: fmt.Sprintf(
+				"%s is a %s, but a %s is required. Because strict_conversions is set for this module, OpenTofu reports implicit conversions between types. Use a value of the required type, or convert it explicitly with the %s function.",
+				what, conv.From.FriendlyName(), conv.To.FriendlyName(), conversionFunctionName(conv.To),
+			),
+			Subject: subject.Ptr(),
+		})
+	}
+	return diags
+}
+
+func conversionFunctionName(ty cty.Type) string {
+	switch ty {
+	case cty.Number:
+		return "tonumber"
+	case cty.Bool:
+		return "tobool"
+	default:
+		return "tostring"
+	}
+}
+
+// checkBlockConversions returns the implicit conversion diagnostics for the
+// attributes of the given body, and of its nested blocks, that have values of
+// a different type than the schema requires.
+func (s *Scope) checkBlockConversions(body hcl.Body, schema *configschema.Block, ctx *hcl.EvalContext) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	// Any problems with the body itself are reported when decoding it.
+	content, _, _ := body.PartialContent(hcldec.ImpliedSchema(schema.DecoderSpec()))
+
+	names := make([]string, 0, len(content.Attributes))
+	for name := range content.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attrS := schema.Attributes[name]
+		if attrS == nil {
+			continue
+		}
+		attr := content.Attributes[name]
+		val, _ := attr.Expr.Value(ctx)
+		diags = append(diags, ImplicitConversionDiagnostics(s.StrictConversions, val.Type(), attrS.ImpliedType(), attr.Expr.Range())...)
+	}
+
+	for _, block := range content.Blocks {
+		if blockS := schema.BlockTypes[block.Type]; blockS != nil {
+			diags = append(diags, s.checkBlockConversions(block.Body, &blockS.Block, ctx)...)
+		}
+	}
+	return diags
+}
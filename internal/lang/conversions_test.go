@@ -0,0 +1,157 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+func TestImplicitConversions(t *testing.T) {
+	tests := map[string]struct {
+		given, want cty.Type
+		wantPaths   []string
+	}{
+		"same type": {
+			given: cty.String,
+			want:  cty.String,
+		},
+		"number to string": {
+			given:     cty.Number,
+			want:      cty.String,
+			wantPaths: []string{""},
+		},
+		"unknown type": {
+			given: cty.DynamicPseudoType,
+			want:  cty.String,
+		},
+		"tuple to list": {
+			given:     cty.Tuple([]cty.Type{cty.String, cty.Bool, cty.Number}),
+			want:      cty.List(cty.String),
+			wantPaths: []string{"[1]", "[2]"},
+		},
+		"list to set": {
+			given:     cty.List(cty.Number),
+			want:      cty.Set(cty.String),
+			wantPaths: []string{"[*]"},
+		},
+		"object to map": {
+			given: cty.Object(map[string]cty.Type{
+				"b": cty.Number,
+				"a": cty.String,
+			}),
+			want:      cty.Map(cty.String),
+			wantPaths: []string{`["b"]`},
+		},
+		"nested object": {
+			given: cty.Object(map[string]cty.Type{
+				"port":  cty.String,
+				"name":  cty.String,
+				"extra": cty.Number,
+			}),
+			want: cty.Object(map[string]cty.Type{
+				"port": cty.Number,
+				"name": cty.String,
+			}),
+			wantPaths: []string{".port"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotPaths []string
+			for _, conv := range ImplicitConversions(test.given, test.want) {
+				gotPaths = append(gotPaths, conv.Path)
+			}
+			if diff := cmp.Diff(test.wantPaths, gotPaths); diff != "" {
+				t.Errorf("wrong conversions\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScopeStrictConversions(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"name": {Type: cty.String, Optional: true},
+			"port": {Type: cty.Number, Optional: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"rule": {
+				Nesting: configschema.NestingList,
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"enabled": {Type: cty.Bool, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	src := `
+name = "web"
+port = "8080"
+rule {
+  enabled = "true"
+}
+`
+	file, parseDiags := hclsyntax.ParseConfig([]byte(src), "test.tf", hcl.InitialPos)
+	if parseDiags.HasErrors() {
+		t.Fatal(parseDiags.Error())
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		scope := &Scope{Data: &dataForTests{}, ParseRef: addrs.ParseRef}
+		_, diags := scope.EvalBlock(file.Body, schema)
+		if len(diags) != 0 {
+			t.Fatalf("unexpected diagnostics: %s", diags.ErrWithWarnings())
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		scope := &Scope{Data: &dataForTests{}, ParseRef: addrs.ParseRef, StrictConversions: hcl.DiagWarning}
+		val, diags := scope.EvalBlock(file.Body, schema)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Err())
+		}
+		if got := val.GetAttr("port"); !got.RawEquals(cty.NumberIntVal(8080)) {
+			t.Errorf("value was not converted: %#v", got)
+		}
+		if len(diags) != 2 {
+			t.Fatalf("wrong number of diagnostics %d; want 2\n%s", len(diags), diags.ErrWithWarnings())
+		}
+		for i, want := range []struct {
+			line   int
+			detail string
+		}{
+			{3, "is a string, but a number is required"},
+			{5, "is a string, but a bool is required"},
+		} {
+			desc := diags[i].Description()
+			rng := diags[i].Source().Subject
+			if desc.Summary != "Implicit type conversion" || !strings.Contains(desc.Detail, want.detail) || rng == nil || rng.Start.Line != want.line {
+				t.Errorf("wrong diagnostic %d: %s: %s (%v)", i, desc.Summary, desc.Detail, rng)
+			}
+		}
+	})
+
+	t.Run("expression", func(t *testing.T) {
+		scope := &Scope{Data: &dataForTests{}, ParseRef: addrs.ParseRef, StrictConversions: hcl.DiagError}
+		expr, parseDiags := hclsyntax.ParseExpression([]byte(`["a", 1]`), "test.tf", hcl.InitialPos)
+		if parseDiags.HasErrors() {
+			t.Fatal(parseDiags.Error())
+		}
+		_, diags := scope.EvalExpr(expr, cty.List(cty.String))
+		if !diags.HasErrors() || !strings.Contains(diags.Err().Error(), "The value at [1] in this expression is a number") {
+			t.Fatalf("wrong diagnostics: %s", diags.ErrWithWarnings())
+		}
+	})
+}
@@ -66,6 +66,11 @@ func (s *Scope) EvalBlock(body hcl.Body, schema *configschema.Block) (cty.Value,
 		return cty.UnknownVal(schema.ImpliedType()), diags
 	}
 
+	diags = diags.Append(blockFunctionDeprecationDiagnostics(body, schema))
+	if s.StrictConversions != hcl.DiagInvalid {
+		diags = diags.Append(s.checkBlockConversions(body, schema, ctx))
+	}
+
 	// HACK: In order to remain compatible with some assumptions made in
 	// Terraform v0.11 and earlier about the approximate equivalence of
 	// attribute vs. block syntax, we do a just-in-time fixup here to allow
@@ -89,6 +94,58 @@ func (s *Scope) EvalSelfBlock(body hcl.Body, self cty.Value, schema *configschem
 
 	spec := schema.DecoderSpec()
 
+	refs, refDiags := References(s.ParseRef, hcldec.Variables(body, spec))
+	diags = diags.Append(refDiags)
+
+	ctx, ctxDiags := s.selfEvalContext(refs, self, keyData)
+	diags = diags.Append(ctxDiags)
+
+	val, decDiags := hcldec.Decode(body, spec, ctx)
+	diags = diags.Append(enhanceFunctionDiags(decDiags))
+	return val, diags
+}
+
+// EvalSelfExpr evaluates the given expression within the same restricted
+// scope as EvalSelfBlock, and converts the result to the given type.
+//
+// Pass an expected type of cty.DynamicPseudoType to skip automatic conversion
+// and just obtain the returned value directly.
+func (s *Scope) EvalSelfExpr(expr hcl.Expression, self cty.Value, wantType cty.Type, keyData instances.RepetitionData) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	refs, refDiags := ReferencesInExpr(s.ParseRef, expr)
+	diags = diags.Append(refDiags)
+
+	ctx, ctxDiags := s.selfEvalContext(refs, self, keyData)
+	diags = diags.Append(ctxDiags)
+
+	val, evalDiags := expr.Value(ctx)
+	diags = diags.Append(enhanceFunctionDiags(evalDiags))
+
+	if wantType != cty.DynamicPseudoType {
+		var convErr error
+		val, convErr = convert.Convert(val, wantType)
+		if convErr != nil {
+			val = cty.UnknownVal(wantType)
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity:    hcl.DiagError,
+				Summary:     "Incorrect value type",
+				Detail:      fmt.Sprintf("Invalid expression value: %s.", tfdiags.FormatError(convErr)),
+				Subject:     expr.Range().Ptr(),
+				Expression:  expr,
+				EvalContext: ctx,
+			})
+		}
+	}
+
+	return val, diags
+}
+
+// selfEvalContext builds the restricted evaluation context used by
+// EvalSelfBlock and EvalSelfExpr.
+func (s *Scope) selfEvalContext(refs []*addrs.Reference, self cty.Value, keyData instances.RepetitionData) (*hcl.EvalContext, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
 	vals := make(map[string]cty.Value)
 	vals["self"] = self
 
@@ -103,9 +160,6 @@ func (s *Scope) EvalSelfBlock(body hcl.Body, self cty.Value, schema *configschem
 		})
 	}
 
-	refs, refDiags := References(s.ParseRef, hcldec.Variables(body, spec))
-	diags = diags.Append(refDiags)
-
 	terraformAttrs := map[string]cty.Value{}
 	pathAttrs := map[string]cty.Value{}
 
@@ -153,10 +207,7 @@ func (s *Scope) EvalSelfBlock(body hcl.Body, self cty.Value, schema *configschem
 		// TODO consider if any provider functions make sense here
 		Functions: s.Functions(),
 	}
-
-	val, decDiags := hcldec.Decode(body, schema.DecoderSpec(), ctx)
-	diags = diags.Append(enhanceFunctionDiags(decDiags))
-	return val, diags
+	return ctx, diags
 }
 
 // EvalExpr evaluates a single expression in the receiving context and returns
@@ -182,8 +233,13 @@ func (s *Scope) EvalExpr(expr hcl.Expression, wantType cty.Type) (cty.Value, tfd
 
 	val, evalDiags := expr.Value(ctx)
 	diags = diags.Append(enhanceFunctionDiags(evalDiags))
+	diags = diags.Append(FunctionDeprecationDiagnostics(expr))
 
 	if wantType != cty.DynamicPseudoType {
+		if s.StrictConversions != hcl.DiagInvalid {
+			diags = diags.Append(ImplicitConversionDiagnostics(s.StrictConversions, val.Type(), wantType, expr.Range()))
+		}
+
 		var convErr error
 		val, convErr = convert.Convert(val, wantType)
 		if convErr != nil {
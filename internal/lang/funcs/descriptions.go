@@ -349,6 +349,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`replace` searches a given string for another given substring, and replaces each occurrence with a given replacement string.",
 		ParamDescription: []string{"", "", ""},
 	},
+	"resource_changes": {
+		Description:      "`resource_changes` returns the changes to resource instances in the saved plan loaded into the OpenTofu console, in the same structure as the `resource_changes` property of the JSON plan representation.",
+		ParamDescription: []string{},
+	},
 	"reverse": {
 		Description:      "`reverse` takes a sequence and produces a new sequence of the same length with all of the same elements as the given sequence but in reverse order.",
 		ParamDescription: []string{""},
@@ -455,6 +459,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`timestamp` returns a UTC timestamp string in [RFC 3339](https://tools.ietf.org/html/rfc3339) format.",
 		ParamDescription: []string{},
 	},
+	"planned_values": {
+		Description:      "`planned_values` returns the planned values of the resources and outputs in the saved plan loaded into the OpenTofu console, in the same structure as the `planned_values` property of the JSON plan representation.",
+		ParamDescription: []string{},
+	},
 	"plantimestamp": {
 		Description:      "`plantimestamp` returns a UTC timestamp string in [RFC 3339](https://tools.ietf.org/html/rfc3339) format, fixed to a constant time representing the time of the plan.",
 		ParamDescription: []string{},
@@ -0,0 +1,41 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"errors"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// MakeSavedPlanFunc constructs a function that returns the named property of
+// the JSON representation of a saved plan, given as a cty value, or the given
+// empty value if the plan doesn't have that property.
+//
+// These functions are only available in the OpenTofu console. If no plan was
+// loaded then plan is cty.NilVal and the function returns an error.
+func MakeSavedPlanFunc(plan cty.Value, property string, empty cty.Value) function.Function {
+	if plan == cty.NilVal {
+		return function.New(&function.Spec{
+			Params: []function.Parameter{},
+			Type:   function.StaticReturnType(cty.DynamicPseudoType),
+			Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+				return cty.DynamicVal, errors.New("no saved plan is loaded; start the console with the -plan option to use this function")
+			},
+		})
+	}
+
+	ret := empty
+	if plan.Type().IsObjectType() && plan.Type().HasAttribute(property) {
+		ret = plan.GetAttr(property)
+	}
+	return function.New(&function.Spec{
+		Params: []function.Parameter{},
+		Type:   function.StaticReturnType(ret.Type()),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return ret, nil
+		},
+	})
+}
@@ -0,0 +1,208 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+// FunctionDeprecation describes a built-in function, or a particular way of
+// calling one, that is deprecated.
+type FunctionDeprecation struct {
+	// Applies returns true if the deprecation applies to the given call. If
+	// Applies is nil then all calls to the function are deprecated.
+	Applies func(call *hclsyntax.FunctionCallExpr) bool
+
+	// Detail explains what is deprecated and what to use instead, for use as
+	// the detail of the diagnostic reported for each deprecated call.
+	Detail string
+
+	// Fix returns source code to replace the whole of the given call with,
+	// given the source code of the file the call is in. Fix may be nil, or
+	// may return false, if the call can't be replaced automatically.
+	Fix func(call *hclsyntax.FunctionCallExpr, src []byte) (string, bool)
+}
+
+// functionDeprecations is the table of deprecations for built-in functions,
+// keyed by function name without the core:: namespace.
+var functionDeprecations = map[string][]FunctionDeprecation{
+	"lookup": {
+		{
+			Applies: func(call *hclsyntax.FunctionCallExpr) bool {
+				return len(call.Args) == 2 && !call.ExpandFinal
+			},
+			Detail: "Calling lookup without a default value is deprecated, because it is equivalent to the index syntax map[key].",
+			Fix: func(call *hclsyntax.FunctionCallExpr, src []byte) (string, bool) {
+				coll := string(call.Args[0].Range().SliceBytes(src))
+				key := string(call.Args[1].Range().SliceBytes(src))
+				if !isIndexableExpr(call.Args[0]) {
+					coll = "(" + coll + ")"
+				}
+				return coll + "[" + key + "]", true
+			},
+		},
+	},
+}
+
+// isIndexableExpr returns true if an index can be appended directly to the
+// source code of the given expression without changing its meaning.
+func isIndexableExpr(expr hclsyntax.Expression) bool {
+	switch expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr, *hclsyntax.RelativeTraversalExpr,
+		*hclsyntax.IndexExpr, *hclsyntax.FunctionCallExpr, *hclsyntax.ParenthesesExpr,
+		*hclsyntax.TupleConsExpr, *hclsyntax.ObjectConsExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// deprecationForCall returns the deprecation that applies to the given call,
+// if any.
+func deprecationForCall(call *hclsyntax.FunctionCallExpr) (*FunctionDeprecation, bool) {
+	deps := functionDeprecations[strings.TrimPrefix(call.Name, CoreNamespace)]
+	for i := range deps {
+		if deps[i].Applies == nil || deps[i].Applies(call) {
+			return &deps[i], true
+		}
+	}
+	return nil, false
+}
+
+// FunctionDeprecationDiagnostics returns a warning for each call to a
+// deprecated built-in function in the given expression.
+//
+// Only expressions in the native syntax can be checked, so for any other
+// expression the result is always empty.
+func FunctionDeprecationDiagnostics(expr hcl.Expression) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	node, ok := hcl.UnwrapExpression(expr).(hclsyntax.Node)
+	if !ok {
+		return nil
+	}
+	hclsyntax.VisitAll(node, func(node hclsyntax.Node) hcl.Diagnostics {
+		call, ok := node.(*hclsyntax.FunctionCallExpr)
+		if !ok {
+			return nil
+		}
+		dep, ok := deprecationForCall(call)
+		if !ok {
+			return nil
+		}
+		detail := dep.Detail
+		if dep.Fix != nil {
+			detail += ` Run "tofu fmt -fix" to update the call automatically.`
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  fmt.Sprintf("Deprecated use of function %q", strings.TrimPrefix(call.Name, CoreNamespace)),
+			Detail:   detail,
+			Subject:  call.Range().Ptr(),
+		})
+		return nil
+	})
+	return diags
+}
+
+// blockFunctionDeprecationDiagnostics returns the function deprecation
+// warnings for the attributes of the given body, and of its nested blocks.
+func blockFunctionDeprecationDiagnostics(body hcl.Body, schema *configschema.Block) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	seen := make(map[hcl.Range]bool)
+	var visit func(body hcl.Body, schema *configschema.Block)
+	visit = func(body hcl.Body, schema *configschema.Block) {
+		// Any problems with the body itself are reported when decoding it.
+		content, _, _ := body.PartialContent(hcldec.ImpliedSchema(schema.DecoderSpec()))
+		for _, attr := range content.Attributes {
+			for _, diag := range FunctionDeprecationDiagnostics(attr.Expr) {
+				// The content of an expanded dynamic block repeats the same
+				// expressions once for each generated block.
+				if seen[*diag.Subject] {
+					continue
+				}
+				seen[*diag.Subject] = true
+				diags = append(diags, diag)
+			}
+		}
+		for _, block := range content.Blocks {
+			if blockS := schema.BlockTypes[block.Type]; blockS != nil {
+				visit(block.Body, &blockS.Block)
+			}
+		}
+	}
+	visit(body, schema)
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Subject.Start.Byte < diags[j].Subject.Start.Byte
+	})
+	return diags
+}
+
+// FixDeprecatedFunctionCalls returns the given native syntax source code
+// with each call to a deprecated built-in function that has an automatic fix
+// replaced by its fix.
+//
+// The source code must be free of syntax errors. The result is not
+// formatted, so callers should format it afterwards.
+func FixDeprecatedFunctionCalls(src []byte, filename string) ([]byte, hcl.Diagnostics) {
+	// Each round replaces only the outermost deprecated calls, so that the
+	// edits never overlap. Calls nested inside those are fixed in a later
+	// round, once the source code has been parsed again.
+	for {
+		file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return src, diags
+		}
+
+		type edit struct {
+			rng         hcl.Range
+			replacement string
+		}
+		var edits []edit
+		hclsyntax.VisitAll(file.Body.(*hclsyntax.Body), func(node hclsyntax.Node) hcl.Diagnostics {
+			call, ok := node.(*hclsyntax.FunctionCallExpr)
+			if !ok {
+				return nil
+			}
+			dep, ok := deprecationForCall(call)
+			if !ok || dep.Fix == nil {
+				return nil
+			}
+			rng := call.Range()
+			for _, other := range edits {
+				if other.rng.Overlaps(rng) {
+					return nil
+				}
+			}
+			if replacement, ok := dep.Fix(call, src); ok {
+				edits = append(edits, edit{rng: rng, replacement: replacement})
+			}
+			return nil
+		})
+		if len(edits) == 0 {
+			return src, nil
+		}
+
+		// Apply the edits from the end of the file backwards, so that the
+		// byte offsets of the edits not yet applied remain correct.
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].rng.Start.Byte > edits[j].rng.Start.Byte
+		})
+		for _, e := range edits {
+			var buf []byte
+			buf = append(buf, src[:e.rng.Start.Byte]...)
+			buf = append(buf, e.replacement...)
+			buf = append(buf, src[e.rng.End.Byte:]...)
+			src = buf
+		}
+	}
+}
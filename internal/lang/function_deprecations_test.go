@@ -0,0 +1,153 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+func TestFunctionDeprecationDiagnostics(t *testing.T) {
+	tests := map[string]struct {
+		expr      string
+		wantCount int
+	}{
+		"not deprecated": {
+			expr: `lookup({a = 1}, "a", 0)`,
+		},
+		"two arguments": {
+			expr:      `lookup({a = 1}, "a")`,
+			wantCount: 1,
+		},
+		"namespaced": {
+			expr:      `core::lookup({a = 1}, "a")`,
+			wantCount: 1,
+		},
+		"expanded arguments": {
+			expr: `lookup([{a = 1}, "a"]...)`,
+		},
+		"nested": {
+			expr:      `[lookup({a = 1}, "a"), lookup(lookup({b = {c = 1}}, "b"), "c")]`,
+			wantCount: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(test.expr), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			got := FunctionDeprecationDiagnostics(expr)
+			if len(got) != test.wantCount {
+				t.Fatalf("wrong number of diagnostics %d; want %d\n%s", len(got), test.wantCount, got.Error())
+			}
+			for _, diag := range got {
+				if diag.Severity != hcl.DiagWarning || diag.Summary != `Deprecated use of function "lookup"` {
+					t.Errorf("wrong diagnostic: %s", diag)
+				}
+			}
+		})
+	}
+}
+
+func TestScopeEvalBlock_functionDeprecations(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"value": {Type: cty.String, Optional: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"item": {
+				Nesting: configschema.NestingList,
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"value": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	src := `
+value = lookup({a = "b"}, "a")
+dynamic "item" {
+  for_each = ["x", "y"]
+  content {
+    value = lookup({x = "y"}, item.value, "")
+  }
+}
+item {
+  value = lookup({c = "d"}, "c")
+}
+`
+	file, diags := hclsyntax.ParseConfig([]byte(src), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	scope := &Scope{Data: &dataForTests{}, ParseRef: addrs.ParseRef}
+	body, expandDiags := scope.ExpandBlock(file.Body, schema)
+	if expandDiags.HasErrors() {
+		t.Fatal(expandDiags.Err())
+	}
+	val, evalDiags := scope.EvalBlock(body, schema)
+	if evalDiags.HasErrors() {
+		t.Fatal(evalDiags.Err())
+	}
+	if got := val.GetAttr("value"); !got.RawEquals(cty.StringVal("b")) {
+		t.Errorf("wrong value %#v", got)
+	}
+	if len(evalDiags) != 2 {
+		t.Fatalf("wrong number of diagnostics %d; want 2\n%s", len(evalDiags), evalDiags.ErrWithWarnings())
+	}
+	for i, wantLine := range []int{2, 10} {
+		if got := evalDiags[i].Source().Subject.Start.Line; got != wantLine {
+			t.Errorf("diagnostic %d is on line %d; want %d", i, got, wantLine)
+		}
+	}
+}
+
+func TestFixDeprecatedFunctionCalls(t *testing.T) {
+	tests := map[string]struct {
+		src, want string
+	}{
+		"nothing to fix": {
+			src:  `a = lookup(var.m, "k", null)`,
+			want: `a = lookup(var.m, "k", null)`,
+		},
+		"traversal": {
+			src:  `a = lookup(var.m, "k")`,
+			want: `a = var.m["k"]`,
+		},
+		"parenthesized": {
+			src:  `a = lookup(merge(var.m, var.n), var.k) == lookup(var.x ? var.m : var.n, "k")`,
+			want: `a = merge(var.m, var.n)[var.k] == (var.x ? var.m : var.n)["k"]`,
+		},
+		"nested": {
+			src:  `a = lookup(lookup(var.m, lookup(var.keys, "k")), "j")`,
+			want: `a = var.m[var.keys["k"]]["j"]`,
+		},
+		"in template": {
+			src:  `a = "${lookup(var.m, "k")}-suffix"`,
+			want: `a = "${var.m["k"]}-suffix"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := FixDeprecatedFunctionCalls([]byte(test.src), "test.tf")
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			if string(got) != test.want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
@@ -37,6 +37,9 @@ func (s *Scope) Functions() map[string]function.Function {
 		if s.ConsoleMode {
 			// The type function is only available in OpenTofu console.
 			s.funcs["type"] = funcs.TypeFunc
+			// So are the functions for interrogating a saved plan.
+			s.funcs["planned_values"] = funcs.MakeSavedPlanFunc(s.SavedPlan, "planned_values", cty.EmptyObjectVal)
+			s.funcs["resource_changes"] = funcs.MakeSavedPlanFunc(s.SavedPlan, "resource_changes", cty.EmptyTupleVal)
 		} else {
 			// The plantimestamp function doesn't make sense in the OpenTofu
 			// console.
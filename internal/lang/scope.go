@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -68,11 +69,22 @@ type Scope struct {
 	// included in this scope.
 	ConsoleMode bool
 
+	// SavedPlan is the JSON representation of a saved plan, as a cty value,
+	// that console-only functions expose when the console is started with
+	// the -plan option. It is cty.NilVal if no plan was loaded.
+	SavedPlan cty.Value
+
 	// PlanTimestamp is a timestamp representing when the plan was made. It will
 	// either have been generated during this operation or read from the plan.
 	PlanTimestamp time.Time
 
 	ProviderFunctions ProviderFunction
+
+	// StrictConversions is the severity of the diagnostics to report for
+	// each value that EvalExpr or EvalBlock implicitly converts between the
+	// string, number and bool types. The zero value, hcl.DiagInvalid,
+	// disables these diagnostics.
+	StrictConversions hcl.DiagnosticSeverity
 }
 
 type ProviderFunction func(addrs.ProviderFunction, tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics)
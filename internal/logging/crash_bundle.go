@@ -0,0 +1,205 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/opentofu/opentofu/version"
+)
+
+// maxCoreStackFrames is the number of OpenTofu's own stack frames recorded
+// for each failed plugin request.
+const maxCoreStackFrames = 32
+
+// PluginRPCFailure describes a plugin request that failed because the plugin
+// stopped responding, usually because it crashed.
+//
+// Only the name of the request is recorded, never its arguments, since those
+// can contain sensitive values.
+type PluginRPCFailure struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Error  string    `json:"error"`
+
+	// CoreStack summarizes OpenTofu's own call stack when the request
+	// failed, as one "function:line" entry per frame, innermost first.
+	CoreStack []string `json:"core_stack"`
+}
+
+// crashBundle is the JSON document written by WritePluginCrashBundle.
+type crashBundle struct {
+	FormatVersion   string              `json:"format_version"`
+	Timestamp       time.Time           `json:"timestamp"`
+	OpenTofuVersion string              `json:"opentofu_version"`
+	Plugins         []crashBundlePlugin `json:"plugins"`
+	FailedRequests  []PluginRPCFailure  `json:"failed_requests"`
+}
+
+type crashBundlePlugin struct {
+	Name string `json:"name"`
+
+	// Panic is the panic message and stack trace the plugin printed, if any.
+	Panic []string `json:"panic,omitempty"`
+
+	// Stderr is the last unstructured output the plugin wrote to stderr.
+	Stderr []string `json:"stderr"`
+}
+
+var crashes = &crashRecorder{}
+
+// crashRecorder keeps track of failed plugin requests, and of where the
+// crash bundle describing them will be written.
+type crashRecorder struct {
+	sync.Mutex
+
+	dir      string
+	path     string
+	failures []PluginRPCFailure
+}
+
+// SetCrashBundleDir sets the directory in which WritePluginCrashBundle
+// writes crash bundles. Crash bundles are not written until this is set.
+func SetCrashBundleDir(dir string) {
+	crashes.Lock()
+	defer crashes.Unlock()
+	crashes.dir = dir
+}
+
+// RecordPluginRPCFailure records that the given plugin request failed because
+// the plugin stopped responding, so that it is included in the crash bundle.
+//
+// The result is the path the crash bundle will be written to when OpenTofu
+// exits, for mentioning in the diagnostic reporting the failure, or an empty
+// string if no crash bundle will be written.
+func RecordPluginRPCFailure(method string, err error) string {
+	failure := PluginRPCFailure{
+		Time:      time.Now().UTC(),
+		Method:    method,
+		Error:     err.Error(),
+		CoreStack: coreStack(3),
+	}
+
+	crashes.Lock()
+	defer crashes.Unlock()
+	crashes.failures = append(crashes.failures, failure)
+	if crashes.dir == "" {
+		return ""
+	}
+	if crashes.path == "" {
+		name := fmt.Sprintf("crash-%s.json", failure.Time.Format("20060102T150405Z"))
+		crashes.path = filepath.Join(crashes.dir, name)
+	}
+	return crashes.path
+}
+
+// WritePluginCrashBundle writes a crash bundle describing any plugins that
+// panicked and any plugin requests that failed, to help with reporting the
+// problem to the plugin's maintainers.
+//
+// The result is the path of the bundle, or an empty string if there was
+// nothing to report or SetCrashBundleDir was not called.
+func WritePluginCrashBundle() (string, error) {
+	crashes.Lock()
+	defer crashes.Unlock()
+
+	plugins := panics.crashedPlugins()
+	if crashes.dir == "" || (len(plugins) == 0 && len(crashes.failures) == 0) {
+		return "", nil
+	}
+
+	now := time.Now().UTC()
+	bundle := crashBundle{
+		FormatVersion:   "1.0",
+		Timestamp:       now,
+		OpenTofuVersion: version.String(),
+		Plugins:         plugins,
+		FailedRequests:  crashes.failures,
+	}
+	if bundle.FailedRequests == nil {
+		bundle.FailedRequests = []PluginRPCFailure{}
+	}
+	src, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := crashes.path
+	if path == "" {
+		path = filepath.Join(crashes.dir, fmt.Sprintf("crash-%s.json", now.Format("20060102T150405Z")))
+	}
+	if err := os.MkdirAll(crashes.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create crash bundle directory: %w", err)
+	}
+	// The plugin output might include sensitive information, so the bundle
+	// is only readable by the current user.
+	if err := os.WriteFile(path, append(src, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write crash bundle: %w", err)
+	}
+	return path, nil
+}
+
+// crashedPlugins returns the plugins to include in a crash bundle: those that
+// panicked or, if none did, all plugins that wrote to stderr, since we can't
+// tell which of them stopped responding.
+func (p *panicRecorder) crashedPlugins() []crashBundlePlugin {
+	p.Lock()
+	defer p.Unlock()
+
+	var ret []crashBundlePlugin
+	for name, lines := range p.panics {
+		if len(lines) == 0 {
+			continue
+		}
+		ret = append(ret, crashBundlePlugin{
+			Name:   name,
+			Panic:  lines,
+			Stderr: p.stderrLines(name),
+		})
+	}
+	if len(ret) == 0 {
+		for name := range p.output {
+			ret = append(ret, crashBundlePlugin{
+				Name:   name,
+				Stderr: p.stderrLines(name),
+			})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+func (p *panicRecorder) stderrLines(name string) []string {
+	if lines := p.output[name]; lines != nil {
+		return lines
+	}
+	return []string{}
+}
+
+// coreStack summarizes the calling goroutine's stack, skipping the given
+// number of frames.
+func coreStack(skip int) []string {
+	pcs := make([]uintptr, maxCoreStackFrames)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var ret []string
+	for {
+		frame, more := frames.Next()
+		ret = append(ret, fmt.Sprintf("%s:%d", frame.Function, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return ret
+}
@@ -0,0 +1,90 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWritePluginCrashBundle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crashes")
+	SetCrashBundleDir(dir)
+	oldPanics := panics
+	panics = &panicRecorder{
+		panics:         make(map[string][]string),
+		maxLines:       100,
+		output:         make(map[string][]string),
+		maxOutputLines: 100,
+	}
+	t.Cleanup(func() {
+		panics = oldPanics
+		crashes = &crashRecorder{}
+	})
+
+	out := panics.registerOutput("crashy")
+	rec := panics.registerPlugin("crashy")
+	for _, line := range []string{"starting up", "panic: oh no", "goroutine 1 [running]:"} {
+		out(line)
+		if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "goroutine") {
+			rec(line)
+		}
+	}
+
+	want := RecordPluginRPCFailure("GRPCProvider.ApplyResourceChange", errors.New("connection reset"))
+	if filepath.Dir(want) != dir {
+		t.Fatalf("wrong crash bundle path %q", want)
+	}
+
+	got, err := WritePluginCrashBundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("crash bundle written to %q, but %q was announced", got, want)
+	}
+
+	src, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundle crashBundle
+	if err := json.Unmarshal(src, &bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bundle.Plugins) != 1 || bundle.Plugins[0].Name != "crashy" {
+		t.Fatalf("wrong plugins %#v", bundle.Plugins)
+	}
+	if got := strings.Join(bundle.Plugins[0].Stderr, "\n"); got != "starting up\npanic: oh no\ngoroutine 1 [running]:" {
+		t.Errorf("wrong stderr %q", got)
+	}
+	if len(bundle.FailedRequests) != 1 {
+		t.Fatalf("wrong failed requests %#v", bundle.FailedRequests)
+	}
+	failure := bundle.FailedRequests[0]
+	if failure.Method != "GRPCProvider.ApplyResourceChange" || failure.Error != "connection reset" {
+		t.Errorf("wrong failed request %#v", failure)
+	}
+	if len(failure.CoreStack) == 0 || !strings.Contains(failure.CoreStack[0], "TestWritePluginCrashBundle") {
+		t.Errorf("core stack doesn't start at the caller: %#v", failure.CoreStack)
+	}
+}
+
+func TestRecorderOutputLimit(t *testing.T) {
+	out := panics.registerOutput("chatty")
+	for i := 0; i < panics.maxOutputLines*2; i++ {
+		out("line")
+	}
+
+	panics.Lock()
+	defer panics.Unlock()
+	if got := len(panics.output["chatty"]); got != panics.maxOutputLines {
+		t.Fatalf("kept %d lines, want %d", got, panics.maxOutputLines)
+	}
+}
@@ -42,8 +42,10 @@ var (
 
 	// initialize our cache of panic output from providers
 	panics = &panicRecorder{
-		panics:   make(map[string][]string),
-		maxLines: 100,
+		panics:         make(map[string][]string),
+		maxLines:       100,
+		output:         make(map[string][]string),
+		maxOutputLines: 100,
 	}
 )
 
@@ -136,6 +136,14 @@ type panicRecorder struct {
 	// don't want to destroy the scrollback. In most cases, the first few lines
 	// of the stack trace is all that are required.
 	maxLines int
+
+	// output maps the plugin name to the most recent lines of unstructured
+	// output received from the logger, for inclusion in crash bundles.
+	output map[string][]string
+
+	// maxOutputLines is the max number of recent output lines we'll keep
+	// for each plugin.
+	maxOutputLines int
 }
 
 // registerPlugin returns an accumulator function which will accept lines of
@@ -165,6 +173,26 @@ func (p *panicRecorder) registerPlugin(name string) func(string) {
 	}
 }
 
+// registerOutput returns an accumulator function which will keep the most
+// recent lines of a plugin's unstructured output.
+func (p *panicRecorder) registerOutput(name string) func(string) {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.output, name)
+
+	return func(line string) {
+		p.Lock()
+		defer p.Unlock()
+
+		lines := append(p.output[name], line)
+		if len(lines) > p.maxOutputLines {
+			lines = lines[len(lines)-p.maxOutputLines:]
+		}
+		p.output[name] = lines
+	}
+}
+
 func (p *panicRecorder) allPanics() []string {
 	p.Lock()
 	defer p.Unlock()
@@ -184,15 +212,17 @@ func (p *panicRecorder) allPanics() []string {
 // that appears to be a panic.
 type logPanicWrapper struct {
 	hclog.Logger
-	panicRecorder func(string)
-	inPanic       bool
+	panicRecorder  func(string)
+	outputRecorder func(string)
+	inPanic        bool
 }
 
 // go-plugin will create a new named logger for each plugin binary.
 func (l *logPanicWrapper) Named(name string) hclog.Logger {
 	return &logPanicWrapper{
-		Logger:        l.Logger.Named(name),
-		panicRecorder: panics.registerPlugin(name),
+		Logger:         l.Logger.Named(name),
+		panicRecorder:  panics.registerPlugin(name),
+		outputRecorder: panics.registerOutput(name),
 	}
 }
 
@@ -211,6 +241,9 @@ func (l *logPanicWrapper) Debug(msg string, args ...interface{}) {
 	if l.inPanic && l.panicRecorder != nil {
 		l.panicRecorder(msg)
 	}
+	if l.outputRecorder != nil {
+		l.outputRecorder(msg)
+	}
 
 	l.Logger.Debug(msg, args...)
 }
@@ -0,0 +1,16 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package longpath helps with using filesystem paths longer than the
+// traditional 260 character limit on Windows, such as those of deeply-nested
+// modules in the module cache.
+//
+// Windows only allows longer paths in the "extended-length" form, which is
+// absolute and starts with \\?\. Go's os package converts absolute paths to
+// that form automatically, but not relative paths or, in some Go versions,
+// paths on UNC network shares.
+//
+// This package uses conditional compilation to select a different
+// implementation for Windows vs. all other platforms, where paths are
+// returned unchanged.
+package longpath
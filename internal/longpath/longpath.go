@@ -0,0 +1,37 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package longpath
+
+import (
+	"strings"
+)
+
+// maxShortPath is the length at which Windows starts rejecting paths that
+// are not in the extended-length form. The limit is 260 characters, but
+// directory paths must leave room for an 8.3 filename within it.
+const maxShortPath = 248
+
+// extendedLengthPath converts the given clean, absolute Windows path to the
+// extended-length form if it is too long to use otherwise.
+//
+// This is separate from Fix, and doesn't depend on the current platform, so
+// that it can be tested everywhere.
+func extendedLengthPath(path string) string {
+	switch {
+	case len(path) < maxShortPath:
+		return path
+	case strings.HasPrefix(path, `\\?\`), strings.HasPrefix(path, `\\.\`):
+		// Already in the extended-length or device namespace form.
+		return path
+	case strings.HasPrefix(path, `\\`):
+		// \\server\share\path becomes \\?\UNC\server\share\path
+		return `\\?\UNC\` + path[2:]
+	case len(path) >= 3 && path[1] == ':' && path[2] == '\\':
+		return `\\?\` + path
+	default:
+		// A relative or drive-relative path can't be converted, since the
+		// extended-length form must be absolute.
+		return path
+	}
+}
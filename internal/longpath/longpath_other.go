@@ -0,0 +1,15 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+// +build !windows
+
+package longpath
+
+// Fix returns a form of the given path that can be used with the functions
+// of the os package even if it is longer than 260 characters.
+//
+// Other platforms have no such limit, and so the path is returned unchanged.
+func Fix(path string) string {
+	return path
+}
@@ -0,0 +1,59 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package longpath
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtendedLengthPath(t *testing.T) {
+	long := strings.Repeat(`module\`, 40) + "main.tf"
+
+	tests := map[string]struct {
+		path string
+		want string
+	}{
+		"short drive path": {
+			`C:\work\.terraform\modules\a`,
+			`C:\work\.terraform\modules\a`,
+		},
+		"long drive path": {
+			`C:\work\` + long,
+			`\\?\C:\work\` + long,
+		},
+		"long UNC path": {
+			`\\server\share\` + long,
+			`\\?\UNC\server\share\` + long,
+		},
+		"already extended": {
+			`\\?\C:\work\` + long,
+			`\\?\C:\work\` + long,
+		},
+		"already extended UNC": {
+			`\\?\UNC\server\share\` + long,
+			`\\?\UNC\server\share\` + long,
+		},
+		"device namespace": {
+			`\\.\C:\work\` + long,
+			`\\.\C:\work\` + long,
+		},
+		"long relative path": {
+			long,
+			long,
+		},
+		"long drive-relative path": {
+			`C:` + long,
+			`C:` + long,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := extendedLengthPath(test.path); got != test.want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+// +build windows
+
+package longpath
+
+import (
+	"path/filepath"
+)
+
+// Fix returns a form of the given path that can be used with the functions
+// of the os package even if it is longer than 260 characters.
+//
+// On Windows, a path that is too long is made absolute and converted to the
+// extended-length form. Such paths are only suitable for passing to Go's own
+// filesystem functions: other programs, such as git, might not accept them.
+func Fix(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return extendedLengthPath(abs)
+}
@@ -5,4 +5,57 @@
 
 package modsdir
 
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
 const ManifestSnapshotFilename = "modules.json"
+
+// LocalModuleDir returns the directory of a module with the given local
+// source address, called from a module in parentDir.
+//
+// Local modules are loaded directly from their source directory rather than
+// being copied into the module cache. Any symbolic links along the path are
+// resolved, so that relative paths within a module reached through a link
+// are interpreted relative to the module's real location.
+func LocalModuleDir(parentDir string, addr addrs.ModuleSourceLocal) (string, error) {
+	return filepath.EvalSymlinks(filepath.Join(parentDir, addr.String()))
+}
+
+// LocalModuleDirChanged returns true if the directory of a module with the
+// given local source address, called from a module in parentDir, no longer
+// resolves to the given recorded directory because a symbolic link along its
+// path was changed since the module was installed, along with the directory
+// it resolves to now.
+//
+// Paths that don't pass through any symbolic links are never reported as
+// changed, since their resolution can't have changed.
+func LocalModuleDirChanged(parentDir, recordedDir string, addr addrs.ModuleSourceLocal) (string, bool) {
+	path := filepath.Join(parentDir, addr.String())
+	if !throughSymlink(path) {
+		return "", false
+	}
+	dir, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		// The link is now broken, and so can't lead to the recorded
+		// directory anymore.
+		return path, true
+	}
+	return dir, filepath.Clean(dir) != filepath.Clean(recordedDir)
+}
+
+// throughSymlink returns true if the given path, or any of its ancestors,
+// is a symbolic link.
+func throughSymlink(path string) bool {
+	for path = filepath.Clean(path); ; path = filepath.Dir(path) {
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+		if parent := filepath.Dir(path); parent == path {
+			return false
+		}
+	}
+}
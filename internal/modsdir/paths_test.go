@@ -0,0 +1,73 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package modsdir
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+func TestLocalModuleDirChanged(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires extra privileges on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	for _, name := range []string{"root", "shared-v1", "shared-v2", "plain"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rootDir := filepath.Join(tmpDir, "root")
+	link := filepath.Join(rootDir, "shared")
+	if err := os.Symlink(filepath.Join(tmpDir, "shared-v1"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	addr := addrs.ModuleSourceLocal("./shared")
+	installed, err := LocalModuleDir(rootDir, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(installed) != "shared-v1" {
+		t.Fatalf("symlink was not resolved: %s", installed)
+	}
+	if dir, changed := LocalModuleDirChanged(rootDir, installed, addr); changed {
+		t.Fatalf("unexpected change to %s", dir)
+	}
+
+	// Point the symlink at another directory, as when a shared module in a
+	// monorepo is switched to a new version.
+	if err := os.Remove(link); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(tmpDir, "shared-v2"), link); err != nil {
+		t.Fatal(err)
+	}
+	dir, changed := LocalModuleDirChanged(rootDir, installed, addr)
+	if !changed {
+		t.Fatal("change was not detected")
+	}
+	if filepath.Base(dir) != "shared-v2" {
+		t.Errorf("wrong new directory %s", dir)
+	}
+
+	// A broken link counts as a change too.
+	if err := os.Remove(filepath.Join(tmpDir, "shared-v2")); err != nil {
+		t.Fatal(err)
+	}
+	if _, changed := LocalModuleDirChanged(rootDir, installed, addr); !changed {
+		t.Error("broken link was not detected")
+	}
+
+	// Paths without symlinks are never reported as changed, even if the
+	// recorded directory is written differently.
+	if dir, changed := LocalModuleDirChanged(tmpDir, "elsewhere", addrs.ModuleSourceLocal("./plain")); changed {
+		t.Errorf("unexpected change to %s", dir)
+	}
+}
@@ -7,8 +7,10 @@ package moduletest
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
@@ -29,6 +31,12 @@ type Run struct {
 	Status Status
 
 	Diagnostics tfdiags.Diagnostics
+
+	// Resources holds the planned or applied values of the resource instances
+	// in the run's configuration, keyed by instance address, so that later
+	// run blocks can refer to them. It is nil until the run has completed
+	// successfully.
+	Resources map[string]cty.Value
 }
 
 // Verbose is a utility struct that holds all the information required for a run
@@ -341,3 +349,119 @@ func (run *Run) BuildExpectedFailuresAndSourceMaps() (addrs.Map[addrs.Referencea
 	}
 	return expectedFailures, sourceRanges
 }
+
+// ValidateExpectedApplyErrors steps through the provided diagnostics (which
+// should be the result of an apply operation) and removes any errors returned
+// by providers for resource instances that were expected to fail, either by an
+// expect_apply_error block or by a reference to the resource in the
+// expect_failures list.
+//
+// Resources from the expect_failures list that matched are marked as failed in
+// the given map, so that ValidateExpectedFailures won't report them as
+// missing. This function adds diagnostics for any expect_apply_error blocks
+// that did not match an error.
+func (run *Run) ValidateExpectedApplyErrors(expectedFailures addrs.Map[addrs.Referenceable, bool], originals tfdiags.Diagnostics) tfdiags.Diagnostics {
+	type expectedError struct {
+		config  *configs.TestRunExpectedError
+		subject addrs.Referenceable
+		found   bool
+	}
+
+	var expectedErrors []*expectedError
+	for _, config := range run.Config.ExpectApplyErrors {
+		expected := &expectedError{config: config}
+		if config.Address != nil {
+			// Ignore the diagnostics returned from the reference parsing,
+			// these references will have been checked earlier in the process
+			// by the validate stage so we don't need to do that again here.
+			reference, _ := addrs.ParseRefFromTestingScope(config.Address)
+			if reference == nil {
+				continue
+			}
+			expected.subject = reference.Subject
+		}
+		expectedErrors = append(expectedErrors, expected)
+	}
+
+	var diags tfdiags.Diagnostics
+	for _, diag := range originals {
+		if diag.Severity() != tfdiags.Error {
+			diags = diags.Append(diag)
+			continue
+		}
+
+		if _, ok := addrs.DiagnosticOriginatesFromCheckRule(diag); ok {
+			// Failed custom conditions are handled by ValidateExpectedFailures.
+			diags = diags.Append(diag)
+			continue
+		}
+
+		// Errors returned by providers while applying a resource instance
+		// are annotated with the address of that instance.
+		desc := diag.Description()
+		if desc.Address == "" {
+			diags = diags.Append(diag)
+			continue
+		}
+		addr, addrDiags := addrs.ParseAbsResourceInstanceStr(desc.Address)
+		if addrDiags.HasErrors() || !addr.Module.IsRoot() {
+			// Errors can only be expected from resources in the root module.
+			diags = diags.Append(diag)
+			continue
+		}
+
+		matched := false
+		for _, expected := range expectedErrors {
+			if expected.subject != nil {
+				key := expected.subject.UniqueKey()
+				if key != addr.Resource.UniqueKey() && key != addr.Resource.Resource.UniqueKey() {
+					continue
+				}
+			}
+			if re := expected.config.Message; re != nil && !re.MatchString(desc.Summary) && !re.MatchString(desc.Detail) {
+				continue
+			}
+			expected.found = true
+			matched = true
+		}
+
+		if expectedFailures.Has(addr.Resource) {
+			expectedFailures.Put(addr.Resource, true)
+			matched = true
+		}
+		if expectedFailures.Has(addr.Resource.Resource) {
+			expectedFailures.Put(addr.Resource.Resource, true)
+			matched = true
+		}
+
+		if !matched {
+			diags = diags.Append(diag)
+		}
+	}
+
+	for _, expected := range expectedErrors {
+		if expected.found {
+			continue
+		}
+
+		var criteria []string
+		if expected.subject != nil {
+			criteria = append(criteria, fmt.Sprintf("from %s", expected.subject))
+		}
+		if expected.config.Message != nil {
+			criteria = append(criteria, fmt.Sprintf("with a message matching %q", expected.config.Message))
+		}
+		detail := "A provider was expected to return an error while applying this run block, but did not."
+		if len(criteria) > 0 {
+			detail = fmt.Sprintf("A provider was expected to return an error %s while applying this run block, but did not.", strings.Join(criteria, " "))
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing expected apply error",
+			Detail:   detail,
+			Subject:  expected.config.DeclRange.Ptr(),
+		})
+	}
+
+	return diags
+}
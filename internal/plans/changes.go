@@ -245,6 +245,12 @@ type ResourceInstanceChange struct {
 	// currently survive a round-trip through a saved plan file.
 	RequiredReplace cty.PathSet
 
+	// DriftCauses is the likely cause of the change to each top-level
+	// attribute or nested block type that changed, keyed by name. It is
+	// populated only for the drifted resources of a refresh-only plan, and
+	// like ActionReason is only for explaining the plan to end-users.
+	DriftCauses map[string]DriftCause
+
 	// Private allows a provider to stash any extra data that is opaque to
 	// OpenTofu that relates to this change. OpenTofu will save this
 	// byte-for-byte and return it to the provider in the apply call.
@@ -273,6 +279,7 @@ func (rc *ResourceInstanceChange) Encode(ty cty.Type) (*ResourceInstanceChangeSr
 		ChangeSrc:       *cs,
 		ActionReason:    rc.ActionReason,
 		RequiredReplace: rc.RequiredReplace,
+		DriftCauses:     rc.DriftCauses,
 		Private:         rc.Private,
 	}, err
 }
@@ -573,9 +580,17 @@ func (c *Change) Encode(ty cty.Type) (*ChangeSrc, error) {
 	if c.After.ContainsMarked() {
 		unmarkedAfter, afterVM = c.After.UnmarkDeepWithPaths()
 	}
-	afterDV, err := NewDynamicValue(unmarkedAfter, ty)
-	if err != nil {
-		return nil, err
+	var afterDV DynamicValue
+	if c.Action == NoOp && unmarkedAfter.RawEquals(unmarkedBefore) {
+		// Most of the objects in a typical plan are unchanged, so we share
+		// a single encoding between before and after rather than holding
+		// two identical copies of what might be a large object.
+		afterDV = beforeDV
+	} else {
+		afterDV, err = NewDynamicValue(unmarkedAfter, ty)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var importing *ImportingSrc
@@ -6,6 +6,7 @@
 package plans
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -70,6 +71,11 @@ type ResourceInstanceChangeSrc struct {
 	// Replace.
 	RequiredReplace cty.PathSet
 
+	// DriftCauses is the likely cause of the change to each top-level
+	// attribute or nested block type that changed, keyed by name. See the
+	// field of the same name in ResourceInstanceChange for more details.
+	DriftCauses map[string]DriftCause
+
 	// Private allows a provider to stash any extra data that is opaque to
 	// OpenTofu that relates to this change. OpenTofu will save this
 	// byte-for-byte and return it to the provider in the apply call.
@@ -98,6 +104,7 @@ func (rcs *ResourceInstanceChangeSrc) Decode(ty cty.Type) (*ResourceInstanceChan
 		Change:          *change,
 		ActionReason:    rcs.ActionReason,
 		RequiredReplace: rcs.RequiredReplace,
+		DriftCauses:     rcs.DriftCauses,
 		Private:         rcs.Private,
 	}, nil
 }
@@ -118,6 +125,14 @@ func (rcs *ResourceInstanceChangeSrc) DeepCopy() *ResourceInstanceChangeSrc {
 
 	ret.RequiredReplace = cty.NewPathSet(ret.RequiredReplace.List()...)
 
+	if ret.DriftCauses != nil {
+		causes := make(map[string]DriftCause, len(ret.DriftCauses))
+		for name, cause := range ret.DriftCauses {
+			causes[name] = cause
+		}
+		ret.DriftCauses = causes
+	}
+
 	if len(ret.Private) != 0 {
 		private := make([]byte, len(ret.Private))
 		copy(private, ret.Private)
@@ -246,7 +261,12 @@ func (cs *ChangeSrc) Decode(ty cty.Type) (*Change, error) {
 			return nil, fmt.Errorf("error decoding 'before' value: %w", err)
 		}
 	}
-	if len(cs.After) > 0 {
+	switch {
+	case len(cs.After) > 0 && bytes.Equal(cs.Before, cs.After):
+		// cty values are immutable, so an unchanged object can share a
+		// single decoded value rather than decoding the same bytes twice.
+		after = before
+	case len(cs.After) > 0:
 		after, err = cs.After.Decode(ty)
 		if err != nil {
 			return nil, fmt.Errorf("error decoding 'after' value: %w", err)
@@ -162,3 +162,34 @@ func TestChangeEncodeSensitive(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeEncodeNoOpShared(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"id":     cty.StringVal("abc123"),
+		"secret": cty.StringVal("hunter2").Mark(marks.Sensitive),
+	})
+	change := Change{
+		Action: NoOp,
+		Before: v,
+		After:  v,
+	}
+
+	encoded, err := change.Encode(v.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &encoded.Before[0] != &encoded.After[0] {
+		t.Error("before and after of a no-op change do not share an encoding")
+	}
+
+	decoded, err := encoded.Decode(v.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.RawEquals(decoded.Before) {
+		t.Errorf("wrong before value\ngot:  %#v\nwant: %#v", decoded.Before, v)
+	}
+	if !v.RawEquals(decoded.After) {
+		t.Errorf("wrong after value\ngot:  %#v\nwant: %#v", decoded.After, v)
+	}
+}
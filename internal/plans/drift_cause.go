@@ -0,0 +1,30 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package plans
+
+// DriftCause describes the likely reason that an attribute of a resource
+// instance changed when OpenTofu refreshed it, for reporting drift in
+// refresh-only plans.
+//
+// This is a heuristic for user feedback only, to help with triaging drift,
+// and must never be used to make decisions in OpenTofu Core.
+type DriftCause string
+
+const (
+	// DriftCauseProvider means that the provider reported a new value for
+	// the attribute that isn't explained by any of the other causes, which
+	// usually means that the remote object was changed outside of OpenTofu.
+	DriftCauseProvider DriftCause = "provider"
+
+	// DriftCauseSchemaUpgrade means that the attribute was added when the
+	// provider upgraded the object from an earlier version of the resource
+	// type schema, and the provider populated it when refreshing.
+	DriftCauseSchemaUpgrade DriftCause = "schema_upgrade"
+
+	// DriftCauseNormalization means that the old and new values of the
+	// attribute are equivalent, and differ only in how they are written,
+	// such as a null value that became an empty string or a JSON document
+	// that was reformatted.
+	DriftCauseNormalization DriftCause = "normalization"
+)
@@ -59,16 +59,11 @@ type Reader struct {
 // plan file should use OpenWrapped instead, so they can support both local and
 // cloud plan files.
 func Open(filename string, enc encryption.PlanEncryption) (*Reader, error) {
-	raw, err := os.ReadFile(filename)
+	decrypted, err := readPlanFile(filename, enc)
 	if err != nil {
 		return nil, err
 	}
 
-	decrypted, diags := enc.DecryptPlan(raw)
-	if diags != nil {
-		return nil, diags
-	}
-
 	r, err := zip.NewReader(bytes.NewReader(decrypted), int64(len(decrypted)))
 	if err != nil {
 		// Check to see if it's encrypted
@@ -108,6 +103,22 @@ func Open(filename string, enc encryption.PlanEncryption) (*Reader, error) {
 	}, nil
 }
 
+// readPlanFile reads and decrypts the plan file with the given filename. The plan file is decrypted as it's read,
+// so that only the decrypted form needs to be held in memory.
+func readPlanFile(filename string, enc encryption.PlanEncryption) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := enc.DecryptPlanStream(f)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
 // ReadPlan reads the plan embedded in the plan file.
 //
 // Errors can be returned for various reasons, including if the plan file
@@ -362,6 +362,22 @@ func resourceChangeFromTfplan(rawChange *planproto.ResourceInstanceChange) (*pla
 		return nil, fmt.Errorf("resource has invalid action reason %s", rawChange.ActionReason)
 	}
 
+	if len(rawChange.DriftCauses) != 0 {
+		ret.DriftCauses = make(map[string]plans.DriftCause, len(rawChange.DriftCauses))
+		for name, rawCause := range rawChange.DriftCauses {
+			switch rawCause {
+			case planproto.DriftCause_PROVIDER_DRIFT:
+				ret.DriftCauses[name] = plans.DriftCauseProvider
+			case planproto.DriftCause_SCHEMA_UPGRADE:
+				ret.DriftCauses[name] = plans.DriftCauseSchemaUpgrade
+			case planproto.DriftCause_NORMALIZATION:
+				ret.DriftCauses[name] = plans.DriftCauseNormalization
+			default:
+				return nil, fmt.Errorf("resource %s has invalid drift cause %s for %q", ret.Addr, rawCause, name)
+			}
+		}
+	}
+
 	if len(rawChange.Private) != 0 {
 		ret.Private = rawChange.Private
 	}
@@ -762,6 +778,22 @@ func resourceChangeToTfplan(change *plans.ResourceInstanceChangeSrc) (*planproto
 		return nil, fmt.Errorf("resource %s has unsupported action reason %s", change.Addr, change.ActionReason)
 	}
 
+	if len(change.DriftCauses) != 0 {
+		ret.DriftCauses = make(map[string]planproto.DriftCause, len(change.DriftCauses))
+		for name, cause := range change.DriftCauses {
+			switch cause {
+			case plans.DriftCauseProvider:
+				ret.DriftCauses[name] = planproto.DriftCause_PROVIDER_DRIFT
+			case plans.DriftCauseSchemaUpgrade:
+				ret.DriftCauses[name] = planproto.DriftCause_SCHEMA_UPGRADE
+			case plans.DriftCauseNormalization:
+				ret.DriftCauses[name] = planproto.DriftCause_NORMALIZATION
+			default:
+				return nil, fmt.Errorf("resource %s has unsupported drift cause %q for %q", change.Addr, cause, name)
+			}
+		}
+	}
+
 	if len(change.Private) > 0 {
 		ret.Private = change.Private
 	}
@@ -213,6 +213,10 @@ func TestTFPlanRoundTrip(t *testing.T) {
 						},
 					},
 				},
+				DriftCauses: map[string]plans.DriftCause{
+					"id":   plans.DriftCauseSchemaUpgrade,
+					"boop": plans.DriftCauseProvider,
+				},
 			},
 		},
 		RelevantAttributes: []globalref.ResourceAttr{
@@ -7,8 +7,8 @@ package planfile
 
 import (
 	"archive/zip"
-	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -56,8 +56,30 @@ type CreateArgs struct {
 // if the world has changed since the plan was created and thus refuse to
 // apply it.
 func Create(filename string, args CreateArgs, enc encryption.PlanEncryption) error {
-	buff := bytes.NewBuffer(make([]byte, 0))
-	zw := zip.NewWriter(buff)
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	err = writePlanFile(f, args, enc)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Don't leave a partial plan file behind.
+		_ = os.Remove(filename)
+		return err
+	}
+	return nil
+}
+
+// writePlanFile writes the plan file for the given arguments to w, encrypting it as it's written so that the
+// whole plan file doesn't need to be held in memory.
+func writePlanFile(w io.Writer, args CreateArgs, enc encryption.PlanEncryption) error {
+	ew, err := enc.EncryptPlanStream(w)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(ew)
 
 	// tfplan file
 	{
@@ -75,17 +97,31 @@ func Create(filename string, args CreateArgs, enc encryption.PlanEncryption) err
 		}
 	}
 
+	// The embedded state snapshots use the compact state format if the user
+	// opted in to it. That format is already compressed, so we store those
+	// snapshots without compressing them a second time.
+	writeState := func(f *statefile.File, w io.Writer) error {
+		return statefile.Write(f, w, encryption.StateEncryptionDisabled())
+	}
+	stateMethod := zip.Deflate
+	if statefile.CompactFormatRequested() {
+		writeState = func(f *statefile.File, w io.Writer) error {
+			return statefile.WriteCompact(f, w, encryption.StateEncryptionDisabled())
+		}
+		stateMethod = zip.Store
+	}
+
 	// tfstate file
 	{
 		w, err := zw.CreateHeader(&zip.FileHeader{
 			Name:     tfstateFilename,
-			Method:   zip.Deflate,
+			Method:   stateMethod,
 			Modified: time.Now(),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create embedded tfstate file: %w", err)
 		}
-		err = statefile.Write(args.StateFile, w, encryption.StateEncryptionDisabled())
+		err = writeState(args.StateFile, w)
 		if err != nil {
 			return fmt.Errorf("failed to write state snapshot: %w", err)
 		}
@@ -95,13 +131,13 @@ func Create(filename string, args CreateArgs, enc encryption.PlanEncryption) err
 	{
 		w, err := zw.CreateHeader(&zip.FileHeader{
 			Name:     tfstatePreviousFilename,
-			Method:   zip.Deflate,
+			Method:   stateMethod,
 			Modified: time.Now(),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create embedded tfstate-prev file: %w", err)
 		}
-		err = statefile.Write(args.PreviousRunStateFile, w, encryption.StateEncryptionDisabled())
+		err = writeState(args.PreviousRunStateFile, w)
 		if err != nil {
 			return fmt.Errorf("failed to write previous state snapshot: %w", err)
 		}
@@ -137,11 +173,9 @@ func Create(filename string, args CreateArgs, enc encryption.PlanEncryption) err
 	}
 
 	// Finish zip file
-	zw.Close()
-	// Encrypt payload
-	encrypted, err := enc.EncryptPlan(buff.Bytes())
-	if err != nil {
-		return err
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write plan file: %w", err)
 	}
-	return os.WriteFile(filename, encrypted, 0644)
+	// Finish the encrypted payload
+	return ew.Close()
 }
@@ -66,3 +66,67 @@ func ProtoToFunctionSpec(proto *tfplugin6.Function) providers.FunctionSpec {
 		DeprecationMessage: proto.DeprecationMessage,
 	}
 }
+
+func CtyTypeToProto(ty cty.Type) ([]byte, error) {
+	return json.Marshal(ty)
+}
+
+func TextFormattingToProto(format providers.TextFormatting) tfplugin6.StringKind {
+	switch format {
+	case providers.TextFormattingMarkdown:
+		return tfplugin6.StringKind_MARKDOWN
+	default:
+		return tfplugin6.StringKind_PLAIN
+	}
+}
+
+func FunctionParameterSpecToProto(spec providers.FunctionParameterSpec) (*tfplugin6.Function_Parameter, error) {
+	ty, err := CtyTypeToProto(spec.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type for parameter %q: %w", spec.Name, err)
+	}
+	return &tfplugin6.Function_Parameter{
+		Name:               spec.Name,
+		Type:               ty,
+		AllowNullValue:     spec.AllowNullValue,
+		AllowUnknownValues: spec.AllowUnknownValues,
+		Description:        spec.Description,
+		DescriptionKind:    TextFormattingToProto(spec.DescriptionFormat),
+	}, nil
+}
+
+// FunctionSpecToProto is the inverse of ProtoToFunctionSpec.
+func FunctionSpecToProto(spec providers.FunctionSpec) (*tfplugin6.Function, error) {
+	params := make([]*tfplugin6.Function_Parameter, len(spec.Parameters))
+	for i, param := range spec.Parameters {
+		var err error
+		params[i], err = FunctionParameterSpecToProto(param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var varParam *tfplugin6.Function_Parameter
+	if spec.VariadicParameter != nil {
+		var err error
+		varParam, err = FunctionParameterSpecToProto(*spec.VariadicParameter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret, err := CtyTypeToProto(spec.Return)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return type: %w", err)
+	}
+
+	return &tfplugin6.Function{
+		Parameters:         params,
+		VariadicParameter:  varParam,
+		Return:             &tfplugin6.Function_Return{Type: ret},
+		Summary:            spec.Summary,
+		Description:        spec.Description,
+		DescriptionKind:    TextFormattingToProto(spec.DescriptionFormat),
+		DeprecationMessage: spec.DeprecationMessage,
+	}, nil
+}
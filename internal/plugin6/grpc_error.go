@@ -10,6 +10,7 @@ import (
 	"path"
 	"runtime"
 
+	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -50,11 +51,15 @@ func grpcErr(err error) (diags tfdiags.Diagnostics) {
 	case codes.Unavailable:
 		// This case is when the plugin has stopped running for some reason,
 		// and is usually the result of a crash.
+		detail := fmt.Sprintf("The plugin encountered an error, and failed to respond to the %s call. "+
+			"The plugin logs may contain more details.", requestName)
+		if bundlePath := logging.RecordPluginRPCFailure(requestName, err); bundlePath != "" {
+			detail += fmt.Sprintf("\n\nA crash bundle with the plugin's output and the failed request will be saved to %s, to help with reporting this problem to the plugin's maintainers.", bundlePath)
+		}
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
 			"Plugin did not respond",
-			fmt.Sprintf("The plugin encountered an error, and failed to respond to the %s call. "+
-				"The plugin logs may contain more details.", requestName),
+			detail,
 		))
 	case codes.Canceled:
 		diags = diags.Append(tfdiags.Sourceless(
@@ -0,0 +1,103 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// ChecksumPolicy describes additional requirements that an operator can
+// impose on the provenance of the provider packages the installer installs,
+// beyond the checks that OpenTofu always performs.
+//
+// The zero value of ChecksumPolicy imposes no additional requirements.
+type ChecksumPolicy struct {
+	// AllowedHashSchemes, if non-empty, is the set of hash schemes that
+	// the installer may record in the dependency lock file. Hashes of any
+	// other scheme are discarded, and so can't be used to verify packages
+	// in future runs.
+	AllowedHashSchemes []getproviders.HashScheme
+
+	// RequiredHashSchemes is a set of hash schemes that must each have at
+	// least one hash recorded in the dependency lock file for every
+	// provider that the installer selects.
+	RequiredHashSchemes []getproviders.HashScheme
+
+	// SigningKeys maps provider namespaces, written as "hostname/namespace",
+	// to the IDs of the only signing keys that are acceptable for packages
+	// installed from that namespace. Packages from namespaces not included
+	// in this map are not subject to any signing key restrictions.
+	SigningKeys map[string][]string
+}
+
+// ChecksumPolicyNamespace returns the key used for the given provider in
+// ChecksumPolicy.SigningKeys.
+func ChecksumPolicyNamespace(provider addrs.Provider) string {
+	return provider.Hostname.String() + "/" + provider.Namespace
+}
+
+// filterHashes returns the subset of the given hashes that use a scheme
+// permitted by the policy.
+func (p *ChecksumPolicy) filterHashes(hashes []getproviders.Hash) []getproviders.Hash {
+	if p == nil || len(p.AllowedHashSchemes) == 0 {
+		return hashes
+	}
+	ret := make([]getproviders.Hash, 0, len(hashes))
+	for _, hash := range hashes {
+		if slices.Contains(p.AllowedHashSchemes, hash.Scheme()) {
+			ret = append(ret, hash)
+		}
+	}
+	return ret
+}
+
+// checkHashes returns an error if the given set of hashes, which are about
+// to be recorded in the dependency lock file, doesn't include a hash of
+// each of the schemes the policy requires.
+func (p *ChecksumPolicy) checkHashes(hashes []getproviders.Hash) error {
+	if p == nil {
+		return nil
+	}
+	var missing []string
+	for _, scheme := range p.RequiredHashSchemes {
+		found := slices.ContainsFunc(hashes, func(hash getproviders.Hash) bool {
+			return hash.HasScheme(scheme)
+		})
+		if !found {
+			missing = append(missing, string(scheme))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the provider checksum policy requires a checksum using the scheme(s) %s, but none could be verified for this package", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkSigningKey returns an error if the given authentication result
+// for a package of the given provider doesn't satisfy the policy's signing
+// key requirements.
+func (p *ChecksumPolicy) checkSigningKey(provider addrs.Provider, authResult *getproviders.PackageAuthenticationResult) error {
+	if p == nil {
+		return nil
+	}
+	namespace := ChecksumPolicyNamespace(provider)
+	keyIDs, ok := p.SigningKeys[namespace]
+	if !ok {
+		return nil
+	}
+	if authResult == nil || !authResult.Signed() {
+		return fmt.Errorf("the provider checksum policy requires packages from %s to be signed by an allowed key, but this package is not signed", namespace)
+	}
+	for _, keyID := range keyIDs {
+		if strings.EqualFold(keyID, authResult.KeyID) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the provider checksum policy does not allow packages from %s signed by key ID %s", namespace, authResult.KeyID)
+}
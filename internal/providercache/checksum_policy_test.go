@@ -0,0 +1,71 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+func TestChecksumPolicy_filterHashes(t *testing.T) {
+	hashes := []getproviders.Hash{
+		getproviders.HashScheme1.New("2n9mLpEjqM9oEtHfx3cIMC3ISo1EgzpKoKN1wIIfd2Q="),
+		getproviders.HashSchemeZip.New("4fe81ba1b2ac6bbd5f6bd2fa0ae3e8b9cb2b9d5d23b4a8e8bb1d7b3d41f66f1e"),
+	}
+
+	var nilPolicy *ChecksumPolicy
+	if diff := cmp.Diff(hashes, nilPolicy.filterHashes(hashes)); diff != "" {
+		t.Errorf("nil policy should not filter hashes\n%s", diff)
+	}
+
+	policy := &ChecksumPolicy{
+		AllowedHashSchemes: []getproviders.HashScheme{getproviders.HashScheme1},
+	}
+	want := hashes[:1]
+	if diff := cmp.Diff(want, policy.filterHashes(hashes)); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestChecksumPolicy_checkHashes(t *testing.T) {
+	policy := &ChecksumPolicy{
+		RequiredHashSchemes: []getproviders.HashScheme{getproviders.HashScheme1},
+	}
+
+	err := policy.checkHashes([]getproviders.Hash{
+		getproviders.HashSchemeZip.New("4fe81ba1b2ac6bbd5f6bd2fa0ae3e8b9cb2b9d5d23b4a8e8bb1d7b3d41f66f1e"),
+	})
+	if err == nil {
+		t.Fatal("succeeded; want error for missing h1: hash")
+	}
+
+	err = policy.checkHashes([]getproviders.Hash{
+		getproviders.HashScheme1.New("2n9mLpEjqM9oEtHfx3cIMC3ISo1EgzpKoKN1wIIfd2Q="),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestChecksumPolicy_checkSigningKey(t *testing.T) {
+	policy := &ChecksumPolicy{
+		SigningKeys: map[string][]string{
+			"registry.opentofu.org/hashicorp": {"0C0AF313E5FD9F80"},
+		},
+	}
+
+	restricted := addrs.MustParseProviderSourceString("hashicorp/null")
+	if err := policy.checkSigningKey(restricted, nil); err == nil {
+		t.Error("succeeded for unsigned package; want error")
+	}
+
+	unrestricted := addrs.MustParseProviderSourceString("example/null")
+	if err := policy.checkSigningKey(unrestricted, nil); err != nil {
+		t.Errorf("unexpected error for namespace without signing key requirements: %s", err)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/longpath"
+)
+
+// usageDirname is the name of the directory at the root of a global plugin
+// cache directory that records when each cached package was last used, and
+// the checksum it had when it was first added to the cache.
+//
+// Each package has its own record file, which is replaced atomically, so
+// that concurrent OpenTofu processes sharing the cache can't lose each
+// other's records.
+const usageDirname = ".tofu-cache-usage"
+
+// usageRecord is the persisted information about a single cached package.
+type usageRecord struct {
+	Hash     string    `json:"hash"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// CacheProblem describes a cached package that failed verification.
+type CacheProblem struct {
+	Entry   CachedProvider
+	Message string
+}
+
+// RecordUsage notes that the given entry in the receiving cache directory
+// has just been used to satisfy a dependency lock file, so that RemoveUnused
+// will retain it.
+//
+// The first time an entry is recorded, its current checksum is also recorded
+// so that Verify can later detect if the package was modified on disk.
+func (d *Dir) RecordUsage(entry *CachedProvider, now time.Time) error {
+	record, exists, err := d.readUsage(entry)
+	if err != nil {
+		return err
+	}
+	if !exists || record.Hash == "" {
+		hash, err := entry.Hash()
+		if err != nil {
+			return fmt.Errorf("failed to compute checksum for %s v%s: %w", entry.Provider, entry.Version, err)
+		}
+		record.Hash = hash.String()
+	}
+	record.LastUsed = now.UTC()
+	return d.writeUsage(entry, record)
+}
+
+// Verify checks every package in the receiving cache directory for the
+// current platform, returning a description of each one that is missing its
+// executable or whose contents no longer match the checksum recorded when it
+// was first added to the cache.
+//
+// Packages that were added by older versions of OpenTofu have no recorded
+// checksum, and so only their executable is checked.
+func (d *Dir) Verify() ([]CacheProblem, error) {
+	var problems []CacheProblem
+	for _, entry := range d.sortedPackages() {
+		if _, err := entry.ExecutableFile(); err != nil {
+			problems = append(problems, CacheProblem{entry, err.Error()})
+			continue
+		}
+		record, ok, err := d.readUsage(&entry)
+		if err != nil {
+			return problems, err
+		}
+		if !ok || record.Hash == "" {
+			continue
+		}
+		want, err := getproviders.ParseHash(record.Hash)
+		if err != nil {
+			problems = append(problems, CacheProblem{entry, fmt.Sprintf("invalid recorded checksum: %s", err)})
+			continue
+		}
+		matches, err := entry.MatchesHash(want)
+		if err != nil {
+			problems = append(problems, CacheProblem{entry, fmt.Sprintf("failed to compute checksum: %s", err)})
+			continue
+		}
+		if !matches {
+			problems = append(problems, CacheProblem{entry, fmt.Sprintf("contents do not match the checksum %s recorded when the package was cached", want)})
+		}
+	}
+	return problems, nil
+}
+
+// RemoveUnused deletes each package for the current platform in the
+// receiving cache directory that has not been used within the given maximum
+// age, returning the entries that were removed.
+//
+// Packages with no usage record are judged by the modification time of their
+// package directory instead.
+func (d *Dir) RemoveUnused(maxAge time.Duration, now time.Time) ([]CachedProvider, error) {
+	// Invalidate our metaCache so that subsequent read calls will re-scan to
+	// incorporate any changes we make here.
+	defer func() { d.metaCache = nil }()
+
+	var removed []CachedProvider
+	cutoff := now.Add(-maxAge)
+	for _, entry := range d.sortedPackages() {
+		record, ok, err := d.readUsage(&entry)
+		if err != nil {
+			return removed, err
+		}
+		lastUsed := record.LastUsed
+		if !ok {
+			info, err := os.Stat(entry.PackageDir)
+			if err != nil {
+				continue
+			}
+			lastUsed = info.ModTime()
+		}
+		if lastUsed.After(cutoff) {
+			continue
+		}
+		log.Printf("[TRACE] providercache.Dir.RemoveUnused: removing %s v%s, last used %s", entry.Provider, entry.Version, lastUsed)
+		if err := os.RemoveAll(longpath.Fix(entry.PackageDir)); err != nil {
+			return removed, fmt.Errorf("failed to remove %s v%s: %w", entry.Provider, entry.Version, err)
+		}
+		// The version directory is now empty unless packages for other
+		// platforms are also cached, in which case this is a no-op.
+		os.Remove(filepath.Dir(entry.PackageDir))
+		if err := os.Remove(d.usageFile(&entry)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove the usage record of %s v%s: %w", entry.Provider, entry.Version, err)
+		}
+		removed = append(removed, entry)
+	}
+	return removed, nil
+}
+
+func (d *Dir) sortedPackages() []CachedProvider {
+	var ret []CachedProvider
+	for _, entries := range d.AllAvailablePackages() {
+		ret = append(ret, entries...)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].PackageDir < ret[j].PackageDir
+	})
+	return ret
+}
+
+// usageFile returns the path of the file that records the usage of the given
+// entry. The name is derived from the provider, version and platform, so that
+// the records don't look like package directories to the cache scanner.
+func (d *Dir) usageFile(entry *CachedProvider) string {
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s %s %s", entry.Provider, entry.Version, d.targetPlatform)))
+	return filepath.Join(d.baseDir, usageDirname, hex.EncodeToString(key[:])+".json")
+}
+
+// readUsage returns the usage record of the given entry, and false if there
+// isn't one.
+func (d *Dir) readUsage(entry *CachedProvider) (usageRecord, bool, error) {
+	var record usageRecord
+	filename := d.usageFile(entry)
+	src, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return record, false, nil
+	}
+	if err != nil {
+		return record, false, fmt.Errorf("failed to read provider cache usage records: %w", err)
+	}
+	if err := json.Unmarshal(src, &record); err != nil {
+		// The usage records are only advisory, so we'll start over rather
+		// than making the cache unusable.
+		log.Printf("[WARN] providercache: ignoring invalid usage record %s: %s", filename, err)
+		return usageRecord{}, false, nil
+	}
+	return record, true, nil
+}
+
+// writeUsage replaces the usage record of the given entry. The record is
+// written to a temporary file first and then renamed into place, so that
+// readers never see a partially-written record.
+func (d *Dir) writeUsage(entry *CachedProvider, record usageRecord) error {
+	src, err := json.Marshal(record)
+	if err != nil {
+		// Should never happen because the records are entirely under our
+		// control.
+		return fmt.Errorf("failed to encode provider cache usage record: %w", err)
+	}
+	filename := d.usageFile(entry)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write provider cache usage record: %w", err)
+	}
+	_, err = tmp.Write(src)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write provider cache usage record: %w", err)
+	}
+	return nil
+}
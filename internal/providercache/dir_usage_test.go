@@ -0,0 +1,125 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+func TestDirUsage(t *testing.T) {
+	baseDir := t.TempDir()
+	platform := getproviders.Platform{OS: "linux", Arch: "amd64"}
+	dir := NewDirWithPlatform(baseDir, platform)
+
+	provider := addrs.MustParseProviderSourceString("example.com/foo/beep")
+	writePackage := func(version string) string {
+		t.Helper()
+		pkgDir := filepath.Join(baseDir, "example.com", "foo", "beep", version, platform.String())
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, "terraform-provider-beep"), []byte("beep"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return pkgDir
+	}
+	oldDir := writePackage("1.0.0")
+	newDir := writePackage("2.0.0")
+
+	now := time.Now()
+	longAgo := now.Add(-90 * 24 * time.Hour)
+	if err := os.Chtimes(oldDir, longAgo, longAgo); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(newDir, longAgo, longAgo); err != nil {
+		t.Fatal(err)
+	}
+
+	// Recording usage of the newer version keeps it alive despite its old
+	// modification time.
+	if err := dir.RecordUsage(dir.ProviderVersion(provider, getproviders.MustParseVersion("2.0.0")), now); err != nil {
+		t.Fatalf("unexpected error recording usage: %s", err)
+	}
+
+	problems, err := dir.Verify()
+	if err != nil {
+		t.Fatalf("unexpected error verifying: %s", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems: %#v", problems)
+	}
+
+	// Modifying the recorded package must be detected.
+	if err := os.WriteFile(filepath.Join(newDir, "terraform-provider-beep"), []byte("boop"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	problems, err = dir.Verify()
+	if err != nil {
+		t.Fatalf("unexpected error verifying: %s", err)
+	}
+	if len(problems) != 1 || problems[0].Entry.Version.String() != "2.0.0" {
+		t.Fatalf("wrong problems: %#v", problems)
+	}
+
+	removed, err := dir.RemoveUnused(30*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("unexpected error removing unused: %s", err)
+	}
+	if len(removed) != 1 || removed[0].Version.String() != "1.0.0" {
+		t.Fatalf("wrong removed entries: %#v", removed)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", oldDir)
+	}
+	if dir.ProviderVersion(provider, getproviders.MustParseVersion("2.0.0")) == nil {
+		t.Errorf("version 2.0.0 was removed")
+	}
+}
+
+func TestDirUsage_concurrent(t *testing.T) {
+	baseDir := t.TempDir()
+	platform := getproviders.Platform{OS: "linux", Arch: "amd64"}
+	provider := addrs.MustParseProviderSourceString("example.com/foo/beep")
+
+	versions := []string{"1.0.0", "1.1.0", "1.2.0", "1.3.0", "1.4.0", "1.5.0", "1.6.0", "1.7.0"}
+	for _, version := range versions {
+		pkgDir := filepath.Join(baseDir, "example.com", "foo", "beep", version, platform.String())
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, "terraform-provider-beep"), []byte("beep"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each goroutine uses its own Dir, like separate OpenTofu processes
+	// sharing the same global cache directory.
+	now := time.Now()
+	errs := make(chan error, len(versions))
+	for _, version := range versions {
+		go func(version string) {
+			dir := NewDirWithPlatform(baseDir, platform)
+			errs <- dir.RecordUsage(dir.ProviderVersion(provider, getproviders.MustParseVersion(version)), now)
+		}(version)
+	}
+	for range versions {
+		if err := <-errs; err != nil {
+			t.Fatalf("unexpected error recording usage: %s", err)
+		}
+	}
+
+	dir := NewDirWithPlatform(baseDir, platform)
+	for _, version := range versions {
+		entry := dir.ProviderVersion(provider, getproviders.MustParseVersion(version))
+		if _, ok, err := dir.readUsage(entry); err != nil || !ok {
+			t.Errorf("missing usage record for %s (error: %v)", version, err)
+		}
+	}
+}
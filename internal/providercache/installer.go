@@ -11,6 +11,7 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/apparentlymart/go-versions/versions"
 
@@ -59,6 +60,10 @@ type Installer struct {
 	// lifecycle for, and therefore does not need to worry about the
 	// installation of.
 	unmanagedProviderTypes map[addrs.Provider]struct{}
+
+	// checksumPolicy is an optional set of additional requirements for the
+	// checksums and signing keys of the provider packages we install.
+	checksumPolicy *ChecksumPolicy
 }
 
 // NewInstaller constructs and returns a new installer with the given target
@@ -162,6 +167,15 @@ func (i *Installer) SetUnmanagedProviderTypes(types map[addrs.Provider]struct{})
 	i.unmanagedProviderTypes = types
 }
 
+// SetChecksumPolicy sets additional requirements that the installer will
+// enforce for the checksums recorded in the dependency lock file and for the
+// signing keys of any provider packages it downloads.
+//
+// A nil policy, which is the default, imposes no additional requirements.
+func (i *Installer) SetChecksumPolicy(policy *ChecksumPolicy) {
+	i.checksumPolicy = policy
+}
+
 // EnsureProviderVersions compares the given provider requirements with what
 // is already available in the installer's target directory and then takes
 // appropriate installation actions to ensure that suitable packages
@@ -361,12 +375,15 @@ NeedProvider:
 		lock := locks.Provider(provider)
 		var preferredHashes []getproviders.Hash
 		if lock != nil && lock.Version() == version { // hash changes are expected if the version is also changing
-			preferredHashes = lock.PreferredHashes()
+			// Hashes using a scheme the checksum policy doesn't allow are
+			// not trusted for verification and are not carried forward
+			// into the updated lock file.
+			preferredHashes = i.checksumPolicy.filterHashes(lock.PreferredHashes())
 		}
 
 		// If our target directory already has the provider version that fulfills the lock file, carry on
 		if installed := i.targetDir.ProviderVersion(provider, version); installed != nil {
-			if len(preferredHashes) > 0 {
+			if len(preferredHashes) > 0 && i.checksumPolicy.checkHashes(preferredHashes) == nil {
 				if matches, _ := installed.MatchesAnyHash(preferredHashes); matches {
 					if cb := evts.ProviderAlreadyInstalled; cb != nil {
 						cb(provider, version)
@@ -529,6 +546,14 @@ NeedProvider:
 					var newHashes []getproviders.Hash
 					newHashes = append(newHashes, priorHashes...)
 					newHashes = append(newHashes, newHash)
+					newHashes = i.checksumPolicy.filterHashes(newHashes)
+					if err := i.checksumPolicy.checkHashes(newHashes); err != nil {
+						errs[provider] = err
+						if cb := evts.LinkFromCacheFailure; cb != nil {
+							cb(provider, version, err)
+						}
+						continue
+					}
 					locks.SetProvider(provider, version, reqs[provider], newHashes)
 					if cb := evts.ProvidersLockUpdated; cb != nil {
 						// We want to ensure that newHash and priorHashes are
@@ -538,6 +563,8 @@ NeedProvider:
 						cb(provider, version, []getproviders.Hash{newHash}, nil, priorHashes)
 					}
 
+					i.recordGlobalCacheUsage(cached)
+
 					if cb := evts.LinkFromCacheSuccess; cb != nil {
 						cb(provider, version, new.PackageDir)
 					}
@@ -593,6 +620,13 @@ NeedProvider:
 			}
 			continue
 		}
+		if err := i.checksumPolicy.checkSigningKey(provider, authResult); err != nil {
+			errs[provider] = err
+			if cb := evts.FetchPackageFailure; cb != nil {
+				cb(provider, version, err)
+			}
+			continue
+		}
 		new := installTo.ProviderVersion(provider, version)
 		if new == nil {
 			err := fmt.Errorf("after installing %s it is still not detected in %s; this is a bug in OpenTofu", provider, installTo.BasePath())
@@ -611,6 +645,8 @@ NeedProvider:
 			continue
 		}
 		if linkTo != nil {
+			i.recordGlobalCacheUsage(new)
+
 			// We skip emitting the "LinkFromCache..." events here because
 			// it's simpler for the caller to treat them as mutually exclusive.
 			// We can just subsume the linking step under the "FetchPackage..."
@@ -694,13 +730,21 @@ NeedProvider:
 			// Otherwise, we'd record only
 			// a new hash we just calculated ourselves from the bytes on disk,
 			// and so the hashes would cover only the current platform.
-			signedHashes = append(signedHashes, meta.AcceptableHashes()...)
+			signedHashes = append(signedHashes, i.checksumPolicy.filterHashes(meta.AcceptableHashes())...)
 		}
 
 		var newHashes []getproviders.Hash
 		newHashes = append(newHashes, newHash)
 		newHashes = append(newHashes, priorHashes...)
 		newHashes = append(newHashes, signedHashes...)
+		newHashes = i.checksumPolicy.filterHashes(newHashes)
+		if err := i.checksumPolicy.checkHashes(newHashes); err != nil {
+			errs[provider] = err
+			if cb := evts.FetchPackageFailure; cb != nil {
+				cb(provider, version, err)
+			}
+			continue
+		}
 
 		locks.SetProvider(provider, version, reqs[provider], newHashes)
 		if cb := evts.ProvidersLockUpdated; cb != nil {
@@ -744,6 +788,16 @@ NeedProvider:
 	return locks, nil
 }
 
+// recordGlobalCacheUsage notes that the given global cache entry was just
+// used, so that it will be retained by a later garbage collection of the
+// global cache directory. Failures are only logged, because the usage
+// records are advisory and must not prevent installation.
+func (i *Installer) recordGlobalCacheUsage(entry *CachedProvider) {
+	if err := i.globalCacheDir.RecordUsage(entry, time.Now()); err != nil {
+		log.Printf("[WARN] Failed to record usage of %s v%s in the provider plugin cache: %s", entry.Provider, entry.Version, err)
+	}
+}
+
 // checkUnspecifiedVersion Check the presence of version 0.0.0 and return an error with a tip
 func checkUnspecifiedVersion(acceptableVersions versions.Set) error {
 	if !acceptableVersions.Exactly(versions.Unspecified) {
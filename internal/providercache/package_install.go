@@ -21,6 +21,7 @@ import (
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/logging"
+	"github.com/opentofu/opentofu/internal/longpath"
 )
 
 // We borrow the "unpack a zip file into a target directory" logic from
@@ -179,7 +180,7 @@ func installFromLocalArchive(ctx context.Context, meta getproviders.PackageMeta,
 	// match the allowed hashes and so our caller should catch that after
 	// we return if so.
 
-	err := unzip.Decompress(targetDir, filename, true, 0000)
+	err := unzip.Decompress(longpath.Fix(targetDir), filename, true, 0000)
 	if err != nil {
 		return authResult, err
 	}
@@ -259,8 +260,14 @@ func installFromLocalDir(ctx context.Context, meta getproviders.PackageMeta, tar
 		}
 	}
 
+	// Provider cache directories can be deeply nested, so we use paths that
+	// work even if they are longer than Windows' traditional path length
+	// limit for the filesystem operations below. The symlink target keeps
+	// its usual form, since it is recorded in the link itself.
+	fixedNew := longpath.Fix(absNew)
+
 	// Delete anything that's already present at this path first.
-	err = os.RemoveAll(targetDir)
+	err = os.RemoveAll(fixedNew)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("failed to remove existing %s before linking it to %s: %w", sourceDir, targetDir, err)
 	}
@@ -279,12 +286,12 @@ func installFromLocalDir(ctx context.Context, meta getproviders.PackageMeta, tar
 	linkTarget := absCurrent
 
 	parentDir := filepath.Dir(absNew)
-	err = os.MkdirAll(parentDir, 0755)
+	err = os.MkdirAll(longpath.Fix(parentDir), 0755)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create parent directories leading to %s: %w", targetDir, err)
 	}
 
-	err = os.Symlink(linkTarget, absNew)
+	err = os.Symlink(linkTarget, fixedNew)
 	if err == nil {
 		// Success, then!
 		return nil, nil
@@ -293,11 +300,11 @@ func installFromLocalDir(ctx context.Context, meta getproviders.PackageMeta, tar
 	// If we get down here then symlinking failed and we need a deep copy
 	// instead. To make a copy, we first need to create the target directory,
 	// which would otherwise be a symlink.
-	err = os.Mkdir(absNew, 0755)
+	err = os.Mkdir(fixedNew, 0755)
 	if err != nil && os.IsExist(err) {
 		return nil, fmt.Errorf("failed to create directory %s: %w", absNew, err)
 	}
-	err = copy.CopyDir(absNew, absCurrent)
+	err = copy.CopyDir(fixedNew, absCurrent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to either symlink or copy %s to %s: %w", absCurrent, absNew, err)
 	}
@@ -0,0 +1,155 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/plugin6/convert"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfplugin6"
+)
+
+// SchemaDir represents a local filesystem directory containing the schemas
+// of specific provider versions, saved after the provider plugin was last
+// started so that later operations can use the schema without starting the
+// plugin again.
+//
+// Schemas are stored in the protocol version 6 wire format, one file per
+// provider version, regardless of which protocol the provider itself uses.
+type SchemaDir struct {
+	baseDir string
+}
+
+// NewSchemaDir creates and returns a new SchemaDir object that will read and
+// write provider schemas in the given filesystem directory.
+func NewSchemaDir(baseDir string) *SchemaDir {
+	return &SchemaDir{
+		baseDir: baseDir,
+	}
+}
+
+// BasePath returns the filesystem path of the base directory of this
+// schema directory.
+func (d *SchemaDir) BasePath() string {
+	return filepath.Clean(d.baseDir)
+}
+
+// ProviderSchema returns the cached schema for the given provider version,
+// or nil if there is no schema cached for that version.
+func (d *SchemaDir) ProviderSchema(provider addrs.Provider, version getproviders.Version) (*providers.ProviderSchema, error) {
+	src, err := os.ReadFile(d.schemaFile(provider, version))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var protoSchema tfplugin6.GetProviderSchema_Response
+	if err := proto.Unmarshal(src, &protoSchema); err != nil {
+		return nil, fmt.Errorf("invalid cached schema for %s %s: %w", provider, version, err)
+	}
+
+	ret := &providers.ProviderSchema{
+		Provider:      protoToSchema(protoSchema.Provider),
+		ProviderMeta:  protoToSchema(protoSchema.ProviderMeta),
+		ResourceTypes: make(map[string]providers.Schema, len(protoSchema.ResourceSchemas)),
+		DataSources:   make(map[string]providers.Schema, len(protoSchema.DataSourceSchemas)),
+		Functions:     make(map[string]providers.FunctionSpec, len(protoSchema.Functions)),
+	}
+	for name, schema := range protoSchema.ResourceSchemas {
+		ret.ResourceTypes[name] = protoToSchema(schema)
+	}
+	for name, schema := range protoSchema.DataSourceSchemas {
+		ret.DataSources[name] = protoToSchema(schema)
+	}
+	for name, fn := range protoSchema.Functions {
+		ret.Functions[name] = convert.ProtoToFunctionSpec(fn)
+	}
+	if protoSchema.ServerCapabilities != nil {
+		ret.ServerCapabilities.PlanDestroy = protoSchema.ServerCapabilities.PlanDestroy
+		ret.ServerCapabilities.GetProviderSchemaOptional = protoSchema.ServerCapabilities.GetProviderSchemaOptional
+	}
+	return ret, nil
+}
+
+// SetProviderSchema saves the given schema for the given provider version,
+// replacing any schema previously cached for that version.
+func (d *SchemaDir) SetProviderSchema(provider addrs.Provider, version getproviders.Version, schema providers.ProviderSchema) error {
+	protoSchema := &tfplugin6.GetProviderSchema_Response{
+		Provider:          schemaToProto(schema.Provider),
+		ProviderMeta:      schemaToProto(schema.ProviderMeta),
+		ResourceSchemas:   make(map[string]*tfplugin6.Schema, len(schema.ResourceTypes)),
+		DataSourceSchemas: make(map[string]*tfplugin6.Schema, len(schema.DataSources)),
+		Functions:         make(map[string]*tfplugin6.Function, len(schema.Functions)),
+		ServerCapabilities: &tfplugin6.ServerCapabilities{
+			PlanDestroy:               schema.ServerCapabilities.PlanDestroy,
+			GetProviderSchemaOptional: schema.ServerCapabilities.GetProviderSchemaOptional,
+		},
+	}
+	for name, s := range schema.ResourceTypes {
+		protoSchema.ResourceSchemas[name] = schemaToProto(s)
+	}
+	for name, s := range schema.DataSources {
+		protoSchema.DataSourceSchemas[name] = schemaToProto(s)
+	}
+	for name, fn := range schema.Functions {
+		protoFn, err := convert.FunctionSpecToProto(fn)
+		if err != nil {
+			return fmt.Errorf("invalid schema for function %q: %w", name, err)
+		}
+		protoSchema.Functions[name] = protoFn
+	}
+
+	src, err := proto.Marshal(protoSchema)
+	if err != nil {
+		return err
+	}
+
+	filename := d.schemaFile(provider, version)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+	// We write to a temporary file first so that a concurrent reader will
+	// never see a partially-written schema.
+	tmpFilename := filename + ".tmp"
+	if err := os.WriteFile(tmpFilename, src, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilename, filename)
+}
+
+func (d *SchemaDir) schemaFile(provider addrs.Provider, version getproviders.Version) string {
+	return filepath.Join(
+		d.baseDir,
+		provider.Hostname.String(),
+		provider.Namespace,
+		provider.Type,
+		version.String()+".tfschema",
+	)
+}
+
+func schemaToProto(s providers.Schema) *tfplugin6.Schema {
+	if s.Block == nil {
+		return nil
+	}
+	return &tfplugin6.Schema{
+		Version: s.Version,
+		Block:   convert.ConfigSchemaToProto(s.Block),
+	}
+}
+
+func protoToSchema(s *tfplugin6.Schema) providers.Schema {
+	if s == nil || s.Block == nil {
+		return providers.Schema{}
+	}
+	return convert.ProtoToProviderSchema(s)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providers"
+)
+
+func TestSchemaDir(t *testing.T) {
+	dir := NewSchemaDir(t.TempDir())
+	provider := addrs.NewDefaultProvider("test")
+	version := getproviders.MustParseVersion("1.2.0")
+
+	got, err := dir.ProviderSchema(provider, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("unexpected schema before saving: %#v", got)
+	}
+
+	want := providers.ProviderSchema{
+		Provider: providers.Schema{
+			Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"region": {Type: cty.String, Optional: true},
+				},
+			},
+		},
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Version: 2,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":  {Type: cty.String, Computed: true},
+						"ami": {Type: cty.String, Required: true},
+					},
+					BlockTypes: map[string]*configschema.NestedBlock{
+						"disk": {
+							Nesting: configschema.NestingList,
+							Block: configschema.Block{
+								Attributes: map[string]*configschema.Attribute{
+									"size": {Type: cty.Number, Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSources: map[string]providers.Schema{
+			"test_data": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"name": {Type: cty.String, Required: true},
+					},
+				},
+			},
+		},
+		Functions: map[string]providers.FunctionSpec{
+			"echo": {
+				Parameters: []providers.FunctionParameterSpec{
+					{
+						Name:              "input",
+						Type:              cty.String,
+						DescriptionFormat: providers.TextFormattingPlain,
+					},
+				},
+				Return:            cty.String,
+				DescriptionFormat: providers.TextFormattingMarkdown,
+			},
+		},
+		ServerCapabilities: providers.ServerCapabilities{
+			PlanDestroy: true,
+		},
+	}
+
+	if err := dir.SetProviderSchema(provider, version, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = dir.ProviderSchema(provider, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("no schema after saving")
+	}
+	if diff := cmp.Diff(want, *got, cmpopts.EquateEmpty(), cmp.Comparer(cty.Type.Equals)); diff != "" {
+		t.Errorf("wrong schema\n%s", diff)
+	}
+
+	// Other versions of the same provider are cached separately.
+	got, err = dir.ProviderSchema(provider, getproviders.MustParseVersion("1.3.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("unexpected schema for other version: %#v", got)
+	}
+}
@@ -36,6 +36,16 @@ const (
 	modulesServiceID   = "modules.v1"
 	providersServiceID = "providers.v1"
 
+	// modulesPublishServiceID is the service discovery identifier for
+	// registries that accept module packages uploaded with
+	// "tofu registry publish-module". The module registry protocol itself is
+	// read-only, so registries opt in to this by advertising it separately.
+	modulesPublishServiceID = "modules-publish.v1"
+
+	// providersPublishServiceID is the equivalent of modulesPublishServiceID
+	// for "tofu registry publish-provider".
+	providersPublishServiceID = "providers-publish.v1"
+
 	// registryDiscoveryRetryEnvName is the name of the environment variable that
 	// can be configured to customize number of retries for module and provider
 	// discovery requests with the remote registry.
@@ -28,6 +28,38 @@ func IsModuleNotFound(err error) bool {
 	return ok
 }
 
+type errModuleVersionExists struct {
+	addr    *regsrc.Module
+	version string
+}
+
+func (e *errModuleVersionExists) Error() string {
+	return fmt.Sprintf("module %s version %s already exists", e.addr, e.version)
+}
+
+// IsModuleVersionExists returns true only if the given error reports that the
+// registry already has a package for the module version being published.
+func IsModuleVersionExists(err error) bool {
+	_, ok := err.(*errModuleVersionExists)
+	return ok
+}
+
+type errProviderVersionExists struct {
+	addr    string
+	version string
+}
+
+func (e *errProviderVersionExists) Error() string {
+	return fmt.Sprintf("provider %s version %s already exists", e.addr, e.version)
+}
+
+// IsProviderVersionExists returns true only if the given error reports that
+// the registry already has the provider version being published.
+func IsProviderVersionExists(err error) bool {
+	_, ok := err.(*errProviderVersionExists)
+	return ok
+}
+
 // IsServiceNotProvided returns true only if the given error is a "service not provided"
 // error. This allows callers to recognize this particular error condition
 // as distinct from operational errors such as poor network connectivity.
@@ -0,0 +1,109 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PackageModule writes a gzip-compressed tar archive of the module in dir to
+// w, in the form expected by PublishModule, and returns the slash-separated
+// paths of the files it included.
+//
+// Hidden files and directories, such as .terraform and .git, are left out, as
+// are state files and crash logs, since those belong to a particular working
+// directory rather than to the module. Symlinks and other special files cause
+// an error.
+func PackageModule(w io.Writer, dir string) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		if excludeFromModulePackage(d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", p)
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan module directory: %w", err)
+	}
+	sort.Strings(names)
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	for _, name := range names {
+		if err := addPackageFile(tw, filepath.Join(dir, filepath.FromSlash(name)), name); err != nil {
+			return nil, fmt.Errorf("failed to add %s to module package: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func excludeFromModulePackage(name string) bool {
+	switch {
+	case strings.HasPrefix(name, "."):
+		return true
+	case strings.HasSuffix(name, ".tfstate"), strings.HasSuffix(name, ".tfstate.backup"):
+		return true
+	case name == "crash.log":
+		return true
+	default:
+		return false
+	}
+}
+
+func addPackageFile(tw *tar.Writer, filename, name string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: int64(info.Mode().Perm()),
+		Size: info.Size(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
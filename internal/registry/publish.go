@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/hashicorp/go-retryablehttp"
+	svchost "github.com/hashicorp/terraform-svchost"
+
+	"github.com/opentofu/opentofu/internal/registry/regsrc"
+)
+
+// PublishModule uploads a module package, as produced by PackageModule, to
+// the registry that hosts the given module, as the given version.
+//
+// The registry must advertise the "modules-publish.v1" service. The package
+// is sent with a PUT request to the path <namespace>/<name>/<system>/<version>
+// relative to that service's URL, using the credentials configured for the
+// registry host.
+func (c *Client) PublishModule(ctx context.Context, module *regsrc.Module, version string, pkg []byte) error {
+	host, err := module.SvcHost()
+	if err != nil {
+		return err
+	}
+
+	service, err := c.Discover(host, modulesPublishServiceID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.publishPut(ctx, host, service, path.Join(module.Module(), version), "application/gzip", pkg)
+	if err != nil {
+		return err
+	}
+	return publishResult(resp, host, module.String(), version, &errModuleVersionExists{addr: module, version: version})
+}
+
+// ProviderPublishRequest describes a provider version being published with
+// PublishProvider. It is sent to the registry as JSON, after all of the
+// release files it refers to have been uploaded.
+type ProviderPublishRequest struct {
+	Protocols                []string                  `json:"protocols"`
+	Platforms                []ProviderPublishPlatform `json:"platforms"`
+	SHASumsFilename          string                    `json:"shasums_filename"`
+	SHASumsSignatureFilename string                    `json:"shasums_signature_filename"`
+	SigningKeys              ProviderPublishKeys       `json:"signing_keys"`
+}
+
+// ProviderPublishPlatform describes the provider package for one platform
+// within a ProviderPublishRequest.
+type ProviderPublishPlatform struct {
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Filename string `json:"filename"`
+	SHASum   string `json:"shasum"`
+}
+
+// ProviderPublishKeys holds the public keys that the checksums of a published
+// provider version are signed with.
+type ProviderPublishKeys struct {
+	GPGPublicKeys []ProviderPublishGPGKey `json:"gpg_public_keys"`
+}
+
+// ProviderPublishGPGKey is an ASCII-armored OpenPGP public key.
+type ProviderPublishGPGKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+// PublishProvider uploads a provider release to the registry on the given
+// host, as the given version of namespace/typeName.
+//
+// The registry must advertise the "providers-publish.v1" service. Each of the
+// given files, keyed by filename, is sent with a PUT request to the path
+// <namespace>/<type>/<version>/files/<filename> relative to that service's
+// URL, and then the JSON-encoded request is sent with a PUT request to
+// <namespace>/<type>/<version> to complete the version.
+func (c *Client) PublishProvider(ctx context.Context, host svchost.Hostname, namespace, typeName, version string, req *ProviderPublishRequest, files map[string]string) error {
+	service, err := c.Discover(host, providersPublishServiceID)
+	if err != nil {
+		return err
+	}
+	display := fmt.Sprintf("%s/%s/%s", host.ForDisplay(), namespace, typeName)
+	versionPath := path.Join(namespace, typeName, version)
+	exists := &errProviderVersionExists{addr: display, version: version}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		content, err := os.ReadFile(files[filename])
+		if err != nil {
+			return err
+		}
+		resp, err := c.publishPut(ctx, host, service, path.Join(versionPath, "files", filename), "application/octet-stream", content)
+		if err != nil {
+			return err
+		}
+		if err := publishResult(resp, host, display, version, exists); err != nil {
+			return err
+		}
+	}
+
+	doc, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := c.publishPut(ctx, host, service, versionPath, "application/json", doc)
+	if err != nil {
+		return err
+	}
+	return publishResult(resp, host, display, version, exists)
+}
+
+func (c *Client) publishPut(ctx context.Context, host svchost.Hostname, service *url.URL, p string, contentType string, body []byte) (*http.Response, error) {
+	ref, err := url.Parse(p)
+	if err != nil {
+		return nil, err
+	}
+	target := service.ResolveReference(ref)
+
+	log.Printf("[DEBUG] publishing to %q", target)
+
+	req, err := retryablehttp.NewRequest("PUT", target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	c.addRequestCreds(host, req.Request)
+	req.Header.Set(xTerraformVersion, tfVersion)
+	req.Header.Set("Content-Type", contentType)
+
+	return c.client.Do(req)
+}
+
+// publishResult closes the body of the given response to a publishing request
+// and translates its status into an error, returning existsErr if the
+// registry reports that the version was already published.
+func publishResult(resp *http.Response, host svchost.Hostname, addr, version string, existsErr error) error {
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusConflict:
+		return existsErr
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s did not accept the credentials for publishing %s (%s); run \"tofu login %s\" to configure credentials for this registry", host.ForDisplay(), addr, resp.Status, host.ForDisplay())
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("error publishing %s version %s: %s resp:%s", addr, version, resp.Status, body)
+	}
+}
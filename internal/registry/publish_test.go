@@ -0,0 +1,232 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/hashicorp/terraform-svchost/auth"
+	"github.com/hashicorp/terraform-svchost/disco"
+
+	"github.com/opentofu/opentofu/internal/registry/regsrc"
+)
+
+func TestPackageModule(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tf":                    "# main",
+		"README.md":                  "# readme",
+		"modules/child/main.tf":      "# child",
+		"terraform.tfstate":          "{}",
+		".terraform/modules/foo.txt": "ignored",
+		".gitignore":                 "ignored",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	names, err := PackageModule(&buf, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"README.md", "main.tf", "modules/child/main.tf"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("wrong files\ngot:  %#v\nwant: %#v", names, want)
+	}
+
+	gzr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gzr)
+	got := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[hdr.Name] = string(content)
+	}
+	for _, name := range want {
+		if got[name] != files[name] {
+			t.Errorf("wrong content for %s: %q", name, got[name])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("wrong number of archive entries: %d", len(got))
+	}
+}
+
+func TestPublishModule(t *testing.T) {
+	var gotPath, gotAuth, gotType string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			http.Error(w, "wrong method", http.StatusMethodNotAllowed)
+			return
+		}
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotType = r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/v1/publish/acme/network/aws/1.0.0":
+			w.WriteHeader(http.StatusCreated)
+		case "/v1/publish/acme/network/aws/0.9.0":
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	host := svchost.Hostname("registry.example.com")
+	services := disco.NewWithCredentialsSource(auth.StaticCredentialsSource(map[svchost.Hostname]map[string]interface{}{
+		host: {"token": "secret"},
+	}))
+	services.ForceHostServices(host, map[string]interface{}{
+		"modules-publish.v1": fmt.Sprintf("%s/v1/publish", server.URL),
+	})
+	client := NewClient(services, nil)
+
+	mod, err := regsrc.ParseModuleSource("registry.example.com/acme/network/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		if err := client.PublishModule(context.Background(), mod, "1.0.0", []byte("package")); err != nil {
+			t.Fatal(err)
+		}
+		if gotPath != "/v1/publish/acme/network/aws/1.0.0" {
+			t.Errorf("wrong path %q", gotPath)
+		}
+		if gotAuth != "Bearer secret" {
+			t.Errorf("wrong authorization header %q", gotAuth)
+		}
+		if gotType != "application/gzip" {
+			t.Errorf("wrong content type %q", gotType)
+		}
+		if string(gotBody) != "package" {
+			t.Errorf("wrong body %q", gotBody)
+		}
+	})
+
+	t.Run("version exists", func(t *testing.T) {
+		err := client.PublishModule(context.Background(), mod, "0.9.0", []byte("package"))
+		if !IsModuleVersionExists(err) {
+			t.Fatalf("expected version exists error, got %v", err)
+		}
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		err := client.PublishModule(context.Background(), mod, "2.0.0", []byte("package"))
+		if err == nil || IsModuleVersionExists(err) {
+			t.Fatalf("expected credentials error, got %v", err)
+		}
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		other, err := regsrc.ParseModuleSource("other.example.com/acme/network/aws")
+		if err != nil {
+			t.Fatal(err)
+		}
+		services.ForceHostServices(svchost.Hostname("other.example.com"), map[string]interface{}{
+			"modules.v1": fmt.Sprintf("%s/v1/modules", server.URL),
+		})
+		if err := client.PublishModule(context.Background(), other, "1.0.0", []byte("package")); err == nil {
+			t.Fatal("expected error for registry without publishing support")
+		}
+	})
+}
+
+func TestPublishProvider(t *testing.T) {
+	var gotPaths []string
+	var gotDoc ProviderPublishRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		gotPaths = append(gotPaths, r.URL.Path)
+		if r.URL.Path == "/v1/publish/acme/widget/1.0.0" {
+			if err := json.NewDecoder(r.Body).Decode(&gotDoc); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	host := svchost.Hostname("registry.example.com")
+	services := disco.NewWithCredentialsSource(auth.StaticCredentialsSource(map[svchost.Hostname]map[string]interface{}{
+		host: {"token": "secret"},
+	}))
+	services.ForceHostServices(host, map[string]interface{}{
+		"providers-publish.v1": fmt.Sprintf("%s/v1/publish/", server.URL),
+	})
+	client := NewClient(services, nil)
+
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, name := range []string{"widget_linux_amd64.zip", "widget_SHA256SUMS", "widget_SHA256SUMS.sig"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files[name] = p
+	}
+	req := &ProviderPublishRequest{
+		Protocols: []string{"6.0"},
+		Platforms: []ProviderPublishPlatform{
+			{OS: "linux", Arch: "amd64", Filename: "widget_linux_amd64.zip", SHASum: "abc"},
+		},
+		SHASumsFilename:          "widget_SHA256SUMS",
+		SHASumsSignatureFilename: "widget_SHA256SUMS.sig",
+	}
+
+	if err := client.PublishProvider(context.Background(), host, "acme", "widget", "1.0.0", req, files); err != nil {
+		t.Fatal(err)
+	}
+	wantPaths := []string{
+		"/v1/publish/acme/widget/1.0.0/files/widget_SHA256SUMS",
+		"/v1/publish/acme/widget/1.0.0/files/widget_SHA256SUMS.sig",
+		"/v1/publish/acme/widget/1.0.0/files/widget_linux_amd64.zip",
+		"/v1/publish/acme/widget/1.0.0",
+	}
+	if !reflect.DeepEqual(gotPaths, wantPaths) {
+		t.Fatalf("wrong requests\ngot:  %#v\nwant: %#v", gotPaths, wantPaths)
+	}
+	if !reflect.DeepEqual(&gotDoc, req) {
+		t.Fatalf("wrong version document\ngot:  %#v\nwant: %#v", gotDoc, req)
+	}
+}
@@ -5,6 +5,11 @@
 
 package response
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ModuleVersions is the response format that contains all metadata about module
 // versions needed for tofu CLI to resolve version constraints. See RFC
 // TF-042 for details on this format.
@@ -15,16 +20,44 @@ type ModuleVersions struct {
 // ModuleProviderVersions is the response format for a single module instance,
 // containing metadata about all versions and their dependencies.
 type ModuleProviderVersions struct {
-	Source   string           `json:"source"`
-	Versions []*ModuleVersion `json:"versions"`
+	Source      string           `json:"source"`
+	Versions    []*ModuleVersion `json:"versions"`
+	Deprecation *Deprecation     `json:"deprecation,omitempty"`
 }
 
 // ModuleVersion is the output metadata for a given version needed by CLI to
 // resolve candidate versions to satisfy requirements.
 type ModuleVersion struct {
-	Version    string              `json:"version"`
-	Root       VersionSubmodule    `json:"root"`
-	Submodules []*VersionSubmodule `json:"submodules"`
+	Version     string              `json:"version"`
+	Root        VersionSubmodule    `json:"root"`
+	Submodules  []*VersionSubmodule `json:"submodules"`
+	Deprecation *Deprecation        `json:"deprecation,omitempty"`
+}
+
+// Deprecation is optional metadata a registry may attach to a module or to
+// one of its versions to announce that it is no longer maintained.
+type Deprecation struct {
+	Reason    string `json:"reason,omitempty"`
+	Link      string `json:"link,omitempty"`
+	EndOfLife string `json:"end_of_life,omitempty"`
+}
+
+// Describe returns a user-facing description of the deprecation of the object
+// with the given display name, for use in warnings.
+func (d *Deprecation) Describe(name string) string {
+	var b strings.Builder
+	if d.EndOfLife != "" {
+		fmt.Fprintf(&b, "%s is deprecated and reaches its end of life on %s.", name, d.EndOfLife)
+	} else {
+		fmt.Fprintf(&b, "%s is deprecated.", name)
+	}
+	if d.Reason != "" {
+		fmt.Fprintf(&b, " %s", d.Reason)
+	}
+	if d.Link != "" {
+		fmt.Fprintf(&b, " For more information, see %s.", d.Link)
+	}
+	return b.String()
 }
 
 // VersionSubmodule is the output metadata for a submodule within a given
@@ -0,0 +1,426 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ExpandDynamicBlocks returns the configuration of the resource with the
+// given address written as HCL, with each of its dynamic blocks replaced by
+// the blocks it generates and each argument replaced by its value. This lets
+// authors check complicated dynamic blocks and for expressions without
+// creating a plan.
+//
+// Only resources in the root module can be expanded. If the resource uses
+// count or for_each then the address must include an instance key, which
+// selects the values of count.index or each.
+//
+// The result also includes warnings about dynamic blocks that are likely to
+// be mistakes, such as those whose content never refers to their iterator.
+func (s *Session) ExpandDynamicBlocks(addrStr string) (string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if s.Config == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No configuration",
+			"Dynamic blocks can only be expanded when the console has loaded a configuration.",
+		))
+		return "", diags
+	}
+
+	addr, addrDiags := addrs.ParseAbsResourceInstanceStr(addrStr)
+	diags = diags.Append(addrDiags)
+	if addrDiags.HasErrors() {
+		return "", diags
+	}
+	if !addr.Module.IsRoot() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported resource address",
+			fmt.Sprintf("Only resources in the root module can be expanded, but %s belongs to %s.", addr, addr.Module),
+		))
+		return "", diags
+	}
+	rc := s.Config.ResourceByAddr(addr.Resource.Resource)
+	if rc == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Resource not declared",
+			fmt.Sprintf("The root module does not declare %s.", addr.Resource.Resource),
+		))
+		return "", diags
+	}
+	body, ok := rc.Config.(*hclsyntax.Body)
+	if !ok {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported configuration syntax",
+			fmt.Sprintf("%s is written in JSON syntax, so its dynamic blocks can't be expanded.", addr.Resource.Resource),
+		))
+		return "", diags
+	}
+
+	e := &expander{session: s}
+	vars, moreDiags := e.instanceVariables(rc, addr.Resource.Key)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return "", diags
+	}
+
+	mode := "resource"
+	if rc.Mode == addrs.DataResourceMode {
+		mode = "data"
+	}
+	fmt.Fprintf(&e.buf, "%s %q %q {\n", mode, rc.Type, rc.Name)
+	e.writeBody(body, vars, 1, true)
+	e.buf.WriteString("}")
+
+	diags = diags.Append(e.diags)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	return e.buf.String(), diags
+}
+
+// resourceMetaArguments and resourceMetaBlocks are the arguments and block
+// types in a resource block that aren't part of the resource's own
+// configuration, and so aren't shown in its expansion.
+var (
+	resourceMetaArguments = map[string]bool{
+		"count":      true,
+		"for_each":   true,
+		"provider":   true,
+		"depends_on": true,
+	}
+	resourceMetaBlocks = map[string]bool{
+		"lifecycle":   true,
+		"provisioner": true,
+		"connection":  true,
+		"wait_for":    true,
+	}
+)
+
+// expander writes the expansion of a resource's configuration for
+// Session.ExpandDynamicBlocks.
+type expander struct {
+	session *Session
+	buf     strings.Builder
+	diags   tfdiags.Diagnostics
+}
+
+// instanceVariables returns the count or each object for the resource
+// instance with the given key.
+func (e *expander) instanceVariables(rc *configs.Resource, key addrs.InstanceKey) (map[string]cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	switch {
+	case rc.Count != nil:
+		intKey, ok := key.(addrs.IntKey)
+		if !ok {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Instance key required",
+				fmt.Sprintf("%s uses count, so the address to expand must include an instance index, such as %s[0].", rc.Addr(), rc.Addr()),
+			))
+			return nil, diags
+		}
+		return map[string]cty.Value{
+			"count": cty.ObjectVal(map[string]cty.Value{
+				"index": cty.NumberIntVal(int64(intKey)),
+			}),
+		}, diags
+
+	case rc.ForEach != nil:
+		strKey, ok := key.(addrs.StringKey)
+		if !ok {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Instance key required",
+				fmt.Sprintf("%s uses for_each, so the address to expand must include an instance key, such as %s[\"example\"].", rc.Addr(), rc.Addr()),
+			))
+			return nil, diags
+		}
+		forEach, moreDiags := e.eval(rc.ForEach, nil)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, diags
+		}
+		forEach, _ = forEach.Unmark()
+		var value cty.Value
+		switch {
+		case !forEach.IsWhollyKnown() || forEach.IsNull():
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid for_each argument",
+				Detail:   "The for_each value must be known and not null for the resource to be expanded.",
+				Subject:  rc.ForEach.Range().Ptr(),
+			})
+			return nil, diags
+		case forEach.Type().IsSetType():
+			if forEach.HasElement(cty.StringVal(string(strKey))).True() {
+				value = cty.StringVal(string(strKey))
+			}
+		case forEach.CanIterateElements():
+			if forEach.Type().IsObjectType() {
+				if forEach.Type().HasAttribute(string(strKey)) {
+					value = forEach.GetAttr(string(strKey))
+				}
+			} else if forEach.HasIndex(cty.StringVal(string(strKey))).True() {
+				value = forEach.Index(cty.StringVal(string(strKey)))
+			}
+		}
+		if value == cty.NilVal {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"No such instance",
+				fmt.Sprintf("The for_each argument of %s doesn't have an element with the key %q.", rc.Addr(), strKey),
+			))
+			return nil, diags
+		}
+		return map[string]cty.Value{
+			"each": cty.ObjectVal(map[string]cty.Value{
+				"key":   cty.StringVal(string(strKey)),
+				"value": value,
+			}),
+		}, diags
+
+	default:
+		if key != addrs.NoKey {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Unexpected instance key",
+				fmt.Sprintf("%s doesn't use count or for_each, so the address to expand must not include an instance key.", rc.Addr()),
+			))
+			return nil, diags
+		}
+		return nil, diags
+	}
+}
+
+// eval evaluates the given expression in the session's scope, with the given
+// extra variables defined too. The extra variables are the count and each
+// objects and the iterators of the dynamic blocks that contain the
+// expression, which the scope itself doesn't know about.
+func (e *expander) eval(expr hcl.Expression, vars map[string]cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	var traversals []hcl.Traversal
+	for _, traversal := range expr.Variables() {
+		if _, ok := vars[traversal.RootName()]; !ok {
+			traversals = append(traversals, traversal)
+		}
+	}
+	refs, moreDiags := lang.References(e.session.Scope.ParseRef, traversals)
+	diags = diags.Append(moreDiags)
+	ctx, moreDiags := e.session.Scope.EvalContext(refs)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+	if len(vars) > 0 {
+		ctx = ctx.NewChild()
+		ctx.Variables = vars
+	}
+	val, hclDiags := expr.Value(ctx)
+	diags = diags.Append(hclDiags)
+	return val, diags
+}
+
+// writeBody writes the arguments and blocks of the given body at the given
+// depth, expanding any dynamic blocks.
+func (e *expander) writeBody(body *hclsyntax.Body, vars map[string]cty.Value, depth int, isResource bool) {
+	indent := strings.Repeat("  ", depth)
+
+	var attrs []*hclsyntax.Attribute
+	width := 0
+	for name, attr := range body.Attributes {
+		if isResource && resourceMetaArguments[name] {
+			continue
+		}
+		attrs = append(attrs, attr)
+		width = max(width, len(name))
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+	for _, attr := range attrs {
+		val, diags := e.eval(attr.Expr, vars)
+		e.diags = e.diags.Append(diags)
+		fmt.Fprintf(&e.buf, "%s%-*s = %s\n", indent, width, attr.Name, FormatValue(val, len(indent)))
+	}
+
+	first := len(attrs) == 0
+	for _, block := range body.Blocks {
+		if isResource && resourceMetaBlocks[block.Type] {
+			continue
+		}
+		if !first {
+			e.buf.WriteString("\n")
+		}
+		first = false
+
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			e.writeDynamicBlock(block, vars, depth)
+			continue
+		}
+		e.writeBlockHeader(indent, block.Type, block.Labels)
+		e.writeBody(block.Body, vars, depth+1, false)
+		e.buf.WriteString(indent + "}\n")
+	}
+}
+
+// writeDynamicBlock writes the blocks that the given dynamic block generates.
+func (e *expander) writeDynamicBlock(block *hclsyntax.Block, vars map[string]cty.Value, depth int) {
+	indent := strings.Repeat("  ", depth)
+	blockType := block.Labels[0]
+
+	forEachAttr, ok := block.Body.Attributes["for_each"]
+	if !ok {
+		e.diags = e.diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing required argument",
+			Detail:   "A dynamic block requires a for_each argument.",
+			Subject:  block.DefRange().Ptr(),
+		})
+		return
+	}
+	var content *hclsyntax.Block
+	for _, child := range block.Body.Blocks {
+		if child.Type == "content" {
+			content = child
+		}
+	}
+	if content == nil {
+		e.diags = e.diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing content block",
+			Detail:   "A dynamic block requires a content block.",
+			Subject:  block.DefRange().Ptr(),
+		})
+		return
+	}
+
+	iterator := blockType
+	if attr, ok := block.Body.Attributes["iterator"]; ok {
+		traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+		e.diags = e.diags.Append(diags)
+		if diags.HasErrors() {
+			return
+		}
+		iterator = traversal.RootName()
+	}
+	labelsAttr := block.Body.Attributes["labels"]
+
+	if !refersTo(content.Body, iterator) && (labelsAttr == nil || !refersTo(labelsAttr.Expr, iterator)) {
+		e.diags = e.diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Dynamic block doesn't use its iterator",
+			Detail:   fmt.Sprintf("The content of this dynamic block never refers to %s, so every %q block that it generates is the same. If you set the iterator argument, refer to the iterator by that name instead of the block type.", iterator, blockType),
+			Subject:  block.DefRange().Ptr(),
+		})
+	}
+
+	forEach, diags := e.eval(forEachAttr.Expr, vars)
+	e.diags = e.diags.Append(diags)
+	if diags.HasErrors() {
+		return
+	}
+	switch {
+	case forEach.HasMark(marks.Sensitive):
+		e.diags = e.diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid dynamic for_each value",
+			Detail:   "The for_each value of a dynamic block can't be sensitive, because the number of blocks it generates would disclose it.",
+			Subject:  forEachAttr.Expr.Range().Ptr(),
+		})
+		return
+	case !forEach.IsKnown():
+		fmt.Fprintf(&e.buf, "%s# The %q blocks will be known after apply\n", indent, blockType)
+		return
+	case forEach.IsNull() || !forEach.CanIterateElements():
+		e.diags = e.diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid dynamic for_each value",
+			Detail:   fmt.Sprintf("Cannot use a %s value in for_each. An iterable collection is required.", forEach.Type().FriendlyName()),
+			Subject:  forEachAttr.Expr.Range().Ptr(),
+		})
+		return
+	case forEach.LengthInt() == 0:
+		fmt.Fprintf(&e.buf, "%s# This dynamic block generates no %q blocks\n", indent, blockType)
+		return
+	}
+
+	first := true
+	for it := forEach.ElementIterator(); it.Next(); {
+		key, value := it.Element()
+		childVars := make(map[string]cty.Value, len(vars)+1)
+		for name, val := range vars {
+			childVars[name] = val
+		}
+		childVars[iterator] = cty.ObjectVal(map[string]cty.Value{
+			"key":   key,
+			"value": value,
+		})
+
+		var labels []string
+		if labelsAttr != nil {
+			labelsVal, diags := e.eval(labelsAttr.Expr, childVars)
+			e.diags = e.diags.Append(diags)
+			if diags.HasErrors() {
+				return
+			}
+			if labelsVal.IsWhollyKnown() && !labelsVal.IsNull() && labelsVal.CanIterateElements() {
+				for lit := labelsVal.ElementIterator(); lit.Next(); {
+					_, label := lit.Element()
+					if label.Type() == cty.String {
+						labels = append(labels, label.AsString())
+					}
+				}
+			}
+		}
+
+		if !first {
+			e.buf.WriteString("\n")
+		}
+		first = false
+		e.writeBlockHeader(indent, blockType, labels)
+		e.writeBody(content.Body, childVars, depth+1, false)
+		e.buf.WriteString(indent + "}\n")
+	}
+}
+
+func (e *expander) writeBlockHeader(indent string, blockType string, labels []string) {
+	e.buf.WriteString(indent + blockType)
+	for _, label := range labels {
+		fmt.Fprintf(&e.buf, " %q", label)
+	}
+	e.buf.WriteString(" {\n")
+}
+
+// refersTo returns true if any expression within the given node refers to a
+// variable with the given name.
+func refersTo(node hclsyntax.Node, name string) bool {
+	found := false
+	hclsyntax.VisitAll(node, func(n hclsyntax.Node) hcl.Diagnostics {
+		if traversal, ok := n.(*hclsyntax.ScopeTraversalExpr); ok && traversal.Traversal.RootName() == name {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
@@ -0,0 +1,141 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestSession_ExpandDynamicBlocks(t *testing.T) {
+	ruleBlock := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"port": {Type: cty.Number, Optional: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"source": {
+				Nesting: configschema.NestingList,
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"cidr": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	p := &tofu.MockProvider{}
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"name": {Type: cty.String, Optional: true},
+					},
+					BlockTypes: map[string]*configschema.NestedBlock{
+						"ingress": {Nesting: configschema.NestingList, Block: *ruleBlock},
+						"egress":  {Nesting: configschema.NestingList, Block: *ruleBlock},
+					},
+				},
+			},
+		},
+	}
+
+	config, _, cleanup, configDiags := initwd.LoadConfigForTests(t, "testdata/expand", "tests")
+	defer cleanup()
+	if configDiags.HasErrors() {
+		t.Fatalf("unexpected problems loading config: %s", configDiags.Err())
+	}
+	ctx, diags := tofu.NewContext(&tofu.ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): providers.FactoryFixed(p),
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("failed to create context: %s", diags.Err())
+	}
+	scope, diags := ctx.Eval(context.Background(), config, states.NewState(), addrs.RootModuleInstance, &tofu.EvalOpts{})
+	if diags.HasErrors() {
+		t.Fatalf("failed to create scope: %s", diags.Err())
+	}
+	s := &Session{
+		Scope:  scope,
+		Config: config.Module,
+	}
+
+	t.Run("nested dynamic blocks", func(t *testing.T) {
+		got, diags := s.ExpandDynamicBlocks("test_instance.web")
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Err())
+		}
+		want := `resource "test_instance" "web" {
+  name = "web"
+
+  ingress {
+    port = 80
+
+    source {
+      cidr = "0.0.0.0/0"
+    }
+  }
+
+  ingress {
+    port = 443
+
+    source {
+      cidr = "10.0.0.0/8"
+    }
+
+    source {
+      cidr = "192.168.0.0/16"
+    }
+  }
+
+  # This dynamic block generates no "egress" blocks
+}`
+		if got != want {
+			t.Errorf("wrong result\ngot:\n%s\n\nwant:\n%s", got, want)
+		}
+		if len(diags) != 0 {
+			t.Errorf("unexpected warnings: %s", diags.ErrWithWarnings())
+		}
+	})
+
+	t.Run("for_each instance", func(t *testing.T) {
+		got, diags := s.ExpandDynamicBlocks(`test_instance.each["b"]`)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Err())
+		}
+		if !strings.Contains(got, `name = "b-2"`) || strings.Count(got, "ingress {") != 2 {
+			t.Errorf("wrong result\n%s", got)
+		}
+		// The content of the dynamic block never refers to its iterator.
+		if len(diags) != 1 || diags[0].Description().Summary != "Dynamic block doesn't use its iterator" {
+			t.Errorf("wrong warnings: %s", diags.ErrWithWarnings())
+		}
+	})
+
+	t.Run("missing instance key", func(t *testing.T) {
+		_, diags := s.ExpandDynamicBlocks("test_instance.each")
+		if !diags.HasErrors() || !strings.Contains(diags.Err().Error(), "must include an instance key") {
+			t.Errorf("wrong errors: %s", diags.Err())
+		}
+	})
+
+	t.Run("undeclared resource", func(t *testing.T) {
+		_, diags := s.ExpandDynamicBlocks("test_instance.missing")
+		if !diags.HasErrors() {
+			t.Error("expected an error for an undeclared resource")
+		}
+	})
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/lang"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/lang/types"
@@ -24,6 +25,14 @@ import (
 type Session struct {
 	// Scope is the evaluation scope where expressions will be evaluated.
 	Scope *lang.Scope
+
+	// Config is the configuration of the module that Scope evaluates
+	// expressions in, and Sources are the source files it was loaded from.
+	// Both are optional. Trace uses them to show where referenced objects
+	// are declared and how local values are defined, and
+	// ExpandDynamicBlocks requires Config to find the resource to expand.
+	Config  *configs.Module
+	Sources map[string]*hcl.File
 }
 
 // Handle handles a single line of input from the REPL.
@@ -0,0 +1,50 @@
+locals {
+  rules = [
+    { port = 80, cidrs = ["0.0.0.0/0"] },
+    { port = 443, cidrs = ["10.0.0.0/8", "192.168.0.0/16"] },
+  ]
+}
+
+resource "test_instance" "web" {
+  name = "web"
+
+  dynamic "ingress" {
+    for_each = local.rules
+    iterator = rule
+    content {
+      port = rule.value.port
+
+      dynamic "source" {
+        for_each = rule.value.cidrs
+        content {
+          cidr = source.value
+        }
+      }
+    }
+  }
+
+  dynamic "egress" {
+    for_each = []
+    content {
+      port = egress.value
+    }
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "test_instance" "each" {
+  for_each = { a = 1, b = 2 }
+
+  name = "${each.key}-${each.value}"
+
+  dynamic "ingress" {
+    for_each = range(each.value)
+    iterator = rule
+    content {
+      port = 8080
+    }
+  }
+}
@@ -0,0 +1,14 @@
+variable "secret" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  names  = ["a", "b"]
+  upper  = [for n in local.names : upper(n)]
+  joined = join(",", local.upper)
+  complex = {
+    joined = local.joined
+    secret = var.secret
+  }
+}
@@ -0,0 +1,250 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Trace evaluates a single expression and returns a description of how its
+// value was derived: the value of each reference and function call in the
+// expression, nested under the expression that uses it, along with where
+// each referenced object is declared and whether its value is sensitive.
+//
+// References to local values are followed into the expressions that define
+// them when the session has the root module configuration, so that deeply
+// nested local values can be debugged one step at a time.
+func (s *Session) Trace(line string) (string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	src := []byte(line)
+	expr, parseDiags := hclsyntax.ParseExpression(src, "<console-input>", hcl.Pos{Line: 1, Column: 1})
+	diags = diags.Append(parseDiags)
+	if parseDiags.HasErrors() {
+		return "", diags
+	}
+
+	// We evaluate the whole expression first so that any errors are reported
+	// in the same way as for the interactive console, before we evaluate
+	// each of its parts separately.
+	_, valDiags := s.Scope.EvalExpr(expr, cty.DynamicPseudoType)
+	diags = diags.Append(valDiags)
+	if valDiags.HasErrors() {
+		return "", diags
+	}
+
+	t := &tracer{
+		session:   s,
+		following: make(map[string]bool),
+	}
+	t.traceExpr(expr, src, 0, true)
+	return strings.TrimSuffix(t.buf.String(), "\n"), diags
+}
+
+// tracer writes the evaluation tree of an expression for Session.Trace.
+type tracer struct {
+	session *Session
+	buf     strings.Builder
+
+	// following records the local values whose definitions are being traced,
+	// so that we don't follow a reference cycle forever.
+	following map[string]bool
+}
+
+// traceExpr writes the tree for the given expression at the given depth,
+// where src is the source code that the expression was parsed from. The
+// expression itself is written only if show is set or if it is a reference
+// or a function call.
+func (t *tracer) traceExpr(expr hcl.Expression, src []byte, depth int, show bool) {
+	node, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		// Expressions in JSON syntax have no structure that we can show.
+		return
+	}
+	refs, diags := lang.ReferencesInExpr(t.session.Scope.ParseRef, expr)
+	ctx, ctxDiags := t.session.Scope.EvalContext(refs)
+	diags = diags.Append(ctxDiags)
+	if diags.HasErrors() {
+		return
+	}
+	t.traceNode(node, ctx, src, depth, show)
+}
+
+func (t *tracer) traceNode(node hclsyntax.Expression, ctx *hcl.EvalContext, src []byte, depth int, show bool) {
+	traversal, isRef := node.(*hclsyntax.ScopeTraversalExpr)
+	if _, isCall := node.(*hclsyntax.FunctionCallExpr); isCall || isRef {
+		show = true
+	}
+
+	childDepth := depth
+	if show {
+		childDepth++
+		val, diags := node.Value(ctx)
+		t.writeValue(depth, node.Range(), src, val, diags)
+		if isRef {
+			t.traceReference(traversal.Traversal, childDepth)
+		}
+	}
+
+	for _, child := range traceChildNodes(node, ctx) {
+		t.traceNode(child, ctx, src, childDepth, false)
+	}
+}
+
+// traceReference writes where the object that the given traversal refers to
+// is declared, and then the tree for the definition of a local value.
+func (t *tracer) traceReference(traversal hcl.Traversal, depth int) {
+	mod := t.session.Config
+	if mod == nil {
+		return
+	}
+	ref, diags := t.session.Scope.ParseRef(traversal)
+	if diags.HasErrors() {
+		return
+	}
+
+	var declRange *hcl.Range
+	switch addr := ref.Subject.(type) {
+	case addrs.LocalValue:
+		local, ok := mod.Locals[addr.Name]
+		if !ok {
+			return
+		}
+		t.writeNote(depth, "%s is declared at %s", addr, local.DeclRange)
+		if t.following[addr.Name] {
+			return
+		}
+		t.following[addr.Name] = true
+		t.traceExpr(local.Expr, t.sourceBytes(local.Expr.Range()), depth, false)
+		delete(t.following, addr.Name)
+		return
+	case addrs.InputVariable:
+		if v, ok := mod.Variables[addr.Name]; ok {
+			declRange = &v.DeclRange
+		}
+	case addrs.Resource:
+		if r := mod.ResourceByAddr(addr); r != nil {
+			declRange = &r.DeclRange
+		}
+	case addrs.ResourceInstance:
+		if r := mod.ResourceByAddr(addr.ContainingResource()); r != nil {
+			declRange = &r.DeclRange
+		}
+	case addrs.ModuleCall:
+		if mc, ok := mod.ModuleCalls[addr.Name]; ok {
+			declRange = &mc.DeclRange
+		}
+	case addrs.ModuleCallInstance:
+		if mc, ok := mod.ModuleCalls[addr.Call.Name]; ok {
+			declRange = &mc.DeclRange
+		}
+	case addrs.ModuleCallInstanceOutput:
+		if mc, ok := mod.ModuleCalls[addr.Call.Call.Name]; ok {
+			declRange = &mc.DeclRange
+		}
+	}
+	if declRange != nil {
+		t.writeNote(depth, "%s is declared at %s", ref.Subject, declRange)
+	}
+}
+
+func (t *tracer) sourceBytes(rng hcl.Range) []byte {
+	if f, ok := t.session.Sources[rng.Filename]; ok {
+		return f.Bytes
+	}
+	return nil
+}
+
+func (t *tracer) writeValue(depth int, rng hcl.Range, src []byte, val cty.Value, diags hcl.Diagnostics) {
+	indent := depth * 2
+	text := fmt.Sprintf("(expression at %s)", rng)
+	if src != nil {
+		// Expressions in the configuration can span several lines, but we
+		// want to show each one on a single line of the tree.
+		text = strings.Join(strings.Fields(string(rng.SliceBytes(src))), " ")
+	}
+
+	if diags.HasErrors() {
+		// This can happen only for parts of the expression that OpenTofu
+		// wouldn't evaluate on their own, since the whole expression was
+		// valid.
+		fmt.Fprintf(&t.buf, "%s%s = (error: %s)\n", strings.Repeat(" ", indent), text, diags[0].Summary)
+		return
+	}
+	fmt.Fprintf(&t.buf, "%s%s = %s\n", strings.Repeat(" ", indent), text, FormatValue(val, indent))
+	switch {
+	case val.HasMark(marks.Sensitive):
+		t.writeNote(depth+1, "sensitive")
+	case marks.Contains(val, marks.Sensitive):
+		t.writeNote(depth+1, "contains sensitive values")
+	}
+}
+
+func (t *tracer) writeNote(depth int, format string, args ...interface{}) {
+	fmt.Fprintf(&t.buf, "%s# %s\n", strings.Repeat(" ", depth*2), fmt.Sprintf(format, args...))
+}
+
+// traceChildNodes returns the parts of the given expression that are worth
+// showing in a trace. The parts that are evaluated with their own local
+// symbols, such as the body of a for expression, are skipped because they
+// can't be evaluated on their own, and so is the branch of a conditional
+// expression that wasn't taken.
+func traceChildNodes(node hclsyntax.Expression, ctx *hcl.EvalContext) []hclsyntax.Expression {
+	switch e := node.(type) {
+	case *hclsyntax.FunctionCallExpr:
+		return e.Args
+	case *hclsyntax.RelativeTraversalExpr:
+		return []hclsyntax.Expression{e.Source}
+	case *hclsyntax.IndexExpr:
+		return []hclsyntax.Expression{e.Collection, e.Key}
+	case *hclsyntax.BinaryOpExpr:
+		return []hclsyntax.Expression{e.LHS, e.RHS}
+	case *hclsyntax.UnaryOpExpr:
+		return []hclsyntax.Expression{e.Val}
+	case *hclsyntax.ParenthesesExpr:
+		return []hclsyntax.Expression{e.Expression}
+	case *hclsyntax.TemplateExpr:
+		return e.Parts
+	case *hclsyntax.TemplateWrapExpr:
+		return []hclsyntax.Expression{e.Wrapped}
+	case *hclsyntax.TupleConsExpr:
+		return e.Exprs
+	case *hclsyntax.ObjectConsExpr:
+		ret := make([]hclsyntax.Expression, 0, len(e.Items))
+		for _, item := range e.Items {
+			ret = append(ret, item.ValueExpr)
+		}
+		return ret
+	case *hclsyntax.SplatExpr:
+		return []hclsyntax.Expression{e.Source}
+	case *hclsyntax.ForExpr:
+		return []hclsyntax.Expression{e.CollExpr}
+	case *hclsyntax.ConditionalExpr:
+		ret := []hclsyntax.Expression{e.Condition}
+		cond, diags := e.Condition.Value(ctx)
+		cond, _ = cond.Unmark()
+		switch {
+		case diags.HasErrors() || cond.IsNull() || cond.Type() != cty.Bool:
+		case !cond.IsKnown():
+			ret = append(ret, e.TrueResult, e.FalseResult)
+		case cond.True():
+			ret = append(ret, e.TrueResult)
+		default:
+			ret = append(ret, e.FalseResult)
+		}
+		return ret
+	default:
+		return nil
+	}
+}
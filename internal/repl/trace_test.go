@@ -0,0 +1,104 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestSession_Trace(t *testing.T) {
+	config, loader, cleanup, configDiags := initwd.LoadConfigForTests(t, "testdata/trace", "tests")
+	defer cleanup()
+	if configDiags.HasErrors() {
+		t.Fatalf("unexpected problems loading config: %s", configDiags.Err())
+	}
+
+	ctx, diags := tofu.NewContext(&tofu.ContextOpts{})
+	if diags.HasErrors() {
+		t.Fatalf("failed to create context: %s", diags.Err())
+	}
+	scope, diags := ctx.Eval(context.Background(), config, states.NewState(), addrs.RootModuleInstance, &tofu.EvalOpts{
+		SetVariables: tofu.InputValues{
+			"secret": &tofu.InputValue{
+				Value:      cty.StringVal("hunter2"),
+				SourceType: tofu.ValueFromCaller,
+			},
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("failed to create scope: %s", diags.Err())
+	}
+	s := &Session{
+		Scope:   scope,
+		Config:  config.Module,
+		Sources: loader.Sources(),
+	}
+
+	tests := map[string]struct {
+		input      string
+		want       []string
+		wantAbsent []string
+	}{
+		"nested locals": {
+			input: "local.complex",
+			want: []string{
+				"local.complex = {\n",
+				"  # contains sensitive values\n",
+				"  # local.complex is declared at ",
+				"  local.joined = \"A,B\"\n",
+				"    join(\",\", local.upper) = \"A,B\"\n",
+				"      local.upper = [\n",
+				"        local.names = [\n",
+				"  var.secret = (sensitive value)\n    # sensitive\n    # var.secret is declared at ",
+			},
+			wantAbsent: []string{
+				// The body of a for expression can't be evaluated on its own.
+				"upper(n)",
+			},
+		},
+		"conditional": {
+			input: "length(local.names) > 5 ? var.secret : local.joined",
+			want: []string{
+				"length(local.names) > 5 ? var.secret : local.joined = \"A,B\"\n",
+				"  length(local.names) = 2\n",
+				"    local.names = [\n",
+			},
+			wantAbsent: []string{
+				// The branch that wasn't taken isn't shown.
+				"\n  var.secret",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := s.Trace(test.input)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Err())
+			}
+			for _, want := range test.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output doesn't contain %q\n\n%s", want, got)
+				}
+			}
+			for _, absent := range test.wantAbsent {
+				if strings.Contains(got, absent) {
+					t.Errorf("output contains %q\n\n%s", absent, got)
+				}
+			}
+		})
+	}
+
+	if _, diags := s.Trace("local.missing"); !diags.HasErrors() {
+		t.Fatal("expected an error for an undeclared local value")
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package selfupdate implements "tofu upgrade", which replaces the running
+// OpenTofu executable with a newer release after verifying that the release
+// was signed by the OpenTofu project.
+package selfupdate
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+const (
+	// DefaultAPIURL is the location of the index of OpenTofu releases, which
+	// is also used by the official installation script.
+	DefaultAPIURL = "https://get.opentofu.org/tofu/api.json"
+
+	// DefaultDownloadURL is the base URL of the release artifacts. Each
+	// release's files are under a "v" + version subdirectory.
+	DefaultDownloadURL = "https://github.com/opentofu/opentofu/releases/download"
+
+	// DefaultSigningKeyURL is the location of the OpenTofu release signing
+	// key, used when the policy doesn't include a key of its own.
+	DefaultSigningKeyURL = "https://get.opentofu.org/opentofu.asc"
+
+	// DefaultSigningKeyFingerprint is the fingerprint of the OpenTofu release
+	// signing key. A downloaded key is served from the same kind of location
+	// as the releases themselves, so it is only trusted if it has this
+	// fingerprint, unless the policy pins a different one.
+	DefaultSigningKeyFingerprint = "E3E6E43D84CB852EADB0051D0C0AF313E5FD9F80"
+)
+
+// Channel selects which kind of releases an Updater considers.
+type Channel string
+
+const (
+	// ChannelStable considers only releases without a prerelease suffix.
+	ChannelStable Channel = "stable"
+
+	// ChannelPrerelease also considers alpha, beta, and release candidate
+	// releases.
+	ChannelPrerelease Channel = "prerelease"
+)
+
+// Policy controls which releases an Updater selects and where it downloads
+// them from. The zero value selects the newest stable release from the
+// official release endpoint.
+type Policy struct {
+	Channel Channel
+
+	// Constraints, if not empty, pins upgrades to the versions that match.
+	Constraints getproviders.VersionConstraints
+
+	// APIURL, DownloadURL and SigningKeyURL override the corresponding
+	// defaults, for example to use an internal mirror of the releases.
+	APIURL        string
+	DownloadURL   string
+	SigningKeyURL string
+
+	// SigningKey, if set, is the ASCII-armored public key that release
+	// checksums must be signed with. SigningKeyFile is the same, but read
+	// from a file. When either is set, the key is not downloaded from
+	// SigningKeyURL.
+	SigningKey     string
+	SigningKeyFile string
+
+	// SigningKeyFingerprint, if set, is the fingerprint that the signing key
+	// must have. A key downloaded from SigningKeyURL must always match a
+	// fingerprint, which defaults to DefaultSigningKeyFingerprint.
+	SigningKeyFingerprint string
+}
+
+// Updater finds, downloads, and verifies OpenTofu releases.
+type Updater struct {
+	Policy   Policy
+	Client   *http.Client
+	Platform getproviders.Platform
+}
+
+// NewUpdater returns an Updater for the given policy and the current
+// platform.
+func NewUpdater(policy Policy, client *http.Client) *Updater {
+	return &Updater{
+		Policy:   policy,
+		Client:   client,
+		Platform: getproviders.CurrentPlatform,
+	}
+}
+
+// AvailableVersions returns the released versions that the policy allows,
+// sorted from oldest to newest.
+func (u *Updater) AvailableVersions(ctx context.Context) (getproviders.VersionList, error) {
+	src, err := u.get(ctx, withDefault(u.Policy.APIURL, DefaultAPIURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+	var index struct {
+		Versions []struct {
+			ID string `json:"id"`
+		} `json:"versions"`
+	}
+	if err := json.Unmarshal(src, &index); err != nil {
+		return nil, fmt.Errorf("invalid release index: %w", err)
+	}
+
+	allowed := getproviders.VersionList{}
+	for _, entry := range index.Versions {
+		v, err := getproviders.ParseVersion(entry.ID)
+		if err != nil {
+			log.Printf("[WARN] Ignoring invalid version %q in the release index", entry.ID)
+			continue
+		}
+		if v.Prerelease != "" && u.Policy.Channel != ChannelPrerelease {
+			continue
+		}
+		if len(u.Policy.Constraints) != 0 && !getproviders.MeetingConstraints(u.Policy.Constraints).Has(v) {
+			continue
+		}
+		allowed = append(allowed, v)
+	}
+	allowed.Sort()
+	return allowed, nil
+}
+
+// Download fetches the given release for the updater's platform, verifies
+// its checksum and the signature of the release checksums, and extracts the
+// executable into a new file in dir, returning its path.
+//
+// dir should be on the same filesystem as the executable being replaced, so
+// that Install can move the new executable into place atomically.
+func (u *Updater) Download(ctx context.Context, version getproviders.Version, dir string) (string, error) {
+	base := strings.TrimSuffix(withDefault(u.Policy.DownloadURL, DefaultDownloadURL), "/") + "/v" + version.String() + "/"
+	prefix := fmt.Sprintf("tofu_%s", version)
+	archiveName := fmt.Sprintf("%s_%s.zip", prefix, u.Platform)
+
+	sums, err := u.get(ctx, base+prefix+"_SHA256SUMS")
+	if err != nil {
+		return "", fmt.Errorf("failed to download release checksums: %w", err)
+	}
+	signature, err := u.get(ctx, base+prefix+"_SHA256SUMS.gpgsig")
+	if err != nil {
+		return "", fmt.Errorf("failed to download release checksums signature: %w", err)
+	}
+	if err := u.verifySignature(ctx, sums, signature); err != nil {
+		return "", err
+	}
+
+	want, err := findChecksum(sums, archiveName)
+	if err != nil {
+		return "", err
+	}
+	archive, err := u.get(ctx, base+archiveName)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", archiveName, err)
+	}
+	if got := sha256.Sum256(archive); got != want {
+		return "", fmt.Errorf("checksum mismatch for %s: got %x, want %x", archiveName, got, want)
+	}
+
+	return extractExecutable(archive, dir)
+}
+
+func (u *Updater) verifySignature(ctx context.Context, document, signature []byte) error {
+	key := u.Policy.SigningKey
+	source := "the configured release signing key"
+	fingerprint := u.Policy.SigningKeyFingerprint
+	if key == "" && u.Policy.SigningKeyFile != "" {
+		src, err := os.ReadFile(u.Policy.SigningKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read release signing key: %w", err)
+		}
+		key = string(src)
+		source = u.Policy.SigningKeyFile
+	}
+	if key == "" {
+		source = withDefault(u.Policy.SigningKeyURL, DefaultSigningKeyURL)
+		src, err := u.get(ctx, source)
+		if err != nil {
+			return fmt.Errorf("failed to download release signing key: %w", err)
+		}
+		key = string(src)
+		fingerprint = withDefault(fingerprint, DefaultSigningKeyFingerprint)
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+	if err != nil {
+		return fmt.Errorf("invalid release signing key: %w", err)
+	}
+	if fingerprint != "" {
+		keyring = keysWithFingerprint(keyring, fingerprint)
+		if len(keyring) == 0 {
+			return fmt.Errorf("the release signing key from %s does not have the expected fingerprint %s", source, fingerprint)
+		}
+	}
+	entity, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(document), bytes.NewReader(signature), nil)
+	if err != nil {
+		return fmt.Errorf("release checksums are not signed by the release signing key: %w", err)
+	}
+	if entity.PrimaryKey != nil {
+		log.Printf("[DEBUG] Release checksums signed by key %s", entity.PrimaryKey.KeyIdString())
+	}
+	return nil
+}
+
+// keysWithFingerprint returns the keys of the keyring whose primary key has
+// the given fingerprint, which may be written in either case and with spaces
+// between groups of digits.
+func keysWithFingerprint(keyring openpgp.EntityList, fingerprint string) openpgp.EntityList {
+	want := strings.ReplaceAll(fingerprint, " ", "")
+	var ret openpgp.EntityList
+	for _, entity := range keyring {
+		if entity.PrimaryKey != nil && strings.EqualFold(hex.EncodeToString(entity.PrimaryKey.Fingerprint), want) {
+			ret = append(ret, entity)
+		}
+	}
+	return ret
+}
+
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func findChecksum(document []byte, filename string) ([sha256.Size]byte, error) {
+	var ret [sha256.Size]byte
+	sc := bufio.NewScanner(bytes.NewReader(document))
+	for sc.Scan() {
+		parts := strings.Fields(sc.Text())
+		if len(parts) != 2 || parts[1] != filename {
+			continue
+		}
+		sum, err := hex.DecodeString(parts[0])
+		if err != nil || len(sum) != sha256.Size {
+			return ret, fmt.Errorf("invalid checksum for %s in the release checksums", filename)
+		}
+		copy(ret[:], sum)
+		return ret, nil
+	}
+	return ret, fmt.Errorf("the release does not include %s; it may not be available for this platform", filename)
+}
+
+// extractExecutable writes the tofu executable from the given release archive
+// to a new file in dir.
+func extractExecutable(archive []byte, dir string) (string, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return "", fmt.Errorf("invalid release archive: %w", err)
+	}
+	name := "tofu"
+	if runtime.GOOS == "windows" {
+		name = "tofu.exe"
+	}
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		in, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		defer in.Close()
+
+		out, err := os.CreateTemp(dir, ".tofu-upgrade-*")
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			os.Remove(out.Name())
+			return "", err
+		}
+		if err := out.Close(); err != nil {
+			os.Remove(out.Name())
+			return "", err
+		}
+		if err := os.Chmod(out.Name(), 0755); err != nil {
+			os.Remove(out.Name())
+			return "", err
+		}
+		return out.Name(), nil
+	}
+	return "", fmt.Errorf("the release archive does not contain %s", name)
+}
+
+// Install replaces the executable at target with the one at newPath, which
+// must be on the same filesystem, so that the executable is never left
+// partially written.
+//
+// Windows doesn't allow replacing a running executable, so there the old
+// executable is first renamed with a ".old" suffix, to be removed by the
+// next upgrade.
+func Install(newPath, target string) error {
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		os.Remove(old) // okay if it fails because it doesn't exist
+		if err := os.Rename(target, old); err != nil {
+			return err
+		}
+		if err := os.Rename(newPath, target); err != nil {
+			// Put the old executable back so that OpenTofu still works.
+			os.Rename(old, target)
+			return err
+		}
+		return nil
+	}
+	return os.Rename(newPath, target)
+}
+
+// Executable returns the path of the running OpenTofu executable, with any
+// symlinks resolved, which is the file Install should replace.
+func Executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+func withDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,268 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package selfupdate
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// testReleaseServer serves a release index listing the given versions, and
+// the artifacts of version 1.8.0 for linux_amd64 signed with a
+// newly-generated key, which is returned in ASCII-armored form.
+func testReleaseServer(t *testing.T, versions ...string) (*httptest.Server, string, map[string][]byte) {
+	t.Helper()
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	name := "tofu"
+	if runtime.GOOS == "windows" {
+		name = "tofu.exe"
+	}
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("new tofu"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(archive.Bytes())
+	sums := fmt.Sprintf("%s  tofu_1.8.0_linux_amd64.zip\n", hex.EncodeToString(sum[:]))
+
+	entity, err := openpgp.NewEntity("OpenTofu Test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signature bytes.Buffer
+	if err := openpgp.DetachSign(&signature, entity, strings.NewReader(sums), nil); err != nil {
+		t.Fatal(err)
+	}
+	var key bytes.Buffer
+	aw, err := armor.Encode(&key, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(aw); err != nil {
+		t.Fatal(err)
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var index strings.Builder
+	index.WriteString(`{"versions":[`)
+	for i, v := range versions {
+		if i > 0 {
+			index.WriteString(",")
+		}
+		fmt.Fprintf(&index, `{"id":%q,"files":[]}`, v)
+	}
+	index.WriteString("]}")
+
+	files := map[string][]byte{
+		"/tofu/api.json":                                []byte(index.String()),
+		"/opentofu.asc":                                 key.Bytes(),
+		"/download/v1.8.0/tofu_1.8.0_SHA256SUMS":        []byte(sums),
+		"/download/v1.8.0/tofu_1.8.0_SHA256SUMS.gpgsig": signature.Bytes(),
+		"/download/v1.8.0/tofu_1.8.0_linux_amd64.zip":   archive.Bytes(),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server, key.String(), files
+}
+
+// testKeyFingerprint returns the fingerprint of the given ASCII-armored key,
+// spaced the way gpg prints it.
+func testKeyFingerprint(t *testing.T, key string) string {
+	t.Helper()
+
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := fmt.Sprintf("%X", keyring[0].PrimaryKey.Fingerprint)
+	var groups []string
+	for i := 0; i < len(fingerprint); i += 4 {
+		groups = append(groups, fingerprint[i:min(i+4, len(fingerprint))])
+	}
+	return strings.Join(groups, " ")
+}
+
+func testUpdater(server *httptest.Server, policy Policy) *Updater {
+	policy.APIURL = server.URL + "/tofu/api.json"
+	policy.DownloadURL = server.URL + "/download"
+	policy.SigningKeyURL = server.URL + "/opentofu.asc"
+	return &Updater{
+		Policy:   policy,
+		Client:   server.Client(),
+		Platform: getproviders.Platform{OS: "linux", Arch: "amd64"},
+	}
+}
+
+func TestUpdaterAvailableVersions(t *testing.T) {
+	server, _, _ := testReleaseServer(t, "1.6.2", "1.8.0", "1.7.3", "1.9.0-beta1", "not-a-version")
+
+	tests := map[string]struct {
+		policy Policy
+		want   string
+	}{
+		"stable": {
+			Policy{},
+			"1.6.2 1.7.3 1.8.0",
+		},
+		"prerelease": {
+			Policy{Channel: ChannelPrerelease},
+			"1.6.2 1.7.3 1.8.0 1.9.0-beta1",
+		},
+		"pinned": {
+			Policy{Constraints: getproviders.MustParseVersionConstraints("~> 1.7.0")},
+			"1.7.3",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := testUpdater(server, test.policy).AvailableVersions(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotStrs []string
+			for _, v := range got {
+				gotStrs = append(gotStrs, v.String())
+			}
+			if strings.Join(gotStrs, " ") != test.want {
+				t.Errorf("wrong versions\ngot:  %s\nwant: %s", strings.Join(gotStrs, " "), test.want)
+			}
+		})
+	}
+}
+
+func TestUpdaterDownload(t *testing.T) {
+	version := getproviders.MustParseVersion("1.8.0")
+
+	t.Run("verified", func(t *testing.T) {
+		server, key, _ := testReleaseServer(t, "1.8.0")
+		dir := t.TempDir()
+		path, err := testUpdater(server, Policy{SigningKeyFingerprint: testKeyFingerprint(t, key)}).Download(context.Background(), version, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Dir(path) != dir {
+			t.Errorf("executable extracted outside of %s: %s", dir, path)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "new tofu" {
+			t.Errorf("wrong executable content %q", got)
+		}
+	})
+
+	t.Run("pinned key", func(t *testing.T) {
+		server, key, files := testReleaseServer(t, "1.8.0")
+		// The pinned key takes precedence over the downloaded one.
+		delete(files, "/opentofu.asc")
+		if _, err := testUpdater(server, Policy{SigningKey: key}).Download(context.Background(), version, t.TempDir()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("downloaded key without pinned fingerprint", func(t *testing.T) {
+		// A downloaded key must have the fingerprint of the official
+		// release signing key unless the policy pins another.
+		server, _, _ := testReleaseServer(t, "1.8.0")
+		_, err := testUpdater(server, Policy{}).Download(context.Background(), version, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "does not have the expected fingerprint "+DefaultSigningKeyFingerprint) {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("configured key with wrong fingerprint", func(t *testing.T) {
+		server, key, _ := testReleaseServer(t, "1.8.0")
+		_, otherKey, _ := testReleaseServer(t, "1.8.0")
+		policy := Policy{SigningKey: key, SigningKeyFingerprint: testKeyFingerprint(t, otherKey)}
+		_, err := testUpdater(server, policy).Download(context.Background(), version, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "does not have the expected fingerprint") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		server, _, _ := testReleaseServer(t, "1.8.0")
+		_, otherKey, _ := testReleaseServer(t, "1.8.0")
+		_, err := testUpdater(server, Policy{SigningKey: otherKey}).Download(context.Background(), version, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "not signed by the release signing key") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("tampered archive", func(t *testing.T) {
+		server, key, files := testReleaseServer(t, "1.8.0")
+		files["/download/v1.8.0/tofu_1.8.0_linux_amd64.zip"] = []byte("tampered")
+		_, err := testUpdater(server, Policy{SigningKey: key}).Download(context.Background(), version, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("unsupported platform", func(t *testing.T) {
+		server, key, _ := testReleaseServer(t, "1.8.0")
+		updater := testUpdater(server, Policy{SigningKey: key})
+		updater.Platform = getproviders.Platform{OS: "plan9", Arch: "arm"}
+		_, err := updater.Download(context.Background(), version, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "not be available for this platform") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+}
+
+func TestInstall(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "tofu")
+	newPath := filepath.Join(dir, ".tofu-upgrade-1")
+	if err := os.WriteFile(target, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newPath, []byte("new"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Install(newPath, target); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" {
+		t.Errorf("wrong content after install %q", got)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Errorf("new executable still present at %s", newPath)
+	}
+}
@@ -46,6 +46,10 @@ type State struct {
 	state, readState     *states.State
 	disableLocks         bool
 
+	// encoder writes the snapshots we persist, re-serializing only the parts
+	// of the state that changed since the previous snapshot.
+	encoder *statefile.Encoder
+
 	// If this is set then the state manager will decline to store intermediate
 	// state snapshots created while a OpenTofu Core apply operation is in
 	// progress. Otherwise (by default) it will accept persistent snapshots
@@ -61,6 +65,7 @@ func NewState(client Client, enc encryption.StateEncryption) *State {
 	return &State{
 		Client:     client,
 		encryption: enc,
+		encoder:    statefile.NewEncoder(false),
 	}
 }
 
@@ -221,8 +226,11 @@ func (s *State) PersistState(schemas *tofu.Schemas) error {
 
 	f := statefile.New(s.state, s.lineage, s.serial)
 
+	if s.encoder == nil {
+		s.encoder = statefile.NewEncoder(false)
+	}
 	var buf bytes.Buffer
-	err := statefile.Write(f, &buf, s.encryption)
+	err := s.encoder.Write(f, &buf, s.encryption)
 	if err != nil {
 		return err
 	}
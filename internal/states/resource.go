@@ -38,6 +38,18 @@ func (rs *Resource) Instance(key addrs.InstanceKey) *ResourceInstance {
 	return rs.Instances[key]
 }
 
+// InstanceProvider returns the provider configuration that most recently
+// managed the instance with the given key, along with the instance key of
+// that provider configuration if it uses for_each. The provider key is
+// addrs.NoKey if the provider configuration has no instances, or if there is
+// no instance with the given key.
+func (rs *Resource) InstanceProvider(key addrs.InstanceKey) (addrs.AbsProviderConfig, addrs.InstanceKey) {
+	if is := rs.Instance(key); is != nil && is.ProviderKey != nil {
+		return rs.ProviderConfig, is.ProviderKey
+	}
+	return rs.ProviderConfig, addrs.NoKey
+}
+
 // CreateInstance creates an instance and adds it to the resource
 func (rs *Resource) CreateInstance(key addrs.InstanceKey) *ResourceInstance {
 	is := NewResourceInstance()
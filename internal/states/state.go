@@ -43,6 +43,17 @@ type State struct {
 	// created by a version of OpenTofu that didn't yet support checks
 	// then this field will be nil.
 	CheckResults *CheckResults
+
+	// ProviderVersions records the version of each provider that was
+	// selected when the state was last updated, for the providers that
+	// the state refers to.
+	//
+	// OpenTofu can't convert resource instance objects back to an older
+	// schema version, so this is used to tell the user which provider
+	// version they need when the selected version is older than the one
+	// that last saved the state. A provider that has no entry here was
+	// last used by a version of OpenTofu that didn't record this.
+	ProviderVersions map[addrs.Provider]getproviders.Version
 }
 
 // NewState constructs a minimal empty state, containing an empty root module.
@@ -7,6 +7,7 @@ package states
 
 import (
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -33,9 +34,17 @@ func (s *State) DeepCopy() *State {
 	for k, m := range s.Modules {
 		modules[k] = m.DeepCopy()
 	}
+	var providerVersions map[addrs.Provider]getproviders.Version
+	if s.ProviderVersions != nil {
+		providerVersions = make(map[addrs.Provider]getproviders.Version, len(s.ProviderVersions))
+		for addr, version := range s.ProviderVersions {
+			providerVersions[addr] = version
+		}
+	}
 	return &State{
-		Modules:      modules,
-		CheckResults: s.CheckResults.DeepCopy(),
+		Modules:          modules,
+		CheckResults:     s.CheckResults.DeepCopy(),
+		ProviderVersions: providerVersions,
 	}
 }
 
@@ -0,0 +1,110 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statefile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// compactFormatEnvVar is the environment variable that opts in to the compact
+// format for state snapshots that OpenTofu persists locally.
+const compactFormatEnvVar = "TF_STATE_FORMAT"
+
+// CompactFormatRequested returns true if the user has opted in to the compact
+// state format for locally persisted state snapshots, by setting the
+// TF_STATE_FORMAT environment variable to "compact".
+//
+// The compact format is always accepted when reading, regardless of this
+// setting, so it's safe to switch back and forth between formats.
+func CompactFormatRequested() bool {
+	return os.Getenv(compactFormatEnvVar) == "compact"
+}
+
+// compactEnvelope is the JSON document that holds a state snapshot in the
+// compact format: the standard JSON snapshot compressed with gzip, alongside
+// the few top-level fields that the encryption layer and the version sniffing
+// in Read need to see without decompressing it.
+//
+// The snapshot is compressed before it's encrypted, because encrypted data
+// doesn't compress, and the envelope is what gets encrypted.
+type compactEnvelope struct {
+	// Compact is always true. It's the first property of the envelope, so
+	// isCompact can recognize the format without parsing the whole document.
+	Compact bool `json:"compact"`
+
+	Version          stateVersionV4 `json:"version"`
+	TerraformVersion string         `json:"terraform_version"`
+	Serial           uint64         `json:"serial"`
+	Lineage          string         `json:"lineage"`
+
+	// State is the gzip-compressed JSON state snapshot.
+	State []byte `json:"state"`
+}
+
+// isCompact returns true if the given (decrypted) state snapshot is in the
+// compact format.
+func isCompact(src []byte) bool {
+	dec := json.NewDecoder(bytes.NewReader(src))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return false
+	}
+	tok, err := dec.Token()
+	return err == nil && tok == "compact"
+}
+
+// compact returns the compact encoding of the given JSON state snapshot,
+// which must be the encoding of the given stateV4.
+//
+// We favor speed over compression ratio here, because the main goal is to
+// reduce the I/O cost of persisting very large states, and state JSON is
+// highly repetitive and so compresses well even at the fastest level.
+func compact(sV4 *stateV4, src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	ret, err := json.Marshal(compactEnvelope{
+		Compact:          true,
+		Version:          sV4.Version,
+		TerraformVersion: sV4.TerraformVersion,
+		Serial:           sV4.Serial,
+		Lineage:          sV4.Lineage,
+		State:            buf.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(ret, '\n'), nil
+}
+
+// uncompact reverses compact, returning the JSON state snapshot.
+func uncompact(src []byte) ([]byte, error) {
+	var envelope compactEnvelope
+	if err := json.Unmarshal(src, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid compact state: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(envelope.State))
+	if err != nil {
+		return nil, fmt.Errorf("invalid compact state: %w", err)
+	}
+	defer zr.Close()
+	ret, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compact state: %w", err)
+	}
+	return ret, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statefile
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/encryption/enctest"
+)
+
+func TestRoundtripCompact(t *testing.T) {
+	const path = "testdata/roundtrip/v4-modules.out.tfstate"
+
+	tests := map[string]encryption.StateEncryption{
+		"unencrypted": encryption.StateEncryptionDisabled(),
+		"encrypted":   enctest.EncryptionWithFallback().State(),
+	}
+
+	for name, enc := range tests {
+		t.Run(name, func(t *testing.T) {
+			in, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer in.Close()
+
+			original, err := Read(in, enc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var standard, compacted bytes.Buffer
+			if err := Write(original, &standard, enc); err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteCompact(original, &compacted, enc); err != nil {
+				t.Fatal(err)
+			}
+
+			want, err := Read(&standard, enc)
+			if err != nil {
+				t.Fatalf("unexpected error reading standard state: %s", err)
+			}
+			got, err := Read(&compacted, enc)
+			if err != nil {
+				t.Fatalf("unexpected error reading compact state: %s", err)
+			}
+
+			problems := deep.Equal(got, want)
+			sort.Strings(problems)
+			for _, problem := range problems {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestWriteCompact_isCompact(t *testing.T) {
+	in, err := os.Open("testdata/roundtrip/v4-modules.out.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	state, err := Read(in, encryption.StateEncryptionDisabled())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var standard, compacted bytes.Buffer
+	if err := Write(state, &standard, encryption.StateEncryptionDisabled()); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteCompact(state, &compacted, encryption.StateEncryptionDisabled()); err != nil {
+		t.Fatal(err)
+	}
+
+	if isCompact(standard.Bytes()) {
+		t.Error("standard state snapshot detected as compact")
+	}
+	if !isCompact(compacted.Bytes()) {
+		t.Error("compact state snapshot not detected as compact")
+	}
+	if compacted.Len() >= standard.Len() {
+		t.Errorf("compact snapshot is %d bytes, but standard snapshot is only %d bytes", compacted.Len(), standard.Len())
+	}
+}
+
+func TestWriteCompact_encrypted(t *testing.T) {
+	in, err := os.Open("testdata/roundtrip/v4-modules.out.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	enc := enctest.EncryptionWithFallback().State()
+	state, err := Read(in, enc)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var standard, compacted bytes.Buffer
+	if err := Write(state, &standard, enc); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteCompact(state, &compacted, enc); err != nil {
+		t.Fatal(err)
+	}
+
+	// The state is compressed before it's encrypted, so the compact format
+	// must still be smaller once encrypted.
+	if compacted.Len() >= standard.Len() {
+		t.Errorf("encrypted compact snapshot is %d bytes, but encrypted standard snapshot is only %d bytes", compacted.Len(), standard.Len())
+	}
+
+	// The encryption layer must still see the state metadata.
+	decrypted, _, err := enc.DecryptState(compacted.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error decrypting: %s", err)
+	}
+	if !isCompact(decrypted) {
+		t.Error("decrypted snapshot not detected as compact")
+	}
+}
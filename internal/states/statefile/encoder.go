@@ -0,0 +1,85 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statefile
+
+import (
+	"io"
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+	tfversion "github.com/opentofu/opentofu/version"
+)
+
+// DefaultCheckpointInterval is the number of snapshots an Encoder writes
+// between full checkpoints, unless overridden by SetCheckpointInterval.
+const DefaultCheckpointInterval = 10
+
+// Encoder writes a series of snapshots of a state that is gradually
+// changing, such as the intermediate snapshots created during a long apply.
+//
+// Rather than serializing the entire state for each snapshot, an Encoder
+// remembers the serialization of each resource instance object it has
+// written and re-serializes only the objects that have been added or changed
+// since the previous snapshot. Each snapshot is still a complete state file
+// that can be read with Read, and is written in full: this saves the cost of
+// serializing unchanged objects, not the cost of writing them.
+//
+// Every few snapshots the Encoder discards everything it remembers and
+// serializes the whole state again, as a full checkpoint.
+//
+// An Encoder is safe for concurrent use, but callers must not modify a state
+// after writing it, because the Encoder may retain references to its data.
+type Encoder struct {
+	compact            bool
+	checkpointInterval int
+
+	mu     sync.Mutex
+	cache  *encodeCache
+	writes int
+}
+
+// NewEncoder returns a new Encoder that writes snapshots in the compact
+// format if compact is set, or in the standard format otherwise.
+func NewEncoder(compact bool) *Encoder {
+	return &Encoder{
+		compact:            compact,
+		checkpointInterval: DefaultCheckpointInterval,
+	}
+}
+
+// SetCheckpointInterval changes the number of snapshots the receiver writes
+// between full checkpoints. An interval of one or less makes every snapshot
+// a full checkpoint.
+func (e *Encoder) SetCheckpointInterval(n int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.checkpointInterval = n
+}
+
+// Write writes the given state to the given writer, in the same way as Write
+// or WriteCompact.
+func (e *Encoder) Write(s *File, w io.Writer, enc encryption.StateEncryption) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Always record the current tofu version in the state.
+	s.TerraformVersion = tfversion.SemVer
+
+	if e.cache == nil || e.writes%max(e.checkpointInterval, 1) == 0 {
+		e.cache = &encodeCache{}
+	}
+	e.writes++
+
+	diags := writeStateV4(s, w, enc, writeOptions{
+		compact: e.compact,
+		cache:   e.cache,
+	})
+	if diags.HasErrors() {
+		// We can't be sure the cache is consistent after a failure, so the
+		// next snapshot will be a full checkpoint.
+		e.cache = nil
+		e.writes = 0
+	}
+	return diags.Err()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statefile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestEncoder(t *testing.T) {
+	enc := encryption.StateEncryptionDisabled()
+	providerAddr := addrs.AbsProviderConfig{
+		Provider: addrs.NewDefaultProvider("test"),
+		Module:   addrs.RootModule,
+	}
+	setInstance := func(s *states.State, name, id string) {
+		s.RootModule().SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: name,
+			}.Instance(addrs.NoKey),
+			&states.ResourceInstanceObjectSrc{
+				Status:    states.ObjectReady,
+				AttrsJSON: []byte(`{"id":"` + id + `"}`),
+			},
+			providerAddr,
+			addrs.NoKey,
+		)
+	}
+
+	state := states.NewState()
+	setInstance(state, "a", "a1")
+	setInstance(state, "b", "b1")
+
+	encoder := NewEncoder(false)
+	encoder.SetCheckpointInterval(3)
+
+	// Each snapshot must be identical to what Write would produce for the
+	// same state, regardless of which objects were cached.
+	steps := []func(s *states.State){
+		func(s *states.State) {},
+		func(s *states.State) { setInstance(s, "a", "a2") },
+		func(s *states.State) { setInstance(s, "c", "c1") },
+		func(s *states.State) {
+			s.RootModule().SetOutputValue("out", cty.StringVal("hello"), false)
+		},
+		func(s *states.State) {
+			s.RootModule().RemoveResource(addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "b",
+			})
+		},
+	}
+	for i, step := range steps {
+		state = state.DeepCopy()
+		step(state)
+		f := New(state, "lineage", uint64(i))
+
+		var want, got bytes.Buffer
+		if err := Write(f, &want, enc); err != nil {
+			t.Fatal(err)
+		}
+		if err := encoder.Write(f, &got, enc); err != nil {
+			t.Fatalf("step %d: unexpected error: %s", i, err)
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("step %d: wrong result\ngot:  %s\nwant: %s", i, got.Bytes(), want.Bytes())
+		}
+	}
+}
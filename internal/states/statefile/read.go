@@ -81,6 +81,15 @@ func Read(r io.Reader, enc encryption.StateEncryption) (*File, error) {
 		return nil, err
 	}
 
+	// A state snapshot in the compact format is compressed before it's
+	// encrypted, so it must be decompressed after it's decrypted.
+	if isCompact(decrypted) {
+		decrypted, err = uncompact(decrypted)
+		if err != nil {
+			return nil, errUnusable(err)
+		}
+	}
+
 	state, err := readState(decrypted)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,7 @@
 package statefile
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -353,11 +355,17 @@ func prepareStateV4(sV4 *stateV4) (*File, tfdiags.Diagnostics) {
 		diags = diags.Append(moreDiags)
 	}
 
+	if sV4.ProviderVersions != nil {
+		var moreDiags tfdiags.Diagnostics
+		state.ProviderVersions, moreDiags = decodeProviderVersionsV4(sV4.ProviderVersions)
+		diags = diags.Append(moreDiags)
+	}
+
 	file.State = state
 	return file, diags
 }
 
-func writeStateV4(file *File, w io.Writer, enc encryption.StateEncryption) tfdiags.Diagnostics {
+func writeStateV4(file *File, w io.Writer, enc encryption.StateEncryption, opts writeOptions) tfdiags.Diagnostics {
 	// Here we'll convert back from the "File" representation to our
 	// stateV4 struct representation and write that.
 	//
@@ -477,10 +485,12 @@ func writeStateV4(file *File, w io.Writer, enc encryption.StateEncryption) tfdia
 	}
 
 	sV4.CheckResults = encodeCheckResultsV4(file.State.CheckResults)
+	sV4.ProviderVersions = encodeProviderVersionsV4(file.State.ProviderVersions)
 
 	sV4.normalize()
 
-	src, err := json.Marshal(sV4)
+	var buf bytes.Buffer
+	err := encodeStateV4(&buf, sV4, opts.cache)
 	if err != nil {
 		// Shouldn't happen if we do our conversion to *stateV4 correctly above.
 		diags = diags.Append(tfdiags.Sourceless(
@@ -490,9 +500,22 @@ func writeStateV4(file *File, w io.Writer, enc encryption.StateEncryption) tfdia
 		))
 		return diags
 	}
-	src = append(src, '\n')
+	buf.WriteByte('\n')
 
-	encrypted, encDiags := enc.EncryptState(src)
+	payload := buf.Bytes()
+	if opts.compact {
+		payload, err = compact(sV4, payload)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to serialize state",
+				fmt.Sprintf("An error occurred while encoding the state in the compact format: %s.", err),
+			))
+			return diags
+		}
+	}
+
+	encrypted, encDiags := enc.EncryptState(payload)
 	diags = diags.Append(encDiags)
 
 	_, err = w.Write(encrypted)
@@ -676,6 +699,47 @@ func encodeCheckResultsV4(in *states.CheckResults) []checkResultsV4 {
 	return ret
 }
 
+func decodeProviderVersionsV4(in map[string]string) (map[addrs.Provider]getproviders.Version, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	ret := make(map[addrs.Provider]getproviders.Version, len(in))
+	for addrStr, versionStr := range in {
+		addr, addrDiags := addrs.ParseProviderSourceString(addrStr)
+		if addrDiags.HasErrors() {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider address in state",
+				fmt.Sprintf("State records the version of provider %q, which is not a valid provider address.", addrStr),
+			))
+			continue
+		}
+		version, err := getproviders.ParseVersion(versionStr)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider version in state",
+				fmt.Sprintf("State records version %q of provider %s, which is not a valid version number: %s.", versionStr, addr, err),
+			))
+			continue
+		}
+		ret[addr] = version
+	}
+
+	return ret, diags
+}
+
+func encodeProviderVersionsV4(in map[addrs.Provider]getproviders.Version) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+
+	ret := make(map[string]string, len(in))
+	for addr, version := range in {
+		ret[addr.String()] = version.String()
+	}
+	return ret
+}
+
 func decodeCheckStatusV4(in string) checks.Status {
 	switch in {
 	case "pass":
@@ -748,6 +812,11 @@ type stateV4 struct {
 	RootOutputs      map[string]outputStateV4 `json:"outputs"`
 	Resources        []resourceStateV4        `json:"resources"`
 	CheckResults     []checkResultsV4         `json:"check_results"`
+
+	// ProviderVersions is omitted when empty so that states without it stay
+	// unchanged. Earlier versions of OpenTofu ignore it when reading the
+	// state, and so drop it when they next write the state.
+	ProviderVersions map[string]string `json:"provider_versions,omitempty"`
 }
 
 // normalize makes some in-place changes to normalize the way items are
@@ -0,0 +1,306 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statefile
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// parallelEncodeThreshold is the number of resources above which
+// encodeStateV4 encodes resources concurrently. Below this the overhead of
+// coordinating goroutines outweighs any benefit.
+const parallelEncodeThreshold = 256
+
+// encodeStateV4 writes the JSON encoding of the given state to w.
+//
+// The result is byte-for-byte identical to json.Marshal(s), but rather than
+// building the whole document in a single pass it encodes each resource
+// separately, using multiple goroutines for large states, and streams the
+// results to w in order. This significantly reduces the time spent
+// serializing states with many thousands of resources.
+//
+// If cache is not nil then the encoding of any resource instance object that
+// is unchanged since the snapshot previously encoded with the same cache is
+// reused, rather than encoding it again. The cache is then updated to
+// describe the objects in the given state.
+//
+// The fields written here must be kept in sync with the stateV4 type.
+func encodeStateV4(w io.Writer, s *stateV4, cache *encodeCache) error {
+	resources, err := encodeResourcesV4(s.Resources, cache)
+	if err != nil {
+		return err
+	}
+
+	ew := &errWriter{w: w}
+	ew.writeString(`{"version":`)
+	ew.writeJSON(s.Version)
+	ew.writeString(`,"terraform_version":`)
+	ew.writeJSON(s.TerraformVersion)
+	ew.writeString(`,"serial":`)
+	ew.writeJSON(s.Serial)
+	ew.writeString(`,"lineage":`)
+	ew.writeJSON(s.Lineage)
+	ew.writeString(`,"outputs":`)
+	ew.writeJSON(s.RootOutputs)
+	ew.writeString(`,"resources":`)
+	if s.Resources == nil {
+		ew.writeString("null")
+	} else {
+		ew.writeString("[")
+		for i, src := range resources {
+			if i > 0 {
+				ew.writeString(",")
+			}
+			ew.write(src)
+		}
+		ew.writeString("]")
+	}
+	ew.writeString(`,"check_results":`)
+	ew.writeJSON(s.CheckResults)
+	if len(s.ProviderVersions) != 0 {
+		ew.writeString(`,"provider_versions":`)
+		ew.writeJSON(s.ProviderVersions)
+	}
+	ew.writeString("}")
+	return ew.err
+}
+
+// encodeResourcesV4 returns the JSON encoding of each of the given resources,
+// in the same order.
+func encodeResourcesV4(resources []resourceStateV4, cache *encodeCache) ([][]byte, error) {
+	ret := make([][]byte, len(resources))
+	// instances is only populated when we're using a cache, and then
+	// captures the encoding of each instance object so we can update the
+	// cache once all of the resources are encoded.
+	var instances [][][]byte
+	if cache != nil {
+		instances = make([][][]byte, len(resources))
+	}
+	encode := func(i int) error {
+		if cache == nil {
+			src, err := json.Marshal(&resources[i])
+			ret[i] = src
+			return err
+		}
+		src, objSrcs, err := cache.encodeResource(&resources[i])
+		ret[i] = src
+		instances[i] = objSrcs
+		return err
+	}
+
+	if len(resources) < parallelEncodeThreshold {
+		for i := range resources {
+			if err := encode(i); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		workers := runtime.GOMAXPROCS(0)
+		errs := make([]error, workers)
+		var wg sync.WaitGroup
+		for worker := 0; worker < workers; worker++ {
+			wg.Add(1)
+			go func(worker int) {
+				defer wg.Done()
+				for i := worker; i < len(resources); i += workers {
+					if err := encode(i); err != nil {
+						errs[worker] = err
+						return
+					}
+				}
+			}(worker)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if cache != nil {
+		cache.update(resources, instances)
+	}
+	return ret, nil
+}
+
+// encodeCache retains the JSON encoding of each resource instance object in
+// a state snapshot, so that a subsequent snapshot of the same state can reuse
+// the encoding of any object that hasn't changed in the meantime.
+//
+// An encodeCache may be read concurrently by multiple encodeResource calls,
+// but update must not be called concurrently with anything else.
+type encodeCache struct {
+	objects map[instanceCacheKey]cachedInstanceObject
+}
+
+type instanceCacheKey struct {
+	module, mode, typ, name string
+	index                   interface{}
+	deposed                 string
+}
+
+type cachedInstanceObject struct {
+	obj instanceObjectStateV4
+	src []byte
+}
+
+func newInstanceCacheKey(rs *resourceStateV4, obj *instanceObjectStateV4) instanceCacheKey {
+	return instanceCacheKey{
+		module:  rs.Module,
+		mode:    rs.Mode,
+		typ:     rs.Type,
+		name:    rs.Name,
+		index:   obj.IndexKey,
+		deposed: obj.Deposed,
+	}
+}
+
+// encodeResource returns the JSON encoding of the given resource, which is
+// identical to json.Marshal(rs), along with the encoding of each of its
+// instance objects in the same order as rs.Instances.
+func (c *encodeCache) encodeResource(rs *resourceStateV4) ([]byte, [][]byte, error) {
+	objSrcs := make([][]byte, len(rs.Instances))
+	for i := range rs.Instances {
+		obj := &rs.Instances[i]
+		if cached, ok := c.objects[newInstanceCacheKey(rs, obj)]; ok && instanceObjectStateV4Equal(&cached.obj, obj) {
+			objSrcs[i] = cached.src
+			continue
+		}
+		src, err := json.Marshal(obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		objSrcs[i] = src
+	}
+
+	var buf bytes.Buffer
+	ew := &errWriter{w: &buf}
+	ew.writeString("{")
+	if rs.Module != "" {
+		ew.writeString(`"module":`)
+		ew.writeJSON(rs.Module)
+		ew.writeString(",")
+	}
+	ew.writeString(`"mode":`)
+	ew.writeJSON(rs.Mode)
+	ew.writeString(`,"type":`)
+	ew.writeJSON(rs.Type)
+	ew.writeString(`,"name":`)
+	ew.writeJSON(rs.Name)
+	if rs.EachMode != "" {
+		ew.writeString(`,"each":`)
+		ew.writeJSON(rs.EachMode)
+	}
+	if rs.ProviderConfig != "" {
+		ew.writeString(`,"provider":`)
+		ew.writeJSON(rs.ProviderConfig)
+	}
+	ew.writeString(`,"instances":`)
+	if rs.Instances == nil {
+		ew.writeString("null")
+	} else {
+		ew.writeString("[")
+		ew.write(bytes.Join(objSrcs, []byte(",")))
+		ew.writeString("]")
+	}
+	ew.writeString("}")
+	return buf.Bytes(), objSrcs, ew.err
+}
+
+// update replaces the content of the cache with the given resources and the
+// encodings of their instance objects, as returned by encodeResource.
+//
+// Objects that are not present in the given resources are discarded, so the
+// cache never grows larger than the most recent snapshot.
+func (c *encodeCache) update(resources []resourceStateV4, objSrcs [][][]byte) {
+	objects := make(map[instanceCacheKey]cachedInstanceObject, len(c.objects))
+	for i := range resources {
+		rs := &resources[i]
+		for j := range rs.Instances {
+			obj := &rs.Instances[j]
+			objects[newInstanceCacheKey(rs, obj)] = cachedInstanceObject{
+				obj: *obj,
+				src: objSrcs[i][j],
+			}
+		}
+	}
+	c.objects = objects
+}
+
+// instanceObjectStateV4Equal returns true if the two given objects would
+// have identical JSON encodings. All of the slice and map fields are
+// "omitempty", so a nil value encodes the same as an empty one.
+//
+// This is considerably cheaper than encoding the objects, because it doesn't
+// need to validate and compact the raw JSON attribute values.
+func instanceObjectStateV4Equal(a, b *instanceObjectStateV4) bool {
+	if a.IndexKey != b.IndexKey ||
+		a.Status != b.Status ||
+		a.Deposed != b.Deposed ||
+		a.ProviderConfig != b.ProviderConfig ||
+		a.SchemaVersion != b.SchemaVersion ||
+		a.CreateBeforeDestroy != b.CreateBeforeDestroy {
+		return false
+	}
+	if !bytes.Equal(a.AttributesRaw, b.AttributesRaw) ||
+		!bytes.Equal(a.AttributeSensitivePaths, b.AttributeSensitivePaths) ||
+		!bytes.Equal(a.PrivateRaw, b.PrivateRaw) {
+		return false
+	}
+	if len(a.AttributesFlat) != len(b.AttributesFlat) {
+		return false
+	}
+	for k, av := range a.AttributesFlat {
+		if bv, ok := b.AttributesFlat[k]; !ok || av != bv {
+			return false
+		}
+	}
+	if len(a.Dependencies) != len(b.Dependencies) {
+		return false
+	}
+	for i := range a.Dependencies {
+		if a.Dependencies[i] != b.Dependencies[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// errWriter is a helper for writing a sequence of values, retaining only the
+// first error encountered.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) write(p []byte) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = ew.w.Write(p)
+}
+
+func (ew *errWriter) writeString(s string) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = io.WriteString(ew.w, s)
+}
+
+func (ew *errWriter) writeJSON(v interface{}) {
+	if ew.err != nil {
+		return
+	}
+	src, err := json.Marshal(v)
+	if err != nil {
+		ew.err = err
+		return
+	}
+	ew.write(src)
+}
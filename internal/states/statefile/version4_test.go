@@ -6,6 +6,9 @@
 package statefile
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -261,3 +264,81 @@ func TestVersion4_marshalPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion4_encodeMatchesMarshal(t *testing.T) {
+	for _, count := range []int{0, 1, parallelEncodeThreshold + 1} {
+		t.Run(fmt.Sprintf("%d resources", count), func(t *testing.T) {
+			s := &stateV4{
+				Version:          stateVersionV4{},
+				TerraformVersion: "1.10.0",
+				Serial:           3,
+				Lineage:          "lineage<&>",
+				RootOutputs: map[string]outputStateV4{
+					"foo": {ValueRaw: []byte(`"bar"`), ValueTypeRaw: []byte(`"string"`)},
+				},
+				Resources: []resourceStateV4{},
+				ProviderVersions: map[string]string{
+					"registry.opentofu.org/hashicorp/test": "1.2.3",
+				},
+			}
+			for i := 0; i < count; i++ {
+				s.Resources = append(s.Resources, resourceStateV4{
+					Mode:           "managed",
+					Type:           "test_instance",
+					Name:           fmt.Sprintf("foo%d", i),
+					ProviderConfig: `provider["registry.opentofu.org/hashicorp/test"]`,
+					Instances: []instanceObjectStateV4{
+						{
+							SchemaVersion: 1,
+							AttributesRaw: []byte(`{"id":"<html>"}`),
+						},
+					},
+				})
+			}
+
+			want, err := json.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bytes.Buffer
+			if err := encodeStateV4(&got, s, nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got.Bytes(), want)
+			}
+
+			// Encoding with a cache must produce the same result, both
+			// when the cache is empty and when it's fully populated.
+			cache := &encodeCache{}
+			for i := 0; i < 2; i++ {
+				got.Reset()
+				if err := encodeStateV4(&got, s, cache); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !bytes.Equal(got.Bytes(), want) {
+					t.Errorf("wrong result with cache (pass %d)\ngot:  %s\nwant: %s", i, got.Bytes(), want)
+				}
+			}
+
+			// Changing an object must invalidate only that object's cache
+			// entry.
+			if count > 0 {
+				s.Resources[0].Instances[0].AttributesRaw = []byte(`{"id":"changed"}`)
+				s.Resources[0].EachMode = "list"
+				s.Resources[0].Module = "module.child"
+				want, err := json.Marshal(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got.Reset()
+				if err := encodeStateV4(&got, s, cache); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !bytes.Equal(got.Bytes(), want) {
+					t.Errorf("wrong result after change\ngot:  %s\nwant: %s", got.Bytes(), want)
+				}
+			}
+		})
+	}
+}
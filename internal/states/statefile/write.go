@@ -18,7 +18,22 @@ func Write(s *File, w io.Writer, enc encryption.StateEncryption) error {
 	// Always record the current tofu version in the state.
 	s.TerraformVersion = tfversion.SemVer
 
-	diags := writeStateV4(s, w, enc)
+	diags := writeStateV4(s, w, enc, writeOptions{})
+	return diags.Err()
+}
+
+// WriteCompact is like Write, but uses the compact state serialization
+// format, which is considerably smaller for large states. Read accepts
+// both formats.
+//
+// The compact format is intended only for state snapshots that OpenTofu
+// itself will read back later. Snapshots intended for consumption by other
+// software should always use Write.
+func WriteCompact(s *File, w io.Writer, enc encryption.StateEncryption) error {
+	// Always record the current tofu version in the state.
+	s.TerraformVersion = tfversion.SemVer
+
+	diags := writeStateV4(s, w, enc, writeOptions{compact: true})
 	return diags.Err()
 }
 
@@ -27,6 +42,16 @@ func Write(s *File, w io.Writer, enc encryption.StateEncryption) error {
 // intended for use in tests that need to override the current tofu
 // version.
 func WriteForTest(s *File, w io.Writer) error {
-	diags := writeStateV4(s, w, encryption.StateEncryptionDisabled())
+	diags := writeStateV4(s, w, encryption.StateEncryptionDisabled(), writeOptions{})
 	return diags.Err()
 }
+
+// writeOptions customizes how a state snapshot is serialized.
+type writeOptions struct {
+	// compact selects the compact state format.
+	compact bool
+
+	// cache, if not nil, allows reusing the encoding of resource instance
+	// objects from the snapshot most recently written with the same cache.
+	cache *encodeCache
+}
@@ -63,6 +63,14 @@ type Filesystem struct {
 	writtenBackup  bool
 
 	encryption encryption.StateEncryption
+
+	// compact is set if state snapshots should be written in the compact
+	// format, as requested by statefile.CompactFormatRequested.
+	compact bool
+
+	// encoder writes the snapshots persisted at path, re-serializing only
+	// the parts of the state that changed since the previous snapshot.
+	encoder *statefile.Encoder
 }
 
 var (
@@ -81,6 +89,8 @@ func NewFilesystem(statePath string, enc encryption.StateEncryption) *Filesystem
 		path:       statePath,
 		readPath:   statePath,
 		encryption: enc,
+		compact:    statefile.CompactFormatRequested(),
+		encoder:    statefile.NewEncoder(statefile.CompactFormatRequested()),
 	}
 }
 
@@ -92,7 +102,18 @@ func NewFilesystemBetweenPaths(readPath, writePath string, enc encryption.StateE
 		path:       writePath,
 		readPath:   readPath,
 		encryption: enc,
+		compact:    statefile.CompactFormatRequested(),
+		encoder:    statefile.NewEncoder(statefile.CompactFormatRequested()),
+	}
+}
+
+// writeFile writes the given state snapshot to w in the format selected
+// when the receiver was created.
+func (s *Filesystem) writeFile(f *statefile.File, w io.Writer) error {
+	if s.compact {
+		return statefile.WriteCompact(f, w, s.encryption)
 	}
+	return statefile.Write(f, w, s.encryption)
 }
 
 // SetBackupPath configures the receiver so that it will create a local
@@ -190,7 +211,7 @@ func (s *Filesystem) persistState(schemas *tofu.Schemas) error {
 			}
 			defer bfh.Close()
 
-			err = statefile.Write(s.backupFile, bfh, s.encryption)
+			err = s.writeFile(s.backupFile, bfh)
 			if err != nil {
 				return fmt.Errorf("failed to write to local state backup file: %w", err)
 			}
@@ -234,7 +255,7 @@ func (s *Filesystem) persistState(schemas *tofu.Schemas) error {
 	}
 
 	log.Printf("[TRACE] statemgr.Filesystem: writing snapshot at %s", s.path)
-	if err := statefile.Write(s.file, s.stateFileOut, s.encryption); err != nil {
+	if err := s.encoder.Write(s.file, s.stateFileOut, s.encryption); err != nil {
 		return err
 	}
 
@@ -79,15 +79,48 @@ type OptionalLocker interface {
 // test hook to verify that LockWithContext has attempted a lock
 var postLockHook func()
 
+// LockWait describes a failed attempt to acquire a lock that is held by
+// another process, as reported by LockWithContextAndWait before it waits to
+// try again.
+type LockWait struct {
+	// Attempt counts the failed attempts so far, starting at 1.
+	Attempt int
+
+	// Holder describes the lock that is currently held, if known.
+	Holder *LockInfo
+
+	// QueuePosition is the position of this lock request in the queue of
+	// waiting requests, or zero if the state manager doesn't queue lock
+	// requests.
+	QueuePosition int
+
+	// Delay is how long we'll wait before the next attempt.
+	Delay time.Duration
+
+	// Remaining is how long we'll keep trying before giving up, or zero if
+	// there is no deadline.
+	Remaining time.Duration
+}
+
 // LockWithContext locks the given state manager using the provided context
 // for both timeout and cancellation.
 //
 // This method has a built-in retry/backoff behavior up to the context's
 // timeout.
 func LockWithContext(ctx context.Context, s Locker, info *LockInfo) (string, error) {
+	return LockWithContextAndWait(ctx, s, info, nil)
+}
+
+// LockWithContextAndWait is like LockWithContext, but also calls the given
+// function, if non-nil, each time it must wait for a lock held by another
+// process.
+//
+// The delay between attempts grows exponentially, with some random jitter so
+// that many processes waiting for the same lock don't all retry at once.
+func LockWithContextAndWait(ctx context.Context, s Locker, info *LockInfo, waiting func(LockWait)) (string, error) {
 	delay := time.Second
 	maxDelay := 16 * time.Second
-	for {
+	for attempt := 1; ; attempt++ {
 		id, err := s.Lock(info)
 		if err == nil {
 			return id, nil
@@ -112,12 +145,29 @@ func LockWithContext(ctx context.Context, s Locker, info *LockInfo) (string, err
 			continue
 		}
 
-		// there's an existing lock, wait and try again
+		// there's an existing lock, wait and try again, unless we've
+		// already run out of time
+		if ctx.Err() != nil {
+			return "", err
+		}
+		wait := delay + time.Duration(rand.Int63n(int64(delay/4)+1))
+		if waiting != nil {
+			lw := LockWait{
+				Attempt:       attempt,
+				Holder:        le.Info,
+				QueuePosition: le.QueuePosition,
+				Delay:         wait,
+			}
+			if deadline, ok := ctx.Deadline(); ok {
+				lw.Remaining = max(time.Until(deadline), 0)
+			}
+			waiting(lw)
+		}
 		select {
 		case <-ctx.Done():
 			// return the last lock error with the info
 			return "", err
-		case <-time.After(delay):
+		case <-time.After(wait):
 			if delay < maxDelay {
 				delay *= 2
 			}
@@ -231,6 +281,11 @@ type LockError struct {
 	// Set when writing of lock file fails because of conflict and
 	// then reading fails because file doesn't exist (removed by other process)
 	InconsistentRead bool
+
+	// QueuePosition can be set by state managers that queue lock requests
+	// to report the position of this request in the queue, starting at 1.
+	// It is zero otherwise.
+	QueuePosition int
 }
 
 func (e *LockError) Error() string {
@@ -0,0 +1,117 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statemgr
+
+import (
+	"errors"
+
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// ErrReadOnly is returned by a ReadOnly state manager for any operation that
+// would write the state or acquire a lock on it.
+var ErrReadOnly = errors.New("state is read-only: OpenTofu is running in read-only mode")
+
+// ReadOnly implements Full but refuses to write or persist new state
+// snapshots and to acquire or release locks, while still allowing the
+// current state to be read and refreshed from the inner state manager.
+type ReadOnly struct {
+	// We can't embed State directly since Go dislikes that a field is
+	// State and State interface has a method State
+	Inner Full
+}
+
+var _ Full = (*ReadOnly)(nil)
+
+// NewReadOnly wraps the given state manager so that it can't be used to
+// modify the state. If the given manager is already read-only then it is
+// returned as-is.
+//
+// The result also implements PersistentMeta and Migrator if the given
+// manager does, so that callers can still inspect the snapshot metadata and
+// read the state for migration. A plan created from a read-only state
+// manager therefore records the same snapshot metadata as any other plan.
+func NewReadOnly(inner Full) Full {
+	switch inner.(type) {
+	case *ReadOnly, *readOnlyMeta, *readOnlyMigrator:
+		return inner
+	}
+
+	ro := &ReadOnly{Inner: inner}
+	if m, ok := inner.(Migrator); ok {
+		return &readOnlyMigrator{readOnlyMeta: &readOnlyMeta{ReadOnly: ro, meta: m}, migrator: m}
+	}
+	if m, ok := inner.(PersistentMeta); ok {
+		return &readOnlyMeta{ReadOnly: ro, meta: m}
+	}
+	return ro
+}
+
+// Unwrap returns the state manager that the read-only state manager reads
+// from, so that callers can consult its optional interfaces that don't
+// write the state.
+func (s *ReadOnly) Unwrap() Full {
+	return s.Inner
+}
+
+func (s *ReadOnly) State() *states.State {
+	return s.Inner.State()
+}
+
+func (s *ReadOnly) GetRootOutputValues() (map[string]*states.OutputValue, error) {
+	return s.Inner.GetRootOutputValues()
+}
+
+func (s *ReadOnly) RefreshState() error {
+	return s.Inner.RefreshState()
+}
+
+func (s *ReadOnly) WriteState(v *states.State) error {
+	return ErrReadOnly
+}
+
+func (s *ReadOnly) PersistState(schemas *tofu.Schemas) error {
+	return ErrReadOnly
+}
+
+func (s *ReadOnly) Lock(info *LockInfo) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (s *ReadOnly) Unlock(id string) error {
+	return ErrReadOnly
+}
+
+// readOnlyMeta is a ReadOnly for a state manager that implements
+// PersistentMeta.
+type readOnlyMeta struct {
+	*ReadOnly
+	meta PersistentMeta
+}
+
+var _ PersistentMeta = (*readOnlyMeta)(nil)
+
+func (s *readOnlyMeta) StateSnapshotMeta() SnapshotMeta {
+	return s.meta.StateSnapshotMeta()
+}
+
+// readOnlyMigrator is a ReadOnly for a state manager that implements
+// Migrator, which can be used as the source of a migration but not as its
+// destination.
+type readOnlyMigrator struct {
+	*readOnlyMeta
+	migrator Migrator
+}
+
+var _ Migrator = (*readOnlyMigrator)(nil)
+
+func (s *readOnlyMigrator) StateForMigration() *statefile.File {
+	return s.migrator.StateForMigration()
+}
+
+func (s *readOnlyMigrator) WriteStateForMigration(f *statefile.File, force bool) error {
+	return ErrReadOnly
+}
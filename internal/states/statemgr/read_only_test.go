@@ -0,0 +1,84 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package statemgr
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestReadOnly(t *testing.T) {
+	initial := TestFullInitialState()
+	inner := NewFullFake(nil, initial)
+	mgr := NewReadOnly(inner)
+
+	if err := mgr.RefreshState(); err != nil {
+		t.Fatalf("unexpected error refreshing state: %s", err)
+	}
+	if got := mgr.State(); !got.Equal(initial) {
+		t.Fatalf("wrong state\ngot:  %s\nwant: %s", got, initial)
+	}
+
+	if err := mgr.WriteState(states.NewState()); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("WriteState returned %v; want ErrReadOnly", err)
+	}
+	if err := mgr.PersistState(nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("PersistState returned %v; want ErrReadOnly", err)
+	}
+	if _, err := mgr.Lock(NewLockInfo()); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Lock returned %v; want ErrReadOnly", err)
+	}
+	if err := mgr.Unlock("foo"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Unlock returned %v; want ErrReadOnly", err)
+	}
+
+	if got := inner.State(); !got.Equal(initial) {
+		t.Fatalf("inner state was modified\ngot:  %s\nwant: %s", got, initial)
+	}
+
+	if NewReadOnly(mgr) != mgr {
+		t.Errorf("wrapping a read-only state manager again should return it unchanged")
+	}
+}
+
+func TestReadOnly_optionalInterfaces(t *testing.T) {
+	inner := NewFilesystem(filepath.Join(t.TempDir(), "terraform.tfstate"), encryption.StateEncryptionDisabled())
+	if err := WriteAndPersist(inner, TestFullInitialState(), nil); err != nil {
+		t.Fatal(err)
+	}
+	mgr := NewReadOnly(inner)
+
+	meta, ok := mgr.(PersistentMeta)
+	if !ok {
+		t.Fatalf("read-only state manager does not implement PersistentMeta")
+	}
+	if got, want := meta.StateSnapshotMeta(), inner.StateSnapshotMeta(); got.Lineage != want.Lineage || got.Serial != want.Serial {
+		t.Errorf("wrong snapshot metadata\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	migrator, ok := mgr.(Migrator)
+	if !ok {
+		t.Fatalf("read-only state manager does not implement Migrator")
+	}
+	f := migrator.StateForMigration()
+	if f.Lineage != inner.StateSnapshotMeta().Lineage {
+		t.Errorf("wrong lineage %q for migration", f.Lineage)
+	}
+	if err := migrator.WriteStateForMigration(f, true); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("WriteStateForMigration returned %v; want ErrReadOnly", err)
+	}
+
+	if NewReadOnly(mgr) != mgr {
+		t.Errorf("wrapping a read-only state manager again should return it unchanged")
+	}
+
+	// A state manager without the optional interfaces doesn't gain them.
+	if _, ok := NewReadOnly(NewFullFake(nil, nil)).(PersistentMeta); ok {
+		t.Errorf("read-only fake state manager implements PersistentMeta")
+	}
+}
@@ -94,6 +94,49 @@ func TestLockWithContext(t *testing.T) {
 	}
 }
 
+func TestLockWithContextAndWait(t *testing.T) {
+	s := NewFullFake(nil, TestFullInitialState())
+
+	id, err := s.Lock(NewLockInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// unlock the state while LockWithContextAndWait is waiting to retry
+	var waits []LockWait
+	info := NewLockInfo()
+	info.Info = "lock with wait"
+	_, err = LockWithContextAndWait(ctx, s, info, func(wait LockWait) {
+		waits = append(waits, wait)
+		if err := s.Unlock(id); err != nil {
+			t.Error(err)
+		}
+	})
+	if err != nil {
+		t.Fatal("lock should have completed within 5s:", err)
+	}
+
+	if len(waits) != 1 {
+		t.Fatalf("expected one wait, got %d", len(waits))
+	}
+	wait := waits[0]
+	if wait.Attempt != 1 {
+		t.Errorf("wrong attempt %d", wait.Attempt)
+	}
+	if wait.Holder == nil || wait.Holder.Info != "lock with wait" {
+		t.Errorf("wrong holder %#v", wait.Holder)
+	}
+	if wait.Delay < time.Second || wait.Delay > 1250*time.Millisecond {
+		t.Errorf("delay %s is not within the expected jitter", wait.Delay)
+	}
+	if wait.Remaining <= 0 || wait.Remaining > 5*time.Second {
+		t.Errorf("wrong remaining time %s", wait.Remaining)
+	}
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 	os.Exit(m.Run())
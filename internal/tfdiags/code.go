@@ -0,0 +1,125 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tfdiags
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Code is a stable, machine-readable identifier for a class of diagnostics,
+// such as "TOFU1001".
+//
+// Unlike the summary and detail of a diagnostic, which we may reword at any
+// time, a code keeps its meaning once it has been assigned, so that external
+// software such as CI systems can reliably recognize particular kinds of
+// problem. All of the codes are declared in codes.go.
+//
+// A Code is also a valid "extra information" value, so a diagnostic
+// constructed as an hcl.Diagnostic can be given a code by assigning it to
+// the Extra field. Use WithCode to give a code to any other diagnostic.
+type Code string
+
+var _ DiagnosticExtraCode = Code("")
+
+// DiagnosticCode implements DiagnosticExtraCode.
+func (c Code) DiagnosticCode() Code {
+	return c
+}
+
+// DocsURL returns the URL of the documentation about the class of diagnostics
+// identified by the code, or an empty string if there is no such
+// documentation.
+func (c Code) DocsURL() string {
+	return codeDocsURLs[c]
+}
+
+// DiagnosticExtraCode is an interface implemented by values in the Extra
+// field of Diagnostic when the diagnostic belongs to a class of diagnostics
+// that has a stable code.
+type DiagnosticExtraCode interface {
+	// DiagnosticCode returns the code of the class of diagnostics that the
+	// associated diagnostic belongs to.
+	DiagnosticCode() Code
+}
+
+// DiagnosticCode returns the stable code of the class of diagnostics that
+// the given diagnostic belongs to, or an empty Code if it doesn't have one.
+//
+// This is a wrapper around checking if the diagnostic's extra info implements
+// interface DiagnosticExtraCode and then calling its method if so.
+func DiagnosticCode(diag Diagnostic) Code {
+	maybe := ExtraInfo[DiagnosticExtraCode](diag)
+	if maybe == nil {
+		return ""
+	}
+	return maybe.DiagnosticCode()
+}
+
+// WithCode returns a diagnostic that is the same as the given diagnostic
+// except that it has the given stable code.
+func WithCode(original Diagnostic, code Code) Diagnostic {
+	return codedDiagnostic{
+		original: original,
+		extra: &codeExtra{
+			code:  code,
+			inner: original.ExtraInfo(),
+		},
+	}
+}
+
+// codedDiagnostic implements the Diagnostic interface by wrapping another
+// Diagnostic while adding a stable code to its extra information.
+type codedDiagnostic struct {
+	original Diagnostic
+	extra    *codeExtra
+}
+
+var _ Diagnostic = codedDiagnostic{}
+
+func (d codedDiagnostic) Severity() Severity {
+	return d.original.Severity()
+}
+
+func (d codedDiagnostic) Description() Description {
+	return d.original.Description()
+}
+
+func (d codedDiagnostic) Source() Source {
+	return d.original.Source()
+}
+
+func (d codedDiagnostic) FromExpr() *FromExpr {
+	return d.original.FromExpr()
+}
+
+func (d codedDiagnostic) ExtraInfo() interface{} {
+	return d.extra
+}
+
+// ElaborateFromConfigBody allows a contextual diagnostic to still find its
+// source location in configuration after it has been given a code.
+func (d codedDiagnostic) ElaborateFromConfigBody(body hcl.Body, addr string) Diagnostic {
+	if contextual, ok := d.original.(contextualFromConfigBody); ok {
+		d.original = contextual.ElaborateFromConfigBody(body, addr)
+	}
+	return d
+}
+
+// codeExtra is the extra information of a diagnostic returned by WithCode,
+// which wraps the extra information of the original diagnostic.
+type codeExtra struct {
+	code  Code
+	inner interface{}
+}
+
+var _ DiagnosticExtraCode = (*codeExtra)(nil)
+var _ DiagnosticExtraUnwrapper = (*codeExtra)(nil)
+
+func (e *codeExtra) DiagnosticCode() Code {
+	return e.code
+}
+
+func (e *codeExtra) UnwrapDiagnosticExtra() interface{} {
+	return e.inner
+}
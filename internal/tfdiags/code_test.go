@@ -0,0 +1,124 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tfdiags
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDiagnosticCode(t *testing.T) {
+	tests := map[string]struct {
+		diag Diagnostic
+		want Code
+	}{
+		"no code": {
+			Sourceless(Error, "summary", "detail"),
+			"",
+		},
+		"with code": {
+			WithCode(Sourceless(Error, "summary", "detail"), CodeResourceTargeting),
+			CodeResourceTargeting,
+		},
+		"hcl extra": {
+			hclDiagnostic{&hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "summary",
+				Extra:    CodeDeprecatedProviderVersion,
+			}},
+			CodeDeprecatedProviderVersion,
+		},
+		"overridden": {
+			Override(WithCode(Sourceless(Error, "summary", "detail"), CodeResourceTargeting), Warning, nil),
+			CodeResourceTargeting,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := DiagnosticCode(test.diag); got != test.want {
+				t.Errorf("wrong code %q; want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWithCode_keepsOriginal(t *testing.T) {
+	original := hclDiagnostic{&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "summary",
+		Detail:   "detail",
+		Subject:  &hcl.Range{Filename: "main.tf"},
+		Extra:    "extra",
+	}}
+	diag := WithCode(original, CodeResourceTargeting)
+
+	if got, want := diag.Severity(), Error; got != want {
+		t.Errorf("wrong severity %s; want %s", got, want)
+	}
+	if got, want := diag.Description(), original.Description(); !got.Equal(want) {
+		t.Errorf("wrong description %#v; want %#v", got, want)
+	}
+	if got, want := diag.Source(), original.Source(); !got.Equal(want) {
+		t.Errorf("wrong source %#v; want %#v", got, want)
+	}
+	if got := ExtraInfo[string](diag); got != "extra" {
+		t.Errorf("wrong wrapped extra info %q", got)
+	}
+}
+
+func TestWithCode_inConfigBody(t *testing.T) {
+	f, parseDiags := hclsyntax.ParseConfig([]byte("foo = 1\n"), "main.tf", hcl.InitialPos)
+	if parseDiags.HasErrors() {
+		t.Fatal(parseDiags.Error())
+	}
+
+	var diags Diagnostics
+	diags = diags.Append(WithCode(
+		AttributeValue(Error, "summary", "detail", cty.GetAttrPath("foo")),
+		CodeResourceTargeting,
+	))
+	diags = diags.InConfigBody(f.Body, "")
+
+	if got := diags[0].Source().Subject; got == nil || got.Filename != "main.tf" {
+		t.Errorf("diagnostic wasn't elaborated, subject is %#v", got)
+	}
+	if got := DiagnosticCode(diags[0]); got != CodeResourceTargeting {
+		t.Errorf("wrong code %q after elaboration", got)
+	}
+}
+
+func TestWithCode_forRPC(t *testing.T) {
+	var diags Diagnostics
+	diags = diags.Append(WithCode(Sourceless(Error, "summary", "detail"), CodeProviderOlderThanState))
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(diags.ForRPC()); err != nil {
+		t.Fatal(err)
+	}
+	var got Diagnostics
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := DiagnosticCode(got[0]); code != CodeProviderOlderThanState {
+		t.Errorf("wrong code %q after RPC", code)
+	}
+}
+
+func TestCodeDocsURL(t *testing.T) {
+	for code, url := range codeDocsURLs {
+		if got := code.DocsURL(); got != url {
+			t.Errorf("wrong URL for %s: %q", code, got)
+		}
+	}
+	if got := Code("TOFU0000").DocsURL(); got != "" {
+		t.Errorf("unknown code has URL %q", got)
+	}
+}
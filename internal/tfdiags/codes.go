@@ -0,0 +1,57 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tfdiags
+
+// These are all of the stable diagnostic codes. A code must never be removed
+// or reused for a different class of diagnostics once it has been released,
+// because external software may depend on it. Add new codes at the end of the
+// list, using the next unused number.
+//
+// The codes are listed in the documentation of the JSON output of the
+// validate command, which must be updated whenever a code is added.
+const (
+	// CodeResourceTargeting is for the warning that a plan was created with
+	// the -target or -exclude option.
+	CodeResourceTargeting Code = "TOFU1001"
+
+	// CodeIncompleteTargetedApply is for the warning that an apply of a plan
+	// created with the -target or -exclude option may be incomplete.
+	CodeIncompleteTargetedApply Code = "TOFU1002"
+
+	// CodeUndeclaredVariableInFile is for the warnings about a variable
+	// definitions file setting a variable that the root module doesn't
+	// declare.
+	CodeUndeclaredVariableInFile Code = "TOFU1003"
+
+	// CodeUndeclaredVariable is for the error about a value being assigned,
+	// other than in a variable definitions file, to a variable that the root
+	// module doesn't declare.
+	CodeUndeclaredVariable Code = "TOFU1004"
+
+	// CodeDeprecatedProviderVersion is for the warning about a version
+	// constraint in a provider configuration block.
+	CodeDeprecatedProviderVersion Code = "TOFU1005"
+
+	// CodeProviderCapabilityNotSupported is for the error about a selected
+	// provider version not supporting a capability that the configuration
+	// requires.
+	CodeProviderCapabilityNotSupported Code = "TOFU1006"
+
+	// CodeProviderOlderThanState is for the error about a selected provider
+	// version being older than the one that saved some resource instances in
+	// the state.
+	CodeProviderOlderThanState Code = "TOFU1007"
+)
+
+// codeDocsURLs are the URLs of the documentation about each class of
+// diagnostics, for the codes that have such documentation.
+var codeDocsURLs = map[Code]string{
+	CodeResourceTargeting:              "https://opentofu.org/docs/cli/commands/plan/#resource-targeting",
+	CodeIncompleteTargetedApply:        "https://opentofu.org/docs/cli/commands/plan/#resource-targeting",
+	CodeUndeclaredVariableInFile:       "https://opentofu.org/docs/language/values/variables/#values-for-undeclared-variables",
+	CodeUndeclaredVariable:             "https://opentofu.org/docs/language/values/variables/#values-for-undeclared-variables",
+	CodeDeprecatedProviderVersion:      "https://opentofu.org/docs/language/providers/requirements/#version-constraints",
+	CodeProviderCapabilityNotSupported: "https://opentofu.org/docs/language/providers/requirements/#provider-capabilities",
+	CodeProviderOlderThanState:         "https://opentofu.org/docs/language/files/dependency-lock/#provider-downgrades",
+}
@@ -15,6 +15,7 @@ type rpcFriendlyDiag struct {
 	Detail_   string
 	Subject_  *SourceRange
 	Context_  *SourceRange
+	Code_     Code
 }
 
 // rpcFriendlyDiag transforms a given diagnostic so that is more friendly to
@@ -32,6 +33,7 @@ func makeRPCFriendlyDiag(diag Diagnostic) Diagnostic {
 		Detail_:   desc.Detail,
 		Subject_:  source.Subject,
 		Context_:  source.Context,
+		Code_:     DiagnosticCode(diag),
 	}
 }
 
@@ -60,7 +62,11 @@ func (d rpcFriendlyDiag) FromExpr() *FromExpr {
 }
 
 func (d rpcFriendlyDiag) ExtraInfo() interface{} {
-	// RPC-friendly diagnostics always discard any "extra information".
+	// RPC-friendly diagnostics discard any "extra information" except for
+	// the diagnostic code, which is just a string.
+	if d.Code_ != "" {
+		return d.Code_
+	}
 	return nil
 }
 
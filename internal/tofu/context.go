@@ -440,5 +440,11 @@ func (c *Context) checkConfigDependencies(config *configs.Config) tfdiags.Diagno
 		}
 	})
 
+	// Provider capabilities can only be checked once we know that all of
+	// the required providers are available.
+	if !diags.HasErrors() {
+		diags = diags.Append(c.CheckProviderCapabilities(config))
+	}
+
 	return diags
 }
@@ -95,14 +95,14 @@ func (c *Context) Apply(ctx context.Context, plan *plans.Plan, config *configs.C
 	}
 
 	if len(plan.TargetAddrs) > 0 || len(plan.ExcludeAddrs) > 0 {
-		diags = diags.Append(tfdiags.Sourceless(
+		diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(
 			tfdiags.Warning,
 			"Applied changes may be incomplete",
 			`The plan was created with the -target or the -exclude option in effect, so some changes requested in the configuration may have been ignored and the output values may not be fully updated. Run the following command to verify that no other changes are pending:
     tofu plan
 	
 Note that the -target and -exclude options are not suitable for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-		))
+		), tfdiags.CodeIncompleteTargetedApply))
 	}
 
 	// FIXME: we cannot check for an empty plan for refresh-only, because root
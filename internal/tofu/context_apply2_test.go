@@ -1312,6 +1312,116 @@ func TestContext2Apply_resourceConditionApplyTimeFail(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_resourceConditionRetry(t *testing.T) {
+	// A postcondition with retry settings is evaluated again against a
+	// refreshed object until it passes or runs out of attempts.
+	tests := map[string]struct {
+		attempts  int
+		readyRead int
+		wantErr   bool
+		wantReads int
+	}{
+		"passes after refresh": {
+			attempts:  3,
+			readyRead: 2,
+			wantReads: 2,
+		},
+		"runs out of attempts": {
+			attempts:  2,
+			readyRead: 5,
+			wantErr:   true,
+			wantReads: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := testModuleInline(t, map[string]string{
+				"main.tf": fmt.Sprintf(`
+					resource "test_resource" "a" {
+						value = "beep"
+
+						lifecycle {
+							postcondition {
+								condition     = self.output == "ready"
+								error_message = "Output must be ready."
+
+								retry {
+									attempts = %d
+									interval = "0s"
+								}
+							}
+						}
+					}
+				`, test.attempts),
+			})
+
+			p := testProvider("test")
+			p.GetProviderSchemaResponse = getProviderSchemaResponseFromProviderSchema(&ProviderSchema{
+				ResourceTypes: map[string]*configschema.Block{
+					"test_resource": {
+						Attributes: map[string]*configschema.Attribute{
+							"value": {
+								Type:     cty.String,
+								Required: true,
+							},
+							"output": {
+								Type:     cty.String,
+								Computed: true,
+							},
+						},
+					},
+				},
+			})
+			p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) (resp providers.PlanResourceChangeResponse) {
+				m := req.ProposedNewState.AsValueMap()
+				m["output"] = cty.UnknownVal(cty.String)
+				resp.PlannedState = cty.ObjectVal(m)
+				return resp
+			}
+			p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) (resp providers.ApplyResourceChangeResponse) {
+				m := req.PlannedState.AsValueMap()
+				m["output"] = cty.StringVal("pending")
+				resp.NewState = cty.ObjectVal(m)
+				return resp
+			}
+			reads := 0
+			p.ReadResourceFn = func(req providers.ReadResourceRequest) (resp providers.ReadResourceResponse) {
+				reads++
+				m := req.PriorState.AsValueMap()
+				if reads >= test.readyRead {
+					m["output"] = cty.StringVal("ready")
+				}
+				resp.NewState = cty.ObjectVal(m)
+				return resp
+			}
+			ctx := testContext2(t, &ContextOpts{
+				Providers: map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				},
+			})
+
+			plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+			assertNoErrors(t, diags)
+
+			_, diags = ctx.Apply(context.Background(), plan, m)
+			if test.wantErr {
+				if !diags.HasErrors() {
+					t.Fatal("apply succeeded, but should've failed with a postcondition error")
+				}
+				if got, want := diags.Err().Error(), "Resource postcondition failed: Output must be ready."; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+			} else {
+				assertNoErrors(t, diags)
+			}
+			if reads != test.wantReads {
+				t.Errorf("wrong number of reads %d; want %d", reads, test.wantReads)
+			}
+		})
+	}
+}
+
 // pass an input through some expanded values, and back to a provider to make
 // sure we can fully evaluate a provider configuration during a destroy plan.
 func TestContext2Apply_destroyWithConfiguredProvider(t *testing.T) {
@@ -4966,3 +5076,59 @@ variable "res_data" {
 		t.Fatalf("Expected: %q, got %q", want, got)
 	}
 }
+
+func TestContext2Apply_planOnlyProvider(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+variable "plan_only" {
+  type = bool
+}
+
+provider "test" {
+  plan_only = var.plan_only
+}
+
+resource "test_object" "a" {
+  test_string = "foo"
+}
+`,
+	})
+
+	for _, planOnly := range []bool{true, false} {
+		t.Run(fmt.Sprintf("plan_only=%t", planOnly), func(t *testing.T) {
+			p := simpleMockProvider()
+			ctx := testContext2(t, &ContextOpts{
+				Providers: map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				},
+			})
+
+			plan, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+				Mode: plans.NormalMode,
+				SetVariables: InputValues{
+					"plan_only": &InputValue{
+						Value:      cty.BoolVal(planOnly),
+						SourceType: ValueFromCLIArg,
+					},
+				},
+			})
+			assertNoErrors(t, diags)
+
+			_, diags = ctx.Apply(context.Background(), plan, m)
+			if !planOnly {
+				assertNoErrors(t, diags)
+				return
+			}
+
+			if !diags.HasErrors() {
+				t.Fatal("expected an error applying with a plan-only provider configuration")
+			}
+			if got, want := diags.Err().Error(), "Provider configuration is plan-only"; !strings.Contains(got, want) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+			}
+			if p.ApplyResourceChangeCalled {
+				t.Fatal("ApplyResourceChange was called for a plan-only provider configuration")
+			}
+		})
+	}
+}
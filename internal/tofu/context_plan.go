@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -78,6 +79,11 @@ type PlanOpts struct {
 	// warnings as part of the planning result.
 	Excludes []addrs.Targetable
 
+	// TargetProviders extends Targets with all resources that are bound to
+	// any of the given provider configurations. Local provider configuration
+	// addresses are resolved in the root module.
+	TargetProviders []addrs.ProviderConfig
+
 	// ForceReplace is a set of resource instance addresses whose corresponding
 	// objects should be forced planned for replacement if the provider's
 	// plan would otherwise have been to either update the object in-place or
@@ -89,6 +95,15 @@ type PlanOpts struct {
 	// fully-functional new object.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// ReplaceDependents extends ForceReplace to also plan replacement of
+	// any resource instance that depends, directly or indirectly, on an
+	// instance that is being replaced because of ForceReplace.
+	//
+	// This avoids the need for a second apply in situations where a
+	// downstream object can't be updated in-place to refer to the new
+	// object replacing one it was derived from.
+	ReplaceDependents bool
+
 	// ExternalReferences allows the external caller to pass in references to
 	// nodes that should not be pruned even if they are not referenced within
 	// the actual graph.
@@ -152,6 +167,15 @@ func (c *Context) Plan(ctx context.Context, config *configs.Config, prevRunState
 		return nil, diags
 	}
 
+	// Likewise, if the selected providers are older than the ones that
+	// last updated the state then we'll report all of the affected resource
+	// instances now, rather than failing partway through the plan.
+	moreDiags = c.CheckStateSchemaVersions(prevRunState)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
 	switch opts.Mode {
 	case plans.NormalMode, plans.DestroyMode:
 		// OK
@@ -186,6 +210,14 @@ func (c *Context) Plan(ctx context.Context, config *configs.Config, prevRunState
 		))
 		return nil, diags
 	}
+	if opts.ReplaceDependents && len(opts.ForceReplace) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid replace options",
+			"Replacing dependent resource instances (with -replace-dependents) requires at least one resource instance to replace (with -replace=...).",
+		))
+		return nil, diags
+	}
 
 	// By the time we get here, we should have values defined for all of
 	// the root module variables, even if some of them are "unknown". It's the
@@ -197,14 +229,36 @@ func (c *Context) Plan(ctx context.Context, config *configs.Config, prevRunState
 	varDiags := checkInputVariables(config.Module.Variables, opts.SetVariables)
 	diags = diags.Append(varDiags)
 
+	if len(opts.TargetProviders) > 0 {
+		if len(opts.Excludes) > 0 {
+			// The CLI layer (and other similar callers) should prevent this
+			// combination of options.
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Incompatible plan options",
+				"Cannot target provider configurations and exclude resources at the same time. This is a bug in OpenTofu.",
+			))
+			return nil, diags
+		}
+		providerTargets, moreDiags := c.providerTargets(config, prevRunState, opts.TargetProviders)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, diags
+		}
+		// We work on a copy so that the caller's options are left unchanged.
+		targetedOpts := *opts
+		targetedOpts.Targets = append(slices.Clip(opts.Targets), providerTargets...)
+		opts = &targetedOpts
+	}
+
 	if len(opts.Targets) > 0 || len(opts.Excludes) > 0 {
-		diags = diags.Append(tfdiags.Sourceless(
+		diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(
 			tfdiags.Warning,
 			"Resource targeting is in effect",
 			`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-		))
+		), tfdiags.CodeResourceTargeting))
 	}
 
 	var plan *plans.Plan
@@ -251,7 +305,7 @@ The -target and -exclude options are not for routine use, and are provided only
 	// targets and provider SHAs.
 	if plan != nil {
 		plan.VariableValues = varVals
-		plan.TargetAddrs = opts.Targets
+		plan.TargetAddrs = recordableTargets(opts.Targets, plan.Changes)
 		plan.ExcludeAddrs = opts.Excludes
 	} else if !diags.HasErrors() {
 		panic("nil plan but no errors")
@@ -640,6 +694,112 @@ func (c *Context) postPlanValidateImports(importResolver *ImportResolver, allIns
 	return diags
 }
 
+// postPlanValidateTargets checks that every instance key mentioned in the
+// -target and -replace options refers to an instance that either exists in
+// the prior state or was declared by the configuration, so that a typo in an
+// instance key is reported rather than silently matching nothing.
+//
+// Instance keys of module calls or resources whose expansion isn't known are
+// not checked, because we can't tell which keys would be valid for them.
+func (c *Context) postPlanValidateTargets(targets []addrs.Targetable, forceReplace []addrs.AbsResourceInstance, allInsts instances.Set, prevRunState *states.State) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, target := range targets {
+		switch addr := target.(type) {
+		case addrs.ModuleInstance:
+			diags = diags.Append(validateTargetModuleInstance(addr, "target", allInsts, prevRunState))
+		case addrs.AbsResource:
+			diags = diags.Append(validateTargetModuleInstance(addr.Module, "target", allInsts, prevRunState))
+		case addrs.AbsResourceInstance:
+			diags = diags.Append(validateTargetResourceInstance(addr, "target", allInsts, prevRunState))
+		}
+	}
+	for _, addr := range forceReplace {
+		diags = diags.Append(validateTargetResourceInstance(addr, "replace", allInsts, prevRunState))
+	}
+	return diags
+}
+
+func validateTargetModuleInstance(addr addrs.ModuleInstance, option string, allInsts instances.Set, prevRunState *states.State) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for i, step := range addr {
+		if step.InstanceKey == addrs.NoKey {
+			// A module call without a key matches all of its instances.
+			continue
+		}
+		call := addr[:i].ChildCall(step.Name)
+		keys, ok := allInsts.ModuleCallInstanceKeys(call)
+		if !ok {
+			// We don't know the expansion of this module call, and so we
+			// can't know about anything nested inside it either.
+			return diags
+		}
+		inst := addr[:i+1]
+		if instanceKeysContain(keys, step.InstanceKey) || prevRunState.Module(inst) != nil {
+			continue
+		}
+
+		valid := make([]string, len(keys))
+		for j, key := range keys {
+			valid[j] = call.Module.Child(call.Call.Name, key).String()
+		}
+		diags = diags.Append(invalidTargetKeyDiag(option, inst.String(), call.String(), valid))
+		return diags
+	}
+	return diags
+}
+
+func validateTargetResourceInstance(addr addrs.AbsResourceInstance, option string, allInsts instances.Set, prevRunState *states.State) tfdiags.Diagnostics {
+	diags := validateTargetModuleInstance(addr.Module, option, allInsts, prevRunState)
+	if diags.HasErrors() || addr.Resource.Key == addrs.NoKey {
+		return diags
+	}
+
+	resAddr := addr.ContainingResource()
+	keys, ok := allInsts.ResourceInstanceKeys(resAddr)
+	if !ok || instanceKeysContain(keys, addr.Resource.Key) || prevRunState.ResourceInstance(addr) != nil {
+		return diags
+	}
+
+	valid := make([]string, len(keys))
+	for i, key := range keys {
+		valid[i] = resAddr.Instance(key).String()
+	}
+	diags = diags.Append(invalidTargetKeyDiag(option, addr.String(), resAddr.String(), valid))
+	return diags
+}
+
+func invalidTargetKeyDiag(option, addr, container string, valid []string) tfdiags.Diagnostic {
+	if len(valid) == 0 {
+		return tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid instance key in "+option+" address",
+			fmt.Sprintf("The -%s option refers to %s, but %s doesn't have any instances.", option, addr, container),
+		)
+	}
+
+	var possibleValidOptions strings.Builder
+	for _, candidate := range valid {
+		fmt.Fprintf(&possibleValidOptions, "\n  -%s=%q", option, candidate)
+	}
+	return tfdiags.Sourceless(
+		tfdiags.Error,
+		"Invalid instance key in "+option+" address",
+		fmt.Sprintf(
+			"The -%s option refers to %s, but %s has no instance with that key.\n\nThe valid instances are:%s",
+			option, addr, container, possibleValidOptions.String(),
+		),
+	)
+}
+
+func instanceKeysContain(keys []addrs.InstanceKey, want addrs.InstanceKey) bool {
+	for _, key := range keys {
+		if key == want {
+			return true
+		}
+	}
+	return false
+}
+
 // findImportTargets builds a list of import targets by going over the import
 // blocks in the config.
 func (c *Context) findImportTargets(config *configs.Config) []*ImportTarget {
@@ -798,6 +958,15 @@ func (c *Context) planWalk(ctx context.Context, config *configs.Config, prevRunS
 	}
 	diags = diags.Append(moveValidateDiags) // might just contain warnings
 
+	if opts.Mode != plans.DestroyMode {
+		// The destroy walk doesn't expand the configuration, so we can only
+		// check instance keys against it in the other planning modes.
+		targetValidateDiags := c.postPlanValidateTargets(opts.Targets, opts.ForceReplace, allInsts, prevRunState)
+		if targetValidateDiags.HasErrors() {
+			return nil, diags.Append(targetValidateDiags)
+		}
+	}
+
 	if moveResults.Blocked.Len() > 0 && !diags.HasErrors() {
 		// If we had blocked moves and we're not going to be returning errors
 		// then we'll report the blockers as a warning. We do this only in the
@@ -812,6 +981,7 @@ func (c *Context) planWalk(ctx context.Context, config *configs.Config, prevRunS
 	// we encountered errors, which we'll return as part of a non-nil plan
 	// so that e.g. the UI can show what was planned so far in case that extra
 	// context helps the user to understand the error messages we're returning.
+	inputState := prevRunState
 	prevRunState = walker.PrevRunState.Close()
 
 	// The refreshed state may have data resource objects which were deferred
@@ -819,7 +989,13 @@ func (c *Context) planWalk(ctx context.Context, config *configs.Config, prevRunS
 	walker.RefreshState.RemovePlannedResourceInstanceObjects()
 	priorState := walker.RefreshState.Close()
 
-	driftedResources, driftDiags := c.driftedResources(config, prevRunState, priorState, moveResults)
+	// Only refresh-only plans annotate the drift with its likely causes,
+	// because only those plans present the drift as the result.
+	var driftInputState *states.State
+	if opts.Mode == plans.RefreshOnlyMode {
+		driftInputState = inputState
+	}
+	driftedResources, driftDiags := c.driftedResources(config, driftInputState, prevRunState, priorState, moveResults)
 	diags = diags.Append(driftDiags)
 
 	plan := &plans.Plan{
@@ -849,6 +1025,7 @@ func (c *Context) planGraph(config *configs.Config, prevRunState *states.State,
 			Targets:                 opts.Targets,
 			Excludes:                opts.Excludes,
 			ForceReplace:            opts.ForceReplace,
+			ReplaceDependents:       opts.ReplaceDependents,
 			skipRefresh:             opts.SkipRefresh,
 			preDestroyRefresh:       opts.PreDestroyRefresh,
 			Operation:               walkPlan,
@@ -899,7 +1076,11 @@ func (c *Context) planGraph(config *configs.Config, prevRunState *states.State,
 // report. This is known to happen when targeting a subset of resources,
 // because the excluded instances will have been removed from the plan and
 // not upgraded.
-func (c *Context) driftedResources(config *configs.Config, oldState, newState *states.State, moves refactoring.MoveResults) ([]*plans.ResourceInstanceChangeSrc, tfdiags.Diagnostics) {
+//
+// If inputState is not nil then it must be the state before the provider
+// upgraded any objects to their current schema versions, and each drifted
+// resource instance is annotated with the likely causes of its changes.
+func (c *Context) driftedResources(config *configs.Config, inputState, oldState, newState *states.State, moves refactoring.MoveResults) ([]*plans.ResourceInstanceChangeSrc, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
 	if newState.ManagedResourcesEqual(oldState) && moves.Changes.Len() == 0 {
@@ -940,7 +1121,7 @@ func (c *Context) driftedResources(config *configs.Config, oldState, newState *s
 
 				newIS := newState.ResourceInstance(addr)
 
-				schema, _ := schemas.ResourceTypeConfig(
+				schema, schemaVersion := schemas.ResourceTypeConfig(
 					provider,
 					addr.Resource.Resource.Mode,
 					addr.Resource.Resource.Type,
@@ -1021,6 +1202,13 @@ func (c *Context) driftedResources(config *configs.Config, oldState, newState *s
 						After:  newVal,
 					},
 				}
+				if inputState != nil {
+					var inputObj *states.ResourceInstanceObjectSrc
+					if inputIS := inputState.ResourceInstance(addr); inputIS != nil {
+						inputObj = inputIS.Current
+					}
+					change.DriftCauses = driftCauses(schema, schemaVersion, inputObj, oldVal, newVal)
+				}
 
 				changeSrc, err := change.Encode(ty)
 				if err != nil {
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -1653,13 +1654,13 @@ func TestContext2Plan_movedResourceUntargeted(t *testing.T) {
 		// comparison-friendly, by discarding all of the dynamic type information.
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			tfdiags.Sourceless(
 				tfdiags.Error,
 				"Moved resource instances excluded by targeting",
@@ -1693,13 +1694,13 @@ Note that adding these options may include further additional resource instances
 		// comparison-friendly, by discarding all of the dynamic type information.
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			tfdiags.Sourceless(
 				tfdiags.Error,
 				"Moved resource instances excluded by targeting",
@@ -1733,13 +1734,13 @@ Note that adding these options may include further additional resource instances
 		// comparison-friendly, by discarding all of the dynamic type information.
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			tfdiags.Sourceless(
 				tfdiags.Error,
 				"Moved resource instances excluded by targeting",
@@ -1781,13 +1782,13 @@ Note that adding these options may include further additional resource instances
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
 			// Still get the warning about the -target option...
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			// ...but now we have no error about test_object.a
 		}.ForRPC()
 
@@ -1812,13 +1813,13 @@ The -target and -exclude options are not for routine use, and are provided only
 		// comparison-friendly, by discarding all of the dynamic type information.
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			tfdiags.Sourceless(
 				tfdiags.Error,
 				"Moved resource instances excluded by targeting",
@@ -1852,13 +1853,13 @@ Note that removing these options may include further additional resource instanc
 		// comparison-friendly, by discarding all of the dynamic type information.
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			tfdiags.Sourceless(
 				tfdiags.Error,
 				"Moved resource instances excluded by targeting",
@@ -1893,13 +1894,13 @@ Note that removing these options may include further additional resource instanc
 		// comparison-friendly, by discarding all of the dynamic type information.
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			tfdiags.Sourceless(
 				tfdiags.Error,
 				"Moved resource instances excluded by targeting",
@@ -1939,13 +1940,13 @@ Note that removing these options may include further additional resource instanc
 		gotDiags := diags.ForRPC()
 		wantDiags := tfdiags.Diagnostics{
 			// Still get the warning about the -target option...
-			tfdiags.Sourceless(
+			tfdiags.WithCode(tfdiags.Sourceless(
 				tfdiags.Warning,
 				"Resource targeting is in effect",
 				`You are creating a plan with either the -target option or the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
 
 The -target and -exclude options are not for routine use, and are provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
-			),
+			), tfdiags.CodeResourceTargeting),
 			// ...but now we have no error about test_object.a
 		}.ForRPC()
 
@@ -2568,6 +2569,101 @@ func TestContext2Plan_refreshOnlyMode_orphan(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_refreshOnlyMode_driftCauses(t *testing.T) {
+	addr := mustResourceInstanceAddr("test_object.a")
+
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+				arg = "before"
+			}
+		`,
+	})
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{"arg":"before","policy":"{\"a\": 1}","name":null}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+	})
+
+	p := simpleMockProvider()
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		Provider: providers.Schema{Block: simpleTestSchema()},
+		ResourceTypes: map[string]providers.Schema{
+			"test_object": {
+				Version: 1,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"arg":    {Type: cty.String, Optional: true},
+						"policy": {Type: cty.String, Optional: true},
+						"name":   {Type: cty.String, Optional: true},
+						"region": {Type: cty.String, Computed: true},
+					},
+				},
+			},
+		},
+	}
+	p.UpgradeResourceStateFn = func(req providers.UpgradeResourceStateRequest) (resp providers.UpgradeResourceStateResponse) {
+		// Version 1 of the schema added the "region" attribute, which the
+		// provider only populates when refreshing.
+		resp.UpgradedState = cty.ObjectVal(map[string]cty.Value{
+			"arg":    cty.StringVal("before"),
+			"policy": cty.StringVal(`{"a": 1}`),
+			"name":   cty.NullVal(cty.String),
+			"region": cty.NullVal(cty.String),
+		})
+		return resp
+	}
+	p.ReadResourceFn = func(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+		return providers.ReadResourceResponse{
+			NewState: cty.ObjectVal(map[string]cty.Value{
+				"arg":    cty.StringVal("changed"),
+				"policy": cty.StringVal(`{"a":1}`),
+				"name":   cty.StringVal(""),
+				"region": cty.StringVal("us-east-1"),
+			}),
+		}
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode: plans.RefreshOnlyMode,
+	})
+	assertNoErrors(t, diags)
+
+	if got, want := len(plan.DriftedResources), 1; got != want {
+		t.Fatalf("wrong number of drifted resources %d; want %d", got, want)
+	}
+	got := plan.DriftedResources[0].DriftCauses
+	want := map[string]plans.DriftCause{
+		"arg":    plans.DriftCauseProvider,
+		"policy": plans.DriftCauseNormalization,
+		"name":   plans.DriftCauseNormalization,
+		"region": plans.DriftCauseSchemaUpgrade,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong drift causes\n%s", diff)
+	}
+
+	// Other planning modes report the same drift, but without causes.
+	plan, diags = ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode: plans.NormalMode,
+	})
+	assertNoErrors(t, diags)
+
+	if got, want := len(plan.DriftedResources), 1; got != want {
+		t.Fatalf("wrong number of drifted resources %d; want %d", got, want)
+	}
+	if got := plan.DriftedResources[0].DriftCauses; got != nil {
+		t.Errorf("unexpected drift causes in normal mode plan: %#v", got)
+	}
+}
+
 func TestContext2Plan_invalidSensitiveModuleOutput(t *testing.T) {
 	m := testModuleInline(t, map[string]string{
 		"child/main.tf": `
@@ -2776,6 +2872,291 @@ func TestContext2Plan_forceReplace(t *testing.T) {
 	})
 }
 
+func TestContext2Plan_forceReplaceDependents(t *testing.T) {
+	addrA := mustResourceInstanceAddr("test_object.a")
+	addrB := mustResourceInstanceAddr("test_object.b")
+	addrC := mustResourceInstanceAddr("test_object.c")
+	addrD := mustResourceInstanceAddr("test_object.d")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+				test_string = "a"
+			}
+			resource "test_object" "b" {
+				test_string = test_object.a.test_string
+			}
+			resource "test_object" "c" {
+				test_string = test_object.b.test_string
+			}
+			resource "test_object" "d" {
+				test_string = "a"
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{addrA, addrB, addrC, addrD} {
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"test_string":"a"}`),
+				Status:    states.ObjectReady,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+		}
+	})
+
+	p := replaceOnStringChangeMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode: plans.NormalMode,
+		ForceReplace: []addrs.AbsResourceInstance{
+			addrA,
+		},
+		ReplaceDependents: true,
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
+	}
+
+	for _, addr := range []addrs.AbsResourceInstance{addrA, addrB, addrC} {
+		t.Run(addr.String(), func(t *testing.T) {
+			instPlan := plan.Changes.ResourceInstance(addr)
+			if instPlan == nil {
+				t.Fatalf("no plan for %s at all", addr)
+			}
+
+			if got, want := instPlan.Action, plans.DeleteThenCreate; got != want {
+				t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := instPlan.ActionReason, plans.ResourceInstanceReplaceByRequest; got != want {
+				t.Errorf("wrong action reason\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+	t.Run(addrD.String(), func(t *testing.T) {
+		instPlan := plan.Changes.ResourceInstance(addrD)
+		if instPlan == nil {
+			t.Fatalf("no plan for %s at all", addrD)
+		}
+
+		if got, want := instPlan.Action, plans.NoOp; got != want {
+			t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestContext2Plan_forceReplaceDependentsOtherReasons(t *testing.T) {
+	addrA := mustResourceInstanceAddr("test_object.a")
+	addrB := mustResourceInstanceAddr("test_object.b")
+	addrC := mustResourceInstanceAddr("test_object.c")
+	addrD0 := mustResourceInstanceAddr("test_object.d[0]")
+	addrD1 := mustResourceInstanceAddr("test_object.d[1]")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+				test_string = "a"
+			}
+			resource "test_object" "b" {
+				test_string = test_object.a.test_string
+			}
+			resource "test_object" "c" {
+				test_string = "a"
+			}
+			resource "test_object" "d" {
+				count       = 2
+				test_string = test_object.c.test_string
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{addrA, addrB, addrC, addrD0, addrD1} {
+			status := states.ObjectReady
+			if addr.Equal(addrA) {
+				status = states.ObjectTainted
+			}
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"test_string":"a"}`),
+				Status:    status,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+		}
+	})
+
+	p := replaceOnStringChangeMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode: plans.NormalMode,
+		ForceReplace: []addrs.AbsResourceInstance{
+			addrC,
+		},
+		ReplaceDependents: true,
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
+	}
+
+	tests := map[string]struct {
+		addr   addrs.AbsResourceInstance
+		action plans.Action
+		reason plans.ResourceInstanceChangeActionReason
+	}{
+		// A tainted dependency is replaced, but not at the user's request,
+		// so its dependents are left alone.
+		"tainted dependency":   {addrA, plans.DeleteThenCreate, plans.ResourceInstanceReplaceBecauseTainted},
+		"dependent of tainted": {addrB, plans.NoOp, plans.ResourceInstanceChangeNoReason},
+		"requested":            {addrC, plans.DeleteThenCreate, plans.ResourceInstanceReplaceByRequest},
+		"dependent instance 0": {addrD0, plans.DeleteThenCreate, plans.ResourceInstanceReplaceByRequest},
+		"dependent instance 1": {addrD1, plans.DeleteThenCreate, plans.ResourceInstanceReplaceByRequest},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			instPlan := plan.Changes.ResourceInstance(test.addr)
+			if instPlan == nil {
+				t.Fatalf("no plan for %s at all", test.addr)
+			}
+			if got, want := instPlan.Action, test.action; got != want {
+				t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := instPlan.ActionReason, test.reason; got != want {
+				t.Errorf("wrong action reason\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestContext2Plan_forceReplaceDependentsInstances(t *testing.T) {
+	addrA0 := mustResourceInstanceAddr("test_object.a[0]")
+	addrA1 := mustResourceInstanceAddr("test_object.a[1]")
+	addrB0 := mustResourceInstanceAddr("test_object.b[0]")
+	addrB1 := mustResourceInstanceAddr("test_object.b[1]")
+	addrC := mustResourceInstanceAddr("test_object.c")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+				count       = 2
+				test_string = "a"
+			}
+			resource "test_object" "b" {
+				count       = 2
+				test_string = test_object.a[count.index].test_string
+			}
+			resource "test_object" "c" {
+				test_string = "a"
+				test_number = length(test_object.a[0].test_string)
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{addrA0, addrA1, addrB0, addrB1} {
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"test_string":"a"}`),
+				Status:    states.ObjectReady,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+		}
+		s.SetResourceInstanceCurrent(addrC, &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{"test_string":"a","test_number":1}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+	})
+
+	p := replaceOnStringChangeMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode: plans.NormalMode,
+		ForceReplace: []addrs.AbsResourceInstance{
+			addrA0,
+		},
+		ReplaceDependents: true,
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
+	}
+
+	tests := map[string]struct {
+		addr   addrs.AbsResourceInstance
+		action plans.Action
+	}{
+		"requested":                   {addrA0, plans.DeleteThenCreate},
+		"other instance of requested": {addrA1, plans.NoOp},
+		// b[0] refers to a[0] in an argument that requires replacement.
+		"dependent instance": {addrB0, plans.DeleteThenCreate},
+		// b[1] only refers to a[1], which is not replaced.
+		"instance of other dependency": {addrB1, plans.NoOp},
+		// c refers to a[0] only in an argument that can be updated.
+		"dependent without replacement": {addrC, plans.NoOp},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			instPlan := plan.Changes.ResourceInstance(test.addr)
+			if instPlan == nil {
+				t.Fatalf("no plan for %s at all", test.addr)
+			}
+			if got, want := instPlan.Action, test.action; got != want {
+				t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+// replaceOnStringChangeMockProvider returns a mock provider for which changing
+// the test_string argument of a test_object requires replacement.
+func replaceOnStringChangeMockProvider() *MockProvider {
+	p := simpleMockProvider()
+	p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) (resp providers.PlanResourceChangeResponse) {
+		resp.PlannedState = req.ProposedNewState
+		if req.PriorState.IsNull() || req.ProposedNewState.IsNull() {
+			return resp
+		}
+		eq := req.PriorState.GetAttr("test_string").Equals(req.ProposedNewState.GetAttr("test_string"))
+		if !eq.IsKnown() || eq.False() {
+			resp.RequiresReplace = []cty.Path{cty.GetAttrPath("test_string")}
+		}
+		return resp
+	}
+	return p
+}
+
+func TestContext2Plan_replaceDependentsWithoutForceReplace(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+			}
+		`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+		Mode:              plans.NormalMode,
+		ReplaceDependents: true,
+	})
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want error")
+	}
+	if got, want := diags.Err().Error(), "requires at least one resource instance to replace"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+	}
+}
+
 func TestContext2Plan_forceReplaceIncompleteAddr(t *testing.T) {
 	addr0 := mustResourceInstanceAddr("test_object.a[0]")
 	addr1 := mustResourceInstanceAddr("test_object.a[1]")
@@ -4513,6 +4894,101 @@ func TestContext2Plan_dataSourceReadPlanError(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_dataSourceWaitFor(t *testing.T) {
+	tests := map[string]struct {
+		readyRead int
+		timeout   string
+		wantErr   string
+		wantReads int
+	}{
+		"condition becomes true": {
+			readyRead: 3,
+			timeout:   "1m",
+			wantReads: 3,
+		},
+		"times out": {
+			readyRead: 1000,
+			timeout:   "20ms",
+			wantErr:   "Timed out waiting for data source",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := testModuleInline(t, map[string]string{
+				"main.tf": fmt.Sprintf(`
+data "test_data_source" "a" {
+  foo = "bar"
+
+  wait_for {
+    condition = self.status == "ready"
+    timeout   = %q
+    interval  = "1ms"
+    backoff   = 1
+  }
+}
+`, test.timeout),
+			})
+
+			p := testProvider("test")
+			p.GetProviderSchemaResponse = getProviderSchemaResponseFromProviderSchema(&ProviderSchema{
+				DataSources: map[string]*configschema.Block{
+					"test_data_source": {
+						Attributes: map[string]*configschema.Attribute{
+							"foo": {
+								Type:     cty.String,
+								Required: true,
+							},
+							"status": {
+								Type:     cty.String,
+								Computed: true,
+							},
+						},
+					},
+				},
+			})
+			reads := 0
+			p.ReadDataSourceFn = func(req providers.ReadDataSourceRequest) (resp providers.ReadDataSourceResponse) {
+				reads++
+				status := "pending"
+				if reads >= test.readyRead {
+					status = "ready"
+				}
+				resp.State = cty.ObjectVal(map[string]cty.Value{
+					"foo":    req.Config.GetAttr("foo"),
+					"status": cty.StringVal(status),
+				})
+				return resp
+			}
+			ctx := testContext2(t, &ContextOpts{
+				Providers: map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				},
+			})
+
+			plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+			if test.wantErr != "" {
+				if !diags.HasErrors() {
+					t.Fatal("plan succeeded, but should've timed out")
+				}
+				if got := diags.Err().Error(); !strings.Contains(got, test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			assertNoErrors(t, diags)
+			if reads != test.wantReads {
+				t.Errorf("wrong number of reads %d; want %d", reads, test.wantReads)
+			}
+
+			rs := plan.PriorState.ResourceInstance(mustResourceInstanceAddr("data.test_data_source.a"))
+			if rs == nil || rs.Current == nil || !bytes.Contains(rs.Current.AttrsJSON, []byte(`"ready"`)) {
+				t.Fatalf("data source result was not saved after waiting: %s", spew.Sdump(rs))
+			}
+		})
+	}
+}
+
 func TestContext2Plan_ignoredMarkedValue(t *testing.T) {
 	m := testModuleInline(t, map[string]string{
 		"main.tf": `
@@ -8128,3 +8604,100 @@ func featuresBlockTestSchema() *configschema.Block {
 		},
 	}
 }
+
+func TestContext2Plan_targetProviders(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+provider "test" {
+}
+
+provider "test" {
+  alias = "east"
+}
+
+resource "test_object" "a" {
+}
+
+resource "test_object" "b" {
+  provider = test.east
+}
+
+module "child" {
+  source = "./child"
+  providers = {
+    test = test.east
+  }
+}
+`,
+		"child/main.tf": `
+resource "test_object" "c" {
+}
+`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.d"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"].east`), addrs.NoKey)
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.e"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`), addrs.NoKey)
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	t.Run("matching", func(t *testing.T) {
+		plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+			Mode: plans.NormalMode,
+			TargetProviders: []addrs.ProviderConfig{
+				addrs.LocalProviderConfig{LocalName: "test", Alias: "east"},
+			},
+		})
+		assertNoErrors(t, diags)
+
+		got := make(map[string]plans.Action)
+		for _, rc := range plan.Changes.Resources {
+			got[rc.Addr.String()] = rc.Action
+		}
+		want := map[string]plans.Action{
+			"test_object.b":              plans.Create,
+			"module.child.test_object.c": plans.Create,
+			"test_object.d":              plans.Delete,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong planned changes\n%s", diff)
+		}
+
+		var gotTargets []string
+		for _, target := range plan.TargetAddrs {
+			gotTargets = append(gotTargets, target.String())
+		}
+		sort.Strings(gotTargets)
+		wantTargets := []string{"module.child.test_object.c", "test_object.b", "test_object.d"}
+		if diff := cmp.Diff(wantTargets, gotTargets); diff != "" {
+			t.Errorf("wrong recorded targets\n%s", diff)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		_, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+			Mode: plans.NormalMode,
+			TargetProviders: []addrs.ProviderConfig{
+				addrs.LocalProviderConfig{LocalName: "test", Alias: "west"},
+			},
+		})
+		if !diags.HasErrors() {
+			t.Fatal("plan succeeded; want error")
+		}
+		if got, want := diags.Err().Error(), "No resources use the targeted provider configuration"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// providerTargets returns targets for all resources that are bound to any of
+// the given provider configurations. Resources that are still declared in the
+// configuration are matched by the provider configuration they resolve to,
+// while resources that only remain in the prior state are matched by the
+// provider configuration recorded for them.
+func (c *Context) providerTargets(config *configs.Config, prevRunState *states.State, providers []addrs.ProviderConfig) ([]addrs.Targetable, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	wanted := make(map[string]bool, len(providers))
+	for _, provider := range providers {
+		wanted[config.ResolveAbsProviderAddr(provider, addrs.RootModule).String()] = true
+	}
+	matched := make(map[string]bool, len(providers))
+
+	// We resolve the provider configurations in the same way as the plan
+	// graph does, including inheritance and passing providers to modules, but
+	// without anything else the plan graph would need.
+	g, moreDiags := (&BasicGraphBuilder{
+		Steps: []GraphTransformer{
+			&ConfigTransformer{Config: config},
+			&AttachResourceConfigTransformer{Config: config},
+			transformProviders(nil, config),
+
+			// The graph must have a single root to pass validation.
+			&RootTransformer{},
+		},
+		Name: "providerTargets",
+	}).Build(addrs.RootModuleInstance)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+
+	var targets []addrs.Targetable
+	for _, v := range g.Vertices() {
+		n, ok := v.(*NodeAbstractResource)
+		if !ok {
+			continue
+		}
+		provider := n.ResolvedProvider.ProviderConfig.String()
+		if wanted[provider] {
+			log.Printf("[TRACE] providerTargets: targeting %s, which uses %s", n.Addr, provider)
+			targets = append(targets, n.Addr)
+			matched[provider] = true
+		}
+	}
+
+	if prevRunState != nil {
+		for _, ms := range prevRunState.Modules {
+			for _, rs := range ms.Resources {
+				if mc := config.DescendentForInstance(rs.Addr.Module); mc != nil && mc.Module.ResourceByAddr(rs.Addr.Resource) != nil {
+					// Handled by the configuration above.
+					continue
+				}
+				provider := rs.ProviderConfig.String()
+				if wanted[provider] {
+					log.Printf("[TRACE] providerTargets: targeting %s, which uses %s in the prior state", rs.Addr, provider)
+					targets = append(targets, rs.Addr)
+					matched[provider] = true
+				}
+			}
+		}
+	}
+
+	for _, provider := range providers {
+		addr := config.ResolveAbsProviderAddr(provider, addrs.RootModule).String()
+		if !matched[addr] {
+			// Targeting nothing at all would plan changes for everything,
+			// which is the opposite of what was requested.
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"No resources use the targeted provider configuration",
+				fmt.Sprintf("No resources in the configuration or the prior state are bound to the provider configuration %s.", addr),
+			))
+		}
+	}
+
+	return targets, diags
+}
+
+// recordableTargets returns the targets to record in a plan. Targets in the
+// plan file use the syntax of the -target option, which can't express a
+// resource in all instances of a module, so any ConfigResource targets are
+// replaced by the resources they matched in the planned changes.
+func recordableTargets(targets []addrs.Targetable, changes *plans.Changes) []addrs.Targetable {
+	var ret []addrs.Targetable
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		cfgAddr, ok := target.(addrs.ConfigResource)
+		if !ok {
+			ret = append(ret, target)
+			continue
+		}
+		if changes == nil {
+			continue
+		}
+		for _, rc := range changes.Resources {
+			if !rc.Addr.ConfigResource().Equal(cfgAddr) {
+				continue
+			}
+			addr := rc.Addr.ContainingResource()
+			if key := addr.String(); !seen[key] {
+				seen[key] = true
+				ret = append(ret, addr)
+			}
+		}
+	}
+	return ret
+}
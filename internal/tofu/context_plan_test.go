@@ -6894,6 +6894,61 @@ func TestContext2Plan_targetedModuleInstance(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_targetedModuleInstanceInvalidKey(t *testing.T) {
+	m := testModule(t, "plan-targeted")
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+		Mode: plans.NormalMode,
+		Targets: []addrs.Targetable{
+			addrs.RootModuleInstance.Child("mod", addrs.IntKey(3)),
+		},
+	})
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want errors")
+	}
+	got := diags.Err().Error()
+	want := `The -target option refers to module.mod[3], but module.mod has no instance with that key.`
+	if !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+	}
+	if !strings.Contains(got, `-target="module.mod[0]"`) {
+		t.Fatalf("error does not list the valid instances\ngot: %s", got)
+	}
+}
+
+func TestContext2Plan_targetedResourceInstanceInvalidKey(t *testing.T) {
+	m := testModule(t, "plan-targeted")
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+		Mode: plans.NormalMode,
+		Targets: []addrs.Targetable{
+			addrs.RootModuleInstance.ResourceInstance(addrs.ManagedResourceMode, "aws_instance", "foo", addrs.IntKey(1)),
+		},
+	})
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want errors")
+	}
+	got := diags.Err().Error()
+	want := `The -target option refers to aws_instance.foo[1], but aws_instance.foo has no instance with that key.`
+	if !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+	}
+}
+
 func TestContext2Plan_excludedModuleInstance(t *testing.T) {
 	m := testModule(t, "plan-targeted")
 	p := testProvider("aws")
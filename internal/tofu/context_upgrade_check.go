@@ -0,0 +1,158 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/version"
+)
+
+// UpgradeCheckResult describes what would happen when upgrading a single
+// resource instance object in the state to the current schema version of
+// its resource type.
+type UpgradeCheckResult struct {
+	Addr       addrs.AbsResourceInstance
+	DeposedKey states.DeposedKey
+	Provider   addrs.Provider
+
+	// FromVersion is the schema version the object was saved with, and
+	// ToVersion is the current schema version of its resource type.
+	FromVersion, ToVersion uint64
+
+	// Diagnostics are any problems the provider reported when upgrading
+	// the object. The upgrade would fail if these include errors.
+	Diagnostics tfdiags.Diagnostics
+}
+
+// UpgradeCheck runs the provider's schema upgrade logic for each managed
+// resource instance object in the given state that was saved with a
+// different schema version than the current one, without saving the
+// results, so that the caller can report which upgrades would fail.
+//
+// The results are sorted by address. Objects that are already at the current
+// schema version aren't included.
+func (c *Context) UpgradeCheck(state *states.State) ([]UpgradeCheckResult, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	var results []UpgradeCheckResult
+
+	instances := make(map[addrs.Provider]providers.Interface)
+	defer func() {
+		for addr, provider := range instances {
+			if err := provider.Close(); err != nil {
+				log.Printf("[WARN] UpgradeCheck: failed to close provider %s: %s", addr, err)
+			}
+		}
+	}()
+
+	for _, ms := range state.Modules {
+		for _, rs := range ms.Resources {
+			if rs.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				// Only managed resources have versioned schemas.
+				continue
+			}
+			providerAddr := rs.ProviderConfig.Provider
+
+			for key, is := range rs.Instances {
+				addr := rs.Addr.Instance(key)
+				objs := make(map[states.DeposedKey]*states.ResourceInstanceObjectSrc, len(is.Deposed)+1)
+				if is.Current != nil {
+					objs[states.NotDeposed] = is.Current
+				}
+				for dk, obj := range is.Deposed {
+					objs[dk] = obj
+				}
+
+				for dk, obj := range objs {
+					result := UpgradeCheckResult{
+						Addr:        addr,
+						DeposedKey:  dk,
+						Provider:    providerAddr,
+						FromVersion: obj.SchemaVersion,
+					}
+
+					schema, currentVersion, err := c.plugins.ResourceTypeSchema(providerAddr, addr.Resource.Resource.Mode, addr.Resource.Resource.Type)
+					if err != nil {
+						result.Diagnostics = result.Diagnostics.Append(tfdiags.Sourceless(
+							tfdiags.Error,
+							"Failed to obtain provider schema",
+							fmt.Sprintf("Could not load the schema for provider %s: %s.", providerAddr, err),
+						))
+						results = append(results, result)
+						continue
+					}
+					if schema == nil {
+						result.Diagnostics = result.Diagnostics.Append(tfdiags.Sourceless(
+							tfdiags.Error,
+							"Resource type not supported",
+							fmt.Sprintf("The selected version of provider %s does not support the resource type %q.", providerAddr, addr.Resource.Resource.Type),
+						))
+						results = append(results, result)
+						continue
+					}
+					result.ToVersion = currentVersion
+					if obj.SchemaVersion == currentVersion {
+						continue
+					}
+
+					provider, ok := instances[providerAddr]
+					if !ok {
+						provider, err = c.plugins.NewProviderInstance(providerAddr)
+						if err != nil {
+							diags = diags.Append(tfdiags.Sourceless(
+								tfdiags.Error,
+								"Failed to start provider",
+								fmt.Sprintf("Could not start provider %s: %s.", providerAddr, err),
+							))
+							return nil, diags
+						}
+						instances[providerAddr] = provider
+
+						// Schema upgraders can expect the provider to be
+						// configured, but we don't evaluate the provider
+						// configuration here, so we configure it as if it
+						// had an empty configuration block. Any errors mean
+						// that the provider needs configuration to work, which
+						// upgraders rarely depend on, so we try them anyway.
+						if providerSchema, err := c.plugins.ProviderSchema(providerAddr); err == nil {
+							config := cty.EmptyObjectVal
+							if providerSchema.Provider.Block != nil {
+								config = providerSchema.Provider.Block.EmptyValue()
+							}
+							resp := provider.ConfigureProvider(providers.ConfigureProviderRequest{
+								TerraformVersion: version.String(),
+								Config:           config,
+							})
+							if resp.Diagnostics.HasErrors() {
+								log.Printf("[WARN] UpgradeCheck: provider %s can't be configured without a configuration: %s", providerAddr, resp.Diagnostics.Err())
+							}
+						}
+					}
+
+					// upgradeResourceState may modify the object it's given,
+					// and we must not change the caller's state.
+					_, upgradeDiags := upgradeResourceState(addr, provider, obj.DeepCopy(), schema, currentVersion)
+					result.Diagnostics = upgradeDiags
+					results = append(results, result)
+				}
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Addr.Equal(results[j].Addr) {
+			return results[i].DeposedKey < results[j].DeposedKey
+		}
+		return results[i].Addr.Less(results[j].Addr)
+	})
+	return results, diags
+}
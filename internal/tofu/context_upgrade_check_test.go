@@ -0,0 +1,116 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestContextUpgradeCheck(t *testing.T) {
+	providerAddr := mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`)
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.current"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON:     []byte(`{"arg":"current"}`),
+			SchemaVersion: 2,
+			Status:        states.ObjectReady,
+		}, providerAddr, addrs.NoKey)
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.old"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON:     []byte(`{"arg":"old"}`),
+			SchemaVersion: 1,
+			Status:        states.ObjectReady,
+		}, providerAddr, addrs.NoKey)
+		s.SetResourceInstanceDeposed(mustResourceInstanceAddr("test_object.old"), states.DeposedKey("00000001"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON:     []byte(`{"arg":"broken"}`),
+			SchemaVersion: 0,
+			Status:        states.ObjectReady,
+		}, providerAddr, addrs.NoKey)
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.newer"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON:     []byte(`{"arg":"newer"}`),
+			SchemaVersion: 3,
+			Status:        states.ObjectReady,
+		}, providerAddr, addrs.NoKey)
+	})
+
+	p := simpleMockProvider()
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		Provider: providers.Schema{Block: simpleTestSchema()},
+		ResourceTypes: map[string]providers.Schema{
+			"test_object": {
+				Version: 2,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"arg": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	p.UpgradeResourceStateFn = func(req providers.UpgradeResourceStateRequest) (resp providers.UpgradeResourceStateResponse) {
+		if bytes.Contains(req.RawStateJSON, []byte("broken")) {
+			resp.Diagnostics = resp.Diagnostics.Append(errors.New("can't upgrade a broken object"))
+			return resp
+		}
+		resp.UpgradedState = cty.ObjectVal(map[string]cty.Value{
+			"arg": cty.StringVal("upgraded"),
+		})
+		return resp
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	results, diags := ctx.UpgradeCheck(state)
+	assertNoErrors(t, diags)
+
+	type summary struct {
+		addr     string
+		deposed  states.DeposedKey
+		from, to uint64
+		fails    bool
+	}
+	var got []summary
+	for _, result := range results {
+		got = append(got, summary{
+			addr:    result.Addr.String(),
+			deposed: result.DeposedKey,
+			from:    result.FromVersion,
+			to:      result.ToVersion,
+			fails:   result.Diagnostics.HasErrors(),
+		})
+	}
+	want := []summary{
+		{addr: "test_object.newer", from: 3, to: 2, fails: true},
+		{addr: "test_object.old", from: 1, to: 2},
+		{addr: "test_object.old", deposed: "00000001", from: 0, to: 2, fails: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("wrong results\ngot:  %#v\nwant: %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wrong result %d\ngot:  %#v\nwant: %#v", i, got[i], want[i])
+		}
+	}
+
+	// The state must not have been changed by the upgrades.
+	obj := state.ResourceInstance(mustResourceInstanceAddr("test_object.old")).Current
+	if got, want := string(obj.AttrsJSON), `{"arg":"old"}`; got != want {
+		t.Errorf("state was modified\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := obj.SchemaVersion, uint64(1); got != want {
+		t.Errorf("state schema version was modified to %d; want %d", got, want)
+	}
+}
@@ -2488,3 +2488,90 @@ locals {
 		t.Fatalf("expected deprecated warning, got: %q\n", warn)
 	}
 }
+
+func TestContext2Validate_providerCapabilities(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    test = {
+      source       = "hashicorp/test"
+      capabilities = ["plan_destroy"]
+    }
+  }
+}
+
+resource "test_object" "a" {
+}
+`,
+	})
+
+	for name, planDestroy := range map[string]bool{"supported": true, "unsupported": false} {
+		t.Run(name, func(t *testing.T) {
+			p := simpleMockProvider()
+			p.GetProviderSchemaResponse.ServerCapabilities.PlanDestroy = planDestroy
+
+			ctx := testContext2(t, &ContextOpts{
+				Providers: map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				},
+			})
+			diags := ctx.Validate(context.Background(), m)
+			if planDestroy {
+				assertNoErrors(t, diags)
+				return
+			}
+			if !diags.HasErrors() {
+				t.Fatal("expected an error")
+			}
+			if got, want := diags.Err().Error(), `requires provider registry.opentofu.org/hashicorp/test to support the "plan_destroy" capability`; !strings.Contains(got, want) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestContext2Validate_strictConversions(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+terraform {
+  strict_conversions = "error"
+}
+
+resource "test_object" "a" {
+  test_string = "ok"
+  test_number = "5"
+}
+
+module "child" {
+  source  = "./child"
+  enabled = "true"
+}
+`,
+		"child/main.tf": `
+variable "enabled" {
+  type = bool
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+	diags := ctx.Validate(context.Background(), m)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error")
+	}
+	got := diags.Err().Error()
+	for _, want := range []string{
+		"The value of this expression is a string, but a number is required.",
+		"The value of this expression is a string, but a bool is required.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing error\ngot:  %s\nwant: %s", got, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+// driftCauses returns the likely cause of the change to each top-level
+// attribute and nested block type that differs between the old and new
+// values of a drifted resource instance.
+//
+// inputObj is the object as it was in the state before the provider upgraded
+// it to schemaVersion, if there was one. The old value is the result of that
+// upgrade, so the upgrade itself never shows up as a difference between the
+// old and new values. What it can explain is an attribute that the upgrade
+// introduced without a value, and that the provider populated when
+// refreshing.
+//
+// The result is nil if the object was created or deleted outside of OpenTofu,
+// because then there are no attribute changes to explain.
+func driftCauses(schema *configschema.Block, schemaVersion uint64, inputObj *states.ResourceInstanceObjectSrc, oldVal, newVal cty.Value) map[string]plans.DriftCause {
+	oldVal, _ = oldVal.UnmarkDeep()
+	newVal, _ = newVal.UnmarkDeep()
+	if oldVal.IsNull() || newVal.IsNull() || !oldVal.IsKnown() || !newVal.IsKnown() {
+		return nil
+	}
+
+	added := addedByUpgrade(schema, schemaVersion, inputObj)
+
+	var names []string
+	for name := range schema.Attributes {
+		names = append(names, name)
+	}
+	for name := range schema.BlockTypes {
+		names = append(names, name)
+	}
+
+	causes := make(map[string]plans.DriftCause)
+	for _, name := range names {
+		before, after := oldVal.GetAttr(name), newVal.GetAttr(name)
+		if before.RawEquals(after) {
+			continue
+		}
+		switch {
+		case normalizationEquivalent(before, after):
+			causes[name] = plans.DriftCauseNormalization
+		case added[name] && before.IsNull():
+			causes[name] = plans.DriftCauseSchemaUpgrade
+		default:
+			causes[name] = plans.DriftCauseProvider
+		}
+	}
+	if len(causes) == 0 {
+		return nil
+	}
+	return causes
+}
+
+// addedByUpgrade returns the set of top-level attributes and nested block
+// types of the current schema that the given object, as stored before the
+// provider upgraded it to schemaVersion, doesn't have at all.
+//
+// The result is nil if the object didn't need upgrading, or if it's stored in
+// the legacy flatmap format, where an absent attribute may just be a null or
+// empty one.
+func addedByUpgrade(schema *configschema.Block, schemaVersion uint64, inputObj *states.ResourceInstanceObjectSrc) map[string]bool {
+	if inputObj == nil || inputObj.SchemaVersion >= schemaVersion || inputObj.AttrsJSON == nil {
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(inputObj.AttrsJSON, &raw); err != nil {
+		return nil
+	}
+
+	ret := make(map[string]bool)
+	for name := range schema.ImpliedType().AttributeTypes() {
+		if _, ok := raw[name]; !ok {
+			ret[name] = true
+		}
+	}
+	return ret
+}
+
+// normalizationEquivalent returns true if the two given values differ only in
+// the way the same content is written, such as a null value in place of an
+// empty string or collection, whitespace around a string, or the formatting
+// of a JSON document in a string.
+func normalizationEquivalent(a, b cty.Value) bool {
+	if isEmptyValue(a) && isEmptyValue(b) {
+		return true
+	}
+	if a.IsNull() || b.IsNull() || !a.IsKnown() || !b.IsKnown() {
+		return false
+	}
+
+	aTy, bTy := a.Type(), b.Type()
+	switch {
+	case aTy == cty.String && bTy == cty.String:
+		as, bs := a.AsString(), b.AsString()
+		if strings.TrimSpace(as) == strings.TrimSpace(bs) {
+			return true
+		}
+		return isJSONDocument(as) && isJSONDocument(bs) && jsonEquivalent(as, bs)
+
+	case (aTy.IsListType() || aTy.IsTupleType()) && (bTy.IsListType() || bTy.IsTupleType()):
+		if a.LengthInt() != b.LengthInt() {
+			return false
+		}
+		for i := 0; i < a.LengthInt(); i++ {
+			idx := cty.NumberIntVal(int64(i))
+			if !normalizationEquivalent(a.Index(idx), b.Index(idx)) {
+				return false
+			}
+		}
+		return true
+
+	case (aTy.IsMapType() || aTy.IsObjectType()) && (bTy.IsMapType() || bTy.IsObjectType()):
+		aMap, bMap := a.AsValueMap(), b.AsValueMap()
+		for key, aElem := range aMap {
+			bElem, ok := bMap[key]
+			if !ok {
+				bElem = cty.NullVal(aElem.Type())
+			}
+			if !normalizationEquivalent(aElem, bElem) {
+				return false
+			}
+		}
+		for key, bElem := range bMap {
+			if _, ok := aMap[key]; !ok && !isEmptyValue(bElem) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		// The elements of sets can't be matched up with one another, so
+		// sets and any other values must be exactly equal.
+		return a.RawEquals(b)
+	}
+}
+
+// isEmptyValue returns true if the given value is null, an empty string or
+// an empty collection.
+func isEmptyValue(v cty.Value) bool {
+	if v.IsNull() {
+		return true
+	}
+	if !v.IsKnown() {
+		return false
+	}
+	ty := v.Type()
+	switch {
+	case ty == cty.String:
+		return v.AsString() == ""
+	case ty.IsCollectionType() || ty.IsTupleType():
+		return v.LengthInt() == 0
+	default:
+		return false
+	}
+}
+
+// isJSONDocument returns true if the given string looks like a JSON object or
+// array, as opposed to a string that only happens to be valid JSON, such as
+// a number.
+func isJSONDocument(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")
+}
+
+// jsonEquivalent returns true if the two given strings are valid JSON that
+// represent the same value.
+func jsonEquivalent(a, b string) bool {
+	var aVal, bVal interface{}
+	if err := json.Unmarshal([]byte(a), &aVal); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bVal); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aVal, bVal)
+}
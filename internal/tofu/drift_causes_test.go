@@ -0,0 +1,175 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestDriftCauses_schemaUpgrade(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"arg":    {Type: cty.String, Optional: true},
+			"region": {Type: cty.String, Computed: true},
+		},
+	}
+	oldVal := cty.ObjectVal(map[string]cty.Value{
+		"arg":    cty.StringVal("upgraded"),
+		"region": cty.NullVal(cty.String),
+	})
+	newVal := cty.ObjectVal(map[string]cty.Value{
+		"arg":    cty.StringVal("changed"),
+		"region": cty.StringVal("us-east-1"),
+	})
+
+	tests := map[string]struct {
+		inputObj *states.ResourceInstanceObjectSrc
+		want     map[string]plans.DriftCause
+	}{
+		"attribute added by the upgrade": {
+			&states.ResourceInstanceObjectSrc{
+				SchemaVersion: 0,
+				AttrsJSON:     []byte(`{"arg":"original"}`),
+			},
+			map[string]plans.DriftCause{
+				// The upgrade changed arg too, but the old value already
+				// reflects that, so the remaining change is drift.
+				"arg":    plans.DriftCauseProvider,
+				"region": plans.DriftCauseSchemaUpgrade,
+			},
+		},
+		"no upgrade": {
+			&states.ResourceInstanceObjectSrc{
+				SchemaVersion: 1,
+				AttrsJSON:     []byte(`{"arg":"upgraded"}`),
+			},
+			map[string]plans.DriftCause{
+				"arg":    plans.DriftCauseProvider,
+				"region": plans.DriftCauseProvider,
+			},
+		},
+		"legacy flatmap object": {
+			&states.ResourceInstanceObjectSrc{
+				SchemaVersion: 0,
+				AttrsFlat:     map[string]string{"arg": "original"},
+			},
+			map[string]plans.DriftCause{
+				"arg":    plans.DriftCauseProvider,
+				"region": plans.DriftCauseProvider,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := driftCauses(schema, 1, test.inputObj, oldVal, newVal)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizationEquivalent(t *testing.T) {
+	tests := map[string]struct {
+		a, b cty.Value
+		want bool
+	}{
+		"null and empty string": {
+			cty.NullVal(cty.String),
+			cty.StringVal(""),
+			true,
+		},
+		"null and empty list": {
+			cty.NullVal(cty.List(cty.String)),
+			cty.ListValEmpty(cty.String),
+			true,
+		},
+		"null and non-empty string": {
+			cty.NullVal(cty.String),
+			cty.StringVal("a"),
+			false,
+		},
+		"surrounding whitespace": {
+			cty.StringVal("value\n"),
+			cty.StringVal("value"),
+			true,
+		},
+		"reformatted JSON": {
+			cty.StringVal(`{"b": [1, 2], "a": "x"}`),
+			cty.StringVal(`{"a":"x","b":[1,2]}`),
+			true,
+		},
+		"different JSON": {
+			cty.StringVal(`{"a": "x"}`),
+			cty.StringVal(`{"a": "y"}`),
+			false,
+		},
+		"JSON scalars": {
+			cty.StringVal(`1.0`),
+			cty.StringVal(`1`),
+			false,
+		},
+		"different strings": {
+			cty.StringVal("a"),
+			cty.StringVal("b"),
+			false,
+		},
+		"map with new empty element": {
+			cty.MapVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+			}),
+			cty.MapVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+				"b": cty.StringVal(""),
+			}),
+			true,
+		},
+		"map with new element": {
+			cty.MapVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+			}),
+			cty.MapVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+				"b": cty.StringVal("y"),
+			}),
+			false,
+		},
+		"list elements": {
+			cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"name": cty.NullVal(cty.String),
+				}),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal(""),
+				}),
+			}),
+			true,
+		},
+		"different numbers": {
+			cty.NumberIntVal(1),
+			cty.NumberIntVal(2),
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizationEquivalent(test.a, test.b); got != test.want {
+				t.Errorf("wrong result %t; want %t", got, test.want)
+			}
+			if got := normalizationEquivalent(test.b, test.a); got != test.want {
+				t.Errorf("wrong result %t with the values swapped; want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
@@ -67,6 +68,91 @@ func evalCheckRules(typ addrs.CheckRuleType, rules []*configs.CheckRule, ctx Eva
 	return diags
 }
 
+// evalPostconditionsWithRetry evaluates the postconditions of a resource
+// instance after apply, like evalCheckRules, but honors the retry settings of
+// the postconditions.
+//
+// If every failing postcondition has retry attempts left, it waits for the
+// longest of their intervals, calls reread to fetch a fresh result for the
+// resource instance, and evaluates the postconditions again. A nil reread
+// disables retrying.
+func evalPostconditionsWithRetry(rules []*configs.CheckRule, ctx EvalContext, self addrs.AbsResourceInstance, keyData instances.RepetitionData, reread func() tfdiags.Diagnostics) tfdiags.Diagnostics {
+	retryable := false
+	for _, rule := range rules {
+		if rule.Retry != nil {
+			retryable = true
+		}
+	}
+	if reread == nil || !retryable {
+		return evalCheckRules(addrs.ResourcePostcondition, rules, ctx, self, keyData, tfdiags.Error)
+	}
+
+	results := make([]checkResult, len(rules))
+	report := func() {
+		checkState := ctx.Checks()
+		for i, result := range results {
+			if result.Status == checks.StatusFail {
+				checkState.ReportCheckFailure(self, addrs.ResourcePostcondition, i, result.FailureMessage)
+			} else {
+				checkState.ReportCheckResult(self, addrs.ResourcePostcondition, i, result.Status)
+			}
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		var diags tfdiags.Diagnostics
+		for i, rule := range rules {
+			result, ruleDiags := evalCheckRule(addrs.NewCheckRule(self, addrs.ResourcePostcondition, i), rule, ctx, keyData, hcl.DiagError)
+			diags = diags.Append(ruleDiags)
+			results[i] = result
+		}
+
+		wait, retry := postconditionRetryWait(rules, results, attempt)
+		if !retry {
+			report()
+			return diags
+		}
+
+		log.Printf("[TRACE] evalPostconditionsWithRetry: %s postconditions failed on attempt %d; retrying in %s", self, attempt, wait)
+		select {
+		case <-ctx.Stopped():
+			report()
+			return diags
+		case <-time.After(wait):
+		}
+
+		if rereadDiags := reread(); rereadDiags.HasErrors() {
+			report()
+			return diags.Append(rereadDiags)
+		}
+	}
+}
+
+// postconditionRetryWait decides whether failed postconditions should be
+// evaluated again after the given attempt, and how long to wait first. It
+// retries only if at least one postcondition failed and every failing one
+// has attempts left; errors in the conditions themselves are never retried.
+func postconditionRetryWait(rules []*configs.CheckRule, results []checkResult, attempt int) (time.Duration, bool) {
+	var wait time.Duration
+	failed := false
+	for i, result := range results {
+		switch result.Status {
+		case checks.StatusFail:
+			retry := rules[i].Retry
+			if retry == nil || attempt >= retry.Attempts {
+				return 0, false
+			}
+			failed = true
+			if retry.Interval > wait {
+				wait = retry.Interval
+			}
+		case checks.StatusError:
+			return 0, false
+		}
+	}
+	return wait, failed
+}
+
 type checkResult struct {
 	Status         checks.Status
 	FailureMessage string
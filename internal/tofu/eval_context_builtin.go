@@ -496,6 +496,9 @@ func (ctx *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source
 		return evalContextProviderFunction(provider, ctx.Evaluator.Operation, pf, rng)
 	})
 	scope.SetActiveExperiments(mc.Module.ActiveExperiments)
+	if mc.Module.StrictConversions != nil {
+		scope.StrictConversions = mc.Module.StrictConversions.Severity
+	}
 
 	return scope
 }
@@ -0,0 +1,101 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/instances"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// readDataSourceWaiting reads the data source like readDataSource, but if the
+// resource has a wait_for block it keeps reading it, with growing intervals
+// between reads, until the wait_for condition holds or the timeout passes.
+func (n *NodeAbstractResourceInstance) readDataSourceWaiting(ctx EvalContext, configVal cty.Value, keyData instances.RepetitionData) (cty.Value, tfdiags.Diagnostics) {
+	waitFor := n.Config.WaitFor
+	if waitFor == nil {
+		return n.readDataSource(ctx, configVal)
+	}
+
+	deadline := time.Now().Add(waitFor.Timeout)
+	interval := waitFor.Interval
+	for attempt := 1; ; attempt++ {
+		newVal, diags := n.readDataSource(ctx, configVal)
+		if diags.HasErrors() {
+			return newVal, diags
+		}
+
+		done, condDiags := evalDataWaitForCondition(ctx, waitFor, newVal, keyData)
+		diags = diags.Append(condDiags)
+		if done || diags.HasErrors() {
+			return newVal, diags
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return newVal, diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Timed out waiting for data source",
+				Detail:   fmt.Sprintf("The wait_for condition for %s was still false after reading the data source %d times over %s.", n.Addr, attempt, waitFor.Timeout),
+				Subject:  waitFor.DeclRange.Ptr(),
+			})
+		}
+
+		wait := min(interval, remaining)
+		log.Printf("[TRACE] readDataSourceWaiting: wait_for condition for %s is false after attempt %d; reading again in %s", n.Addr, attempt, wait)
+		select {
+		case <-ctx.Stopped():
+			return newVal, diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Interrupted while waiting for data source",
+				Detail:   fmt.Sprintf("OpenTofu was interrupted before the wait_for condition for %s became true.", n.Addr),
+				Subject:  waitFor.DeclRange.Ptr(),
+			})
+		case <-time.After(wait):
+		}
+		interval = time.Duration(float64(interval) * waitFor.Backoff)
+	}
+}
+
+// evalDataWaitForCondition returns true if the wait_for condition holds for
+// the given data source result.
+func evalDataWaitForCondition(ctx EvalContext, waitFor *configs.DataWaitFor, val cty.Value, keyData instances.RepetitionData) (bool, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	scope := ctx.EvaluationScope(nil, nil, keyData)
+	result, moreDiags := scope.EvalSelfExpr(waitFor.Condition, val, cty.Bool, keyData)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return false, diags
+	}
+
+	// The condition result may be marked if the expression refers to a
+	// sensitive value.
+	result, _ = result.Unmark()
+
+	switch {
+	case result.IsNull():
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity:   hcl.DiagError,
+			Summary:    "Invalid wait_for condition result",
+			Detail:     "Condition expression must return either true or false, not null.",
+			Subject:    waitFor.Condition.Range().Ptr(),
+			Expression: waitFor.Condition,
+		})
+		return false, diags
+	case !result.IsKnown():
+		// The result of a data source read is always known, so this should
+		// not happen, but there's nothing to wait for if it does.
+		log.Printf("[WARN] evalDataWaitForCondition: wait_for condition result is unknown")
+		return true, diags
+	}
+	return result.True(), diags
+}
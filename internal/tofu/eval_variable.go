@@ -24,6 +24,40 @@ import (
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
+// mergeVariableDefault returns the given value with any object attributes
+// that are missing or null, at any depth, taken from the default value.
+//
+// Only objects are merged: a given list, set, map or primitive value replaces
+// the corresponding default value entirely.
+func mergeVariableDefault(given, defaultVal cty.Value) cty.Value {
+	if given.IsNull() {
+		return defaultVal
+	}
+	if defaultVal == cty.NilVal || defaultVal.IsNull() || !defaultVal.IsKnown() || !defaultVal.Type().IsObjectType() {
+		return given
+	}
+
+	given, givenMarks := given.Unmark()
+	if !given.IsKnown() || !(given.Type().IsObjectType() || given.Type().IsMapType()) {
+		return given.WithMarks(givenMarks)
+	}
+
+	attrs := make(map[string]cty.Value)
+	for it := given.ElementIterator(); it.Next(); {
+		k, v := it.Element()
+		attrs[k.AsString()] = v
+	}
+	for name := range defaultVal.Type().AttributeTypes() {
+		defaultAttr := defaultVal.GetAttr(name)
+		if givenAttr, exists := attrs[name]; exists {
+			attrs[name] = mergeVariableDefault(givenAttr, defaultAttr)
+		} else {
+			attrs[name] = defaultAttr
+		}
+	}
+	return cty.ObjectVal(attrs).WithMarks(givenMarks)
+}
+
 func prepareFinalInputVariableValue(addr addrs.AbsInputVariableInstance, raw *InputValue, cfg *configs.Variable) (cty.Value, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
@@ -97,6 +131,11 @@ func prepareFinalInputVariableValue(addr addrs.AbsInputVariableInstance, raw *In
 		}
 
 		given = defaultVal // must be set, because we checked above that the variable isn't required
+	} else if cfg.MergeDefault && !given.IsNull() {
+		// Fill in whatever the given value leaves out from the default value,
+		// before applying the type constraint defaults so that a value in the
+		// variable's default takes precedence over an optional attribute's.
+		given = mergeVariableDefault(given, defaultVal)
 	}
 
 	// Apply defaults from the variable's type constraint to the converted value,
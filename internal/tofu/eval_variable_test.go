@@ -1067,6 +1067,114 @@ func TestPrepareFinalInputVariableValue(t *testing.T) {
 	})
 }
 
+func TestPrepareFinalInputVariableValue_mergeDefault(t *testing.T) {
+	cfg := testModuleInline(t, map[string]string{
+		"main.tf": `
+			variable "settings" {
+				type = object({
+					name    = string
+					size    = optional(number, 1)
+					network = optional(object({
+						subnet = optional(string)
+						public = optional(bool)
+					}))
+					tags = optional(map(string))
+				})
+				default = {
+					name = "default"
+					size = 2
+					network = {
+						subnet = "internal"
+						public = false
+					}
+					tags = {
+						owner = "platform"
+					}
+				}
+				merge_default = true
+			}
+		`,
+	})
+	varCfg := cfg.Module.Variables["settings"]
+	varAddr := addrs.InputVariable{Name: "settings"}.Absolute(addrs.RootModuleInstance)
+
+	tests := map[string]struct {
+		given cty.Value
+		want  cty.Value
+	}{
+		"partial object": {
+			cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("web"),
+				"network": cty.ObjectVal(map[string]cty.Value{
+					"public": cty.True,
+				}),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("web"),
+				"size": cty.NumberIntVal(2),
+				"network": cty.ObjectVal(map[string]cty.Value{
+					"subnet": cty.StringVal("internal"),
+					"public": cty.True,
+				}),
+				"tags": cty.MapVal(map[string]cty.Value{
+					"owner": cty.StringVal("platform"),
+				}),
+			}),
+		},
+		"null attributes": {
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("web"),
+				"size":    cty.NullVal(cty.Number),
+				"network": cty.NullVal(cty.DynamicPseudoType),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("web"),
+				"size": cty.NumberIntVal(2),
+				"network": cty.ObjectVal(map[string]cty.Value{
+					"subnet": cty.StringVal("internal"),
+					"public": cty.False,
+				}),
+				"tags": cty.MapVal(map[string]cty.Value{
+					"owner": cty.StringVal("platform"),
+				}),
+			}),
+		},
+		"maps are replaced": {
+			cty.ObjectVal(map[string]cty.Value{
+				"tags": cty.ObjectVal(map[string]cty.Value{
+					"team": cty.StringVal("web"),
+				}),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("default"),
+				"size": cty.NumberIntVal(2),
+				"network": cty.ObjectVal(map[string]cty.Value{
+					"subnet": cty.StringVal("internal"),
+					"public": cty.False,
+				}),
+				"tags": cty.MapVal(map[string]cty.Value{
+					"team": cty.StringVal("web"),
+				}),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := prepareFinalInputVariableValue(varAddr, &InputValue{
+				Value:      test.given,
+				SourceType: ValueFromCaller,
+			}, varCfg)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Err())
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
 // These tests cover the JSON syntax configuration edge case handling,
 // the background of which is described in detail in comments in the
 // evalVariableValidations function. Future versions of OpenTofu may
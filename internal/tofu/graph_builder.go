@@ -63,7 +63,18 @@ func (b *BasicGraphBuilder) Build(path addrs.ModuleInstance) (*Graph, tfdiags.Di
 		}
 	}
 
-	if err := g.Validate(); err != nil {
+	// Cycles are the most likely validation failure to be caused by the
+	// user's configuration, so we describe them in more detail than
+	// g.Validate would. Finding them is expensive for large graphs, so we
+	// do it only once.
+	cycles := g.Cycles()
+	if cycleDiags := cycleDiagnostics(g, cycles); cycleDiags.HasErrors() {
+		log.Printf("[ERROR] Graph validation failed. Graph:\n\n%s", g.String())
+		diags = diags.Append(cycleDiags)
+		return nil, diags
+	}
+
+	if err := g.ValidateWithCycles(cycles); err != nil {
 		log.Printf("[ERROR] Graph validation failed. Graph:\n\n%s", g.String())
 		diags = diags.Append(err)
 		return nil, diags
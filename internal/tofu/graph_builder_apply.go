@@ -186,6 +186,7 @@ func (b *ApplyGraphBuilder) Steps() []GraphTransformer {
 		&DestroyEdgeTransformer{
 			Changes:   b.Changes,
 			Operation: b.Operation,
+			Config:    b.Config,
 		},
 		&CBDEdgeTransformer{},
 
@@ -54,6 +54,10 @@ type PlanGraphBuilder struct {
 	// action instead. Create and Delete actions are not affected.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// ReplaceDependents causes resource instances that depend on any of the
+	// instances in ForceReplace to be replaced too.
+	ReplaceDependents bool
+
 	// skipRefresh indicates that we should skip refreshing managed resources
 	skipRefresh bool
 
@@ -232,6 +236,7 @@ func (b *PlanGraphBuilder) Steps() []GraphTransformer {
 		// TargetingTransformer can determine which nodes to keep in the graph.
 		&DestroyEdgeTransformer{
 			Operation: b.Operation,
+			Config:    b.Config,
 		},
 
 		&pruneUnusedNodesTransformer{
@@ -275,6 +280,7 @@ func (b *PlanGraphBuilder) initPlan() {
 			skipPlanChanges:      b.skipPlanChanges,
 			preDestroyRefresh:    b.preDestroyRefresh,
 			forceReplace:         b.ForceReplace,
+			replaceDependents:    b.ReplaceDependents,
 		}
 	}
 
@@ -0,0 +1,192 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// cycleDiagnostics returns an error diagnostic for each of the given cycles
+// in the given graph, as returned by g.Cycles, or no diagnostics at all if
+// there are no cycles.
+//
+// Each diagnostic lists the members of the cycle in order, along with the
+// references in the configuration that created each of the edges between
+// them, so that the user has some hope of working out which reference to
+// remove to break the cycle.
+func cycleDiagnostics(g *Graph, cycles [][]dag.Vertex) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if len(cycles) == 0 {
+		return diags
+	}
+
+	refMap := NewReferenceMap(g.Vertices())
+	for _, cycle := range cycles {
+		path := cyclePath(g, cycle)
+
+		names := make([]string, len(path))
+		for i, v := range path {
+			names[i] = dag.VertexName(v)
+		}
+
+		var detail strings.Builder
+		var subject *hcl.Range
+		var viaDependsOn, viaDestroy bool
+		detail.WriteString("OpenTofu can't determine an order in which to process these objects, because each of them depends on the next:\n")
+		for i, from := range path {
+			to := path[(i+1)%len(path)]
+			if _, ok := from.(GraphNodeDestroyer); ok {
+				viaDestroy = true
+			}
+
+			refs, dependsOn := cycleEdgeReferences(refMap, from, to)
+			if len(refs) == 0 {
+				fmt.Fprintf(&detail, "\n  - %s depends on %s", dag.VertexName(from), dag.VertexName(to))
+				continue
+			}
+			for _, ref := range refs {
+				how := "references"
+				if dependsOn[ref] {
+					how = "has depends_on for"
+					viaDependsOn = true
+				}
+				fmt.Fprintf(&detail, "\n  - %s %s %s at %s", dag.VertexName(from), how, ref.Subject, ref.SourceRange.StartString())
+				if subject == nil {
+					subject = ref.SourceRange.ToHCL().Ptr()
+				}
+			}
+		}
+
+		detail.WriteString("\n\nTo break the cycle, remove or change one of the references above so that the objects no longer depend on each other.")
+		if viaDependsOn {
+			detail.WriteString(" Entries in depends_on are often broader than necessary, so consider whether each of them is really required.")
+		}
+		if viaDestroy {
+			detail.WriteString(" This cycle involves destroying objects, which can happen when only some of the resources that depend on each other use create_before_destroy. Consider setting create_before_destroy consistently on all of them.")
+		}
+
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Cycle: %s", strings.Join(names, ", ")),
+			Detail:   detail.String(),
+			Subject:  subject,
+		})
+	}
+
+	return diags
+}
+
+// cyclePath returns the members of the given strongly-connected component
+// of the graph as a path in which each vertex depends on the next, and the
+// last vertex depends on the first.
+//
+// The path starts at the member with the lowest name and follows the
+// shortest route back to it, so the result is consistent between runs.
+func cyclePath(g *Graph, members []dag.Vertex) []dag.Vertex {
+	inCycle := make(map[dag.Vertex]bool, len(members))
+	for _, v := range members {
+		inCycle[v] = true
+	}
+
+	start := members[0]
+	for _, v := range members[1:] {
+		if dag.VertexName(v) < dag.VertexName(start) {
+			start = v
+		}
+	}
+
+	// Breadth-first search from the start back to itself, remembering how we
+	// reached each vertex so we can reconstruct the path afterwards.
+	prev := make(map[dag.Vertex]dag.Vertex)
+	queue := []dag.Vertex{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		for _, next := range sortedDownEdges(g, v) {
+			if !inCycle[next] {
+				continue
+			}
+			if next == start {
+				path := []dag.Vertex{v}
+				for v != start {
+					v = prev[v]
+					path = append(path, v)
+				}
+				// We built the path backwards from its end.
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := prev[next]; seen {
+				continue
+			}
+			prev[next] = v
+			queue = append(queue, next)
+		}
+	}
+
+	// Should never get here for a strongly-connected component, but we'll
+	// fall back on the members in their original order if we do.
+	return members
+}
+
+func sortedDownEdges(g *Graph, v dag.Vertex) []dag.Vertex {
+	ret := dag.AsVertexList(g.DownEdges(v))
+	sort.Slice(ret, func(i, j int) bool {
+		return dag.VertexName(ret[i]) < dag.VertexName(ret[j])
+	})
+	return ret
+}
+
+// cycleEdgeReferences returns the references from one vertex that caused it
+// to depend on another, along with a set of those references that came from
+// a depends_on argument.
+//
+// The result is empty if the dependency was created by something other than
+// a reference, such as the ordering rules for destroying objects.
+func cycleEdgeReferences(refMap ReferenceMap, from, to dag.Vertex) ([]*addrs.Reference, map[*addrs.Reference]bool) {
+	rn, ok := from.(GraphNodeReferencer)
+	if !ok {
+		return nil, nil
+	}
+	if _, ok := from.(GraphNodeModulePath); !ok {
+		return nil, nil
+	}
+
+	var dependsOnRanges []tfdiags.SourceRange
+	if dn, ok := from.(graphNodeDependsOn); ok {
+		for _, ref := range dn.DependsOn() {
+			dependsOnRanges = append(dependsOnRanges, ref.SourceRange)
+		}
+	}
+
+	var refs []*addrs.Reference
+	dependsOn := make(map[*addrs.Reference]bool)
+	for _, ref := range rn.References() {
+		for _, match := range refMap.addReference(vertexReferencePath(from), from, ref) {
+			if match != to {
+				continue
+			}
+			refs = append(refs, ref)
+			for _, rng := range dependsOnRanges {
+				if rng.Equal(&ref.SourceRange) {
+					dependsOn[ref] = true
+				}
+			}
+			break
+		}
+	}
+	return refs, dependsOn
+}
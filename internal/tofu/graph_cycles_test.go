@@ -0,0 +1,62 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestContext2Plan_cycleDiagnostic(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  test_string = test_object.b.test_string
+}
+
+resource "test_object" "b" {
+  depends_on = [test_object.a]
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want cycle error")
+	}
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Err())
+	}
+
+	desc := diags[0].Description()
+	if got, want := desc.Summary, "Cycle: test_object.a (expand), test_object.b (expand)"; got != want {
+		t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+	}
+	for _, want := range []string{
+		"test_object.a (expand) references test_object.b at ",
+		"main.tf:3,17",
+		"test_object.b (expand) has depends_on for test_object.a at ",
+		"main.tf:7,17",
+		"consider whether each of them is really required",
+	} {
+		if !strings.Contains(desc.Detail, want) {
+			t.Errorf("detail is missing %q\n%s", want, desc.Detail)
+		}
+	}
+	if subj := diags[0].Source().Subject; subj == nil || subj.Start.Line != 3 {
+		t.Errorf("wrong subject %#v; want line 3", subj)
+	}
+}
@@ -176,6 +176,10 @@ func (n *nodeCloseModule) ReferenceOutside() (selfPath, referencePath addrs.Modu
 }
 
 func (n *nodeCloseModule) ReferenceableAddrs() []addrs.Referenceable {
+	if n.Addr.IsRoot() {
+		// The root module can't be referenced, since it has no call.
+		return nil
+	}
 	_, call := n.Addr.Call()
 	return []addrs.Referenceable{
 		call,
@@ -273,6 +277,7 @@ func (n *nodeValidateModule) Execute(ctx EvalContext, op walkOperation) (diags t
 		}
 
 		diags = diags.Append(validateDependsOn(ctx, n.ModuleCall.DependsOn))
+		diags = diags.Append(validateDestroyAfter(ctx, n.ModuleCall.DestroyAfter))
 
 		// now set our own mode to single
 		expander.SetModuleSingle(module, call)
@@ -220,6 +220,12 @@ func (n *nodeModuleVariable) evalModuleVariable(ctx EvalContext, validateOnly bo
 		}
 		givenVal = val
 		errSourceRange = tfdiags.SourceRangeFromHCL(expr.Range())
+
+		// The calling module's strict_conversions setting applies, because
+		// that's where the expression is.
+		if scope.StrictConversions != hcl.DiagInvalid {
+			diags = diags.Append(lang.ImplicitConversionDiagnostics(scope.StrictConversions, val.Type(), n.Config.ConstraintType, expr.Range()))
+		}
 	} else {
 		// We'll use cty.NilVal to represent the variable not being set at all.
 		givenVal = cty.NilVal
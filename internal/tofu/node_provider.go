@@ -84,16 +84,68 @@ func (n *NodeApplyableProvider) executeInstance(ctx EvalContext, op walkOperatio
 	case walkValidate:
 		log.Printf("[TRACE] NodeApplyableProvider: validating configuration for %s", n.Addr)
 		return n.ValidateProvider(ctx, providerKey, provider)
-	case walkPlan, walkPlanDestroy, walkApply, walkDestroy:
+	case walkPlan, walkPlanDestroy:
+		log.Printf("[TRACE] NodeApplyableProvider: configuring %s", n.Addr)
+		return n.ConfigureProvider(ctx, providerKey, provider, false)
+	case walkApply, walkDestroy:
+		if diags := n.checkNotPlanOnly(ctx, providerKey, "apply changes"); diags.HasErrors() {
+			return diags
+		}
 		log.Printf("[TRACE] NodeApplyableProvider: configuring %s", n.Addr)
 		return n.ConfigureProvider(ctx, providerKey, provider, false)
 	case walkImport:
+		if diags := n.checkNotPlanOnly(ctx, providerKey, "import resources"); diags.HasErrors() {
+			return diags
+		}
 		log.Printf("[TRACE] NodeApplyableProvider: configuring %s (requiring that configuration is wholly known)", n.Addr)
 		return n.ConfigureProvider(ctx, providerKey, provider, true)
 	}
 	return nil
 }
 
+// checkNotPlanOnly returns an error if the provider configuration declares
+// that it may only be used for planning, in which case it must not be
+// configured for an operation that could change remote objects or state.
+// The action describes that operation, for the error message.
+func (n *NodeApplyableProvider) checkNotPlanOnly(ctx EvalContext, providerKey addrs.InstanceKey, action string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	config := n.ProviderConfig()
+	if config == nil || config.PlanOnly == nil {
+		return diags
+	}
+
+	data := EvalDataForNoInstanceKey
+	if config.Instances != nil {
+		data = config.Instances[providerKey]
+	}
+	scope := ctx.EvaluationScope(nil, nil, data)
+	val, evalDiags := scope.EvalExpr(config.PlanOnly, cty.Bool)
+	diags = diags.Append(evalDiags)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	val, _ = val.Unmark()
+	if val.IsNull() || !val.IsKnown() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid plan_only value",
+			Detail:   "The plan_only argument must be either true or false, and must be known before apply.",
+			Subject:  config.PlanOnly.Range().Ptr(),
+		})
+		return diags
+	}
+	if val.True() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Provider configuration is plan-only",
+			Detail:   fmt.Sprintf("The configuration for %s declares that it may only be used for planning, so OpenTofu cannot use it to %s.", n.Addr.InstanceString(providerKey), action),
+			Subject:  config.PlanOnly.Range().Ptr(),
+		})
+	}
+	return diags
+}
+
 func (n *NodeApplyableProvider) ValidateProvider(ctx EvalContext, providerKey addrs.InstanceKey, provider providers.Interface) tfdiags.Diagnostics {
 	configBody := buildProviderConfig(ctx, n.Addr, n.ProviderConfig())
 
@@ -1906,7 +1906,7 @@ func (n *NodeAbstractResourceInstance) planDataSource(ctx EvalContext, checkRule
 
 	// We have a complete configuration with no dependencies to wait on, so we
 	// can read the data source into the state.
-	newVal, readDiags := n.readDataSource(ctx, configVal)
+	newVal, readDiags := n.readDataSourceWaiting(ctx, configVal, keyData)
 
 	// Now we've loaded the data, and diags tells us whether we were successful
 	// or not, we are going to create our plannedChange and our
@@ -2105,7 +2105,7 @@ func (n *NodeAbstractResourceInstance) applyDataSource(ctx EvalContext, planned
 		return nil, keyData, diags
 	}
 
-	newVal, readDiags := n.readDataSource(ctx, configVal)
+	newVal, readDiags := n.readDataSourceWaiting(ctx, configVal, keyData)
 	if check, nested := n.nestedInCheckBlock(); nested {
 		addr := check.Addr().Absolute(n.Addr.Module)
 
@@ -2310,8 +2310,11 @@ func (n *NodeAbstractResourceInstance) applyProvisioners(ctx EvalContext, state
 			}
 		}
 
-		// The output function
+		// The output function, which removes anything matching the
+		// provisioner's redact_output patterns before the hooks can show or
+		// record it.
 		outputFn := func(msg string) {
+			msg = redactProvisionerOutput(prov.RedactOutput, msg)
 			ctx.Hook(func(h Hook) (HookAction, error) {
 				h.ProvisionOutput(n.Addr, prov.Type, msg)
 				return HookActionContinue, nil
@@ -2344,7 +2347,7 @@ func (n *NodeAbstractResourceInstance) applyProvisioners(ctx EvalContext, state
 			Connection: unmarkedConnInfo,
 			UIOutput:   &output,
 		})
-		applyDiags := resp.Diagnostics.InConfigBody(prov.Config, n.Addr.String())
+		applyDiags := redactProvisionerDiags(prov.RedactOutput, resp.Diagnostics.InConfigBody(prov.Config, n.Addr.String()))
 
 		// Call post hook
 		hookErr := ctx.Hook(func(h Hook) (HookAction, error) {
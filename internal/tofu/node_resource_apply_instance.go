@@ -206,12 +206,27 @@ func (n *NodeApplyableResourceInstance) dataResourceExecute(ctx EvalContext) (di
 	// _after_ writing the state/diff because we want to check against
 	// the result of the operation, and to fail on future operations
 	// until the user makes the condition succeed.
-	checkDiags := evalCheckRules(
-		addrs.ResourcePostcondition,
+	//
+	// Postconditions with retry settings can only be retried if we actually
+	// read the data source during apply, because otherwise their result was
+	// already decided during planning.
+	var reread func() tfdiags.Diagnostics
+	if change.Action == plans.Read {
+		reread = func() (diags tfdiags.Diagnostics) {
+			state, _, applyDiags := n.applyDataSource(ctx, change)
+			diags = diags.Append(applyDiags)
+			if diags.HasErrors() || state == nil {
+				return diags
+			}
+			diags = diags.Append(n.writeResourceInstanceState(ctx, state, workingState))
+			return diags.Append(updateStateHook(ctx))
+		}
+	}
+	checkDiags := evalPostconditionsWithRetry(
 		n.Config.Postconditions,
 		ctx, n.ResourceInstanceAddr(),
 		repeatData,
-		tfdiags.Error,
+		reread,
 	)
 	diags = diags.Append(checkDiags)
 
@@ -392,12 +407,28 @@ func (n *NodeApplyableResourceInstance) managedResourceExecute(ctx EvalContext)
 }
 
 func (n *NodeApplyableResourceInstance) managedResourcePostconditions(ctx EvalContext, repeatData instances.RepetitionData) (diags tfdiags.Diagnostics) {
+	// Postconditions with retry settings are evaluated again against a
+	// refreshed copy of the object, to give remote changes time to settle.
+	reread := func() (diags tfdiags.Diagnostics) {
+		addr := n.ResourceInstanceAddr()
+		state, readDiags := n.readResourceInstanceState(ctx, addr)
+		diags = diags.Append(readDiags)
+		if diags.HasErrors() || state == nil {
+			return diags
+		}
+		state, refreshDiags := n.refresh(ctx, states.NotDeposed, state)
+		diags = diags.Append(refreshDiags)
+		if diags.HasErrors() {
+			return diags
+		}
+		diags = diags.Append(n.writeResourceInstanceState(ctx, state, workingState))
+		return diags.Append(updateStateHook(ctx))
+	}
 
-	checkDiags := evalCheckRules(
-		addrs.ResourcePostcondition,
+	checkDiags := evalPostconditionsWithRetry(
 		n.Config.Postconditions,
 		ctx, n.ResourceInstanceAddr(), repeatData,
-		tfdiags.Error,
+		reread,
 	)
 	return diags.Append(checkDiags)
 }
@@ -41,6 +41,11 @@ type nodeExpandPlannableResource struct {
 	// that this node represents, which the node itself must therefore ignore.
 	forceReplace []addrs.AbsResourceInstance
 
+	// replaceDependents indicates that instances of this resource should be
+	// replaced if any of their dependencies are being replaced because of
+	// forceReplace.
+	replaceDependents bool
+
 	// We attach dependencies to the Resource during refresh, since the
 	// instances are instantiated during DynamicExpand.
 	// FIXME: These would be better off converted to a generic Set data
@@ -371,6 +376,7 @@ func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx EvalContext,
 			skipRefresh:              n.skipRefresh,
 			skipPlanChanges:          n.skipPlanChanges,
 			forceReplace:             n.forceReplace,
+			replaceDependents:        n.replaceDependents,
 		}
 
 		resolvedImportTarget := ctx.ImportResolver().GetImport(a.Addr)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"slices"
 	"sort"
 
 	"github.com/hashicorp/hcl/v2"
@@ -20,7 +21,9 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/genconfig"
 	"github.com/opentofu/opentofu/internal/instances"
+	"github.com/opentofu/opentofu/internal/lang"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/plans/objchange"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -46,6 +49,10 @@ type NodePlannableResourceInstance struct {
 	// that this node represents, which the node itself must therefore ignore.
 	forceReplace []addrs.AbsResourceInstance
 
+	// replaceDependents indicates that this instance should be replaced if
+	// any of its dependencies are being replaced at the user's request.
+	replaceDependents bool
+
 	// replaceTriggeredBy stores references from replace_triggered_by which
 	// triggered this instance to be replaced.
 	replaceTriggeredBy []*addrs.Reference
@@ -299,9 +306,21 @@ func (n *NodePlannableResourceInstance) managedResourceExecute(ctx EvalContext)
 		if diags.HasErrors() {
 			return diags
 		}
+		forceReplace := n.forceReplace
+		replaceDependent, replaceDiags := n.replaceDependentTriggered(ctx, instanceRefreshState)
+		diags = diags.Append(replaceDiags)
+		if diags.HasErrors() {
+			return diags
+		}
+		if replaceDependent {
+			// n.forceReplace is shared with the other instances of this
+			// resource, which are planned concurrently, so we must not
+			// append to it in place.
+			forceReplace = append(slices.Clip(n.forceReplace), n.Addr)
+		}
 
 		change, instancePlanState, repeatData, planDiags := n.plan(
-			ctx, nil, instanceRefreshState, n.ForceCreateBeforeDestroy, n.forceReplace,
+			ctx, nil, instanceRefreshState, n.ForceCreateBeforeDestroy, forceReplace,
 		)
 		diags = diags.Append(planDiags)
 		if diags.HasErrors() {
@@ -473,6 +492,190 @@ func (n *NodePlannableResourceInstance) replaceTriggered(ctx EvalContext, repDat
 	return diags
 }
 
+// replaceDependentTriggered returns true if this instance needs to be
+// replaced because its configuration refers to a resource instance that is
+// planned to be replaced at the user's request, when the user asked for
+// dependents to be replaced too.
+//
+// Referring to a replaced instance isn't enough on its own: we evaluate our
+// configuration once more with the replaced instances entirely unknown, as
+// they will be until the new objects exist, and ask the provider to plan that
+// configuration. Only if the provider reports that one of the arguments derived
+// from the replaced instances requires replacement do we replace this instance.
+// This is decided for each instance separately, so instances of a resource
+// that only refer to instances which are not replaced are left alone.
+//
+// Our dependencies are always planned before we are, and any instance
+// replaced this way is itself recorded as replaced by request, so the
+// replacement propagates along the whole chain of dependents. Dependencies
+// that are unchanged, or replaced for any other reason, don't trigger a
+// replacement.
+func (n *NodePlannableResourceInstance) replaceDependentTriggered(ctx EvalContext, currentState *states.ResourceInstanceObject) (bool, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if !n.replaceDependents || n.Config == nil || slices.ContainsFunc(n.forceReplace, n.Addr.Equal) {
+		return false, diags
+	}
+	if currentState == nil || currentState.Value.IsNull() || currentState.Status == states.ObjectTainted {
+		// There is no existing object to replace.
+		return false, diags
+	}
+
+	// Our configuration can only refer directly to the instances in our own
+	// module instance.
+	replaced := addrs.MakeSet[addrs.ResourceInstance]()
+	changes := ctx.Changes()
+	for _, dep := range n.Dependencies {
+		for _, change := range changes.GetChangesForConfigResource(dep) {
+			if change.DeposedKey != states.NotDeposed || !change.Action.IsReplace() {
+				continue
+			}
+			if change.ActionReason != plans.ResourceInstanceReplaceByRequest || !change.Addr.Module.Equal(n.Addr.Module) {
+				continue
+			}
+			replaced.Add(change.Addr.Resource)
+		}
+	}
+	if len(replaced) == 0 {
+		return false, diags
+	}
+
+	provider, providerSchema, err := n.getProvider(ctx)
+	if err != nil {
+		return false, diags.Append(err)
+	}
+	schema, _ := providerSchema.SchemaForResourceAddr(n.Addr.Resource.Resource)
+	if schema == nil {
+		// Should be caught during validation, so we don't bother with a pretty error here
+		return false, diags.Append(fmt.Errorf("provider does not support resource type %q", n.Addr.Resource.Resource.Type))
+	}
+
+	forEach, _ := evaluateForEachExpression(n.Config.ForEach, ctx, n.Addr)
+	keyData := EvalDataForInstanceKey(n.ResourceInstanceAddr().Resource.Key, forEach)
+	configVal, _, configDiags := ctx.EvaluateBlock(n.Config.Config, schema, nil, keyData)
+	diags = diags.Append(configDiags)
+	scope := ctx.EvaluationScope(nil, nil, keyData)
+	scope.Data = &replacedInstancesData{Data: scope.Data, replaced: replaced}
+	probeVal, probeDiags := scope.EvalBlock(n.Config.Config, schema)
+	diags = diags.Append(probeDiags)
+	if diags.HasErrors() {
+		return false, diags
+	}
+	probeVal, ignoreDiags := n.processIgnoreChanges(currentState.Value, probeVal, schema)
+	diags = diags.Append(ignoreDiags)
+	if diags.HasErrors() {
+		return false, diags
+	}
+	configVal, _ = configVal.UnmarkDeep()
+	probeVal, _ = probeVal.UnmarkDeep()
+
+	// The arguments that are known in our configuration but unknown when
+	// the replaced instances are unknown are the ones derived from them.
+	var derived []cty.Path
+	cty.Walk(probeVal, func(path cty.Path, v cty.Value) (bool, error) {
+		if v.IsKnown() {
+			return true, nil
+		}
+		if orig, err := path.Apply(configVal); err == nil && orig.IsKnown() {
+			derived = append(derived, path.Copy())
+		}
+		return false, nil
+	})
+	if len(derived) == 0 {
+		return false, diags
+	}
+
+	metaConfigVal, metaDiags := n.providerMetas(ctx)
+	diags = diags.Append(metaDiags)
+	if diags.HasErrors() {
+		return false, diags
+	}
+	priorVal, _ := currentState.Value.UnmarkDeep()
+	resp := provider.PlanResourceChange(providers.PlanResourceChangeRequest{
+		TypeName:         n.Addr.Resource.Resource.Type,
+		Config:           probeVal,
+		PriorState:       priorVal,
+		ProposedNewState: objchange.ProposedNew(schema, priorVal, probeVal),
+		PriorPrivate:     currentState.Private,
+		ProviderMeta:     metaConfigVal,
+	})
+	diags = diags.Append(resp.Diagnostics.InConfigBody(n.Config.Config, n.Addr.String()))
+	if diags.HasErrors() {
+		return false, diags
+	}
+
+	for _, requiresReplace := range resp.RequiresReplace {
+		for _, path := range derived {
+			if pathHasPrefix(path, requiresReplace) || pathHasPrefix(requiresReplace, path) {
+				log.Printf("[DEBUG] ReplaceDependents forcing replacement of %s because %s requires replacement", n.Addr, tfdiags.FormatCtyPath(requiresReplace))
+				return true, diags
+			}
+		}
+	}
+	return false, diags
+}
+
+// pathHasPrefix returns true if the given path starts with the given prefix.
+func pathHasPrefix(path, prefix cty.Path) bool {
+	return len(path) >= len(prefix) && path[:len(prefix)].Equals(prefix)
+}
+
+// replacedInstancesData is a lang.Data that evaluates the given resource
+// instances as entirely unknown, to find which parts of a configuration are
+// derived from them.
+type replacedInstancesData struct {
+	lang.Data
+	replaced addrs.Set[addrs.ResourceInstance]
+}
+
+func (d *replacedInstancesData) GetResource(addr addrs.Resource, rng tfdiags.SourceRange) (cty.Value, tfdiags.Diagnostics) {
+	val, diags := d.Data.GetResource(addr, rng)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() {
+		return val, diags
+	}
+	if d.replaced.Has(addr.Instance(addrs.NoKey)) {
+		return cty.UnknownVal(val.Type()).WithSameMarks(val), diags
+	}
+
+	replacedKeys := false
+	for _, inst := range d.replaced {
+		replacedKeys = replacedKeys || inst.Resource.Equal(addr)
+	}
+	if !replacedKeys || !val.CanIterateElements() || val.LengthInt() == 0 {
+		return val, diags
+	}
+
+	// A resource with count or for_each is a collection of its instances.
+	val, marks := val.Unmark()
+	ty := val.Type()
+	switch {
+	case ty.IsTupleType() || ty.IsListType():
+		elems := val.AsValueSlice()
+		for i, elem := range elems {
+			if d.replaced.Has(addr.Instance(addrs.IntKey(i))) {
+				elems[i] = cty.UnknownVal(elem.Type()).WithSameMarks(elem)
+			}
+		}
+		if ty.IsListType() {
+			val = cty.ListVal(elems)
+		} else {
+			val = cty.TupleVal(elems)
+		}
+	case ty.IsObjectType() || ty.IsMapType():
+		elems := val.AsValueMap()
+		for k, elem := range elems {
+			if d.replaced.Has(addr.Instance(addrs.StringKey(k))) {
+				elems[k] = cty.UnknownVal(elem.Type()).WithSameMarks(elem)
+			}
+		}
+		if ty.IsMapType() {
+			val = cty.MapVal(elems)
+		} else {
+			val = cty.ObjectVal(elems)
+		}
+	}
+	return val.WithMarks(marks), diags
+}
+
 func (n *NodePlannableResourceInstance) importState(ctx EvalContext, addr addrs.AbsResourceInstance, importId string, provider providers.Interface, providerSchema providers.ProviderSchema) (*states.ResourceInstanceObject, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 	absAddr := addr.Resource.Absolute(ctx.Path())
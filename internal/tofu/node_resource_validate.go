@@ -258,6 +258,10 @@ var connectionBlockSupersetSchema = &configschema.Block{
 			Type:     cty.String,
 			Optional: true,
 		},
+		"jump_hosts": {
+			Type:     cty.List(cty.String),
+			Optional: true,
+		},
 
 		// For type=winrm only (enforced in winrm communicator)
 		"https": {
@@ -316,6 +320,9 @@ func (n *NodeValidatableResource) validateResource(ctx EvalContext) tfdiags.Diag
 	}
 
 	diags = diags.Append(validateDependsOn(ctx, n.Config.DependsOn))
+	if n.Config.Managed != nil {
+		diags = diags.Append(validateDestroyAfter(ctx, n.Config.Managed.DestroyAfter))
+	}
 
 	// Validate the provider_meta block for the provider this resource
 	// belongs to, if there is one.
@@ -608,3 +615,24 @@ func validateDependsOn(ctx EvalContext, dependsOn []hcl.Traversal) (diags tfdiag
 	}
 	return diags
 }
+
+// validateDestroyAfter checks that the references in a destroy_after argument
+// refer to objects that exist. The kinds of objects referenced were already
+// checked when the configuration was decoded.
+func validateDestroyAfter(ctx EvalContext, destroyAfter []hcl.Traversal) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, traversal := range destroyAfter {
+		ref, refDiags := addrs.ParseRef(traversal)
+		diags = diags.Append(refDiags)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		scope := ctx.EvaluationScope(nil, nil, EvalDataForNoInstanceKey)
+		if scope != nil { // sometimes nil in tests, due to incomplete mocks
+			_, refDiags = scope.EvalReference(ref, cty.DynamicPseudoType)
+			diags = diags.Append(refDiags)
+		}
+	}
+	return diags
+}
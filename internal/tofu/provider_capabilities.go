@@ -0,0 +1,92 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// CheckProviderCapabilities returns an error for each capability declared in
+// a required_providers entry of the given configuration that the selected
+// version of the provider doesn't support.
+//
+// Only the providers that have declared capabilities are started, so this is
+// cheap for configurations that don't use the capabilities argument.
+func (c *Context) CheckProviderCapabilities(config *configs.Config) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	config.DeepEach(func(modCfg *configs.Config) {
+		if modCfg == nil || modCfg.Module == nil || modCfg.Module.ProviderRequirements == nil {
+			return
+		}
+		reqs := modCfg.Module.ProviderRequirements.RequiredProviders
+
+		names := make([]string, 0, len(reqs))
+		for name := range reqs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			req := reqs[name]
+			if len(req.Capabilities) == 0 || !c.plugins.HasProvider(req.Type) {
+				// A missing provider is reported by checkConfigDependencies.
+				continue
+			}
+
+			schema, err := c.plugins.ProviderSchema(req.Type)
+			if err != nil {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Failed to obtain provider schema",
+					fmt.Sprintf("Could not load the schema for provider %s: %s.", req.Type, err),
+				))
+				continue
+			}
+
+			for _, capability := range req.Capabilities {
+				if providerHasCapability(schema, capability) {
+					continue
+				}
+				module := "The root module"
+				if !modCfg.Path.IsRoot() {
+					module = fmt.Sprintf("Module %s", modCfg.Path)
+				}
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Provider capability not supported",
+					Detail: fmt.Sprintf(
+						"%s requires provider %s to support the %q capability, but the selected version of the provider does not support it.\n\nSelect a newer version of the provider that supports this capability, for example by raising its version constraint and running:\n  tofu init -upgrade",
+						module, req.Type, capability,
+					),
+					Subject: req.DeclRange.Ptr(),
+					Extra:   tfdiags.CodeProviderCapabilityNotSupported,
+				})
+			}
+		}
+	})
+
+	return diags
+}
+
+func providerHasCapability(schema providers.ProviderSchema, capability configs.ProviderCapability) bool {
+	switch capability {
+	case configs.ProviderCapabilityFunctions:
+		return len(schema.Functions) != 0
+	case configs.ProviderCapabilityPlanDestroy:
+		return schema.ServerCapabilities.PlanDestroy
+	case configs.ProviderCapabilityOptionalSchema:
+		return schema.ServerCapabilities.GetProviderSchemaOptional
+	default:
+		// The configuration decoder rejects unknown capabilities.
+		return false
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"regexp"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// redactedOutput replaces text matched by a provisioner's redact_output
+// patterns.
+const redactedOutput = "(redacted)"
+
+// redactProvisionerOutput returns the given provisioner output with all text
+// matching any of the given patterns replaced.
+func redactProvisionerOutput(patterns []*regexp.Regexp, msg string) string {
+	for _, pattern := range patterns {
+		msg = pattern.ReplaceAllLiteralString(msg, redactedOutput)
+	}
+	return msg
+}
+
+// redactProvisionerDiags returns the given provisioner diagnostics with all
+// text in their summaries and details matching any of the given patterns
+// replaced, since provisioners often include their output in errors.
+func redactProvisionerDiags(patterns []*regexp.Regexp, diags tfdiags.Diagnostics) tfdiags.Diagnostics {
+	if len(patterns) == 0 {
+		return diags
+	}
+
+	var ret tfdiags.Diagnostics
+	for _, diag := range diags {
+		ret = ret.Append(redactedDiagnostic{
+			Diagnostic: diag,
+			patterns:   patterns,
+		})
+	}
+	return ret
+}
+
+// redactedDiagnostic wraps a diagnostic, redacting its description.
+type redactedDiagnostic struct {
+	tfdiags.Diagnostic
+	patterns []*regexp.Regexp
+}
+
+var _ tfdiags.Diagnostic = redactedDiagnostic{}
+
+func (d redactedDiagnostic) Description() tfdiags.Description {
+	desc := d.Diagnostic.Description()
+	desc.Summary = redactProvisionerOutput(d.patterns, desc.Summary)
+	desc.Detail = redactProvisionerOutput(d.patterns, desc.Detail)
+	return desc
+}
@@ -0,0 +1,109 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// maxReportedDowngradedObjects is the number of affected resource instance
+// objects that CheckStateSchemaVersions lists for each provider before
+// summarizing the rest, to keep the error readable for large states.
+const maxReportedDowngradedObjects = 20
+
+// CheckStateSchemaVersions returns an error for each provider whose selected
+// version uses an older schema version for one of its resource types than
+// some objects of that type in the given state were saved with, which
+// usually means that the provider was downgraded after the state was last
+// updated.
+//
+// OpenTofu can't convert resource instance objects back to an older schema
+// version, so these would otherwise fail one at a time partway through
+// planning. This reports all of the affected objects up front instead.
+// Providers that aren't available and resource types that the provider
+// doesn't support are ignored here, since they're reported elsewhere.
+func (c *Context) CheckStateSchemaVersions(state *states.State) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if state == nil {
+		return diags
+	}
+
+	affected := make(map[addrs.Provider][]string)
+	for _, ms := range state.Modules {
+		for _, rs := range ms.Resources {
+			if rs.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				// Only managed resources have versioned schemas.
+				continue
+			}
+			providerAddr := rs.ProviderConfig.Provider
+			if !c.plugins.HasProvider(providerAddr) {
+				continue
+			}
+			schema, currentVersion, err := c.plugins.ResourceTypeSchema(providerAddr, rs.Addr.Resource.Mode, rs.Addr.Resource.Type)
+			if err != nil || schema == nil {
+				continue
+			}
+
+			for key, is := range rs.Instances {
+				addr := rs.Addr.Instance(key)
+				if obj := is.Current; obj != nil && obj.SchemaVersion > currentVersion {
+					affected[providerAddr] = append(affected[providerAddr], fmt.Sprintf(
+						"%s (schema version %d, but the selected provider version supports up to %d)",
+						addr, obj.SchemaVersion, currentVersion,
+					))
+				}
+				for dk, obj := range is.Deposed {
+					if obj.SchemaVersion > currentVersion {
+						affected[providerAddr] = append(affected[providerAddr], fmt.Sprintf(
+							"%s, deposed object %s (schema version %d, but the selected provider version supports up to %d)",
+							addr, dk, obj.SchemaVersion, currentVersion,
+						))
+					}
+				}
+			}
+		}
+	}
+
+	providerAddrs := make([]addrs.Provider, 0, len(affected))
+	for providerAddr := range affected {
+		providerAddrs = append(providerAddrs, providerAddr)
+	}
+	sort.Slice(providerAddrs, func(i, j int) bool {
+		return providerAddrs[i].String() < providerAddrs[j].String()
+	})
+
+	for _, providerAddr := range providerAddrs {
+		objs := affected[providerAddr]
+		sort.Strings(objs)
+
+		var detail strings.Builder
+		fmt.Fprintf(&detail, "The state contains resource instances that were saved by a newer version of provider %s than the one selected, and OpenTofu can't convert them back to the schema versions that the selected version uses:\n", providerAddr)
+		for i, obj := range objs {
+			if i == maxReportedDowngradedObjects {
+				fmt.Fprintf(&detail, "  - ... and %d more\n", len(objs)-i)
+				break
+			}
+			fmt.Fprintf(&detail, "  - %s\n", obj)
+		}
+		if v, ok := state.ProviderVersions[providerAddr]; ok {
+			fmt.Fprintf(&detail, "\nThe state was last updated using version %s of this provider. Select version %s or newer, for example by changing its version constraint and running:\n  tofu init -upgrade", v, v)
+		} else {
+			detail.WriteString("\nSelect the provider version that was used when the state was last updated, or a newer one, for example by changing its version constraint and running:\n  tofu init -upgrade")
+		}
+
+		diags = diags.Append(tfdiags.WithCode(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Selected provider version is older than the state requires",
+			detail.String(),
+		), tfdiags.CodeProviderOlderThanState))
+	}
+
+	return diags
+}
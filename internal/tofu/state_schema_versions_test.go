@@ -0,0 +1,130 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestContextCheckStateSchemaVersions(t *testing.T) {
+	providerAddr := mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`)
+	buildState := func(versions map[addrs.Provider]getproviders.Version) *states.State {
+		state := states.BuildState(func(s *states.SyncState) {
+			s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.current"), &states.ResourceInstanceObjectSrc{
+				AttrsJSON:     []byte(`{"arg":"current"}`),
+				SchemaVersion: 1,
+				Status:        states.ObjectReady,
+			}, providerAddr, addrs.NoKey)
+			s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.newer"), &states.ResourceInstanceObjectSrc{
+				AttrsJSON:     []byte(`{"arg":"newer"}`),
+				SchemaVersion: 2,
+				Status:        states.ObjectReady,
+			}, providerAddr, addrs.NoKey)
+			s.SetResourceInstanceDeposed(mustResourceInstanceAddr("test_object.current"), states.DeposedKey("00000001"), &states.ResourceInstanceObjectSrc{
+				AttrsJSON:     []byte(`{"arg":"deposed"}`),
+				SchemaVersion: 3,
+				Status:        states.ObjectReady,
+			}, providerAddr, addrs.NoKey)
+		})
+		state.ProviderVersions = versions
+		return state
+	}
+
+	p := simpleMockProvider()
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		Provider: providers.Schema{Block: simpleTestSchema()},
+		ResourceTypes: map[string]providers.Schema{
+			"test_object": {
+				Version: 1,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"arg": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	t.Run("known version", func(t *testing.T) {
+		state := buildState(map[addrs.Provider]getproviders.Version{
+			addrs.NewDefaultProvider("test"): getproviders.MustParseVersion("2.1.0"),
+		})
+		diags := ctx.CheckStateSchemaVersions(state)
+		if len(diags) != 1 {
+			t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.ErrWithWarnings())
+		}
+		desc := diags[0].Description()
+		if got, want := desc.Summary, "Selected provider version is older than the state requires"; got != want {
+			t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+		}
+		for _, want := range []string{
+			"provider registry.opentofu.org/hashicorp/test",
+			"  - test_object.current, deposed object 00000001 (schema version 3, but the selected provider version supports up to 1)\n",
+			"  - test_object.newer (schema version 2, but the selected provider version supports up to 1)\n",
+			"Select version 2.1.0 or newer",
+		} {
+			if !strings.Contains(desc.Detail, want) {
+				t.Errorf("detail is missing %q\n%s", want, desc.Detail)
+			}
+		}
+		if strings.Contains(desc.Detail, "test_object.current (") {
+			t.Errorf("detail includes an object at the current schema version\n%s", desc.Detail)
+		}
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		diags := ctx.CheckStateSchemaVersions(buildState(nil))
+		if len(diags) != 1 {
+			t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.ErrWithWarnings())
+		}
+		if got, want := diags[0].Description().Detail, "Select the provider version that was used when the state was last updated"; !strings.Contains(got, want) {
+			t.Errorf("detail is missing %q\n%s", want, got)
+		}
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		m := testModuleInline(t, map[string]string{
+			"main.tf": `
+resource "test_object" "current" {
+}
+`,
+		})
+		_, diags := ctx.Plan(context.Background(), m, buildState(nil), DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want error")
+		}
+		if got, want := diags.Err().Error(), "Selected provider version is older than the state requires"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+		if p.UpgradeResourceStateCalled {
+			t.Error("provider was asked to upgrade state before the check failed")
+		}
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		state := states.BuildState(func(s *states.SyncState) {
+			s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.current"), &states.ResourceInstanceObjectSrc{
+				AttrsJSON:     []byte(`{"arg":"current"}`),
+				SchemaVersion: 0,
+				Status:        states.ObjectReady,
+			}, providerAddr, addrs.NoKey)
+		})
+		assertNoDiagnostics(t, ctx.CheckStateSchemaVersions(state))
+	})
+}
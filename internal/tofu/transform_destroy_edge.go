@@ -8,7 +8,10 @@ package tofu
 import (
 	"log"
 
+	"github.com/hashicorp/hcl/v2"
+
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/dag"
 	"github.com/opentofu/opentofu/internal/plans"
 )
@@ -56,6 +59,11 @@ type DestroyEdgeTransformer struct {
 	// inter-provider dependencies and remove the cycle checks in
 	// tryInterProviderDestroyEdge.
 	Operation walkOperation
+
+	// Config is the root module configuration, used to find the explicit
+	// destroy ordering declared with the destroy_after lifecycle argument.
+	// If it is nil, only the dependencies recorded in the state are used.
+	Config *configs.Config
 }
 
 // tryInterProviderDestroyEdge checks if we're inserting a destroy edge
@@ -267,9 +275,108 @@ func (t *DestroyEdgeTransformer) Transform(g *Graph) error {
 		}
 	}
 
+	t.connectDestroyAfter(g, destroyers)
+
 	return nil
 }
 
+// connectDestroyAfter connects each destroyer to the destroyers of the
+// objects named in the destroy_after arguments of its resource and of the
+// module calls containing it, so that it only runs once those have completed.
+//
+// Unlike the dependencies recorded in the state, these edges are not skipped
+// when they cross a provider boundary: they were explicitly requested, so a
+// resulting cycle must be reported to the user rather than silently ignored.
+func (t *DestroyEdgeTransformer) connectDestroyAfter(g *Graph, destroyers map[string][]GraphNodeDestroyer) {
+	if t.Config == nil {
+		return
+	}
+
+	var all []GraphNodeDestroyer
+	for _, ds := range destroyers {
+		all = append(all, ds...)
+	}
+
+	for _, des := range all {
+		addr := *des.DestroyAddr()
+		for _, constraint := range destroyAfterConstraints(t.Config, addr) {
+			if constraint.matches(addr) {
+				// A self-reference can't be satisfied, and would only
+				// create cycles between the instances of the object.
+				continue
+			}
+			for _, other := range all {
+				if constraint.matches(*other.DestroyAddr()) {
+					log.Printf("[TRACE] DestroyEdgeTransformer: %s must be destroyed after %s", dag.VertexName(des), dag.VertexName(other))
+					g.Connect(dag.BasicEdge(des, other))
+				}
+			}
+		}
+	}
+}
+
+// destroyAfterConstraint is a single destroy_after reference, resolved in the
+// module instance that declared it.
+type destroyAfterConstraint struct {
+	module addrs.ModuleInstance
+	ref    addrs.Referenceable
+}
+
+// matches returns true if the given resource instance is one of the objects
+// that the constraint refers to.
+func (c destroyAfterConstraint) matches(addr addrs.AbsResourceInstance) bool {
+	switch ref := c.ref.(type) {
+	case addrs.Resource:
+		return addr.Module.Equal(c.module) && addr.Resource.Resource == ref
+	case addrs.ModuleCall:
+		depth := len(c.module)
+		return len(addr.Module) > depth && addr.Module[:depth].Equal(c.module) && addr.Module[depth].Name == ref.Name
+	default:
+		return false
+	}
+}
+
+// destroyAfterConstraints returns the destroy ordering constraints that apply
+// to the given resource instance: those of the module calls along its module
+// path, and those of its own resource configuration.
+func destroyAfterConstraints(root *configs.Config, addr addrs.AbsResourceInstance) []destroyAfterConstraint {
+	var ret []destroyAfterConstraint
+	appendConstraints := func(module addrs.ModuleInstance, traversals []hcl.Traversal) {
+		for _, traversal := range traversals {
+			ref, diags := addrs.ParseRef(traversal)
+			if diags.HasErrors() {
+				// Invalid references were already rejected when decoding the
+				// configuration.
+				log.Printf("[ERROR] Can't parse %#v from destroy_after as reference: %s", traversal, diags.Err())
+				continue
+			}
+			ret = append(ret, destroyAfterConstraint{module: module, ref: ref.Subject})
+		}
+	}
+
+	cfg := root
+	for i, step := range addr.Module {
+		if cfg.Module == nil {
+			return ret
+		}
+		if call, ok := cfg.Module.ModuleCalls[step.Name]; ok {
+			appendConstraints(addr.Module[:i], call.DestroyAfter)
+		}
+		cfg = cfg.Children[step.Name]
+		if cfg == nil {
+			// The module is no longer in the configuration.
+			return ret
+		}
+	}
+	if cfg.Module == nil {
+		return ret
+	}
+	if rc := cfg.Module.ResourceByAddr(addr.Resource.Resource); rc != nil && rc.Managed != nil {
+		appendConstraints(addr.Module, rc.Managed.DestroyAfter)
+	}
+	return ret
+}
+
 // Remove any nodes that aren't needed when destroying modules.
 // Variables, outputs, locals, and expanders may not be able to evaluate
 // correctly, so we can remove these if nothing depends on them. The module
@@ -313,6 +313,62 @@ test_object.B (destroy)
 	}
 }
 
+func TestDestroyEdgeTransformer_destroyAfter(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "A" {
+  lifecycle {
+    destroy_after = [test_object.A, test_object.B, module.child]
+  }
+}
+
+resource "test_object" "B" {
+}
+
+module "child" {
+  source = "./child"
+}
+
+module "other" {
+  source = "./child"
+
+  lifecycle {
+    destroy_after = [module.child]
+  }
+}
+`,
+		"child/main.tf": `
+resource "test_object" "C" {
+}
+`,
+	})
+
+	g := Graph{Path: addrs.RootModuleInstance}
+	g.Add(testDestroyNode("test_object.A"))
+	g.Add(testDestroyNode("test_object.B"))
+	g.Add(testDestroyNode("module.child.test_object.C"))
+	g.Add(testDestroyNode("module.other.test_object.C"))
+
+	tf := &DestroyEdgeTransformer{Config: m}
+	if err := tf.Transform(&g); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(g.String())
+	expected := strings.TrimSpace(`
+module.child.test_object.C (destroy)
+module.other.test_object.C (destroy)
+  module.child.test_object.C (destroy)
+test_object.A (destroy)
+  module.child.test_object.C (destroy)
+  test_object.B (destroy)
+test_object.B (destroy)
+`)
+	if actual != expected {
+		t.Fatalf("wrong result\n\ngot:\n%s\n\nwant:\n%s", actual, expected)
+	}
+}
+
 func TestPruneUnusedNodesTransformer_rootModuleOutputValues(t *testing.T) {
 	// This is a kinda-weird test case covering the very narrow situation
 	// where a root module output value depends on a resource, where we
@@ -0,0 +1,191 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofumigrate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// legacyRegistryHost is the hostname of the registry that provider and module
+// addresses written for Terraform refer to by default.
+const legacyRegistryHost = "registry.terraform.io"
+
+// SourceMigration describes the changes that move a configuration and its
+// state from addresses on the legacy registry to the equivalent addresses on
+// the OpenTofu registry.
+//
+// Unlike MigrateStateProviderAddresses, which only changes the in-memory
+// view of the state, a SourceMigration is intended to be shown to the user
+// and then applied to the stored state, while the configuration changes are
+// only suggested, since OpenTofu doesn't rewrite configuration files.
+type SourceMigration struct {
+	// StateChanges are the provider addresses in the state that will change,
+	// in the same cases where MigrateStateProviderAddresses would change them.
+	StateChanges []StateProviderChange
+
+	// ConfigChanges are the suggested changes to source addresses in the
+	// configuration, ordered by their location.
+	ConfigChanges []ConfigSourceChange
+}
+
+// StateProviderChange is a change of the provider address of some of the
+// resources in the state.
+type StateProviderChange struct {
+	From, To  addrs.Provider
+	Resources []addrs.AbsResource
+}
+
+// ConfigSourceChange is a suggested change to a source address in the
+// configuration.
+type ConfigSourceChange struct {
+	// Subject describes the block containing the address, such as
+	// `module "vpc"`.
+	Subject string
+
+	// Range is the location of the address, or of the declaration containing
+	// it if the address itself has no location.
+	Range hcl.Range
+
+	From, To string
+}
+
+// PlanSourceMigration finds the legacy registry addresses in the given
+// configuration and state, either of which may be nil.
+//
+// Only modules that are part of the configuration's own files are considered
+// for configuration changes, since modules installed from elsewhere can't be
+// edited by the user.
+func PlanSourceMigration(config *configs.Config, state *states.State) (*SourceMigration, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	ret := &SourceMigration{}
+
+	if config != nil {
+		for _, c := range config.AllModules() {
+			if !editableModule(c) {
+				continue
+			}
+			ret.ConfigChanges = append(ret.ConfigChanges, moduleSourceChanges(c.Module)...)
+		}
+		sort.Slice(ret.ConfigChanges, func(i, j int) bool {
+			a, b := ret.ConfigChanges[i].Range, ret.ConfigChanges[j].Range
+			if a.Filename != b.Filename {
+				return a.Filename < b.Filename
+			}
+			return a.Start.Line < b.Start.Line
+		})
+	}
+
+	if state != nil {
+		migrated, moreDiags := MigrateStateProviderAddresses(config, state)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, diags
+		}
+
+		changes := make(map[addrs.Provider]*StateProviderChange)
+		for _, module := range state.Modules {
+			for key, resource := range module.Resources {
+				from := resource.ProviderConfig.Provider
+				to := migrated.Module(module.Addr).Resources[key].ProviderConfig.Provider
+				if from.Equals(to) {
+					continue
+				}
+				change, exists := changes[from]
+				if !exists {
+					change = &StateProviderChange{From: from, To: to}
+					changes[from] = change
+				}
+				change.Resources = append(change.Resources, resource.Addr)
+			}
+		}
+		for _, change := range changes {
+			sort.Slice(change.Resources, func(i, j int) bool {
+				return change.Resources[i].String() < change.Resources[j].String()
+			})
+			ret.StateChanges = append(ret.StateChanges, *change)
+		}
+		sort.Slice(ret.StateChanges, func(i, j int) bool {
+			return ret.StateChanges[i].From.String() < ret.StateChanges[j].From.String()
+		})
+	}
+
+	return ret, diags
+}
+
+// Empty returns true if the migration has no changes to make or suggest.
+func (m *SourceMigration) Empty() bool {
+	return len(m.StateChanges) == 0 && len(m.ConfigChanges) == 0
+}
+
+// ApplyToState changes the provider addresses of the resources in the given
+// state, which must be the state the migration was planned from.
+func (m *SourceMigration) ApplyToState(state *states.State) {
+	for _, change := range m.StateChanges {
+		for _, addr := range change.Resources {
+			resource := state.Resource(addr)
+			if resource == nil || !resource.ProviderConfig.Provider.Equals(change.From) {
+				continue
+			}
+			resource.ProviderConfig.Provider = change.To
+		}
+	}
+}
+
+// editableModule returns true if the given module's files are part of the
+// configuration, rather than installed from a remote source.
+func editableModule(c *configs.Config) bool {
+	for ; c.Parent != nil; c = c.Parent {
+		if _, ok := c.SourceAddr.(addrs.ModuleSourceLocal); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func moduleSourceChanges(mod *configs.Module) []ConfigSourceChange {
+	var ret []ConfigSourceChange
+
+	if mod.ProviderRequirements != nil {
+		for name, req := range mod.ProviderRequirements.RequiredProviders {
+			if req.Source == "" || req.Type.Hostname != legacyRegistryHost {
+				continue
+			}
+			to := addrs.NewProvider(addrs.DefaultProviderRegistryHost, req.Type.Namespace, req.Type.Type)
+			ret = append(ret, ConfigSourceChange{
+				Subject: fmt.Sprintf("required provider %q", name),
+				Range:   req.DeclRange,
+				From:    req.Source,
+				To:      to.ForDisplay(),
+			})
+		}
+	}
+
+	for _, call := range mod.ModuleCalls {
+		source, ok := call.SourceAddr.(addrs.ModuleSourceRegistry)
+		if !ok || source.Package.Host != legacyRegistryHost {
+			continue
+		}
+		source.Package.Host = addrs.DefaultModuleRegistryHost
+		rng := call.DeclRange
+		if call.Source != nil {
+			rng = call.Source.Range()
+		}
+		ret = append(ret, ConfigSourceChange{
+			Subject: fmt.Sprintf("module %q", call.Name),
+			Range:   rng,
+			From:    call.SourceAddrRaw,
+			To:      source.ForDisplay(),
+		})
+	}
+
+	return ret
+}
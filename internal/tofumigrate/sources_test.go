@@ -0,0 +1,83 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofumigrate
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestPlanSourceMigration(t *testing.T) {
+	loader, close := configload.NewLoaderForTests(t)
+	defer close()
+
+	cfg, hclDiags := loader.LoadConfig("testdata/mention", configs.RootModuleCallForTesting())
+	if hclDiags.HasErrors() {
+		t.Fatalf("invalid configuration: %s", hclDiags.Error())
+	}
+
+	randomAddr := addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "random_id", Name: "example"}.Absolute(addrs.RootModuleInstance)
+	awsAddr := addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "aws_instance", Name: "example"}.Absolute(addrs.RootModuleInstance)
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, r := range []struct {
+			addr     addrs.AbsResource
+			provider string
+		}{
+			{randomAddr, "registry.terraform.io/hashicorp/random"},
+			{awsAddr, "registry.terraform.io/hashicorp/aws"},
+		} {
+			s.SetResourceInstanceCurrent(
+				r.addr.Instance(addrs.NoKey),
+				&states.ResourceInstanceObjectSrc{
+					Status:    states.ObjectReady,
+					AttrsJSON: []byte(`{}`),
+				},
+				addrs.AbsProviderConfig{
+					Module:   addrs.RootModule,
+					Provider: addrs.MustParseProviderSourceString(r.provider),
+				},
+				addrs.NoKey,
+			)
+		}
+	})
+
+	migration, diags := PlanSourceMigration(cfg, state)
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	// The aws provider is explicitly required from the legacy registry, so
+	// its resources keep their address and a configuration change is
+	// suggested instead.
+	if got, want := len(migration.StateChanges), 1; got != want {
+		t.Fatalf("wrong number of state changes %d; want %d", got, want)
+	}
+	change := migration.StateChanges[0]
+	if got, want := change.To.String(), "registry.opentofu.org/hashicorp/random"; got != want {
+		t.Errorf("wrong new provider %s; want %s", got, want)
+	}
+	if len(change.Resources) != 1 || !change.Resources[0].Equal(randomAddr) {
+		t.Errorf("wrong resources %s", change.Resources)
+	}
+
+	if got, want := len(migration.ConfigChanges), 1; got != want {
+		t.Fatalf("wrong number of configuration changes %d; want %d", got, want)
+	}
+	configChange := migration.ConfigChanges[0]
+	if configChange.From != "registry.terraform.io/hashicorp/aws" || configChange.To != "hashicorp/aws" {
+		t.Errorf("wrong configuration change from %q to %q", configChange.From, configChange.To)
+	}
+
+	migration.ApplyToState(state)
+	if got, want := state.Resource(randomAddr).ProviderConfig.Provider.String(), "registry.opentofu.org/hashicorp/random"; got != want {
+		t.Errorf("random_id.example has provider %s; want %s", got, want)
+	}
+	if got, want := state.Resource(awsAddr).ProviderConfig.Provider.String(), "registry.terraform.io/hashicorp/aws"; got != want {
+		t.Errorf("aws_instance.example has provider %s; want %s", got, want)
+	}
+}
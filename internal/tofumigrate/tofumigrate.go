@@ -66,7 +66,7 @@ func MigrateStateProviderAddresses(config *configs.Config, state *states.State)
 	for _, module := range stateCopy.Modules {
 		for _, resource := range module.Resources {
 			_, referencedInConfig := providers[resource.ProviderConfig.Provider]
-			if resource.ProviderConfig.Provider.Hostname == "registry.terraform.io" && !referencedInConfig {
+			if resource.ProviderConfig.Provider.Hostname == legacyRegistryHost && !referencedInConfig {
 				resource.ProviderConfig.Provider.Hostname = tfaddr.DefaultProviderRegistryHost
 			}
 		}
@@ -0,0 +1,5 @@
+terraform {
+    encryption {
+        enforced = true
+    }
+}
@@ -0,0 +1,22 @@
+terraform {
+  encryption {
+    key_provider "pbkdf2" "data_key" {
+      passphrase = var.passphrase
+    }
+    key_provider "aws_kms" "kek" {
+      kms_key_id = "a4f791e1-0d46-4c8e-b489-917e0bec05ef"
+      region = "us-east-1"
+      key_spec = "AES_256"
+    }
+    key_provider "envelope" "wrapped" {
+      kek = key_provider.aws_kms.kek
+      key = key_provider.pbkdf2.data_key
+    }
+    method "aes_gcm" "wrapped" {
+      keys = key_provider.envelope.wrapped
+    }
+    state {
+      method = method.aes_gcm.wrapped
+    }
+  }
+}
@@ -9,7 +9,7 @@ import (
 
 // Header is the initial greeting the key provider sends out.
 type Header struct {
-	// Magic must always be OpenTofu-External-Keyprovider
+	// Magic must always be OpenTofu-External-Key-Provider
 	Magic string `json:"magic"`
 	// Version must be 1.
 	Version int `json:"version"`
@@ -26,12 +26,12 @@ type Input *Metadata
 
 // Output describes the output data written to stdout.
 type Output struct {
-	Key struct {
+	Keys struct {
 		// EncryptionKey must always be provided.
 		EncryptionKey []byte `json:"encryption_key,omitempty"`
 		// DecryptionKey must be provided when the input metadata is present.
 		DecryptionKey []byte `json:"decryption_key,omitempty"`
-	} `json:"key"`
+	} `json:"keys"`
 	// Meta contains the metadata to store alongside the encrypted data. You can
 	// store data here you need to reconstruct the decryption key later.
 	Meta Metadata `json:"meta"`